@@ -0,0 +1,16 @@
+package main
+
+import (
+	"context"
+	"os"
+	"os/signal"
+
+	"github.com/cloudogu/k8s-support-archive-operator/pkg/adapter/cli"
+)
+
+func main() {
+	ctx, stop := signal.NotifyContext(context.Background(), os.Interrupt)
+	exitCode := cli.Run(ctx, os.Args[1:], os.Stdout, os.Stderr)
+	stop()
+	os.Exit(exitCode)
+}
@@ -1,9 +1,24 @@
 package main
 
 import (
+	"context"
+	"time"
+
+	libapi "github.com/cloudogu/k8s-support-archive-lib/api/v1"
 	"sigs.k8s.io/controller-runtime/pkg/manager"
 )
 
 type controllerManager interface {
 	manager.Manager
 }
+
+// downloadURLSigner is nil if the download server is disabled.
+type downloadURLSigner interface {
+	Sign(cr *libapi.SupportArchive, url string, now time.Time) (string, time.Time)
+	ExpiresAt(url string) (time.Time, bool)
+}
+
+// archiveClaimHandler is nil if leader election is disabled.
+type archiveClaimHandler interface {
+	Claim(ctx context.Context, cr *libapi.SupportArchive) (claimCtx context.Context, release context.CancelFunc, retryAfter time.Duration, err error)
+}
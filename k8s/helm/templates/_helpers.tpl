@@ -61,3 +61,10 @@ Create the name of the service account to use
 {{- default "default" .Values.serviceAccount.name }}
 {{- end }}
 {{- end }}
+
+{{/*
+Name of the secret with the key to sign download tokens.
+*/}}
+{{- define "helm.downloadTokenSecretName" -}}
+{{- default (printf "%s-download-token" (include "helm.fullname" .)) .Values.controllerManager.env.downloadServer.secretName }}
+{{- end }}
@@ -4,9 +4,8 @@ import (
 	"context"
 	"flag"
 	"fmt"
-	"github.com/cloudogu/k8s-support-archive-operator/pkg/adapter/loki"
-	"net/http"
 	"os"
+	"time"
 
 	// Import all Kubernetes client auth plugins (e.g., Azure, GCP, OIDC, etc.)
 	// to ensure that exec-entrypoint and run can make use of them.
@@ -25,17 +24,17 @@ import (
 	"sigs.k8s.io/controller-runtime/pkg/log"
 	"sigs.k8s.io/controller-runtime/pkg/manager"
 	metricsserver "sigs.k8s.io/controller-runtime/pkg/metrics/server"
+	"sigs.k8s.io/controller-runtime/pkg/webhook"
 
 	k8scloudogucomv1 "github.com/cloudogu/k8s-support-archive-lib/api/v1"
 	k8scloudoguclient "github.com/cloudogu/k8s-support-archive-lib/client"
+	libclient "github.com/cloudogu/k8s-support-archive-lib/client/v1"
 	"github.com/cloudogu/k8s-support-archive-operator/pkg/adapter/archive/file"
-	"github.com/cloudogu/k8s-support-archive-operator/pkg/adapter/collector"
 	"github.com/cloudogu/k8s-support-archive-operator/pkg/adapter/config"
+	"github.com/cloudogu/k8s-support-archive-operator/pkg/adapter/download"
 	"github.com/cloudogu/k8s-support-archive-operator/pkg/adapter/filesystem"
 	adapterK8s "github.com/cloudogu/k8s-support-archive-operator/pkg/adapter/kubernetes"
-	"github.com/cloudogu/k8s-support-archive-operator/pkg/adapter/prometheus"
-	v1 "github.com/cloudogu/k8s-support-archive-operator/pkg/adapter/prometheus/v1"
-	"github.com/cloudogu/k8s-support-archive-operator/pkg/domain"
+	"github.com/cloudogu/k8s-support-archive-operator/pkg/adapter/setup"
 	"github.com/cloudogu/k8s-support-archive-operator/pkg/usecase"
 )
 
@@ -52,6 +51,13 @@ var (
 const (
 	archivePath = "/data/support-archives"
 	workPath    = "/data/work"
+	// spoolPath contains the files written to the object storage until they are uploaded.
+	spoolPath = "/data/spool"
+	// leaderElectionID is the name of the lease of the leader election.
+	leaderElectionID = "k8s-support-archive-operator.k8s.cloudogu.com"
+	// archiveClaimDuration defines how long a replica holds the claim of a support archive without renewing it.
+	// Temporary archives of other replicas are only removed if they were not modified for this duration.
+	archiveClaimDuration = time.Minute
 )
 
 func init() {
@@ -113,9 +119,20 @@ func startOperator(
 	}
 
 	v1SupportArchive := ecoClientSet.SupportArchiveV1()
-	supportArchiveRepository := file.NewZipFileArchiveRepository(archivePath, file.NewZipWriter, operatorConfig)
+	fs, err := newFilesystem(ctx, operatorConfig)
+	if err != nil {
+		return err
+	}
 
-	fs := filesystem.FileSystem{}
+	supportArchiveRepository := file.NewZipFileArchiveRepository(archivePath, file.NewZipWriter, fs, operatorConfig)
+	var temporaryArchiveMinAge time.Duration
+	if operatorConfig.LeaderElectionEnabled {
+		temporaryArchiveMinAge = archiveClaimDuration
+	}
+	err = supportArchiveRepository.RemoveTemporaryArchives(ctx, temporaryArchiveMinAge)
+	if err != nil {
+		return fmt.Errorf("unable to remove temporary support archives: %w", err)
+	}
 
 	address := fmt.Sprintf(
 		"%s://%s.%s.svc.cluster.local:%s",
@@ -124,51 +141,42 @@ func startOperator(
 		operatorConfig.Namespace,
 		operatorConfig.MetricsServicePort,
 	)
-	// TODO Implement ServiceAccount for Prometheus. Create secret in Prometheus Chart and use it?
-	metricsClient, err := prometheus.GetClient(address, "")
+	clients := setup.Clients{Kubernetes: k8sClientSet, Generic: k8sManager.GetClient()}
+	mapping, err := setup.NewCollectorMapping(clients, operatorConfig, address, workPath, fs)
 	if err != nil {
-		return fmt.Errorf("unable to create prometheus client: %w", err)
+		return err
 	}
-	metricsCollector := v1.NewPrometheusMetricsV1API(metricsClient, operatorConfig.MetricsMaxSamples)
+	postProcessing := setup.NewPostProcessingRepositories(workPath, fs)
 
-	volumesCollector := collector.NewVolumesCollector(ecoClientSet.CoreV1(), metricsCollector)
-	volumeRepository := file.NewVolumesFileRepository(workPath, fs)
-
-	nodeInfoCollector := collector.NewNodeInfoCollector(
-		metricsCollector,
-		operatorConfig.NodeInfoUsageMetricStep,
-		operatorConfig.NodeInfoHardwareMetricStep,
-	)
-	nodeInfoRepository := file.NewNodeInfoFileRepository(workPath, fs)
-
-	secretsCollector := collector.NewSecretCollector(ecoClientSet.CoreV1())
-	secretRepository := file.NewSecretsFileRepository(workPath, fs)
+	var urlSigner downloadURLSigner
+	var tokenSigner *download.TokenSigner
+	if operatorConfig.DownloadServerEnabled {
+		tokenSigner = download.NewTokenSigner(operatorConfig.DownloadTokenSecret, operatorConfig.DownloadTokenTTL)
+		urlSigner = tokenSigner
+	}
 
-	systemStateCollector, err := collector.NewSystemStateCollector(k8sManager.GetClient(), k8sClientSet.Discovery(), operatorConfig.SystemStateLabelSelectors, operatorConfig.SystemStateGvkExclusions)
+	archiveLocks := usecase.NewArchiveLocks()
+	inMemoryArchives, err := newInMemoryArchives(clients, operatorConfig, address)
 	if err != nil {
 		return err
 	}
-	systemStateRepository := file.NewSystemStateFileRepository(workPath, fs)
-
-	logProvider := loki.NewLokiLogsProvider(http.DefaultClient, operatorConfig)
-	eventsCollector := collector.NewEventsCollector(logProvider)
-	eventsRepository := file.NewEventFileRepository(workPath, fs)
-
-	logCollector := collector.NewLogCollector(logProvider)
-	logRepository := file.NewLogFileRepository(workPath, fs)
-
-	mapping := make(map[domain.CollectorType]usecase.CollectorAndRepository)
-	mapping[domain.CollectorTypeLog] = usecase.CollectorAndRepository{Collector: logCollector, Repository: logRepository}
-	mapping[domain.CollectorTypeVolumeInfo] = usecase.CollectorAndRepository{Collector: volumesCollector, Repository: volumeRepository}
-	mapping[domain.CollectorTypeNodeInfo] = usecase.CollectorAndRepository{Collector: nodeInfoCollector, Repository: nodeInfoRepository}
-	mapping[domain.CollectorTypeVolumeInfo] = usecase.CollectorAndRepository{Collector: volumesCollector, Repository: volumeRepository}
-	mapping[domain.CollectorTypeSecret] = usecase.CollectorAndRepository{Collector: secretsCollector, Repository: secretRepository}
-	mapping[domain.CollectorTypeEvents] = usecase.CollectorAndRepository{Collector: eventsCollector, Repository: eventsRepository}
-	mapping[domain.CollectorTypeSystemState] = usecase.CollectorAndRepository{Collector: systemStateCollector, Repository: systemStateRepository}
-
-	createUseCase := usecase.NewCreateArchiveUseCase(v1SupportArchive, mapping, supportArchiveRepository)
-	deleteUseCase := usecase.NewDeleteArchiveUseCase(mapping, supportArchiveRepository)
-	r := adapterK8s.NewSupportArchiveReconciler(v1SupportArchive, createUseCase, deleteUseCase)
+	// Archives created in memory before are still rebuilt and deleted if in-memory archives are disabled now.
+	inMemoryArchiveRepository := file.NewInMemoryArchiveFileRepository(workPath, fs)
+	createUseCase := usecase.NewCreateArchiveUseCase(v1SupportArchive, mapping, supportArchiveRepository, postProcessing, archiveLocks, usecase.CreateArchiveOptions{
+		CollectorMaxRetries:       operatorConfig.CollectorMaxRetries,
+		ArchiveDeadline:           operatorConfig.SupportArchiveDeadline,
+		DefaultContentTimeframe:   operatorConfig.DefaultContentTimeframe,
+		TimelineEnabled:           operatorConfig.TimelineEnabled,
+		DownloadURLSigner:         urlSigner,
+		InMemoryArchives:          inMemoryArchives,
+		InMemoryArchiveRepository: inMemoryArchiveRepository,
+	})
+	deleteUseCase := usecase.NewDeleteArchiveUseCase(mapping, supportArchiveRepository, archiveLocks, inMemoryArchiveRepository)
+	var claimHandler archiveClaimHandler
+	if operatorConfig.LeaderElectionEnabled {
+		claimHandler = usecase.NewClaimArchiveUseCase(v1SupportArchive, operatorConfig.PodName, archiveClaimDuration)
+	}
+	r := adapterK8s.NewSupportArchiveReconciler(v1SupportArchive, createUseCase, deleteUseCase, claimHandler)
 
 	reconciliationTrigger := make(chan event.GenericEvent)
 	syncHandler := usecase.NewSyncArchiveUseCase(
@@ -192,6 +200,20 @@ func startOperator(
 		return fmt.Errorf("unable to configure manager: %w", err)
 	}
 
+	if operatorConfig.WebhookEnabled {
+		err = addWebhook(k8sManager, operatorConfig)
+		if err != nil {
+			return err
+		}
+	}
+
+	if operatorConfig.DownloadServerEnabled {
+		err = addDownloadServer(k8sManager, operatorConfig, v1SupportArchive, k8sClientSet, supportArchiveRepository, fs, tokenSigner)
+		if err != nil {
+			return err
+		}
+	}
+
 	err = startK8sManager(ctx, k8sManager)
 	if err != nil {
 		return fmt.Errorf("unable to start operator: %w", err)
@@ -220,6 +242,7 @@ func configureManager(
 		return fmt.Errorf("unable to configure reconciler: %w", err)
 	}
 
+	// Runnables without NeedLeaderElection only run on the leader if leader election is enabled.
 	err = k8sManager.Add(manager.RunnableFunc(func(ctx context.Context) error {
 		return syncHandler.SyncArchivesWithInterval(ctx)
 	}))
@@ -242,6 +265,80 @@ func configureManager(
 	return nil
 }
 
+// newInMemoryArchives returns the collectors and repositories for archives created in memory.
+// It returns nil if archives are never created in memory.
+func newInMemoryArchives(clients setup.Clients, operatorConfig *config.OperatorConfig, metricsAddress string) (*usecase.InMemoryArchives, error) {
+	if operatorConfig.InMemoryArchiveMaxSize == 0 {
+		return nil, nil
+	}
+
+	memoryFileSystem := filesystem.NewMemoryFileSystem()
+	mapping, err := setup.NewCollectorMapping(clients, operatorConfig, metricsAddress, workPath, memoryFileSystem)
+	if err != nil {
+		return nil, fmt.Errorf("unable to create collectors for in-memory archives: %w", err)
+	}
+
+	return &usecase.InMemoryArchives{
+		CollectorMapping: mapping,
+		PostProcessing:   setup.NewPostProcessingRepositories(workPath, memoryFileSystem),
+		MaxSize:          operatorConfig.InMemoryArchiveMaxSize,
+	}, nil
+}
+
+// newFilesystem returns the object storage if S3 is enabled and the volume otherwise.
+func newFilesystem(ctx context.Context, operatorConfig *config.OperatorConfig) (filesystem.Filesystem, error) {
+	if !operatorConfig.S3Enabled {
+		return filesystem.FileSystem{}, nil
+	}
+
+	s3FileSystem, err := filesystem.NewS3FileSystem(ctx, operatorConfig.S3Config, spoolPath)
+	if err != nil {
+		return nil, fmt.Errorf("unable to connect to s3: %w", err)
+	}
+
+	return s3FileSystem, nil
+}
+
+// addDownloadServer serves the archives from the operator. Downloads require a download token and, if enabled,
+// also accept ServiceAccount tokens.
+func addDownloadServer(
+	k8sManager controllerManager,
+	operatorConfig *config.OperatorConfig,
+	supportArchives libclient.SupportArchiveV1Interface,
+	clientSet kubernetes.Interface,
+	archives *file.ZipFileArchiveRepository,
+	fs filesystem.Filesystem,
+	tokenSigner *download.TokenSigner,
+) error {
+	var serviceAccountReviews *download.ServiceAccountReviews
+	if operatorConfig.DownloadTokenReviewEnabled {
+		serviceAccountReviews = &download.ServiceAccountReviews{
+			TokenReviews:  clientSet.AuthenticationV1().TokenReviews(),
+			AccessReviews: clientSet.AuthorizationV1().SubjectAccessReviews(),
+		}
+	}
+
+	handler := download.NewHandler(supportArchives, tokenSigner, serviceAccountReviews, archives, fs, ctrl.Log.WithName("download-audit"))
+	err := k8sManager.Add(download.NewServer(operatorConfig.DownloadServerPort, handler))
+	if err != nil {
+		return fmt.Errorf("unable to add download server: %w", err)
+	}
+
+	return nil
+}
+
+// addWebhook validates and defaults support archives at admission.
+// The serving certificate is mounted to the default certificate directory of the webhook server.
+func addWebhook(k8sManager controllerManager, operatorConfig *config.OperatorConfig) error {
+	supportArchiveWebhook := adapterK8s.NewSupportArchiveWebhook(operatorConfig.DefaultContentTimeframe, operatorConfig.MaxContentTimeframe)
+	err := supportArchiveWebhook.SetupWithManager(k8sManager)
+	if err != nil {
+		return fmt.Errorf("unable to add webhook: %w", err)
+	}
+
+	return nil
+}
+
 func getK8sManagerOptions(flags *flag.FlagSet, args []string, operatorConfig *config.OperatorConfig) ctrl.Options {
 	controllerOpts := ctrl.Options{
 		Scheme: scheme,
@@ -249,6 +346,20 @@ func getK8sManagerOptions(flags *flag.FlagSet, args []string, operatorConfig *co
 			operatorConfig.Namespace: {},
 		}},
 	}
+	// Archives are locked individually, so different archives can be reconciled concurrently.
+	controllerOpts.Controller.MaxConcurrentReconciles = operatorConfig.MaxConcurrentReconciles
+	if operatorConfig.WebhookEnabled {
+		controllerOpts.WebhookServer = webhook.NewServer(webhook.Options{Port: operatorConfig.WebhookPort})
+	}
+	if operatorConfig.LeaderElectionEnabled {
+		// The reconciler, the sync and the garbage collection only run on the leader. The download server and the
+		// webhook are served by every replica.
+		controllerOpts.LeaderElection = true
+		controllerOpts.LeaderElectionID = leaderElectionID
+		controllerOpts.LeaderElectionNamespace = operatorConfig.Namespace
+		// The lease is released before running reconciliations finished, so the archives are claimed additionally.
+		controllerOpts.LeaderElectionReleaseOnCancel = true
+	}
 	controllerOpts = parseManagerFlags(flags, args, controllerOpts)
 
 	return controllerOpts
@@ -3,7 +3,10 @@ package main
 import (
 	"context"
 	"flag"
+	"net/http"
+	"net/http/httptest"
 	"testing"
+	"time"
 
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/mock"
@@ -11,14 +14,18 @@ import (
 
 	v1 "github.com/cloudogu/k8s-support-archive-lib/api/v1"
 	"github.com/cloudogu/k8s-support-archive-operator/pkg/adapter/config"
+	"github.com/cloudogu/k8s-support-archive-operator/pkg/adapter/download"
+	"github.com/cloudogu/k8s-support-archive-operator/pkg/adapter/filesystem"
 
 	"github.com/go-logr/logr"
 	"k8s.io/apimachinery/pkg/runtime"
 	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/client-go/kubernetes/fake"
 	"k8s.io/client-go/rest"
 	ctrl "sigs.k8s.io/controller-runtime"
 	config2 "sigs.k8s.io/controller-runtime/pkg/config"
 	"sigs.k8s.io/controller-runtime/pkg/manager"
+	"sigs.k8s.io/controller-runtime/pkg/webhook"
 )
 
 var testCtx = context.Background()
@@ -80,6 +87,125 @@ func Test_startOperator(t *testing.T) {
 	})
 }
 
+func Test_addDownloadServer(t *testing.T) {
+	downloadConfig := &config.OperatorConfig{DownloadServerEnabled: true, DownloadServerPort: "8083", DownloadTokenReviewEnabled: true}
+
+	t.Run("should add download server to manager", func(t *testing.T) {
+		// given
+		managerMock := newMockControllerManager(t)
+		managerMock.EXPECT().Add(mock.AnythingOfType("*download.Server")).Return(nil)
+
+		// when
+		err := addDownloadServer(managerMock, downloadConfig, nil, fake.NewClientset(), nil, filesystem.FileSystem{}, download.NewTokenSigner("secret", time.Hour))
+
+		// then
+		require.NoError(t, err)
+	})
+	t.Run("should fail to add download server", func(t *testing.T) {
+		// given
+		managerMock := newMockControllerManager(t)
+		managerMock.EXPECT().Add(mock.Anything).Return(assert.AnError)
+
+		// when
+		err := addDownloadServer(managerMock, downloadConfig, nil, fake.NewClientset(), nil, filesystem.FileSystem{}, download.NewTokenSigner("secret", time.Hour))
+
+		// then
+		require.Error(t, err)
+		assert.ErrorIs(t, err, assert.AnError)
+		assert.ErrorContains(t, err, "unable to add download server")
+	})
+}
+
+func Test_newFilesystem(t *testing.T) {
+	t.Run("should use the volume if s3 is disabled", func(t *testing.T) {
+		// when
+		fs, err := newFilesystem(testCtx, &config.OperatorConfig{})
+
+		// then
+		require.NoError(t, err)
+		assert.Equal(t, filesystem.FileSystem{}, fs)
+	})
+	t.Run("should fail if the object storage is not reachable", func(t *testing.T) {
+		// given
+		operatorConfig := &config.OperatorConfig{S3Enabled: true, S3Config: config.S3Config{Endpoint: "invalid endpoint", Bucket: "archives"}}
+
+		// when
+		_, err := newFilesystem(testCtx, operatorConfig)
+
+		// then
+		assert.ErrorContains(t, err, "unable to connect to s3")
+	})
+}
+
+func Test_getK8sManagerOptions(t *testing.T) {
+	t.Run("should enable leader election", func(t *testing.T) {
+		// given
+		operatorConfig := &config.OperatorConfig{Namespace: "test", LeaderElectionEnabled: true, PodName: "operator-1"}
+
+		// when
+		options := getK8sManagerOptions(flag.NewFlagSet("test", flag.ContinueOnError), []string{}, operatorConfig)
+
+		// then
+		assert.True(t, options.LeaderElection)
+		assert.Equal(t, "k8s-support-archive-operator.k8s.cloudogu.com", options.LeaderElectionID)
+		assert.Equal(t, "test", options.LeaderElectionNamespace)
+		assert.True(t, options.LeaderElectionReleaseOnCancel)
+	})
+	t.Run("should not enable leader election by default", func(t *testing.T) {
+		// when
+		options := getK8sManagerOptions(flag.NewFlagSet("test", flag.ContinueOnError), []string{}, testOperatorConfig)
+
+		// then
+		assert.False(t, options.LeaderElection)
+	})
+	t.Run("should set max concurrent reconciles", func(t *testing.T) {
+		// given
+		operatorConfig := &config.OperatorConfig{Namespace: "test", MaxConcurrentReconciles: 4}
+
+		// when
+		options := getK8sManagerOptions(flag.NewFlagSet("test", flag.ContinueOnError), []string{}, operatorConfig)
+
+		// then
+		assert.Equal(t, 4, options.Controller.MaxConcurrentReconciles)
+	})
+}
+
+func Test_addWebhook(t *testing.T) {
+	webhookConfig := &config.OperatorConfig{WebhookEnabled: true, DefaultContentTimeframe: 96 * time.Hour, MaxContentTimeframe: 720 * time.Hour}
+
+	t.Run("should register webhooks at the webhook server", func(t *testing.T) {
+		// given
+		server := webhook.NewServer(webhook.Options{})
+		managerMock := newMockControllerManager(t)
+		managerMock.EXPECT().GetConfig().Return(&rest.Config{})
+		managerMock.EXPECT().GetScheme().Return(scheme)
+		managerMock.EXPECT().GetWebhookServer().Return(server)
+
+		// when
+		err := addWebhook(managerMock, webhookConfig)
+
+		// then
+		require.NoError(t, err)
+		for _, path := range []string{"/mutate-k8s-cloudogu-com-v1-supportarchive", "/validate-k8s-cloudogu-com-v1-supportarchive"} {
+			_, pattern := server.WebhookMux().Handler(httptest.NewRequest(http.MethodPost, path, nil))
+			assert.Equal(t, path, pattern)
+		}
+	})
+	t.Run("should fail if support archives are not registered in the scheme", func(t *testing.T) {
+		// given
+		managerMock := newMockControllerManager(t)
+		managerMock.EXPECT().GetConfig().Return(&rest.Config{})
+		managerMock.EXPECT().GetScheme().Return(runtime.NewScheme())
+
+		// when
+		err := addWebhook(managerMock, webhookConfig)
+
+		// then
+		require.Error(t, err)
+		assert.ErrorContains(t, err, "unable to add webhook")
+	})
+}
+
 func Test_configureManager(t *testing.T) {
 	t.Run("should fail to configure Manager", func(t *testing.T) {
 		// given
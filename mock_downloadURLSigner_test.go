@@ -0,0 +1,152 @@
+// Code generated by mockery v2.53.3. DO NOT EDIT.
+
+package main
+
+import (
+	time "time"
+
+	mock "github.com/stretchr/testify/mock"
+
+	v1 "github.com/cloudogu/k8s-support-archive-lib/api/v1"
+)
+
+// mockDownloadURLSigner is an autogenerated mock type for the downloadURLSigner type
+type mockDownloadURLSigner struct {
+	mock.Mock
+}
+
+type mockDownloadURLSigner_Expecter struct {
+	mock *mock.Mock
+}
+
+func (_m *mockDownloadURLSigner) EXPECT() *mockDownloadURLSigner_Expecter {
+	return &mockDownloadURLSigner_Expecter{mock: &_m.Mock}
+}
+
+// ExpiresAt provides a mock function with given fields: url
+func (_m *mockDownloadURLSigner) ExpiresAt(url string) (time.Time, bool) {
+	ret := _m.Called(url)
+
+	if len(ret) == 0 {
+		panic("no return value specified for ExpiresAt")
+	}
+
+	var r0 time.Time
+	var r1 bool
+	if rf, ok := ret.Get(0).(func(string) (time.Time, bool)); ok {
+		return rf(url)
+	}
+	if rf, ok := ret.Get(0).(func(string) time.Time); ok {
+		r0 = rf(url)
+	} else {
+		r0 = ret.Get(0).(time.Time)
+	}
+
+	if rf, ok := ret.Get(1).(func(string) bool); ok {
+		r1 = rf(url)
+	} else {
+		r1 = ret.Get(1).(bool)
+	}
+
+	return r0, r1
+}
+
+// mockDownloadURLSigner_ExpiresAt_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'ExpiresAt'
+type mockDownloadURLSigner_ExpiresAt_Call struct {
+	*mock.Call
+}
+
+// ExpiresAt is a helper method to define mock.On call
+//   - url string
+func (_e *mockDownloadURLSigner_Expecter) ExpiresAt(url interface{}) *mockDownloadURLSigner_ExpiresAt_Call {
+	return &mockDownloadURLSigner_ExpiresAt_Call{Call: _e.mock.On("ExpiresAt", url)}
+}
+
+func (_c *mockDownloadURLSigner_ExpiresAt_Call) Run(run func(url string)) *mockDownloadURLSigner_ExpiresAt_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(string))
+	})
+	return _c
+}
+
+func (_c *mockDownloadURLSigner_ExpiresAt_Call) Return(_a0 time.Time, _a1 bool) *mockDownloadURLSigner_ExpiresAt_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *mockDownloadURLSigner_ExpiresAt_Call) RunAndReturn(run func(string) (time.Time, bool)) *mockDownloadURLSigner_ExpiresAt_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// Sign provides a mock function with given fields: cr, url, now
+func (_m *mockDownloadURLSigner) Sign(cr *v1.SupportArchive, url string, now time.Time) (string, time.Time) {
+	ret := _m.Called(cr, url, now)
+
+	if len(ret) == 0 {
+		panic("no return value specified for Sign")
+	}
+
+	var r0 string
+	var r1 time.Time
+	if rf, ok := ret.Get(0).(func(*v1.SupportArchive, string, time.Time) (string, time.Time)); ok {
+		return rf(cr, url, now)
+	}
+	if rf, ok := ret.Get(0).(func(*v1.SupportArchive, string, time.Time) string); ok {
+		r0 = rf(cr, url, now)
+	} else {
+		r0 = ret.Get(0).(string)
+	}
+
+	if rf, ok := ret.Get(1).(func(*v1.SupportArchive, string, time.Time) time.Time); ok {
+		r1 = rf(cr, url, now)
+	} else {
+		r1 = ret.Get(1).(time.Time)
+	}
+
+	return r0, r1
+}
+
+// mockDownloadURLSigner_Sign_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'Sign'
+type mockDownloadURLSigner_Sign_Call struct {
+	*mock.Call
+}
+
+// Sign is a helper method to define mock.On call
+//   - cr *v1.SupportArchive
+//   - url string
+//   - now time.Time
+func (_e *mockDownloadURLSigner_Expecter) Sign(cr interface{}, url interface{}, now interface{}) *mockDownloadURLSigner_Sign_Call {
+	return &mockDownloadURLSigner_Sign_Call{Call: _e.mock.On("Sign", cr, url, now)}
+}
+
+func (_c *mockDownloadURLSigner_Sign_Call) Run(run func(cr *v1.SupportArchive, url string, now time.Time)) *mockDownloadURLSigner_Sign_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(*v1.SupportArchive), args[1].(string), args[2].(time.Time))
+	})
+	return _c
+}
+
+func (_c *mockDownloadURLSigner_Sign_Call) Return(_a0 string, _a1 time.Time) *mockDownloadURLSigner_Sign_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *mockDownloadURLSigner_Sign_Call) RunAndReturn(run func(*v1.SupportArchive, string, time.Time) (string, time.Time)) *mockDownloadURLSigner_Sign_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// newMockDownloadURLSigner creates a new instance of mockDownloadURLSigner. It also registers a testing interface on the mock and a cleanup function to assert the mocks expectations.
+// The first argument is typically a *testing.T value.
+func newMockDownloadURLSigner(t interface {
+	mock.TestingT
+	Cleanup(func())
+}) *mockDownloadURLSigner {
+	mock := &mockDownloadURLSigner{}
+	mock.Mock.Test(t)
+
+	t.Cleanup(func() { mock.AssertExpectations(t) })
+
+	return mock
+}
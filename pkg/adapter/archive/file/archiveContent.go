@@ -0,0 +1,163 @@
+package file
+
+import (
+	"archive/zip"
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"maps"
+	"path"
+	"path/filepath"
+	"slices"
+	"strings"
+
+	"github.com/cloudogu/k8s-support-archive-operator/pkg/domain"
+	"gopkg.in/yaml.v3"
+)
+
+// contentFiles provides the files of an archive. The collected data in the work directory has the same layout as
+// the archive, so both can be compared.
+type contentFiles interface {
+	// walk calls fn for every file with the given extension in the directory or its subdirectories. The path is
+	// slash-separated and relative to the root of the archive. A missing directory is ignored.
+	walk(ctx context.Context, dirName, extension string, fn func(filePath string, reader io.Reader) error) error
+}
+
+// readArchiveContent reads the parts of the archive which are compared with another archive.
+func readArchiveContent(ctx context.Context, files contentFiles) (*domain.ArchiveContent, error) {
+	content := &domain.ArchiveContent{}
+	err := files.walk(ctx, archiveSystemStateDirName, ".yaml", func(filePath string, reader io.Reader) error {
+		var resource domain.UnstructuredResource
+		err := decodeYAML(reader, &resource)
+		if err != nil {
+			return err
+		}
+		content.Resources = append(content.Resources, domain.ArchiveResource{Path: filePath, Content: resource.Content})
+
+		return nil
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to read system state: %w", err)
+	}
+
+	err = files.walk(ctx, archiveSecretsInfoDirName, ".yaml", func(filePath string, reader io.Reader) error {
+		var secret domain.SecretYaml
+		err := decodeYAML(reader, &secret)
+		if err != nil {
+			return err
+		}
+		content.Secrets = append(content.Secrets, domain.ArchiveSecret{Path: filePath, Keys: slices.Sorted(maps.Keys(secret.Data))})
+
+		return nil
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to read secrets: %w", err)
+	}
+
+	err = files.walk(ctx, archiveVolumeInfoDirName, ".yaml", func(_ string, reader io.Reader) error {
+		var volume domain.VolumeInfo
+		err := decodeYAML(reader, &volume)
+		if err != nil {
+			return err
+		}
+		content.Volumes = append(content.Volumes, volume)
+
+		return nil
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to read volume info: %w", err)
+	}
+
+	err = files.walk(ctx, archiveNodeInfoDirName, ".csv", func(filePath string, reader io.Reader) error {
+		samples, err := parseNodeInfoCSV(reader, strings.TrimSuffix(path.Base(filePath), ".csv"))
+		if err != nil {
+			return err
+		}
+		content.NodeInfo = append(content.NodeInfo, samples...)
+
+		return nil
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to read node info: %w", err)
+	}
+
+	return content, nil
+}
+
+func decodeYAML(reader io.Reader, out any) error {
+	err := yaml.NewDecoder(reader).Decode(out)
+	if err != nil && !errors.Is(err, io.EOF) {
+		return err
+	}
+
+	return nil
+}
+
+// zipContentFiles provides the files of a zip archive.
+type zipContentFiles struct {
+	archive *zip.Reader
+}
+
+func (z zipContentFiles) walk(_ context.Context, dirName, extension string, fn func(filePath string, reader io.Reader) error) error {
+	for _, file := range z.archive.File {
+		if !strings.HasPrefix(file.Name, dirName+"/") || path.Ext(file.Name) != extension {
+			continue
+		}
+
+		err := z.read(file, fn)
+		if err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+func (z zipContentFiles) read(file *zip.File, fn func(filePath string, reader io.Reader) error) error {
+	reader, err := file.Open()
+	if err != nil {
+		return fmt.Errorf("failed to open file %s: %w", file.Name, err)
+	}
+	defer func() {
+		_ = reader.Close()
+	}()
+
+	err = fn(file.Name, reader)
+	if err != nil {
+		return fmt.Errorf("failed to read file %s: %w", file.Name, err)
+	}
+
+	return nil
+}
+
+// workDirContentFiles provides the collected data of an archive in the work directory.
+type workDirContentFiles struct {
+	filesystem volumeFs
+	// archivePath is the directory of the archive in the work directory.
+	archivePath string
+}
+
+func (w workDirContentFiles) walk(ctx context.Context, dirName, extension string, fn func(filePath string, reader io.Reader) error) error {
+	return walkFiles(ctx, w.filesystem, filepath.Join(w.archivePath, filepath.FromSlash(dirName)), extension, func(filePath string) error {
+		relativePath, err := filepath.Rel(w.archivePath, filePath)
+		if err != nil {
+			return fmt.Errorf("failed to get relative path of %s: %w", filePath, err)
+		}
+
+		file, err := w.filesystem.Open(ctx, filePath)
+		if err != nil {
+			return fmt.Errorf("failed to open file %s: %w", filePath, err)
+		}
+		defer func() {
+			_ = file.Close()
+		}()
+
+		err = fn(filepath.ToSlash(relativePath), file)
+		if err != nil {
+			return fmt.Errorf("failed to read file %s: %w", filePath, err)
+		}
+
+		return nil
+	})
+}
@@ -0,0 +1,122 @@
+package file
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/cloudogu/k8s-support-archive-operator/pkg/adapter/filesystem"
+	"github.com/cloudogu/k8s-support-archive-operator/pkg/domain"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+var testContentFiles = map[string]string{
+	"Resources/SystemState/apps/v1/Deployment/ldap.yaml": "name: ldap\npath: apps/v1/Deployment\ncontent:\n  kind: Deployment\n  spec:\n    replicas: 1\n",
+	"Resources/Secrets/ldap.yaml":                        "kind: Secret\ndata:\n  user: '***'\n  password: '***'\nmetadata:\n  name: ldap\n",
+	"VolumeInfo/persistentVolumeClaims.yaml":             testVolumeInfoYaml,
+	"NodeInfo/cpuCores.csv":                              "label,value,time\nnode-1,4.00,2025-09-01T10:00:00Z\n",
+	"Logs/logs.log":                                      "LOGS\n",
+}
+
+var testContent = &domain.ArchiveContent{
+	Resources: []domain.ArchiveResource{{
+		Path:    "Resources/SystemState/apps/v1/Deployment/ldap.yaml",
+		Content: map[string]any{"kind": "Deployment", "spec": map[string]any{"replicas": 1}},
+	}},
+	Secrets: []domain.ArchiveSecret{{Path: "Resources/Secrets/ldap.yaml", Keys: []string{"password", "user"}}},
+	Volumes: []domain.VolumeInfo{{
+		Name:      "volumeInfo",
+		Timestamp: time.Date(2025, 9, 1, 10, 0, 0, 0, time.UTC),
+		Items:     []domain.VolumeInfoItem{{Name: "ldap", Capacity: 100, Used: 93, PercentageUsage: "93.00%", InodesUsed: 10, Phase: "Bound"}},
+	}},
+	NodeInfo: []domain.LabeledSample{{MetricName: "cpuCores", ID: "node-1", Value: 4, Time: time.Date(2025, 9, 1, 10, 0, 0, 0, time.UTC)}},
+}
+
+func TestZipArchiveReader_Content(t *testing.T) {
+	t.Run("should read content to compare", func(t *testing.T) {
+		// given
+		sut := openTestZipArchive(t, testContentFiles)
+
+		// when
+		content, err := sut.Content(testCtx)
+
+		// then
+		require.NoError(t, err)
+		assert.Equal(t, testContent, content)
+	})
+
+	t.Run("should return error on invalid resource", func(t *testing.T) {
+		// given
+		sut := openTestZipArchive(t, map[string]string{"Resources/SystemState/core/v1/Pod/ldap-0.yaml": "content: ["})
+
+		// when
+		_, err := sut.Content(testCtx)
+
+		// then
+		require.Error(t, err)
+		assert.ErrorContains(t, err, "failed to read system state: failed to read file Resources/SystemState/core/v1/Pod/ldap-0.yaml")
+	})
+}
+
+func TestCollectedDataReader_ReadContent(t *testing.T) {
+	t.Run("should read collected content to compare", func(t *testing.T) {
+		// given
+		workPath := t.TempDir()
+		for name, content := range testContentFiles {
+			path := filepath.Join(workPath, testNamespace, testName, filepath.FromSlash(name))
+			require.NoError(t, os.MkdirAll(filepath.Dir(path), 0755))
+			require.NoError(t, os.WriteFile(path, []byte(content), 0644))
+		}
+		sut := NewCollectedDataReader(workPath, filesystem.FileSystem{})
+
+		// when
+		content, err := sut.ReadContent(testCtx, testID)
+
+		// then
+		require.NoError(t, err)
+		assert.Equal(t, testContent, content)
+	})
+
+	t.Run("should return empty content if nothing was collected", func(t *testing.T) {
+		// given
+		sut := NewCollectedDataReader(t.TempDir(), filesystem.FileSystem{})
+
+		// when
+		content, err := sut.ReadContent(testCtx, testID)
+
+		// then
+		require.NoError(t, err)
+		assert.Equal(t, &domain.ArchiveContent{}, content)
+	})
+}
+
+func TestZipFileArchiveRepository_ReadContent(t *testing.T) {
+	t.Run("should read content of the archive", func(t *testing.T) {
+		// given
+		archivesPath := t.TempDir()
+		require.NoError(t, os.MkdirAll(filepath.Join(archivesPath, testNamespace), 0755))
+		require.NoError(t, os.Rename(writeTestZipArchive(t, testContentFiles), filepath.Join(archivesPath, testNamespace, testName+".zip")))
+		sut := &ZipFileArchiveRepository{filesystem: filesystem.FileSystem{}, archivesPath: archivesPath}
+
+		// when
+		content, err := sut.ReadContent(testCtx, testID)
+
+		// then
+		require.NoError(t, err)
+		assert.Equal(t, testContent, content)
+	})
+
+	t.Run("should return error if the archive does not exist", func(t *testing.T) {
+		// given
+		sut := &ZipFileArchiveRepository{filesystem: filesystem.FileSystem{}, archivesPath: t.TempDir()}
+
+		// when
+		_, err := sut.ReadContent(testCtx, testID)
+
+		// then
+		require.Error(t, err)
+		assert.ErrorIs(t, err, os.ErrNotExist)
+	})
+}
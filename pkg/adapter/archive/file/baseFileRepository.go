@@ -9,13 +9,17 @@ import (
 	"io/fs"
 	"os"
 	"path/filepath"
+	"strconv"
 
 	"github.com/cloudogu/k8s-support-archive-operator/pkg/domain"
 	"sigs.k8s.io/controller-runtime/pkg/log"
 )
 
 const (
-	stateFileName = ".done"
+	stateFileName    = ".done"
+	skippedFileName  = ".skipped"
+	specHashFileName = ".spec-hash"
+	failuresFileName = ".failures"
 )
 
 type createFn[DATATYPE domain.CollectorUnionDataType] = func(context.Context, domain.SupportArchiveID, *DATATYPE) error
@@ -41,12 +45,12 @@ func (l *baseFileRepository) finishCollection(ctx context.Context, id domain.Sup
 	logger := log.FromContext(ctx).WithName("baseFileRepository.finishCollection")
 	stateFilePath := getStateFilePath(l.workPath, id, l.collectorDir)
 
-	err := l.filesystem.MkdirAll(filepath.Dir(stateFilePath), os.ModePerm)
+	err := l.filesystem.MkdirAll(ctx, filepath.Dir(stateFilePath), os.ModePerm)
 	if err != nil {
 		return fmt.Errorf("failed to create directory: %w", err)
 	}
 
-	stateFile, err := l.filesystem.Create(stateFilePath)
+	stateFile, err := l.filesystem.Create(ctx, stateFilePath)
 	if err != nil {
 		return fmt.Errorf("failed to create file %s: %w", stateFilePath, err)
 	}
@@ -64,9 +68,9 @@ func (l *baseFileRepository) finishCollection(ctx context.Context, id domain.Sup
 	return nil
 }
 
-func (l *baseFileRepository) IsCollected(_ context.Context, id domain.SupportArchiveID) (bool, error) {
+func (l *baseFileRepository) IsCollected(ctx context.Context, id domain.SupportArchiveID) (bool, error) {
 	stateFilePath := getStateFilePath(l.workPath, id, l.collectorDir)
-	_, err := l.filesystem.Stat(stateFilePath)
+	_, err := l.filesystem.Stat(ctx, stateFilePath)
 
 	if err != nil && os.IsNotExist(err) {
 		return false, nil
@@ -76,10 +80,136 @@ func (l *baseFileRepository) IsCollected(_ context.Context, id domain.SupportArc
 	return true, nil
 }
 
+// Skip removes partially collected data and marks the collection as skipped.
+// The reason is persisted so that it can be added to the archive later.
+func (l *baseFileRepository) Skip(ctx context.Context, id domain.SupportArchiveID, reason string) error {
+	dirPath := filepath.Join(l.workPath, id.Namespace, id.Name, l.collectorDir)
+	err := l.filesystem.RemoveAll(ctx, dirPath)
+	if err != nil {
+		return fmt.Errorf("failed to remove partially collected data in %s: %w", dirPath, err)
+	}
+
+	err = l.filesystem.MkdirAll(ctx, dirPath, os.ModePerm)
+	if err != nil {
+		return fmt.Errorf("failed to create directory: %w", err)
+	}
+
+	skippedFilePath := getSkippedFilePath(l.workPath, id, l.collectorDir)
+	err = l.filesystem.WriteFile(ctx, skippedFilePath, []byte(reason), 0644)
+	if err != nil {
+		return fmt.Errorf("failed to write file %s: %w", skippedFilePath, err)
+	}
+
+	log.FromContext(ctx).WithName("baseFileRepository.Skip").Info("marked collection as skipped", "collector", l.collectorDir)
+	return nil
+}
+
+// IsSkipped returns true and the reason if the collection was skipped before.
+func (l *baseFileRepository) IsSkipped(ctx context.Context, id domain.SupportArchiveID) (bool, string, error) {
+	skippedFilePath := getSkippedFilePath(l.workPath, id, l.collectorDir)
+	file, err := l.filesystem.Open(ctx, skippedFilePath)
+	if err != nil && os.IsNotExist(err) {
+		return false, "", nil
+	} else if err != nil {
+		return false, "", fmt.Errorf("failed to open file %s: %w", skippedFilePath, err)
+	}
+	defer func() {
+		_ = file.Close()
+	}()
+
+	reason, err := l.filesystem.ReadAll(file)
+	if err != nil {
+		return false, "", fmt.Errorf("failed to read file %s: %w", skippedFilePath, err)
+	}
+
+	return true, string(reason), nil
+}
+
+// SetSpecHash records the hash of the spec the data is collected with. It is recorded before the collection, so the
+// directory of the collector is created if it does not exist yet.
+func (l *baseFileRepository) SetSpecHash(ctx context.Context, id domain.SupportArchiveID, hash string) error {
+	specHashFilePath := getSpecHashFilePath(l.workPath, id, l.collectorDir)
+	err := l.filesystem.MkdirAll(ctx, filepath.Dir(specHashFilePath), os.ModePerm)
+	if err != nil {
+		return fmt.Errorf("failed to create directory: %w", err)
+	}
+
+	err = l.filesystem.WriteFile(ctx, specHashFilePath, []byte(hash), 0644)
+	if err != nil {
+		return fmt.Errorf("failed to write file %s: %w", specHashFilePath, err)
+	}
+
+	return nil
+}
+
+// GetSpecHash returns the hash of the spec the data was collected with or an empty string if no hash was recorded.
+func (l *baseFileRepository) GetSpecHash(ctx context.Context, id domain.SupportArchiveID) (string, error) {
+	specHashFilePath := getSpecHashFilePath(l.workPath, id, l.collectorDir)
+	file, err := l.filesystem.Open(ctx, specHashFilePath)
+	if err != nil && os.IsNotExist(err) {
+		return "", nil
+	} else if err != nil {
+		return "", fmt.Errorf("failed to open file %s: %w", specHashFilePath, err)
+	}
+	defer func() {
+		_ = file.Close()
+	}()
+
+	hash, err := l.filesystem.ReadAll(file)
+	if err != nil {
+		return "", fmt.Errorf("failed to read file %s: %w", specHashFilePath, err)
+	}
+
+	return string(hash), nil
+}
+
+// SetFailures records how often the collection failed. The count is removed together with the collected data, e.g. on
+// deletion, refresh or skip.
+func (l *baseFileRepository) SetFailures(ctx context.Context, id domain.SupportArchiveID, failures int) error {
+	failuresFilePath := getFailuresFilePath(l.workPath, id, l.collectorDir)
+	err := l.filesystem.MkdirAll(ctx, filepath.Dir(failuresFilePath), os.ModePerm)
+	if err != nil {
+		return fmt.Errorf("failed to create directory: %w", err)
+	}
+
+	err = l.filesystem.WriteFile(ctx, failuresFilePath, []byte(strconv.Itoa(failures)), 0644)
+	if err != nil {
+		return fmt.Errorf("failed to write file %s: %w", failuresFilePath, err)
+	}
+
+	return nil
+}
+
+// GetFailures returns how often the collection failed or 0 if no failures were recorded.
+func (l *baseFileRepository) GetFailures(ctx context.Context, id domain.SupportArchiveID) (int, error) {
+	failuresFilePath := getFailuresFilePath(l.workPath, id, l.collectorDir)
+	file, err := l.filesystem.Open(ctx, failuresFilePath)
+	if err != nil && os.IsNotExist(err) {
+		return 0, nil
+	} else if err != nil {
+		return 0, fmt.Errorf("failed to open file %s: %w", failuresFilePath, err)
+	}
+	defer func() {
+		_ = file.Close()
+	}()
+
+	content, err := l.filesystem.ReadAll(file)
+	if err != nil {
+		return 0, fmt.Errorf("failed to read file %s: %w", failuresFilePath, err)
+	}
+
+	failures, err := strconv.Atoi(string(content))
+	if err != nil {
+		return 0, fmt.Errorf("failed to parse failures from file %s: %w", failuresFilePath, err)
+	}
+
+	return failures, nil
+}
+
 func (l *baseFileRepository) Delete(ctx context.Context, id domain.SupportArchiveID) error {
 	dirPath := filepath.Join(l.workPath, id.Namespace, id.Name, l.collectorDir)
 
-	err := l.filesystem.RemoveAll(dirPath)
+	err := l.filesystem.RemoveAll(ctx, dirPath)
 	if err != nil {
 		return fmt.Errorf("failed to remove %s directory %s: %w", l.collectorDir, dirPath, err)
 	}
@@ -90,13 +220,13 @@ func (l *baseFileRepository) Delete(ctx context.Context, id domain.SupportArchiv
 	parentDir := filepath.Dir(dirPath)
 	// Stop at the root
 	for parentDir != l.workPath {
-		dirEntries, readErr := l.filesystem.ReadDir(parentDir)
+		dirEntries, readErr := l.filesystem.ReadDir(ctx, parentDir)
 		if readErr != nil {
 			logger.Error(readErr, "failed to read directory %s", parentDir)
 			break
 		}
 		if len(dirEntries) == 0 {
-			removeErr := l.filesystem.Remove(parentDir)
+			removeErr := l.filesystem.Remove(ctx, parentDir)
 
 			if removeErr != nil {
 				logger.Error(removeErr, "failed to remove directory %s", parentDir)
@@ -112,13 +242,14 @@ func (l *baseFileRepository) Delete(ctx context.Context, id domain.SupportArchiv
 }
 
 // create receives elements from the stream and calls the concrete createFn for each element.
-// If an error occurs, create executes deleteFn to tidy up.
+// If an error occurs or the context is done, e.g. because the collector failed, create executes deleteFn to tidy up,
+// so that a retry does not append to the partial data.
 // If the stream is closed, create will end and call the finishFn.
 func create[DATATYPE domain.CollectorUnionDataType](ctx context.Context, id domain.SupportArchiveID, dataStream <-chan *DATATYPE, createFn createFn[DATATYPE], deleteFn deleteFn, finishFn finishFn, closeFn closeFn) error {
 	for {
 		select {
 		case <-ctx.Done():
-			return ctx.Err()
+			return abortCreate(ctx, id, closeFn, deleteFn)
 		case data, ok := <-dataStream:
 			if ok {
 				err := createFn(ctx, id, data)
@@ -148,6 +279,26 @@ func doSafeClose(ctx context.Context, id domain.SupportArchiveID, closeFn closeF
 	return nil
 }
 
+// abortCreate closes and removes the partial data if the context is done. The cleanup uses a context without
+// cancellation, as the given context is already done.
+func abortCreate(ctx context.Context, id domain.SupportArchiveID, closeFn closeFn, deleteFn deleteFn) error {
+	errs := []error{fmt.Errorf("collection was aborted: %w", ctx.Err())}
+	cleanupCtx := context.WithoutCancel(ctx)
+	if closeFn != nil {
+		closeErr := closeFn(cleanupCtx, id)
+		if closeErr != nil {
+			errs = append(errs, fmt.Errorf("error during close function: %w", closeErr))
+		}
+	}
+
+	cleanErr := deleteFn(cleanupCtx, id)
+	if cleanErr != nil {
+		errs = append(errs, fmt.Errorf("failed to clean up data after error: %w", cleanErr))
+	}
+
+	return errors.Join(errs...)
+}
+
 func handleCreateErr(ctx context.Context, id domain.SupportArchiveID, err error, closeFn closeFn, deleteFn deleteFn) error {
 	if err != nil {
 		if closeFn != nil {
@@ -171,12 +322,13 @@ func handleCreateErr(ctx context.Context, id domain.SupportArchiveID, err error,
 func (l *baseFileRepository) Stream(ctx context.Context, id domain.SupportArchiveID, stream *domain.Stream) error {
 	dirPath := filepath.Join(l.workPath, id.Namespace, id.Name, l.collectorDir)
 
-	err := l.filesystem.WalkDir(dirPath, func(path string, info fs.DirEntry, err error) error {
+	err := l.filesystem.WalkDir(ctx, dirPath, func(path string, info fs.DirEntry, err error) error {
 		if err != nil {
 			return err
 		}
 
-		if info.IsDir() {
+		// State files are only used by the operator and are no part of the archive.
+		if info.IsDir() || isStateFile(info.Name()) {
 			return nil
 		}
 
@@ -186,7 +338,7 @@ func (l *baseFileRepository) Stream(ctx context.Context, id domain.SupportArchiv
 		}
 		writeSaveToChannel(ctx, domain.StreamData{
 			ID:                rel,
-			StreamConstructor: l.createStreamConstructor(path),
+			StreamConstructor: l.createStreamConstructor(ctx, path),
 		}, stream.Data)
 
 		return nil
@@ -201,9 +353,9 @@ func (l *baseFileRepository) Stream(ctx context.Context, id domain.SupportArchiv
 	return nil
 }
 
-func (l *baseFileRepository) createStreamConstructor(path string) domain.StreamConstructor {
+func (l *baseFileRepository) createStreamConstructor(ctx context.Context, path string) domain.StreamConstructor {
 	return func() (io.Reader, domain.CloseStreamFunc, error) {
-		open, openErr := l.filesystem.Open(path)
+		open, openErr := l.filesystem.Open(ctx, path)
 		if openErr != nil {
 			return nil, nil, fmt.Errorf("failed to open file %s: %w", path, openErr)
 		}
@@ -224,3 +376,19 @@ func writeSaveToChannel[T any](ctx context.Context, data T, dataChannel chan<- T
 func getStateFilePath(workPath string, id domain.SupportArchiveID, collectorDir string) string {
 	return filepath.Join(workPath, id.Namespace, id.Name, collectorDir, stateFileName)
 }
+
+func getSkippedFilePath(workPath string, id domain.SupportArchiveID, collectorDir string) string {
+	return filepath.Join(workPath, id.Namespace, id.Name, collectorDir, skippedFileName)
+}
+
+func getSpecHashFilePath(workPath string, id domain.SupportArchiveID, collectorDir string) string {
+	return filepath.Join(workPath, id.Namespace, id.Name, collectorDir, specHashFileName)
+}
+
+func getFailuresFilePath(workPath string, id domain.SupportArchiveID, collectorDir string) string {
+	return filepath.Join(workPath, id.Namespace, id.Name, collectorDir, failuresFileName)
+}
+
+func isStateFile(name string) bool {
+	return name == stateFileName || name == skippedFileName || name == specHashFileName || name == failuresFileName
+}
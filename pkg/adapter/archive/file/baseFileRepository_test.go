@@ -51,8 +51,8 @@ func Test_baseFileRepository_FinishCollection(t *testing.T) {
 					fileMock.EXPECT().Write([]byte("done")).Return(0, nil)
 
 					fsMock := newMockVolumeFs(t)
-					fsMock.EXPECT().MkdirAll(testWorkDirCollectorPath, fs.ModePerm).Return(nil)
-					fsMock.EXPECT().Create(testStateFilePath).Return(fileMock, nil)
+					fsMock.EXPECT().MkdirAll(mock.Anything, testWorkDirCollectorPath, fs.ModePerm).Return(nil)
+					fsMock.EXPECT().Create(mock.Anything, testStateFilePath).Return(fileMock, nil)
 
 					return fsMock
 				},
@@ -76,8 +76,8 @@ func Test_baseFileRepository_FinishCollection(t *testing.T) {
 					fileMock.EXPECT().Write([]byte("done")).Return(0, assert.AnError)
 
 					fsMock := newMockVolumeFs(t)
-					fsMock.EXPECT().MkdirAll(testWorkDirCollectorPath, fs.ModePerm).Return(nil)
-					fsMock.EXPECT().Create(testStateFilePath).Return(fileMock, nil)
+					fsMock.EXPECT().MkdirAll(mock.Anything, testWorkDirCollectorPath, fs.ModePerm).Return(nil)
+					fsMock.EXPECT().Create(mock.Anything, testStateFilePath).Return(fileMock, nil)
 
 					return fsMock
 				},
@@ -98,8 +98,8 @@ func Test_baseFileRepository_FinishCollection(t *testing.T) {
 			fields: fields{
 				filesystem: func(t *testing.T) volumeFs {
 					fsMock := newMockVolumeFs(t)
-					fsMock.EXPECT().MkdirAll(testWorkDirCollectorPath, fs.ModePerm).Return(nil)
-					fsMock.EXPECT().Create(testStateFilePath).Return(nil, assert.AnError)
+					fsMock.EXPECT().MkdirAll(mock.Anything, testWorkDirCollectorPath, fs.ModePerm).Return(nil)
+					fsMock.EXPECT().Create(mock.Anything, testStateFilePath).Return(nil, assert.AnError)
 
 					return fsMock
 				},
@@ -120,7 +120,7 @@ func Test_baseFileRepository_FinishCollection(t *testing.T) {
 			fields: fields{
 				filesystem: func(t *testing.T) volumeFs {
 					fsMock := newMockVolumeFs(t)
-					fsMock.EXPECT().MkdirAll(testWorkDirCollectorPath, fs.ModePerm).Return(assert.AnError)
+					fsMock.EXPECT().MkdirAll(mock.Anything, testWorkDirCollectorPath, fs.ModePerm).Return(assert.AnError)
 
 					return fsMock
 				},
@@ -171,7 +171,7 @@ func Test_baseFileRepository_IsCollected(t *testing.T) {
 			fields: fields{
 				filesystem: func(t *testing.T) volumeFs {
 					fsMock := newMockVolumeFs(t)
-					fsMock.EXPECT().Stat(testStateFilePath).Return(nil, nil)
+					fsMock.EXPECT().Stat(mock.Anything, testStateFilePath).Return(nil, nil)
 					return fsMock
 				},
 				workPath:     testWorkPath,
@@ -189,7 +189,7 @@ func Test_baseFileRepository_IsCollected(t *testing.T) {
 			fields: fields{
 				filesystem: func(t *testing.T) volumeFs {
 					fsMock := newMockVolumeFs(t)
-					fsMock.EXPECT().Stat(testStateFilePath).Return(nil, os.ErrNotExist)
+					fsMock.EXPECT().Stat(mock.Anything, testStateFilePath).Return(nil, os.ErrNotExist)
 					return fsMock
 				},
 				workPath:     testWorkPath,
@@ -207,7 +207,7 @@ func Test_baseFileRepository_IsCollected(t *testing.T) {
 			fields: fields{
 				filesystem: func(t *testing.T) volumeFs {
 					fsMock := newMockVolumeFs(t)
-					fsMock.EXPECT().Stat(testStateFilePath).Return(nil, assert.AnError)
+					fsMock.EXPECT().Stat(mock.Anything, testStateFilePath).Return(nil, assert.AnError)
 					return fsMock
 				},
 				workPath:     testWorkPath,
@@ -260,8 +260,8 @@ func Test_baseFileRepository_Delete(t *testing.T) {
 				collectorDir: testCollectorDirName,
 				filesystem: func(t *testing.T) volumeFs {
 					fsMock := newMockVolumeFs(t)
-					fsMock.EXPECT().RemoveAll(testWorkDirCollectorPath).Return(nil)
-					fsMock.EXPECT().ReadDir(testWorkDirArchivePath).Return([]fs.DirEntry{testEntry{"other collector"}}, nil)
+					fsMock.EXPECT().RemoveAll(mock.Anything, testWorkDirCollectorPath).Return(nil)
+					fsMock.EXPECT().ReadDir(mock.Anything, testWorkDirArchivePath).Return([]fs.DirEntry{testEntry{"other collector"}}, nil)
 
 					return fsMock
 				},
@@ -279,11 +279,11 @@ func Test_baseFileRepository_Delete(t *testing.T) {
 				collectorDir: testCollectorDirName,
 				filesystem: func(t *testing.T) volumeFs {
 					fsMock := newMockVolumeFs(t)
-					fsMock.EXPECT().RemoveAll(testWorkDirCollectorPath).Return(nil)
-					fsMock.EXPECT().ReadDir(testWorkDirArchivePath).Return([]fs.DirEntry{}, nil)
-					fsMock.EXPECT().Remove(testWorkDirArchivePath).Return(nil)
-					fsMock.EXPECT().ReadDir(testWorkDirNamespacePath).Return([]fs.DirEntry{}, nil)
-					fsMock.EXPECT().Remove(testWorkDirNamespacePath).Return(nil)
+					fsMock.EXPECT().RemoveAll(mock.Anything, testWorkDirCollectorPath).Return(nil)
+					fsMock.EXPECT().ReadDir(mock.Anything, testWorkDirArchivePath).Return([]fs.DirEntry{}, nil)
+					fsMock.EXPECT().Remove(mock.Anything, testWorkDirArchivePath).Return(nil)
+					fsMock.EXPECT().ReadDir(mock.Anything, testWorkDirNamespacePath).Return([]fs.DirEntry{}, nil)
+					fsMock.EXPECT().Remove(mock.Anything, testWorkDirNamespacePath).Return(nil)
 
 					return fsMock
 				},
@@ -301,7 +301,7 @@ func Test_baseFileRepository_Delete(t *testing.T) {
 				collectorDir: testCollectorDirName,
 				filesystem: func(t *testing.T) volumeFs {
 					fsMock := newMockVolumeFs(t)
-					fsMock.EXPECT().RemoveAll(testWorkDirCollectorPath).Return(assert.AnError)
+					fsMock.EXPECT().RemoveAll(mock.Anything, testWorkDirCollectorPath).Return(assert.AnError)
 
 					return fsMock
 				},
@@ -451,6 +451,45 @@ func Test_create(t *testing.T) {
 				assert.ErrorContains(t, err, "error creating element from data stream")
 			},
 		},
+		{
+			name: "should close and delete partial data if the context is canceled",
+			args: args[domain.LogLine]{
+				ctx:        canceledCtx(),
+				id:         testID,
+				dataStream: make(chan *domain.LogLine),
+				closeFn: func(ctx context.Context, id domain.SupportArchiveID) error {
+					return assert.AnError
+				},
+				deleteFn: func(ctx context.Context, id domain.SupportArchiveID) error {
+					return ctx.Err()
+				},
+			},
+			wantErr: func(t *testing.T, err error) {
+				require.Error(t, err)
+				assert.ErrorIs(t, err, context.Canceled)
+				assert.ErrorIs(t, err, assert.AnError)
+				assert.ErrorContains(t, err, "collection was aborted")
+				assert.ErrorContains(t, err, "error during close function")
+				assert.NotContains(t, err.Error(), "failed to clean up data after error")
+			},
+		},
+		{
+			name: "should return join error on cleanup error if the context is canceled",
+			args: args[domain.LogLine]{
+				ctx:        canceledCtx(),
+				id:         testID,
+				dataStream: make(chan *domain.LogLine),
+				deleteFn: func(ctx context.Context, id domain.SupportArchiveID) error {
+					return assert.AnError
+				},
+			},
+			wantErr: func(t *testing.T, err error) {
+				require.Error(t, err)
+				assert.ErrorIs(t, err, context.Canceled)
+				assert.ErrorIs(t, err, assert.AnError)
+				assert.ErrorContains(t, err, "failed to clean up data after error")
+			},
+		},
 	}
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
@@ -459,6 +498,13 @@ func Test_create(t *testing.T) {
 	}
 }
 
+func canceledCtx() context.Context {
+	ctx, cancel := context.WithCancel(testCtx)
+	cancel()
+
+	return ctx
+}
+
 func getSuccessStream() chan *domain.LogLine {
 	channel := make(chan *domain.LogLine)
 
@@ -500,7 +546,7 @@ func Test_baseFileRepository_Stream(t *testing.T) {
 				directory: testCollectorDirName,
 				filesystem: func(t *testing.T) volumeFs {
 					fsMock := newMockVolumeFs(t)
-					fsMock.EXPECT().WalkDir(testWorkDirCollectorPath, mock.Anything).Return(assert.AnError)
+					fsMock.EXPECT().WalkDir(mock.Anything, testWorkDirCollectorPath, mock.Anything).Return(assert.AnError)
 					return fsMock
 				},
 			},
@@ -586,7 +632,7 @@ func Test_baseFileRepository_createStreamConstructor(t *testing.T) {
 				workPath: testWorkPath,
 				filesystem: func(t *testing.T) (volumeFs, io.Reader) {
 					fsMock := newMockVolumeFs(t)
-					fsMock.EXPECT().Open(testWorkLog).Return(nil, assert.AnError)
+					fsMock.EXPECT().Open(mock.Anything, testWorkLog).Return(nil, assert.AnError)
 					return fsMock, nil
 				},
 			},
@@ -606,7 +652,7 @@ func Test_baseFileRepository_createStreamConstructor(t *testing.T) {
 				filesystem: func(t *testing.T) (volumeFs, io.Reader) {
 					fsMock := newMockVolumeFs(t)
 					fileMock := newMockClosableRWFile(t)
-					fsMock.EXPECT().Open(testWorkLog).Return(fileMock, nil)
+					fsMock.EXPECT().Open(mock.Anything, testWorkLog).Return(fileMock, nil)
 					return fsMock, bufio.NewReader(fileMock)
 				},
 			},
@@ -625,7 +671,7 @@ func Test_baseFileRepository_createStreamConstructor(t *testing.T) {
 				workPath:   tt.fields.workPath,
 				filesystem: testFilesystem,
 			}
-			reader, closeReader, err := l.createStreamConstructor(tt.args.path)()
+			reader, closeReader, err := l.createStreamConstructor(testCtx, tt.args.path)()
 			tt.wantErr(t, err)
 			assert.Equal(t, expectedWant, reader)
 			// this is a workaround, since functions cannot be compared reliably, see https://github.com/stretchr/testify/issues/565
@@ -677,6 +723,265 @@ func TestBaseFileRepository(t *testing.T) {
 		_, open := <-stream.Data
 		assert.False(t, open)
 	})
+	t.Run("should not stream state files", func(t *testing.T) {
+		const collectorDir = "collectorDir"
+		workPath := filepath.Join(t.TempDir(), "work")
+		fullPath := filepath.Join(workPath, testNamespace, testName, collectorDir)
+		require.NoError(t, os.MkdirAll(fullPath, os.ModePerm))
+		require.NoError(t, os.WriteFile(filepath.Join(fullPath, "file01.txt"), []byte("file01"), os.ModePerm))
+		require.NoError(t, os.WriteFile(filepath.Join(fullPath, stateFileName), []byte("done"), os.ModePerm))
+		require.NoError(t, os.WriteFile(filepath.Join(fullPath, specHashFileName), []byte("abc123"), os.ModePerm))
+
+		repo := NewBaseFileRepository(workPath, collectorDir, filesystem.FileSystem{})
+		stream := &domain.Stream{
+			Data: make(chan domain.StreamData, 3),
+		}
+
+		err := repo.Stream(testCtx, testID, stream)
+
+		require.NoError(t, err)
+		var ids []string
+		for data := range stream.Data {
+			ids = append(ids, data.ID)
+		}
+		assert.Equal(t, []string{"file01.txt"}, ids)
+	})
+	t.Run("should replace collected data with skip reason", func(t *testing.T) {
+		const collectorDir = "collectorDir"
+		workPath := filepath.Join(t.TempDir(), "work")
+		fullPath := filepath.Join(workPath, testNamespace, testName, collectorDir)
+		require.NoError(t, os.MkdirAll(fullPath, os.ModePerm))
+		require.NoError(t, os.WriteFile(filepath.Join(fullPath, "partial.txt"), []byte("partial"), os.ModePerm))
+
+		repo := NewBaseFileRepository(workPath, collectorDir, filesystem.FileSystem{})
+
+		skipped, _, err := repo.IsSkipped(testCtx, testID)
+		require.NoError(t, err)
+		assert.False(t, skipped)
+
+		err = repo.Skip(testCtx, testID, "collector failed")
+		require.NoError(t, err)
+
+		skipped, reason, err := repo.IsSkipped(testCtx, testID)
+		require.NoError(t, err)
+		assert.True(t, skipped)
+		assert.Equal(t, "collector failed", reason)
+		assert.NoFileExists(t, filepath.Join(fullPath, "partial.txt"))
+	})
+}
+
+func Test_baseFileRepository_Skip(t *testing.T) {
+	t.Run("should return error on error removing partial data", func(t *testing.T) {
+		fsMock := newMockVolumeFs(t)
+		fsMock.EXPECT().RemoveAll(mock.Anything, testWorkDirCollectorPath).Return(assert.AnError)
+		l := NewBaseFileRepository(testWorkPath, testCollectorDirName, fsMock)
+
+		err := l.Skip(testCtx, testID, "reason")
+
+		require.Error(t, err)
+		assert.ErrorIs(t, err, assert.AnError)
+		assert.ErrorContains(t, err, "failed to remove partially collected data")
+	})
+	t.Run("should return error on error creating directory", func(t *testing.T) {
+		fsMock := newMockVolumeFs(t)
+		fsMock.EXPECT().RemoveAll(mock.Anything, testWorkDirCollectorPath).Return(nil)
+		fsMock.EXPECT().MkdirAll(mock.Anything, testWorkDirCollectorPath, os.ModePerm).Return(assert.AnError)
+		l := NewBaseFileRepository(testWorkPath, testCollectorDirName, fsMock)
+
+		err := l.Skip(testCtx, testID, "reason")
+
+		require.Error(t, err)
+		assert.ErrorIs(t, err, assert.AnError)
+		assert.ErrorContains(t, err, "failed to create directory")
+	})
+	t.Run("should return error on error writing skipped file", func(t *testing.T) {
+		fsMock := newMockVolumeFs(t)
+		fsMock.EXPECT().RemoveAll(mock.Anything, testWorkDirCollectorPath).Return(nil)
+		fsMock.EXPECT().MkdirAll(mock.Anything, testWorkDirCollectorPath, os.ModePerm).Return(nil)
+		fsMock.EXPECT().WriteFile(mock.Anything, testWorkDirCollectorPath+"/.skipped", []byte("reason"), os.FileMode(0644)).Return(assert.AnError)
+		l := NewBaseFileRepository(testWorkPath, testCollectorDirName, fsMock)
+
+		err := l.Skip(testCtx, testID, "reason")
+
+		require.Error(t, err)
+		assert.ErrorIs(t, err, assert.AnError)
+		assert.ErrorContains(t, err, "failed to write file")
+	})
+}
+
+func Test_baseFileRepository_IsSkipped(t *testing.T) {
+	t.Run("should return error on error opening skipped file", func(t *testing.T) {
+		fsMock := newMockVolumeFs(t)
+		fsMock.EXPECT().Open(mock.Anything, testWorkDirCollectorPath+"/.skipped").Return(nil, assert.AnError)
+		l := NewBaseFileRepository(testWorkPath, testCollectorDirName, fsMock)
+
+		skipped, _, err := l.IsSkipped(testCtx, testID)
+
+		require.Error(t, err)
+		assert.False(t, skipped)
+		assert.ErrorIs(t, err, assert.AnError)
+	})
+	t.Run("should return error on error reading skipped file", func(t *testing.T) {
+		fileMock := newMockClosableRWFile(t)
+		fileMock.EXPECT().Close().Return(nil)
+		fsMock := newMockVolumeFs(t)
+		fsMock.EXPECT().Open(mock.Anything, testWorkDirCollectorPath+"/.skipped").Return(fileMock, nil)
+		fsMock.EXPECT().ReadAll(fileMock).Return(nil, assert.AnError)
+		l := NewBaseFileRepository(testWorkPath, testCollectorDirName, fsMock)
+
+		skipped, _, err := l.IsSkipped(testCtx, testID)
+
+		require.Error(t, err)
+		assert.False(t, skipped)
+		assert.ErrorContains(t, err, "failed to read file")
+	})
+}
+
+func Test_baseFileRepository_SpecHash(t *testing.T) {
+	t.Run("should record spec hash before the collection", func(t *testing.T) {
+		const collectorDir = "collectorDir"
+		workPath := filepath.Join(t.TempDir(), "work")
+		repo := NewBaseFileRepository(workPath, collectorDir, filesystem.FileSystem{})
+
+		hash, err := repo.GetSpecHash(testCtx, testID)
+		require.NoError(t, err)
+		assert.Empty(t, hash)
+
+		err = repo.SetSpecHash(testCtx, testID, "abc123")
+		require.NoError(t, err)
+
+		hash, err = repo.GetSpecHash(testCtx, testID)
+		require.NoError(t, err)
+		assert.Equal(t, "abc123", hash)
+	})
+	t.Run("should return error on error creating collector directory", func(t *testing.T) {
+		fsMock := newMockVolumeFs(t)
+		fsMock.EXPECT().MkdirAll(mock.Anything, testWorkDirCollectorPath, os.ModePerm).Return(assert.AnError)
+		l := NewBaseFileRepository(testWorkPath, testCollectorDirName, fsMock)
+
+		err := l.SetSpecHash(testCtx, testID, "abc123")
+
+		require.ErrorIs(t, err, assert.AnError)
+		assert.ErrorContains(t, err, "failed to create directory")
+	})
+	t.Run("should return error on error writing spec hash file", func(t *testing.T) {
+		fsMock := newMockVolumeFs(t)
+		fsMock.EXPECT().MkdirAll(mock.Anything, testWorkDirCollectorPath, os.ModePerm).Return(nil)
+		fsMock.EXPECT().WriteFile(mock.Anything, testWorkDirCollectorPath+"/.spec-hash", []byte("abc123"), os.FileMode(0644)).Return(assert.AnError)
+		l := NewBaseFileRepository(testWorkPath, testCollectorDirName, fsMock)
+
+		err := l.SetSpecHash(testCtx, testID, "abc123")
+
+		require.Error(t, err)
+		assert.ErrorIs(t, err, assert.AnError)
+		assert.ErrorContains(t, err, "failed to write file")
+	})
+	t.Run("should return error on error opening spec hash file", func(t *testing.T) {
+		fsMock := newMockVolumeFs(t)
+		fsMock.EXPECT().Open(mock.Anything, testWorkDirCollectorPath+"/.spec-hash").Return(nil, assert.AnError)
+		l := NewBaseFileRepository(testWorkPath, testCollectorDirName, fsMock)
+
+		_, err := l.GetSpecHash(testCtx, testID)
+
+		require.Error(t, err)
+		assert.ErrorIs(t, err, assert.AnError)
+	})
+	t.Run("should return error on error reading spec hash file", func(t *testing.T) {
+		fileMock := newMockClosableRWFile(t)
+		fileMock.EXPECT().Close().Return(nil)
+		fsMock := newMockVolumeFs(t)
+		fsMock.EXPECT().Open(mock.Anything, testWorkDirCollectorPath+"/.spec-hash").Return(fileMock, nil)
+		fsMock.EXPECT().ReadAll(fileMock).Return(nil, assert.AnError)
+		l := NewBaseFileRepository(testWorkPath, testCollectorDirName, fsMock)
+
+		_, err := l.GetSpecHash(testCtx, testID)
+
+		require.Error(t, err)
+		assert.ErrorContains(t, err, "failed to read file")
+	})
+}
+
+func Test_baseFileRepository_Failures(t *testing.T) {
+	t.Run("should record failures until the collector is skipped", func(t *testing.T) {
+		const collectorDir = "collectorDir"
+		workPath := filepath.Join(t.TempDir(), "work")
+		repo := NewBaseFileRepository(workPath, collectorDir, filesystem.FileSystem{})
+
+		failures, err := repo.GetFailures(testCtx, testID)
+		require.NoError(t, err)
+		assert.Equal(t, 0, failures)
+
+		err = repo.SetFailures(testCtx, testID, 2)
+		require.NoError(t, err)
+
+		failures, err = repo.GetFailures(testCtx, testID)
+		require.NoError(t, err)
+		assert.Equal(t, 2, failures)
+
+		err = repo.Skip(testCtx, testID, "failed")
+		require.NoError(t, err)
+
+		failures, err = repo.GetFailures(testCtx, testID)
+		require.NoError(t, err)
+		assert.Equal(t, 0, failures)
+	})
+	t.Run("should return error on error creating collector directory", func(t *testing.T) {
+		fsMock := newMockVolumeFs(t)
+		fsMock.EXPECT().MkdirAll(mock.Anything, testWorkDirCollectorPath, os.ModePerm).Return(assert.AnError)
+		l := NewBaseFileRepository(testWorkPath, testCollectorDirName, fsMock)
+
+		err := l.SetFailures(testCtx, testID, 1)
+
+		require.ErrorIs(t, err, assert.AnError)
+		assert.ErrorContains(t, err, "failed to create directory")
+	})
+	t.Run("should return error on error writing failures file", func(t *testing.T) {
+		fsMock := newMockVolumeFs(t)
+		fsMock.EXPECT().MkdirAll(mock.Anything, testWorkDirCollectorPath, os.ModePerm).Return(nil)
+		fsMock.EXPECT().WriteFile(mock.Anything, testWorkDirCollectorPath+"/.failures", []byte("1"), os.FileMode(0644)).Return(assert.AnError)
+		l := NewBaseFileRepository(testWorkPath, testCollectorDirName, fsMock)
+
+		err := l.SetFailures(testCtx, testID, 1)
+
+		require.ErrorIs(t, err, assert.AnError)
+		assert.ErrorContains(t, err, "failed to write file")
+	})
+	t.Run("should return error on error opening failures file", func(t *testing.T) {
+		fsMock := newMockVolumeFs(t)
+		fsMock.EXPECT().Open(mock.Anything, testWorkDirCollectorPath+"/.failures").Return(nil, assert.AnError)
+		l := NewBaseFileRepository(testWorkPath, testCollectorDirName, fsMock)
+
+		_, err := l.GetFailures(testCtx, testID)
+
+		require.ErrorIs(t, err, assert.AnError)
+		assert.ErrorContains(t, err, "failed to open file")
+	})
+	t.Run("should return error on error reading failures file", func(t *testing.T) {
+		fileMock := newMockClosableRWFile(t)
+		fileMock.EXPECT().Close().Return(nil)
+		fsMock := newMockVolumeFs(t)
+		fsMock.EXPECT().Open(mock.Anything, testWorkDirCollectorPath+"/.failures").Return(fileMock, nil)
+		fsMock.EXPECT().ReadAll(fileMock).Return(nil, assert.AnError)
+		l := NewBaseFileRepository(testWorkPath, testCollectorDirName, fsMock)
+
+		_, err := l.GetFailures(testCtx, testID)
+
+		require.ErrorIs(t, err, assert.AnError)
+		assert.ErrorContains(t, err, "failed to read file")
+	})
+	t.Run("should return error on invalid failures file", func(t *testing.T) {
+		fileMock := newMockClosableRWFile(t)
+		fileMock.EXPECT().Close().Return(nil)
+		fsMock := newMockVolumeFs(t)
+		fsMock.EXPECT().Open(mock.Anything, testWorkDirCollectorPath+"/.failures").Return(fileMock, nil)
+		fsMock.EXPECT().ReadAll(fileMock).Return([]byte("invalid"), nil)
+		l := NewBaseFileRepository(testWorkPath, testCollectorDirName, fsMock)
+
+		_, err := l.GetFailures(testCtx, testID)
+
+		require.Error(t, err)
+		assert.ErrorContains(t, err, "failed to parse failures from file")
+	})
 }
 
 func checkError(t *testing.T, err error) {
@@ -0,0 +1,497 @@
+package file
+
+import (
+	"bufio"
+	"context"
+	"encoding/csv"
+	"errors"
+	"fmt"
+	"io"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"slices"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/cloudogu/k8s-support-archive-operator/pkg/domain"
+	"gopkg.in/yaml.v3"
+	"sigs.k8s.io/controller-runtime/pkg/log"
+)
+
+// nodeInfoBuckets is the number of periods the samples of a node metric are reduced to. It matches the width of the
+// summary charts, which show the minimum and maximum per pixel.
+const nodeInfoBuckets = summaryChartWidth
+
+// CollectedDataReader reads the collected data from the work directory to analyze it.
+type CollectedDataReader struct {
+	workPath   string
+	filesystem volumeFs
+}
+
+func NewCollectedDataReader(workPath string, fs volumeFs) *CollectedDataReader {
+	return &CollectedDataReader{
+		workPath:   workPath,
+		filesystem: fs,
+	}
+}
+
+// Read returns the data of the given collectors within their content timeframes. Collectors which are not needed for
+// the analysis are ignored. The files are streamed and large data is aggregated while reading.
+func (r *CollectedDataReader) Read(ctx context.Context, id domain.SupportArchiveID, collectors []domain.CollectorType, timeframes domain.ContentTimeframes) (*domain.CollectedData, error) {
+	logger := log.FromContext(ctx).WithName("CollectedDataReader.Read")
+	archivePath := filepath.Join(r.workPath, id.Namespace, id.Name)
+
+	data := &domain.CollectedData{
+		Start:        timeframes.Default.Start,
+		End:          timeframes.Default.End,
+		LogTimeframe: timeframes.For(domain.CollectorTypeLog),
+	}
+	for _, col := range collectors {
+		var err error
+		switch col {
+		case domain.CollectorTypeVolumeInfo:
+			data.Volumes, err = readVolumeInfos(ctx, r.filesystem, filepath.Join(archivePath, archiveVolumeInfoDirName))
+		case domain.CollectorTypeNodeInfo:
+			data.NodeInfo, err = readNodeInfo(ctx, r.filesystem, filepath.Join(archivePath, archiveNodeInfoDirName))
+		case domain.CollectorTypeSystemState:
+			data.Resources, err = readResourceStatuses(ctx, r.filesystem, filepath.Join(archivePath, archiveSystemStateDirName))
+		case domain.CollectorTypeLog:
+			err = scanLogIncidents(ctx, r.filesystem, filepath.Join(archivePath, archiveLogDirName, logFileName), func(entry *domain.TimelineEntry) error {
+				if entry.Severity == domain.TimelineSeverityError {
+					data.AddErrorLog(entry.Timestamp)
+				}
+				return nil
+			})
+		case domain.CollectorTypeEvents:
+			data.Events, err = readWarningEvents(ctx, r.filesystem, filepath.Join(archivePath, archiveEventsDirName, archiveEventsYamlName))
+		default:
+			continue
+		}
+		if err != nil {
+			return nil, fmt.Errorf("failed to read collected data of collector %s: %w", col, err)
+		}
+	}
+	logger.Info("read collected data", "volumes", len(data.Volumes), "nodeInfoSamples", len(data.NodeInfo), "resources", len(data.Resources), "errorLogMinutes", len(data.ErrorLogsPerMinute), "events", len(data.Events))
+
+	return data, nil
+}
+
+// ReadContent returns the collected system state, secrets, volume info and node metrics to compare them with
+// another archive.
+func (r *CollectedDataReader) ReadContent(ctx context.Context, id domain.SupportArchiveID) (*domain.ArchiveContent, error) {
+	return readArchiveContent(ctx, workDirContentFiles{filesystem: r.filesystem, archivePath: filepath.Join(r.workPath, id.Namespace, id.Name)})
+}
+
+// scanLogIncidents calls fn for every warning and error log line without keeping the log lines in memory.
+// A missing file is ignored.
+func scanLogIncidents(ctx context.Context, filesystem volumeFs, filePath string, fn func(entry *domain.TimelineEntry) error) error {
+	file, err := filesystem.Open(ctx, filePath)
+	if err != nil && os.IsNotExist(err) {
+		return nil
+	} else if err != nil {
+		return fmt.Errorf("failed to open file %s: %w", filePath, err)
+	}
+	defer func() {
+		_ = file.Close()
+	}()
+
+	scanner := bufio.NewScanner(file)
+	scanner.Buffer(make([]byte, 0, 64*1024), maxLogLineSize)
+	for scanner.Scan() {
+		entry := logLineToTimelineEntry(scanner.Text())
+		if entry == nil {
+			continue
+		}
+		err = fn(entry)
+		if err != nil {
+			return err
+		}
+	}
+	if scanner.Err() != nil {
+		return fmt.Errorf("failed to read file %s: %w", filePath, scanner.Err())
+	}
+
+	return nil
+}
+
+func readEvents(ctx context.Context, filesystem volumeFs, filePath string) ([]*domain.Event, error) {
+	var events []*domain.Event
+	_, err := readYAMLFile(ctx, filesystem, filePath, &events)
+	if err != nil {
+		return nil, err
+	}
+
+	return events, nil
+}
+
+func readWarningEvents(ctx context.Context, filesystem volumeFs, filePath string) ([]*domain.Event, error) {
+	events, err := readEvents(ctx, filesystem, filePath)
+	if err != nil {
+		return nil, err
+	}
+
+	return slices.DeleteFunc(events, func(event *domain.Event) bool {
+		return event.Type != "Warning"
+	}), nil
+}
+
+func readVolumeInfos(ctx context.Context, filesystem volumeFs, dirPath string) ([]domain.VolumeInfo, error) {
+	var volumes []domain.VolumeInfo
+	err := walkFiles(ctx, filesystem, dirPath, ".yaml", func(path string) error {
+		var volume domain.VolumeInfo
+		_, err := readYAMLFile(ctx, filesystem, path, &volume)
+		if err != nil {
+			return err
+		}
+		volumes = append(volumes, volume)
+
+		return nil
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to read volume info in %s: %w", dirPath, err)
+	}
+
+	return volumes, nil
+}
+
+// readNodeInfo reads the samples of all node metrics. The metric name is the name of the csv file.
+// The samples of every node and metric are reduced to the minimum and maximum of nodeInfoBuckets periods.
+func readNodeInfo(ctx context.Context, filesystem volumeFs, dirPath string) ([]domain.LabeledSample, error) {
+	var samples []domain.LabeledSample
+	err := walkFiles(ctx, filesystem, dirPath, ".csv", func(path string) error {
+		metricSamples, err := readNodeInfoFile(ctx, filesystem, path)
+		if err != nil {
+			return err
+		}
+		samples = append(samples, metricSamples...)
+
+		return nil
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to read node info in %s: %w", dirPath, err)
+	}
+
+	return samples, nil
+}
+
+// readNodeInfoFile reads the file twice: first to find the timeframe of the samples and then to reduce them.
+func readNodeInfoFile(ctx context.Context, filesystem volumeFs, filePath string) ([]domain.LabeledSample, error) {
+	var start, end time.Time
+	err := scanNodeInfoFile(ctx, filesystem, filePath, func(sample domain.LabeledSample) {
+		if start.IsZero() || sample.Time.Before(start) {
+			start = sample.Time
+		}
+		if sample.Time.After(end) {
+			end = sample.Time
+		}
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	reducer := newSampleReducer(start, end, nodeInfoBuckets)
+	err = scanNodeInfoFile(ctx, filesystem, filePath, reducer.add)
+	if err != nil {
+		return nil, err
+	}
+
+	return reducer.samples(), nil
+}
+
+func scanNodeInfoFile(ctx context.Context, filesystem volumeFs, filePath string, fn func(sample domain.LabeledSample)) error {
+	file, err := filesystem.Open(ctx, filePath)
+	if err != nil {
+		return fmt.Errorf("failed to open file %s: %w", filePath, err)
+	}
+	defer func() {
+		_ = file.Close()
+	}()
+
+	err = scanNodeInfoCSV(file, strings.TrimSuffix(filepath.Base(filePath), ".csv"), fn)
+	if err != nil {
+		return fmt.Errorf("failed to read csv file %s: %w", filePath, err)
+	}
+
+	return nil
+}
+
+// parseNodeInfoCSV parses the samples of a metric. Records with an invalid value or time are ignored.
+func parseNodeInfoCSV(reader io.Reader, metricName string) ([]domain.LabeledSample, error) {
+	var samples []domain.LabeledSample
+	err := scanNodeInfoCSV(reader, metricName, func(sample domain.LabeledSample) {
+		samples = append(samples, sample)
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return samples, nil
+}
+
+// scanNodeInfoCSV calls fn for every sample of a metric without reading the whole file into memory.
+// Records with an invalid value or time are ignored.
+func scanNodeInfoCSV(reader io.Reader, metricName string, fn func(sample domain.LabeledSample)) error {
+	csvReader := csv.NewReader(reader)
+	csvReader.ReuseRecord = true
+	// The first record is the header.
+	header := true
+	for {
+		record, err := csvReader.Read()
+		if errors.Is(err, io.EOF) {
+			return nil
+		} else if err != nil {
+			return err
+		}
+
+		if header {
+			header = false
+			continue
+		}
+		if len(record) != 3 {
+			continue
+		}
+		value, valueErr := strconv.ParseFloat(record[1], 64)
+		timestamp, timeErr := time.Parse(time.RFC3339, record[2])
+		if valueErr != nil || timeErr != nil {
+			continue
+		}
+		fn(domain.LabeledSample{MetricName: metricName, ID: record[0], Value: value, Time: timestamp})
+	}
+}
+
+// sampleExtremes contains the minimum and maximum sample of a period.
+type sampleExtremes struct {
+	min, max domain.LabeledSample
+	set      bool
+}
+
+// sampleReducer keeps the minimum and maximum sample per node for a fixed number of periods of the timeframe.
+type sampleReducer struct {
+	start    time.Time
+	duration time.Duration
+	buckets  int
+	extremes map[string][]sampleExtremes
+}
+
+func newSampleReducer(start, end time.Time, buckets int) *sampleReducer {
+	return &sampleReducer{
+		start:    start,
+		duration: end.Sub(start),
+		buckets:  buckets,
+		extremes: make(map[string][]sampleExtremes),
+	}
+}
+
+func (r *sampleReducer) add(sample domain.LabeledSample) {
+	bucket := 0
+	if r.duration > 0 {
+		bucket = min(int(float64(sample.Time.Sub(r.start))/float64(r.duration)*float64(r.buckets)), r.buckets-1)
+	}
+
+	nodeExtremes, ok := r.extremes[sample.ID]
+	if !ok {
+		nodeExtremes = make([]sampleExtremes, r.buckets)
+		r.extremes[sample.ID] = nodeExtremes
+	}
+
+	extremes := &nodeExtremes[max(bucket, 0)]
+	if !extremes.set {
+		*extremes = sampleExtremes{min: sample, max: sample, set: true}
+		return
+	}
+	if sample.Value < extremes.min.Value {
+		extremes.min = sample
+	}
+	// the latest maximum is kept, so that constant values keep the first and last sample of the period
+	if sample.Value >= extremes.max.Value {
+		extremes.max = sample
+	}
+}
+
+// samples returns the extremes of every node in chronological order.
+func (r *sampleReducer) samples() []domain.LabeledSample {
+	var samples []domain.LabeledSample
+	for _, node := range sortedKeys(r.extremes) {
+		for _, extremes := range r.extremes[node] {
+			if !extremes.set {
+				continue
+			}
+
+			first, second := extremes.min, extremes.max
+			if second.Time.Before(first.Time) {
+				first, second = second, first
+			}
+			samples = append(samples, first)
+			if second != first {
+				samples = append(samples, second)
+			}
+		}
+	}
+
+	return samples
+}
+
+func readResourceStatuses(ctx context.Context, filesystem volumeFs, dirPath string) ([]domain.ResourceStatus, error) {
+	var resources []domain.ResourceStatus
+	err := walkFiles(ctx, filesystem, dirPath, ".yaml", func(path string) error {
+		var resource domain.UnstructuredResource
+		_, err := readYAMLFile(ctx, filesystem, path, &resource)
+		if err != nil {
+			return err
+		}
+		relativePath, err := filepath.Rel(dirPath, path)
+		if err != nil {
+			return fmt.Errorf("failed to get relative path of %s: %w", path, err)
+		}
+		status := toResourceStatus(resource.Content)
+		status.Path = filepath.ToSlash(filepath.Join(archiveSystemStateDirName, relativePath))
+		resources = append(resources, status)
+
+		return nil
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to read system state in %s: %w", dirPath, err)
+	}
+
+	return resources, nil
+}
+
+func toResourceStatus(content map[string]any) domain.ResourceStatus {
+	metadata, _ := content["metadata"].(map[string]any)
+	status, _ := content["status"].(map[string]any)
+	resource := domain.ResourceStatus{
+		Kind:      stringField(content, "kind"),
+		Namespace: stringField(metadata, "namespace"),
+		Name:      stringField(metadata, "name"),
+		Phase:     stringField(status, "phase"),
+	}
+
+	for _, condition := range mapSlice(status["conditions"]) {
+		lastTransitionTime, _ := timeField(condition, "lastTransitionTime")
+		resource.Conditions = append(resource.Conditions, domain.ResourceCondition{
+			Type:               stringField(condition, "type"),
+			Status:             stringField(condition, "status"),
+			Reason:             stringField(condition, "reason"),
+			Message:            stringField(condition, "message"),
+			LastTransitionTime: lastTransitionTime,
+		})
+	}
+
+	containerStatuses := append(mapSlice(status["initContainerStatuses"]), mapSlice(status["containerStatuses"])...)
+	for _, containerStatus := range containerStatuses {
+		container := domain.ContainerStatus{
+			Name:         stringField(containerStatus, "name"),
+			RestartCount: intField(containerStatus, "restartCount"),
+		}
+		for _, stateField := range []string{"state", "lastState"} {
+			state, _ := containerStatus[stateField].(map[string]any)
+			terminated, ok := state["terminated"].(map[string]any)
+			if !ok {
+				continue
+			}
+
+			finishedAt, _ := timeField(terminated, "finishedAt")
+			container.Terminations = append(container.Terminations, domain.ContainerTermination{
+				ExitCode:   intField(terminated, "exitCode"),
+				Reason:     stringField(terminated, "reason"),
+				Message:    stringField(terminated, "message"),
+				FinishedAt: finishedAt,
+			})
+		}
+		resource.Containers = append(resource.Containers, container)
+	}
+
+	return resource
+}
+
+// walkFiles calls fn for every file with the given extension in the directory. A missing directory is ignored.
+func walkFiles(ctx context.Context, filesystem volumeFs, dirPath, extension string, fn func(path string) error) error {
+	return filesystem.WalkDir(ctx, dirPath, func(path string, info fs.DirEntry, err error) error {
+		if err != nil && os.IsNotExist(err) && path == dirPath {
+			return fs.SkipDir
+		} else if err != nil {
+			return err
+		}
+
+		if info.IsDir() || filepath.Ext(path) != extension {
+			return nil
+		}
+
+		return fn(path)
+	})
+}
+
+// readYAMLFile decodes the file into out. It returns false if the file does not exist.
+func readYAMLFile(ctx context.Context, filesystem volumeFs, filePath string, out any) (bool, error) {
+	file, err := filesystem.Open(ctx, filePath)
+	if err != nil && os.IsNotExist(err) {
+		return false, nil
+	} else if err != nil {
+		return false, fmt.Errorf("failed to open file %s: %w", filePath, err)
+	}
+	defer func() {
+		_ = file.Close()
+	}()
+
+	err = yaml.NewDecoder(file).Decode(out)
+	if err != nil && !errors.Is(err, io.EOF) {
+		return false, fmt.Errorf("failed to decode file %s: %w", filePath, err)
+	}
+
+	return true, nil
+}
+
+func firstStringField(fields map[string]any, names []string) string {
+	for _, name := range names {
+		if value := stringField(fields, name); value != "" {
+			return value
+		}
+	}
+
+	return ""
+}
+
+func stringField(fields map[string]any, name string) string {
+	value, _ := fields[name].(string)
+	return value
+}
+
+// timeField accepts RFC3339 strings as well as already decoded timestamps.
+func timeField(fields map[string]any, name string) (time.Time, bool) {
+	switch value := fields[name].(type) {
+	case time.Time:
+		return value, true
+	case string:
+		timestamp, err := time.Parse(time.RFC3339, value)
+		return timestamp, err == nil
+	default:
+		return time.Time{}, false
+	}
+}
+
+func mapSlice(value any) []map[string]any {
+	list, _ := value.([]any)
+	result := make([]map[string]any, 0, len(list))
+	for _, element := range list {
+		if m, ok := element.(map[string]any); ok {
+			result = append(result, m)
+		}
+	}
+
+	return result
+}
+
+func intField(fields map[string]any, name string) int {
+	switch value := fields[name].(type) {
+	case int:
+		return value
+	case int64:
+		return int(value)
+	case float64:
+		return int(value)
+	default:
+		return 0
+	}
+}
@@ -0,0 +1,183 @@
+package file
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"slices"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/cloudogu/k8s-support-archive-operator/pkg/adapter/filesystem"
+	"github.com/cloudogu/k8s-support-archive-operator/pkg/domain"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+const testVolumeInfoYaml = `name: volumeInfo
+timestamp: 2025-09-01T10:00:00Z
+items:
+  - name: ldap
+    capacity: 100
+    used: 93
+    percentageUsage: "93.00%"
+    inodesUsed: 10
+    phase: Bound
+`
+
+func TestNewCollectedDataReader(t *testing.T) {
+	// given
+	fsMock := newMockVolumeFs(t)
+
+	// when
+	reader := NewCollectedDataReader(testWorkPath, fsMock)
+
+	// then
+	require.NotNil(t, reader)
+	assert.Equal(t, testWorkPath, reader.workPath)
+	assert.Equal(t, fsMock, reader.filesystem)
+}
+
+func TestCollectedDataReader_Read(t *testing.T) {
+	writeTestFile := func(t *testing.T, path, content string) {
+		require.NoError(t, os.MkdirAll(filepath.Dir(path), 0755))
+		require.NoError(t, os.WriteFile(path, []byte(content), 0644))
+	}
+
+	testTimeframe := domain.Timeframe{Start: time.Date(2025, 9, 1, 10, 0, 0, 0, time.UTC), End: time.Date(2025, 9, 1, 11, 0, 0, 0, time.UTC)}
+	testTimeframes := domain.ContentTimeframes{Default: testTimeframe}
+
+	t.Run("should read data of all collectors", func(t *testing.T) {
+		// given
+		workPath := t.TempDir()
+		archivePath := filepath.Join(workPath, testNamespace, testName)
+		writeTestFile(t, filepath.Join(archivePath, "VolumeInfo", "volumeInfo.yaml"), testVolumeInfoYaml)
+		writeTestFile(t, filepath.Join(archivePath, "NodeInfo", "ramUsedRelative.csv"), "label,value,time\nnode-1,91.50,2025-09-01T10:00:00+00:00\nnode-1,invalid,2025-09-01T10:00:00+00:00\n")
+		writeTestFile(t, filepath.Join(archivePath, "NodeInfo", ".done"), "done")
+		writeTestFile(t, filepath.Join(archivePath, "Resources", "SystemState", "core", "v1", "Pod", "ldap-0.yaml"), testTimelineSystemStatePod)
+		writeTestFile(t, filepath.Join(archivePath, "Events", "events.yaml"), "- namespace: ecosystem\n  involvedObject: {kind: Pod, name: ldap-0}\n  type: Warning\n  reason: BackOff\n  count: 2\n- type: Normal\n  reason: Pulled\n")
+		writeTestFile(t, filepath.Join(archivePath, "Logs", "logs.log"), "LOGS\n"+`{"level":"error","msg":"connection refused","time_unix_nano":"1756720801000000000"}`+"\n"+`{"level":"warn","msg":"slow","time_unix_nano":"1756720802000000000"}`+"\n"+`{"level":"error","msg":"timeout","time_unix_nano":"1756720921000000000"}`+"\n")
+		sut := NewCollectedDataReader(workPath, filesystem.FileSystem{})
+
+		// when
+		data, err := sut.Read(testCtx, testID, []domain.CollectorType{domain.CollectorTypeVolumeInfo, domain.CollectorTypeNodeInfo, domain.CollectorTypeSystemState, domain.CollectorTypeLog, domain.CollectorTypeEvents, domain.CollectorTypeSecret}, testTimeframes)
+
+		// then
+		require.NoError(t, err)
+		require.Len(t, data.Volumes, 1)
+		assert.Equal(t, int64(93), data.Volumes[0].Items[0].Used)
+		require.Len(t, data.NodeInfo, 1)
+		assert.Equal(t, "ramUsedRelative", data.NodeInfo[0].MetricName)
+		assert.Equal(t, "node-1", data.NodeInfo[0].ID)
+		assert.Equal(t, 91.5, data.NodeInfo[0].Value)
+		assert.True(t, time.Date(2025, 9, 1, 10, 0, 0, 0, time.UTC).Equal(data.NodeInfo[0].Time))
+		require.Len(t, data.Resources, 1)
+		assert.Equal(t, domain.ResourceStatus{
+			Path:      "Resources/SystemState/core/v1/Pod/ldap-0.yaml",
+			Kind:      "Pod",
+			Namespace: "ecosystem",
+			Name:      "ldap-0",
+			Conditions: []domain.ResourceCondition{
+				{Type: "Ready", Status: "False", Reason: "ContainersNotReady", LastTransitionTime: time.Date(2025, 9, 1, 10, 0, 3, 0, time.UTC)},
+			},
+			Containers: []domain.ContainerStatus{
+				{Name: "ldap", Terminations: []domain.ContainerTermination{{ExitCode: 137, Reason: "OOMKilled", FinishedAt: time.Date(2025, 9, 1, 10, 0, 2, 0, time.UTC)}}},
+			},
+		}, data.Resources[0])
+		assert.Equal(t, testTimeframe.Start, data.Start)
+		assert.Equal(t, testTimeframe.End, data.End)
+		assert.Equal(t, []int{1, 0, 1}, data.ErrorLogsPerMinute)
+		require.Len(t, data.Events, 1)
+		assert.Equal(t, "BackOff", data.Events[0].Reason)
+		assert.Equal(t, 2, data.Events[0].Count)
+	})
+	t.Run("should count error logs within the timeframe of the logs", func(t *testing.T) {
+		// given
+		workPath := t.TempDir()
+		archivePath := filepath.Join(workPath, testNamespace, testName)
+		writeTestFile(t, filepath.Join(archivePath, "Logs", "logs.log"), "LOGS\n"+`{"level":"error","msg":"connection refused","time_unix_nano":"1756719000000000000"}`+"\n"+`{"level":"error","msg":"timeout","time_unix_nano":"1756720801000000000"}`+"\n")
+		logTimeframe := domain.Timeframe{Start: testTimeframe.Start.Add(-time.Hour), End: testTimeframe.End}
+		timeframes := domain.ContentTimeframes{Default: testTimeframe, Collectors: map[domain.CollectorType]domain.Timeframe{domain.CollectorTypeLog: logTimeframe}}
+		sut := NewCollectedDataReader(workPath, filesystem.FileSystem{})
+
+		// when
+		data, err := sut.Read(testCtx, testID, []domain.CollectorType{domain.CollectorTypeLog}, timeframes)
+
+		// then
+		require.NoError(t, err)
+		assert.Equal(t, testTimeframe.Start, data.Start)
+		assert.Equal(t, logTimeframe, data.LogTimeframe)
+		require.Len(t, data.ErrorLogsPerMinute, 61)
+		assert.Equal(t, 1, data.ErrorLogsPerMinute[30])
+		assert.Equal(t, 1, data.ErrorLogsPerMinute[60])
+	})
+
+	t.Run("should reduce node info samples to the extremes per period", func(t *testing.T) {
+		// given
+		workPath := t.TempDir()
+		content := strings.Builder{}
+		content.WriteString("label,value,time\n")
+		for i := 0; i < 7*24*60; i++ {
+			value := "50.00"
+			if i == 5000 {
+				value = "99.00"
+			}
+			content.WriteString(fmt.Sprintf("node-1,%s,%s\n", value, testTimeframe.Start.Add(time.Duration(i)*time.Minute).Format(time.RFC3339)))
+		}
+		writeTestFile(t, filepath.Join(workPath, testNamespace, testName, "NodeInfo", "ramUsedRelative.csv"), content.String())
+		sut := NewCollectedDataReader(workPath, filesystem.FileSystem{})
+
+		// when
+		data, err := sut.Read(testCtx, testID, []domain.CollectorType{domain.CollectorTypeNodeInfo}, testTimeframes)
+
+		// then
+		require.NoError(t, err)
+		assert.LessOrEqual(t, len(data.NodeInfo), 2*nodeInfoBuckets)
+		assert.True(t, slices.IsSortedFunc(data.NodeInfo, func(a, b domain.LabeledSample) int {
+			return a.Time.Compare(b.Time)
+		}))
+		assert.Contains(t, data.NodeInfo, domain.LabeledSample{MetricName: "ramUsedRelative", ID: "node-1", Value: 99, Time: testTimeframe.Start.Add(5000 * time.Minute)})
+		assert.Equal(t, testTimeframe.Start, data.NodeInfo[0].Time)
+		assert.Equal(t, testTimeframe.Start.Add((7*24*60-1)*time.Minute), data.NodeInfo[len(data.NodeInfo)-1].Time)
+	})
+
+	t.Run("should return empty data if nothing was collected", func(t *testing.T) {
+		// given
+		sut := NewCollectedDataReader(t.TempDir(), filesystem.FileSystem{})
+
+		// when
+		data, err := sut.Read(testCtx, testID, []domain.CollectorType{domain.CollectorTypeVolumeInfo, domain.CollectorTypeNodeInfo, domain.CollectorTypeSystemState, domain.CollectorTypeLog}, domain.ContentTimeframes{})
+
+		// then
+		require.NoError(t, err)
+		assert.Equal(t, &domain.CollectedData{}, data)
+	})
+
+	t.Run("should return error on invalid volume info", func(t *testing.T) {
+		// given
+		workPath := t.TempDir()
+		writeTestFile(t, filepath.Join(workPath, testNamespace, testName, "VolumeInfo", "volumeInfo.yaml"), "items: [")
+		sut := NewCollectedDataReader(workPath, filesystem.FileSystem{})
+
+		// when
+		_, err := sut.Read(testCtx, testID, []domain.CollectorType{domain.CollectorTypeVolumeInfo}, testTimeframes)
+
+		// then
+		require.Error(t, err)
+		assert.ErrorContains(t, err, "failed to read collected data of collector VolumeInfo")
+	})
+}
+
+func Test_timeField(t *testing.T) {
+	timestamp := time.Date(2025, 9, 1, 10, 0, 0, 0, time.UTC)
+
+	parsed, ok := timeField(map[string]any{"a": "2025-09-01T10:00:00Z", "b": timestamp, "c": 1}, "a")
+	assert.True(t, ok)
+	assert.Equal(t, timestamp, parsed)
+	parsed, ok = timeField(map[string]any{"b": timestamp}, "b")
+	assert.True(t, ok)
+	assert.Equal(t, timestamp, parsed)
+	_, ok = timeField(map[string]any{"c": 1}, "c")
+	assert.False(t, ok)
+}
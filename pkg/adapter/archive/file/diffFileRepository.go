@@ -0,0 +1,109 @@
+package file
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"path/filepath"
+	"strconv"
+	"strings"
+
+	"github.com/cloudogu/k8s-support-archive-operator/pkg/domain"
+	"sigs.k8s.io/controller-runtime/pkg/log"
+)
+
+const (
+	// archiveDiffDirName is only used in the work directory. The diff is placed in the root of the archive.
+	archiveDiffDirName = "Diff"
+)
+
+// DiffFileRepository writes the differences to another archive as markdown and json.
+type DiffFileRepository struct {
+	baseFileRepo
+	workPath   string
+	filesystem volumeFs
+}
+
+func NewDiffFileRepository(workPath string, fs volumeFs) *DiffFileRepository {
+	return &DiffFileRepository{
+		workPath:     workPath,
+		filesystem:   fs,
+		baseFileRepo: NewBaseFileRepository(workPath, archiveDiffDirName, fs),
+	}
+}
+
+// Create writes the diff. An existing diff is overwritten.
+func (d *DiffFileRepository) Create(ctx context.Context, id domain.SupportArchiveID, diff *domain.ArchiveDiff) error {
+	logger := log.FromContext(ctx).WithName("DiffFileRepository.Create")
+	dirPath := filepath.Join(d.workPath, id.Namespace, id.Name, archiveDiffDirName)
+	err := d.filesystem.MkdirAll(ctx, dirPath, 0755)
+	if err != nil {
+		return fmt.Errorf("failed to create directory %s: %w", dirPath, err)
+	}
+
+	out, err := json.MarshalIndent(diff, "", "  ")
+	if err != nil {
+		return fmt.Errorf("failed to marshal diff: %w", err)
+	}
+	jsonPath := filepath.Join(dirPath, domain.DiffJSONFileName)
+	err = d.filesystem.WriteFile(ctx, jsonPath, out, 0644)
+	if err != nil {
+		return fmt.Errorf("failed to write file %s: %w", jsonPath, err)
+	}
+
+	markdownPath := filepath.Join(dirPath, domain.DiffMarkdownFileName)
+	err = d.filesystem.WriteFile(ctx, markdownPath, []byte(toDiffMarkdown(id, diff)), 0644)
+	if err != nil {
+		return fmt.Errorf("failed to write file %s: %w", markdownPath, err)
+	}
+	logger.Info("created diff", "reference", diff.Reference, "resources", len(diff.Resources), "secrets", len(diff.Secrets), "volumes", len(diff.Volumes))
+
+	return nil
+}
+
+func toDiffMarkdown(id domain.SupportArchiveID, diff *domain.ArchiveDiff) string {
+	var sb strings.Builder
+	_, _ = fmt.Fprintf(&sb, "# Differences of support archive %s/%s to %s\n", id.Namespace, id.Name, diff.Reference)
+
+	if diff.IsEmpty() {
+		sb.WriteString("\nNo differences were found in the system state, secrets, volumes and nodes.\n")
+		return sb.String()
+	}
+
+	if len(diff.Resources) > 0 {
+		sb.WriteString("\n## Resources\n\n| Change | Resource | Fields |\n|---|---|---|\n")
+		for _, resource := range diff.Resources {
+			_, _ = fmt.Fprintf(&sb, "| %s | %s | %s |\n", resource.Change, escapeMarkdownCell(resource.Path), escapeMarkdownCell(strings.Join(resource.Fields, ", ")))
+		}
+	}
+
+	if len(diff.Secrets) > 0 {
+		sb.WriteString("\n## Secrets\n\n| Change | Secret | Added keys | Removed keys |\n|---|---|---|---|\n")
+		for _, secret := range diff.Secrets {
+			_, _ = fmt.Fprintf(&sb, "| %s | %s | %s | %s |\n", secret.Change, escapeMarkdownCell(secret.Path),
+				escapeMarkdownCell(strings.Join(secret.AddedKeys, ", ")), escapeMarkdownCell(strings.Join(secret.RemovedKeys, ", ")))
+		}
+	}
+
+	if len(diff.Volumes) > 0 {
+		sb.WriteString("\n## Volumes\n\n| Change | Volume | Used before | Used after | Capacity before | Capacity after |\n|---|---|---|---|---|---|\n")
+		for _, volume := range diff.Volumes {
+			_, _ = fmt.Fprintf(&sb, "| %s | %s | %s | %s | %s | %s |\n", volume.Change, escapeMarkdownCell(volume.Name),
+				domain.FormatBytes(volume.UsedBefore), domain.FormatBytes(volume.UsedAfter),
+				domain.FormatBytes(volume.CapacityBefore), domain.FormatBytes(volume.CapacityAfter))
+		}
+	}
+
+	if len(diff.Capacity) > 0 || diff.Nodes.Before != diff.Nodes.After {
+		_, _ = fmt.Fprintf(&sb, "\n## Nodes\n\nNumber of nodes: %d → %d\n", diff.Nodes.Before, diff.Nodes.After)
+	}
+	if len(diff.Capacity) > 0 {
+		sb.WriteString("\n| Change | Node | Metric | Before | After |\n|---|---|---|---|---|\n")
+		for _, capacity := range diff.Capacity {
+			_, _ = fmt.Fprintf(&sb, "| %s | %s | %s | %s | %s |\n", capacity.Change, escapeMarkdownCell(capacity.Node), capacity.Metric,
+				strconv.FormatFloat(capacity.Before, 'f', -1, 64), strconv.FormatFloat(capacity.After, 'f', -1, 64))
+		}
+	}
+
+	return sb.String()
+}
@@ -0,0 +1,97 @@
+package file
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/cloudogu/k8s-support-archive-operator/pkg/adapter/filesystem"
+	"github.com/cloudogu/k8s-support-archive-operator/pkg/domain"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+)
+
+func TestNewDiffFileRepository(t *testing.T) {
+	// given
+	fsMock := newMockVolumeFs(t)
+
+	// when
+	repository := NewDiffFileRepository(testWorkPath, fsMock)
+
+	// then
+	assert.NotNil(t, repository)
+	assert.Equal(t, testWorkPath, repository.workPath)
+	assert.Equal(t, fsMock, repository.filesystem)
+	assert.NotEmpty(t, repository.baseFileRepo)
+}
+
+func TestDiffFileRepository_Create(t *testing.T) {
+	t.Run("should write diff as json and markdown", func(t *testing.T) {
+		// given
+		workPath := t.TempDir()
+		sut := NewDiffFileRepository(workPath, filesystem.FileSystem{})
+		diff := &domain.ArchiveDiff{
+			Reference: "archive-1",
+			Resources: []domain.ResourceDiff{{Path: "Resources/SystemState/apps/v1/Deployment/ldap.yaml", Change: domain.DiffChangeChanged, Fields: []string{"spec.replicas"}}},
+			Secrets:   []domain.SecretDiff{{Path: "Resources/Secrets/ldap.yaml", Change: domain.DiffChangeChanged, AddedKeys: []string{"admin"}, RemovedKeys: []string{"password"}}},
+			Volumes:   []domain.VolumeUsageDelta{{Name: "ldap-data", Change: domain.DiffChangeChanged, UsedBefore: 1024, UsedAfter: 2048, CapacityBefore: 4096, CapacityAfter: 4096}},
+			Nodes:     domain.NodeCountDelta{Before: 2, After: 3},
+			Capacity:  []domain.NodeCapacityDelta{{Node: "node-3", Metric: "cpuCores", Change: domain.DiffChangeAdded, After: 4}},
+		}
+
+		// when
+		err := sut.Create(testCtx, testID, diff)
+
+		// then
+		require.NoError(t, err)
+		jsonContent, err := os.ReadFile(filepath.Join(workPath, testNamespace, testName, "Diff", "diff.json"))
+		require.NoError(t, err)
+		assert.JSONEq(t, `{
+			"reference": "archive-1",
+			"resources": [{"path": "Resources/SystemState/apps/v1/Deployment/ldap.yaml", "change": "changed", "fields": ["spec.replicas"]}],
+			"secrets": [{"path": "Resources/Secrets/ldap.yaml", "change": "changed", "addedKeys": ["admin"], "removedKeys": ["password"]}],
+			"volumes": [{"name": "ldap-data", "change": "changed", "usedBefore": 1024, "usedAfter": 2048, "capacityBefore": 4096, "capacityAfter": 4096}],
+			"nodes": {"before": 2, "after": 3},
+			"capacity": [{"node": "node-3", "metric": "cpuCores", "change": "added", "before": 0, "after": 4}]
+		}`, string(jsonContent))
+		markdownContent, err := os.ReadFile(filepath.Join(workPath, testNamespace, testName, "Diff", "diff.md"))
+		require.NoError(t, err)
+		assert.Equal(t, "# Differences of support archive ecosystem/archive-123 to archive-1\n"+
+			"\n## Resources\n\n| Change | Resource | Fields |\n|---|---|---|\n| changed | Resources/SystemState/apps/v1/Deployment/ldap.yaml | spec.replicas |\n"+
+			"\n## Secrets\n\n| Change | Secret | Added keys | Removed keys |\n|---|---|---|---|\n| changed | Resources/Secrets/ldap.yaml | admin | password |\n"+
+			"\n## Volumes\n\n| Change | Volume | Used before | Used after | Capacity before | Capacity after |\n|---|---|---|---|---|---|\n| changed | ldap-data | 1.0 KiB | 2.0 KiB | 4.0 KiB | 4.0 KiB |\n"+
+			"\n## Nodes\n\nNumber of nodes: 2 → 3\n"+
+			"\n| Change | Node | Metric | Before | After |\n|---|---|---|---|---|\n| added | node-3 | cpuCores | 0 | 4 |\n", string(markdownContent))
+	})
+
+	t.Run("should write report without differences", func(t *testing.T) {
+		// given
+		workPath := t.TempDir()
+		sut := NewDiffFileRepository(workPath, filesystem.FileSystem{})
+
+		// when
+		err := sut.Create(testCtx, testID, &domain.ArchiveDiff{Reference: "archive-1"})
+
+		// then
+		require.NoError(t, err)
+		markdownContent, err := os.ReadFile(filepath.Join(workPath, testNamespace, testName, "Diff", "diff.md"))
+		require.NoError(t, err)
+		assert.Contains(t, string(markdownContent), "No differences were found in the system state, secrets, volumes and nodes.")
+	})
+
+	t.Run("should return error if directory cannot be created", func(t *testing.T) {
+		// given
+		fsMock := newMockVolumeFs(t)
+		fsMock.EXPECT().MkdirAll(mock.Anything, filepath.Join(testWorkPath, testNamespace, testName, "Diff"), os.FileMode(0755)).Return(assert.AnError)
+		sut := NewDiffFileRepository(testWorkPath, fsMock)
+
+		// when
+		err := sut.Create(testCtx, testID, &domain.ArchiveDiff{})
+
+		// then
+		require.Error(t, err)
+		assert.ErrorIs(t, err, assert.AnError)
+		assert.ErrorContains(t, err, "failed to create directory")
+	})
+}
@@ -1,15 +1,167 @@
 package file
 
+import (
+	"context"
+	"encoding/csv"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/cloudogu/k8s-support-archive-operator/pkg/domain"
+	"gopkg.in/yaml.v3"
+	"sigs.k8s.io/controller-runtime/pkg/log"
+)
+
 const (
-	archiveEventsDirName = "Events"
+	archiveEventsDirName  = "Events"
+	archiveEventsYamlName = "events.yaml"
+	archiveEventsCsvName  = "events.csv"
 )
 
+var eventsCsvHeader = []string{"lastTimestamp", "firstTimestamp", "type", "reason", "involvedObject", "namespace", "count", "reportingController", "note", "sources"}
+
+type eventFiles struct {
+	yaml closableRWFile
+	csv  closableRWFile
+}
+
+// EventFileRepository writes the events as a yaml list and as csv.
+// The events are written in the order they are received, so the collector is responsible for sorting them.
 type EventFileRepository struct {
-	*SingleLogFileRepository
+	baseFileRepo
+	workPath   string
+	filesystem volumeFs
+	files      map[domain.SupportArchiveID]*eventFiles
+	// filesMu guards files, because different archives are collected concurrently.
+	filesMu sync.Mutex
+}
+
+func NewEventFileRepository(workPath string, fs volumeFs) *EventFileRepository {
+	return &EventFileRepository{
+		workPath:     workPath,
+		filesystem:   fs,
+		baseFileRepo: NewBaseFileRepository(workPath, archiveEventsDirName, fs),
+		files:        make(map[domain.SupportArchiveID]*eventFiles),
+	}
+}
+
+func (e *EventFileRepository) Create(ctx context.Context, id domain.SupportArchiveID, dataStream <-chan *domain.Event) error {
+	return create(ctx, id, dataStream, e.createEvent, e.Delete, e.finishCollection, e.close)
+}
+
+func (e *EventFileRepository) createEvent(ctx context.Context, id domain.SupportArchiveID, data *domain.Event) error {
+	files, err := e.getOrOpenFiles(ctx, id)
+	if err != nil {
+		return err
+	}
+
+	// Marshalling a list with a single element appends the event as element to the yaml list in the file.
+	out, err := yaml.Marshal([]*domain.Event{data})
+	if err != nil {
+		return fmt.Errorf("failed to marshal event: %w", err)
+	}
+	_, err = files.yaml.Write(out)
+	if err != nil {
+		return fmt.Errorf("failed to write event to yaml file: %w", err)
+	}
+
+	err = writeCsvRecord(files.csv, toEventCsvRecord(data))
+	if err != nil {
+		return fmt.Errorf("failed to write event to csv file: %w", err)
+	}
+
+	return nil
+}
+
+func (e *EventFileRepository) getOrOpenFiles(ctx context.Context, id domain.SupportArchiveID) (*eventFiles, error) {
+	e.filesMu.Lock()
+	defer e.filesMu.Unlock()
+
+	if e.files[id] == nil {
+		files, err := e.openFiles(ctx, id)
+		if err != nil {
+			return nil, err
+		}
+		e.files[id] = files
+	}
+
+	return e.files[id], nil
 }
 
-func NewEventFileRepository(workPath string, fs volumeFs) *LogFileRepository {
-	return &LogFileRepository{
-		NewSingleLogFileRepository(workPath, archiveEventsDirName, fs),
+func (e *EventFileRepository) openFiles(ctx context.Context, id domain.SupportArchiveID) (*eventFiles, error) {
+	logger := log.FromContext(ctx).WithName("EventFileRepository.openFiles")
+	dirPath := filepath.Join(e.workPath, id.Namespace, id.Name, archiveEventsDirName)
+	err := e.filesystem.MkdirAll(ctx, dirPath, 0755)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create directory %s: %w", dirPath, err)
+	}
+
+	yamlPath := filepath.Join(dirPath, archiveEventsYamlName)
+	yamlFile, err := e.filesystem.OpenFile(ctx, yamlPath, os.O_TRUNC|os.O_CREATE|os.O_WRONLY, os.FileMode(0644))
+	if err != nil {
+		return nil, fmt.Errorf("failed to create events file %s: %w", yamlPath, err)
+	}
+
+	csvPath := filepath.Join(dirPath, archiveEventsCsvName)
+	csvFile, err := e.filesystem.OpenFile(ctx, csvPath, os.O_TRUNC|os.O_CREATE|os.O_WRONLY, os.FileMode(0644))
+	if err != nil {
+		return nil, errors.Join(fmt.Errorf("failed to create events file %s: %w", csvPath, err), yamlFile.Close())
 	}
+
+	err = writeCsvRecord(csvFile, eventsCsvHeader)
+	if err != nil {
+		return nil, errors.Join(fmt.Errorf("failed to write header to events file %s: %w", csvPath, err), yamlFile.Close(), csvFile.Close())
+	}
+	logger.Info(fmt.Sprintf("Created event files in %s", dirPath))
+
+	return &eventFiles{yaml: yamlFile, csv: csvFile}, nil
+}
+
+func (e *EventFileRepository) close(_ context.Context, id domain.SupportArchiveID) error {
+	e.filesMu.Lock()
+	files := e.files[id]
+	delete(e.files, id)
+	e.filesMu.Unlock()
+
+	if files == nil {
+		return nil
+	}
+
+	err := errors.Join(files.yaml.Close(), files.csv.Close())
+	if err != nil {
+		return fmt.Errorf("failed to close event files %s: %w", id, err)
+	}
+
+	return nil
+}
+
+func toEventCsvRecord(event *domain.Event) []string {
+	return []string{
+		event.LastTimestamp.UTC().Format(time.RFC3339),
+		event.FirstTimestamp.UTC().Format(time.RFC3339),
+		event.Type,
+		event.Reason,
+		event.InvolvedObject.String(),
+		event.Namespace,
+		strconv.Itoa(event.Count),
+		event.ReportingController,
+		event.Note,
+		strings.Join(event.Sources, ","),
+	}
+}
+
+func writeCsvRecord(file closableRWFile, record []string) error {
+	writer := csv.NewWriter(file)
+	err := writer.Write(record)
+	if err != nil {
+		return err
+	}
+	writer.Flush()
+
+	return writer.Error()
 }
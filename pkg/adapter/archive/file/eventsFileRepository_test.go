@@ -1,10 +1,38 @@
 package file
 
 import (
-	"github.com/stretchr/testify/assert"
+	"os"
+	"path/filepath"
+	"strings"
 	"testing"
+	"time"
+
+	"github.com/cloudogu/k8s-support-archive-operator/pkg/adapter/filesystem"
+	"github.com/cloudogu/k8s-support-archive-operator/pkg/domain"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+)
+
+const (
+	testEventsWorkDirArchivePath = testWorkPath + "/" + testNamespace + "/" + testName + "/Events"
+	testEventsYamlFile           = testEventsWorkDirArchivePath + "/events.yaml"
+	testEventsCsvFile            = testEventsWorkDirArchivePath + "/events.csv"
 )
 
+var testEvent = &domain.Event{
+	Namespace:           testNamespace,
+	InvolvedObject:      domain.EventObject{Kind: "Pod", Namespace: testNamespace, Name: "ldap-0"},
+	Reason:              "BackOff",
+	Type:                "Warning",
+	Note:                "Back-off restarting failed container, see \"ldap\"",
+	ReportingController: "kubelet",
+	Count:               3,
+	FirstTimestamp:      time.Date(2025, 9, 1, 10, 0, 0, 0, time.UTC),
+	LastTimestamp:       time.Date(2025, 9, 1, 12, 0, 0, 0, time.UTC),
+	Sources:             []string{domain.EventSourceKubernetesAPI, domain.EventSourceLoki},
+}
+
 func TestNewEventFileRepository(t *testing.T) {
 	// given
 	fsMock := newMockVolumeFs(t)
@@ -16,5 +44,116 @@ func TestNewEventFileRepository(t *testing.T) {
 	assert.NotNil(t, repository.files)
 	assert.Equal(t, fsMock, repository.filesystem)
 	assert.Equal(t, testWorkPath, repository.workPath)
-	assert.Equal(t, "Events", repository.dirName)
+	assert.NotEmpty(t, repository.baseFileRepo)
+}
+
+func TestEventFileRepository_createEvent(t *testing.T) {
+	t.Run("should return error on error creating directory", func(t *testing.T) {
+		// given
+		fsMock := newMockVolumeFs(t)
+		fsMock.EXPECT().MkdirAll(mock.Anything, testEventsWorkDirArchivePath, os.FileMode(0755)).Return(assert.AnError)
+		sut := NewEventFileRepository(testWorkPath, fsMock)
+
+		// when
+		err := sut.createEvent(testCtx, testID, testEvent)
+
+		// then
+		require.Error(t, err)
+		assert.ErrorIs(t, err, assert.AnError)
+		assert.ErrorContains(t, err, "failed to create directory")
+	})
+
+	t.Run("should close yaml file on error opening csv file", func(t *testing.T) {
+		// given
+		yamlMock := newMockClosableRWFile(t)
+		yamlMock.EXPECT().Close().Return(nil)
+		fsMock := newMockVolumeFs(t)
+		fsMock.EXPECT().MkdirAll(mock.Anything, testEventsWorkDirArchivePath, os.FileMode(0755)).Return(nil)
+		fsMock.EXPECT().OpenFile(mock.Anything, testEventsYamlFile, os.O_TRUNC|os.O_CREATE|os.O_WRONLY, os.FileMode(0644)).Return(yamlMock, nil)
+		fsMock.EXPECT().OpenFile(mock.Anything, testEventsCsvFile, os.O_TRUNC|os.O_CREATE|os.O_WRONLY, os.FileMode(0644)).Return(nil, assert.AnError)
+		sut := NewEventFileRepository(testWorkPath, fsMock)
+
+		// when
+		err := sut.createEvent(testCtx, testID, testEvent)
+
+		// then
+		require.Error(t, err)
+		assert.ErrorIs(t, err, assert.AnError)
+		assert.ErrorContains(t, err, "failed to create events file")
+		assert.Nil(t, sut.files[testID])
+	})
+
+	t.Run("should write event as yaml list element and csv record", func(t *testing.T) {
+		// given
+		var yamlContent, csvContent strings.Builder
+		yamlMock := newMockClosableRWFile(t)
+		yamlMock.EXPECT().Write(mock.Anything).RunAndReturn(yamlContent.Write)
+		csvMock := newMockClosableRWFile(t)
+		csvMock.EXPECT().Write(mock.Anything).RunAndReturn(csvContent.Write)
+		fsMock := newMockVolumeFs(t)
+		fsMock.EXPECT().MkdirAll(mock.Anything, testEventsWorkDirArchivePath, os.FileMode(0755)).Return(nil)
+		fsMock.EXPECT().OpenFile(mock.Anything, testEventsYamlFile, os.O_TRUNC|os.O_CREATE|os.O_WRONLY, os.FileMode(0644)).Return(yamlMock, nil)
+		fsMock.EXPECT().OpenFile(mock.Anything, testEventsCsvFile, os.O_TRUNC|os.O_CREATE|os.O_WRONLY, os.FileMode(0644)).Return(csvMock, nil)
+		sut := NewEventFileRepository(testWorkPath, fsMock)
+
+		// when
+		err := sut.createEvent(testCtx, testID, testEvent)
+
+		// then
+		require.NoError(t, err)
+		assert.NotNil(t, sut.files[testID])
+		assert.True(t, strings.HasPrefix(yamlContent.String(), "- namespace: ecosystem\n"))
+		assert.Contains(t, yamlContent.String(), "reason: BackOff\n")
+		assert.Equal(t, "lastTimestamp,firstTimestamp,type,reason,involvedObject,namespace,count,reportingController,note,sources\n"+
+			"2025-09-01T12:00:00Z,2025-09-01T10:00:00Z,Warning,BackOff,Pod/ldap-0,ecosystem,3,kubelet,\"Back-off restarting failed container, see \"\"ldap\"\"\",\"KubernetesAPI,Loki\"\n", csvContent.String())
+	})
+}
+
+func TestEventFileRepository_close(t *testing.T) {
+	t.Run("should do nothing if no files are open", func(t *testing.T) {
+		sut := NewEventFileRepository(testWorkPath, newMockVolumeFs(t))
+
+		err := sut.close(testCtx, testID)
+
+		require.NoError(t, err)
+	})
+
+	t.Run("should close both files and return errors", func(t *testing.T) {
+		// given
+		yamlMock := newMockClosableRWFile(t)
+		yamlMock.EXPECT().Close().Return(assert.AnError)
+		csvMock := newMockClosableRWFile(t)
+		csvMock.EXPECT().Close().Return(nil)
+		sut := NewEventFileRepository(testWorkPath, newMockVolumeFs(t))
+		sut.files[testID] = &eventFiles{yaml: yamlMock, csv: csvMock}
+
+		// when
+		err := sut.close(testCtx, testID)
+
+		// then
+		require.Error(t, err)
+		assert.ErrorIs(t, err, assert.AnError)
+		assert.ErrorContains(t, err, "failed to close event files")
+		assert.Nil(t, sut.files[testID])
+	})
+}
+
+func TestEventFileRepository_concurrentArchives(t *testing.T) {
+	// given
+	workPath := t.TempDir()
+	sut := NewEventFileRepository(workPath, filesystem.FileSystem{})
+
+	// when
+	forConcurrentArchives(t, func(id domain.SupportArchiveID) {
+		for range 10 {
+			require.NoError(t, sut.createEvent(testCtx, id, testEvent))
+		}
+		require.NoError(t, sut.close(testCtx, id))
+	})
+
+	// then
+	assert.Empty(t, sut.files)
+	content, err := os.ReadFile(filepath.Join(workPath, testNamespace, "archive-3", archiveEventsDirName, archiveEventsCsvName))
+	require.NoError(t, err)
+	assert.Equal(t, 11, strings.Count(string(content), "\n"))
 }
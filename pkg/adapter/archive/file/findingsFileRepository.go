@@ -0,0 +1,114 @@
+package file
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"path/filepath"
+	"strings"
+
+	"github.com/cloudogu/k8s-support-archive-operator/pkg/domain"
+	"sigs.k8s.io/controller-runtime/pkg/log"
+)
+
+const (
+	// archiveFindingsDirName is only used in the work directory. The findings are placed in the root of the archive.
+	archiveFindingsDirName = "Findings"
+)
+
+var findingSeverities = []domain.FindingSeverity{domain.FindingSeverityCritical, domain.FindingSeverityWarning, domain.FindingSeverityInfo}
+
+type findingsReport struct {
+	Summary  map[domain.FindingSeverity]int `json:"summary"`
+	Findings []domain.Finding               `json:"findings"`
+}
+
+// FindingsFileRepository writes the findings of the archive analysis as markdown and json.
+type FindingsFileRepository struct {
+	baseFileRepo
+	workPath   string
+	filesystem volumeFs
+}
+
+func NewFindingsFileRepository(workPath string, fs volumeFs) *FindingsFileRepository {
+	return &FindingsFileRepository{
+		workPath:     workPath,
+		filesystem:   fs,
+		baseFileRepo: NewBaseFileRepository(workPath, archiveFindingsDirName, fs),
+	}
+}
+
+// Create writes the findings in the given order. Existing findings are overwritten.
+func (f *FindingsFileRepository) Create(ctx context.Context, id domain.SupportArchiveID, findings []domain.Finding) error {
+	logger := log.FromContext(ctx).WithName("FindingsFileRepository.Create")
+	dirPath := filepath.Join(f.workPath, id.Namespace, id.Name, archiveFindingsDirName)
+	err := f.filesystem.MkdirAll(ctx, dirPath, 0755)
+	if err != nil {
+		return fmt.Errorf("failed to create directory %s: %w", dirPath, err)
+	}
+
+	report := findingsReport{Summary: make(map[domain.FindingSeverity]int), Findings: findings}
+	for _, severity := range findingSeverities {
+		report.Summary[severity] = 0
+	}
+	for _, finding := range findings {
+		report.Summary[finding.Severity]++
+	}
+	if report.Findings == nil {
+		report.Findings = []domain.Finding{}
+	}
+
+	out, err := json.MarshalIndent(report, "", "  ")
+	if err != nil {
+		return fmt.Errorf("failed to marshal findings: %w", err)
+	}
+	jsonPath := filepath.Join(dirPath, domain.FindingsJSONFileName)
+	err = f.filesystem.WriteFile(ctx, jsonPath, out, 0644)
+	if err != nil {
+		return fmt.Errorf("failed to write file %s: %w", jsonPath, err)
+	}
+
+	markdownPath := filepath.Join(dirPath, domain.FindingsMarkdownFileName)
+	err = f.filesystem.WriteFile(ctx, markdownPath, []byte(toFindingsMarkdown(id, report)), 0644)
+	if err != nil {
+		return fmt.Errorf("failed to write file %s: %w", markdownPath, err)
+	}
+	logger.Info("created findings", "findings", len(findings))
+
+	return nil
+}
+
+func toFindingsMarkdown(id domain.SupportArchiveID, report findingsReport) string {
+	var sb strings.Builder
+	_, _ = fmt.Fprintf(&sb, "# Findings for support archive %s/%s\n\n", id.Namespace, id.Name)
+
+	sb.WriteString("| Severity | Count |\n|---|---|\n")
+	for _, severity := range findingSeverities {
+		_, _ = fmt.Fprintf(&sb, "| %s | %d |\n", severity, report.Summary[severity])
+	}
+
+	if len(report.Findings) == 0 {
+		sb.WriteString("\nNo problems were found in the collected data.\n")
+		return sb.String()
+	}
+
+	for _, severity := range findingSeverities {
+		if report.Summary[severity] == 0 {
+			continue
+		}
+
+		_, _ = fmt.Fprintf(&sb, "\n## %s\n\n| Rule | Object | Message |\n|---|---|---|\n", severity)
+		for _, finding := range report.Findings {
+			if finding.Severity != severity {
+				continue
+			}
+			_, _ = fmt.Fprintf(&sb, "| %s | %s | %s |\n", escapeMarkdownCell(finding.Rule), escapeMarkdownCell(finding.Object), escapeMarkdownCell(finding.Message))
+		}
+	}
+
+	return sb.String()
+}
+
+func escapeMarkdownCell(value string) string {
+	return strings.NewReplacer("|", `\|`, "\n", " ").Replace(value)
+}
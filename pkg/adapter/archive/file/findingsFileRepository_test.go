@@ -0,0 +1,111 @@
+package file
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/cloudogu/k8s-support-archive-operator/pkg/adapter/filesystem"
+	"github.com/cloudogu/k8s-support-archive-operator/pkg/domain"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+)
+
+const testFindingsWorkDirArchivePath = testWorkPath + "/" + testNamespace + "/" + testName + "/Findings"
+
+func TestNewFindingsFileRepository(t *testing.T) {
+	// given
+	fsMock := newMockVolumeFs(t)
+
+	// when
+	repository := NewFindingsFileRepository(testWorkPath, fsMock)
+
+	// then
+	assert.NotNil(t, repository)
+	assert.Equal(t, testWorkPath, repository.workPath)
+	assert.Equal(t, fsMock, repository.filesystem)
+	assert.NotEmpty(t, repository.baseFileRepo)
+}
+
+func TestFindingsFileRepository_Create(t *testing.T) {
+	t.Run("should write findings as json and markdown", func(t *testing.T) {
+		// given
+		workPath := t.TempDir()
+		sut := NewFindingsFileRepository(workPath, filesystem.FileSystem{})
+		findings := []domain.Finding{
+			{Rule: "VolumeUsage", Severity: domain.FindingSeverityCritical, Object: "PersistentVolumeClaim/ldap", Message: "Volume is 96% full"},
+			{Rule: "ConditionFalse", Severity: domain.FindingSeverityWarning, Object: "Pod/ldap-0", Message: "Condition Ready | False"},
+		}
+
+		// when
+		err := sut.Create(testCtx, testID, findings)
+
+		// then
+		require.NoError(t, err)
+		jsonContent, err := os.ReadFile(filepath.Join(workPath, testNamespace, testName, "Findings", "findings.json"))
+		require.NoError(t, err)
+		assert.JSONEq(t, `{
+			"summary": {"Critical": 1, "Warning": 1, "Info": 0},
+			"findings": [
+				{"rule": "VolumeUsage", "severity": "Critical", "object": "PersistentVolumeClaim/ldap", "message": "Volume is 96% full"},
+				{"rule": "ConditionFalse", "severity": "Warning", "object": "Pod/ldap-0", "message": "Condition Ready | False"}
+			]
+		}`, string(jsonContent))
+		markdownContent, err := os.ReadFile(filepath.Join(workPath, testNamespace, testName, "Findings", "findings.md"))
+		require.NoError(t, err)
+		assert.Equal(t, "# Findings for support archive ecosystem/archive-123\n\n"+
+			"| Severity | Count |\n|---|---|\n| Critical | 1 |\n| Warning | 1 |\n| Info | 0 |\n"+
+			"\n## Critical\n\n| Rule | Object | Message |\n|---|---|---|\n| VolumeUsage | PersistentVolumeClaim/ldap | Volume is 96% full |\n"+
+			"\n## Warning\n\n| Rule | Object | Message |\n|---|---|---|\n| ConditionFalse | Pod/ldap-0 | Condition Ready \\| False |\n", string(markdownContent))
+	})
+
+	t.Run("should write empty report without findings", func(t *testing.T) {
+		// given
+		workPath := t.TempDir()
+		sut := NewFindingsFileRepository(workPath, filesystem.FileSystem{})
+
+		// when
+		err := sut.Create(testCtx, testID, nil)
+
+		// then
+		require.NoError(t, err)
+		jsonContent, err := os.ReadFile(filepath.Join(workPath, testNamespace, testName, "Findings", "findings.json"))
+		require.NoError(t, err)
+		assert.JSONEq(t, `{"summary": {"Critical": 0, "Warning": 0, "Info": 0}, "findings": []}`, string(jsonContent))
+		markdownContent, err := os.ReadFile(filepath.Join(workPath, testNamespace, testName, "Findings", "findings.md"))
+		require.NoError(t, err)
+		assert.Contains(t, string(markdownContent), "No problems were found in the collected data.")
+	})
+
+	t.Run("should return error on error writing json file", func(t *testing.T) {
+		// given
+		fsMock := newMockVolumeFs(t)
+		fsMock.EXPECT().MkdirAll(mock.Anything, testFindingsWorkDirArchivePath, os.FileMode(0755)).Return(nil)
+		fsMock.EXPECT().WriteFile(mock.Anything, testFindingsWorkDirArchivePath+"/findings.json", mock.Anything, os.FileMode(0644)).Return(assert.AnError)
+		sut := NewFindingsFileRepository(testWorkPath, fsMock)
+
+		// when
+		err := sut.Create(testCtx, testID, nil)
+
+		// then
+		require.Error(t, err)
+		assert.ErrorIs(t, err, assert.AnError)
+		assert.ErrorContains(t, err, "failed to write file")
+	})
+
+	t.Run("should return error on error creating directory", func(t *testing.T) {
+		// given
+		fsMock := newMockVolumeFs(t)
+		fsMock.EXPECT().MkdirAll(mock.Anything, testFindingsWorkDirArchivePath, os.FileMode(0755)).Return(assert.AnError)
+		sut := NewFindingsFileRepository(testWorkPath, fsMock)
+
+		// when
+		err := sut.Create(testCtx, testID, nil)
+
+		// then
+		require.Error(t, err)
+		assert.ErrorIs(t, err, assert.AnError)
+		assert.ErrorContains(t, err, "failed to create directory")
+	})
+}
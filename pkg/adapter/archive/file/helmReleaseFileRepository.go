@@ -0,0 +1,48 @@
+package file
+
+import (
+	"context"
+	"fmt"
+	"path/filepath"
+
+	"github.com/cloudogu/k8s-support-archive-operator/pkg/domain"
+	"sigs.k8s.io/controller-runtime/pkg/log"
+)
+
+const (
+	archiveHelmReleasesDirName = "Resources/HelmReleases"
+)
+
+type HelmReleaseFileRepository struct {
+	baseFileRepo
+	workPath   string
+	filesystem volumeFs
+}
+
+func NewHelmReleaseFileRepository(workPath string, fs volumeFs) *HelmReleaseFileRepository {
+	return &HelmReleaseFileRepository{
+		workPath:     workPath,
+		filesystem:   fs,
+		baseFileRepo: NewBaseFileRepository(workPath, archiveHelmReleasesDirName, fs),
+	}
+}
+
+func (h *HelmReleaseFileRepository) Create(ctx context.Context, id domain.SupportArchiveID, dataStream <-chan *domain.HelmRelease) error {
+	return create(ctx, id, dataStream, h.createHelmRelease, h.Delete, h.finishCollection, nil)
+}
+
+// createHelmRelease writes a single helm release to its own file.
+// If the release file exists, it overrides the existing file.
+func (h *HelmReleaseFileRepository) createHelmRelease(ctx context.Context, id domain.SupportArchiveID, data *domain.HelmRelease) error {
+	logger := log.FromContext(ctx).WithName("HelmReleaseFileRepository.createHelmRelease")
+	filePath := fmt.Sprintf("%s.yaml", filepath.Join(h.workPath, id.Namespace, id.Name, archiveHelmReleasesDirName, data.Name))
+
+	err := createYAMLFile(ctx, h.filesystem, filePath, data)
+	if err != nil {
+		return err
+	}
+
+	logger.Info(fmt.Sprintf("created file for helm release %s", data.Name))
+
+	return nil
+}
@@ -0,0 +1,17 @@
+package file
+
+const (
+	archiveInMemoryDirName = "InMemory"
+)
+
+// InMemoryArchiveFileRepository records the spec hash of archives created in memory in the work directory. These
+// archives keep no collected data in the work directory, so the hash replaces the spec hashes of the collectors.
+type InMemoryArchiveFileRepository struct {
+	baseFileRepo
+}
+
+func NewInMemoryArchiveFileRepository(workPath string, fs volumeFs) *InMemoryArchiveFileRepository {
+	return &InMemoryArchiveFileRepository{
+		NewBaseFileRepository(workPath, archiveInMemoryDirName, fs),
+	}
+}
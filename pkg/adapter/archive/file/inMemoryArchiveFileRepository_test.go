@@ -0,0 +1,46 @@
+package file
+
+import (
+	"testing"
+
+	"github.com/cloudogu/k8s-support-archive-operator/pkg/adapter/filesystem"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestNewInMemoryArchiveFileRepository(t *testing.T) {
+	// given
+	fsMock := newMockVolumeFs(t)
+
+	// when
+	repository := NewInMemoryArchiveFileRepository(testWorkPath, fsMock)
+
+	// then
+	assert.NotNil(t, repository)
+	assert.NotEmpty(t, repository.baseFileRepo)
+}
+
+func TestInMemoryArchiveFileRepository_SpecHash(t *testing.T) {
+	t.Run("should record the spec hash in the work directory until the archive is deleted", func(t *testing.T) {
+		// given
+		workPath := t.TempDir()
+		sut := NewInMemoryArchiveFileRepository(workPath, filesystem.FileSystem{})
+
+		// when
+		emptyHash, err := sut.GetSpecHash(testCtx, testID)
+		require.NoError(t, err)
+		err = sut.SetSpecHash(testCtx, testID, "hash")
+		require.NoError(t, err)
+		hash, err := sut.GetSpecHash(testCtx, testID)
+		require.NoError(t, err)
+		err = sut.Delete(testCtx, testID)
+		require.NoError(t, err)
+		deletedHash, err := sut.GetSpecHash(testCtx, testID)
+
+		// then
+		require.NoError(t, err)
+		assert.Empty(t, emptyHash)
+		assert.Equal(t, "hash", hash)
+		assert.Empty(t, deletedHash)
+	})
+}
@@ -1,6 +1,7 @@
 package file
 
 import (
+	"archive/zip"
 	"context"
 	"io"
 
@@ -20,7 +21,7 @@ type closableRWFile interface {
 
 type Zipper interface {
 	Close() error
-	Create(name string) (io.Writer, error)
+	CreateHeader(fh *zip.FileHeader) (io.Writer, error)
 }
 
 //nolint:unused
@@ -31,6 +32,12 @@ type Reader interface {
 
 type baseFileRepo interface {
 	IsCollected(ctx context.Context, id domain.SupportArchiveID) (bool, error)
+	Skip(ctx context.Context, id domain.SupportArchiveID, reason string) error
+	IsSkipped(ctx context.Context, id domain.SupportArchiveID) (bool, string, error)
+	SetSpecHash(ctx context.Context, id domain.SupportArchiveID, hash string) error
+	GetSpecHash(ctx context.Context, id domain.SupportArchiveID) (string, error)
+	SetFailures(ctx context.Context, id domain.SupportArchiveID, failures int) error
+	GetFailures(ctx context.Context, id domain.SupportArchiveID) (int, error)
 	finishCollection(ctx context.Context, id domain.SupportArchiveID) error
 	Delete(ctx context.Context, id domain.SupportArchiveID) error
 	Stream(ctx context.Context, id domain.SupportArchiveID, stream *domain.Stream) error
@@ -3,6 +3,7 @@
 package file
 
 import (
+	zip "archive/zip"
 	io "io"
 
 	mock "github.com/stretchr/testify/mock"
@@ -66,29 +67,29 @@ func (_c *MockZipper_Close_Call) RunAndReturn(run func() error) *MockZipper_Clos
 	return _c
 }
 
-// Create provides a mock function with given fields: name
-func (_m *MockZipper) Create(name string) (io.Writer, error) {
-	ret := _m.Called(name)
+// CreateHeader provides a mock function with given fields: fh
+func (_m *MockZipper) CreateHeader(fh *zip.FileHeader) (io.Writer, error) {
+	ret := _m.Called(fh)
 
 	if len(ret) == 0 {
-		panic("no return value specified for Create")
+		panic("no return value specified for CreateHeader")
 	}
 
 	var r0 io.Writer
 	var r1 error
-	if rf, ok := ret.Get(0).(func(string) (io.Writer, error)); ok {
-		return rf(name)
+	if rf, ok := ret.Get(0).(func(*zip.FileHeader) (io.Writer, error)); ok {
+		return rf(fh)
 	}
-	if rf, ok := ret.Get(0).(func(string) io.Writer); ok {
-		r0 = rf(name)
+	if rf, ok := ret.Get(0).(func(*zip.FileHeader) io.Writer); ok {
+		r0 = rf(fh)
 	} else {
 		if ret.Get(0) != nil {
 			r0 = ret.Get(0).(io.Writer)
 		}
 	}
 
-	if rf, ok := ret.Get(1).(func(string) error); ok {
-		r1 = rf(name)
+	if rf, ok := ret.Get(1).(func(*zip.FileHeader) error); ok {
+		r1 = rf(fh)
 	} else {
 		r1 = ret.Error(1)
 	}
@@ -96,30 +97,30 @@ func (_m *MockZipper) Create(name string) (io.Writer, error) {
 	return r0, r1
 }
 
-// MockZipper_Create_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'Create'
-type MockZipper_Create_Call struct {
+// MockZipper_CreateHeader_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'CreateHeader'
+type MockZipper_CreateHeader_Call struct {
 	*mock.Call
 }
 
-// Create is a helper method to define mock.On call
-//   - name string
-func (_e *MockZipper_Expecter) Create(name interface{}) *MockZipper_Create_Call {
-	return &MockZipper_Create_Call{Call: _e.mock.On("Create", name)}
+// CreateHeader is a helper method to define mock.On call
+//   - fh *zip.FileHeader
+func (_e *MockZipper_Expecter) CreateHeader(fh interface{}) *MockZipper_CreateHeader_Call {
+	return &MockZipper_CreateHeader_Call{Call: _e.mock.On("CreateHeader", fh)}
 }
 
-func (_c *MockZipper_Create_Call) Run(run func(name string)) *MockZipper_Create_Call {
+func (_c *MockZipper_CreateHeader_Call) Run(run func(fh *zip.FileHeader)) *MockZipper_CreateHeader_Call {
 	_c.Call.Run(func(args mock.Arguments) {
-		run(args[0].(string))
+		run(args[0].(*zip.FileHeader))
 	})
 	return _c
 }
 
-func (_c *MockZipper_Create_Call) Return(_a0 io.Writer, _a1 error) *MockZipper_Create_Call {
+func (_c *MockZipper_CreateHeader_Call) Return(_a0 io.Writer, _a1 error) *MockZipper_CreateHeader_Call {
 	_c.Call.Return(_a0, _a1)
 	return _c
 }
 
-func (_c *MockZipper_Create_Call) RunAndReturn(run func(string) (io.Writer, error)) *MockZipper_Create_Call {
+func (_c *MockZipper_CreateHeader_Call) RunAndReturn(run func(*zip.FileHeader) (io.Writer, error)) *MockZipper_CreateHeader_Call {
 	_c.Call.Return(run)
 	return _c
 }
@@ -69,6 +69,120 @@ func (_c *mockBaseFileRepo_Delete_Call) RunAndReturn(run func(context.Context, d
 	return _c
 }
 
+// GetFailures provides a mock function with given fields: ctx, id
+func (_m *mockBaseFileRepo) GetFailures(ctx context.Context, id domain.SupportArchiveID) (int, error) {
+	ret := _m.Called(ctx, id)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetFailures")
+	}
+
+	var r0 int
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, domain.SupportArchiveID) (int, error)); ok {
+		return rf(ctx, id)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, domain.SupportArchiveID) int); ok {
+		r0 = rf(ctx, id)
+	} else {
+		r0 = ret.Get(0).(int)
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, domain.SupportArchiveID) error); ok {
+		r1 = rf(ctx, id)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// mockBaseFileRepo_GetFailures_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'GetFailures'
+type mockBaseFileRepo_GetFailures_Call struct {
+	*mock.Call
+}
+
+// GetFailures is a helper method to define mock.On call
+//   - ctx context.Context
+//   - id domain.SupportArchiveID
+func (_e *mockBaseFileRepo_Expecter) GetFailures(ctx interface{}, id interface{}) *mockBaseFileRepo_GetFailures_Call {
+	return &mockBaseFileRepo_GetFailures_Call{Call: _e.mock.On("GetFailures", ctx, id)}
+}
+
+func (_c *mockBaseFileRepo_GetFailures_Call) Run(run func(ctx context.Context, id domain.SupportArchiveID)) *mockBaseFileRepo_GetFailures_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(domain.SupportArchiveID))
+	})
+	return _c
+}
+
+func (_c *mockBaseFileRepo_GetFailures_Call) Return(_a0 int, _a1 error) *mockBaseFileRepo_GetFailures_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *mockBaseFileRepo_GetFailures_Call) RunAndReturn(run func(context.Context, domain.SupportArchiveID) (int, error)) *mockBaseFileRepo_GetFailures_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// GetSpecHash provides a mock function with given fields: ctx, id
+func (_m *mockBaseFileRepo) GetSpecHash(ctx context.Context, id domain.SupportArchiveID) (string, error) {
+	ret := _m.Called(ctx, id)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetSpecHash")
+	}
+
+	var r0 string
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, domain.SupportArchiveID) (string, error)); ok {
+		return rf(ctx, id)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, domain.SupportArchiveID) string); ok {
+		r0 = rf(ctx, id)
+	} else {
+		r0 = ret.Get(0).(string)
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, domain.SupportArchiveID) error); ok {
+		r1 = rf(ctx, id)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// mockBaseFileRepo_GetSpecHash_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'GetSpecHash'
+type mockBaseFileRepo_GetSpecHash_Call struct {
+	*mock.Call
+}
+
+// GetSpecHash is a helper method to define mock.On call
+//   - ctx context.Context
+//   - id domain.SupportArchiveID
+func (_e *mockBaseFileRepo_Expecter) GetSpecHash(ctx interface{}, id interface{}) *mockBaseFileRepo_GetSpecHash_Call {
+	return &mockBaseFileRepo_GetSpecHash_Call{Call: _e.mock.On("GetSpecHash", ctx, id)}
+}
+
+func (_c *mockBaseFileRepo_GetSpecHash_Call) Run(run func(ctx context.Context, id domain.SupportArchiveID)) *mockBaseFileRepo_GetSpecHash_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(domain.SupportArchiveID))
+	})
+	return _c
+}
+
+func (_c *mockBaseFileRepo_GetSpecHash_Call) Return(_a0 string, _a1 error) *mockBaseFileRepo_GetSpecHash_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *mockBaseFileRepo_GetSpecHash_Call) RunAndReturn(run func(context.Context, domain.SupportArchiveID) (string, error)) *mockBaseFileRepo_GetSpecHash_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
 // IsCollected provides a mock function with given fields: ctx, id
 func (_m *mockBaseFileRepo) IsCollected(ctx context.Context, id domain.SupportArchiveID) (bool, error) {
 	ret := _m.Called(ctx, id)
@@ -126,6 +240,214 @@ func (_c *mockBaseFileRepo_IsCollected_Call) RunAndReturn(run func(context.Conte
 	return _c
 }
 
+// IsSkipped provides a mock function with given fields: ctx, id
+func (_m *mockBaseFileRepo) IsSkipped(ctx context.Context, id domain.SupportArchiveID) (bool, string, error) {
+	ret := _m.Called(ctx, id)
+
+	if len(ret) == 0 {
+		panic("no return value specified for IsSkipped")
+	}
+
+	var r0 bool
+	var r1 string
+	var r2 error
+	if rf, ok := ret.Get(0).(func(context.Context, domain.SupportArchiveID) (bool, string, error)); ok {
+		return rf(ctx, id)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, domain.SupportArchiveID) bool); ok {
+		r0 = rf(ctx, id)
+	} else {
+		r0 = ret.Get(0).(bool)
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, domain.SupportArchiveID) string); ok {
+		r1 = rf(ctx, id)
+	} else {
+		r1 = ret.Get(1).(string)
+	}
+
+	if rf, ok := ret.Get(2).(func(context.Context, domain.SupportArchiveID) error); ok {
+		r2 = rf(ctx, id)
+	} else {
+		r2 = ret.Error(2)
+	}
+
+	return r0, r1, r2
+}
+
+// mockBaseFileRepo_IsSkipped_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'IsSkipped'
+type mockBaseFileRepo_IsSkipped_Call struct {
+	*mock.Call
+}
+
+// IsSkipped is a helper method to define mock.On call
+//   - ctx context.Context
+//   - id domain.SupportArchiveID
+func (_e *mockBaseFileRepo_Expecter) IsSkipped(ctx interface{}, id interface{}) *mockBaseFileRepo_IsSkipped_Call {
+	return &mockBaseFileRepo_IsSkipped_Call{Call: _e.mock.On("IsSkipped", ctx, id)}
+}
+
+func (_c *mockBaseFileRepo_IsSkipped_Call) Run(run func(ctx context.Context, id domain.SupportArchiveID)) *mockBaseFileRepo_IsSkipped_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(domain.SupportArchiveID))
+	})
+	return _c
+}
+
+func (_c *mockBaseFileRepo_IsSkipped_Call) Return(_a0 bool, _a1 string, _a2 error) *mockBaseFileRepo_IsSkipped_Call {
+	_c.Call.Return(_a0, _a1, _a2)
+	return _c
+}
+
+func (_c *mockBaseFileRepo_IsSkipped_Call) RunAndReturn(run func(context.Context, domain.SupportArchiveID) (bool, string, error)) *mockBaseFileRepo_IsSkipped_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// SetFailures provides a mock function with given fields: ctx, id, failures
+func (_m *mockBaseFileRepo) SetFailures(ctx context.Context, id domain.SupportArchiveID, failures int) error {
+	ret := _m.Called(ctx, id, failures)
+
+	if len(ret) == 0 {
+		panic("no return value specified for SetFailures")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, domain.SupportArchiveID, int) error); ok {
+		r0 = rf(ctx, id, failures)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// mockBaseFileRepo_SetFailures_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'SetFailures'
+type mockBaseFileRepo_SetFailures_Call struct {
+	*mock.Call
+}
+
+// SetFailures is a helper method to define mock.On call
+//   - ctx context.Context
+//   - id domain.SupportArchiveID
+//   - failures int
+func (_e *mockBaseFileRepo_Expecter) SetFailures(ctx interface{}, id interface{}, failures interface{}) *mockBaseFileRepo_SetFailures_Call {
+	return &mockBaseFileRepo_SetFailures_Call{Call: _e.mock.On("SetFailures", ctx, id, failures)}
+}
+
+func (_c *mockBaseFileRepo_SetFailures_Call) Run(run func(ctx context.Context, id domain.SupportArchiveID, failures int)) *mockBaseFileRepo_SetFailures_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(domain.SupportArchiveID), args[2].(int))
+	})
+	return _c
+}
+
+func (_c *mockBaseFileRepo_SetFailures_Call) Return(_a0 error) *mockBaseFileRepo_SetFailures_Call {
+	_c.Call.Return(_a0)
+	return _c
+}
+
+func (_c *mockBaseFileRepo_SetFailures_Call) RunAndReturn(run func(context.Context, domain.SupportArchiveID, int) error) *mockBaseFileRepo_SetFailures_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// SetSpecHash provides a mock function with given fields: ctx, id, hash
+func (_m *mockBaseFileRepo) SetSpecHash(ctx context.Context, id domain.SupportArchiveID, hash string) error {
+	ret := _m.Called(ctx, id, hash)
+
+	if len(ret) == 0 {
+		panic("no return value specified for SetSpecHash")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, domain.SupportArchiveID, string) error); ok {
+		r0 = rf(ctx, id, hash)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// mockBaseFileRepo_SetSpecHash_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'SetSpecHash'
+type mockBaseFileRepo_SetSpecHash_Call struct {
+	*mock.Call
+}
+
+// SetSpecHash is a helper method to define mock.On call
+//   - ctx context.Context
+//   - id domain.SupportArchiveID
+//   - hash string
+func (_e *mockBaseFileRepo_Expecter) SetSpecHash(ctx interface{}, id interface{}, hash interface{}) *mockBaseFileRepo_SetSpecHash_Call {
+	return &mockBaseFileRepo_SetSpecHash_Call{Call: _e.mock.On("SetSpecHash", ctx, id, hash)}
+}
+
+func (_c *mockBaseFileRepo_SetSpecHash_Call) Run(run func(ctx context.Context, id domain.SupportArchiveID, hash string)) *mockBaseFileRepo_SetSpecHash_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(domain.SupportArchiveID), args[2].(string))
+	})
+	return _c
+}
+
+func (_c *mockBaseFileRepo_SetSpecHash_Call) Return(_a0 error) *mockBaseFileRepo_SetSpecHash_Call {
+	_c.Call.Return(_a0)
+	return _c
+}
+
+func (_c *mockBaseFileRepo_SetSpecHash_Call) RunAndReturn(run func(context.Context, domain.SupportArchiveID, string) error) *mockBaseFileRepo_SetSpecHash_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// Skip provides a mock function with given fields: ctx, id, reason
+func (_m *mockBaseFileRepo) Skip(ctx context.Context, id domain.SupportArchiveID, reason string) error {
+	ret := _m.Called(ctx, id, reason)
+
+	if len(ret) == 0 {
+		panic("no return value specified for Skip")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, domain.SupportArchiveID, string) error); ok {
+		r0 = rf(ctx, id, reason)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// mockBaseFileRepo_Skip_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'Skip'
+type mockBaseFileRepo_Skip_Call struct {
+	*mock.Call
+}
+
+// Skip is a helper method to define mock.On call
+//   - ctx context.Context
+//   - id domain.SupportArchiveID
+//   - reason string
+func (_e *mockBaseFileRepo_Expecter) Skip(ctx interface{}, id interface{}, reason interface{}) *mockBaseFileRepo_Skip_Call {
+	return &mockBaseFileRepo_Skip_Call{Call: _e.mock.On("Skip", ctx, id, reason)}
+}
+
+func (_c *mockBaseFileRepo_Skip_Call) Run(run func(ctx context.Context, id domain.SupportArchiveID, reason string)) *mockBaseFileRepo_Skip_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(domain.SupportArchiveID), args[2].(string))
+	})
+	return _c
+}
+
+func (_c *mockBaseFileRepo_Skip_Call) Return(_a0 error) *mockBaseFileRepo_Skip_Call {
+	_c.Call.Return(_a0)
+	return _c
+}
+
+func (_c *mockBaseFileRepo_Skip_Call) RunAndReturn(run func(context.Context, domain.SupportArchiveID, string) error) *mockBaseFileRepo_Skip_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
 // Stream provides a mock function with given fields: ctx, id, stream
 func (_m *mockBaseFileRepo) Stream(ctx context.Context, id domain.SupportArchiveID, stream *domain.Stream) error {
 	ret := _m.Called(ctx, id, stream)
@@ -1,8 +1,9 @@
-// Code generated by mockery v2.53.3. DO NOT EDIT.
+// Code generated by mockery v2.53.7. DO NOT EDIT.
 
 package file
 
 import (
+	context "context"
 	fs "io/fs"
 
 	filesystem "github.com/cloudogu/k8s-support-archive-operator/pkg/adapter/filesystem"
@@ -82,9 +83,9 @@ func (_c *mockSecretFs_Copy_Call) RunAndReturn(run func(io.Writer, io.Reader) (i
 	return _c
 }
 
-// Create provides a mock function with given fields: name
-func (_m *mockSecretFs) Create(name string) (filesystem.ClosableRWFile, error) {
-	ret := _m.Called(name)
+// Create provides a mock function with given fields: ctx, name
+func (_m *mockSecretFs) Create(ctx context.Context, name string) (filesystem.ClosableRWFile, error) {
+	ret := _m.Called(ctx, name)
 
 	if len(ret) == 0 {
 		panic("no return value specified for Create")
@@ -92,19 +93,19 @@ func (_m *mockSecretFs) Create(name string) (filesystem.ClosableRWFile, error) {
 
 	var r0 filesystem.ClosableRWFile
 	var r1 error
-	if rf, ok := ret.Get(0).(func(string) (filesystem.ClosableRWFile, error)); ok {
-		return rf(name)
+	if rf, ok := ret.Get(0).(func(context.Context, string) (filesystem.ClosableRWFile, error)); ok {
+		return rf(ctx, name)
 	}
-	if rf, ok := ret.Get(0).(func(string) filesystem.ClosableRWFile); ok {
-		r0 = rf(name)
+	if rf, ok := ret.Get(0).(func(context.Context, string) filesystem.ClosableRWFile); ok {
+		r0 = rf(ctx, name)
 	} else {
 		if ret.Get(0) != nil {
 			r0 = ret.Get(0).(filesystem.ClosableRWFile)
 		}
 	}
 
-	if rf, ok := ret.Get(1).(func(string) error); ok {
-		r1 = rf(name)
+	if rf, ok := ret.Get(1).(func(context.Context, string) error); ok {
+		r1 = rf(ctx, name)
 	} else {
 		r1 = ret.Error(1)
 	}
@@ -118,14 +119,15 @@ type mockSecretFs_Create_Call struct {
 }
 
 // Create is a helper method to define mock.On call
+//   - ctx context.Context
 //   - name string
-func (_e *mockSecretFs_Expecter) Create(name interface{}) *mockSecretFs_Create_Call {
-	return &mockSecretFs_Create_Call{Call: _e.mock.On("Create", name)}
+func (_e *mockSecretFs_Expecter) Create(ctx interface{}, name interface{}) *mockSecretFs_Create_Call {
+	return &mockSecretFs_Create_Call{Call: _e.mock.On("Create", ctx, name)}
 }
 
-func (_c *mockSecretFs_Create_Call) Run(run func(name string)) *mockSecretFs_Create_Call {
+func (_c *mockSecretFs_Create_Call) Run(run func(ctx context.Context, name string)) *mockSecretFs_Create_Call {
 	_c.Call.Run(func(args mock.Arguments) {
-		run(args[0].(string))
+		run(args[0].(context.Context), args[1].(string))
 	})
 	return _c
 }
@@ -135,22 +137,22 @@ func (_c *mockSecretFs_Create_Call) Return(_a0 filesystem.ClosableRWFile, _a1 er
 	return _c
 }
 
-func (_c *mockSecretFs_Create_Call) RunAndReturn(run func(string) (filesystem.ClosableRWFile, error)) *mockSecretFs_Create_Call {
+func (_c *mockSecretFs_Create_Call) RunAndReturn(run func(context.Context, string) (filesystem.ClosableRWFile, error)) *mockSecretFs_Create_Call {
 	_c.Call.Return(run)
 	return _c
 }
 
-// MkdirAll provides a mock function with given fields: path, perm
-func (_m *mockSecretFs) MkdirAll(path string, perm fs.FileMode) error {
-	ret := _m.Called(path, perm)
+// MkdirAll provides a mock function with given fields: ctx, path, perm
+func (_m *mockSecretFs) MkdirAll(ctx context.Context, path string, perm fs.FileMode) error {
+	ret := _m.Called(ctx, path, perm)
 
 	if len(ret) == 0 {
 		panic("no return value specified for MkdirAll")
 	}
 
 	var r0 error
-	if rf, ok := ret.Get(0).(func(string, fs.FileMode) error); ok {
-		r0 = rf(path, perm)
+	if rf, ok := ret.Get(0).(func(context.Context, string, fs.FileMode) error); ok {
+		r0 = rf(ctx, path, perm)
 	} else {
 		r0 = ret.Error(0)
 	}
@@ -164,15 +166,16 @@ type mockSecretFs_MkdirAll_Call struct {
 }
 
 // MkdirAll is a helper method to define mock.On call
+//   - ctx context.Context
 //   - path string
 //   - perm fs.FileMode
-func (_e *mockSecretFs_Expecter) MkdirAll(path interface{}, perm interface{}) *mockSecretFs_MkdirAll_Call {
-	return &mockSecretFs_MkdirAll_Call{Call: _e.mock.On("MkdirAll", path, perm)}
+func (_e *mockSecretFs_Expecter) MkdirAll(ctx interface{}, path interface{}, perm interface{}) *mockSecretFs_MkdirAll_Call {
+	return &mockSecretFs_MkdirAll_Call{Call: _e.mock.On("MkdirAll", ctx, path, perm)}
 }
 
-func (_c *mockSecretFs_MkdirAll_Call) Run(run func(path string, perm fs.FileMode)) *mockSecretFs_MkdirAll_Call {
+func (_c *mockSecretFs_MkdirAll_Call) Run(run func(ctx context.Context, path string, perm fs.FileMode)) *mockSecretFs_MkdirAll_Call {
 	_c.Call.Run(func(args mock.Arguments) {
-		run(args[0].(string), args[1].(fs.FileMode))
+		run(args[0].(context.Context), args[1].(string), args[2].(fs.FileMode))
 	})
 	return _c
 }
@@ -182,14 +185,14 @@ func (_c *mockSecretFs_MkdirAll_Call) Return(_a0 error) *mockSecretFs_MkdirAll_C
 	return _c
 }
 
-func (_c *mockSecretFs_MkdirAll_Call) RunAndReturn(run func(string, fs.FileMode) error) *mockSecretFs_MkdirAll_Call {
+func (_c *mockSecretFs_MkdirAll_Call) RunAndReturn(run func(context.Context, string, fs.FileMode) error) *mockSecretFs_MkdirAll_Call {
 	_c.Call.Return(run)
 	return _c
 }
 
-// Open provides a mock function with given fields: path
-func (_m *mockSecretFs) Open(path string) (filesystem.ClosableRWFile, error) {
-	ret := _m.Called(path)
+// Open provides a mock function with given fields: ctx, path
+func (_m *mockSecretFs) Open(ctx context.Context, path string) (filesystem.ClosableRWFile, error) {
+	ret := _m.Called(ctx, path)
 
 	if len(ret) == 0 {
 		panic("no return value specified for Open")
@@ -197,19 +200,19 @@ func (_m *mockSecretFs) Open(path string) (filesystem.ClosableRWFile, error) {
 
 	var r0 filesystem.ClosableRWFile
 	var r1 error
-	if rf, ok := ret.Get(0).(func(string) (filesystem.ClosableRWFile, error)); ok {
-		return rf(path)
+	if rf, ok := ret.Get(0).(func(context.Context, string) (filesystem.ClosableRWFile, error)); ok {
+		return rf(ctx, path)
 	}
-	if rf, ok := ret.Get(0).(func(string) filesystem.ClosableRWFile); ok {
-		r0 = rf(path)
+	if rf, ok := ret.Get(0).(func(context.Context, string) filesystem.ClosableRWFile); ok {
+		r0 = rf(ctx, path)
 	} else {
 		if ret.Get(0) != nil {
 			r0 = ret.Get(0).(filesystem.ClosableRWFile)
 		}
 	}
 
-	if rf, ok := ret.Get(1).(func(string) error); ok {
-		r1 = rf(path)
+	if rf, ok := ret.Get(1).(func(context.Context, string) error); ok {
+		r1 = rf(ctx, path)
 	} else {
 		r1 = ret.Error(1)
 	}
@@ -223,14 +226,15 @@ type mockSecretFs_Open_Call struct {
 }
 
 // Open is a helper method to define mock.On call
+//   - ctx context.Context
 //   - path string
-func (_e *mockSecretFs_Expecter) Open(path interface{}) *mockSecretFs_Open_Call {
-	return &mockSecretFs_Open_Call{Call: _e.mock.On("Open", path)}
+func (_e *mockSecretFs_Expecter) Open(ctx interface{}, path interface{}) *mockSecretFs_Open_Call {
+	return &mockSecretFs_Open_Call{Call: _e.mock.On("Open", ctx, path)}
 }
 
-func (_c *mockSecretFs_Open_Call) Run(run func(path string)) *mockSecretFs_Open_Call {
+func (_c *mockSecretFs_Open_Call) Run(run func(ctx context.Context, path string)) *mockSecretFs_Open_Call {
 	_c.Call.Run(func(args mock.Arguments) {
-		run(args[0].(string))
+		run(args[0].(context.Context), args[1].(string))
 	})
 	return _c
 }
@@ -240,14 +244,14 @@ func (_c *mockSecretFs_Open_Call) Return(_a0 filesystem.ClosableRWFile, _a1 erro
 	return _c
 }
 
-func (_c *mockSecretFs_Open_Call) RunAndReturn(run func(string) (filesystem.ClosableRWFile, error)) *mockSecretFs_Open_Call {
+func (_c *mockSecretFs_Open_Call) RunAndReturn(run func(context.Context, string) (filesystem.ClosableRWFile, error)) *mockSecretFs_Open_Call {
 	_c.Call.Return(run)
 	return _c
 }
 
-// OpenFile provides a mock function with given fields: path, flag, perm
-func (_m *mockSecretFs) OpenFile(path string, flag int, perm fs.FileMode) (filesystem.ClosableRWFile, error) {
-	ret := _m.Called(path, flag, perm)
+// OpenFile provides a mock function with given fields: ctx, path, flag, perm
+func (_m *mockSecretFs) OpenFile(ctx context.Context, path string, flag int, perm fs.FileMode) (filesystem.ClosableRWFile, error) {
+	ret := _m.Called(ctx, path, flag, perm)
 
 	if len(ret) == 0 {
 		panic("no return value specified for OpenFile")
@@ -255,19 +259,19 @@ func (_m *mockSecretFs) OpenFile(path string, flag int, perm fs.FileMode) (files
 
 	var r0 filesystem.ClosableRWFile
 	var r1 error
-	if rf, ok := ret.Get(0).(func(string, int, fs.FileMode) (filesystem.ClosableRWFile, error)); ok {
-		return rf(path, flag, perm)
+	if rf, ok := ret.Get(0).(func(context.Context, string, int, fs.FileMode) (filesystem.ClosableRWFile, error)); ok {
+		return rf(ctx, path, flag, perm)
 	}
-	if rf, ok := ret.Get(0).(func(string, int, fs.FileMode) filesystem.ClosableRWFile); ok {
-		r0 = rf(path, flag, perm)
+	if rf, ok := ret.Get(0).(func(context.Context, string, int, fs.FileMode) filesystem.ClosableRWFile); ok {
+		r0 = rf(ctx, path, flag, perm)
 	} else {
 		if ret.Get(0) != nil {
 			r0 = ret.Get(0).(filesystem.ClosableRWFile)
 		}
 	}
 
-	if rf, ok := ret.Get(1).(func(string, int, fs.FileMode) error); ok {
-		r1 = rf(path, flag, perm)
+	if rf, ok := ret.Get(1).(func(context.Context, string, int, fs.FileMode) error); ok {
+		r1 = rf(ctx, path, flag, perm)
 	} else {
 		r1 = ret.Error(1)
 	}
@@ -281,16 +285,17 @@ type mockSecretFs_OpenFile_Call struct {
 }
 
 // OpenFile is a helper method to define mock.On call
+//   - ctx context.Context
 //   - path string
 //   - flag int
 //   - perm fs.FileMode
-func (_e *mockSecretFs_Expecter) OpenFile(path interface{}, flag interface{}, perm interface{}) *mockSecretFs_OpenFile_Call {
-	return &mockSecretFs_OpenFile_Call{Call: _e.mock.On("OpenFile", path, flag, perm)}
+func (_e *mockSecretFs_Expecter) OpenFile(ctx interface{}, path interface{}, flag interface{}, perm interface{}) *mockSecretFs_OpenFile_Call {
+	return &mockSecretFs_OpenFile_Call{Call: _e.mock.On("OpenFile", ctx, path, flag, perm)}
 }
 
-func (_c *mockSecretFs_OpenFile_Call) Run(run func(path string, flag int, perm fs.FileMode)) *mockSecretFs_OpenFile_Call {
+func (_c *mockSecretFs_OpenFile_Call) Run(run func(ctx context.Context, path string, flag int, perm fs.FileMode)) *mockSecretFs_OpenFile_Call {
 	_c.Call.Run(func(args mock.Arguments) {
-		run(args[0].(string), args[1].(int), args[2].(fs.FileMode))
+		run(args[0].(context.Context), args[1].(string), args[2].(int), args[3].(fs.FileMode))
 	})
 	return _c
 }
@@ -300,7 +305,7 @@ func (_c *mockSecretFs_OpenFile_Call) Return(_a0 filesystem.ClosableRWFile, _a1
 	return _c
 }
 
-func (_c *mockSecretFs_OpenFile_Call) RunAndReturn(run func(string, int, fs.FileMode) (filesystem.ClosableRWFile, error)) *mockSecretFs_OpenFile_Call {
+func (_c *mockSecretFs_OpenFile_Call) RunAndReturn(run func(context.Context, string, int, fs.FileMode) (filesystem.ClosableRWFile, error)) *mockSecretFs_OpenFile_Call {
 	_c.Call.Return(run)
 	return _c
 }
@@ -363,9 +368,9 @@ func (_c *mockSecretFs_ReadAll_Call) RunAndReturn(run func(io.Reader) ([]byte, e
 	return _c
 }
 
-// ReadDir provides a mock function with given fields: name
-func (_m *mockSecretFs) ReadDir(name string) ([]fs.DirEntry, error) {
-	ret := _m.Called(name)
+// ReadDir provides a mock function with given fields: ctx, name
+func (_m *mockSecretFs) ReadDir(ctx context.Context, name string) ([]fs.DirEntry, error) {
+	ret := _m.Called(ctx, name)
 
 	if len(ret) == 0 {
 		panic("no return value specified for ReadDir")
@@ -373,19 +378,19 @@ func (_m *mockSecretFs) ReadDir(name string) ([]fs.DirEntry, error) {
 
 	var r0 []fs.DirEntry
 	var r1 error
-	if rf, ok := ret.Get(0).(func(string) ([]fs.DirEntry, error)); ok {
-		return rf(name)
+	if rf, ok := ret.Get(0).(func(context.Context, string) ([]fs.DirEntry, error)); ok {
+		return rf(ctx, name)
 	}
-	if rf, ok := ret.Get(0).(func(string) []fs.DirEntry); ok {
-		r0 = rf(name)
+	if rf, ok := ret.Get(0).(func(context.Context, string) []fs.DirEntry); ok {
+		r0 = rf(ctx, name)
 	} else {
 		if ret.Get(0) != nil {
 			r0 = ret.Get(0).([]fs.DirEntry)
 		}
 	}
 
-	if rf, ok := ret.Get(1).(func(string) error); ok {
-		r1 = rf(name)
+	if rf, ok := ret.Get(1).(func(context.Context, string) error); ok {
+		r1 = rf(ctx, name)
 	} else {
 		r1 = ret.Error(1)
 	}
@@ -399,14 +404,15 @@ type mockSecretFs_ReadDir_Call struct {
 }
 
 // ReadDir is a helper method to define mock.On call
+//   - ctx context.Context
 //   - name string
-func (_e *mockSecretFs_Expecter) ReadDir(name interface{}) *mockSecretFs_ReadDir_Call {
-	return &mockSecretFs_ReadDir_Call{Call: _e.mock.On("ReadDir", name)}
+func (_e *mockSecretFs_Expecter) ReadDir(ctx interface{}, name interface{}) *mockSecretFs_ReadDir_Call {
+	return &mockSecretFs_ReadDir_Call{Call: _e.mock.On("ReadDir", ctx, name)}
 }
 
-func (_c *mockSecretFs_ReadDir_Call) Run(run func(name string)) *mockSecretFs_ReadDir_Call {
+func (_c *mockSecretFs_ReadDir_Call) Run(run func(ctx context.Context, name string)) *mockSecretFs_ReadDir_Call {
 	_c.Call.Run(func(args mock.Arguments) {
-		run(args[0].(string))
+		run(args[0].(context.Context), args[1].(string))
 	})
 	return _c
 }
@@ -416,22 +422,22 @@ func (_c *mockSecretFs_ReadDir_Call) Return(_a0 []fs.DirEntry, _a1 error) *mockS
 	return _c
 }
 
-func (_c *mockSecretFs_ReadDir_Call) RunAndReturn(run func(string) ([]fs.DirEntry, error)) *mockSecretFs_ReadDir_Call {
+func (_c *mockSecretFs_ReadDir_Call) RunAndReturn(run func(context.Context, string) ([]fs.DirEntry, error)) *mockSecretFs_ReadDir_Call {
 	_c.Call.Return(run)
 	return _c
 }
 
-// Remove provides a mock function with given fields: name
-func (_m *mockSecretFs) Remove(name string) error {
-	ret := _m.Called(name)
+// Remove provides a mock function with given fields: ctx, name
+func (_m *mockSecretFs) Remove(ctx context.Context, name string) error {
+	ret := _m.Called(ctx, name)
 
 	if len(ret) == 0 {
 		panic("no return value specified for Remove")
 	}
 
 	var r0 error
-	if rf, ok := ret.Get(0).(func(string) error); ok {
-		r0 = rf(name)
+	if rf, ok := ret.Get(0).(func(context.Context, string) error); ok {
+		r0 = rf(ctx, name)
 	} else {
 		r0 = ret.Error(0)
 	}
@@ -445,14 +451,15 @@ type mockSecretFs_Remove_Call struct {
 }
 
 // Remove is a helper method to define mock.On call
+//   - ctx context.Context
 //   - name string
-func (_e *mockSecretFs_Expecter) Remove(name interface{}) *mockSecretFs_Remove_Call {
-	return &mockSecretFs_Remove_Call{Call: _e.mock.On("Remove", name)}
+func (_e *mockSecretFs_Expecter) Remove(ctx interface{}, name interface{}) *mockSecretFs_Remove_Call {
+	return &mockSecretFs_Remove_Call{Call: _e.mock.On("Remove", ctx, name)}
 }
 
-func (_c *mockSecretFs_Remove_Call) Run(run func(name string)) *mockSecretFs_Remove_Call {
+func (_c *mockSecretFs_Remove_Call) Run(run func(ctx context.Context, name string)) *mockSecretFs_Remove_Call {
 	_c.Call.Run(func(args mock.Arguments) {
-		run(args[0].(string))
+		run(args[0].(context.Context), args[1].(string))
 	})
 	return _c
 }
@@ -462,22 +469,22 @@ func (_c *mockSecretFs_Remove_Call) Return(_a0 error) *mockSecretFs_Remove_Call
 	return _c
 }
 
-func (_c *mockSecretFs_Remove_Call) RunAndReturn(run func(string) error) *mockSecretFs_Remove_Call {
+func (_c *mockSecretFs_Remove_Call) RunAndReturn(run func(context.Context, string) error) *mockSecretFs_Remove_Call {
 	_c.Call.Return(run)
 	return _c
 }
 
-// RemoveAll provides a mock function with given fields: path
-func (_m *mockSecretFs) RemoveAll(path string) error {
-	ret := _m.Called(path)
+// RemoveAll provides a mock function with given fields: ctx, path
+func (_m *mockSecretFs) RemoveAll(ctx context.Context, path string) error {
+	ret := _m.Called(ctx, path)
 
 	if len(ret) == 0 {
 		panic("no return value specified for RemoveAll")
 	}
 
 	var r0 error
-	if rf, ok := ret.Get(0).(func(string) error); ok {
-		r0 = rf(path)
+	if rf, ok := ret.Get(0).(func(context.Context, string) error); ok {
+		r0 = rf(ctx, path)
 	} else {
 		r0 = ret.Error(0)
 	}
@@ -491,14 +498,15 @@ type mockSecretFs_RemoveAll_Call struct {
 }
 
 // RemoveAll is a helper method to define mock.On call
+//   - ctx context.Context
 //   - path string
-func (_e *mockSecretFs_Expecter) RemoveAll(path interface{}) *mockSecretFs_RemoveAll_Call {
-	return &mockSecretFs_RemoveAll_Call{Call: _e.mock.On("RemoveAll", path)}
+func (_e *mockSecretFs_Expecter) RemoveAll(ctx interface{}, path interface{}) *mockSecretFs_RemoveAll_Call {
+	return &mockSecretFs_RemoveAll_Call{Call: _e.mock.On("RemoveAll", ctx, path)}
 }
 
-func (_c *mockSecretFs_RemoveAll_Call) Run(run func(path string)) *mockSecretFs_RemoveAll_Call {
+func (_c *mockSecretFs_RemoveAll_Call) Run(run func(ctx context.Context, path string)) *mockSecretFs_RemoveAll_Call {
 	_c.Call.Run(func(args mock.Arguments) {
-		run(args[0].(string))
+		run(args[0].(context.Context), args[1].(string))
 	})
 	return _c
 }
@@ -508,14 +516,62 @@ func (_c *mockSecretFs_RemoveAll_Call) Return(_a0 error) *mockSecretFs_RemoveAll
 	return _c
 }
 
-func (_c *mockSecretFs_RemoveAll_Call) RunAndReturn(run func(string) error) *mockSecretFs_RemoveAll_Call {
+func (_c *mockSecretFs_RemoveAll_Call) RunAndReturn(run func(context.Context, string) error) *mockSecretFs_RemoveAll_Call {
 	_c.Call.Return(run)
 	return _c
 }
 
-// Stat provides a mock function with given fields: name
-func (_m *mockSecretFs) Stat(name string) (fs.FileInfo, error) {
-	ret := _m.Called(name)
+// Rename provides a mock function with given fields: ctx, oldPath, newPath
+func (_m *mockSecretFs) Rename(ctx context.Context, oldPath string, newPath string) error {
+	ret := _m.Called(ctx, oldPath, newPath)
+
+	if len(ret) == 0 {
+		panic("no return value specified for Rename")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, string, string) error); ok {
+		r0 = rf(ctx, oldPath, newPath)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// mockSecretFs_Rename_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'Rename'
+type mockSecretFs_Rename_Call struct {
+	*mock.Call
+}
+
+// Rename is a helper method to define mock.On call
+//   - ctx context.Context
+//   - oldPath string
+//   - newPath string
+func (_e *mockSecretFs_Expecter) Rename(ctx interface{}, oldPath interface{}, newPath interface{}) *mockSecretFs_Rename_Call {
+	return &mockSecretFs_Rename_Call{Call: _e.mock.On("Rename", ctx, oldPath, newPath)}
+}
+
+func (_c *mockSecretFs_Rename_Call) Run(run func(ctx context.Context, oldPath string, newPath string)) *mockSecretFs_Rename_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(string), args[2].(string))
+	})
+	return _c
+}
+
+func (_c *mockSecretFs_Rename_Call) Return(_a0 error) *mockSecretFs_Rename_Call {
+	_c.Call.Return(_a0)
+	return _c
+}
+
+func (_c *mockSecretFs_Rename_Call) RunAndReturn(run func(context.Context, string, string) error) *mockSecretFs_Rename_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// Stat provides a mock function with given fields: ctx, name
+func (_m *mockSecretFs) Stat(ctx context.Context, name string) (fs.FileInfo, error) {
+	ret := _m.Called(ctx, name)
 
 	if len(ret) == 0 {
 		panic("no return value specified for Stat")
@@ -523,19 +579,19 @@ func (_m *mockSecretFs) Stat(name string) (fs.FileInfo, error) {
 
 	var r0 fs.FileInfo
 	var r1 error
-	if rf, ok := ret.Get(0).(func(string) (fs.FileInfo, error)); ok {
-		return rf(name)
+	if rf, ok := ret.Get(0).(func(context.Context, string) (fs.FileInfo, error)); ok {
+		return rf(ctx, name)
 	}
-	if rf, ok := ret.Get(0).(func(string) fs.FileInfo); ok {
-		r0 = rf(name)
+	if rf, ok := ret.Get(0).(func(context.Context, string) fs.FileInfo); ok {
+		r0 = rf(ctx, name)
 	} else {
 		if ret.Get(0) != nil {
 			r0 = ret.Get(0).(fs.FileInfo)
 		}
 	}
 
-	if rf, ok := ret.Get(1).(func(string) error); ok {
-		r1 = rf(name)
+	if rf, ok := ret.Get(1).(func(context.Context, string) error); ok {
+		r1 = rf(ctx, name)
 	} else {
 		r1 = ret.Error(1)
 	}
@@ -549,14 +605,15 @@ type mockSecretFs_Stat_Call struct {
 }
 
 // Stat is a helper method to define mock.On call
+//   - ctx context.Context
 //   - name string
-func (_e *mockSecretFs_Expecter) Stat(name interface{}) *mockSecretFs_Stat_Call {
-	return &mockSecretFs_Stat_Call{Call: _e.mock.On("Stat", name)}
+func (_e *mockSecretFs_Expecter) Stat(ctx interface{}, name interface{}) *mockSecretFs_Stat_Call {
+	return &mockSecretFs_Stat_Call{Call: _e.mock.On("Stat", ctx, name)}
 }
 
-func (_c *mockSecretFs_Stat_Call) Run(run func(name string)) *mockSecretFs_Stat_Call {
+func (_c *mockSecretFs_Stat_Call) Run(run func(ctx context.Context, name string)) *mockSecretFs_Stat_Call {
 	_c.Call.Run(func(args mock.Arguments) {
-		run(args[0].(string))
+		run(args[0].(context.Context), args[1].(string))
 	})
 	return _c
 }
@@ -566,22 +623,22 @@ func (_c *mockSecretFs_Stat_Call) Return(_a0 fs.FileInfo, _a1 error) *mockSecret
 	return _c
 }
 
-func (_c *mockSecretFs_Stat_Call) RunAndReturn(run func(string) (fs.FileInfo, error)) *mockSecretFs_Stat_Call {
+func (_c *mockSecretFs_Stat_Call) RunAndReturn(run func(context.Context, string) (fs.FileInfo, error)) *mockSecretFs_Stat_Call {
 	_c.Call.Return(run)
 	return _c
 }
 
-// WalkDir provides a mock function with given fields: root, fn
-func (_m *mockSecretFs) WalkDir(root string, fn fs.WalkDirFunc) error {
-	ret := _m.Called(root, fn)
+// WalkDir provides a mock function with given fields: ctx, root, fn
+func (_m *mockSecretFs) WalkDir(ctx context.Context, root string, fn fs.WalkDirFunc) error {
+	ret := _m.Called(ctx, root, fn)
 
 	if len(ret) == 0 {
 		panic("no return value specified for WalkDir")
 	}
 
 	var r0 error
-	if rf, ok := ret.Get(0).(func(string, fs.WalkDirFunc) error); ok {
-		r0 = rf(root, fn)
+	if rf, ok := ret.Get(0).(func(context.Context, string, fs.WalkDirFunc) error); ok {
+		r0 = rf(ctx, root, fn)
 	} else {
 		r0 = ret.Error(0)
 	}
@@ -595,15 +652,16 @@ type mockSecretFs_WalkDir_Call struct {
 }
 
 // WalkDir is a helper method to define mock.On call
+//   - ctx context.Context
 //   - root string
 //   - fn fs.WalkDirFunc
-func (_e *mockSecretFs_Expecter) WalkDir(root interface{}, fn interface{}) *mockSecretFs_WalkDir_Call {
-	return &mockSecretFs_WalkDir_Call{Call: _e.mock.On("WalkDir", root, fn)}
+func (_e *mockSecretFs_Expecter) WalkDir(ctx interface{}, root interface{}, fn interface{}) *mockSecretFs_WalkDir_Call {
+	return &mockSecretFs_WalkDir_Call{Call: _e.mock.On("WalkDir", ctx, root, fn)}
 }
 
-func (_c *mockSecretFs_WalkDir_Call) Run(run func(root string, fn fs.WalkDirFunc)) *mockSecretFs_WalkDir_Call {
+func (_c *mockSecretFs_WalkDir_Call) Run(run func(ctx context.Context, root string, fn fs.WalkDirFunc)) *mockSecretFs_WalkDir_Call {
 	_c.Call.Run(func(args mock.Arguments) {
-		run(args[0].(string), args[1].(fs.WalkDirFunc))
+		run(args[0].(context.Context), args[1].(string), args[2].(fs.WalkDirFunc))
 	})
 	return _c
 }
@@ -613,22 +671,22 @@ func (_c *mockSecretFs_WalkDir_Call) Return(_a0 error) *mockSecretFs_WalkDir_Cal
 	return _c
 }
 
-func (_c *mockSecretFs_WalkDir_Call) RunAndReturn(run func(string, fs.WalkDirFunc) error) *mockSecretFs_WalkDir_Call {
+func (_c *mockSecretFs_WalkDir_Call) RunAndReturn(run func(context.Context, string, fs.WalkDirFunc) error) *mockSecretFs_WalkDir_Call {
 	_c.Call.Return(run)
 	return _c
 }
 
-// WriteFile provides a mock function with given fields: name, data, perm
-func (_m *mockSecretFs) WriteFile(name string, data []byte, perm fs.FileMode) error {
-	ret := _m.Called(name, data, perm)
+// WriteFile provides a mock function with given fields: ctx, name, data, perm
+func (_m *mockSecretFs) WriteFile(ctx context.Context, name string, data []byte, perm fs.FileMode) error {
+	ret := _m.Called(ctx, name, data, perm)
 
 	if len(ret) == 0 {
 		panic("no return value specified for WriteFile")
 	}
 
 	var r0 error
-	if rf, ok := ret.Get(0).(func(string, []byte, fs.FileMode) error); ok {
-		r0 = rf(name, data, perm)
+	if rf, ok := ret.Get(0).(func(context.Context, string, []byte, fs.FileMode) error); ok {
+		r0 = rf(ctx, name, data, perm)
 	} else {
 		r0 = ret.Error(0)
 	}
@@ -642,16 +700,17 @@ type mockSecretFs_WriteFile_Call struct {
 }
 
 // WriteFile is a helper method to define mock.On call
+//   - ctx context.Context
 //   - name string
 //   - data []byte
 //   - perm fs.FileMode
-func (_e *mockSecretFs_Expecter) WriteFile(name interface{}, data interface{}, perm interface{}) *mockSecretFs_WriteFile_Call {
-	return &mockSecretFs_WriteFile_Call{Call: _e.mock.On("WriteFile", name, data, perm)}
+func (_e *mockSecretFs_Expecter) WriteFile(ctx interface{}, name interface{}, data interface{}, perm interface{}) *mockSecretFs_WriteFile_Call {
+	return &mockSecretFs_WriteFile_Call{Call: _e.mock.On("WriteFile", ctx, name, data, perm)}
 }
 
-func (_c *mockSecretFs_WriteFile_Call) Run(run func(name string, data []byte, perm fs.FileMode)) *mockSecretFs_WriteFile_Call {
+func (_c *mockSecretFs_WriteFile_Call) Run(run func(ctx context.Context, name string, data []byte, perm fs.FileMode)) *mockSecretFs_WriteFile_Call {
 	_c.Call.Run(func(args mock.Arguments) {
-		run(args[0].(string), args[1].([]byte), args[2].(fs.FileMode))
+		run(args[0].(context.Context), args[1].(string), args[2].([]byte), args[3].(fs.FileMode))
 	})
 	return _c
 }
@@ -661,7 +720,7 @@ func (_c *mockSecretFs_WriteFile_Call) Return(_a0 error) *mockSecretFs_WriteFile
 	return _c
 }
 
-func (_c *mockSecretFs_WriteFile_Call) RunAndReturn(run func(string, []byte, fs.FileMode) error) *mockSecretFs_WriteFile_Call {
+func (_c *mockSecretFs_WriteFile_Call) RunAndReturn(run func(context.Context, string, []byte, fs.FileMode) error) *mockSecretFs_WriteFile_Call {
 	_c.Call.Return(run)
 	return _c
 }
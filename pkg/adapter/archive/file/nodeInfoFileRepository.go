@@ -7,6 +7,7 @@ import (
 	"fmt"
 	"os"
 	"path/filepath"
+	"sync"
 
 	"github.com/cloudogu/k8s-support-archive-operator/pkg/domain"
 )
@@ -24,8 +25,10 @@ type NodeInfoRepository struct {
 	baseFileRepo
 	workPath      string
 	filesystem    volumeFs
-	nodeInfoFiles map[metricForID]*os.File
+	nodeInfoFiles map[metricForID]closableRWFile
 	writers       map[metricForID]*csv.Writer
+	// filesMu guards nodeInfoFiles and writers, because different archives are collected concurrently.
+	filesMu sync.Mutex
 }
 
 func NewNodeInfoFileRepository(workPath string, fs volumeFs) *NodeInfoRepository {
@@ -33,7 +36,7 @@ func NewNodeInfoFileRepository(workPath string, fs volumeFs) *NodeInfoRepository
 		workPath:      workPath,
 		filesystem:    fs,
 		baseFileRepo:  NewBaseFileRepository(workPath, archiveNodeInfoDirName, fs),
-		nodeInfoFiles: make(map[metricForID]*os.File),
+		nodeInfoFiles: make(map[metricForID]closableRWFile),
 		writers:       make(map[metricForID]*csv.Writer),
 	}
 }
@@ -44,32 +47,19 @@ func (v *NodeInfoRepository) Create(ctx context.Context, id domain.SupportArchiv
 
 // createNodeInfo writes the content from data to the volumeInfo file.
 // If the volumeInfo file exists, it overrides the existing file.
-func (v *NodeInfoRepository) createNodeInfo(_ context.Context, id domain.SupportArchiveID, data *domain.LabeledSample) error {
+func (v *NodeInfoRepository) createNodeInfo(ctx context.Context, id domain.SupportArchiveID, data *domain.LabeledSample) error {
 	idMetric := metricForID{
 		data.MetricName,
 		id,
 	}
 
-	if v.nodeInfoFiles[idMetric] == nil {
-		filePath := fmt.Sprintf("%s.csv", filepath.Join(v.workPath, id.Namespace, id.Name, archiveNodeInfoDirName, data.MetricName))
-		err := v.filesystem.MkdirAll(filepath.Dir(filePath), 0755)
-		if err != nil {
-			return fmt.Errorf("error creating directory for volume node info file: %w", err)
-		}
-		v.nodeInfoFiles[idMetric], err = os.OpenFile(filePath, os.O_APPEND|os.O_CREATE|os.O_WRONLY, 0666)
-		if err != nil {
-			return err
-		}
-
-		v.writers[idMetric] = csv.NewWriter(v.nodeInfoFiles[idMetric])
-		err = v.writers[idMetric].Write(data.GetHeader())
-		if err != nil {
-			return fmt.Errorf("failed to write header: %w", err)
-		}
+	writer, err := v.getOrCreateWriter(ctx, idMetric, data)
+	if err != nil {
+		return err
 	}
 
 	row := data.GetRow()
-	err := v.writers[idMetric].Write(row)
+	err = writer.Write(row)
 	if err != nil {
 		return fmt.Errorf("failed to write row: %w", err)
 	}
@@ -77,6 +67,36 @@ func (v *NodeInfoRepository) createNodeInfo(_ context.Context, id domain.Support
 	return nil
 }
 
+func (v *NodeInfoRepository) getOrCreateWriter(ctx context.Context, idMetric metricForID, data *domain.LabeledSample) (*csv.Writer, error) {
+	v.filesMu.Lock()
+	defer v.filesMu.Unlock()
+
+	if v.nodeInfoFiles[idMetric] != nil {
+		return v.writers[idMetric], nil
+	}
+
+	id := idMetric.SupportArchiveID
+	filePath := fmt.Sprintf("%s.csv", filepath.Join(v.workPath, id.Namespace, id.Name, archiveNodeInfoDirName, data.MetricName))
+	err := v.filesystem.MkdirAll(ctx, filepath.Dir(filePath), 0755)
+	if err != nil {
+		return nil, fmt.Errorf("error creating directory for volume node info file: %w", err)
+	}
+	file, err := v.filesystem.OpenFile(ctx, filePath, os.O_APPEND|os.O_CREATE|os.O_WRONLY, 0666)
+	if err != nil {
+		return nil, err
+	}
+	v.nodeInfoFiles[idMetric] = file
+
+	writer := csv.NewWriter(file)
+	v.writers[idMetric] = writer
+	err = writer.Write(data.GetHeader())
+	if err != nil {
+		return nil, fmt.Errorf("failed to write header: %w", err)
+	}
+
+	return writer, nil
+}
+
 func (v *NodeInfoRepository) finishCollection(ctx context.Context, id domain.SupportArchiveID) error {
 	var multiErr []error
 	err := v.close(ctx, id)
@@ -93,25 +113,43 @@ func (v *NodeInfoRepository) finishCollection(ctx context.Context, id domain.Sup
 }
 
 func (v *NodeInfoRepository) close(_ context.Context, id domain.SupportArchiveID) error {
+	writers, files := v.removeFiles(id)
+
+	for _, writer := range writers {
+		writer.Flush()
+	}
+
+	var multiErr []error
+	for _, file := range files {
+		closeErr := file.Close()
+		if closeErr != nil {
+			multiErr = append(multiErr, closeErr)
+		}
+	}
+
+	return errors.Join(multiErr...)
+}
+
+// removeFiles removes the writers and files of the archive, so that they can be closed without holding the lock.
+func (v *NodeInfoRepository) removeFiles(id domain.SupportArchiveID) ([]*csv.Writer, []closableRWFile) {
+	v.filesMu.Lock()
+	defer v.filesMu.Unlock()
+
+	var writers []*csv.Writer
 	for key, val := range v.writers {
 		if key.SupportArchiveID == id {
-			val.Flush()
-			val = nil
+			writers = append(writers, val)
 			delete(v.writers, key)
 		}
 	}
 
-	var multiErr []error
+	var files []closableRWFile
 	for key, val := range v.nodeInfoFiles {
 		if key.SupportArchiveID == id {
-			closeErr := val.Close()
-			if closeErr != nil {
-				multiErr = append(multiErr, closeErr)
-			}
-			val = nil
+			files = append(files, val)
 			delete(v.nodeInfoFiles, key)
 		}
 	}
 
-	return errors.Join(multiErr...)
+	return writers, files
 }
@@ -5,11 +5,14 @@ import (
 	"fmt"
 	"os"
 	"path/filepath"
+	"strings"
 	"testing"
 	"time"
 
+	"github.com/cloudogu/k8s-support-archive-operator/pkg/adapter/filesystem"
 	"github.com/cloudogu/k8s-support-archive-operator/pkg/domain"
 	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
 	"github.com/stretchr/testify/require"
 )
 
@@ -42,7 +45,7 @@ func TestNodeInfoRepository_Create(t *testing.T) {
 				filesystem: func(t *testing.T) volumeFs {
 					fs := newMockVolumeFs(t)
 					dir := filepath.Join("/data/work", testNamespace, testName, archiveNodeInfoDirName)
-					fs.EXPECT().MkdirAll(dir, os.FileMode(0755)).Return(assert.AnError)
+					fs.EXPECT().MkdirAll(mock.Anything, dir, os.FileMode(0755)).Return(assert.AnError)
 					return fs
 				},
 			},
@@ -71,9 +74,7 @@ func TestNodeInfoRepository_Create(t *testing.T) {
 					return m
 				},
 				filesystem: func(t *testing.T) volumeFs {
-					fs := newMockVolumeFs(t)
-					// Allow MkdirAll call on the NodeInfo directory and also create it on the real FS so os.OpenFile succeeds
-					return fs
+					return filesystem.FileSystem{}
 				},
 			},
 			args: args{
@@ -88,7 +89,6 @@ func TestNodeInfoRepository_Create(t *testing.T) {
 			wantErr: func(t *testing.T, err error) { require.NoError(t, err) },
 			assertFile: func(t *testing.T, workPath string, args args) {
 				csvPath := filepath.Join(workPath, testNamespace, testName, archiveNodeInfoDirName, args.sample.MetricName+".csv")
-				// Ensure directory exists for MkdirAll; Create will call MkdirAll itself. To be safe, let MkdirAll be a no-op via mock.
 				// Read file and verify contents
 				data, readErr := os.ReadFile(csvPath)
 				require.NoError(t, readErr)
@@ -108,19 +108,10 @@ func TestNodeInfoRepository_Create(t *testing.T) {
 				baseFileRepo:  tt.fields.baseFileRepo(t),
 				workPath:      wp,
 				filesystem:    fsMock,
-				nodeInfoFiles: make(map[metricForID]*os.File),
+				nodeInfoFiles: make(map[metricForID]closableRWFile),
 				writers:       make(map[metricForID]*csv.Writer),
 			}
 
-			// For the success case, ensure the directory exists on real FS and set matching expectation on mock
-			if tt.assertFile != nil {
-				dir := filepath.Join(wp, testNamespace, testName, archiveNodeInfoDirName)
-				if m, ok := fsMock.(*mockVolumeFs); ok {
-					m.EXPECT().MkdirAll(dir, os.FileMode(0755)).Return(nil)
-				}
-				_ = os.MkdirAll(dir, os.FileMode(0755))
-			}
-
 			dataStream := make(chan *domain.LabeledSample)
 			go func() {
 				dataStream <- tt.args.sample
@@ -134,3 +125,27 @@ func TestNodeInfoRepository_Create(t *testing.T) {
 		})
 	}
 }
+
+func TestNodeInfoRepository_concurrentArchives(t *testing.T) {
+	// given
+	workPath := t.TempDir()
+	sut := NewNodeInfoFileRepository(workPath, filesystem.FileSystem{})
+
+	// when
+	forConcurrentArchives(t, func(id domain.SupportArchiveID) {
+		for _, metric := range []string{"cpu", "memory"} {
+			for i := range 10 {
+				sample := &domain.LabeledSample{MetricName: metric, ID: fmt.Sprintf("node-%d", i), Value: 1, Time: time.Now()}
+				require.NoError(t, sut.createNodeInfo(testCtx, id, sample))
+			}
+		}
+		require.NoError(t, sut.close(testCtx, id))
+	})
+
+	// then
+	assert.Empty(t, sut.nodeInfoFiles)
+	assert.Empty(t, sut.writers)
+	content, err := os.ReadFile(filepath.Join(workPath, testNamespace, "archive-3", archiveNodeInfoDirName, "memory.csv"))
+	require.NoError(t, err)
+	assert.Equal(t, 11, strings.Count(string(content), "\n"))
+}
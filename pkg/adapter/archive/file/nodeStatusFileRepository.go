@@ -0,0 +1,48 @@
+package file
+
+import (
+	"context"
+	"fmt"
+	"path/filepath"
+
+	"github.com/cloudogu/k8s-support-archive-operator/pkg/domain"
+	"sigs.k8s.io/controller-runtime/pkg/log"
+)
+
+const (
+	archiveNodeStatusDirName = "NodeStatus"
+)
+
+type NodeStatusFileRepository struct {
+	baseFileRepo
+	workPath   string
+	filesystem volumeFs
+}
+
+func NewNodeStatusFileRepository(workPath string, fs volumeFs) *NodeStatusFileRepository {
+	return &NodeStatusFileRepository{
+		workPath:     workPath,
+		filesystem:   fs,
+		baseFileRepo: NewBaseFileRepository(workPath, archiveNodeStatusDirName, fs),
+	}
+}
+
+func (n *NodeStatusFileRepository) Create(ctx context.Context, id domain.SupportArchiveID, dataStream <-chan *domain.NodeStatus) error {
+	return create(ctx, id, dataStream, n.createNodeStatus, n.Delete, n.finishCollection, nil)
+}
+
+// createNodeStatus writes the status of a single node to its own file.
+// If the node status file exists, it overrides the existing file.
+func (n *NodeStatusFileRepository) createNodeStatus(ctx context.Context, id domain.SupportArchiveID, data *domain.NodeStatus) error {
+	logger := log.FromContext(ctx).WithName("NodeStatusFileRepository.createNodeStatus")
+	filePath := fmt.Sprintf("%s.yaml", filepath.Join(n.workPath, id.Namespace, id.Name, archiveNodeStatusDirName, data.Name))
+
+	err := createYAMLFile(ctx, n.filesystem, filePath, data)
+	if err != nil {
+		return err
+	}
+
+	logger.Info(fmt.Sprintf("created status file for node %s", data.Name))
+
+	return nil
+}
@@ -38,7 +38,7 @@ func (v *SecretsFileRepository) createCoreSecret(ctx context.Context, id domain.
 
 	filePath := filepath.Join(v.workPath, id.Namespace, id.Name, archiveSecretsInfoDirName, fmt.Sprintf("%s%s", data.Metadata.Name, ".yaml"))
 
-	err := createYAMLFile(v.filesystem, filePath, data)
+	err := createYAMLFile(ctx, v.filesystem, filePath, data)
 	if err != nil {
 		return err
 	}
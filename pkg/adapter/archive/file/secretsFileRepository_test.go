@@ -54,7 +54,7 @@ func TestSecretFileRepository_createCoreSecret(t *testing.T) {
 				workPath: testWorkPath,
 				filesystem: func(t *testing.T) secretFs {
 					fsMock := newMockSecretFs(t)
-					fsMock.EXPECT().MkdirAll(testSecretWorkDirArchivePath, os.FileMode(0755)).Return(assert.AnError)
+					fsMock.EXPECT().MkdirAll(mock.Anything, testSecretWorkDirArchivePath, os.FileMode(0755)).Return(assert.AnError)
 
 					return fsMock
 				},
@@ -76,8 +76,8 @@ func TestSecretFileRepository_createCoreSecret(t *testing.T) {
 				workPath: testWorkPath,
 				filesystem: func(t *testing.T) secretFs {
 					fsMock := newMockSecretFs(t)
-					fsMock.EXPECT().MkdirAll(testSecretWorkDirArchivePath, os.FileMode(0755)).Return(nil)
-					fsMock.EXPECT().WriteFile(testSecretWorkFile, mock.Anything, os.FileMode(0644)).Return(assert.AnError)
+					fsMock.EXPECT().MkdirAll(mock.Anything, testSecretWorkDirArchivePath, os.FileMode(0755)).Return(nil)
+					fsMock.EXPECT().WriteFile(mock.Anything, testSecretWorkFile, mock.Anything, os.FileMode(0644)).Return(assert.AnError)
 
 					return fsMock
 				},
@@ -105,8 +105,8 @@ func TestSecretFileRepository_createCoreSecret(t *testing.T) {
 						Metadata: domain.SecretYamlMetaData{Name: "secret"},
 					})
 					require.NoError(t, err)
-					fsMock.EXPECT().MkdirAll(testSecretWorkDirArchivePath, os.FileMode(0755)).Return(nil)
-					fsMock.EXPECT().WriteFile(testSecretWorkFile, out, os.FileMode(0644)).Return(nil)
+					fsMock.EXPECT().MkdirAll(mock.Anything, testSecretWorkDirArchivePath, os.FileMode(0755)).Return(nil)
+					fsMock.EXPECT().WriteFile(mock.Anything, testSecretWorkFile, out, os.FileMode(0644)).Return(nil)
 
 					return fsMock
 				},
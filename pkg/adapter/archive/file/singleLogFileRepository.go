@@ -5,6 +5,7 @@ import (
 	"fmt"
 	"os"
 	"path/filepath"
+	"sync"
 
 	"github.com/cloudogu/k8s-support-archive-operator/pkg/domain"
 	"sigs.k8s.io/controller-runtime/pkg/log"
@@ -15,7 +16,9 @@ type SingleLogFileRepository struct {
 	workPath   string
 	filesystem volumeFs
 	files      map[domain.SupportArchiveID]closableRWFile
-	dirName    string
+	// filesMu guards files, because different archives are collected concurrently.
+	filesMu sync.Mutex
+	dirName string
 }
 
 func NewSingleLogFileRepository(workPath, dirname string, fs volumeFs) *SingleLogFileRepository {
@@ -33,26 +36,12 @@ func (l *SingleLogFileRepository) Create(ctx context.Context, id domain.SupportA
 }
 
 func (l *SingleLogFileRepository) createLog(ctx context.Context, id domain.SupportArchiveID, data *domain.LogLine) error {
-	logger := log.FromContext(ctx).WithName("LogFileRepository.createLog")
-
-	if l.files[id] == nil {
-		filePath := filepath.Join(l.workPath, id.Namespace, id.Name, l.dirName, fmt.Sprintf("%s%s", "logs", ".log"))
-		err := l.filesystem.MkdirAll(filepath.Dir(filePath), 0755)
-		if err != nil {
-			return fmt.Errorf("failed to create directory %s: %w", filepath.Dir(filePath), err)
-		}
-		l.files[id], err = l.filesystem.OpenFile(filePath, os.O_APPEND|os.O_CREATE|os.O_WRONLY, os.FileMode(0666))
-		if err != nil {
-			return fmt.Errorf("failed to create log file %s: %w", filePath, err)
-		}
-		_, err = l.files[id].Write([]byte("LOGS\n"))
-		if err != nil {
-			return fmt.Errorf("failed to write header to log file %s: %w", filePath, err)
-		}
-		logger.Info(fmt.Sprintf("Created log file %s", filePath))
+	file, err := l.getOrCreateLogFile(ctx, id)
+	if err != nil {
+		return err
 	}
 
-	_, err := fmt.Fprintf(l.files[id], "%s\n", data.Value)
+	_, err = fmt.Fprintf(file, "%s\n", data.Value)
 	if err != nil {
 		return fmt.Errorf("failed to write data to log file %s: %w", id, err)
 	}
@@ -60,18 +49,46 @@ func (l *SingleLogFileRepository) createLog(ctx context.Context, id domain.Suppo
 	return nil
 }
 
-func (l *SingleLogFileRepository) close(_ context.Context, id domain.SupportArchiveID) error {
-	defer delete(l.files, id)
+func (l *SingleLogFileRepository) getOrCreateLogFile(ctx context.Context, id domain.SupportArchiveID) (closableRWFile, error) {
+	logger := log.FromContext(ctx).WithName("LogFileRepository.createLog")
 
-	if l.files == nil {
-		return nil
+	l.filesMu.Lock()
+	defer l.filesMu.Unlock()
+
+	if l.files[id] != nil {
+		return l.files[id], nil
+	}
+
+	filePath := filepath.Join(l.workPath, id.Namespace, id.Name, l.dirName, fmt.Sprintf("%s%s", "logs", ".log"))
+	err := l.filesystem.MkdirAll(ctx, filepath.Dir(filePath), 0755)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create directory %s: %w", filepath.Dir(filePath), err)
+	}
+	file, err := l.filesystem.OpenFile(ctx, filePath, os.O_APPEND|os.O_CREATE|os.O_WRONLY, os.FileMode(0666))
+	if err != nil {
+		return nil, fmt.Errorf("failed to create log file %s: %w", filePath, err)
+	}
+	l.files[id] = file
+	_, err = file.Write([]byte("LOGS\n"))
+	if err != nil {
+		return nil, fmt.Errorf("failed to write header to log file %s: %w", filePath, err)
 	}
+	logger.Info(fmt.Sprintf("Created log file %s", filePath))
+
+	return file, nil
+}
+
+func (l *SingleLogFileRepository) close(_ context.Context, id domain.SupportArchiveID) error {
+	l.filesMu.Lock()
+	file := l.files[id]
+	delete(l.files, id)
+	l.filesMu.Unlock()
 
-	if l.files[id] == nil {
+	if file == nil {
 		return nil
 	}
 
-	err := l.files[id].Close()
+	err := file.Close()
 	if err != nil {
 		return fmt.Errorf("failed to close log file %s: %w", id, err)
 	}
@@ -4,11 +4,16 @@ import (
 	"context"
 	"fmt"
 	"os"
+	"path/filepath"
+	"strings"
+	"sync"
 	"testing"
 
+	"github.com/cloudogu/k8s-support-archive-operator/pkg/adapter/filesystem"
 	"github.com/cloudogu/k8s-support-archive-operator/pkg/domain"
 
 	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
 	"github.com/stretchr/testify/require"
 )
 
@@ -46,7 +51,7 @@ func TestSingleLogFileRepository_createLog(t *testing.T) {
 				workPath: testWorkPath,
 				filesystem: func(t *testing.T, _ closableRWFile) volumeFs {
 					fsMock := newMockVolumeFs(t)
-					fsMock.EXPECT().MkdirAll(testWorkDirCollectorPath, os.FileMode(0755)).Return(assert.AnError)
+					fsMock.EXPECT().MkdirAll(mock.Anything, testWorkDirCollectorPath, os.FileMode(0755)).Return(assert.AnError)
 
 					return fsMock
 				},
@@ -76,8 +81,8 @@ func TestSingleLogFileRepository_createLog(t *testing.T) {
 				workPath: testWorkPath,
 				filesystem: func(t *testing.T, _ closableRWFile) volumeFs {
 					fsMock := newMockVolumeFs(t)
-					fsMock.EXPECT().MkdirAll(testWorkDirCollectorPath, os.FileMode(0755)).Return(nil)
-					fsMock.EXPECT().OpenFile(testWorkDirArchiveFile, os.O_APPEND|os.O_CREATE|os.O_WRONLY, os.FileMode(0666)).Return(nil, assert.AnError)
+					fsMock.EXPECT().MkdirAll(mock.Anything, testWorkDirCollectorPath, os.FileMode(0755)).Return(nil)
+					fsMock.EXPECT().OpenFile(mock.Anything, testWorkDirArchiveFile, os.O_APPEND|os.O_CREATE|os.O_WRONLY, os.FileMode(0666)).Return(nil, assert.AnError)
 
 					return fsMock
 				},
@@ -107,8 +112,8 @@ func TestSingleLogFileRepository_createLog(t *testing.T) {
 				workPath: testWorkPath,
 				filesystem: func(t *testing.T, fileMock closableRWFile) volumeFs {
 					fsMock := newMockVolumeFs(t)
-					fsMock.EXPECT().MkdirAll(testWorkDirCollectorPath, os.FileMode(0755)).Return(nil)
-					fsMock.EXPECT().OpenFile(testWorkDirArchiveFile, os.O_APPEND|os.O_CREATE|os.O_WRONLY, os.FileMode(0666)).Return(fileMock, nil)
+					fsMock.EXPECT().MkdirAll(mock.Anything, testWorkDirCollectorPath, os.FileMode(0755)).Return(nil)
+					fsMock.EXPECT().OpenFile(mock.Anything, testWorkDirArchiveFile, os.O_APPEND|os.O_CREATE|os.O_WRONLY, os.FileMode(0666)).Return(fileMock, nil)
 
 					return fsMock
 				},
@@ -140,8 +145,8 @@ func TestSingleLogFileRepository_createLog(t *testing.T) {
 				workPath: testWorkPath,
 				filesystem: func(t *testing.T, fileMock closableRWFile) volumeFs {
 					fsMock := newMockVolumeFs(t)
-					fsMock.EXPECT().MkdirAll(testWorkDirCollectorPath, os.FileMode(0755)).Return(nil)
-					fsMock.EXPECT().OpenFile(testWorkDirArchiveFile, os.O_APPEND|os.O_CREATE|os.O_WRONLY, os.FileMode(0666)).Return(fileMock, nil)
+					fsMock.EXPECT().MkdirAll(mock.Anything, testWorkDirCollectorPath, os.FileMode(0755)).Return(nil)
+					fsMock.EXPECT().OpenFile(mock.Anything, testWorkDirArchiveFile, os.O_APPEND|os.O_CREATE|os.O_WRONLY, os.FileMode(0666)).Return(fileMock, nil)
 
 					return fsMock
 				},
@@ -175,8 +180,8 @@ func TestSingleLogFileRepository_createLog(t *testing.T) {
 				workPath: testWorkPath,
 				filesystem: func(t *testing.T, fileMock closableRWFile) volumeFs {
 					fsMock := newMockVolumeFs(t)
-					fsMock.EXPECT().MkdirAll(testWorkDirCollectorPath, os.FileMode(0755)).Return(nil)
-					fsMock.EXPECT().OpenFile(testWorkDirArchiveFile, os.O_APPEND|os.O_CREATE|os.O_WRONLY, os.FileMode(0666)).Return(fileMock, nil)
+					fsMock.EXPECT().MkdirAll(mock.Anything, testWorkDirCollectorPath, os.FileMode(0755)).Return(nil)
+					fsMock.EXPECT().OpenFile(mock.Anything, testWorkDirArchiveFile, os.O_APPEND|os.O_CREATE|os.O_WRONLY, os.FileMode(0666)).Return(fileMock, nil)
 
 					return fsMock
 				},
@@ -357,3 +362,36 @@ func TestSingleLogFileRepository_close(t *testing.T) {
 		})
 	}
 }
+
+func TestSingleLogFileRepository_concurrentArchives(t *testing.T) {
+	// given
+	workPath := t.TempDir()
+	sut := NewSingleLogFileRepository(workPath, testCollectorDirName, filesystem.FileSystem{})
+
+	// when
+	forConcurrentArchives(t, func(id domain.SupportArchiveID) {
+		for i := range 10 {
+			require.NoError(t, sut.createLog(testCtx, id, &domain.LogLine{Value: fmt.Sprintf("line %d", i)}))
+		}
+		require.NoError(t, sut.close(testCtx, id))
+	})
+
+	// then
+	assert.Empty(t, sut.files)
+	content, err := os.ReadFile(filepath.Join(workPath, testNamespace, "archive-3", testCollectorDirName, "logs.log"))
+	require.NoError(t, err)
+	assert.Equal(t, 11, strings.Count(string(content), "\n"))
+}
+
+// forConcurrentArchives calls the function concurrently for multiple support archives.
+func forConcurrentArchives(t *testing.T, fn func(id domain.SupportArchiveID)) {
+	t.Helper()
+
+	var wg sync.WaitGroup
+	for i := range 5 {
+		wg.Go(func() {
+			fn(domain.SupportArchiveID{Namespace: testNamespace, Name: fmt.Sprintf("archive-%d", i)})
+		})
+	}
+	wg.Wait()
+}
@@ -0,0 +1,342 @@
+package file
+
+import (
+	"bytes"
+	"context"
+	_ "embed"
+	"fmt"
+	"html/template"
+	"path/filepath"
+	"slices"
+	"strings"
+	"time"
+
+	"github.com/cloudogu/k8s-support-archive-operator/pkg/domain"
+	"sigs.k8s.io/controller-runtime/pkg/log"
+)
+
+const (
+	// archiveSummaryDirName is only used in the work directory. The summary is placed in the root of the archive.
+	archiveSummaryDirName = "Summary"
+	maxSummaryEvents      = 100
+	summaryChartWidth     = 720
+	summaryChartHeight    = 200
+	clusterScopedName     = "Cluster-scoped resources"
+)
+
+var (
+	//go:embed summary.html.tmpl
+	summaryTemplateContent string
+	summaryTemplate        = template.Must(template.New("summary").Funcs(template.FuncMap{
+		"formatTime": formatSummaryTime,
+	}).Parse(summaryTemplateContent))
+	summaryChartColors = []string{"#1f77b4", "#ff7f0e", "#2ca02c", "#d62728", "#9467bd", "#8c564b", "#e377c2", "#7f7f7f"}
+)
+
+type summaryView struct {
+	ID         domain.SupportArchiveID
+	CreatedAt  time.Time
+	Start      time.Time
+	End        time.Time
+	Collectors []domain.CollectorSummary
+	Findings   []domain.Finding
+	Charts     []summaryChart
+	Volumes    []summaryVolume
+	Events     []*domain.Event
+	Namespaces []summaryNamespace
+}
+
+type summaryChart struct {
+	Title    string
+	Width    int
+	Height   int
+	MaxValue string
+	Start    time.Time
+	End      time.Time
+	Series   []summarySeries
+}
+
+type summarySeries struct {
+	Name  string
+	Color string
+	// Points contains the coordinates of the polyline, e.g. "0,200 10,150".
+	Points string
+}
+
+type summaryVolume struct {
+	domain.VolumeInfoItem
+	Capacity string
+	Used     string
+	Percent  string
+}
+
+type summaryNamespace struct {
+	Name  string
+	Kinds []summaryKind
+}
+
+type summaryKind struct {
+	Kind      string
+	Resources []domain.ResourceStatus
+}
+
+// SummaryFileRepository writes a self-contained html page giving an overview of the archive.
+type SummaryFileRepository struct {
+	baseFileRepo
+	workPath   string
+	filesystem volumeFs
+}
+
+func NewSummaryFileRepository(workPath string, fs volumeFs) *SummaryFileRepository {
+	return &SummaryFileRepository{
+		workPath:     workPath,
+		filesystem:   fs,
+		baseFileRepo: NewBaseFileRepository(workPath, archiveSummaryDirName, fs),
+	}
+}
+
+// Create renders the summary as html without any external assets. An existing summary is overwritten.
+func (s *SummaryFileRepository) Create(ctx context.Context, id domain.SupportArchiveID, summary *domain.ArchiveSummary) error {
+	logger := log.FromContext(ctx).WithName("SummaryFileRepository.Create")
+
+	var out bytes.Buffer
+	err := summaryTemplate.Execute(&out, toSummaryView(summary))
+	if err != nil {
+		return fmt.Errorf("failed to render summary: %w", err)
+	}
+
+	dirPath := filepath.Join(s.workPath, id.Namespace, id.Name, archiveSummaryDirName)
+	err = s.filesystem.MkdirAll(ctx, dirPath, 0755)
+	if err != nil {
+		return fmt.Errorf("failed to create directory %s: %w", dirPath, err)
+	}
+
+	filePath := filepath.Join(dirPath, domain.SummaryFileName)
+	err = s.filesystem.WriteFile(ctx, filePath, out.Bytes(), 0644)
+	if err != nil {
+		return fmt.Errorf("failed to write file %s: %w", filePath, err)
+	}
+	logger.Info("created summary")
+
+	return nil
+}
+
+func toSummaryView(summary *domain.ArchiveSummary) summaryView {
+	data := summary.Data
+	if data == nil {
+		data = &domain.CollectedData{}
+	}
+
+	return summaryView{
+		ID:         summary.ID,
+		CreatedAt:  summary.CreatedAt,
+		Start:      data.Start,
+		End:        data.End,
+		Collectors: summary.Collectors,
+		Findings:   summary.Findings,
+		Charts:     toSummaryCharts(data.NodeInfo),
+		Volumes:    toSummaryVolumes(data.Volumes),
+		Events:     recentWarningEvents(data.Events),
+		Namespaces: toSummaryNamespaces(data.Resources),
+	}
+}
+
+// toSummaryCharts creates a line chart with one series per node for every metric.
+func toSummaryCharts(samples []domain.LabeledSample) []summaryChart {
+	samplesByMetric := make(map[string][]domain.LabeledSample)
+	for _, sample := range samples {
+		samplesByMetric[sample.MetricName] = append(samplesByMetric[sample.MetricName], sample)
+	}
+
+	var charts []summaryChart
+	for _, metric := range sortedKeys(samplesByMetric) {
+		charts = append(charts, toSummaryChart(metric, samplesByMetric[metric]))
+	}
+
+	return charts
+}
+
+func toSummaryChart(metric string, samples []domain.LabeledSample) summaryChart {
+	start, end := samples[0].Time, samples[0].Time
+	maxValue := 0.0
+	samplesByNode := make(map[string][]domain.LabeledSample)
+	for _, sample := range samples {
+		if sample.Time.Before(start) {
+			start = sample.Time
+		}
+		if sample.Time.After(end) {
+			end = sample.Time
+		}
+		maxValue = max(maxValue, sample.Value)
+		samplesByNode[sample.ID] = append(samplesByNode[sample.ID], sample)
+	}
+
+	chart := summaryChart{
+		Title:    metric,
+		Width:    summaryChartWidth,
+		Height:   summaryChartHeight,
+		MaxValue: fmt.Sprintf("%.2f", maxValue),
+		Start:    start,
+		End:      end,
+	}
+	if maxValue == 0 {
+		maxValue = 1
+	}
+	duration := end.Sub(start)
+
+	for i, node := range sortedKeys(samplesByNode) {
+		nodeSamples := samplesByNode[node]
+		slices.SortFunc(nodeSamples, func(a, b domain.LabeledSample) int {
+			return a.Time.Compare(b.Time)
+		})
+
+		points := make([]chartPoint, 0, len(nodeSamples))
+		for _, sample := range nodeSamples {
+			x := 0.0
+			if duration > 0 {
+				x = float64(sample.Time.Sub(start)) / float64(duration) * summaryChartWidth
+			}
+			y := summaryChartHeight - sample.Value/maxValue*summaryChartHeight
+			points = append(points, chartPoint{x: x, y: y})
+		}
+		chart.Series = append(chart.Series, summarySeries{
+			Name:   node,
+			Color:  summaryChartColors[i%len(summaryChartColors)],
+			Points: formatPoints(downsamplePoints(points)),
+		})
+	}
+
+	return chart
+}
+
+type chartPoint struct {
+	x, y float64
+}
+
+// downsamplePoints keeps the minimum and maximum of every pixel column, so that the size of a chart does not grow with
+// the number of samples while peaks remain visible. The points must be sorted by x.
+func downsamplePoints(points []chartPoint) []chartPoint {
+	var result []chartPoint
+	for len(points) > 0 {
+		column := int(points[0].x)
+		end := 1
+		for end < len(points) && int(points[end].x) == column {
+			end++
+		}
+
+		minIndex, maxIndex := 0, 0
+		for i, point := range points[:end] {
+			if point.y < points[minIndex].y {
+				minIndex = i
+			}
+			if point.y > points[maxIndex].y {
+				maxIndex = i
+			}
+		}
+		// keep the chronological order of the extremes
+		result = append(result, points[min(minIndex, maxIndex)])
+		if minIndex != maxIndex {
+			result = append(result, points[max(minIndex, maxIndex)])
+		}
+
+		points = points[end:]
+	}
+
+	return result
+}
+
+func formatPoints(points []chartPoint) string {
+	formatted := make([]string, 0, len(points))
+	for _, point := range points {
+		formatted = append(formatted, fmt.Sprintf("%.1f,%.1f", point.x, point.y))
+	}
+
+	return strings.Join(formatted, " ")
+}
+
+func toSummaryVolumes(volumeInfos []domain.VolumeInfo) []summaryVolume {
+	var volumes []summaryVolume
+	for _, volumeInfo := range volumeInfos {
+		for _, item := range volumeInfo.Items {
+			volume := summaryVolume{
+				VolumeInfoItem: item,
+				Capacity:       domain.FormatBytes(item.Capacity),
+				Used:           domain.FormatBytes(item.Used),
+				Percent:        "0.0",
+			}
+			if item.Capacity > 0 {
+				volume.Percent = fmt.Sprintf("%.1f", float64(item.Used)/float64(item.Capacity)*100)
+			}
+			volumes = append(volumes, volume)
+		}
+	}
+	slices.SortFunc(volumes, func(a, b summaryVolume) int {
+		return strings.Compare(a.Name, b.Name)
+	})
+
+	return volumes
+}
+
+// recentWarningEvents returns the latest warning events first.
+func recentWarningEvents(events []*domain.Event) []*domain.Event {
+	var warnings []*domain.Event
+	for _, event := range events {
+		if event.Type == "Warning" {
+			warnings = append(warnings, event)
+		}
+	}
+	slices.SortStableFunc(warnings, func(a, b *domain.Event) int {
+		return b.LastTimestamp.Compare(a.LastTimestamp)
+	})
+
+	return warnings[:min(len(warnings), maxSummaryEvents)]
+}
+
+// toSummaryNamespaces groups the resources by namespace and kind. Cluster-scoped resources come first.
+func toSummaryNamespaces(resources []domain.ResourceStatus) []summaryNamespace {
+	resourcesByNamespace := make(map[string]map[string][]domain.ResourceStatus)
+	for _, resource := range resources {
+		if resourcesByNamespace[resource.Namespace] == nil {
+			resourcesByNamespace[resource.Namespace] = make(map[string][]domain.ResourceStatus)
+		}
+		resourcesByNamespace[resource.Namespace][resource.Kind] = append(resourcesByNamespace[resource.Namespace][resource.Kind], resource)
+	}
+
+	var namespaces []summaryNamespace
+	for _, namespace := range sortedKeys(resourcesByNamespace) {
+		summary := summaryNamespace{Name: namespace}
+		if namespace == "" {
+			summary.Name = clusterScopedName
+		}
+
+		resourcesByKind := resourcesByNamespace[namespace]
+		for _, kind := range sortedKeys(resourcesByKind) {
+			kindResources := resourcesByKind[kind]
+			slices.SortFunc(kindResources, func(a, b domain.ResourceStatus) int {
+				return strings.Compare(a.Name, b.Name)
+			})
+			summary.Kinds = append(summary.Kinds, summaryKind{Kind: kind, Resources: kindResources})
+		}
+		namespaces = append(namespaces, summary)
+	}
+
+	return namespaces
+}
+
+func sortedKeys[V any](m map[string]V) []string {
+	keys := make([]string, 0, len(m))
+	for key := range m {
+		keys = append(keys, key)
+	}
+	slices.Sort(keys)
+
+	return keys
+}
+
+func formatSummaryTime(t time.Time) string {
+	if t.IsZero() {
+		return "-"
+	}
+
+	return t.UTC().Format(time.RFC3339)
+}
@@ -0,0 +1,192 @@
+package file
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/cloudogu/k8s-support-archive-operator/pkg/adapter/filesystem"
+	"github.com/cloudogu/k8s-support-archive-operator/pkg/domain"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+)
+
+const testSummaryWorkDirArchivePath = testWorkPath + "/" + testNamespace + "/" + testName + "/Summary"
+
+var testSummaryTime = time.Date(2025, 9, 1, 10, 0, 0, 0, time.UTC)
+
+func TestNewSummaryFileRepository(t *testing.T) {
+	// given
+	fsMock := newMockVolumeFs(t)
+
+	// when
+	repository := NewSummaryFileRepository(testWorkPath, fsMock)
+
+	// then
+	assert.NotNil(t, repository)
+	assert.Equal(t, testWorkPath, repository.workPath)
+	assert.Equal(t, fsMock, repository.filesystem)
+	assert.NotEmpty(t, repository.baseFileRepo)
+}
+
+func TestSummaryFileRepository_Create(t *testing.T) {
+	t.Run("should write self-contained html summary", func(t *testing.T) {
+		// given
+		workPath := t.TempDir()
+		sut := NewSummaryFileRepository(workPath, filesystem.FileSystem{})
+		summary := &domain.ArchiveSummary{
+			ID:        testID,
+			CreatedAt: testSummaryTime,
+			Collectors: []domain.CollectorSummary{
+				{Type: domain.CollectorTypeEvents, SkippedReason: "events error"},
+				{Type: domain.CollectorTypeNodeInfo},
+			},
+			Findings: []domain.Finding{{Rule: "VolumeUsage", Severity: domain.FindingSeverityCritical, Object: "PersistentVolumeClaim/ldap", Message: "<script>alert(1)</script>"}},
+			Data: &domain.CollectedData{
+				Start: testSummaryTime.Add(-time.Hour),
+				End:   testSummaryTime,
+				NodeInfo: []domain.LabeledSample{
+					{MetricName: "ramUsedRelative", ID: "node-1", Value: 50, Time: testSummaryTime},
+					{MetricName: "ramUsedRelative", ID: "node-1", Value: 100, Time: testSummaryTime.Add(-time.Hour)},
+				},
+				Volumes: []domain.VolumeInfo{{Items: []domain.VolumeInfoItem{{Name: "ldap-data", Capacity: 2 * 1024 * 1024 * 1024, Used: 1024 * 1024 * 1024, Phase: "Bound"}}}},
+				Events: []*domain.Event{
+					{Type: "Normal", Reason: "Pulled"},
+					{Type: "Warning", Reason: "BackOff", Namespace: "ecosystem", InvolvedObject: domain.EventObject{Kind: "Pod", Name: "ldap-0"}, Count: 3, LastTimestamp: testSummaryTime},
+				},
+				Resources: []domain.ResourceStatus{
+					{Path: "Resources/SystemState/core/v1/Pod/ldap-0.yaml", Kind: "Pod", Namespace: "ecosystem", Name: "ldap-0", Phase: "Running",
+						Conditions: []domain.ResourceCondition{{Type: "Ready", Status: "False"}},
+						Containers: []domain.ContainerStatus{{Name: "ldap", RestartCount: 4}}},
+					{Path: "Resources/SystemState/core/v1/Node/node-1.yaml", Kind: "Node", Name: "node-1"},
+				},
+			},
+		}
+
+		// when
+		err := sut.Create(testCtx, testID, summary)
+
+		// then
+		require.NoError(t, err)
+		content, err := os.ReadFile(filepath.Join(workPath, testNamespace, testName, "Summary", "index.html"))
+		require.NoError(t, err)
+		html := string(content)
+		assert.Contains(t, html, "<h1>Support archive ecosystem/archive-123</h1>")
+		assert.Contains(t, html, "<tr><th>Content from</th><td>2025-09-01T09:00:00Z</td></tr>")
+		assert.Contains(t, html, `<tr><td>Events</td><td class="skipped">Skipped</td><td>events error</td></tr>`)
+		assert.Contains(t, html, "<tr><td>NodeInfo</td><td>Collected</td><td></td></tr>")
+		assert.Contains(t, html, "&lt;script&gt;alert(1)&lt;/script&gt;")
+		assert.Contains(t, html, `<h3>ramUsedRelative</h3>`)
+		assert.Contains(t, html, `points="0.0,0.0 720.0,100.0"`)
+		assert.Contains(t, html, "<tr><td>ldap-data</td><td>Bound</td><td></td><td>2.0 GiB</td><td>1.0 GiB</td><td>50.0%")
+		assert.Contains(t, html, "<td>BackOff</td>")
+		assert.NotContains(t, html, "Pulled")
+		assert.Contains(t, html, "<summary>Cluster-scoped resources</summary>")
+		assert.Contains(t, html, `<a href="Resources/SystemState/core/v1/Pod/ldap-0.yaml">ldap-0</a> (Running)`)
+		assert.Contains(t, html, `<tr class="abnormal"><td>Ready</td><td>False</td>`)
+		assert.Contains(t, html, "<tr><td>ldap</td><td>4</td></tr>")
+		assert.NotContains(t, html, "http://")
+		assert.NotContains(t, html, "https://")
+		assert.Less(t, strings.Index(html, "Cluster-scoped resources"), strings.Index(html, "<summary>ecosystem</summary>"))
+	})
+
+	t.Run("should write summary without collected data", func(t *testing.T) {
+		// given
+		workPath := t.TempDir()
+		sut := NewSummaryFileRepository(workPath, filesystem.FileSystem{})
+
+		// when
+		err := sut.Create(testCtx, testID, &domain.ArchiveSummary{ID: testID})
+
+		// then
+		require.NoError(t, err)
+		content, err := os.ReadFile(filepath.Join(workPath, testNamespace, testName, "Summary", "index.html"))
+		require.NoError(t, err)
+		assert.Contains(t, string(content), "No collectors were executed.")
+		assert.Contains(t, string(content), "No node metrics were collected.")
+		assert.Contains(t, string(content), "No resources were collected.")
+	})
+
+	t.Run("should return error on error writing file", func(t *testing.T) {
+		// given
+		fsMock := newMockVolumeFs(t)
+		fsMock.EXPECT().MkdirAll(mock.Anything, testSummaryWorkDirArchivePath, os.FileMode(0755)).Return(nil)
+		fsMock.EXPECT().WriteFile(mock.Anything, testSummaryWorkDirArchivePath+"/index.html", mock.Anything, os.FileMode(0644)).Return(assert.AnError)
+		sut := NewSummaryFileRepository(testWorkPath, fsMock)
+
+		// when
+		err := sut.Create(testCtx, testID, &domain.ArchiveSummary{ID: testID})
+
+		// then
+		require.Error(t, err)
+		assert.ErrorIs(t, err, assert.AnError)
+		assert.ErrorContains(t, err, "failed to write file")
+	})
+
+	t.Run("should return error on error creating directory", func(t *testing.T) {
+		// given
+		fsMock := newMockVolumeFs(t)
+		fsMock.EXPECT().MkdirAll(mock.Anything, testSummaryWorkDirArchivePath, os.FileMode(0755)).Return(assert.AnError)
+		sut := NewSummaryFileRepository(testWorkPath, fsMock)
+
+		// when
+		err := sut.Create(testCtx, testID, &domain.ArchiveSummary{ID: testID})
+
+		// then
+		require.Error(t, err)
+		assert.ErrorIs(t, err, assert.AnError)
+		assert.ErrorContains(t, err, "failed to create directory")
+	})
+}
+
+func Test_recentWarningEvents(t *testing.T) {
+	var events []*domain.Event
+	for i := 0; i < maxSummaryEvents+10; i++ {
+		events = append(events, &domain.Event{Type: "Warning", LastTimestamp: testSummaryTime.Add(time.Duration(i) * time.Minute)})
+	}
+
+	recent := recentWarningEvents(events)
+
+	require.Len(t, recent, maxSummaryEvents)
+	assert.Equal(t, events[len(events)-1], recent[0])
+}
+
+func Test_toSummaryChart(t *testing.T) {
+	t.Run("should downsample the samples to the width of the chart and keep peaks", func(t *testing.T) {
+		// given
+		var samples []domain.LabeledSample
+		for i := 0; i < 7*24*60; i++ {
+			samples = append(samples, domain.LabeledSample{MetricName: "cpuUsage", ID: "node1", Value: 1, Time: testSummaryTime.Add(time.Duration(i) * time.Minute)})
+		}
+		samples[5000].Value = 10
+
+		// when
+		chart := toSummaryChart("cpuUsage", samples)
+
+		// then
+		require.Len(t, chart.Series, 1)
+		points := strings.Split(chart.Series[0].Points, " ")
+		assert.LessOrEqual(t, len(points), 2*(summaryChartWidth+1))
+		assert.Equal(t, "0.0,180.0", points[0])
+		assert.Equal(t, "720.0,180.0", points[len(points)-1])
+		assert.Contains(t, points, "357.2,0.0")
+	})
+	t.Run("should keep all samples of short timeframes", func(t *testing.T) {
+		// given
+		samples := []domain.LabeledSample{
+			{MetricName: "cpuUsage", ID: "node1", Value: 1, Time: testSummaryTime},
+			{MetricName: "cpuUsage", ID: "node1", Value: 2, Time: testSummaryTime.Add(time.Hour)},
+			{MetricName: "cpuUsage", ID: "node1", Value: 4, Time: testSummaryTime.Add(2 * time.Hour)},
+		}
+
+		// when
+		chart := toSummaryChart("cpuUsage", samples)
+
+		// then
+		require.Len(t, chart.Series, 1)
+		assert.Equal(t, "0.0,150.0 360.0,100.0 720.0,0.0", chart.Series[0].Points)
+	})
+}
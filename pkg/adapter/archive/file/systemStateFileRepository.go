@@ -38,7 +38,7 @@ func (v *SystemStateFileRepository) createSystemState(ctx context.Context, id do
 	logger := log.FromContext(ctx).WithName("SystemStateFileRepository.createVolumeInfo")
 	filePath := fmt.Sprintf("%s.yaml", filepath.Join(v.workPath, id.Namespace, id.Name, archiveSystemStateDirName, data.Path, data.Name))
 
-	err := createYAMLFile(v.filesystem, filePath, data)
+	err := createYAMLFile(ctx, v.filesystem, filePath, data)
 	if err != nil {
 		return err
 	}
@@ -48,8 +48,8 @@ func (v *SystemStateFileRepository) createSystemState(ctx context.Context, id do
 	return nil
 }
 
-func createYAMLFile(filesystem volumeFs, filePath string, data interface{}) error {
-	err := filesystem.MkdirAll(filepath.Dir(filePath), 0755)
+func createYAMLFile(ctx context.Context, filesystem volumeFs, filePath string, data interface{}) error {
+	err := filesystem.MkdirAll(ctx, filepath.Dir(filePath), 0755)
 	if err != nil {
 		return fmt.Errorf("error creating directory for file: %w", err)
 	}
@@ -59,7 +59,7 @@ func createYAMLFile(filesystem volumeFs, filePath string, data interface{}) erro
 		return fmt.Errorf("error marshalling file: %w", err)
 	}
 
-	err = filesystem.WriteFile(filePath, out, 0644)
+	err = filesystem.WriteFile(ctx, filePath, out, 0644)
 	if err != nil {
 		return fmt.Errorf("error creating file: %w", err)
 	}
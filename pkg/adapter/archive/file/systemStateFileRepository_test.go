@@ -51,7 +51,7 @@ func TestSystemStateFileRepository_createSystemStateInfo(t *testing.T) {
 				workPath: testWorkPath,
 				filesystem: func(t *testing.T) volumeFs {
 					fsMock := newMockVolumeFs(t)
-					fsMock.EXPECT().MkdirAll(testSystemStateWorkDirArchivePath, os.FileMode(0755)).Return(assert.AnError)
+					fsMock.EXPECT().MkdirAll(mock.Anything, testSystemStateWorkDirArchivePath, os.FileMode(0755)).Return(assert.AnError)
 
 					return fsMock
 				},
@@ -73,8 +73,8 @@ func TestSystemStateFileRepository_createSystemStateInfo(t *testing.T) {
 				workPath: testWorkPath,
 				filesystem: func(t *testing.T) volumeFs {
 					fsMock := newMockVolumeFs(t)
-					fsMock.EXPECT().MkdirAll(testSystemStateWorkDirArchivePath, os.FileMode(0755)).Return(nil)
-					fsMock.EXPECT().WriteFile(testSystemStateWorkFile, mock.Anything, os.FileMode(0644)).Return(assert.AnError)
+					fsMock.EXPECT().MkdirAll(mock.Anything, testSystemStateWorkDirArchivePath, os.FileMode(0755)).Return(nil)
+					fsMock.EXPECT().WriteFile(mock.Anything, testSystemStateWorkFile, mock.Anything, os.FileMode(0644)).Return(assert.AnError)
 
 					return fsMock
 				},
@@ -96,8 +96,8 @@ func TestSystemStateFileRepository_createSystemStateInfo(t *testing.T) {
 				workPath: testWorkPath,
 				filesystem: func(t *testing.T) volumeFs {
 					fsMock := newMockVolumeFs(t)
-					fsMock.EXPECT().MkdirAll(testSystemStateWorkDirArchivePath, os.FileMode(0755)).Return(nil)
-					fsMock.EXPECT().WriteFile(testSystemStateWorkFile, mock.Anything, os.FileMode(0644)).Return(nil)
+					fsMock.EXPECT().MkdirAll(mock.Anything, testSystemStateWorkDirArchivePath, os.FileMode(0755)).Return(nil)
+					fsMock.EXPECT().WriteFile(mock.Anything, testSystemStateWorkFile, mock.Anything, os.FileMode(0644)).Return(nil)
 
 					return fsMock
 				},
@@ -0,0 +1,326 @@
+package file
+
+import (
+	"bufio"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"regexp"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/cloudogu/k8s-support-archive-operator/pkg/domain"
+	"sigs.k8s.io/controller-runtime/pkg/log"
+)
+
+const (
+	// archiveTimelineDirName is only used in the work directory. The timeline is placed in the root of the archive.
+	archiveTimelineDirName = "Timeline"
+	logFileName            = "logs.log"
+	logFileHeader          = "LOGS"
+	maxLogLineSize         = 1024 * 1024
+)
+
+var (
+	logLevelFields     = []string{"level", "lvl", "severity", "log.level"}
+	logMessageFields   = []string{"message", "msg"}
+	logfmtLevelMatcher = regexp.MustCompile(`(?i)\blevel=["']?(\w+)`)
+	plainLevelMatcher  = regexp.MustCompile(`\b(ERROR|FATAL|PANIC|WARN|WARNING)\b`)
+)
+
+// TimelineFileRepository builds the timeline from the data of other collectors in the work directory.
+type TimelineFileRepository struct {
+	baseFileRepo
+	workPath   string
+	filesystem volumeFs
+	// runSize is the maximum number of entries sorted in memory.
+	runSize int
+}
+
+func NewTimelineFileRepository(workPath string, fs volumeFs) *TimelineFileRepository {
+	return &TimelineFileRepository{
+		workPath:     workPath,
+		filesystem:   fs,
+		baseFileRepo: NewBaseFileRepository(workPath, archiveTimelineDirName, fs),
+		runSize:      timelineRunSize,
+	}
+}
+
+// Create merges warning and error log lines, warning events, container terminations and condition transitions
+// of the given collectors into a chronologically sorted timeline. Collectors without timeline relevant data are ignored.
+// An existing timeline is overwritten. The log lines are streamed and at most runSize entries are sorted in memory.
+// Larger timelines are written as sorted runs to the work directory and merged afterward.
+func (t *TimelineFileRepository) Create(ctx context.Context, id domain.SupportArchiveID, collectors []domain.CollectorType) (err error) {
+	logger := log.FromContext(ctx).WithName("TimelineFileRepository.Create")
+
+	dirPath := filepath.Join(t.workPath, id.Namespace, id.Name, archiveTimelineDirName)
+	err = t.filesystem.MkdirAll(ctx, dirPath, 0755)
+	if err != nil {
+		return fmt.Errorf("failed to create directory %s: %w", dirPath, err)
+	}
+
+	runs, err := newTimelineRuns(ctx, t.filesystem, filepath.Join(dirPath, timelineRunsDirName), t.runSize)
+	if err != nil {
+		return err
+	}
+	defer func() {
+		err = errors.Join(err, runs.remove())
+	}()
+
+	for _, col := range collectors {
+		var colErr error
+		switch col {
+		case domain.CollectorTypeLog:
+			colErr = t.addLogEntries(ctx, id, runs)
+		case domain.CollectorTypeEvents:
+			colErr = t.addEventEntries(ctx, id, runs)
+		case domain.CollectorTypeSystemState:
+			colErr = t.addSystemStateEntries(ctx, id, runs)
+		default:
+			continue
+		}
+		if colErr != nil {
+			return fmt.Errorf("failed to read timeline entries of collector %s: %w", col, colErr)
+		}
+	}
+
+	count, err := t.writeTimeline(ctx, filepath.Join(dirPath, domain.TimelineFileName), runs)
+	if err != nil {
+		return err
+	}
+	logger.Info("created timeline", "entries", count, "runs", len(runs.files))
+
+	return nil
+}
+
+func (t *TimelineFileRepository) writeTimeline(ctx context.Context, filePath string, runs *timelineRuns) (count int, err error) {
+	merger, err := runs.merge()
+	if err != nil {
+		return 0, err
+	}
+	defer func() {
+		err = errors.Join(err, merger.close())
+	}()
+
+	file, err := t.filesystem.OpenFile(ctx, filePath, os.O_TRUNC|os.O_CREATE|os.O_WRONLY, os.FileMode(0644))
+	if err != nil {
+		return 0, fmt.Errorf("failed to create timeline file %s: %w", filePath, err)
+	}
+	defer func() {
+		closeErr := file.Close()
+		if closeErr != nil {
+			err = errors.Join(err, fmt.Errorf("failed to close timeline file %s: %w", filePath, closeErr))
+		}
+	}()
+
+	writer := bufio.NewWriter(file)
+	encoder := json.NewEncoder(writer)
+	for {
+		entry, nextErr := merger.next()
+		if nextErr != nil {
+			return count, nextErr
+		}
+		if entry == nil {
+			break
+		}
+
+		err = encoder.Encode(entry)
+		if err != nil {
+			return count, fmt.Errorf("failed to write timeline entry: %w", err)
+		}
+		count++
+	}
+
+	err = writer.Flush()
+	if err != nil {
+		return count, fmt.Errorf("failed to write timeline file %s: %w", filePath, err)
+	}
+
+	return count, nil
+}
+
+func (t *TimelineFileRepository) addLogEntries(ctx context.Context, id domain.SupportArchiveID, runs *timelineRuns) error {
+	return scanLogIncidents(ctx, t.filesystem, filepath.Join(t.workPath, id.Namespace, id.Name, archiveLogDirName, logFileName), runs.add)
+}
+
+// logLineToTimelineEntry returns nil for log lines which are neither warnings nor errors.
+func logLineToTimelineEntry(line string) *domain.TimelineEntry {
+	line = strings.TrimSpace(line)
+	if line == "" || line == logFileHeader {
+		return nil
+	}
+
+	var fields map[string]any
+	err := json.Unmarshal([]byte(line), &fields)
+	if err != nil {
+		return nil
+	}
+
+	message := firstStringField(fields, logMessageFields)
+	if message == "" {
+		message = line
+	}
+
+	severity := toTimelineSeverity(firstStringField(fields, logLevelFields))
+	if severity == "" {
+		severity = findSeverityInMessage(message)
+	}
+	if severity == "" || severity == domain.TimelineSeverityInfo {
+		return nil
+	}
+
+	nanos, err := strconv.ParseInt(stringField(fields, "time_unix_nano"), 10, 64)
+	if err != nil {
+		return nil
+	}
+
+	entry := &domain.TimelineEntry{
+		Timestamp: time.Unix(0, nanos).UTC(),
+		Source:    domain.TimelineSourceLog,
+		Severity:  severity,
+		Namespace: stringField(fields, "stream_namespace"),
+		Message:   message,
+	}
+	if pod := stringField(fields, "stream_pod"); pod != "" {
+		entry.Object = fmt.Sprintf("Pod/%s", pod)
+	}
+
+	return entry
+}
+
+func findSeverityInMessage(message string) domain.TimelineSeverity {
+	match := logfmtLevelMatcher.FindStringSubmatch(message)
+	if match != nil {
+		return toTimelineSeverity(match[1])
+	}
+
+	match = plainLevelMatcher.FindStringSubmatch(message)
+	if match != nil {
+		return toTimelineSeverity(match[1])
+	}
+
+	return ""
+}
+
+func toTimelineSeverity(level string) domain.TimelineSeverity {
+	switch strings.ToLower(level) {
+	case "":
+		return ""
+	case "warn", "warning":
+		return domain.TimelineSeverityWarning
+	case "err", "error", "fatal", "panic", "critical", "crit":
+		return domain.TimelineSeverityError
+	default:
+		return domain.TimelineSeverityInfo
+	}
+}
+
+func (t *TimelineFileRepository) addEventEntries(ctx context.Context, id domain.SupportArchiveID, runs *timelineRuns) error {
+	events, err := readEvents(ctx, t.filesystem, filepath.Join(t.workPath, id.Namespace, id.Name, archiveEventsDirName, archiveEventsYamlName))
+	if err != nil {
+		return err
+	}
+
+	for _, event := range events {
+		if event.Type != "Warning" {
+			continue
+		}
+
+		message := event.Note
+		if event.Count > 1 {
+			message = fmt.Sprintf("%s (%d times since %s)", event.Note, event.Count, event.FirstTimestamp.UTC().Format(time.RFC3339))
+		}
+		err = runs.add(&domain.TimelineEntry{
+			Timestamp: event.LastTimestamp,
+			Source:    domain.TimelineSourceEvent,
+			Severity:  domain.TimelineSeverityWarning,
+			Namespace: event.InvolvedObject.Namespace,
+			Object:    event.InvolvedObject.String(),
+			Reason:    event.Reason,
+			Message:   message,
+		})
+		if err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+func (t *TimelineFileRepository) addSystemStateEntries(ctx context.Context, id domain.SupportArchiveID, runs *timelineRuns) error {
+	resources, err := readResourceStatuses(ctx, t.filesystem, filepath.Join(t.workPath, id.Namespace, id.Name, archiveSystemStateDirName))
+	if err != nil {
+		return err
+	}
+
+	for _, resource := range resources {
+		for _, entry := range resourceToTimelineEntries(resource) {
+			err = runs.add(entry)
+			if err != nil {
+				return err
+			}
+		}
+	}
+
+	return nil
+}
+
+// resourceToTimelineEntries returns the condition transitions and container terminations of a resource.
+func resourceToTimelineEntries(resource domain.ResourceStatus) []*domain.TimelineEntry {
+	var entries []*domain.TimelineEntry
+	for _, condition := range resource.Conditions {
+		if condition.LastTransitionTime.IsZero() {
+			continue
+		}
+
+		message := fmt.Sprintf("Condition %s changed to %s", condition.Type, condition.Status)
+		if condition.Message != "" {
+			message = fmt.Sprintf("%s: %s", message, condition.Message)
+		}
+		severity := domain.TimelineSeverityInfo
+		if condition.IsAbnormal() {
+			severity = domain.TimelineSeverityWarning
+		}
+		entries = append(entries, &domain.TimelineEntry{
+			Timestamp: condition.LastTransitionTime,
+			Source:    domain.TimelineSourceCondition,
+			Severity:  severity,
+			Namespace: resource.Namespace,
+			Object:    resource.Object(),
+			Reason:    condition.Reason,
+			Message:   message,
+		})
+	}
+
+	for _, container := range resource.Containers {
+		for _, termination := range container.Terminations {
+			if termination.FinishedAt.IsZero() {
+				continue
+			}
+
+			severity := domain.TimelineSeverityInfo
+			if termination.ExitCode != 0 {
+				severity = domain.TimelineSeverityError
+			}
+			message := fmt.Sprintf("Container %s terminated with exit code %d", container.Name, termination.ExitCode)
+			if termination.Message != "" {
+				message = fmt.Sprintf("%s: %s", message, termination.Message)
+			}
+			entries = append(entries, &domain.TimelineEntry{
+				Timestamp: termination.FinishedAt,
+				Source:    domain.TimelineSourceContainerTermination,
+				Severity:  severity,
+				Namespace: resource.Namespace,
+				Object:    resource.Object(),
+				Reason:    termination.Reason,
+				Message:   message,
+			})
+		}
+	}
+
+	return entries
+}
@@ -0,0 +1,227 @@
+package file
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/cloudogu/k8s-support-archive-operator/pkg/adapter/filesystem"
+	"github.com/cloudogu/k8s-support-archive-operator/pkg/domain"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+)
+
+const testTimelineSystemStatePod = `name: ldap-0
+path: core/v1/Pod
+content:
+  kind: Pod
+  metadata:
+    name: ldap-0
+    namespace: ecosystem
+  status:
+    conditions:
+      - type: Ready
+        status: "False"
+        reason: ContainersNotReady
+        lastTransitionTime: "2025-09-01T10:00:03Z"
+    containerStatuses:
+      - name: ldap
+        state:
+          running:
+            startedAt: "2025-09-01T10:00:05Z"
+        lastState:
+          terminated:
+            exitCode: 137
+            reason: OOMKilled
+            finishedAt: "2025-09-01T10:00:02Z"
+`
+
+const testTimelineEvents = `- namespace: ecosystem
+  involvedObject:
+    kind: Pod
+    namespace: ecosystem
+    name: ldap-0
+  reason: BackOff
+  type: Warning
+  note: Back-off restarting failed container
+  count: 3
+  firstTimestamp: 2025-09-01T09:00:00Z
+  lastTimestamp: 2025-09-01T10:00:04Z
+  sources: [KubernetesAPI]
+- namespace: ecosystem
+  involvedObject:
+    kind: Pod
+    name: ldap-0
+  reason: Pulled
+  type: Normal
+  count: 1
+  firstTimestamp: 2025-09-01T10:00:00Z
+  lastTimestamp: 2025-09-01T10:00:00Z
+`
+
+func TestNewTimelineFileRepository(t *testing.T) {
+	// given
+	fsMock := newMockVolumeFs(t)
+
+	// when
+	repository := NewTimelineFileRepository(testWorkPath, fsMock)
+
+	// then
+	assert.NotNil(t, repository)
+	assert.Equal(t, testWorkPath, repository.workPath)
+	assert.Equal(t, fsMock, repository.filesystem)
+	assert.NotEmpty(t, repository.baseFileRepo)
+	assert.Equal(t, timelineRunSize, repository.runSize)
+}
+
+func TestTimelineFileRepository_Create(t *testing.T) {
+	writeTestFile := func(t *testing.T, path, content string) {
+		require.NoError(t, os.MkdirAll(filepath.Dir(path), 0755))
+		require.NoError(t, os.WriteFile(path, []byte(content), 0644))
+	}
+
+	t.Run("should merge logs, events and system state in chronological order", func(t *testing.T) {
+		// given
+		workPath := t.TempDir()
+		archivePath := filepath.Join(workPath, testNamespace, testName)
+		writeTestFile(t, filepath.Join(archivePath, "Logs", "logs.log"), "LOGS\n"+
+			`{"level":"error","msg":"connection refused","time_unix_nano":"1756720801000000000","stream_pod":"ldap-0","stream_namespace":"ecosystem"}`+"\n\n"+
+			`{"level":"info","msg":"started","time_unix_nano":"1756720800000000000"}`+"\n"+
+			`{"message":"2025-09-01 WARN disk almost full","time_unix_nano":"1756720806000000000"}`+"\n")
+		writeTestFile(t, filepath.Join(archivePath, "Events", "events.yaml"), testTimelineEvents)
+		writeTestFile(t, filepath.Join(archivePath, "Resources", "SystemState", "core", "v1", "Pod", "ldap-0.yaml"), testTimelineSystemStatePod)
+		sut := NewTimelineFileRepository(workPath, filesystem.FileSystem{})
+
+		// when
+		err := sut.Create(testCtx, testID, []domain.CollectorType{domain.CollectorTypeLog, domain.CollectorTypeEvents, domain.CollectorTypeSystemState, domain.CollectorTypeSecret})
+
+		// then
+		require.NoError(t, err)
+		content, err := os.ReadFile(filepath.Join(archivePath, "Timeline", "timeline.jsonl"))
+		require.NoError(t, err)
+		lines := strings.Split(strings.TrimSpace(string(content)), "\n")
+		require.Len(t, lines, 5)
+		assert.JSONEq(t, `{"timestamp":"2025-09-01T10:00:01Z","source":"Log","severity":"Error","namespace":"ecosystem","object":"Pod/ldap-0","message":"connection refused"}`, lines[0])
+		assert.JSONEq(t, `{"timestamp":"2025-09-01T10:00:02Z","source":"ContainerTermination","severity":"Error","namespace":"ecosystem","object":"Pod/ldap-0","reason":"OOMKilled","message":"Container ldap terminated with exit code 137"}`, lines[1])
+		assert.JSONEq(t, `{"timestamp":"2025-09-01T10:00:03Z","source":"Condition","severity":"Warning","namespace":"ecosystem","object":"Pod/ldap-0","reason":"ContainersNotReady","message":"Condition Ready changed to False"}`, lines[2])
+		assert.JSONEq(t, `{"timestamp":"2025-09-01T10:00:04Z","source":"Event","severity":"Warning","namespace":"ecosystem","object":"Pod/ldap-0","reason":"BackOff","message":"Back-off restarting failed container (3 times since 2025-09-01T09:00:00Z)"}`, lines[3])
+		assert.JSONEq(t, `{"timestamp":"2025-09-01T10:00:06Z","source":"Log","severity":"Warning","message":"2025-09-01 WARN disk almost full"}`, lines[4])
+	})
+
+	t.Run("should merge sorted runs if the timeline exceeds the run size", func(t *testing.T) {
+		// given
+		workPath := t.TempDir()
+		archivePath := filepath.Join(workPath, testNamespace, testName)
+		logs := strings.Builder{}
+		logs.WriteString("LOGS\n")
+		// the lines of every time window are grouped by stream, so that the log file is not sorted
+		for _, second := range []int{5, 1, 7, 3, 6, 2, 4} {
+			logs.WriteString(fmt.Sprintf(`{"level":"error","msg":"line %d","time_unix_nano":"%d"}`+"\n", second, time.Date(2025, 9, 1, 10, 0, second, 0, time.UTC).UnixNano()))
+		}
+		writeTestFile(t, filepath.Join(archivePath, "Logs", "logs.log"), logs.String())
+		writeTestFile(t, filepath.Join(archivePath, "Events", "events.yaml"), testTimelineEvents)
+		// runs of an aborted creation are removed
+		writeTestFile(t, filepath.Join(archivePath, "Timeline", ".runs", "0.jsonl"), "invalid")
+		sut := NewTimelineFileRepository(workPath, filesystem.FileSystem{})
+		sut.runSize = 2
+
+		// when
+		err := sut.Create(testCtx, testID, []domain.CollectorType{domain.CollectorTypeLog, domain.CollectorTypeEvents})
+
+		// then
+		require.NoError(t, err)
+		content, err := os.ReadFile(filepath.Join(archivePath, "Timeline", "timeline.jsonl"))
+		require.NoError(t, err)
+		var messages []string
+		for _, line := range strings.Split(strings.TrimSpace(string(content)), "\n") {
+			entry := domain.TimelineEntry{}
+			require.NoError(t, json.Unmarshal([]byte(line), &entry))
+			messages = append(messages, entry.Message)
+		}
+		assert.Equal(t, []string{"line 1", "line 2", "line 3", "line 4", "Back-off restarting failed container (3 times since 2025-09-01T09:00:00Z)", "line 5", "line 6", "line 7"}, messages)
+		assert.NoDirExists(t, filepath.Join(archivePath, "Timeline", ".runs"))
+	})
+
+	t.Run("should keep the order of entries with the same timestamp across runs", func(t *testing.T) {
+		// given
+		workPath := t.TempDir()
+		archivePath := filepath.Join(workPath, testNamespace, testName)
+		logs := strings.Builder{}
+		logs.WriteString("LOGS\n")
+		for i := 0; i < 5; i++ {
+			logs.WriteString(fmt.Sprintf(`{"level":"error","msg":"line %d","time_unix_nano":"1756720801000000000"}`+"\n", i))
+		}
+		writeTestFile(t, filepath.Join(archivePath, "Logs", "logs.log"), logs.String())
+		sut := NewTimelineFileRepository(workPath, filesystem.FileSystem{})
+		sut.runSize = 2
+
+		// when
+		err := sut.Create(testCtx, testID, []domain.CollectorType{domain.CollectorTypeLog})
+
+		// then
+		require.NoError(t, err)
+		content, err := os.ReadFile(filepath.Join(archivePath, "Timeline", "timeline.jsonl"))
+		require.NoError(t, err)
+		lines := strings.Split(strings.TrimSpace(string(content)), "\n")
+		require.Len(t, lines, 5)
+		for i, line := range lines {
+			assert.Contains(t, line, fmt.Sprintf(`"line %d"`, i))
+		}
+	})
+
+	t.Run("should create empty timeline if no data was collected", func(t *testing.T) {
+		// given
+		workPath := t.TempDir()
+		sut := NewTimelineFileRepository(workPath, filesystem.FileSystem{})
+
+		// when
+		err := sut.Create(testCtx, testID, []domain.CollectorType{domain.CollectorTypeLog, domain.CollectorTypeEvents, domain.CollectorTypeSystemState})
+
+		// then
+		require.NoError(t, err)
+		content, err := os.ReadFile(filepath.Join(workPath, testNamespace, testName, "Timeline", "timeline.jsonl"))
+		require.NoError(t, err)
+		assert.Empty(t, content)
+	})
+
+	t.Run("should return error on invalid events file", func(t *testing.T) {
+		// given
+		workPath := t.TempDir()
+		writeTestFile(t, filepath.Join(workPath, testNamespace, testName, "Events", "events.yaml"), "invalid: [")
+		sut := NewTimelineFileRepository(workPath, filesystem.FileSystem{})
+
+		// when
+		err := sut.Create(testCtx, testID, []domain.CollectorType{domain.CollectorTypeEvents})
+
+		// then
+		require.Error(t, err)
+		assert.ErrorContains(t, err, "failed to read timeline entries of collector Events")
+	})
+
+	t.Run("should return error on error creating directory", func(t *testing.T) {
+		// given
+		fsMock := newMockVolumeFs(t)
+		fsMock.EXPECT().MkdirAll(mock.Anything, testWorkPath+"/"+testNamespace+"/"+testName+"/Timeline", os.FileMode(0755)).Return(assert.AnError)
+		sut := NewTimelineFileRepository(testWorkPath, fsMock)
+
+		// when
+		err := sut.Create(testCtx, testID, nil)
+
+		// then
+		require.Error(t, err)
+		assert.ErrorIs(t, err, assert.AnError)
+		assert.ErrorContains(t, err, "failed to create directory")
+	})
+}
+
+func Test_toTimelineSeverity(t *testing.T) {
+	assert.Equal(t, domain.TimelineSeverity(""), toTimelineSeverity(""))
+	assert.Equal(t, domain.TimelineSeverityWarning, toTimelineSeverity("WARN"))
+	assert.Equal(t, domain.TimelineSeverityError, toTimelineSeverity("fatal"))
+	assert.Equal(t, domain.TimelineSeverityInfo, toTimelineSeverity("debug"))
+}
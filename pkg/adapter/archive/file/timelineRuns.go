@@ -0,0 +1,235 @@
+package file
+
+import (
+	"bufio"
+	"container/heap"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+
+	"github.com/cloudogu/k8s-support-archive-operator/pkg/domain"
+)
+
+const (
+	// timelineRunSize is the maximum number of timeline entries which are sorted in memory.
+	timelineRunSize = 10000
+	// timelineRunsDirName contains the sorted runs while the timeline is created. It is removed afterward.
+	timelineRunsDirName = ".runs"
+)
+
+// timelineRuns sorts timeline entries with bounded memory. Entries are collected in memory until the run size is
+// reached, then they are sorted and written to a run file. The runs are merged into a single sorted sequence.
+type timelineRuns struct {
+	// ctx is the context of the timeline creation. The runs only exist during the creation.
+	ctx        context.Context
+	filesystem volumeFs
+	dirPath    string
+	size       int
+	entries    []*domain.TimelineEntry
+	files      []string
+}
+
+// newTimelineRuns removes runs of previous attempts, e.g. if the operator crashed during the creation.
+func newTimelineRuns(ctx context.Context, filesystem volumeFs, dirPath string, size int) (*timelineRuns, error) {
+	runs := &timelineRuns{ctx: ctx, filesystem: filesystem, dirPath: dirPath, size: size}
+	err := runs.remove()
+	if err != nil {
+		return nil, err
+	}
+
+	return runs, nil
+}
+
+func (r *timelineRuns) add(entry *domain.TimelineEntry) error {
+	r.entries = append(r.entries, entry)
+	if len(r.entries) < r.size {
+		return nil
+	}
+
+	return r.flush()
+}
+
+func (r *timelineRuns) flush() (err error) {
+	err = r.filesystem.MkdirAll(r.ctx, r.dirPath, 0755)
+	if err != nil {
+		return fmt.Errorf("failed to create directory %s: %w", r.dirPath, err)
+	}
+
+	filePath := filepath.Join(r.dirPath, fmt.Sprintf("%d.jsonl", len(r.files)))
+	file, err := r.filesystem.OpenFile(r.ctx, filePath, os.O_TRUNC|os.O_CREATE|os.O_WRONLY, os.FileMode(0644))
+	if err != nil {
+		return fmt.Errorf("failed to create timeline run %s: %w", filePath, err)
+	}
+	defer func() {
+		closeErr := file.Close()
+		if closeErr != nil {
+			err = errors.Join(err, fmt.Errorf("failed to close timeline run %s: %w", filePath, closeErr))
+		}
+	}()
+
+	domain.SortTimeline(r.entries)
+	writer := bufio.NewWriter(file)
+	encoder := json.NewEncoder(writer)
+	for _, entry := range r.entries {
+		err = encoder.Encode(entry)
+		if err != nil {
+			return fmt.Errorf("failed to write timeline run %s: %w", filePath, err)
+		}
+	}
+	err = writer.Flush()
+	if err != nil {
+		return fmt.Errorf("failed to write timeline run %s: %w", filePath, err)
+	}
+
+	r.files = append(r.files, filePath)
+	r.entries = nil
+
+	return nil
+}
+
+// merge returns the entries of all runs in chronological order. Entries with the same timestamp keep the order in
+// which they were added.
+func (r *timelineRuns) merge() (*timelineMerger, error) {
+	domain.SortTimeline(r.entries)
+	merger := &timelineMerger{}
+	for i, filePath := range r.files {
+		file, err := r.filesystem.Open(r.ctx, filePath)
+		if err != nil {
+			return nil, errors.Join(fmt.Errorf("failed to open timeline run %s: %w", filePath, err), merger.close())
+		}
+		merger.files = append(merger.files, file)
+		err = merger.push(&timelineRun{index: i, decoder: json.NewDecoder(bufio.NewReader(file))})
+		if err != nil {
+			return nil, errors.Join(err, merger.close())
+		}
+	}
+	// the entries in memory were added last
+	err := merger.push(&timelineRun{index: len(r.files), entries: r.entries})
+	if err != nil {
+		return nil, errors.Join(err, merger.close())
+	}
+
+	return merger, nil
+}
+
+func (r *timelineRuns) remove() error {
+	err := r.filesystem.RemoveAll(r.ctx, r.dirPath)
+	if err != nil {
+		return fmt.Errorf("failed to remove timeline runs %s: %w", r.dirPath, err)
+	}
+
+	return nil
+}
+
+// timelineRun is either a run file or the sorted entries in memory.
+type timelineRun struct {
+	index   int
+	head    *domain.TimelineEntry
+	decoder *json.Decoder
+	entries []*domain.TimelineEntry
+}
+
+// advance sets the head to the next entry of the run or nil if the run is exhausted.
+func (r *timelineRun) advance() error {
+	if r.decoder == nil {
+		r.head = nil
+		if len(r.entries) > 0 {
+			r.head, r.entries = r.entries[0], r.entries[1:]
+		}
+		return nil
+	}
+
+	entry := &domain.TimelineEntry{}
+	err := r.decoder.Decode(entry)
+	if errors.Is(err, io.EOF) {
+		r.head = nil
+		return nil
+	} else if err != nil {
+		return fmt.Errorf("failed to read timeline run: %w", err)
+	}
+	r.head = entry
+
+	return nil
+}
+
+// timelineMerger merges the runs with a heap ordered by the head of every run.
+type timelineMerger struct {
+	runs  timelineRunHeap
+	files []closableRWFile
+}
+
+func (m *timelineMerger) push(run *timelineRun) error {
+	err := run.advance()
+	if err != nil {
+		return err
+	}
+	if run.head != nil {
+		heap.Push(&m.runs, run)
+	}
+
+	return nil
+}
+
+// next returns the next entry in chronological order or nil if all runs are exhausted.
+func (m *timelineMerger) next() (*domain.TimelineEntry, error) {
+	if len(m.runs) == 0 {
+		return nil, nil
+	}
+
+	run := m.runs[0]
+	entry := run.head
+	err := run.advance()
+	if err != nil {
+		return nil, err
+	}
+	if run.head == nil {
+		heap.Pop(&m.runs)
+	} else {
+		heap.Fix(&m.runs, 0)
+	}
+
+	return entry, nil
+}
+
+func (m *timelineMerger) close() error {
+	var errs []error
+	for _, file := range m.files {
+		errs = append(errs, file.Close())
+	}
+
+	return errors.Join(errs...)
+}
+
+type timelineRunHeap []*timelineRun
+
+func (h timelineRunHeap) Len() int {
+	return len(h)
+}
+
+func (h timelineRunHeap) Less(i, j int) bool {
+	if h[i].head.Timestamp.Equal(h[j].head.Timestamp) {
+		return h[i].index < h[j].index
+	}
+
+	return h[i].head.Timestamp.Before(h[j].head.Timestamp)
+}
+
+func (h timelineRunHeap) Swap(i, j int) {
+	h[i], h[j] = h[j], h[i]
+}
+
+func (h *timelineRunHeap) Push(x any) {
+	*h = append(*h, x.(*timelineRun))
+}
+
+func (h *timelineRunHeap) Pop() any {
+	old := *h
+	run := old[len(old)-1]
+	*h = old[:len(old)-1]
+
+	return run
+}
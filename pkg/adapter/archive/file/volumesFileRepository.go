@@ -37,7 +37,7 @@ func (v *VolumesFileRepository) createVolumeInfo(ctx context.Context, id domain.
 	logger := log.FromContext(ctx).WithName("VolumesFileRepository.createVolumeInfo")
 	filePath := fmt.Sprintf("%s.yaml", filepath.Join(v.workPath, id.Namespace, id.Name, archiveVolumeInfoDirName, data.Name))
 
-	err := createYAMLFile(v.filesystem, filePath, data)
+	err := createYAMLFile(ctx, v.filesystem, filePath, data)
 	if err != nil {
 		return err
 	}
@@ -51,7 +51,7 @@ func TestVolumesFileRepository_createVolumeInfo(t *testing.T) {
 				workPath: testWorkPath,
 				filesystem: func(t *testing.T) volumeFs {
 					fsMock := newMockVolumeFs(t)
-					fsMock.EXPECT().MkdirAll(testVolumeWorkDirArchivePath, os.FileMode(0755)).Return(assert.AnError)
+					fsMock.EXPECT().MkdirAll(mock.Anything, testVolumeWorkDirArchivePath, os.FileMode(0755)).Return(assert.AnError)
 
 					return fsMock
 				},
@@ -73,8 +73,8 @@ func TestVolumesFileRepository_createVolumeInfo(t *testing.T) {
 				workPath: testWorkPath,
 				filesystem: func(t *testing.T) volumeFs {
 					fsMock := newMockVolumeFs(t)
-					fsMock.EXPECT().MkdirAll(testVolumeWorkDirArchivePath, os.FileMode(0755)).Return(nil)
-					fsMock.EXPECT().WriteFile(testVolumeWorkFile, mock.Anything, os.FileMode(0644)).Return(assert.AnError)
+					fsMock.EXPECT().MkdirAll(mock.Anything, testVolumeWorkDirArchivePath, os.FileMode(0755)).Return(nil)
+					fsMock.EXPECT().WriteFile(mock.Anything, testVolumeWorkFile, mock.Anything, os.FileMode(0644)).Return(assert.AnError)
 
 					return fsMock
 				},
@@ -96,8 +96,8 @@ func TestVolumesFileRepository_createVolumeInfo(t *testing.T) {
 				workPath: testWorkPath,
 				filesystem: func(t *testing.T) volumeFs {
 					fsMock := newMockVolumeFs(t)
-					fsMock.EXPECT().MkdirAll(testVolumeWorkDirArchivePath, os.FileMode(0755)).Return(nil)
-					fsMock.EXPECT().WriteFile(testVolumeWorkFile, mock.Anything, os.FileMode(0644)).Return(nil)
+					fsMock.EXPECT().MkdirAll(mock.Anything, testVolumeWorkDirArchivePath, os.FileMode(0755)).Return(nil)
+					fsMock.EXPECT().WriteFile(mock.Anything, testVolumeWorkFile, mock.Anything, os.FileMode(0644)).Return(nil)
 
 					return fsMock
 				},
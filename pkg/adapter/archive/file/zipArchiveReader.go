@@ -0,0 +1,441 @@
+package file
+
+import (
+	"archive/zip"
+	"bufio"
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"io/fs"
+	"path"
+	"path/filepath"
+	"slices"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/cloudogu/k8s-support-archive-operator/pkg/domain"
+	"gopkg.in/yaml.v3"
+)
+
+const grafanaEventsFileName = "events.log"
+
+var archiveLogLevelFields = append(slices.Clone(logLevelFields), "stream_detected_level")
+
+// grafanaEventLine is the format of the events in the log file expected by the grafana dashboards.
+type grafanaEventLine struct {
+	Count               int    `json:"count"`
+	Kind                string `json:"kind"`
+	Level               string `json:"level"`
+	Msg                 string `json:"msg"`
+	Name                string `json:"name"`
+	Namespace           string `json:"namespace"`
+	Reason              string `json:"reason"`
+	ReportingController string `json:"reportingcontroller,omitempty"`
+	Time                string `json:"time"`
+	TimeDay             int    `json:"time_day"`
+	TimeMonth           int    `json:"time_month"`
+	TimeUnixNano        string `json:"time_unix_nano"`
+	TimeYear            int    `json:"time_year"`
+	Type                string `json:"type"`
+}
+
+// ZipArchiveReader reads a downloaded support archive without access to the cluster.
+type ZipArchiveReader struct {
+	archive    *zip.Reader
+	closer     io.Closer
+	filesystem volumeFs
+}
+
+// OpenZipArchiveReader opens the archive at the given path with the filesystem. The filesystem is also used to export
+// files of the archive.
+func OpenZipArchiveReader(ctx context.Context, archivePath string, fs volumeFs) (*ZipArchiveReader, error) {
+	archive, closer, err := openZip(ctx, fs, archivePath)
+	if err != nil {
+		return nil, fmt.Errorf("failed to open archive %s: %w", archivePath, err)
+	}
+
+	return &ZipArchiveReader{archive: archive, closer: closer, filesystem: fs}, nil
+}
+
+// openZip opens the zip archive with the filesystem. The opened file has to support reading at offsets.
+func openZip(ctx context.Context, fs volumeFs, archivePath string) (*zip.Reader, io.Closer, error) {
+	info, err := fs.Stat(ctx, archivePath)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	file, err := fs.Open(ctx, archivePath)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	content, ok := file.(io.ReaderAt)
+	if !ok {
+		return nil, nil, errors.Join(fmt.Errorf("file %s does not support reading at offsets", archivePath), file.Close())
+	}
+
+	archive, err := zip.NewReader(content, info.Size())
+	if err != nil {
+		return nil, nil, errors.Join(err, file.Close())
+	}
+
+	return archive, file, nil
+}
+
+func (r *ZipArchiveReader) Close() error {
+	return r.closer.Close()
+}
+
+// Files returns the path and the uncompressed size of all files in the archive sorted by path.
+func (r *ZipArchiveReader) Files() []domain.ManifestFile {
+	var files []domain.ManifestFile
+	for _, file := range r.archive.File {
+		if file.FileInfo().IsDir() {
+			continue
+		}
+		files = append(files, domain.ManifestFile{Path: file.Name, Size: int64(file.UncompressedSize64)})
+	}
+	slices.SortFunc(files, func(a, b domain.ManifestFile) int {
+		return strings.Compare(a.Path, b.Path)
+	})
+
+	return files
+}
+
+// Open opens the file with the given path in the archive.
+func (r *ZipArchiveReader) Open(name string) (fs.File, error) {
+	return r.archive.Open(name)
+}
+
+// Manifest returns the manifest of the archive. Archives created by older versions have no manifest.
+func (r *ZipArchiveReader) Manifest() (*domain.ArchiveManifest, error) {
+	file, err := r.archive.Open(domain.ManifestFileName)
+	if err != nil {
+		return nil, fmt.Errorf("failed to open manifest: %w", err)
+	}
+	defer func() {
+		_ = file.Close()
+	}()
+
+	manifest := &domain.ArchiveManifest{}
+	err = json.NewDecoder(file).Decode(manifest)
+	if err != nil {
+		return nil, fmt.Errorf("failed to decode manifest: %w", err)
+	}
+
+	return manifest, nil
+}
+
+// Content returns the system state, the secrets, the volume info and the node metrics to compare the archive with
+// another archive.
+func (r *ZipArchiveReader) Content(ctx context.Context) (*domain.ArchiveContent, error) {
+	return readArchiveContent(ctx, zipContentFiles{archive: r.archive})
+}
+
+// Verify compares size and checksum of all files with the manifest.
+func (r *ZipArchiveReader) Verify() (*domain.ManifestVerification, error) {
+	manifest, err := r.Manifest()
+	if err != nil {
+		return nil, err
+	}
+
+	listed := make(map[string]domain.ManifestFile, len(manifest.Files))
+	for _, file := range manifest.Files {
+		listed[file.Path] = file
+	}
+
+	verification := &domain.ManifestVerification{}
+	for _, file := range r.Files() {
+		if file.Path == domain.ManifestFileName {
+			continue
+		}
+
+		expected, ok := listed[file.Path]
+		if !ok {
+			verification.Unlisted = append(verification.Unlisted, file.Path)
+			continue
+		}
+		delete(listed, file.Path)
+
+		checksum, size, checksumErr := r.checksum(file.Path)
+		if checksumErr != nil {
+			return nil, checksumErr
+		}
+		if checksum != expected.SHA256 || size != expected.Size {
+			verification.Mismatched = append(verification.Mismatched, file.Path)
+		}
+	}
+
+	for _, filePath := range sortedKeys(listed) {
+		verification.Missing = append(verification.Missing, filePath)
+	}
+
+	return verification, nil
+}
+
+func (r *ZipArchiveReader) checksum(filePath string) (string, int64, error) {
+	file, err := r.archive.Open(filePath)
+	if err != nil {
+		return "", 0, fmt.Errorf("failed to open file %s: %w", filePath, err)
+	}
+	defer func() {
+		_ = file.Close()
+	}()
+
+	hash := sha256.New()
+	size, err := io.Copy(hash, file)
+	if err != nil {
+		return "", 0, fmt.Errorf("failed to read file %s: %w", filePath, err)
+	}
+
+	return hex.EncodeToString(hash.Sum(nil)), size, nil
+}
+
+// Logs calls fn for every log line matching the filter. Missing logs are ignored.
+func (r *ZipArchiveReader) Logs(filter domain.LogFilter, fn func(line domain.ArchiveLogLine) error) error {
+	filePath := path.Join(archiveLogDirName, logFileName)
+	file, err := r.archive.Open(filePath)
+	if err != nil && errors.Is(err, fs.ErrNotExist) {
+		return nil
+	} else if err != nil {
+		return fmt.Errorf("failed to open file %s: %w", filePath, err)
+	}
+	defer func() {
+		_ = file.Close()
+	}()
+
+	scanner := bufio.NewScanner(file)
+	scanner.Buffer(make([]byte, 0, 64*1024), maxLogLineSize)
+	for scanner.Scan() {
+		line, ok := parseArchiveLogLine(scanner.Text())
+		if !ok || !filter.Matches(line) {
+			continue
+		}
+
+		err = fn(line)
+		if err != nil {
+			return err
+		}
+	}
+	if scanner.Err() != nil {
+		return fmt.Errorf("failed to read file %s: %w", filePath, scanner.Err())
+	}
+
+	return nil
+}
+
+// parseArchiveLogLine parses a json log line as written by Loki. The header and invalid lines are skipped.
+func parseArchiveLogLine(line string) (domain.ArchiveLogLine, bool) {
+	line = strings.TrimSpace(line)
+	if line == "" || line == logFileHeader {
+		return domain.ArchiveLogLine{}, false
+	}
+
+	var fields map[string]any
+	err := json.Unmarshal([]byte(line), &fields)
+	if err != nil {
+		return domain.ArchiveLogLine{}, false
+	}
+
+	logLine := domain.ArchiveLogLine{
+		Namespace: stringField(fields, "stream_namespace"),
+		Pod:       stringField(fields, "stream_pod"),
+		Container: stringField(fields, "stream_container"),
+		Level:     strings.ToLower(firstStringField(fields, archiveLogLevelFields)),
+		Message:   firstStringField(fields, logMessageFields),
+	}
+	if nanos, parseErr := strconv.ParseInt(stringField(fields, "time_unix_nano"), 10, 64); parseErr == nil {
+		logLine.Time = time.Unix(0, nanos).UTC()
+	}
+
+	return logLine, true
+}
+
+// NodeInfo returns the samples of all node metrics.
+func (r *ZipArchiveReader) NodeInfo() ([]domain.LabeledSample, error) {
+	var samples []domain.LabeledSample
+	for _, file := range r.filesIn(archiveNodeInfoDirName, ".csv") {
+		metricSamples, err := r.readNodeInfoFile(file)
+		if err != nil {
+			return nil, err
+		}
+		samples = append(samples, metricSamples...)
+	}
+
+	return samples, nil
+}
+
+func (r *ZipArchiveReader) readNodeInfoFile(file *zip.File) ([]domain.LabeledSample, error) {
+	reader, err := file.Open()
+	if err != nil {
+		return nil, fmt.Errorf("failed to open file %s: %w", file.Name, err)
+	}
+	defer func() {
+		_ = reader.Close()
+	}()
+
+	samples, err := parseNodeInfoCSV(reader, strings.TrimSuffix(path.Base(file.Name), ".csv"))
+	if err != nil {
+		return nil, fmt.Errorf("failed to read csv file %s: %w", file.Name, err)
+	}
+
+	return samples, nil
+}
+
+// filesIn returns the files with the given extension directly in the directory of the archive.
+func (r *ZipArchiveReader) filesIn(dirName, extension string) []*zip.File {
+	var files []*zip.File
+	for _, file := range r.archive.File {
+		if path.Dir(file.Name) == dirName && path.Ext(file.Name) == extension {
+			files = append(files, file)
+		}
+	}
+
+	return files
+}
+
+// ExportGrafana writes the logs, events and node metrics to the directory in the layout expected by the
+// provisioning of the grafana docker-compose setup.
+func (r *ZipArchiveReader) ExportGrafana(ctx context.Context, dirPath string) error {
+	var files []*zip.File
+	files = append(files, r.filesIn(archiveLogDirName, ".log")...)
+	files = append(files, r.filesIn(archiveNodeInfoDirName, ".csv")...)
+	files = append(files, r.filesIn(archiveEventsDirName, ".log")...)
+	for _, file := range files {
+		err := r.exportFile(ctx, file, dirPath)
+		if err != nil {
+			return err
+		}
+	}
+
+	// Archives of older versions already contain the events in the expected format.
+	if len(r.filesIn(archiveEventsDirName, ".log")) > 0 {
+		return nil
+	}
+
+	events, err := r.readEvents(path.Join(archiveEventsDirName, archiveEventsYamlName))
+	if err != nil {
+		return err
+	}
+	if events == nil {
+		return nil
+	}
+
+	return r.writeGrafanaEvents(ctx, events, filepath.Join(dirPath, archiveEventsDirName, grafanaEventsFileName))
+}
+
+func (r *ZipArchiveReader) exportFile(ctx context.Context, file *zip.File, dirPath string) error {
+	reader, err := file.Open()
+	if err != nil {
+		return fmt.Errorf("failed to open file %s: %w", file.Name, err)
+	}
+	defer func() {
+		_ = reader.Close()
+	}()
+
+	filePath := filepath.Join(dirPath, filepath.FromSlash(file.Name))
+	writer, err := r.createExportFile(ctx, filePath)
+	if err != nil {
+		return err
+	}
+	defer func() {
+		_ = writer.Close()
+	}()
+
+	_, err = r.filesystem.Copy(writer, reader)
+	if err != nil {
+		return fmt.Errorf("failed to copy file %s: %w", file.Name, err)
+	}
+
+	return nil
+}
+
+func (r *ZipArchiveReader) readEvents(filePath string) ([]*domain.Event, error) {
+	file, err := r.archive.Open(filePath)
+	if err != nil && errors.Is(err, fs.ErrNotExist) {
+		return nil, nil
+	} else if err != nil {
+		return nil, fmt.Errorf("failed to open file %s: %w", filePath, err)
+	}
+	defer func() {
+		_ = file.Close()
+	}()
+
+	var events []*domain.Event
+	err = yaml.NewDecoder(file).Decode(&events)
+	if err != nil && !errors.Is(err, io.EOF) {
+		return nil, fmt.Errorf("failed to decode file %s: %w", filePath, err)
+	}
+
+	return events, nil
+}
+
+// writeGrafanaEvents writes the events as json lines with the same header as the log file.
+func (r *ZipArchiveReader) writeGrafanaEvents(ctx context.Context, events []*domain.Event, filePath string) error {
+	writer, err := r.createExportFile(ctx, filePath)
+	if err != nil {
+		return err
+	}
+	defer func() {
+		_ = writer.Close()
+	}()
+
+	_, err = fmt.Fprintln(writer, logFileHeader)
+	if err != nil {
+		return fmt.Errorf("failed to write header to file %s: %w", filePath, err)
+	}
+
+	encoder := json.NewEncoder(writer)
+	for _, event := range events {
+		err = encoder.Encode(toGrafanaEventLine(event))
+		if err != nil {
+			return fmt.Errorf("failed to write event to file %s: %w", filePath, err)
+		}
+	}
+
+	return nil
+}
+
+func toGrafanaEventLine(event *domain.Event) grafanaEventLine {
+	timestamp := event.LastTimestamp.UTC()
+	level := "info"
+	if event.Type == "Warning" {
+		level = "warning"
+	}
+
+	return grafanaEventLine{
+		Count:               event.Count,
+		Kind:                event.InvolvedObject.Kind,
+		Level:               level,
+		Msg:                 event.Note,
+		Name:                event.InvolvedObject.Name,
+		Namespace:           event.Namespace,
+		Reason:              event.Reason,
+		ReportingController: event.ReportingController,
+		Time:                timestamp.String(),
+		TimeDay:             timestamp.Day(),
+		TimeMonth:           int(timestamp.Month()),
+		TimeUnixNano:        strconv.FormatInt(timestamp.UnixNano(), 10),
+		TimeYear:            timestamp.Year(),
+		Type:                event.Type,
+	}
+}
+
+func (r *ZipArchiveReader) createExportFile(ctx context.Context, filePath string) (closableRWFile, error) {
+	err := r.filesystem.MkdirAll(ctx, filepath.Dir(filePath), 0755)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create directory %s: %w", filepath.Dir(filePath), err)
+	}
+
+	file, err := r.filesystem.Create(ctx, filePath)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create file %s: %w", filePath, err)
+	}
+
+	return file, nil
+}
@@ -0,0 +1,333 @@
+package file
+
+import (
+	"archive/zip"
+	"crypto/sha256"
+	"encoding/hex"
+	"io"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/cloudogu/k8s-support-archive-operator/pkg/adapter/filesystem"
+	"github.com/cloudogu/k8s-support-archive-operator/pkg/domain"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+)
+
+const (
+	testArchiveLogs = `LOGS
+{"message":"started","stream_namespace":"ecosystem","stream_pod":"ldap-0","stream_container":"ldap","stream_detected_level":"info","time_unix_nano":"1735689600000000000"}
+{"msg":"failed","stream_namespace":"ecosystem","stream_pod":"cas-1","level":"ERROR","time_unix_nano":"1735689660000000000"}
+no json
+`
+	testArchiveNodeInfo = `label,value,time
+node-1,50.00,2025-01-01T00:00:00+00:00
+node-1,70.00,2025-01-01T00:01:00+00:00
+`
+	testArchiveEvents = `- namespace: ecosystem
+  involvedObject:
+    kind: Pod
+    name: ldap-0
+  reason: BackOff
+  type: Warning
+  note: Back-off restarting failed container
+  count: 3
+  firstTimestamp: 2025-01-01T00:00:00Z
+  lastTimestamp: 2025-01-01T00:05:00Z
+  sources: [KubernetesAPI]
+`
+)
+
+// writeTestZipArchive creates an archive with the given files and a manifest listing all of them.
+func writeTestZipArchive(t *testing.T, files map[string]string) string {
+	t.Helper()
+
+	archivePath := filepath.Join(t.TempDir(), "archive.zip")
+	out, err := os.Create(archivePath)
+	require.NoError(t, err)
+	zipWriter := zip.NewWriter(out)
+
+	manifest := &domain.ArchiveManifest{Namespace: testNamespace, Name: testName}
+	for _, name := range sortedKeys(files) {
+		writer, createErr := zipWriter.Create(name)
+		require.NoError(t, createErr)
+		_, err = writer.Write([]byte(files[name]))
+		require.NoError(t, err)
+		checksum := sha256.Sum256([]byte(files[name]))
+		manifest.Files = append(manifest.Files, domain.ManifestFile{Path: name, Size: int64(len(files[name])), SHA256: hex.EncodeToString(checksum[:])})
+	}
+	require.NoError(t, writeManifest(zipWriter, manifest))
+	require.NoError(t, zipWriter.Close())
+	require.NoError(t, out.Close())
+
+	return archivePath
+}
+
+func openTestZipArchive(t *testing.T, files map[string]string) *ZipArchiveReader {
+	t.Helper()
+
+	reader, err := OpenZipArchiveReader(testCtx, writeTestZipArchive(t, files), filesystem.FileSystem{})
+	require.NoError(t, err)
+	t.Cleanup(func() {
+		_ = reader.Close()
+	})
+
+	return reader
+}
+
+func TestOpenZipArchiveReader(t *testing.T) {
+	t.Run("should return error if archive does not exist", func(t *testing.T) {
+		_, err := OpenZipArchiveReader(testCtx, filepath.Join(t.TempDir(), "missing.zip"), filesystem.FileSystem{})
+
+		require.Error(t, err)
+		assert.ErrorContains(t, err, "failed to open archive")
+	})
+}
+
+func TestZipArchiveReader_Files(t *testing.T) {
+	// given
+	sut := openTestZipArchive(t, map[string]string{"Logs/logs.log": "LOGS\n", "NodeInfo/count.csv": ""})
+
+	// when
+	files := sut.Files()
+
+	// then
+	require.Len(t, files, 3)
+	assert.Equal(t, domain.ManifestFile{Path: "Logs/logs.log", Size: 5}, files[0])
+	assert.Equal(t, "NodeInfo/count.csv", files[1].Path)
+	assert.Equal(t, domain.ManifestFileName, files[2].Path)
+}
+
+func TestZipArchiveReader_Open(t *testing.T) {
+	// given
+	sut := openTestZipArchive(t, map[string]string{"Logs/logs.log": "LOGS\n"})
+
+	// when
+	file, err := sut.Open("Logs/logs.log")
+
+	// then
+	require.NoError(t, err)
+	content, err := io.ReadAll(file)
+	require.NoError(t, err)
+	assert.Equal(t, "LOGS\n", string(content))
+	require.NoError(t, file.Close())
+	_, err = sut.Open("missing.log")
+	assert.ErrorIs(t, err, fs.ErrNotExist)
+}
+
+func TestZipArchiveReader_Manifest(t *testing.T) {
+	// given
+	sut := openTestZipArchive(t, map[string]string{"Logs/logs.log": "LOGS\n"})
+
+	// when
+	manifest, err := sut.Manifest()
+
+	// then
+	require.NoError(t, err)
+	assert.Equal(t, testNamespace, manifest.Namespace)
+	assert.Equal(t, testName, manifest.Name)
+	require.Len(t, manifest.Files, 1)
+	assert.Equal(t, "Logs/logs.log", manifest.Files[0].Path)
+}
+
+func TestZipArchiveReader_Verify(t *testing.T) {
+	t.Run("should succeed for unchanged archive", func(t *testing.T) {
+		// given
+		sut := openTestZipArchive(t, map[string]string{"Logs/logs.log": "LOGS\n", "Events/events.yaml": "[]"})
+
+		// when
+		verification, err := sut.Verify()
+
+		// then
+		require.NoError(t, err)
+		assert.True(t, verification.Valid())
+	})
+	t.Run("should report changed, missing and unlisted files", func(t *testing.T) {
+		// given
+		archivePath := filepath.Join(t.TempDir(), "archive.zip")
+		out, err := os.Create(archivePath)
+		require.NoError(t, err)
+		zipWriter := zip.NewWriter(out)
+		for name, content := range map[string]string{"Logs/logs.log": "changed", "extra.txt": "extra"} {
+			writer, createErr := zipWriter.Create(name)
+			require.NoError(t, createErr)
+			_, err = writer.Write([]byte(content))
+			require.NoError(t, err)
+		}
+		require.NoError(t, writeManifest(zipWriter, &domain.ArchiveManifest{Files: []domain.ManifestFile{
+			{Path: "Logs/logs.log", Size: 7, SHA256: "0000"},
+			{Path: "NodeInfo/count.csv", Size: 1, SHA256: "0000"},
+		}}))
+		require.NoError(t, zipWriter.Close())
+		require.NoError(t, out.Close())
+		sut, err := OpenZipArchiveReader(testCtx, archivePath, filesystem.FileSystem{})
+		require.NoError(t, err)
+		defer func() {
+			_ = sut.Close()
+		}()
+
+		// when
+		verification, err := sut.Verify()
+
+		// then
+		require.NoError(t, err)
+		assert.Equal(t, &domain.ManifestVerification{
+			Mismatched: []string{"Logs/logs.log"},
+			Missing:    []string{"NodeInfo/count.csv"},
+			Unlisted:   []string{"extra.txt"},
+		}, verification)
+	})
+}
+
+func TestZipArchiveReader_Logs(t *testing.T) {
+	t.Run("should parse and filter log lines", func(t *testing.T) {
+		// given
+		sut := openTestZipArchive(t, map[string]string{"Logs/logs.log": testArchiveLogs})
+		var lines []domain.ArchiveLogLine
+
+		// when
+		err := sut.Logs(domain.LogFilter{Level: "error"}, func(line domain.ArchiveLogLine) error {
+			lines = append(lines, line)
+			return nil
+		})
+
+		// then
+		require.NoError(t, err)
+		assert.Equal(t, []domain.ArchiveLogLine{{
+			Time:      time.Date(2025, 1, 1, 0, 1, 0, 0, time.UTC),
+			Namespace: "ecosystem",
+			Pod:       "cas-1",
+			Level:     "error",
+			Message:   "failed",
+		}}, lines)
+	})
+	t.Run("should read level detected by loki", func(t *testing.T) {
+		// given
+		sut := openTestZipArchive(t, map[string]string{"Logs/logs.log": testArchiveLogs})
+		var lines []domain.ArchiveLogLine
+
+		// when
+		err := sut.Logs(domain.LogFilter{Pod: "ldap"}, func(line domain.ArchiveLogLine) error {
+			lines = append(lines, line)
+			return nil
+		})
+
+		// then
+		require.NoError(t, err)
+		require.Len(t, lines, 1)
+		assert.Equal(t, "info", lines[0].Level)
+		assert.Equal(t, "ldap", lines[0].Container)
+	})
+	t.Run("should return error of callback", func(t *testing.T) {
+		sut := openTestZipArchive(t, map[string]string{"Logs/logs.log": testArchiveLogs})
+
+		err := sut.Logs(domain.LogFilter{}, func(_ domain.ArchiveLogLine) error {
+			return assert.AnError
+		})
+
+		assert.ErrorIs(t, err, assert.AnError)
+	})
+	t.Run("should ignore missing logs", func(t *testing.T) {
+		sut := openTestZipArchive(t, map[string]string{})
+
+		err := sut.Logs(domain.LogFilter{}, func(_ domain.ArchiveLogLine) error {
+			return assert.AnError
+		})
+
+		assert.NoError(t, err)
+	})
+}
+
+func TestZipArchiveReader_NodeInfo(t *testing.T) {
+	// given
+	sut := openTestZipArchive(t, map[string]string{
+		"NodeInfo/ramUsedRelative.csv":      testArchiveNodeInfo,
+		"NodeInfo/nested/ignored.csv":       testArchiveNodeInfo,
+		"Resources/SystemState/ignored.csv": testArchiveNodeInfo,
+	})
+
+	// when
+	samples, err := sut.NodeInfo()
+
+	// then
+	require.NoError(t, err)
+	require.Len(t, samples, 2)
+	assert.Equal(t, "ramUsedRelative", samples[0].MetricName)
+	assert.Equal(t, "node-1", samples[0].ID)
+	assert.Equal(t, 50.0, samples[0].Value)
+	assert.True(t, time.Date(2025, 1, 1, 0, 0, 0, 0, time.UTC).Equal(samples[0].Time))
+	assert.Equal(t, 70.0, samples[1].Value)
+}
+
+func TestZipArchiveReader_ExportGrafana(t *testing.T) {
+	t.Run("should convert events to log lines", func(t *testing.T) {
+		// given
+		sut := openTestZipArchive(t, map[string]string{
+			"Logs/logs.log":                testArchiveLogs,
+			"NodeInfo/ramUsedRelative.csv": testArchiveNodeInfo,
+			"Events/events.yaml":           testArchiveEvents,
+			"Events/events.csv":            "ignored",
+			"VolumeInfo/ldap.yaml":         "ignored",
+		})
+		dirPath := t.TempDir()
+
+		// when
+		err := sut.ExportGrafana(testCtx, dirPath)
+
+		// then
+		require.NoError(t, err)
+		logs, err := os.ReadFile(filepath.Join(dirPath, "Logs", "logs.log"))
+		require.NoError(t, err)
+		assert.Equal(t, testArchiveLogs, string(logs))
+		nodeInfo, err := os.ReadFile(filepath.Join(dirPath, "NodeInfo", "ramUsedRelative.csv"))
+		require.NoError(t, err)
+		assert.Equal(t, testArchiveNodeInfo, string(nodeInfo))
+		events, err := os.ReadFile(filepath.Join(dirPath, "Events", "events.log"))
+		require.NoError(t, err)
+		assert.Equal(t, "LOGS\n"+
+			`{"count":3,"kind":"Pod","level":"warning","msg":"Back-off restarting failed container","name":"ldap-0","namespace":"ecosystem","reason":"BackOff",`+
+			`"time":"2025-01-01 00:05:00 +0000 UTC","time_day":1,"time_month":1,"time_unix_nano":"1735689900000000000","time_year":2025,"type":"Warning"}`+"\n", string(events))
+		entries, err := os.ReadDir(dirPath)
+		require.NoError(t, err)
+		assert.Len(t, entries, 3)
+		_, err = os.Stat(filepath.Join(dirPath, "Events", "events.csv"))
+		assert.True(t, os.IsNotExist(err))
+	})
+	t.Run("should copy existing event log of older archives", func(t *testing.T) {
+		// given
+		sut := openTestZipArchive(t, map[string]string{"Events/events.log": "LOGS\n{}\n", "Events/events.yaml": testArchiveEvents})
+		dirPath := t.TempDir()
+
+		// when
+		err := sut.ExportGrafana(testCtx, dirPath)
+
+		// then
+		require.NoError(t, err)
+		events, err := os.ReadFile(filepath.Join(dirPath, "Events", "events.log"))
+		require.NoError(t, err)
+		assert.Equal(t, "LOGS\n{}\n", string(events))
+	})
+	t.Run("should return error on error creating directory", func(t *testing.T) {
+		// given
+		reader, err := OpenZipArchiveReader(testCtx, writeTestZipArchive(t, map[string]string{"Logs/logs.log": "LOGS\n"}), filesystem.FileSystem{})
+		require.NoError(t, err)
+		defer func() {
+			_ = reader.Close()
+		}()
+		fsMock := newMockVolumeFs(t)
+		reader.filesystem = fsMock
+		fsMock.EXPECT().MkdirAll(mock.Anything, "out/Logs", os.FileMode(0755)).Return(assert.AnError)
+
+		// when
+		err = reader.ExportGrafana(testCtx, "out")
+
+		// then
+		require.Error(t, err)
+		assert.ErrorIs(t, err, assert.AnError)
+		assert.ErrorContains(t, err, "failed to create directory out/Logs")
+	})
+}
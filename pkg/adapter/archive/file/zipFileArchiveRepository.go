@@ -3,13 +3,20 @@ package file
 import (
 	"archive/zip"
 	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
 	"errors"
 	"fmt"
 	"io"
 	"io/fs"
+	"maps"
 	"os"
 	"path/filepath"
 	"regexp"
+	"slices"
+	"strings"
+	"time"
 
 	"github.com/cloudogu/k8s-support-archive-operator/pkg/adapter/config"
 	"github.com/cloudogu/k8s-support-archive-operator/pkg/adapter/filesystem"
@@ -18,81 +25,164 @@ import (
 	"sigs.k8s.io/controller-runtime/pkg/log"
 )
 
+// temporaryArchiveSuffix is appended to the path of archives while they are written.
+const temporaryArchiveSuffix = ".tmp"
+
 type zipCreator func(w io.Writer) Zipper
 
 func NewZipWriter(w io.Writer) Zipper {
 	return zip.NewWriter(w)
 }
 
+// zipVerifier returns an error if the file at the path is no complete zip archive.
+type zipVerifier func(ctx context.Context, path string) error
+
+// newZipVerifier returns a verifier reading the central directory at the end of the zip archive, which is missing in
+// truncated archives.
+func newZipVerifier(fs volumeFs) zipVerifier {
+	return func(ctx context.Context, path string) error {
+		_, closer, err := openZip(ctx, fs, path)
+		if err != nil {
+			return err
+		}
+
+		return closer.Close()
+	}
+}
+
 type ZipFileArchiveRepository struct {
 	filesystem                           volumeFs
 	zipCreator                           zipCreator
+	zipVerifier                          zipVerifier
 	archivesPath                         string
 	archiveVolumeDownloadServiceName     string
 	archiveVolumeDownloadServicePort     string
 	archiveVolumeDownloadServiceProtocol string
 }
 
-func NewZipFileArchiveRepository(archivesPath string, zipCreator zipCreator, config *config.OperatorConfig) *ZipFileArchiveRepository {
+func NewZipFileArchiveRepository(archivesPath string, zipCreator zipCreator, fs volumeFs, config *config.OperatorConfig) *ZipFileArchiveRepository {
 	return &ZipFileArchiveRepository{
-		filesystem:                           filesystem.FileSystem{},
+		filesystem:                           fs,
 		archivesPath:                         archivesPath,
 		zipCreator:                           zipCreator,
+		zipVerifier:                          newZipVerifier(fs),
 		archiveVolumeDownloadServiceName:     config.ArchiveVolumeDownloadServiceName,
 		archiveVolumeDownloadServicePort:     config.ArchiveVolumeDownloadServicePort,
 		archiveVolumeDownloadServiceProtocol: config.ArchiveVolumeDownloadServiceProtocol,
 	}
 }
 
-func (z *ZipFileArchiveRepository) Create(ctx context.Context, id domain.SupportArchiveID, streams map[domain.CollectorType]*domain.Stream) (string, error) {
+// Create writes the archive to a temporary file and renames it after it is synced to the volume. The archive is verified
+// once after the rename. So an interrupted creation, e.g. by a restart of the operator, never leaves an incomplete
+// archive at the final path.
+// The collectors are packaged in a fixed order and all entries are dated to the end of the collection, so that
+// identical data results in a byte-identical archive.
+func (z *ZipFileArchiveRepository) Create(ctx context.Context, id domain.SupportArchiveID, streams map[domain.CollectorType]*domain.Stream, collectionEnd time.Time) (string, error) {
 	logger := log.FromContext(ctx).WithName("ZipFileArchiveRepository.finishCollection")
-	destinationPath := z.getArchivePath(id)
+	destinationPath := z.GetArchivePath(id)
+	temporaryPath := destinationPath + temporaryArchiveSuffix
 
-	err := z.filesystem.MkdirAll(filepath.Dir(destinationPath), 0755)
+	err := z.filesystem.MkdirAll(ctx, filepath.Dir(destinationPath), 0755)
 	if err != nil {
 		return "", fmt.Errorf("failed to create zip archive directory: %w", err)
 	}
 
-	zipFile, err := z.filesystem.OpenFile(destinationPath, os.O_RDWR|os.O_CREATE|os.O_TRUNC, 0644)
+	zipFile, err := z.filesystem.OpenFile(ctx, temporaryPath, os.O_RDWR|os.O_CREATE|os.O_TRUNC, 0644)
 	if err != nil {
-		return "", fmt.Errorf("failed to open file %s: %w", destinationPath, err)
+		return "", fmt.Errorf("failed to open file %s: %w", temporaryPath, err)
 	}
-	defer func() {
-		// If any error occurs during creating, try to delete the zip to avoid false recognition bei Exists method.
-		if err == nil {
-			return
+
+	err = z.writeArchive(ctx, id, streams, collectionEnd, zipFile, temporaryPath)
+	if err == nil {
+		err = z.commitArchive(ctx, temporaryPath, destinationPath)
+	}
+	if err != nil {
+		// Remove the incomplete archive here. Leftovers of a killed operator are removed by RemoveTemporaryArchives.
+		removeErr := z.filesystem.Remove(ctx, temporaryPath)
+		if removeErr != nil && !os.IsNotExist(removeErr) {
+			logger.Error(removeErr, fmt.Sprintf("failed to remove temporary zip file %s after error: %s", temporaryPath, err))
 		}
-		err = z.filesystem.Remove(destinationPath)
+		return "", err
+	}
+
+	return z.getArchiveURL(id), nil
+}
+
+// writeArchive writes the data of the streams and the manifest to the zip file, syncs and closes it.
+func (z *ZipFileArchiveRepository) writeArchive(ctx context.Context, id domain.SupportArchiveID, streams map[domain.CollectorType]*domain.Stream, collectionEnd time.Time, zipFile filesystem.ClosableRWFile, path string) error {
+	err := z.writeZipEntries(ctx, id, streams, collectionEnd, zipFile)
+	if err == nil {
+		err = z.syncFile(zipFile, path)
+	}
+
+	if closeErr := zipFile.Close(); closeErr != nil {
+		err = errors.Join(err, fmt.Errorf("failed to close zip file %s: %w", path, closeErr))
+	}
+
+	return err
+}
+
+func (z *ZipFileArchiveRepository) writeZipEntries(ctx context.Context, id domain.SupportArchiveID, streams map[domain.CollectorType]*domain.Stream, collectionEnd time.Time, w io.Writer) error {
+	zipWriter := z.zipCreator(w)
+
+	err := z.writeStreams(ctx, id, streams, collectionEnd, zipWriter)
+	// closing the writer writes the central directory of the archive
+	if closeErr := zipWriter.Close(); closeErr != nil {
+		err = errors.Join(err, fmt.Errorf("failed to close zip writer: %w", closeErr))
+	}
+
+	return err
+}
+
+// writeStreams writes the streams sorted by collector. The entries of a stream keep the order of the stream.
+func (z *ZipFileArchiveRepository) writeStreams(ctx context.Context, id domain.SupportArchiveID, streams map[domain.CollectorType]*domain.Stream, collectionEnd time.Time, zipWriter Zipper) error {
+	manifest := &domain.ArchiveManifest{Namespace: id.Namespace, Name: id.Name, CreatedAt: collectionEnd.UTC()}
+	for _, collector := range slices.Sorted(maps.Keys(streams)) {
+		err := z.rangeOverStream(ctx, collector, streams[collector], zipWriter, manifest)
 		if err != nil {
-			logger.Error(err, fmt.Sprintf("failed to remove zip file %s after error: %s", destinationPath, err))
+			return err
 		}
-	}()
+	}
 
-	zipWriter := z.zipCreator(zipFile)
-	defer func() {
-		if closeErr := zipWriter.Close(); closeErr != nil {
-			logger.Error(closeErr, "failed to close zip writer")
-		}
-		if closeErr := zipFile.Close(); closeErr != nil {
-			logger.Error(closeErr, "failed to close zip file")
-		}
-	}()
+	return writeManifest(zipWriter, manifest)
+}
 
-	for collector, stream := range streams {
-		err = z.rangeOverStream(ctx, collector, stream, zipWriter)
-		if err != nil {
-			return "", err
+func (z *ZipFileArchiveRepository) syncFile(file filesystem.ClosableRWFile, path string) error {
+	err := file.Sync()
+	if err != nil {
+		return fmt.Errorf("failed to sync zip file %s: %w", path, err)
+	}
+
+	return nil
+}
+
+// commitArchive moves the written archive to its final path and verifies it there, because the rename is a copy on some
+// filesystems. A broken archive is removed, so Exists only has to check that the archive is present. The rename
+// replaces an existing archive atomically.
+func (z *ZipFileArchiveRepository) commitArchive(ctx context.Context, temporaryPath, destinationPath string) error {
+	err := z.filesystem.Rename(ctx, temporaryPath, destinationPath)
+	if err != nil {
+		return fmt.Errorf("failed to rename zip file %s to %s: %w", temporaryPath, destinationPath, err)
+	}
+
+	err = z.zipVerifier(ctx, destinationPath)
+	if err != nil {
+		err = fmt.Errorf("failed to verify zip file %s: %w", destinationPath, err)
+		removeErr := z.filesystem.Remove(ctx, destinationPath)
+		if removeErr != nil {
+			return errors.Join(err, fmt.Errorf("failed to remove unverified zip file %s: %w", destinationPath, removeErr))
 		}
+		return err
 	}
 
-	return z.getArchiveURL(id), nil
+	return nil
 }
 
 func (z *ZipFileArchiveRepository) getArchiveURL(id domain.SupportArchiveID) string {
 	return fmt.Sprintf("%s://%s.%s.svc.cluster.local:%s/%s/%s.zip", z.archiveVolumeDownloadServiceProtocol, z.archiveVolumeDownloadServiceName, id.Namespace, z.archiveVolumeDownloadServicePort, id.Namespace, id.Name)
 }
 
-func (z *ZipFileArchiveRepository) rangeOverStream(ctx context.Context, collector domain.CollectorType, stream *domain.Stream, zipWriter Zipper) error {
+func (z *ZipFileArchiveRepository) rangeOverStream(ctx context.Context, collector domain.CollectorType, stream *domain.Stream, zipWriter Zipper, manifest *domain.ArchiveManifest) error {
 	var closeFuncs []domain.CloseStreamFunc
 	defer func() {
 		for _, closeFunc := range closeFuncs {
@@ -115,7 +205,7 @@ func (z *ZipFileArchiveRepository) rangeOverStream(ctx context.Context, collecto
 				}
 				closeFuncs = append(closeFuncs, closeReader)
 
-				dataErr := z.copyDataFromStreamToArchive(zipWriter, collector, data.ID, reader)
+				dataErr := z.copyDataFromStreamToArchive(zipWriter, collector, data.ID, reader, manifest)
 				if dataErr != nil {
 					return fmt.Errorf("error streaming data: %w", dataErr)
 				}
@@ -126,16 +216,49 @@ func (z *ZipFileArchiveRepository) rangeOverStream(ctx context.Context, collecto
 	}
 }
 
-func (z *ZipFileArchiveRepository) copyDataFromStreamToArchive(zipper Zipper, collector domain.CollectorType, path string, dataReader io.Reader) error {
-	zipFileWriter, err := zipper.Create(filepath.Join(string(collector), path))
+// copyDataFromStreamToArchive adds the file to the archive and its checksum to the manifest.
+func (z *ZipFileArchiveRepository) copyDataFromStreamToArchive(zipper Zipper, collector domain.CollectorType, path string, dataReader io.Reader, manifest *domain.ArchiveManifest) error {
+	archivePath := filepath.Join(string(collector), path)
+	zipFileWriter, err := createZipEntry(zipper, archivePath, manifest.CreatedAt)
 	if err != nil {
 		return fmt.Errorf("failed to create zip writer for file %s: %w", path, err)
 	}
 
-	_, err = z.filesystem.Copy(zipFileWriter, dataReader)
+	hash := sha256.New()
+	size, err := z.filesystem.Copy(io.MultiWriter(zipFileWriter, hash), dataReader)
 	if err != nil {
 		return fmt.Errorf("failed to copy file %s: %w", path, err)
 	}
+	manifest.Files = append(manifest.Files, domain.ManifestFile{
+		Path:   filepath.ToSlash(archivePath),
+		Size:   size,
+		SHA256: hex.EncodeToString(hash.Sum(nil)),
+	})
+
+	return nil
+}
+
+// createZipEntry adds a compressed file with the given modification time to the archive.
+func createZipEntry(zipper Zipper, name string, modified time.Time) (io.Writer, error) {
+	return zipper.CreateHeader(&zip.FileHeader{Name: name, Method: zip.Deflate, Modified: modified})
+}
+
+func writeManifest(zipper Zipper, manifest *domain.ArchiveManifest) error {
+	manifest.SortFiles()
+	out, err := json.MarshalIndent(manifest, "", "  ")
+	if err != nil {
+		return fmt.Errorf("failed to marshal manifest: %w", err)
+	}
+
+	writer, err := createZipEntry(zipper, domain.ManifestFileName, manifest.CreatedAt)
+	if err != nil {
+		return fmt.Errorf("failed to create zip writer for manifest: %w", err)
+	}
+
+	_, err = writer.Write(out)
+	if err != nil {
+		return fmt.Errorf("failed to write manifest: %w", err)
+	}
 
 	return nil
 }
@@ -148,19 +271,19 @@ func (z *ZipFileArchiveRepository) Delete(ctx context.Context, id domain.Support
 	archiveFile := fmt.Sprintf("%s.zip", filepath.Join(archiveNamespaceDir, id.Name))
 
 	var multiErr []error
-	if err := z.filesystem.Remove(archiveFile); err != nil {
+	if err := z.filesystem.Remove(ctx, archiveFile); err != nil {
 		multiErr = append(multiErr, fmt.Errorf("failed to remove archive %s: %w", archiveFile, err))
 	}
 
-	if err := z.removeDirIfEmpty(archiveNamespaceDir); err != nil {
+	if err := z.removeDirIfEmpty(ctx, archiveNamespaceDir); err != nil {
 		multiErr = append(multiErr, err)
 	}
 
 	return errors.Join(multiErr...)
 }
 
-func (z *ZipFileArchiveRepository) removeDirIfEmpty(path string) error {
-	dirEntries, err := z.filesystem.ReadDir(path)
+func (z *ZipFileArchiveRepository) removeDirIfEmpty(ctx context.Context, path string) error {
+	dirEntries, err := z.filesystem.ReadDir(ctx, path)
 	if err != nil {
 		return fmt.Errorf("error reading dir %q: %w", path, err)
 	}
@@ -169,7 +292,7 @@ func (z *ZipFileArchiveRepository) removeDirIfEmpty(path string) error {
 		return nil
 	}
 
-	err = z.filesystem.Remove(path)
+	err = z.filesystem.Remove(ctx, path)
 	if err != nil {
 		return fmt.Errorf("error removing empty dir %q: %w", path, err)
 	}
@@ -177,28 +300,89 @@ func (z *ZipFileArchiveRepository) removeDirIfEmpty(path string) error {
 	return nil
 }
 
-func (z *ZipFileArchiveRepository) Exists(_ context.Context, id domain.SupportArchiveID) (bool, error) {
-	destinationPath := z.getArchivePath(id)
+// Exists returns true if the archive exists. Archives are verified when they are created, so the archive is not read.
+func (z *ZipFileArchiveRepository) Exists(ctx context.Context, id domain.SupportArchiveID) (bool, error) {
+	destinationPath := z.GetArchivePath(id)
 
-	_, err := z.filesystem.Stat(destinationPath)
+	_, err := z.filesystem.Stat(ctx, destinationPath)
 
 	if err != nil && os.IsNotExist(err) {
 		return false, nil
 	} else if err != nil {
 		return false, fmt.Errorf("failed to check if file %s exists: %w", destinationPath, err)
 	}
+
 	return true, nil
 }
 
-func (z *ZipFileArchiveRepository) List(_ context.Context) ([]domain.SupportArchiveID, error) {
+// ReadContent returns the system state, the secrets, the volume info and the node metrics of the archive to compare it
+// with another archive.
+func (z *ZipFileArchiveRepository) ReadContent(ctx context.Context, id domain.SupportArchiveID) (*domain.ArchiveContent, error) {
+	reader, err := OpenZipArchiveReader(ctx, z.GetArchivePath(id), z.filesystem)
+	if err != nil {
+		return nil, err
+	}
+	defer func() {
+		_ = reader.Close()
+	}()
+
+	return reader.Content(ctx)
+}
+
+// RemoveTemporaryArchives removes temporary files of archive creations which were interrupted, e.g. by a restart of
+// the operator. Files modified within the minimum age are kept because other replicas sharing the volume may still
+// write them. A minimum age of zero must only be used while no archive is created.
+func (z *ZipFileArchiveRepository) RemoveTemporaryArchives(ctx context.Context, minAge time.Duration) error {
+	logger := log.FromContext(ctx).WithName("ZipFileArchiveRepository.RemoveTemporaryArchives")
+
+	var multiErr []error
+	err := z.filesystem.WalkDir(ctx, z.archivesPath, func(path string, d fs.DirEntry, err error) error {
+		if err != nil {
+			if path == z.archivesPath && os.IsNotExist(err) {
+				return nil
+			}
+			return err
+		}
+
+		if d.IsDir() || !isTemporaryArchive(path) {
+			return nil
+		}
+
+		info, err := d.Info()
+		if err != nil {
+			multiErr = append(multiErr, fmt.Errorf("failed to get info of temporary archive %s: %w", path, err))
+			return nil
+		}
+		if time.Since(info.ModTime()) < minAge {
+			return nil
+		}
+
+		logger.Info(fmt.Sprintf("removing temporary archive %s", path))
+		if removeErr := z.filesystem.Remove(ctx, path); removeErr != nil {
+			multiErr = append(multiErr, fmt.Errorf("failed to remove temporary archive %s: %w", path, removeErr))
+		}
+		return nil
+	})
+	if err != nil {
+		multiErr = append(multiErr, fmt.Errorf("failed to walk archives path %s: %w", z.archivesPath, err))
+	}
+
+	return errors.Join(multiErr...)
+}
+
+func isTemporaryArchive(path string) bool {
+	return strings.HasSuffix(path, temporaryArchiveSuffix)
+}
+
+func (z *ZipFileArchiveRepository) List(ctx context.Context) ([]domain.SupportArchiveID, error) {
 	archiveMatcher := regexp.MustCompile(fmt.Sprintf("%s/%s", regexp.QuoteMeta(z.archivesPath), `(?P<namespace>[^/]+)/(?P<name>[^/.]+)\.zip`))
 	namespaceIndex := archiveMatcher.SubexpIndex("namespace")
 	nameIndex := archiveMatcher.SubexpIndex("name")
 
 	var list []domain.SupportArchiveID
-	err := z.filesystem.WalkDir(z.archivesPath, func(path string, d fs.DirEntry, err error) error {
+	err := z.filesystem.WalkDir(ctx, z.archivesPath, func(path string, d fs.DirEntry, err error) error {
 		errs := []error{err}
-		if !d.IsDir() {
+		if !d.IsDir() && !isTemporaryArchive(path) {
 			matches := archiveMatcher.FindStringSubmatch(path)
 			if matches == nil || len(matches) != 3 {
 				errs = append(errs, fmt.Errorf("failed to match path %q: not an archive", path))
@@ -216,6 +400,7 @@ func (z *ZipFileArchiveRepository) List(_ context.Context) ([]domain.SupportArch
 	return list, err
 }
 
-func (z *ZipFileArchiveRepository) getArchivePath(id domain.SupportArchiveID) string {
+// GetArchivePath returns the path of the archive in the local filesystem.
+func (z *ZipFileArchiveRepository) GetArchivePath(id domain.SupportArchiveID) string {
 	return fmt.Sprintf("%s.zip", filepath.Join(z.archivesPath, id.Namespace, id.Name))
 }
@@ -1,15 +1,21 @@
 package file
 
 import (
+	"archive/zip"
+	"bytes"
 	"context"
+	"encoding/json"
 	"fmt"
 	"github.com/cloudogu/k8s-support-archive-operator/pkg/adapter/config"
+	"github.com/cloudogu/k8s-support-archive-operator/pkg/adapter/filesystem"
 	"github.com/cloudogu/k8s-support-archive-operator/pkg/domain"
 	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
 	"github.com/stretchr/testify/require"
 	"io"
 	"io/fs"
 	"os"
+	"path/filepath"
 	"testing"
 	"time"
 )
@@ -19,6 +25,7 @@ const (
 	testNamespace     = "ecosystem"
 	testName          = "archive-123"
 	testArchivePath   = testArchivesPath + "/" + testNamespace + "/" + testName + ".zip"
+	testTemporaryPath = testArchivePath + ".tmp"
 	testNamespacePath = testArchivesPath + "/" + testNamespace
 	testArchiveURL    = "https://servicename.ecosystem.svc.cluster.local:8080/ecosystem/archive-123.zip"
 	testServiceName   = "servicename"
@@ -27,8 +34,9 @@ const (
 )
 
 var (
-	testCtx = context.Background()
-	testID  = domain.SupportArchiveID{
+	testCollectionEnd = time.Date(2025, 1, 5, 0, 0, 0, 0, time.UTC)
+	testCtx           = context.Background()
+	testID            = domain.SupportArchiveID{
 		Namespace: testNamespace,
 		Name:      testName,
 	}
@@ -55,7 +63,7 @@ func TestZipFileArchiveRepository_Exists(t *testing.T) {
 			fields: fields{
 				filesystem: func(t *testing.T) volumeFs {
 					fsMock := newMockVolumeFs(t)
-					fsMock.EXPECT().Stat(testArchivePath).Return(nil, nil)
+					fsMock.EXPECT().Stat(mock.Anything, testArchivePath).Return(nil, nil)
 					return fsMock
 				},
 				archivePath: testArchivesPath,
@@ -72,7 +80,7 @@ func TestZipFileArchiveRepository_Exists(t *testing.T) {
 			fields: fields{
 				filesystem: func(t *testing.T) volumeFs {
 					fsMock := newMockVolumeFs(t)
-					fsMock.EXPECT().Stat(testArchivePath).Return(nil, fs.ErrNotExist)
+					fsMock.EXPECT().Stat(mock.Anything, testArchivePath).Return(nil, fs.ErrNotExist)
 					return fsMock
 				},
 				archivePath: testArchivesPath,
@@ -89,7 +97,7 @@ func TestZipFileArchiveRepository_Exists(t *testing.T) {
 			fields: fields{
 				filesystem: func(t *testing.T) volumeFs {
 					fsMock := newMockVolumeFs(t)
-					fsMock.EXPECT().Stat(testArchivePath).Return(nil, assert.AnError)
+					fsMock.EXPECT().Stat(mock.Anything, testArchivePath).Return(nil, assert.AnError)
 					return fsMock
 				},
 				archivePath: testArchivesPath,
@@ -104,6 +112,7 @@ func TestZipFileArchiveRepository_Exists(t *testing.T) {
 	}
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
+			// the archive is not verified, so no verifier is set
 			z := &ZipFileArchiveRepository{
 				filesystem:   tt.fields.filesystem(t),
 				archivesPath: tt.fields.archivePath,
@@ -140,9 +149,9 @@ func TestZipFileArchiveRepository_Delete(t *testing.T) {
 			fields: fields{
 				filesystem: func(t *testing.T) volumeFs {
 					fsMock := newMockVolumeFs(t)
-					fsMock.EXPECT().Remove(testArchivePath).Return(nil)
-					fsMock.EXPECT().ReadDir(testNamespacePath).Return(nil, nil)
-					fsMock.EXPECT().Remove(testNamespacePath).Return(nil)
+					fsMock.EXPECT().Remove(mock.Anything, testArchivePath).Return(nil)
+					fsMock.EXPECT().ReadDir(mock.Anything, testNamespacePath).Return(nil, nil)
+					fsMock.EXPECT().Remove(mock.Anything, testNamespacePath).Return(nil)
 
 					return fsMock
 				},
@@ -159,8 +168,8 @@ func TestZipFileArchiveRepository_Delete(t *testing.T) {
 			fields: fields{
 				filesystem: func(t *testing.T) volumeFs {
 					fsMock := newMockVolumeFs(t)
-					fsMock.EXPECT().Remove(testArchivePath).Return(nil)
-					fsMock.EXPECT().ReadDir(testNamespacePath).Return([]os.DirEntry{testEntry{}}, nil)
+					fsMock.EXPECT().Remove(mock.Anything, testArchivePath).Return(nil)
+					fsMock.EXPECT().ReadDir(mock.Anything, testNamespacePath).Return([]os.DirEntry{testEntry{}}, nil)
 
 					return fsMock
 				},
@@ -177,8 +186,8 @@ func TestZipFileArchiveRepository_Delete(t *testing.T) {
 			fields: fields{
 				filesystem: func(t *testing.T) volumeFs {
 					fsMock := newMockVolumeFs(t)
-					fsMock.EXPECT().Remove(testArchivePath).Return(assert.AnError)
-					fsMock.EXPECT().ReadDir(testNamespacePath).Return([]os.DirEntry{testEntry{}}, nil)
+					fsMock.EXPECT().Remove(mock.Anything, testArchivePath).Return(assert.AnError)
+					fsMock.EXPECT().ReadDir(mock.Anything, testNamespacePath).Return([]os.DirEntry{testEntry{}}, nil)
 
 					return fsMock
 				},
@@ -195,8 +204,8 @@ func TestZipFileArchiveRepository_Delete(t *testing.T) {
 			fields: fields{
 				filesystem: func(t *testing.T) volumeFs {
 					fsMock := newMockVolumeFs(t)
-					fsMock.EXPECT().Remove(testArchivePath).Return(nil)
-					fsMock.EXPECT().ReadDir(testNamespacePath).Return([]os.DirEntry{testEntry{}}, assert.AnError)
+					fsMock.EXPECT().Remove(mock.Anything, testArchivePath).Return(nil)
+					fsMock.EXPECT().ReadDir(mock.Anything, testNamespacePath).Return([]os.DirEntry{testEntry{}}, assert.AnError)
 
 					return fsMock
 				},
@@ -213,9 +222,9 @@ func TestZipFileArchiveRepository_Delete(t *testing.T) {
 			fields: fields{
 				filesystem: func(t *testing.T) volumeFs {
 					fsMock := newMockVolumeFs(t)
-					fsMock.EXPECT().Remove(testArchivePath).Return(nil)
-					fsMock.EXPECT().ReadDir(testNamespacePath).Return(nil, nil)
-					fsMock.EXPECT().Remove(testNamespacePath).Return(assert.AnError)
+					fsMock.EXPECT().Remove(mock.Anything, testArchivePath).Return(nil)
+					fsMock.EXPECT().ReadDir(mock.Anything, testNamespacePath).Return(nil, nil)
+					fsMock.EXPECT().Remove(mock.Anything, testNamespacePath).Return(assert.AnError)
 
 					return fsMock
 				},
@@ -260,6 +269,7 @@ func (t testEntry) Info() (fs.FileInfo, error) {
 }
 
 func TestZipFileArchiveRepository_Create(t *testing.T) {
+	testManifestWriter := &bytes.Buffer{}
 	casWriter := newMockClosableRWFile(t)
 	casReader := NewMockReader(t)
 	ldapWriter := newMockClosableRWFile(t)
@@ -290,7 +300,7 @@ func TestZipFileArchiveRepository_Create(t *testing.T) {
 			fields: fields{
 				filesystem: func(t *testing.T) volumeFs {
 					fsMock := newMockVolumeFs(t)
-					fsMock.EXPECT().MkdirAll(testNamespacePath, os.FileMode(0755)).Return(assert.AnError)
+					fsMock.EXPECT().MkdirAll(mock.Anything, testNamespacePath, os.FileMode(0755)).Return(assert.AnError)
 					return fsMock
 				},
 				archivesPath: testArchivesPath,
@@ -309,8 +319,8 @@ func TestZipFileArchiveRepository_Create(t *testing.T) {
 			fields: fields{
 				filesystem: func(t *testing.T) volumeFs {
 					fsMock := newMockVolumeFs(t)
-					fsMock.EXPECT().MkdirAll(testNamespacePath, os.FileMode(0755)).Return(nil)
-					fsMock.EXPECT().OpenFile(testArchivePath, os.O_RDWR|os.O_CREATE|os.O_TRUNC, os.FileMode(0644)).Return(nil, assert.AnError)
+					fsMock.EXPECT().MkdirAll(mock.Anything, testNamespacePath, os.FileMode(0755)).Return(nil)
+					fsMock.EXPECT().OpenFile(mock.Anything, testTemporaryPath, os.O_RDWR|os.O_CREATE|os.O_TRUNC, os.FileMode(0644)).Return(nil, assert.AnError)
 					return fsMock
 				},
 				archivesPath: testArchivesPath,
@@ -329,14 +339,16 @@ func TestZipFileArchiveRepository_Create(t *testing.T) {
 			fields: fields{
 				filesystem: func(t *testing.T) volumeFs {
 					fileMock := newMockClosableRWFile(t)
+					fileMock.EXPECT().Sync().Return(nil)
 					fileMock.EXPECT().Close().Return(nil)
 
 					fsMock := newMockVolumeFs(t)
-					fsMock.EXPECT().MkdirAll(testNamespacePath, os.FileMode(0755)).Return(nil)
-					fsMock.EXPECT().OpenFile(testArchivePath, os.O_RDWR|os.O_CREATE|os.O_TRUNC, os.FileMode(0644)).Return(fileMock, nil)
+					fsMock.EXPECT().MkdirAll(mock.Anything, testNamespacePath, os.FileMode(0755)).Return(nil)
+					fsMock.EXPECT().OpenFile(mock.Anything, testTemporaryPath, os.O_RDWR|os.O_CREATE|os.O_TRUNC, os.FileMode(0644)).Return(fileMock, nil)
 
-					fsMock.EXPECT().Copy(casWriter, casReader).Return(0, nil)
-					fsMock.EXPECT().Copy(ldapWriter, ldapReader).Return(0, nil)
+					fsMock.EXPECT().Copy(mock.AnythingOfType("*io.multiWriter"), casReader).Return(3, nil)
+					fsMock.EXPECT().Copy(mock.AnythingOfType("*io.multiWriter"), ldapReader).Return(0, nil)
+					fsMock.EXPECT().Rename(mock.Anything, testTemporaryPath, testArchivePath).Return(nil)
 
 					return fsMock
 				},
@@ -344,8 +356,9 @@ func TestZipFileArchiveRepository_Create(t *testing.T) {
 					zipMock := NewMockZipper(t)
 					zipMock.EXPECT().Close().Return(nil)
 
-					zipMock.EXPECT().Create("Logs/cas.log").Return(casWriter, nil)
-					zipMock.EXPECT().Create("Logs/ldap.log").Return(ldapWriter, nil)
+					zipMock.EXPECT().CreateHeader(testZipHeader("Logs/cas.log")).Return(casWriter, nil)
+					zipMock.EXPECT().CreateHeader(testZipHeader("Logs/ldap.log")).Return(ldapWriter, nil)
+					zipMock.EXPECT().CreateHeader(testZipHeader("manifest.json")).Return(testManifestWriter, nil)
 
 					return func(w io.Writer) Zipper {
 						return zipMock
@@ -373,10 +386,10 @@ func TestZipFileArchiveRepository_Create(t *testing.T) {
 					fileMock.EXPECT().Close().Return(nil)
 
 					fsMock := newMockVolumeFs(t)
-					fsMock.EXPECT().MkdirAll(testNamespacePath, os.FileMode(0755)).Return(nil)
-					fsMock.EXPECT().OpenFile(testArchivePath, os.O_RDWR|os.O_CREATE|os.O_TRUNC, os.FileMode(0644)).Return(fileMock, nil)
+					fsMock.EXPECT().MkdirAll(mock.Anything, testNamespacePath, os.FileMode(0755)).Return(nil)
+					fsMock.EXPECT().OpenFile(mock.Anything, testTemporaryPath, os.O_RDWR|os.O_CREATE|os.O_TRUNC, os.FileMode(0644)).Return(fileMock, nil)
 
-					fsMock.EXPECT().Remove(testArchivePath).Return(nil)
+					fsMock.EXPECT().Remove(mock.Anything, testTemporaryPath).Return(nil)
 
 					return fsMock
 				},
@@ -413,17 +426,17 @@ func TestZipFileArchiveRepository_Create(t *testing.T) {
 					fileMock.EXPECT().Close().Return(nil)
 
 					fsMock := newMockVolumeFs(t)
-					fsMock.EXPECT().MkdirAll(testNamespacePath, os.FileMode(0755)).Return(nil)
-					fsMock.EXPECT().OpenFile(testArchivePath, os.O_RDWR|os.O_CREATE|os.O_TRUNC, os.FileMode(0644)).Return(fileMock, nil)
+					fsMock.EXPECT().MkdirAll(mock.Anything, testNamespacePath, os.FileMode(0755)).Return(nil)
+					fsMock.EXPECT().OpenFile(mock.Anything, testTemporaryPath, os.O_RDWR|os.O_CREATE|os.O_TRUNC, os.FileMode(0644)).Return(fileMock, nil)
 
-					fsMock.EXPECT().Remove(testArchivePath).Return(nil)
+					fsMock.EXPECT().Remove(mock.Anything, testTemporaryPath).Return(nil)
 
 					return fsMock
 				},
 				zipCreator: func(t *testing.T) zipCreator {
 					zipMock := NewMockZipper(t)
 					zipMock.EXPECT().Close().Return(nil)
-					zipMock.EXPECT().Create("Logs/cas.log").Return(casWriter, assert.AnError)
+					zipMock.EXPECT().CreateHeader(testZipHeader("Logs/cas.log")).Return(casWriter, assert.AnError)
 
 					return func(w io.Writer) Zipper {
 						return zipMock
@@ -454,12 +467,12 @@ func TestZipFileArchiveRepository_Create(t *testing.T) {
 					fileMock.EXPECT().Close().Return(nil)
 
 					fsMock := newMockVolumeFs(t)
-					fsMock.EXPECT().MkdirAll(testNamespacePath, os.FileMode(0755)).Return(nil)
-					fsMock.EXPECT().OpenFile(testArchivePath, os.O_RDWR|os.O_CREATE|os.O_TRUNC, os.FileMode(0644)).Return(fileMock, nil)
+					fsMock.EXPECT().MkdirAll(mock.Anything, testNamespacePath, os.FileMode(0755)).Return(nil)
+					fsMock.EXPECT().OpenFile(mock.Anything, testTemporaryPath, os.O_RDWR|os.O_CREATE|os.O_TRUNC, os.FileMode(0644)).Return(fileMock, nil)
 
-					fsMock.EXPECT().Copy(casWriter, casReader).Return(0, assert.AnError)
+					fsMock.EXPECT().Copy(mock.AnythingOfType("*io.multiWriter"), casReader).Return(0, assert.AnError)
 
-					fsMock.EXPECT().Remove(testArchivePath).Return(nil)
+					fsMock.EXPECT().Remove(mock.Anything, testTemporaryPath).Return(nil)
 
 					return fsMock
 				},
@@ -467,7 +480,7 @@ func TestZipFileArchiveRepository_Create(t *testing.T) {
 					zipMock := NewMockZipper(t)
 					zipMock.EXPECT().Close().Return(nil)
 
-					zipMock.EXPECT().Create("Logs/cas.log").Return(casWriter, nil)
+					zipMock.EXPECT().CreateHeader(testZipHeader("Logs/cas.log")).Return(casWriter, nil)
 
 					return func(w io.Writer) Zipper {
 						return zipMock
@@ -498,10 +511,10 @@ func TestZipFileArchiveRepository_Create(t *testing.T) {
 					fileMock.EXPECT().Close().Return(nil)
 
 					fsMock := newMockVolumeFs(t)
-					fsMock.EXPECT().MkdirAll(testNamespacePath, os.FileMode(0755)).Return(nil)
-					fsMock.EXPECT().OpenFile(testArchivePath, os.O_RDWR|os.O_CREATE|os.O_TRUNC, os.FileMode(0644)).Return(fileMock, nil)
+					fsMock.EXPECT().MkdirAll(mock.Anything, testNamespacePath, os.FileMode(0755)).Return(nil)
+					fsMock.EXPECT().OpenFile(mock.Anything, testTemporaryPath, os.O_RDWR|os.O_CREATE|os.O_TRUNC, os.FileMode(0644)).Return(fileMock, nil)
 
-					fsMock.EXPECT().Remove(testArchivePath).Return(nil)
+					fsMock.EXPECT().Remove(mock.Anything, testTemporaryPath).Return(nil)
 
 					return fsMock
 				},
@@ -544,14 +557,18 @@ func TestZipFileArchiveRepository_Create(t *testing.T) {
 			}
 
 			z := &ZipFileArchiveRepository{
-				filesystem:                           filesystem,
-				zipCreator:                           creator,
+				filesystem: filesystem,
+				zipCreator: creator,
+				zipVerifier: func(_ context.Context, path string) error {
+					assert.Equal(t, testArchivePath, path)
+					return nil
+				},
 				archivesPath:                         tt.fields.archivesPath,
 				archiveVolumeDownloadServiceName:     tt.fields.archiveVolumeDownloadServiceName,
 				archiveVolumeDownloadServicePort:     tt.fields.archiveVolumeDownloadServicePort,
 				archiveVolumeDownloadServiceProtocol: tt.fields.archiveVolumeDownloadServiceProtocol,
 			}
-			got, err := z.Create(tt.args.ctx, tt.args.id, tt.args.streams)
+			got, err := z.Create(tt.args.ctx, tt.args.id, tt.args.streams, testCollectionEnd)
 			if err != nil {
 				tt.wantErr(t, err)
 				return
@@ -559,6 +576,154 @@ func TestZipFileArchiveRepository_Create(t *testing.T) {
 			assert.Equalf(t, tt.want, got, "Create(%v, %v, %v)", tt.args.ctx, tt.args.id, tt.args.streams)
 		})
 	}
+
+	t.Run("should add manifest with checksums of all files", func(t *testing.T) {
+		var manifest domain.ArchiveManifest
+		require.NoError(t, json.Unmarshal(testManifestWriter.Bytes(), &manifest))
+		assert.Equal(t, testNamespace, manifest.Namespace)
+		assert.Equal(t, testName, manifest.Name)
+		assert.Equal(t, testCollectionEnd, manifest.CreatedAt)
+		emptyChecksum := "e3b0c44298fc1c149afbf4c8996fb92427ae41e4649b934ca495991b7852b855"
+		assert.Equal(t, []domain.ManifestFile{
+			{Path: "Logs/cas.log", Size: 3, SHA256: emptyChecksum},
+			{Path: "Logs/ldap.log", Size: 0, SHA256: emptyChecksum},
+		}, manifest.Files)
+	})
+
+	t.Run("should remove temporary file on sync error", func(t *testing.T) {
+		// given
+		fileMock := newMockClosableRWFile(t)
+		fileMock.EXPECT().Sync().Return(assert.AnError)
+		fileMock.EXPECT().Close().Return(nil)
+		fsMock := newMockVolumeFs(t)
+		fsMock.EXPECT().MkdirAll(mock.Anything, testNamespacePath, os.FileMode(0755)).Return(nil)
+		fsMock.EXPECT().OpenFile(mock.Anything, testTemporaryPath, os.O_RDWR|os.O_CREATE|os.O_TRUNC, os.FileMode(0644)).Return(fileMock, nil)
+		fsMock.EXPECT().Remove(mock.Anything, testTemporaryPath).Return(nil)
+		zipMock := NewMockZipper(t)
+		zipMock.EXPECT().CreateHeader(testZipHeader("manifest.json")).Return(&bytes.Buffer{}, nil)
+		zipMock.EXPECT().Close().Return(nil)
+		z := &ZipFileArchiveRepository{
+			filesystem:   fsMock,
+			zipCreator:   func(w io.Writer) Zipper { return zipMock },
+			archivesPath: testArchivesPath,
+		}
+
+		// when
+		_, err := z.Create(testCtx, testID, nil, testCollectionEnd)
+
+		// then
+		require.Error(t, err)
+		assert.ErrorIs(t, err, assert.AnError)
+		assert.ErrorContains(t, err, "failed to sync zip file test-archives/ecosystem/archive-123.zip.tmp")
+	})
+
+	t.Run("should remove the renamed archive if the verification fails", func(t *testing.T) {
+		// given
+		fileMock := newMockClosableRWFile(t)
+		fileMock.EXPECT().Sync().Return(nil)
+		fileMock.EXPECT().Close().Return(nil)
+		fsMock := newMockVolumeFs(t)
+		fsMock.EXPECT().MkdirAll(mock.Anything, testNamespacePath, os.FileMode(0755)).Return(nil)
+		fsMock.EXPECT().OpenFile(mock.Anything, testTemporaryPath, os.O_RDWR|os.O_CREATE|os.O_TRUNC, os.FileMode(0644)).Return(fileMock, nil)
+		fsMock.EXPECT().Rename(mock.Anything, testTemporaryPath, testArchivePath).Return(nil)
+		fsMock.EXPECT().Remove(mock.Anything, testArchivePath).Return(nil)
+		fsMock.EXPECT().Remove(mock.Anything, testTemporaryPath).Return(fs.ErrNotExist)
+		zipMock := NewMockZipper(t)
+		zipMock.EXPECT().CreateHeader(testZipHeader("manifest.json")).Return(&bytes.Buffer{}, nil)
+		zipMock.EXPECT().Close().Return(nil)
+		z := &ZipFileArchiveRepository{
+			filesystem:   fsMock,
+			zipCreator:   func(w io.Writer) Zipper { return zipMock },
+			zipVerifier:  func(context.Context, string) error { return zip.ErrFormat },
+			archivesPath: testArchivesPath,
+		}
+
+		// when
+		_, err := z.Create(testCtx, testID, nil, testCollectionEnd)
+
+		// then
+		require.Error(t, err)
+		assert.ErrorIs(t, err, zip.ErrFormat)
+		assert.ErrorContains(t, err, "failed to verify zip file test-archives/ecosystem/archive-123.zip")
+	})
+
+	t.Run("should return error if the unverified archive cannot be removed", func(t *testing.T) {
+		// given
+		fileMock := newMockClosableRWFile(t)
+		fileMock.EXPECT().Sync().Return(nil)
+		fileMock.EXPECT().Close().Return(nil)
+		fsMock := newMockVolumeFs(t)
+		fsMock.EXPECT().MkdirAll(mock.Anything, testNamespacePath, os.FileMode(0755)).Return(nil)
+		fsMock.EXPECT().OpenFile(mock.Anything, testTemporaryPath, os.O_RDWR|os.O_CREATE|os.O_TRUNC, os.FileMode(0644)).Return(fileMock, nil)
+		fsMock.EXPECT().Rename(mock.Anything, testTemporaryPath, testArchivePath).Return(nil)
+		fsMock.EXPECT().Remove(mock.Anything, testArchivePath).Return(assert.AnError)
+		fsMock.EXPECT().Remove(mock.Anything, testTemporaryPath).Return(fs.ErrNotExist)
+		zipMock := NewMockZipper(t)
+		zipMock.EXPECT().CreateHeader(testZipHeader("manifest.json")).Return(&bytes.Buffer{}, nil)
+		zipMock.EXPECT().Close().Return(nil)
+		z := &ZipFileArchiveRepository{
+			filesystem:   fsMock,
+			zipCreator:   func(w io.Writer) Zipper { return zipMock },
+			zipVerifier:  func(context.Context, string) error { return zip.ErrFormat },
+			archivesPath: testArchivesPath,
+		}
+
+		// when
+		_, err := z.Create(testCtx, testID, nil, testCollectionEnd)
+
+		// then
+		require.Error(t, err)
+		assert.ErrorIs(t, err, zip.ErrFormat)
+		assert.ErrorIs(t, err, assert.AnError)
+		assert.ErrorContains(t, err, "failed to remove unverified zip file test-archives/ecosystem/archive-123.zip")
+	})
+
+	t.Run("should return error on rename error", func(t *testing.T) {
+		// given
+		fileMock := newMockClosableRWFile(t)
+		fileMock.EXPECT().Sync().Return(nil)
+		fileMock.EXPECT().Close().Return(nil)
+		fsMock := newMockVolumeFs(t)
+		fsMock.EXPECT().MkdirAll(mock.Anything, testNamespacePath, os.FileMode(0755)).Return(nil)
+		fsMock.EXPECT().OpenFile(mock.Anything, testTemporaryPath, os.O_RDWR|os.O_CREATE|os.O_TRUNC, os.FileMode(0644)).Return(fileMock, nil)
+		fsMock.EXPECT().Rename(mock.Anything, testTemporaryPath, testArchivePath).Return(assert.AnError)
+		fsMock.EXPECT().Remove(mock.Anything, testTemporaryPath).Return(nil)
+		zipMock := NewMockZipper(t)
+		zipMock.EXPECT().CreateHeader(testZipHeader("manifest.json")).Return(&bytes.Buffer{}, nil)
+		zipMock.EXPECT().Close().Return(nil)
+		z := &ZipFileArchiveRepository{
+			filesystem:   fsMock,
+			zipCreator:   func(w io.Writer) Zipper { return zipMock },
+			zipVerifier:  func(context.Context, string) error { return nil },
+			archivesPath: testArchivesPath,
+		}
+
+		// when
+		_, err := z.Create(testCtx, testID, nil, testCollectionEnd)
+
+		// then
+		require.Error(t, err)
+		assert.ErrorIs(t, err, assert.AnError)
+		assert.ErrorContains(t, err, "failed to rename zip file")
+	})
+
+	t.Run("should return error on error creating manifest", func(t *testing.T) {
+		// given
+		zipMock := NewMockZipper(t)
+		zipMock.EXPECT().CreateHeader(testZipHeader("manifest.json")).Return(nil, assert.AnError)
+
+		// when
+		err := writeManifest(zipMock, &domain.ArchiveManifest{CreatedAt: testCollectionEnd})
+
+		// then
+		require.Error(t, err)
+		assert.ErrorIs(t, err, assert.AnError)
+		assert.ErrorContains(t, err, "failed to create zip writer for manifest")
+	})
+}
+
+func testZipHeader(name string) *zip.FileHeader {
+	return &zip.FileHeader{Name: name, Method: zip.Deflate, Modified: testCollectionEnd}
 }
 
 func getTestStream(casReader io.Reader, ldapReader io.Reader, failToCreate, failToCloseReader, closeStream bool) *domain.Stream {
@@ -631,7 +796,7 @@ func TestNewZipFileArchiveRepository(t *testing.T) {
 	}
 
 	// when
-	repository := NewZipFileArchiveRepository(testArchivesPath, creatorFunc, c)
+	repository := NewZipFileArchiveRepository(testArchivesPath, creatorFunc, filesystem.FileSystem{}, c)
 
 	// then
 	require.NotNil(t, repository)
@@ -640,4 +805,139 @@ func TestNewZipFileArchiveRepository(t *testing.T) {
 	assert.Equal(t, testProtocol, repository.archiveVolumeDownloadServiceProtocol)
 	assert.Equal(t, testServiceName, repository.archiveVolumeDownloadServiceName)
 	assert.Equal(t, zipMock, repository.zipCreator(nil))
+	assert.NotNil(t, repository.zipVerifier)
+}
+
+func TestZipFileArchiveRepository_reproducible(t *testing.T) {
+	newStreams := func() map[domain.CollectorType]*domain.Stream {
+		newStream := func(files ...string) *domain.Stream {
+			data := make(chan domain.StreamData, len(files))
+			for _, file := range files {
+				data <- domain.StreamData{ID: file, StreamConstructor: func() (io.Reader, domain.CloseStreamFunc, error) {
+					return bytes.NewBufferString("content of " + file), func() error { return nil }, nil
+				}}
+			}
+			close(data)
+			return &domain.Stream{Data: data}
+		}
+
+		return map[domain.CollectorType]*domain.Stream{
+			domain.CollectorTypeSystemState: newStream("core/v1/Pod/a.yaml", "core/v1/Pod/b.yaml"),
+			domain.CollectorTypeLog:         newStream("logs.log"),
+			domain.CollectorTypeSecret:      newStream("secret.yaml"),
+			domain.ArchiveRootDir:           newStream("summary.html"),
+		}
+	}
+	createArchive := func(t *testing.T) []byte {
+		z := NewZipFileArchiveRepository(t.TempDir(), NewZipWriter, filesystem.FileSystem{}, &config.OperatorConfig{})
+		_, err := z.Create(testCtx, testID, newStreams(), testCollectionEnd.In(time.FixedZone("CET", 3600)))
+		require.NoError(t, err)
+		content, err := os.ReadFile(z.GetArchivePath(testID))
+		require.NoError(t, err)
+		return content
+	}
+
+	// when
+	first := createArchive(t)
+	second := createArchive(t)
+
+	// then
+	assert.Equal(t, first, second)
+	reader, err := zip.NewReader(bytes.NewReader(first), int64(len(first)))
+	require.NoError(t, err)
+	var names []string
+	for _, file := range reader.File {
+		names = append(names, file.Name)
+		assert.True(t, testCollectionEnd.Equal(file.Modified), file.Name)
+	}
+	assert.Equal(t, []string{"summary.html", "Logs/logs.log", "Resources/Secrets/secret.yaml", "Resources/SystemState/core/v1/Pod/a.yaml", "Resources/SystemState/core/v1/Pod/b.yaml", "manifest.json"}, names)
+}
+
+func TestZipFileArchiveRepository_interruptedCreation(t *testing.T) {
+	newRepository := func(t *testing.T) *ZipFileArchiveRepository {
+		return NewZipFileArchiveRepository(t.TempDir(), NewZipWriter, filesystem.FileSystem{}, &config.OperatorConfig{})
+	}
+
+	t.Run("should create the archive without temporary file", func(t *testing.T) {
+		// given
+		z := newRepository(t)
+
+		// when
+		_, err := z.Create(testCtx, testID, map[domain.CollectorType]*domain.Stream{}, testCollectionEnd)
+
+		// then
+		require.NoError(t, err)
+		exists, err := z.Exists(testCtx, testID)
+		require.NoError(t, err)
+		assert.True(t, exists)
+		_, err = os.Stat(z.GetArchivePath(testID) + ".tmp")
+		assert.ErrorIs(t, err, fs.ErrNotExist)
+	})
+
+	t.Run("should detect truncated archive on verification", func(t *testing.T) {
+		// given
+		z := newRepository(t)
+		_, err := z.Create(testCtx, testID, map[domain.CollectorType]*domain.Stream{}, testCollectionEnd)
+		require.NoError(t, err)
+		content, err := os.ReadFile(z.GetArchivePath(testID))
+		require.NoError(t, err)
+		require.NoError(t, os.WriteFile(z.GetArchivePath(testID), content[:len(content)/2], 0644))
+
+		// when
+		err = z.zipVerifier(testCtx, z.GetArchivePath(testID))
+
+		// then
+		assert.ErrorIs(t, err, zip.ErrFormat)
+	})
+
+	t.Run("should remove and not list temporary archives", func(t *testing.T) {
+		// given
+		z := newRepository(t)
+		_, err := z.Create(testCtx, testID, map[domain.CollectorType]*domain.Stream{}, testCollectionEnd)
+		require.NoError(t, err)
+		temporaryPath := filepath.Join(z.archivesPath, "other", "interrupted.zip.tmp")
+		require.NoError(t, os.MkdirAll(filepath.Dir(temporaryPath), 0755))
+		require.NoError(t, os.WriteFile(temporaryPath, []byte("PK"), 0644))
+
+		list, err := z.List(testCtx)
+		require.NoError(t, err)
+		assert.Equal(t, []domain.SupportArchiveID{testID}, list)
+
+		// when
+		err = z.RemoveTemporaryArchives(testCtx, 0)
+
+		// then
+		require.NoError(t, err)
+		_, err = os.Stat(temporaryPath)
+		assert.ErrorIs(t, err, fs.ErrNotExist)
+		_, err = os.Stat(z.GetArchivePath(testID))
+		assert.NoError(t, err)
+	})
+
+	t.Run("should keep recently modified temporary archives", func(t *testing.T) {
+		// given
+		z := newRepository(t)
+		temporaryPath := filepath.Join(z.archivesPath, "other", "writing.zip.tmp")
+		require.NoError(t, os.MkdirAll(filepath.Dir(temporaryPath), 0755))
+		require.NoError(t, os.WriteFile(temporaryPath, []byte("PK"), 0644))
+
+		// when
+		err := z.RemoveTemporaryArchives(testCtx, time.Hour)
+
+		// then
+		require.NoError(t, err)
+		_, err = os.Stat(temporaryPath)
+		assert.NoError(t, err)
+	})
+
+	t.Run("should ignore missing archives path", func(t *testing.T) {
+		// given
+		z := NewZipFileArchiveRepository(filepath.Join(t.TempDir(), "missing"), NewZipWriter, filesystem.FileSystem{}, &config.OperatorConfig{})
+
+		// when
+		err := z.RemoveTemporaryArchives(testCtx, 0)
+
+		// then
+		require.NoError(t, err)
+	})
 }
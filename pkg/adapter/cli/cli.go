@@ -0,0 +1,284 @@
+// Package cli implements the support-archive command line tool which inspects downloaded archives
+// without access to the cluster and creates archives without the operator.
+package cli
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"flag"
+	"fmt"
+	"io"
+	"text/tabwriter"
+	"time"
+
+	"github.com/cloudogu/k8s-support-archive-operator/pkg/adapter/archive/file"
+	"github.com/cloudogu/k8s-support-archive-operator/pkg/adapter/filesystem"
+	"github.com/cloudogu/k8s-support-archive-operator/pkg/domain"
+)
+
+const (
+	exitCodeOK      = 0
+	exitCodeFailure = 1
+	exitCodeUsage   = 2
+
+	defaultGrafanaDir = "grafana/archives"
+)
+
+const usage = `Usage: support-archive <command> [flags] <archive.zip>
+       support-archive diff [flags] <before.zip> <after.zip>
+       support-archive create [flags]
+
+Commands:
+  create     create an archive with the current kubeconfig without the operator
+  list       list all files of the archive
+  manifest   print the manifest of the archive
+  logs       print log lines, filtered by pod, level and time range
+  nodeinfo   print minimum, average, maximum and last value of the node metrics
+  verify     verify the files of the archive against the checksums of the manifest
+  grafana    export the archive in the directory layout of the grafana docker-compose setup
+  diff       compare the system state, secrets, volumes and nodes of two archives
+
+Run 'support-archive <command> -h' for the flags of a command.
+`
+
+var errVerificationFailed = errors.New("verification failed")
+
+type command struct {
+	flags *flag.FlagSet
+	run   func(ctx context.Context, archive *file.ZipArchiveReader, stdout io.Writer) error
+	// runWithoutArchive is set for commands which do not read an existing archive.
+	runWithoutArchive func(ctx context.Context, stdout, stderr io.Writer) error
+	// runWithTwoArchives is set for commands which compare two archives.
+	runWithTwoArchives func(ctx context.Context, before, after *file.ZipArchiveReader, stdout io.Writer) error
+}
+
+// Run executes the command given by args and returns the exit code.
+func Run(ctx context.Context, args []string, stdout, stderr io.Writer) int {
+	if len(args) == 0 {
+		_, _ = fmt.Fprint(stderr, usage)
+		return exitCodeUsage
+	}
+
+	cmd, ok := newCommands()[args[0]]
+	if !ok {
+		_, _ = fmt.Fprintf(stderr, "unknown command %q\n\n%s", args[0], usage)
+		return exitCodeUsage
+	}
+
+	cmd.flags.SetOutput(stderr)
+	err := cmd.flags.Parse(args[1:])
+	if errors.Is(err, flag.ErrHelp) {
+		return exitCodeOK
+	} else if err != nil {
+		return exitCodeUsage
+	}
+	if cmd.runWithoutArchive != nil {
+		return runWithoutArchive(ctx, cmd, stdout, stderr)
+	}
+	if cmd.runWithTwoArchives != nil {
+		return runWithTwoArchives(ctx, cmd, stdout, stderr)
+	}
+	if cmd.flags.NArg() != 1 {
+		_, _ = fmt.Fprintf(stderr, "expected exactly one archive but got %d arguments\n", cmd.flags.NArg())
+		cmd.flags.Usage()
+		return exitCodeUsage
+	}
+
+	archive, err := file.OpenZipArchiveReader(ctx, cmd.flags.Arg(0), filesystem.FileSystem{})
+	if err != nil {
+		_, _ = fmt.Fprintln(stderr, err)
+		return exitCodeFailure
+	}
+	defer func() {
+		_ = archive.Close()
+	}()
+
+	err = cmd.run(ctx, archive, stdout)
+	if err != nil {
+		_, _ = fmt.Fprintln(stderr, err)
+		return exitCodeFailure
+	}
+
+	return exitCodeOK
+}
+
+func runWithoutArchive(ctx context.Context, cmd command, stdout, stderr io.Writer) int {
+	if cmd.flags.NArg() != 0 {
+		_, _ = fmt.Fprintf(stderr, "expected no arguments but got %d\n", cmd.flags.NArg())
+		cmd.flags.Usage()
+		return exitCodeUsage
+	}
+
+	err := cmd.runWithoutArchive(ctx, stdout, stderr)
+	if err != nil {
+		_, _ = fmt.Fprintln(stderr, err)
+		return exitCodeFailure
+	}
+
+	return exitCodeOK
+}
+
+func runWithTwoArchives(ctx context.Context, cmd command, stdout, stderr io.Writer) int {
+	if cmd.flags.NArg() != 2 {
+		_, _ = fmt.Fprintf(stderr, "expected exactly two archives but got %d arguments\n", cmd.flags.NArg())
+		cmd.flags.Usage()
+		return exitCodeUsage
+	}
+
+	var archives []*file.ZipArchiveReader
+	defer func() {
+		for _, archive := range archives {
+			_ = archive.Close()
+		}
+	}()
+	for _, archivePath := range cmd.flags.Args() {
+		archive, err := file.OpenZipArchiveReader(ctx, archivePath, filesystem.FileSystem{})
+		if err != nil {
+			_, _ = fmt.Fprintln(stderr, err)
+			return exitCodeFailure
+		}
+		archives = append(archives, archive)
+	}
+
+	err := cmd.runWithTwoArchives(ctx, archives[0], archives[1], stdout)
+	if err != nil {
+		_, _ = fmt.Fprintln(stderr, err)
+		return exitCodeFailure
+	}
+
+	return exitCodeOK
+}
+
+func newCommands() map[string]command {
+	return map[string]command{
+		"create":   newCreateCommand(),
+		"list":     {flags: flag.NewFlagSet("list", flag.ContinueOnError), run: listFiles},
+		"manifest": {flags: flag.NewFlagSet("manifest", flag.ContinueOnError), run: printManifest},
+		"logs":     newLogsCommand(),
+		"nodeinfo": {flags: flag.NewFlagSet("nodeinfo", flag.ContinueOnError), run: printNodeInfo},
+		"verify":   {flags: flag.NewFlagSet("verify", flag.ContinueOnError), run: verify},
+		"grafana":  newGrafanaCommand(),
+		"diff":     newDiffCommand(),
+	}
+}
+
+func newLogsCommand() command {
+	flags := flag.NewFlagSet("logs", flag.ContinueOnError)
+	pod := flags.String("pod", "", "only print lines of pods starting with this name")
+	level := flags.String("level", "", "only print lines with this level, e.g. error")
+	since := flags.String("since", "", "only print lines at or after this time (RFC3339)")
+	until := flags.String("until", "", "only print lines at or before this time (RFC3339)")
+
+	return command{flags: flags, run: func(_ context.Context, archive *file.ZipArchiveReader, stdout io.Writer) error {
+		filter := domain.LogFilter{Pod: *pod, Level: *level}
+		var err error
+		filter.Since, err = parseOptionalTime("since", *since)
+		if err != nil {
+			return err
+		}
+		filter.Until, err = parseOptionalTime("until", *until)
+		if err != nil {
+			return err
+		}
+
+		return printLogs(archive, filter, stdout)
+	}}
+}
+
+func newGrafanaCommand() command {
+	flags := flag.NewFlagSet("grafana", flag.ContinueOnError)
+	out := flags.String("out", defaultGrafanaDir, "directory mounted as archives by the grafana docker-compose setup")
+
+	return command{flags: flags, run: func(ctx context.Context, archive *file.ZipArchiveReader, stdout io.Writer) error {
+		err := archive.ExportGrafana(ctx, *out)
+		if err != nil {
+			return err
+		}
+		_, err = fmt.Fprintf(stdout, "exported archive to %s\n", *out)
+		return err
+	}}
+}
+
+func parseOptionalTime(name, value string) (time.Time, error) {
+	if value == "" {
+		return time.Time{}, nil
+	}
+
+	timestamp, err := time.Parse(time.RFC3339, value)
+	if err != nil {
+		return time.Time{}, fmt.Errorf("invalid value for %s: %w", name, err)
+	}
+
+	return timestamp, nil
+}
+
+func listFiles(_ context.Context, archive *file.ZipArchiveReader, stdout io.Writer) error {
+	writer := tabwriter.NewWriter(stdout, 0, 0, 2, ' ', tabwriter.AlignRight)
+	for _, f := range archive.Files() {
+		_, _ = fmt.Fprintf(writer, "%d\t  %s\t\n", f.Size, f.Path)
+	}
+
+	return writer.Flush()
+}
+
+func printManifest(_ context.Context, archive *file.ZipArchiveReader, stdout io.Writer) error {
+	manifest, err := archive.Manifest()
+	if err != nil {
+		return err
+	}
+
+	encoder := json.NewEncoder(stdout)
+	encoder.SetIndent("", "  ")
+	return encoder.Encode(manifest)
+}
+
+func printLogs(archive *file.ZipArchiveReader, filter domain.LogFilter, stdout io.Writer) error {
+	return archive.Logs(filter, func(line domain.ArchiveLogLine) error {
+		timestamp := "-"
+		if !line.Time.IsZero() {
+			timestamp = line.Time.Format(time.RFC3339Nano)
+		}
+		_, err := fmt.Fprintf(stdout, "%s %s/%s/%s [%s] %s\n", timestamp, line.Namespace, line.Pod, line.Container, line.Level, line.Message)
+		return err
+	})
+}
+
+func printNodeInfo(_ context.Context, archive *file.ZipArchiveReader, stdout io.Writer) error {
+	samples, err := archive.NodeInfo()
+	if err != nil {
+		return err
+	}
+
+	writer := tabwriter.NewWriter(stdout, 0, 0, 2, ' ', 0)
+	_, _ = fmt.Fprintln(writer, "METRIC\tNODE\tSAMPLES\tMIN\tAVG\tMAX\tLAST\tLAST TIME")
+	for _, summary := range domain.SummarizeSamples(samples) {
+		_, _ = fmt.Fprintf(writer, "%s\t%s\t%d\t%.2f\t%.2f\t%.2f\t%.2f\t%s\n", summary.MetricName, summary.ID, summary.Count,
+			summary.Min, summary.Avg, summary.Max, summary.Last, summary.LastTime.UTC().Format(time.RFC3339))
+	}
+
+	return writer.Flush()
+}
+
+func verify(_ context.Context, archive *file.ZipArchiveReader, stdout io.Writer) error {
+	verification, err := archive.Verify()
+	if err != nil {
+		return err
+	}
+
+	for _, path := range verification.Mismatched {
+		_, _ = fmt.Fprintf(stdout, "checksum mismatch: %s\n", path)
+	}
+	for _, path := range verification.Missing {
+		_, _ = fmt.Fprintf(stdout, "missing: %s\n", path)
+	}
+	for _, path := range verification.Unlisted {
+		_, _ = fmt.Fprintf(stdout, "not in manifest: %s\n", path)
+	}
+	if !verification.Valid() {
+		return errVerificationFailed
+	}
+
+	_, err = fmt.Fprintln(stdout, "all files match the manifest")
+	return err
+}
@@ -0,0 +1,234 @@
+package cli
+
+import (
+	"archive/zip"
+	"bytes"
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/cloudogu/k8s-support-archive-operator/pkg/domain"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+const (
+	testLogs = `LOGS
+{"message":"started","stream_namespace":"ecosystem","stream_pod":"ldap-0","stream_container":"ldap","stream_detected_level":"info","time_unix_nano":"1735689600000000000"}
+{"message":"failed","stream_namespace":"ecosystem","stream_pod":"cas-1","stream_container":"cas","level":"error","time_unix_nano":"1735689660000000000"}
+`
+	testNodeInfo = `label,value,time
+node-1,50.00,2025-01-01T00:00:00+00:00
+node-1,70.00,2025-01-01T00:01:00+00:00
+`
+)
+
+// writeTestArchive creates an archive with the given files. The manifest lists the files of listed.
+func writeTestArchive(t *testing.T, files map[string]string, listed map[string]string) string {
+	t.Helper()
+
+	archivePath := filepath.Join(t.TempDir(), "archive.zip")
+	out, err := os.Create(archivePath)
+	require.NoError(t, err)
+	zipWriter := zip.NewWriter(out)
+
+	manifest := domain.ArchiveManifest{Namespace: "ecosystem", Name: "archive-123"}
+	for name, content := range listed {
+		checksum := sha256.Sum256([]byte(content))
+		manifest.Files = append(manifest.Files, domain.ManifestFile{Path: name, Size: int64(len(content)), SHA256: hex.EncodeToString(checksum[:])})
+	}
+	manifest.SortFiles()
+	manifestContent, err := json.Marshal(manifest)
+	require.NoError(t, err)
+
+	for name, content := range files {
+		writer, createErr := zipWriter.Create(name)
+		require.NoError(t, createErr)
+		_, err = writer.Write([]byte(content))
+		require.NoError(t, err)
+	}
+	writer, err := zipWriter.Create(domain.ManifestFileName)
+	require.NoError(t, err)
+	_, err = writer.Write(manifestContent)
+	require.NoError(t, err)
+	require.NoError(t, zipWriter.Close())
+	require.NoError(t, out.Close())
+
+	return archivePath
+}
+
+func run(args ...string) (int, string, string) {
+	var stdout, stderr bytes.Buffer
+	exitCode := Run(context.Background(), args, &stdout, &stderr)
+	return exitCode, stdout.String(), stderr.String()
+}
+
+func TestRun(t *testing.T) {
+	files := map[string]string{"Logs/logs.log": testLogs, "NodeInfo/ramUsedRelative.csv": testNodeInfo}
+	archivePath := writeTestArchive(t, files, files)
+
+	t.Run("should print usage without command", func(t *testing.T) {
+		exitCode, _, stderr := run()
+
+		assert.Equal(t, exitCodeUsage, exitCode)
+		assert.Contains(t, stderr, "Usage: support-archive <command>")
+	})
+	t.Run("should fail on unknown command", func(t *testing.T) {
+		exitCode, _, stderr := run("unknown", archivePath)
+
+		assert.Equal(t, exitCodeUsage, exitCode)
+		assert.Contains(t, stderr, `unknown command "unknown"`)
+	})
+	t.Run("should fail without archive", func(t *testing.T) {
+		exitCode, _, stderr := run("list")
+
+		assert.Equal(t, exitCodeUsage, exitCode)
+		assert.Contains(t, stderr, "expected exactly one archive but got 0 arguments")
+	})
+	t.Run("should fail if archive cannot be opened", func(t *testing.T) {
+		exitCode, _, stderr := run("list", filepath.Join(t.TempDir(), "missing.zip"))
+
+		assert.Equal(t, exitCodeFailure, exitCode)
+		assert.Contains(t, stderr, "failed to open archive")
+	})
+	t.Run("should list files", func(t *testing.T) {
+		exitCode, stdout, _ := run("list", archivePath)
+
+		assert.Equal(t, exitCodeOK, exitCode)
+		assert.Contains(t, stdout, "  Logs/logs.log\n")
+		assert.Contains(t, stdout, "  NodeInfo/ramUsedRelative.csv\n")
+		assert.Contains(t, stdout, "  manifest.json\n")
+	})
+	t.Run("should print manifest", func(t *testing.T) {
+		exitCode, stdout, _ := run("manifest", archivePath)
+
+		assert.Equal(t, exitCodeOK, exitCode)
+		assert.Contains(t, stdout, `"namespace": "ecosystem"`)
+		assert.Contains(t, stdout, `"path": "Logs/logs.log"`)
+	})
+	t.Run("should print filtered logs", func(t *testing.T) {
+		exitCode, stdout, _ := run("logs", "-level", "error", "-since", "2025-01-01T00:00:30Z", archivePath)
+
+		assert.Equal(t, exitCodeOK, exitCode)
+		assert.Equal(t, "2025-01-01T00:01:00Z ecosystem/cas-1/cas [error] failed\n", stdout)
+	})
+	t.Run("should print logs of pod", func(t *testing.T) {
+		exitCode, stdout, _ := run("logs", "-pod", "ldap", "-until", "2025-01-01T00:00:00Z", archivePath)
+
+		assert.Equal(t, exitCodeOK, exitCode)
+		assert.Equal(t, "2025-01-01T00:00:00Z ecosystem/ldap-0/ldap [info] started\n", stdout)
+	})
+	t.Run("should fail on invalid time", func(t *testing.T) {
+		exitCode, _, stderr := run("logs", "-since", "yesterday", archivePath)
+
+		assert.Equal(t, exitCodeFailure, exitCode)
+		assert.Contains(t, stderr, "invalid value for since")
+	})
+	t.Run("should print node info summary", func(t *testing.T) {
+		exitCode, stdout, _ := run("nodeinfo", archivePath)
+
+		assert.Equal(t, exitCodeOK, exitCode)
+		assert.Contains(t, stdout, "METRIC")
+		assert.Regexp(t, `ramUsedRelative\s+node-1\s+2\s+50.00\s+60.00\s+70.00\s+70.00\s+2025-01-01T00:01:00Z`, stdout)
+	})
+	t.Run("should verify unchanged archive", func(t *testing.T) {
+		exitCode, stdout, _ := run("verify", archivePath)
+
+		assert.Equal(t, exitCodeOK, exitCode)
+		assert.Equal(t, "all files match the manifest\n", stdout)
+	})
+	t.Run("should fail verification of changed archive", func(t *testing.T) {
+		changed := writeTestArchive(t, map[string]string{"Logs/logs.log": "changed", "extra.txt": ""}, files)
+
+		exitCode, stdout, stderr := run("verify", changed)
+
+		assert.Equal(t, exitCodeFailure, exitCode)
+		assert.Equal(t, "checksum mismatch: Logs/logs.log\nmissing: NodeInfo/ramUsedRelative.csv\nnot in manifest: extra.txt\n", stdout)
+		assert.Contains(t, stderr, "verification failed")
+	})
+	t.Run("should export archive for grafana", func(t *testing.T) {
+		out := filepath.Join(t.TempDir(), "archives")
+
+		exitCode, stdout, _ := run("grafana", "-out", out, archivePath)
+
+		assert.Equal(t, exitCodeOK, exitCode)
+		assert.Contains(t, stdout, "exported archive to "+out)
+		assert.FileExists(t, filepath.Join(out, "Logs", "logs.log"))
+		assert.FileExists(t, filepath.Join(out, "NodeInfo", "ramUsedRelative.csv"))
+	})
+	t.Run("should fail with archive for create", func(t *testing.T) {
+		exitCode, _, stderr := run("create", archivePath)
+
+		assert.Equal(t, exitCodeUsage, exitCode)
+		assert.Contains(t, stderr, "expected no arguments but got 1")
+	})
+	t.Run("should fail create on invalid excluded content", func(t *testing.T) {
+		exitCode, _, stderr := run("create", "-exclude", "metrics")
+
+		assert.Equal(t, exitCodeFailure, exitCode)
+		assert.Contains(t, stderr, `invalid content "metrics" to exclude`)
+	})
+	t.Run("should print help of command", func(t *testing.T) {
+		exitCode, _, stderr := run("logs", "-h")
+
+		assert.Equal(t, exitCodeOK, exitCode)
+		assert.Contains(t, stderr, "-pod")
+	})
+}
+
+func TestRun_diff(t *testing.T) {
+	beforeFiles := map[string]string{
+		"Resources/SystemState/apps/v1/Deployment/ldap.yaml": "name: ldap\ncontent:\n  spec:\n    replicas: 1\n",
+		"Resources/Secrets/ldap.yaml":                        "data:\n  password: '***'\n",
+		"NodeInfo/count.csv":                                 "label,value,time\n,2.00,2025-01-01T00:00:00Z\n",
+	}
+	afterFiles := map[string]string{
+		"Resources/SystemState/apps/v1/Deployment/ldap.yaml": "name: ldap\ncontent:\n  spec:\n    replicas: 2\n",
+		"Resources/Secrets/ldap.yaml":                        "data:\n  password: '***'\n  admin: '***'\n",
+		"NodeInfo/count.csv":                                 "label,value,time\n,3.00,2025-01-02T00:00:00Z\n",
+	}
+	beforePath := writeTestArchive(t, beforeFiles, beforeFiles)
+	afterPath := writeTestArchive(t, afterFiles, afterFiles)
+
+	t.Run("should fail without two archives", func(t *testing.T) {
+		exitCode, _, stderr := run("diff", beforePath)
+
+		assert.Equal(t, exitCodeUsage, exitCode)
+		assert.Contains(t, stderr, "expected exactly two archives but got 1 arguments")
+	})
+	t.Run("should fail if archive cannot be opened", func(t *testing.T) {
+		exitCode, _, stderr := run("diff", beforePath, filepath.Join(t.TempDir(), "missing.zip"))
+
+		assert.Equal(t, exitCodeFailure, exitCode)
+		assert.Contains(t, stderr, "failed to open archive")
+	})
+	t.Run("should print differences", func(t *testing.T) {
+		exitCode, stdout, _ := run("diff", beforePath, afterPath)
+
+		assert.Equal(t, exitCodeOK, exitCode)
+		assert.Equal(t, "CHANGE   KIND      OBJECT                                              DETAILS\n"+
+			"changed  resource  Resources/SystemState/apps/v1/Deployment/ldap.yaml  spec.replicas\n"+
+			"changed  secret    Resources/Secrets/ldap.yaml                         +admin\n"+
+			"changed  nodes     count                                               2 -> 3\n", stdout)
+	})
+	t.Run("should print differences as json", func(t *testing.T) {
+		exitCode, stdout, _ := run("diff", "-json", beforePath, afterPath)
+
+		assert.Equal(t, exitCodeOK, exitCode)
+		var diff domain.ArchiveDiff
+		require.NoError(t, json.Unmarshal([]byte(stdout), &diff))
+		assert.Equal(t, beforePath, diff.Reference)
+		assert.Equal(t, domain.NodeCountDelta{Before: 2, After: 3}, diff.Nodes)
+		assert.Equal(t, []domain.SecretDiff{{Path: "Resources/Secrets/ldap.yaml", Change: domain.DiffChangeChanged, AddedKeys: []string{"admin"}}}, diff.Secrets)
+	})
+	t.Run("should print no differences for the same archive", func(t *testing.T) {
+		exitCode, stdout, _ := run("diff", afterPath, afterPath)
+
+		assert.Equal(t, exitCodeOK, exitCode)
+		assert.Equal(t, "no differences in system state, secrets, volumes and nodes\n", stdout)
+	})
+}
@@ -0,0 +1,238 @@
+package cli
+
+import (
+	"context"
+	"flag"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"slices"
+	"strings"
+	"time"
+
+	"k8s.io/client-go/kubernetes"
+	clientgoscheme "k8s.io/client-go/kubernetes/scheme"
+	"k8s.io/client-go/rest"
+	"k8s.io/client-go/tools/clientcmd"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/log"
+	"sigs.k8s.io/controller-runtime/pkg/log/zap"
+
+	libapi "github.com/cloudogu/k8s-support-archive-lib/api/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	"github.com/cloudogu/k8s-support-archive-operator/pkg/adapter/archive/file"
+	"github.com/cloudogu/k8s-support-archive-operator/pkg/adapter/config"
+	"github.com/cloudogu/k8s-support-archive-operator/pkg/adapter/filesystem"
+	"github.com/cloudogu/k8s-support-archive-operator/pkg/adapter/setup"
+	"github.com/cloudogu/k8s-support-archive-operator/pkg/domain"
+	"github.com/cloudogu/k8s-support-archive-operator/pkg/usecase"
+)
+
+const (
+	defaultNamespace     = "ecosystem"
+	defaultPrometheusURL = "http://localhost:9090"
+	// lokiPasswordEnvVar is used instead of a flag to keep the password out of the shell history.
+	lokiPasswordEnvVar = "LOG_GATEWAY_PASSWORD"
+)
+
+var excludableContents = []string{"logs", "volumeInfo", "systemInfo", "sensitiveData", "events", "systemState"}
+
+type createOptions struct {
+	kubeconfig    string
+	kubeContext   string
+	namespace     string
+	name          string
+	out           string
+	exclude       string
+	since         string
+	until         string
+	last          string
+	timeframes    string
+	prometheusURL string
+	lokiURL       string
+	lokiUsername  string
+	timeline      bool
+	deadline      time.Duration
+}
+
+func newCreateCommand() command {
+	flags := flag.NewFlagSet("create", flag.ContinueOnError)
+	opts := &createOptions{}
+	flags.StringVar(&opts.kubeconfig, "kubeconfig", "", "path of the kubeconfig, defaults to $KUBECONFIG or ~/.kube/config")
+	flags.StringVar(&opts.kubeContext, "context", "", "context of the kubeconfig, defaults to the current context")
+	flags.StringVar(&opts.namespace, "namespace", defaultNamespace, "namespace of the ecosystem")
+	flags.StringVar(&opts.name, "name", "", "name of the archive, defaults to support-archive-<timestamp>")
+	flags.StringVar(&opts.out, "out", "", "path of the created archive, defaults to <name>.zip")
+	flags.StringVar(&opts.exclude, "exclude", "", "comma separated contents to exclude: "+strings.Join(excludableContents, ", "))
+	flags.StringVar(&opts.since, "since", "", "start of the content timeframe (RFC3339)")
+	flags.StringVar(&opts.until, "until", "", "end of the content timeframe (RFC3339), defaults to now")
+	flags.StringVar(&opts.last, "last", "", "relative content timeframe up to now, e.g. 6h or 7d; replaces since and until")
+	flags.StringVar(&opts.timeframes, "collector-timeframes", "", "comma separated relative timeframes of single collectors, e.g. logs=24h,metrics=7d")
+	flags.StringVar(&opts.prometheusURL, "prometheus-url", defaultPrometheusURL, "url of prometheus, e.g. forwarded with kubectl port-forward")
+	flags.StringVar(&opts.lokiURL, "loki-url", "", "url of the loki gateway, e.g. forwarded with kubectl port-forward; the password is read from "+lokiPasswordEnvVar)
+	flags.StringVar(&opts.lokiUsername, "loki-username", "", "username for the loki gateway")
+	flags.BoolVar(&opts.timeline, "timeline", true, "add the timeline of warnings, errors and state changes")
+	flags.DurationVar(&opts.deadline, "deadline", 2*time.Hour, "maximum duration of the collection")
+
+	return command{flags: flags, runWithoutArchive: func(ctx context.Context, stdout, stderr io.Writer) error {
+		return createArchive(ctx, opts, stdout, stderr)
+	}}
+}
+
+// toSupportArchive creates the support archive resource which is only used locally and never applied to the cluster.
+func (o *createOptions) toSupportArchive(now time.Time) (*libapi.SupportArchive, error) {
+	cr := &libapi.SupportArchive{ObjectMeta: metav1.ObjectMeta{Namespace: o.namespace, Name: o.name}}
+	if cr.Name == "" {
+		cr.Name = fmt.Sprintf("support-archive-%s", now.UTC().Format("20060102-150405"))
+	}
+
+	for _, content := range strings.Split(o.exclude, ",") {
+		content = strings.TrimSpace(content)
+		switch content {
+		case "":
+		case "logs":
+			cr.Spec.ExcludedContents.Logs = true
+		case "volumeInfo":
+			cr.Spec.ExcludedContents.VolumeInfo = true
+		case "systemInfo":
+			cr.Spec.ExcludedContents.SystemInfo = true
+		case "sensitiveData":
+			cr.Spec.ExcludedContents.SensitiveData = true
+		case "events":
+			cr.Spec.ExcludedContents.Events = true
+		case "systemState":
+			cr.Spec.ExcludedContents.SystemState = true
+		default:
+			return nil, fmt.Errorf("invalid content %q to exclude, valid contents are %s", content, strings.Join(excludableContents, ", "))
+		}
+	}
+
+	since, err := parseOptionalTime("since", o.since)
+	if err != nil {
+		return nil, err
+	}
+	until, err := parseOptionalTime("until", o.until)
+	if err != nil {
+		return nil, err
+	}
+	cr.Spec.ContentTimeframe = libapi.ContentTimeframe{StartTime: metav1.NewTime(since), EndTime: metav1.NewTime(until)}
+
+	// Relative timeframes are resolved by the use case like for the resource in the cluster.
+	annotations := map[string]string{}
+	if o.last != "" {
+		annotations[domain.LastAnnotation] = o.last
+	}
+	if o.timeframes != "" {
+		annotations[domain.CollectorTimeframesAnnotation] = o.timeframes
+	}
+	if len(annotations) > 0 {
+		cr.Annotations = annotations
+	}
+
+	return cr, nil
+}
+
+func (o *createOptions) outputPath(name string) string {
+	if o.out != "" {
+		return o.out
+	}
+
+	return fmt.Sprintf("%s.zip", name)
+}
+
+func (o *createOptions) restConfig() (*rest.Config, error) {
+	loadingRules := clientcmd.NewDefaultClientConfigLoadingRules()
+	loadingRules.ExplicitPath = o.kubeconfig
+	overrides := &clientcmd.ConfigOverrides{CurrentContext: o.kubeContext}
+
+	restConfig, err := clientcmd.NewNonInteractiveDeferredLoadingClientConfig(loadingRules, overrides).ClientConfig()
+	if err != nil {
+		return nil, fmt.Errorf("failed to load kubeconfig: %w", err)
+	}
+
+	return restConfig, nil
+}
+
+// createArchive runs the collectors with the given kubeconfig and writes the archive to the output path.
+// The work directory is created next to the output path so that the archive can be moved without copying.
+func createArchive(ctx context.Context, opts *createOptions, stdout, stderr io.Writer) error {
+	logger := zap.New(zap.WriteTo(stderr))
+	ctrl.SetLogger(logger)
+	ctx = log.IntoContext(ctx, logger)
+
+	cr, err := opts.toSupportArchive(time.Now())
+	if err != nil {
+		return err
+	}
+	outputPath := opts.outputPath(cr.Name)
+
+	restConfig, err := opts.restConfig()
+	if err != nil {
+		return err
+	}
+	clientSet, err := kubernetes.NewForConfig(restConfig)
+	if err != nil {
+		return fmt.Errorf("failed to create kubernetes client set: %w", err)
+	}
+	genericClient, err := client.New(restConfig, client.Options{Scheme: clientgoscheme.Scheme})
+	if err != nil {
+		return fmt.Errorf("failed to create kubernetes client: %w", err)
+	}
+
+	operatorConfig := config.NewLocalConfig(opts.namespace)
+	operatorConfig.LogGatewayConfig = config.LogGatewayConfig{Url: opts.lokiURL, Username: opts.lokiUsername, Password: os.Getenv(lokiPasswordEnvVar)}
+	operatorConfig.SupportArchiveDeadline = opts.deadline
+	operatorConfig.TimelineEnabled = opts.timeline
+
+	workDir, err := os.MkdirTemp(filepath.Dir(outputPath), ".support-archive-")
+	if err != nil {
+		return fmt.Errorf("failed to create work directory: %w", err)
+	}
+	defer func() {
+		_ = os.RemoveAll(workDir)
+	}()
+
+	fs := filesystem.FileSystem{}
+	workPath := filepath.Join(workDir, "work")
+	clients := setup.Clients{Kubernetes: clientSet, Generic: genericClient}
+	mapping, err := setup.NewCollectorMapping(clients, operatorConfig, opts.prometheusURL, workPath, fs)
+	if err != nil {
+		return err
+	}
+	archiveRepository := file.NewZipFileArchiveRepository(filepath.Join(workDir, "archives"), file.NewZipWriter, fs, operatorConfig)
+	createUseCase := usecase.NewLocalCreateArchiveUseCase(mapping, archiveRepository, setup.NewPostProcessingRepositories(workPath, fs), usecase.CreateArchiveOptions{
+		ArchiveDeadline:         operatorConfig.SupportArchiveDeadline,
+		DefaultContentTimeframe: operatorConfig.DefaultContentTimeframe,
+		TimelineEnabled:         operatorConfig.TimelineEnabled,
+	})
+
+	skippedCollectors, err := createUseCase.CreateLocalArchive(ctx, cr)
+	if err != nil {
+		return err
+	}
+
+	id := domain.SupportArchiveID{Namespace: cr.Namespace, Name: cr.Name}
+	err = os.Rename(archiveRepository.GetArchivePath(id), outputPath)
+	if err != nil {
+		return fmt.Errorf("failed to move archive to %s: %w", outputPath, err)
+	}
+
+	printCreatedArchive(stdout, outputPath, skippedCollectors)
+	return nil
+}
+
+func printCreatedArchive(stdout io.Writer, outputPath string, skippedCollectors map[domain.CollectorType]string) {
+	_, _ = fmt.Fprintf(stdout, "created archive %s\n", outputPath)
+
+	collectors := make([]domain.CollectorType, 0, len(skippedCollectors))
+	for col := range skippedCollectors {
+		collectors = append(collectors, col)
+	}
+	slices.Sort(collectors)
+	for _, col := range collectors {
+		_, _ = fmt.Fprintf(stdout, "skipped %s: %s\n", col, skippedCollectors[col])
+	}
+}
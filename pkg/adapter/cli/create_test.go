@@ -0,0 +1,96 @@
+package cli
+
+import (
+	"bytes"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/cloudogu/k8s-support-archive-operator/pkg/domain"
+)
+
+func TestCreateOptions_toSupportArchive(t *testing.T) {
+	now := time.Date(2025, 1, 2, 3, 4, 5, 0, time.UTC)
+
+	t.Run("should use defaults", func(t *testing.T) {
+		// given
+		sut := &createOptions{namespace: "ecosystem"}
+
+		// when
+		cr, err := sut.toSupportArchive(now)
+
+		// then
+		require.NoError(t, err)
+		assert.Equal(t, "ecosystem", cr.Namespace)
+		assert.Equal(t, "support-archive-20250102-030405", cr.Name)
+		assert.False(t, cr.Spec.ExcludedContents.Logs)
+		assert.True(t, cr.Spec.ContentTimeframe.StartTime.IsZero())
+		assert.True(t, cr.Spec.ContentTimeframe.EndTime.IsZero())
+		assert.Empty(t, cr.Annotations)
+		assert.Equal(t, "support-archive-20250102-030405.zip", sut.outputPath(cr.Name))
+	})
+	t.Run("should set excluded contents and timeframe", func(t *testing.T) {
+		// given
+		sut := &createOptions{
+			namespace: "ecosystem",
+			name:      "archive",
+			out:       "out.zip",
+			exclude:   "logs, volumeInfo,systemInfo,sensitiveData,events,systemState",
+			since:     "2025-01-01T00:00:00Z",
+			until:     "2025-01-02T00:00:00Z",
+		}
+
+		// when
+		cr, err := sut.toSupportArchive(now)
+
+		// then
+		require.NoError(t, err)
+		assert.Equal(t, "archive", cr.Name)
+		excluded := cr.Spec.ExcludedContents
+		assert.True(t, excluded.Logs && excluded.VolumeInfo && excluded.SystemInfo && excluded.SensitiveData && excluded.Events && excluded.SystemState)
+		assert.True(t, time.Date(2025, 1, 1, 0, 0, 0, 0, time.UTC).Equal(cr.Spec.ContentTimeframe.StartTime.Time))
+		assert.True(t, time.Date(2025, 1, 2, 0, 0, 0, 0, time.UTC).Equal(cr.Spec.ContentTimeframe.EndTime.Time))
+		assert.Equal(t, "out.zip", sut.outputPath(cr.Name))
+	})
+	t.Run("should set relative timeframes as annotations", func(t *testing.T) {
+		// given
+		sut := &createOptions{namespace: "ecosystem", last: "6h", timeframes: "logs=24h,metrics=7d"}
+
+		// when
+		cr, err := sut.toSupportArchive(now)
+
+		// then
+		require.NoError(t, err)
+		assert.Equal(t, map[string]string{
+			domain.LastAnnotation:                "6h",
+			domain.CollectorTimeframesAnnotation: "logs=24h,metrics=7d",
+		}, cr.Annotations)
+	})
+	t.Run("should return error on invalid content", func(t *testing.T) {
+		_, err := (&createOptions{exclude: "metrics"}).toSupportArchive(now)
+
+		require.Error(t, err)
+		assert.ErrorContains(t, err, `invalid content "metrics" to exclude`)
+	})
+	t.Run("should return error on invalid time", func(t *testing.T) {
+		_, err := (&createOptions{until: "tomorrow"}).toSupportArchive(now)
+
+		require.Error(t, err)
+		assert.ErrorContains(t, err, "invalid value for until")
+	})
+}
+
+func TestPrintCreatedArchive(t *testing.T) {
+	var stdout bytes.Buffer
+
+	printCreatedArchive(&stdout, "archive.zip", map[domain.CollectorType]string{
+		domain.CollectorTypeVolumeInfo: "Collector VolumeInfo failed: timeout",
+		domain.CollectorTypeLog:        "Collector Logs failed: timeout",
+	})
+
+	assert.Equal(t, "created archive archive.zip\n"+
+		"skipped Logs: Collector Logs failed: timeout\n"+
+		"skipped VolumeInfo: Collector VolumeInfo failed: timeout\n", stdout.String())
+}
@@ -0,0 +1,86 @@
+package cli
+
+import (
+	"context"
+	"encoding/json"
+	"flag"
+	"fmt"
+	"io"
+	"strconv"
+	"strings"
+	"text/tabwriter"
+
+	"github.com/cloudogu/k8s-support-archive-operator/pkg/adapter/archive/file"
+	"github.com/cloudogu/k8s-support-archive-operator/pkg/domain"
+	"github.com/cloudogu/k8s-support-archive-operator/pkg/usecase"
+)
+
+func newDiffCommand() command {
+	flags := flag.NewFlagSet("diff", flag.ContinueOnError)
+	printJSON := flags.Bool("json", false, "print the diff report as json")
+
+	return command{flags: flags, runWithTwoArchives: func(ctx context.Context, before, after *file.ZipArchiveReader, stdout io.Writer) error {
+		diff, err := diffArchives(ctx, flags.Arg(0), before, after)
+		if err != nil {
+			return err
+		}
+
+		if *printJSON {
+			encoder := json.NewEncoder(stdout)
+			encoder.SetIndent("", "  ")
+			return encoder.Encode(diff)
+		}
+
+		return printDiff(diff, stdout)
+	}}
+}
+
+func diffArchives(ctx context.Context, reference string, before, after *file.ZipArchiveReader) (*domain.ArchiveDiff, error) {
+	beforeContent, err := before.Content(ctx)
+	if err != nil {
+		return nil, err
+	}
+	afterContent, err := after.Content(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	return usecase.DiffArchives(reference, beforeContent, afterContent), nil
+}
+
+func printDiff(diff *domain.ArchiveDiff, stdout io.Writer) error {
+	if diff.IsEmpty() {
+		_, err := fmt.Fprintln(stdout, "no differences in system state, secrets, volumes and nodes")
+		return err
+	}
+
+	writer := tabwriter.NewWriter(stdout, 0, 0, 2, ' ', 0)
+	_, _ = fmt.Fprintln(writer, "CHANGE\tKIND\tOBJECT\tDETAILS")
+	for _, resource := range diff.Resources {
+		_, _ = fmt.Fprintf(writer, "%s\tresource\t%s\t%s\n", resource.Change, resource.Path, strings.Join(resource.Fields, ", "))
+	}
+	for _, secret := range diff.Secrets {
+		var keys []string
+		for _, key := range secret.AddedKeys {
+			keys = append(keys, "+"+key)
+		}
+		for _, key := range secret.RemovedKeys {
+			keys = append(keys, "-"+key)
+		}
+		_, _ = fmt.Fprintf(writer, "%s\tsecret\t%s\t%s\n", secret.Change, secret.Path, strings.Join(keys, " "))
+	}
+	for _, volume := range diff.Volumes {
+		_, _ = fmt.Fprintf(writer, "%s\tvolume\t%s\tused %s -> %s, capacity %s -> %s\n", volume.Change, volume.Name,
+			domain.FormatBytes(volume.UsedBefore), domain.FormatBytes(volume.UsedAfter),
+			domain.FormatBytes(volume.CapacityBefore), domain.FormatBytes(volume.CapacityAfter))
+	}
+	if diff.Nodes.Before != diff.Nodes.After {
+		_, _ = fmt.Fprintf(writer, "%s\tnodes\tcount\t%d -> %d\n", domain.DiffChangeChanged, diff.Nodes.Before, diff.Nodes.After)
+	}
+	for _, capacity := range diff.Capacity {
+		_, _ = fmt.Fprintf(writer, "%s\tnode\t%s\t%s %s -> %s\n", capacity.Change, capacity.Node, capacity.Metric,
+			strconv.FormatFloat(capacity.Before, 'f', -1, 64), strconv.FormatFloat(capacity.After, 'f', -1, 64))
+	}
+
+	return writer.Flush()
+}
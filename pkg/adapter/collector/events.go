@@ -1,29 +1,72 @@
 package collector
 
 import (
+	"cmp"
 	"context"
+	"encoding/json"
 	"fmt"
+	"slices"
+	"strconv"
+	"strings"
 	"time"
 
 	"github.com/cloudogu/k8s-support-archive-operator/pkg/domain"
+	eventsv1 "k8s.io/api/events/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"sigs.k8s.io/controller-runtime/pkg/log"
 )
 
+// EventsCollector reads the events from the events.k8s.io/v1 API.
+// Because the API only retains events for a short time, older events are added from the event history in Loki.
+// Events are deduplicated so that every event is exported once with its count and its first and last timestamps.
 type EventsCollector struct {
-	logsProvider LogsProvider
+	eventsV1Interface eventsV1Interface
+	logsProvider      LogsProvider
 }
 
-func NewEventsCollector(logsProvider LogsProvider) *EventsCollector {
+func NewEventsCollector(eventsV1Interface eventsV1Interface, logsProvider LogsProvider) *EventsCollector {
 	return &EventsCollector{
-		logsProvider: logsProvider,
+		eventsV1Interface: eventsV1Interface,
+		logsProvider:      logsProvider,
 	}
 }
 
-func (ec *EventsCollector) Collect(ctx context.Context, namespace string, startTime, endTime time.Time, resultChan chan<- *domain.LogLine) error {
+func (ec *EventsCollector) Collect(ctx context.Context, namespace string, startTime, endTime time.Time, resultChan chan<- *domain.Event) error {
 	defer close(resultChan)
 
-	err := ec.logsProvider.FindEvents(ctx, startTime, endTime, namespace, resultChan)
+	logger := log.FromContext(ctx).WithName("EventsCollector.Collect")
+	list, err := ec.eventsV1Interface.Events(namespace).List(ctx, metav1.ListOptions{})
 	if err != nil {
-		return fmt.Errorf("error finding events: %w", err)
+		return fmt.Errorf("error listing events: %w", err)
+	}
+
+	events := make(map[string]*domain.Event)
+	for _, apiEvent := range list.Items {
+		mergeEvent(events, toDomainEvent(apiEvent))
+	}
+
+	lokiEvents, err := ec.findLokiEvents(ctx, namespace, startTime, endTime)
+	if err != nil {
+		// The Loki event history is optional, e.g., if the event scraper is not deployed.
+		logger.Error(err, "could not find event history in loki, only events from the kubernetes api are collected")
+	}
+	for _, lokiEvent := range lokiEvents {
+		mergeEvent(events, lokiEvent)
+	}
+
+	result := make([]*domain.Event, 0, len(events))
+	for _, event := range events {
+		if event.LastTimestamp.Before(startTime) || event.FirstTimestamp.After(endTime) {
+			continue
+		}
+		result = append(result, event)
+	}
+	slices.SortFunc(result, func(a, b *domain.Event) int {
+		return cmp.Or(a.LastTimestamp.Compare(b.LastTimestamp), cmp.Compare(a.Key(), b.Key()))
+	})
+
+	for _, event := range result {
+		writeSaveToChannel(ctx, event, resultChan)
 	}
 
 	return nil
@@ -32,3 +75,194 @@ func (ec *EventsCollector) Collect(ctx context.Context, namespace string, startT
 func (ec *EventsCollector) Name() string {
 	return string(domain.CollectorTypeEvents)
 }
+
+// findLokiEvents reads the event history from loki and summarizes all log lines of the same event.
+// Loki contains a log line for every update of an event which contains the count of the event so far.
+func (ec *EventsCollector) findLokiEvents(ctx context.Context, namespace string, startTime, endTime time.Time) ([]*domain.Event, error) {
+	lineChan := make(chan *domain.LogLine)
+	errChan := make(chan error, 1)
+	go func() {
+		defer close(lineChan)
+		errChan <- ec.logsProvider.FindEvents(ctx, startTime, endTime, namespace, lineChan)
+	}()
+
+	logger := log.FromContext(ctx).WithName("EventsCollector.findLokiEvents")
+	events := make(map[string]*domain.Event)
+	for line := range lineChan {
+		event, err := lokiLineToEvent(line, namespace)
+		if err != nil {
+			logger.Error(err, "skipping event from loki")
+			continue
+		}
+		mergeEvent(events, event)
+	}
+
+	result := make([]*domain.Event, 0, len(events))
+	for _, event := range events {
+		result = append(result, event)
+	}
+
+	return result, <-errChan
+}
+
+func toDomainEvent(event eventsv1.Event) *domain.Event {
+	firstTimestamp := event.DeprecatedFirstTimestamp.Time
+	if firstTimestamp.IsZero() {
+		firstTimestamp = event.EventTime.Time
+	}
+	if firstTimestamp.IsZero() {
+		firstTimestamp = event.CreationTimestamp.Time
+	}
+
+	lastTimestamp := event.DeprecatedLastTimestamp.Time
+	count := int(event.DeprecatedCount)
+	if event.Series != nil {
+		lastTimestamp = event.Series.LastObservedTime.Time
+		count = int(event.Series.Count)
+	}
+	if lastTimestamp.IsZero() {
+		lastTimestamp = firstTimestamp
+	}
+
+	return &domain.Event{
+		Namespace: event.Namespace,
+		InvolvedObject: domain.EventObject{
+			Kind:      event.Regarding.Kind,
+			Namespace: event.Regarding.Namespace,
+			Name:      event.Regarding.Name,
+		},
+		Reason:              event.Reason,
+		Type:                event.Type,
+		Note:                event.Note,
+		ReportingController: event.ReportingController,
+		Count:               max(count, 1),
+		FirstTimestamp:      firstTimestamp,
+		LastTimestamp:       lastTimestamp,
+		Sources:             []string{domain.EventSourceKubernetesAPI},
+	}
+}
+
+// lokiLineToEvent parses an event log line which is either a json object or logfmt in the message field.
+func lokiLineToEvent(line *domain.LogLine, namespace string) (*domain.Event, error) {
+	var jsonFields map[string]any
+	err := json.Unmarshal([]byte(line.Value), &jsonFields)
+	if err != nil {
+		return nil, fmt.Errorf("failed to unmarshal event log line: %w", err)
+	}
+
+	fields := make(map[string]string, len(jsonFields))
+	for key, value := range jsonFields {
+		fields[key] = fmt.Sprint(value)
+	}
+	if message, ok := jsonFields["message"].(string); ok {
+		for key, value := range parseLogfmt(message) {
+			if _, exists := fields[key]; !exists {
+				fields[key] = value
+			}
+		}
+	}
+
+	count, err := strconv.Atoi(cmp.Or(fields["count"], "1"))
+	if err != nil {
+		count = 1
+	}
+
+	return &domain.Event{
+		Namespace: namespace,
+		InvolvedObject: domain.EventObject{
+			Kind:      fields["kind"],
+			Namespace: namespace,
+			Name:      fields["name"],
+		},
+		Reason:              fields["reason"],
+		Type:                fields["type"],
+		Note:                fields["msg"],
+		ReportingController: cmp.Or(fields["reportingcontroller"], fields["sourcecomponent"]),
+		Count:               max(count, 1),
+		FirstTimestamp:      line.Timestamp,
+		LastTimestamp:       line.Timestamp,
+		Sources:             []string{domain.EventSourceLoki},
+	}, nil
+}
+
+// mergeEvent adds the event or merges it into the existing occurrences of the same event.
+// The same event is reported by the API and by every line of the Loki history with its count so far. Loki does not
+// contain the UID of the event, so that copies cannot be told apart from recreated events with the same key.
+// Therefore, the highest count of all sources is kept, which counts every copy once.
+func mergeEvent(events map[string]*domain.Event, event *domain.Event) {
+	existing, ok := events[event.Key()]
+	if !ok {
+		events[event.Key()] = event
+		return
+	}
+
+	existing.Count = max(existing.Count, event.Count)
+	if event.FirstTimestamp.Before(existing.FirstTimestamp) {
+		existing.FirstTimestamp = event.FirstTimestamp
+	}
+	if event.LastTimestamp.After(existing.LastTimestamp) {
+		existing.LastTimestamp = event.LastTimestamp
+	}
+	for _, source := range event.Sources {
+		if !slices.Contains(existing.Sources, source) {
+			existing.Sources = append(existing.Sources, source)
+		}
+	}
+}
+
+// parseLogfmt parses key value pairs like `key=value key2="quoted value"`.
+func parseLogfmt(line string) map[string]string {
+	result := make(map[string]string)
+	for len(line) > 0 {
+		line = strings.TrimLeft(line, " ")
+		separator := strings.IndexAny(line, "= ")
+		if separator <= 0 || line[separator] != '=' {
+			// skip keys without values
+			next := strings.IndexByte(line, ' ')
+			if next < 0 {
+				break
+			}
+			line = line[next:]
+			continue
+		}
+
+		key := line[:separator]
+		line = line[separator+1:]
+
+		var value string
+		if strings.HasPrefix(line, `"`) {
+			end := findClosingQuote(line)
+			unquoted, err := strconv.Unquote(line[:end])
+			if err != nil {
+				unquoted = strings.Trim(line[:end], `"`)
+			}
+			value = unquoted
+			line = line[end:]
+		} else {
+			end := strings.IndexByte(line, ' ')
+			if end < 0 {
+				end = len(line)
+			}
+			value = line[:end]
+			line = line[end:]
+		}
+
+		result[key] = value
+	}
+
+	return result
+}
+
+// findClosingQuote returns the index after the closing quote of the quoted value at the start of the line.
+func findClosingQuote(line string) int {
+	for i := 1; i < len(line); i++ {
+		switch line[i] {
+		case '\\':
+			i++
+		case '"':
+			return i + 1
+		}
+	}
+
+	return len(line)
+}
@@ -1,103 +1,207 @@
 package collector
 
 import (
-	"sync"
+	"context"
+	"fmt"
 	"testing"
 	"time"
 
 	"github.com/cloudogu/k8s-support-archive-operator/pkg/domain"
-	"github.com/stretchr/testify/mock"
-
 	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
 	"github.com/stretchr/testify/require"
+	v1 "k8s.io/api/core/v1"
+	eventsv1 "k8s.io/api/events/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 )
 
-func TestCollect(t *testing.T) {
-	t.Run("should call log provider to find events and close result channel", func(t *testing.T) {
+func TestNewEventsCollector(t *testing.T) {
+	// given
+	eventsV1Mock := newMockEventsV1Interface(t)
+	logPrvMock := NewMockLogsProvider(t)
+
+	// when
+	sut := NewEventsCollector(eventsV1Mock, logPrvMock)
+
+	// then
+	require.NotNil(t, sut)
+	assert.Equal(t, eventsV1Mock, sut.eventsV1Interface)
+	assert.Equal(t, logPrvMock, sut.logsProvider)
+}
+
+func TestEventsCollector_Collect(t *testing.T) {
+	startTime := time.Date(2025, 9, 1, 0, 0, 0, 0, time.UTC)
+	endTime := startTime.Add(24 * time.Hour)
+	regarding := v1.ObjectReference{Kind: "Pod", Namespace: testNamespace, Name: "ldap-0"}
+
+	t.Run("should return error on error listing events", func(t *testing.T) {
 		// given
-		startTime := time.Now()
-		endTime := startTime.AddDate(0, 0, 10)
-		resultChannel := make(chan *domain.LogLine)
+		eventMock := newMockEventInterface(t)
+		eventMock.EXPECT().List(testCtx, metav1.ListOptions{}).Return(nil, assert.AnError)
+		eventsV1Mock := newMockEventsV1Interface(t)
+		eventsV1Mock.EXPECT().Events(testNamespace).Return(eventMock)
+		resultChan := make(chan *domain.Event)
+		sut := NewEventsCollector(eventsV1Mock, NewMockLogsProvider(t))
 
-		logPrvMock := NewMockLogsProvider(t)
-		logPrvMock.EXPECT().FindEvents(testCtx, startTime, endTime, testNamespace, mock.Anything).Return(nil)
-
-		group := sync.WaitGroup{}
-		group.Add(1)
-		timeout := time.NewTimer(time.Minute)
-		failed := false
-		go func() {
-			for {
-				select {
-				case _, closed := <-resultChannel:
-					if !closed {
-						group.Done()
-						return
-					}
-				case <-timeout.C:
-					failed = true
-					group.Done()
-					return
-				}
-			}
-		}()
+		// when
+		err := sut.Collect(testCtx, testNamespace, startTime, endTime, resultChan)
 
-		sut := NewEventsCollector(logPrvMock)
+		// then
+		require.Error(t, err)
+		assert.ErrorIs(t, err, assert.AnError)
+		assert.ErrorContains(t, err, "error listing events")
+		_, open := <-resultChan
+		assert.False(t, open)
+	})
+
+	t.Run("should deduplicate api events and merge loki history", func(t *testing.T) {
+		// given
+		apiEvents := &eventsv1.EventList{Items: []eventsv1.Event{
+			{
+				ObjectMeta:               metav1.ObjectMeta{Namespace: testNamespace, Name: "ldap-0.1"},
+				Regarding:                regarding,
+				Reason:                   "BackOff",
+				Type:                     "Warning",
+				Note:                     "Back-off restarting failed container",
+				ReportingController:      "kubelet",
+				DeprecatedFirstTimestamp: metav1.NewTime(startTime.Add(2 * time.Hour)),
+				Series:                   &eventsv1.EventSeries{Count: 5, LastObservedTime: metav1.NewMicroTime(startTime.Add(3 * time.Hour))},
+			},
+			{
+				ObjectMeta: metav1.ObjectMeta{Namespace: testNamespace, Name: "ldap-0.2"},
+				Regarding:  regarding,
+				Reason:     "BackOff",
+				Type:       "Warning",
+				Note:       "Back-off restarting failed container",
+				EventTime:  metav1.NewMicroTime(startTime.Add(4 * time.Hour)),
+			},
+			{
+				ObjectMeta: metav1.ObjectMeta{Namespace: testNamespace, Name: "too-old"},
+				Regarding:  regarding,
+				Reason:     "Scheduled",
+				Type:       "Normal",
+				EventTime:  metav1.NewMicroTime(startTime.Add(-time.Hour)),
+			},
+		}}
+		eventMock := newMockEventInterface(t)
+		eventMock.EXPECT().List(testCtx, metav1.ListOptions{}).Return(apiEvents, nil)
+		eventsV1Mock := newMockEventsV1Interface(t)
+		eventsV1Mock.EXPECT().Events(testNamespace).Return(eventMock)
+
+		logPrvMock := NewMockLogsProvider(t)
+		logPrvMock.EXPECT().FindEvents(testCtx, startTime, endTime, testNamespace, mock.Anything).RunAndReturn(func(_ context.Context, _, _ time.Time, _ string, lines chan<- *domain.LogLine) error {
+			lines <- &domain.LogLine{Timestamp: startTime.Add(time.Hour), Value: `{"message":"name=ldap-0 kind=Pod reason=BackOff type=Warning count=2 msg=\"Back-off restarting failed container\""}`}
+			lines <- &domain.LogLine{Timestamp: startTime.Add(90 * time.Minute), Value: `{"name":"ldap-0","kind":"Pod","reason":"Killing","type":"Normal","msg":"Stopping container"}`}
+			lines <- &domain.LogLine{Timestamp: startTime, Value: `not json`}
+			return nil
+		})
+		resultChan := make(chan *domain.Event, 3)
+		sut := NewEventsCollector(eventsV1Mock, logPrvMock)
 
 		// when
-		err := sut.Collect(testCtx, testNamespace, startTime, endTime, resultChannel)
+		err := sut.Collect(testCtx, testNamespace, startTime, endTime, resultChan)
 
 		// then
-		group.Wait()
-		if failed {
-			t.Fatal("failed waiting for channel closing")
-		}
 		require.NoError(t, err)
+		var events []*domain.Event
+		for event := range resultChan {
+			events = append(events, event)
+		}
+		require.Len(t, events, 2)
+		assert.Equal(t, &domain.Event{
+			Namespace:      testNamespace,
+			InvolvedObject: domain.EventObject{Kind: "Pod", Namespace: testNamespace, Name: "ldap-0"},
+			Reason:         "Killing",
+			Type:           "Normal",
+			Note:           "Stopping container",
+			Count:          1,
+			FirstTimestamp: startTime.Add(90 * time.Minute),
+			LastTimestamp:  startTime.Add(90 * time.Minute),
+			Sources:        []string{domain.EventSourceLoki},
+		}, events[0])
+		assert.Equal(t, &domain.Event{
+			Namespace:           testNamespace,
+			InvolvedObject:      domain.EventObject{Kind: "Pod", Namespace: testNamespace, Name: "ldap-0"},
+			Reason:              "BackOff",
+			Type:                "Warning",
+			Note:                "Back-off restarting failed container",
+			ReportingController: "kubelet",
+			Count:               5,
+			FirstTimestamp:      startTime.Add(time.Hour),
+			LastTimestamp:       startTime.Add(4 * time.Hour),
+			Sources:             []string{domain.EventSourceKubernetesAPI, domain.EventSourceLoki},
+		}, events[1])
 	})
 
-	t.Run("should issue an error if log provider returns one", func(t *testing.T) {
+	t.Run("should count the same event from api and loki once", func(t *testing.T) {
 		// given
-		startTime := time.Now()
-		endTime := startTime.AddDate(0, 0, 10)
-		resultChannel := make(chan *domain.LogLine)
+		apiEvents := &eventsv1.EventList{Items: []eventsv1.Event{
+			{
+				ObjectMeta:               metav1.ObjectMeta{Namespace: testNamespace, Name: "ldap-0.1"},
+				Regarding:                regarding,
+				Reason:                   "BackOff",
+				Type:                     "Warning",
+				Note:                     "Back-off restarting failed container",
+				DeprecatedFirstTimestamp: metav1.NewTime(startTime.Add(time.Hour)),
+				Series:                   &eventsv1.EventSeries{Count: 3, LastObservedTime: metav1.NewMicroTime(startTime.Add(3 * time.Hour))},
+			},
+		}}
+		eventMock := newMockEventInterface(t)
+		eventMock.EXPECT().List(testCtx, metav1.ListOptions{}).Return(apiEvents, nil)
+		eventsV1Mock := newMockEventsV1Interface(t)
+		eventsV1Mock.EXPECT().Events(testNamespace).Return(eventMock)
 
 		logPrvMock := NewMockLogsProvider(t)
-		logPrvMock.EXPECT().FindEvents(testCtx, startTime, endTime, testNamespace, mock.Anything).Return(assert.AnError)
-
-		group := sync.WaitGroup{}
-		group.Add(1)
-		timeout := time.NewTimer(time.Minute)
-		failed := false
-		go func() {
-			for {
-				select {
-				case _, closed := <-resultChannel:
-					if !closed {
-						group.Done()
-						return
-					}
-				case <-timeout.C:
-					failed = true
-					group.Done()
-					return
-				}
+		logPrvMock.EXPECT().FindEvents(testCtx, startTime, endTime, testNamespace, mock.Anything).RunAndReturn(func(_ context.Context, _, _ time.Time, _ string, lines chan<- *domain.LogLine) error {
+			// every update of the event is in the history with its count so far
+			for i := 1; i <= 3; i++ {
+				lines <- &domain.LogLine{Timestamp: startTime.Add(time.Duration(i) * time.Hour), Value: fmt.Sprintf(`{"name":"ldap-0","kind":"Pod","reason":"BackOff","type":"Warning","msg":"Back-off restarting failed container","count":"%d"}`, i)}
 			}
-		}()
-
-		eventsCol := NewEventsCollector(logPrvMock)
+			return nil
+		})
+		resultChan := make(chan *domain.Event, 1)
+		sut := NewEventsCollector(eventsV1Mock, logPrvMock)
 
 		// when
-		err := eventsCol.Collect(testCtx, testNamespace, startTime, endTime, resultChannel)
+		err := sut.Collect(testCtx, testNamespace, startTime, endTime, resultChan)
 
 		// then
-		group.Wait()
-		if failed {
-			t.Fatal("failed waiting for channel closing")
+		require.NoError(t, err)
+		var events []*domain.Event
+		for event := range resultChan {
+			events = append(events, event)
 		}
+		require.Len(t, events, 1)
+		assert.Equal(t, 3, events[0].Count)
+		assert.Equal(t, startTime.Add(time.Hour), events[0].FirstTimestamp)
+		assert.Equal(t, startTime.Add(3*time.Hour), events[0].LastTimestamp)
+		assert.Equal(t, []string{domain.EventSourceKubernetesAPI, domain.EventSourceLoki}, events[0].Sources)
+	})
 
-		assert.Error(t, err)
-		assert.ErrorContains(t, err, "error finding events")
-		assert.ErrorIs(t, err, assert.AnError)
+	t.Run("should collect api events if loki is not available", func(t *testing.T) {
+		// given
+		apiEvents := &eventsv1.EventList{Items: []eventsv1.Event{
+			{Regarding: regarding, Reason: "Pulled", Type: "Normal", EventTime: metav1.NewMicroTime(startTime.Add(time.Hour))},
+		}}
+		eventMock := newMockEventInterface(t)
+		eventMock.EXPECT().List(testCtx, metav1.ListOptions{}).Return(apiEvents, nil)
+		eventsV1Mock := newMockEventsV1Interface(t)
+		eventsV1Mock.EXPECT().Events(testNamespace).Return(eventMock)
+		logPrvMock := NewMockLogsProvider(t)
+		logPrvMock.EXPECT().FindEvents(testCtx, startTime, endTime, testNamespace, mock.Anything).Return(assert.AnError)
+		resultChan := make(chan *domain.Event, 1)
+		sut := NewEventsCollector(eventsV1Mock, logPrvMock)
+
+		// when
+		err := sut.Collect(testCtx, testNamespace, startTime, endTime, resultChan)
+
+		// then
+		require.NoError(t, err)
+		event := <-resultChan
+		assert.Equal(t, "Pulled", event.Reason)
+		assert.Equal(t, 1, event.Count)
+		_, open := <-resultChan
+		assert.False(t, open)
 	})
 }
 
@@ -108,3 +212,14 @@ func TestEventsCollector_Name(t *testing.T) {
 		assert.Equal(t, "Events", sut.Name())
 	})
 }
+
+func Test_parseLogfmt(t *testing.T) {
+	result := parseLogfmt(`name=ldap-0 action= flag msg="quoted \"value\" with spaces" count=3`)
+
+	assert.Equal(t, map[string]string{
+		"name":   "ldap-0",
+		"action": "",
+		"msg":    `quoted "value" with spaces`,
+		"count":  "3",
+	}, result)
+}
@@ -0,0 +1,258 @@
+package collector
+
+import (
+	"bytes"
+	"cmp"
+	"compress/gzip"
+	"context"
+	"encoding/base64"
+	"encoding/json"
+	"fmt"
+	"io"
+	"slices"
+	"time"
+
+	"github.com/cloudogu/k8s-support-archive-operator/pkg/domain"
+	v1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"sigs.k8s.io/controller-runtime/pkg/log"
+)
+
+const (
+	helmReleaseLabelSelector = "owner=helm"
+	helmReleaseSecretType    = "helm.sh/release.v1"
+	helmReleaseDataKey       = "release"
+)
+
+var gzipMagicHeader = []byte{0x1f, 0x8b, 0x08}
+
+// helmReleaseRecord contains the fields of a release record of the helm secret storage driver used by the collector.
+type helmReleaseRecord struct {
+	Name      string `json:"name"`
+	Namespace string `json:"namespace"`
+	Version   int    `json:"version"`
+	Info      struct {
+		Status       string `json:"status"`
+		Description  string `json:"description"`
+		LastDeployed string `json:"last_deployed"`
+	} `json:"info"`
+	Chart struct {
+		Metadata struct {
+			Name       string `json:"name"`
+			Version    string `json:"version"`
+			AppVersion string `json:"appVersion"`
+		} `json:"metadata"`
+		Values map[string]any `json:"values"`
+	} `json:"chart"`
+	Config map[string]any `json:"config"`
+}
+
+// HelmReleaseCollector decodes the release records helm stores as secrets in the namespace.
+type HelmReleaseCollector struct {
+	coreV1Interface coreV1Interface
+	// excludeValues drops the values of the releases because they can contain sensitive data even if censored.
+	excludeValues bool
+}
+
+func NewHelmReleaseCollector(coreV1Interface coreV1Interface) *HelmReleaseCollector {
+	return &HelmReleaseCollector{coreV1Interface: coreV1Interface}
+}
+
+// WithoutSensitiveData returns a collector which collects the releases without their values.
+func (hc *HelmReleaseCollector) WithoutSensitiveData() any {
+	return &HelmReleaseCollector{coreV1Interface: hc.coreV1Interface, excludeValues: true}
+}
+
+func (hc *HelmReleaseCollector) Name() string {
+	return string(domain.CollectorTypeHelmRelease)
+}
+
+func (hc *HelmReleaseCollector) Collect(ctx context.Context, namespace string, _, _ time.Time, resultChan chan<- *domain.HelmRelease) error {
+	defer close(resultChan)
+
+	releases, err := hc.listReleases(ctx, namespace)
+	if err != nil {
+		return err
+	}
+
+	for _, release := range releases {
+		writeSaveToChannel(ctx, release, resultChan)
+	}
+
+	return nil
+}
+
+// Estimate decodes the helm releases like in Collect and measures their size.
+func (hc *HelmReleaseCollector) Estimate(ctx context.Context, namespace string, _, _ time.Time) (domain.CollectorEstimate, error) {
+	releases, err := hc.listReleases(ctx, namespace)
+	if err != nil {
+		return domain.CollectorEstimate{}, err
+	}
+
+	estimate := domain.CollectorEstimate{Items: int64(len(releases)), Unit: "helm releases", Requests: 1}
+	for _, release := range releases {
+		estimate.Bytes += yamlSize(release)
+	}
+
+	return estimate, nil
+}
+
+// listReleases decodes the release records of all helm releases in the namespace.
+func (hc *HelmReleaseCollector) listReleases(ctx context.Context, namespace string) ([]*domain.HelmRelease, error) {
+	logger := log.FromContext(ctx).WithName("HelmReleaseCollector.listReleases")
+	list, err := hc.coreV1Interface.Secrets(namespace).List(ctx, metav1.ListOptions{LabelSelector: helmReleaseLabelSelector})
+	if err != nil {
+		return nil, fmt.Errorf("error listing helm release secrets: %w", err)
+	}
+
+	recordsByRelease := make(map[string][]helmReleaseRecord)
+	for _, secret := range list.Items {
+		if secret.Type != helmReleaseSecretType {
+			continue
+		}
+
+		record, decodeErr := decodeHelmReleaseSecret(secret)
+		if decodeErr != nil {
+			// A single broken release record should not prevent collecting the other releases.
+			logger.Error(decodeErr, fmt.Sprintf("skipping helm release secret %q", secret.Name))
+			continue
+		}
+		recordsByRelease[record.Name] = append(recordsByRelease[record.Name], record)
+	}
+
+	if len(recordsByRelease) == 0 {
+		logger.Info("Helm release list is empty")
+		return nil, nil
+	}
+
+	releases := make([]*domain.HelmRelease, 0, len(recordsByRelease))
+	for _, records := range recordsByRelease {
+		release := toHelmRelease(records)
+		if hc.excludeValues {
+			release.Values = nil
+		}
+		releases = append(releases, release)
+	}
+	// The records are grouped in a map, so the releases are sorted to keep the archive content stable.
+	slices.SortFunc(releases, func(a, b *domain.HelmRelease) int {
+		return cmp.Compare(a.Name, b.Name)
+	})
+
+	return releases, nil
+}
+
+// decodeHelmReleaseSecret decodes the release record which is base64 encoded and usually gzipped json.
+func decodeHelmReleaseSecret(secret v1.Secret) (helmReleaseRecord, error) {
+	var record helmReleaseRecord
+	decoded, err := base64.StdEncoding.DecodeString(string(secret.Data[helmReleaseDataKey]))
+	if err != nil {
+		return record, fmt.Errorf("failed to decode base64 of release in secret %s: %w", secret.Name, err)
+	}
+
+	if bytes.HasPrefix(decoded, gzipMagicHeader) {
+		reader, gzipErr := gzip.NewReader(bytes.NewReader(decoded))
+		if gzipErr != nil {
+			return record, fmt.Errorf("failed to create gzip reader for release in secret %s: %w", secret.Name, gzipErr)
+		}
+		defer func() {
+			_ = reader.Close()
+		}()
+
+		decoded, err = io.ReadAll(reader)
+		if err != nil {
+			return record, fmt.Errorf("failed to decompress release in secret %s: %w", secret.Name, err)
+		}
+	}
+
+	err = json.Unmarshal(decoded, &record)
+	if err != nil {
+		return record, fmt.Errorf("failed to unmarshal release in secret %s: %w", secret.Name, err)
+	}
+
+	return record, nil
+}
+
+func toHelmRelease(records []helmReleaseRecord) *domain.HelmRelease {
+	slices.SortFunc(records, func(a, b helmReleaseRecord) int {
+		return cmp.Compare(a.Version, b.Version)
+	})
+	latest := records[len(records)-1]
+
+	values := censorHelmValues(mergeHelmValues(latest.Chart.Values, latest.Config))
+
+	history := make([]domain.HelmReleaseRevision, 0, len(records))
+	for _, record := range records {
+		history = append(history, domain.HelmReleaseRevision{
+			Revision:     record.Version,
+			Status:       record.Info.Status,
+			ChartVersion: record.Chart.Metadata.Version,
+			AppVersion:   record.Chart.Metadata.AppVersion,
+			Updated:      record.Info.LastDeployed,
+			Description:  record.Info.Description,
+		})
+	}
+
+	return &domain.HelmRelease{
+		Name:         latest.Name,
+		Namespace:    latest.Namespace,
+		Chart:        latest.Chart.Metadata.Name,
+		ChartVersion: latest.Chart.Metadata.Version,
+		AppVersion:   latest.Chart.Metadata.AppVersion,
+		Status:       latest.Info.Status,
+		Revision:     latest.Version,
+		Updated:      latest.Info.LastDeployed,
+		Description:  latest.Info.Description,
+		History:      history,
+		Values:       values,
+	}
+}
+
+// mergeHelmValues computes the values like helm does by overriding the chart values with the user supplied values.
+func mergeHelmValues(chartValues, userValues map[string]any) map[string]any {
+	result := make(map[string]any, len(chartValues))
+	for key, value := range chartValues {
+		result[key] = value
+	}
+
+	for key, value := range userValues {
+		userMap, isUserMap := value.(map[string]any)
+		chartMap, isChartMap := result[key].(map[string]any)
+		if isUserMap && isChartMap {
+			result[key] = mergeHelmValues(chartMap, userMap)
+			continue
+		}
+		result[key] = value
+	}
+
+	return result
+}
+
+// censorHelmValues censors all values like the SecretCollector censors the config of sensitive secrets. Only the keys
+// remain because values of any key, e.g. urls or connection strings, can contain credentials.
+func censorHelmValues(values map[string]any) map[string]any {
+	if len(values) == 0 {
+		return nil
+	}
+
+	censored, _ := censorValue(values).(map[string]any)
+	return censored
+}
+
+func censorValue(value any) any {
+	switch typed := value.(type) {
+	case map[string]any:
+		censored := make(map[string]any, len(typed))
+		for key, nested := range typed {
+			censored[key] = censorValue(nested)
+		}
+		return censored
+	case []any:
+		censored := make([]any, 0, len(typed))
+		for _, nested := range typed {
+			censored = append(censored, censorValue(nested))
+		}
+		return censored
+	default:
+		return censoredValue
+	}
+}
@@ -0,0 +1,272 @@
+package collector
+
+import (
+	"bytes"
+	"compress/gzip"
+	"encoding/base64"
+	"encoding/json"
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/cloudogu/k8s-support-archive-operator/pkg/domain"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	v1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+func TestNewHelmReleaseCollector(t *testing.T) {
+	// given
+	coreV1Mock := newMockCoreV1Interface(t)
+
+	// when
+	sut := NewHelmReleaseCollector(coreV1Mock)
+
+	// then
+	require.NotNil(t, sut)
+	assert.Equal(t, coreV1Mock, sut.coreV1Interface)
+}
+
+func TestHelmReleaseCollector_Name(t *testing.T) {
+	// given
+	sut := &HelmReleaseCollector{}
+
+	// when
+	name := sut.Name()
+
+	// then
+	assert.Equal(t, "Resources/HelmReleases", name)
+}
+
+func TestHelmReleaseCollector_Collect(t *testing.T) {
+	helmListOptions := metav1.ListOptions{LabelSelector: "owner=helm"}
+
+	t.Run("should return error on error listing secrets", func(t *testing.T) {
+		// given
+		secretMock := newMockSecretInterface(t)
+		secretMock.EXPECT().List(testCtx, helmListOptions).Return(nil, assert.AnError)
+		coreV1Mock := newMockCoreV1Interface(t)
+		coreV1Mock.EXPECT().Secrets(testNamespace).Return(secretMock)
+		resultChan := make(chan *domain.HelmRelease)
+		sut := &HelmReleaseCollector{coreV1Interface: coreV1Mock}
+
+		// when
+		err := sut.Collect(testCtx, testNamespace, time.Time{}, time.Time{}, resultChan)
+
+		// then
+		require.Error(t, err)
+		assert.ErrorIs(t, err, assert.AnError)
+		assert.ErrorContains(t, err, "error listing helm release secrets")
+		_, open := <-resultChan
+		assert.False(t, open)
+	})
+
+	t.Run("should skip broken and foreign secrets", func(t *testing.T) {
+		// given
+		secrets := &v1.SecretList{Items: []v1.Secret{
+			{ObjectMeta: metav1.ObjectMeta{Name: "other"}, Type: v1.SecretTypeOpaque},
+			{ObjectMeta: metav1.ObjectMeta{Name: "broken"}, Type: helmReleaseSecretType, Data: map[string][]byte{"release": []byte("not base64!")}},
+		}}
+		secretMock := newMockSecretInterface(t)
+		secretMock.EXPECT().List(testCtx, helmListOptions).Return(secrets, nil)
+		coreV1Mock := newMockCoreV1Interface(t)
+		coreV1Mock.EXPECT().Secrets(testNamespace).Return(secretMock)
+		resultChan := make(chan *domain.HelmRelease)
+		sut := &HelmReleaseCollector{coreV1Interface: coreV1Mock}
+
+		// when
+		err := sut.Collect(testCtx, testNamespace, time.Time{}, time.Time{}, resultChan)
+
+		// then
+		require.NoError(t, err)
+		_, open := <-resultChan
+		assert.False(t, open)
+	})
+
+	t.Run("should write decoded and censored release with history to channel", func(t *testing.T) {
+		// given
+		secrets := &v1.SecretList{Items: []v1.Secret{
+			createHelmReleaseSecret(t, 2, "deployed", "1.1.0", true, map[string]any{"replicas": 2, "db": map[string]any{"password": "top-secret", "url": "postgres://user:pw@postgres"}, "hosts": []any{"cas"}}),
+			createHelmReleaseSecret(t, 1, "superseded", "1.0.0", false, nil),
+		}}
+		secretMock := newMockSecretInterface(t)
+		secretMock.EXPECT().List(testCtx, helmListOptions).Return(secrets, nil)
+		coreV1Mock := newMockCoreV1Interface(t)
+		coreV1Mock.EXPECT().Secrets(testNamespace).Return(secretMock)
+		resultChan := make(chan *domain.HelmRelease, 1)
+		sut := &HelmReleaseCollector{coreV1Interface: coreV1Mock}
+
+		// when
+		err := sut.Collect(testCtx, testNamespace, time.Time{}, time.Time{}, resultChan)
+
+		// then
+		require.NoError(t, err)
+		release := <-resultChan
+		assert.Equal(t, &domain.HelmRelease{
+			Name:         "k8s-dogu-operator",
+			Namespace:    testNamespace,
+			Chart:        "k8s-dogu-operator",
+			ChartVersion: "1.1.0",
+			AppVersion:   "app-1.1.0",
+			Status:       "deployed",
+			Revision:     2,
+			Updated:      "2025-09-01T10:00:00Z",
+			Description:  "revision 2",
+			History: []domain.HelmReleaseRevision{
+				{Revision: 1, Status: "superseded", ChartVersion: "1.0.0", AppVersion: "app-1.0.0", Updated: "2025-09-01T10:00:00Z", Description: "revision 1"},
+				{Revision: 2, Status: "deployed", ChartVersion: "1.1.0", AppVersion: "app-1.1.0", Updated: "2025-09-01T10:00:00Z", Description: "revision 2"},
+			},
+			Values: map[string]any{
+				"replicas": "***",
+				"logLevel": "***",
+				"db":       map[string]any{"host": "***", "password": "***", "url": "***"},
+				"apiToken": "***",
+				"hosts":    []any{"***"},
+			},
+		}, release)
+		_, open := <-resultChan
+		assert.False(t, open)
+	})
+
+	t.Run("should write release without values if sensitive data is excluded", func(t *testing.T) {
+		// given
+		secrets := &v1.SecretList{Items: []v1.Secret{
+			createHelmReleaseSecret(t, 1, "deployed", "1.0.0", true, map[string]any{"db": map[string]any{"password": "top-secret"}}),
+		}}
+		secretMock := newMockSecretInterface(t)
+		secretMock.EXPECT().List(testCtx, helmListOptions).Return(secrets, nil)
+		coreV1Mock := newMockCoreV1Interface(t)
+		coreV1Mock.EXPECT().Secrets(testNamespace).Return(secretMock)
+		resultChan := make(chan *domain.HelmRelease, 1)
+		sut := NewHelmReleaseCollector(coreV1Mock).WithoutSensitiveData().(*HelmReleaseCollector)
+
+		// when
+		err := sut.Collect(testCtx, testNamespace, time.Time{}, time.Time{}, resultChan)
+
+		// then
+		require.NoError(t, err)
+		release := <-resultChan
+		assert.Equal(t, "k8s-dogu-operator", release.Name)
+		assert.Len(t, release.History, 1)
+		assert.Nil(t, release.Values)
+	})
+
+	t.Run("should write releases sorted by name", func(t *testing.T) {
+		// given
+		secrets := &v1.SecretList{Items: []v1.Secret{
+			createNamedHelmReleaseSecret(t, "k8s-velero", 1, "deployed", "1.0.0", false, nil),
+			createNamedHelmReleaseSecret(t, "k8s-dogu-operator", 1, "deployed", "1.0.0", false, nil),
+			createNamedHelmReleaseSecret(t, "k8s-loki", 1, "deployed", "1.0.0", false, nil),
+		}}
+		secretMock := newMockSecretInterface(t)
+		secretMock.EXPECT().List(testCtx, helmListOptions).Return(secrets, nil)
+		coreV1Mock := newMockCoreV1Interface(t)
+		coreV1Mock.EXPECT().Secrets(testNamespace).Return(secretMock)
+		resultChan := make(chan *domain.HelmRelease, 3)
+		sut := NewHelmReleaseCollector(coreV1Mock)
+
+		// when
+		err := sut.Collect(testCtx, testNamespace, time.Time{}, time.Time{}, resultChan)
+
+		// then
+		require.NoError(t, err)
+		var names []string
+		for release := range resultChan {
+			names = append(names, release.Name)
+		}
+		assert.Equal(t, []string{"k8s-dogu-operator", "k8s-loki", "k8s-velero"}, names)
+	})
+}
+
+func TestHelmReleaseCollector_Estimate(t *testing.T) {
+	helmListOptions := metav1.ListOptions{LabelSelector: "owner=helm"}
+
+	t.Run("should measure decoded releases", func(t *testing.T) {
+		// given
+		secrets := &v1.SecretList{Items: []v1.Secret{
+			createHelmReleaseSecret(t, 2, "deployed", "1.1.0", true, nil),
+			createHelmReleaseSecret(t, 1, "superseded", "1.0.0", false, nil),
+		}}
+		secretMock := newMockSecretInterface(t)
+		secretMock.EXPECT().List(testCtx, helmListOptions).Return(secrets, nil)
+		coreV1Mock := newMockCoreV1Interface(t)
+		coreV1Mock.EXPECT().Secrets(testNamespace).Return(secretMock)
+		sut := &HelmReleaseCollector{coreV1Interface: coreV1Mock}
+
+		// when
+		estimate, err := sut.Estimate(testCtx, testNamespace, time.Time{}, time.Time{})
+
+		// then
+		require.NoError(t, err)
+		assert.Equal(t, int64(1), estimate.Items)
+		assert.Equal(t, "helm releases", estimate.Unit)
+		assert.Equal(t, 1, estimate.Requests)
+		assert.Positive(t, estimate.Bytes)
+	})
+	t.Run("should fail to list secrets", func(t *testing.T) {
+		// given
+		secretMock := newMockSecretInterface(t)
+		secretMock.EXPECT().List(testCtx, helmListOptions).Return(nil, assert.AnError)
+		coreV1Mock := newMockCoreV1Interface(t)
+		coreV1Mock.EXPECT().Secrets(testNamespace).Return(secretMock)
+		sut := &HelmReleaseCollector{coreV1Interface: coreV1Mock}
+
+		// when
+		_, err := sut.Estimate(testCtx, testNamespace, time.Time{}, time.Time{})
+
+		// then
+		require.ErrorIs(t, err, assert.AnError)
+		assert.ErrorContains(t, err, "error listing helm release secrets")
+	})
+}
+
+func Test_mergeHelmValues(t *testing.T) {
+	chartValues := map[string]any{"a": 1, "nested": map[string]any{"b": 2, "c": 3}, "list": []any{1, 2}}
+	userValues := map[string]any{"nested": map[string]any{"c": 4}, "list": []any{3}}
+
+	result := mergeHelmValues(chartValues, userValues)
+
+	assert.Equal(t, map[string]any{"a": 1, "nested": map[string]any{"b": 2, "c": 4}, "list": []any{3}}, result)
+	// the chart values must not be modified
+	assert.Equal(t, map[string]any{"b": 2, "c": 3}, chartValues["nested"])
+}
+
+func createHelmReleaseSecret(t *testing.T, revision int, status, chartVersion string, compressed bool, userValues map[string]any) v1.Secret {
+	t.Helper()
+
+	return createNamedHelmReleaseSecret(t, "k8s-dogu-operator", revision, status, chartVersion, compressed, userValues)
+}
+
+func createNamedHelmReleaseSecret(t *testing.T, name string, revision int, status, chartVersion string, compressed bool, userValues map[string]any) v1.Secret {
+	t.Helper()
+
+	record := map[string]any{
+		"name":      name,
+		"namespace": testNamespace,
+		"version":   revision,
+		"info":      map[string]any{"status": status, "description": fmt.Sprintf("revision %d", revision), "last_deployed": "2025-09-01T10:00:00Z"},
+		"chart": map[string]any{
+			"metadata": map[string]any{"name": "k8s-dogu-operator", "version": chartVersion, "appVersion": "app-" + chartVersion},
+			"values":   map[string]any{"replicas": 1, "logLevel": "info", "db": map[string]any{"host": "postgres"}, "apiToken": "abc"},
+		},
+		"config": userValues,
+	}
+	encoded, err := json.Marshal(record)
+	require.NoError(t, err)
+
+	if compressed {
+		var buffer bytes.Buffer
+		writer := gzip.NewWriter(&buffer)
+		_, err = writer.Write(encoded)
+		require.NoError(t, err)
+		require.NoError(t, writer.Close())
+		encoded = buffer.Bytes()
+	}
+
+	return v1.Secret{
+		ObjectMeta: metav1.ObjectMeta{Name: fmt.Sprintf("sh.helm.release.v1.%s.v%d", name, revision), Namespace: testNamespace},
+		Type:       helmReleaseSecretType,
+		Data:       map[string][]byte{"release": []byte(base64.StdEncoding.EncodeToString(encoded))},
+	}
+}
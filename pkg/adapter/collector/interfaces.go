@@ -8,6 +8,7 @@ import (
 
 	"github.com/cloudogu/k8s-support-archive-operator/pkg/domain"
 	corev1 "k8s.io/client-go/kubernetes/typed/core/v1"
+	eventsv1 "k8s.io/client-go/kubernetes/typed/events/v1"
 )
 
 type coreV1Interface interface {
@@ -15,8 +16,9 @@ type coreV1Interface interface {
 }
 
 type metricsProvider interface {
-	GetCapacityBytesForPVC(ctx context.Context, namespace, pvcName string, ts time.Time) (int64, error)
-	GetUsedBytesForPVC(ctx context.Context, namespace, pvcName string, ts time.Time) (int64, error)
+	GetCapacityBytesForPVC(ctx context.Context, namespace, pvcName string, start, end time.Time, step time.Duration) ([]domain.LabeledSample, error)
+	GetUsedBytesForPVC(ctx context.Context, namespace, pvcName string, start, end time.Time, step time.Duration) ([]domain.LabeledSample, error)
+	GetUsedInodesForPVC(ctx context.Context, namespace, pvcName string, start, end time.Time, step time.Duration) ([]domain.LabeledSample, error)
 	GetNodeCount(ctx context.Context, start, end time.Time, steps time.Duration, resultChan chan<- *domain.LabeledSample) error
 	GetNodeNames(ctx context.Context, start, end time.Time, steps time.Duration, resultChan chan<- *domain.LabeledSample) error
 	GetNodeStorage(ctx context.Context, start, end time.Time, steps time.Duration, resultChan chan<- *domain.LabeledSample) error
@@ -38,15 +40,45 @@ type pvcInterface interface {
 	corev1.PersistentVolumeClaimInterface
 }
 
+//nolint:unused
+//goland:noinspection GoUnusedType
+type pvInterface interface {
+	corev1.PersistentVolumeInterface
+}
+
+//nolint:unused
+//goland:noinspection GoUnusedType
+type nodeInterface interface {
+	corev1.NodeInterface
+}
+
+//nolint:unused
+//goland:noinspection GoUnusedType
+type podInterface interface {
+	corev1.PodInterface
+}
+
 //nolint:unused
 //goland:noinspection GoUnusedType
 type secretInterface interface {
 	corev1.SecretInterface
 }
 
+type eventsV1Interface interface {
+	eventsv1.EventsV1Interface
+}
+
+//nolint:unused
+//goland:noinspection GoUnusedType
+type eventInterface interface {
+	eventsv1.EventInterface
+}
+
 type LogsProvider interface {
 	FindLogs(ctx context.Context, start, end time.Time, namespace string, resultChan chan<- *domain.LogLine) error
 	FindEvents(ctx context.Context, start, end time.Time, namespace string, resultChan chan<- *domain.LogLine) error
+	// EstimateLogs counts the log lines FindLogs would return without fetching them.
+	EstimateLogs(ctx context.Context, start, end time.Time, namespace string) (domain.CollectorEstimate, error)
 }
 
 type k8sClient interface {
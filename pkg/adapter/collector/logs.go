@@ -31,6 +31,16 @@ func (l *LogCollector) Collect(ctx context.Context, namespace string, startTime,
 	return nil
 }
 
+// Estimate counts the log lines of the timeframe without fetching them.
+func (l *LogCollector) Estimate(ctx context.Context, namespace string, startTime, endTime time.Time) (domain.CollectorEstimate, error) {
+	estimate, err := l.logProvider.EstimateLogs(ctx, startTime, endTime, namespace)
+	if err != nil {
+		return domain.CollectorEstimate{}, fmt.Errorf("failed to estimate logs: %w", err)
+	}
+
+	return estimate, nil
+}
+
 // Select ctx.Done on writing to avoid blocking this process if the receiver throws an error and does not read the channel anymore.
 // The context muss be derived from the shared error group.
 func writeSaveToChannel[T any](ctx context.Context, data T, dataChannel chan<- T) {
@@ -107,3 +107,36 @@ func TestLogCollector_Name(t *testing.T) {
 		assert.Equal(t, "Logs", sut.Name())
 	})
 }
+
+func TestLogCollector_Estimate(t *testing.T) {
+	startTime := time.Now()
+	endTime := startTime.AddDate(0, 0, 10)
+
+	t.Run("should return estimate of log provider", func(t *testing.T) {
+		// given
+		expected := domain.CollectorEstimate{Items: 10, Unit: "log lines", Bytes: 100, Requests: 1}
+		logPrvMock := NewMockLogsProvider(t)
+		logPrvMock.EXPECT().EstimateLogs(testCtx, startTime, endTime, testNamespace).Return(expected, nil)
+		sut := NewLogCollector(logPrvMock)
+
+		// when
+		estimate, err := sut.Estimate(testCtx, testNamespace, startTime, endTime)
+
+		// then
+		require.NoError(t, err)
+		assert.Equal(t, expected, estimate)
+	})
+	t.Run("should return error of log provider", func(t *testing.T) {
+		// given
+		logPrvMock := NewMockLogsProvider(t)
+		logPrvMock.EXPECT().EstimateLogs(testCtx, startTime, endTime, testNamespace).Return(domain.CollectorEstimate{}, assert.AnError)
+		sut := NewLogCollector(logPrvMock)
+
+		// when
+		_, err := sut.Estimate(testCtx, testNamespace, startTime, endTime)
+
+		// then
+		require.ErrorIs(t, err, assert.AnError)
+		assert.ErrorContains(t, err, "failed to estimate logs")
+	})
+}
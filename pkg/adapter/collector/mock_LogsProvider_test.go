@@ -24,6 +24,65 @@ func (_m *MockLogsProvider) EXPECT() *MockLogsProvider_Expecter {
 	return &MockLogsProvider_Expecter{mock: &_m.Mock}
 }
 
+// EstimateLogs provides a mock function with given fields: ctx, start, end, namespace
+func (_m *MockLogsProvider) EstimateLogs(ctx context.Context, start time.Time, end time.Time, namespace string) (domain.CollectorEstimate, error) {
+	ret := _m.Called(ctx, start, end, namespace)
+
+	if len(ret) == 0 {
+		panic("no return value specified for EstimateLogs")
+	}
+
+	var r0 domain.CollectorEstimate
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, time.Time, time.Time, string) (domain.CollectorEstimate, error)); ok {
+		return rf(ctx, start, end, namespace)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, time.Time, time.Time, string) domain.CollectorEstimate); ok {
+		r0 = rf(ctx, start, end, namespace)
+	} else {
+		r0 = ret.Get(0).(domain.CollectorEstimate)
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, time.Time, time.Time, string) error); ok {
+		r1 = rf(ctx, start, end, namespace)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// MockLogsProvider_EstimateLogs_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'EstimateLogs'
+type MockLogsProvider_EstimateLogs_Call struct {
+	*mock.Call
+}
+
+// EstimateLogs is a helper method to define mock.On call
+//   - ctx context.Context
+//   - start time.Time
+//   - end time.Time
+//   - namespace string
+func (_e *MockLogsProvider_Expecter) EstimateLogs(ctx interface{}, start interface{}, end interface{}, namespace interface{}) *MockLogsProvider_EstimateLogs_Call {
+	return &MockLogsProvider_EstimateLogs_Call{Call: _e.mock.On("EstimateLogs", ctx, start, end, namespace)}
+}
+
+func (_c *MockLogsProvider_EstimateLogs_Call) Run(run func(ctx context.Context, start time.Time, end time.Time, namespace string)) *MockLogsProvider_EstimateLogs_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(time.Time), args[2].(time.Time), args[3].(string))
+	})
+	return _c
+}
+
+func (_c *MockLogsProvider_EstimateLogs_Call) Return(_a0 domain.CollectorEstimate, _a1 error) *MockLogsProvider_EstimateLogs_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *MockLogsProvider_EstimateLogs_Call) RunAndReturn(run func(context.Context, time.Time, time.Time, string) (domain.CollectorEstimate, error)) *MockLogsProvider_EstimateLogs_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
 // FindEvents provides a mock function with given fields: ctx, start, end, namespace, resultChan
 func (_m *MockLogsProvider) FindEvents(ctx context.Context, start time.Time, end time.Time, namespace string, resultChan chan<- *domain.LogLine) error {
 	ret := _m.Called(ctx, start, end, namespace, resultChan)
@@ -0,0 +1,132 @@
+// Code generated by mockery v2.53.3. DO NOT EDIT.
+
+package collector
+
+import (
+	mock "github.com/stretchr/testify/mock"
+	rest "k8s.io/client-go/rest"
+
+	v1 "k8s.io/client-go/kubernetes/typed/events/v1"
+)
+
+// mockEventsV1Interface is an autogenerated mock type for the eventsV1Interface type
+type mockEventsV1Interface struct {
+	mock.Mock
+}
+
+type mockEventsV1Interface_Expecter struct {
+	mock *mock.Mock
+}
+
+func (_m *mockEventsV1Interface) EXPECT() *mockEventsV1Interface_Expecter {
+	return &mockEventsV1Interface_Expecter{mock: &_m.Mock}
+}
+
+// Events provides a mock function with given fields: namespace
+func (_m *mockEventsV1Interface) Events(namespace string) v1.EventInterface {
+	ret := _m.Called(namespace)
+
+	if len(ret) == 0 {
+		panic("no return value specified for Events")
+	}
+
+	var r0 v1.EventInterface
+	if rf, ok := ret.Get(0).(func(string) v1.EventInterface); ok {
+		r0 = rf(namespace)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(v1.EventInterface)
+		}
+	}
+
+	return r0
+}
+
+// mockEventsV1Interface_Events_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'Events'
+type mockEventsV1Interface_Events_Call struct {
+	*mock.Call
+}
+
+// Events is a helper method to define mock.On call
+//   - namespace string
+func (_e *mockEventsV1Interface_Expecter) Events(namespace interface{}) *mockEventsV1Interface_Events_Call {
+	return &mockEventsV1Interface_Events_Call{Call: _e.mock.On("Events", namespace)}
+}
+
+func (_c *mockEventsV1Interface_Events_Call) Run(run func(namespace string)) *mockEventsV1Interface_Events_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(string))
+	})
+	return _c
+}
+
+func (_c *mockEventsV1Interface_Events_Call) Return(_a0 v1.EventInterface) *mockEventsV1Interface_Events_Call {
+	_c.Call.Return(_a0)
+	return _c
+}
+
+func (_c *mockEventsV1Interface_Events_Call) RunAndReturn(run func(string) v1.EventInterface) *mockEventsV1Interface_Events_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// RESTClient provides a mock function with no fields
+func (_m *mockEventsV1Interface) RESTClient() rest.Interface {
+	ret := _m.Called()
+
+	if len(ret) == 0 {
+		panic("no return value specified for RESTClient")
+	}
+
+	var r0 rest.Interface
+	if rf, ok := ret.Get(0).(func() rest.Interface); ok {
+		r0 = rf()
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(rest.Interface)
+		}
+	}
+
+	return r0
+}
+
+// mockEventsV1Interface_RESTClient_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'RESTClient'
+type mockEventsV1Interface_RESTClient_Call struct {
+	*mock.Call
+}
+
+// RESTClient is a helper method to define mock.On call
+func (_e *mockEventsV1Interface_Expecter) RESTClient() *mockEventsV1Interface_RESTClient_Call {
+	return &mockEventsV1Interface_RESTClient_Call{Call: _e.mock.On("RESTClient")}
+}
+
+func (_c *mockEventsV1Interface_RESTClient_Call) Run(run func()) *mockEventsV1Interface_RESTClient_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run()
+	})
+	return _c
+}
+
+func (_c *mockEventsV1Interface_RESTClient_Call) Return(_a0 rest.Interface) *mockEventsV1Interface_RESTClient_Call {
+	_c.Call.Return(_a0)
+	return _c
+}
+
+func (_c *mockEventsV1Interface_RESTClient_Call) RunAndReturn(run func() rest.Interface) *mockEventsV1Interface_RESTClient_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// newMockEventsV1Interface creates a new instance of mockEventsV1Interface. It also registers a testing interface on the mock and a cleanup function to assert the mocks expectations.
+// The first argument is typically a *testing.T value.
+func newMockEventsV1Interface(t interface {
+	mock.TestingT
+	Cleanup(func())
+}) *mockEventsV1Interface {
+	mock := &mockEventsV1Interface{}
+	mock.Mock.Test(t)
+
+	t.Cleanup(func() { mock.AssertExpectations(t) })
+
+	return mock
+}
@@ -24,27 +24,29 @@ func (_m *mockMetricsProvider) EXPECT() *mockMetricsProvider_Expecter {
 	return &mockMetricsProvider_Expecter{mock: &_m.Mock}
 }
 
-// GetCapacityBytesForPVC provides a mock function with given fields: ctx, namespace, pvcName, ts
-func (_m *mockMetricsProvider) GetCapacityBytesForPVC(ctx context.Context, namespace string, pvcName string, ts time.Time) (int64, error) {
-	ret := _m.Called(ctx, namespace, pvcName, ts)
+// GetCapacityBytesForPVC provides a mock function with given fields: ctx, namespace, pvcName, start, end, step
+func (_m *mockMetricsProvider) GetCapacityBytesForPVC(ctx context.Context, namespace string, pvcName string, start time.Time, end time.Time, step time.Duration) ([]domain.LabeledSample, error) {
+	ret := _m.Called(ctx, namespace, pvcName, start, end, step)
 
 	if len(ret) == 0 {
 		panic("no return value specified for GetCapacityBytesForPVC")
 	}
 
-	var r0 int64
+	var r0 []domain.LabeledSample
 	var r1 error
-	if rf, ok := ret.Get(0).(func(context.Context, string, string, time.Time) (int64, error)); ok {
-		return rf(ctx, namespace, pvcName, ts)
+	if rf, ok := ret.Get(0).(func(context.Context, string, string, time.Time, time.Time, time.Duration) ([]domain.LabeledSample, error)); ok {
+		return rf(ctx, namespace, pvcName, start, end, step)
 	}
-	if rf, ok := ret.Get(0).(func(context.Context, string, string, time.Time) int64); ok {
-		r0 = rf(ctx, namespace, pvcName, ts)
+	if rf, ok := ret.Get(0).(func(context.Context, string, string, time.Time, time.Time, time.Duration) []domain.LabeledSample); ok {
+		r0 = rf(ctx, namespace, pvcName, start, end, step)
 	} else {
-		r0 = ret.Get(0).(int64)
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]domain.LabeledSample)
+		}
 	}
 
-	if rf, ok := ret.Get(1).(func(context.Context, string, string, time.Time) error); ok {
-		r1 = rf(ctx, namespace, pvcName, ts)
+	if rf, ok := ret.Get(1).(func(context.Context, string, string, time.Time, time.Time, time.Duration) error); ok {
+		r1 = rf(ctx, namespace, pvcName, start, end, step)
 	} else {
 		r1 = ret.Error(1)
 	}
@@ -61,24 +63,26 @@ type mockMetricsProvider_GetCapacityBytesForPVC_Call struct {
 //   - ctx context.Context
 //   - namespace string
 //   - pvcName string
-//   - ts time.Time
-func (_e *mockMetricsProvider_Expecter) GetCapacityBytesForPVC(ctx interface{}, namespace interface{}, pvcName interface{}, ts interface{}) *mockMetricsProvider_GetCapacityBytesForPVC_Call {
-	return &mockMetricsProvider_GetCapacityBytesForPVC_Call{Call: _e.mock.On("GetCapacityBytesForPVC", ctx, namespace, pvcName, ts)}
+//   - start time.Time
+//   - end time.Time
+//   - step time.Duration
+func (_e *mockMetricsProvider_Expecter) GetCapacityBytesForPVC(ctx interface{}, namespace interface{}, pvcName interface{}, start interface{}, end interface{}, step interface{}) *mockMetricsProvider_GetCapacityBytesForPVC_Call {
+	return &mockMetricsProvider_GetCapacityBytesForPVC_Call{Call: _e.mock.On("GetCapacityBytesForPVC", ctx, namespace, pvcName, start, end, step)}
 }
 
-func (_c *mockMetricsProvider_GetCapacityBytesForPVC_Call) Run(run func(ctx context.Context, namespace string, pvcName string, ts time.Time)) *mockMetricsProvider_GetCapacityBytesForPVC_Call {
+func (_c *mockMetricsProvider_GetCapacityBytesForPVC_Call) Run(run func(ctx context.Context, namespace string, pvcName string, start time.Time, end time.Time, step time.Duration)) *mockMetricsProvider_GetCapacityBytesForPVC_Call {
 	_c.Call.Run(func(args mock.Arguments) {
-		run(args[0].(context.Context), args[1].(string), args[2].(string), args[3].(time.Time))
+		run(args[0].(context.Context), args[1].(string), args[2].(string), args[3].(time.Time), args[4].(time.Time), args[5].(time.Duration))
 	})
 	return _c
 }
 
-func (_c *mockMetricsProvider_GetCapacityBytesForPVC_Call) Return(_a0 int64, _a1 error) *mockMetricsProvider_GetCapacityBytesForPVC_Call {
+func (_c *mockMetricsProvider_GetCapacityBytesForPVC_Call) Return(_a0 []domain.LabeledSample, _a1 error) *mockMetricsProvider_GetCapacityBytesForPVC_Call {
 	_c.Call.Return(_a0, _a1)
 	return _c
 }
 
-func (_c *mockMetricsProvider_GetCapacityBytesForPVC_Call) RunAndReturn(run func(context.Context, string, string, time.Time) (int64, error)) *mockMetricsProvider_GetCapacityBytesForPVC_Call {
+func (_c *mockMetricsProvider_GetCapacityBytesForPVC_Call) RunAndReturn(run func(context.Context, string, string, time.Time, time.Time, time.Duration) ([]domain.LabeledSample, error)) *mockMetricsProvider_GetCapacityBytesForPVC_Call {
 	_c.Call.Return(run)
 	return _c
 }
@@ -733,27 +737,29 @@ func (_c *mockMetricsProvider_GetNodeStorageFreeRelative_Call) RunAndReturn(run
 	return _c
 }
 
-// GetUsedBytesForPVC provides a mock function with given fields: ctx, namespace, pvcName, ts
-func (_m *mockMetricsProvider) GetUsedBytesForPVC(ctx context.Context, namespace string, pvcName string, ts time.Time) (int64, error) {
-	ret := _m.Called(ctx, namespace, pvcName, ts)
+// GetUsedBytesForPVC provides a mock function with given fields: ctx, namespace, pvcName, start, end, step
+func (_m *mockMetricsProvider) GetUsedBytesForPVC(ctx context.Context, namespace string, pvcName string, start time.Time, end time.Time, step time.Duration) ([]domain.LabeledSample, error) {
+	ret := _m.Called(ctx, namespace, pvcName, start, end, step)
 
 	if len(ret) == 0 {
 		panic("no return value specified for GetUsedBytesForPVC")
 	}
 
-	var r0 int64
+	var r0 []domain.LabeledSample
 	var r1 error
-	if rf, ok := ret.Get(0).(func(context.Context, string, string, time.Time) (int64, error)); ok {
-		return rf(ctx, namespace, pvcName, ts)
+	if rf, ok := ret.Get(0).(func(context.Context, string, string, time.Time, time.Time, time.Duration) ([]domain.LabeledSample, error)); ok {
+		return rf(ctx, namespace, pvcName, start, end, step)
 	}
-	if rf, ok := ret.Get(0).(func(context.Context, string, string, time.Time) int64); ok {
-		r0 = rf(ctx, namespace, pvcName, ts)
+	if rf, ok := ret.Get(0).(func(context.Context, string, string, time.Time, time.Time, time.Duration) []domain.LabeledSample); ok {
+		r0 = rf(ctx, namespace, pvcName, start, end, step)
 	} else {
-		r0 = ret.Get(0).(int64)
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]domain.LabeledSample)
+		}
 	}
 
-	if rf, ok := ret.Get(1).(func(context.Context, string, string, time.Time) error); ok {
-		r1 = rf(ctx, namespace, pvcName, ts)
+	if rf, ok := ret.Get(1).(func(context.Context, string, string, time.Time, time.Time, time.Duration) error); ok {
+		r1 = rf(ctx, namespace, pvcName, start, end, step)
 	} else {
 		r1 = ret.Error(1)
 	}
@@ -770,24 +776,89 @@ type mockMetricsProvider_GetUsedBytesForPVC_Call struct {
 //   - ctx context.Context
 //   - namespace string
 //   - pvcName string
-//   - ts time.Time
-func (_e *mockMetricsProvider_Expecter) GetUsedBytesForPVC(ctx interface{}, namespace interface{}, pvcName interface{}, ts interface{}) *mockMetricsProvider_GetUsedBytesForPVC_Call {
-	return &mockMetricsProvider_GetUsedBytesForPVC_Call{Call: _e.mock.On("GetUsedBytesForPVC", ctx, namespace, pvcName, ts)}
+//   - start time.Time
+//   - end time.Time
+//   - step time.Duration
+func (_e *mockMetricsProvider_Expecter) GetUsedBytesForPVC(ctx interface{}, namespace interface{}, pvcName interface{}, start interface{}, end interface{}, step interface{}) *mockMetricsProvider_GetUsedBytesForPVC_Call {
+	return &mockMetricsProvider_GetUsedBytesForPVC_Call{Call: _e.mock.On("GetUsedBytesForPVC", ctx, namespace, pvcName, start, end, step)}
+}
+
+func (_c *mockMetricsProvider_GetUsedBytesForPVC_Call) Run(run func(ctx context.Context, namespace string, pvcName string, start time.Time, end time.Time, step time.Duration)) *mockMetricsProvider_GetUsedBytesForPVC_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(string), args[2].(string), args[3].(time.Time), args[4].(time.Time), args[5].(time.Duration))
+	})
+	return _c
+}
+
+func (_c *mockMetricsProvider_GetUsedBytesForPVC_Call) Return(_a0 []domain.LabeledSample, _a1 error) *mockMetricsProvider_GetUsedBytesForPVC_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *mockMetricsProvider_GetUsedBytesForPVC_Call) RunAndReturn(run func(context.Context, string, string, time.Time, time.Time, time.Duration) ([]domain.LabeledSample, error)) *mockMetricsProvider_GetUsedBytesForPVC_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// GetUsedInodesForPVC provides a mock function with given fields: ctx, namespace, pvcName, start, end, step
+func (_m *mockMetricsProvider) GetUsedInodesForPVC(ctx context.Context, namespace string, pvcName string, start time.Time, end time.Time, step time.Duration) ([]domain.LabeledSample, error) {
+	ret := _m.Called(ctx, namespace, pvcName, start, end, step)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetUsedInodesForPVC")
+	}
+
+	var r0 []domain.LabeledSample
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, string, string, time.Time, time.Time, time.Duration) ([]domain.LabeledSample, error)); ok {
+		return rf(ctx, namespace, pvcName, start, end, step)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, string, string, time.Time, time.Time, time.Duration) []domain.LabeledSample); ok {
+		r0 = rf(ctx, namespace, pvcName, start, end, step)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]domain.LabeledSample)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, string, string, time.Time, time.Time, time.Duration) error); ok {
+		r1 = rf(ctx, namespace, pvcName, start, end, step)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// mockMetricsProvider_GetUsedInodesForPVC_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'GetUsedInodesForPVC'
+type mockMetricsProvider_GetUsedInodesForPVC_Call struct {
+	*mock.Call
+}
+
+// GetUsedInodesForPVC is a helper method to define mock.On call
+//   - ctx context.Context
+//   - namespace string
+//   - pvcName string
+//   - start time.Time
+//   - end time.Time
+//   - step time.Duration
+func (_e *mockMetricsProvider_Expecter) GetUsedInodesForPVC(ctx interface{}, namespace interface{}, pvcName interface{}, start interface{}, end interface{}, step interface{}) *mockMetricsProvider_GetUsedInodesForPVC_Call {
+	return &mockMetricsProvider_GetUsedInodesForPVC_Call{Call: _e.mock.On("GetUsedInodesForPVC", ctx, namespace, pvcName, start, end, step)}
 }
 
-func (_c *mockMetricsProvider_GetUsedBytesForPVC_Call) Run(run func(ctx context.Context, namespace string, pvcName string, ts time.Time)) *mockMetricsProvider_GetUsedBytesForPVC_Call {
+func (_c *mockMetricsProvider_GetUsedInodesForPVC_Call) Run(run func(ctx context.Context, namespace string, pvcName string, start time.Time, end time.Time, step time.Duration)) *mockMetricsProvider_GetUsedInodesForPVC_Call {
 	_c.Call.Run(func(args mock.Arguments) {
-		run(args[0].(context.Context), args[1].(string), args[2].(string), args[3].(time.Time))
+		run(args[0].(context.Context), args[1].(string), args[2].(string), args[3].(time.Time), args[4].(time.Time), args[5].(time.Duration))
 	})
 	return _c
 }
 
-func (_c *mockMetricsProvider_GetUsedBytesForPVC_Call) Return(_a0 int64, _a1 error) *mockMetricsProvider_GetUsedBytesForPVC_Call {
+func (_c *mockMetricsProvider_GetUsedInodesForPVC_Call) Return(_a0 []domain.LabeledSample, _a1 error) *mockMetricsProvider_GetUsedInodesForPVC_Call {
 	_c.Call.Return(_a0, _a1)
 	return _c
 }
 
-func (_c *mockMetricsProvider_GetUsedBytesForPVC_Call) RunAndReturn(run func(context.Context, string, string, time.Time) (int64, error)) *mockMetricsProvider_GetUsedBytesForPVC_Call {
+func (_c *mockMetricsProvider_GetUsedInodesForPVC_Call) RunAndReturn(run func(context.Context, string, string, time.Time, time.Time, time.Duration) ([]domain.LabeledSample, error)) *mockMetricsProvider_GetUsedInodesForPVC_Call {
 	_c.Call.Return(run)
 	return _c
 }
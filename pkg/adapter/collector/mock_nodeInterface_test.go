@@ -0,0 +1,757 @@
+// Code generated by mockery v2.53.3. DO NOT EDIT.
+
+package collector
+
+import (
+	context "context"
+
+	corev1 "k8s.io/api/core/v1"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	mock "github.com/stretchr/testify/mock"
+
+	types "k8s.io/apimachinery/pkg/types"
+
+	v1 "k8s.io/client-go/applyconfigurations/core/v1"
+
+	watch "k8s.io/apimachinery/pkg/watch"
+)
+
+// mockNodeInterface is an autogenerated mock type for the nodeInterface type
+type mockNodeInterface struct {
+	mock.Mock
+}
+
+type mockNodeInterface_Expecter struct {
+	mock *mock.Mock
+}
+
+func (_m *mockNodeInterface) EXPECT() *mockNodeInterface_Expecter {
+	return &mockNodeInterface_Expecter{mock: &_m.Mock}
+}
+
+// Apply provides a mock function with given fields: ctx, node, opts
+func (_m *mockNodeInterface) Apply(ctx context.Context, node *v1.NodeApplyConfiguration, opts metav1.ApplyOptions) (*corev1.Node, error) {
+	ret := _m.Called(ctx, node, opts)
+
+	if len(ret) == 0 {
+		panic("no return value specified for Apply")
+	}
+
+	var r0 *corev1.Node
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, *v1.NodeApplyConfiguration, metav1.ApplyOptions) (*corev1.Node, error)); ok {
+		return rf(ctx, node, opts)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, *v1.NodeApplyConfiguration, metav1.ApplyOptions) *corev1.Node); ok {
+		r0 = rf(ctx, node, opts)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*corev1.Node)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, *v1.NodeApplyConfiguration, metav1.ApplyOptions) error); ok {
+		r1 = rf(ctx, node, opts)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// mockNodeInterface_Apply_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'Apply'
+type mockNodeInterface_Apply_Call struct {
+	*mock.Call
+}
+
+// Apply is a helper method to define mock.On call
+//   - ctx context.Context
+//   - node *v1.NodeApplyConfiguration
+//   - opts metav1.ApplyOptions
+func (_e *mockNodeInterface_Expecter) Apply(ctx interface{}, node interface{}, opts interface{}) *mockNodeInterface_Apply_Call {
+	return &mockNodeInterface_Apply_Call{Call: _e.mock.On("Apply", ctx, node, opts)}
+}
+
+func (_c *mockNodeInterface_Apply_Call) Run(run func(ctx context.Context, node *v1.NodeApplyConfiguration, opts metav1.ApplyOptions)) *mockNodeInterface_Apply_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(*v1.NodeApplyConfiguration), args[2].(metav1.ApplyOptions))
+	})
+	return _c
+}
+
+func (_c *mockNodeInterface_Apply_Call) Return(result *corev1.Node, err error) *mockNodeInterface_Apply_Call {
+	_c.Call.Return(result, err)
+	return _c
+}
+
+func (_c *mockNodeInterface_Apply_Call) RunAndReturn(run func(context.Context, *v1.NodeApplyConfiguration, metav1.ApplyOptions) (*corev1.Node, error)) *mockNodeInterface_Apply_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// ApplyStatus provides a mock function with given fields: ctx, node, opts
+func (_m *mockNodeInterface) ApplyStatus(ctx context.Context, node *v1.NodeApplyConfiguration, opts metav1.ApplyOptions) (*corev1.Node, error) {
+	ret := _m.Called(ctx, node, opts)
+
+	if len(ret) == 0 {
+		panic("no return value specified for ApplyStatus")
+	}
+
+	var r0 *corev1.Node
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, *v1.NodeApplyConfiguration, metav1.ApplyOptions) (*corev1.Node, error)); ok {
+		return rf(ctx, node, opts)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, *v1.NodeApplyConfiguration, metav1.ApplyOptions) *corev1.Node); ok {
+		r0 = rf(ctx, node, opts)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*corev1.Node)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, *v1.NodeApplyConfiguration, metav1.ApplyOptions) error); ok {
+		r1 = rf(ctx, node, opts)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// mockNodeInterface_ApplyStatus_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'ApplyStatus'
+type mockNodeInterface_ApplyStatus_Call struct {
+	*mock.Call
+}
+
+// ApplyStatus is a helper method to define mock.On call
+//   - ctx context.Context
+//   - node *v1.NodeApplyConfiguration
+//   - opts metav1.ApplyOptions
+func (_e *mockNodeInterface_Expecter) ApplyStatus(ctx interface{}, node interface{}, opts interface{}) *mockNodeInterface_ApplyStatus_Call {
+	return &mockNodeInterface_ApplyStatus_Call{Call: _e.mock.On("ApplyStatus", ctx, node, opts)}
+}
+
+func (_c *mockNodeInterface_ApplyStatus_Call) Run(run func(ctx context.Context, node *v1.NodeApplyConfiguration, opts metav1.ApplyOptions)) *mockNodeInterface_ApplyStatus_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(*v1.NodeApplyConfiguration), args[2].(metav1.ApplyOptions))
+	})
+	return _c
+}
+
+func (_c *mockNodeInterface_ApplyStatus_Call) Return(result *corev1.Node, err error) *mockNodeInterface_ApplyStatus_Call {
+	_c.Call.Return(result, err)
+	return _c
+}
+
+func (_c *mockNodeInterface_ApplyStatus_Call) RunAndReturn(run func(context.Context, *v1.NodeApplyConfiguration, metav1.ApplyOptions) (*corev1.Node, error)) *mockNodeInterface_ApplyStatus_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// Create provides a mock function with given fields: ctx, node, opts
+func (_m *mockNodeInterface) Create(ctx context.Context, node *corev1.Node, opts metav1.CreateOptions) (*corev1.Node, error) {
+	ret := _m.Called(ctx, node, opts)
+
+	if len(ret) == 0 {
+		panic("no return value specified for Create")
+	}
+
+	var r0 *corev1.Node
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, *corev1.Node, metav1.CreateOptions) (*corev1.Node, error)); ok {
+		return rf(ctx, node, opts)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, *corev1.Node, metav1.CreateOptions) *corev1.Node); ok {
+		r0 = rf(ctx, node, opts)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*corev1.Node)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, *corev1.Node, metav1.CreateOptions) error); ok {
+		r1 = rf(ctx, node, opts)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// mockNodeInterface_Create_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'Create'
+type mockNodeInterface_Create_Call struct {
+	*mock.Call
+}
+
+// Create is a helper method to define mock.On call
+//   - ctx context.Context
+//   - node *corev1.Node
+//   - opts metav1.CreateOptions
+func (_e *mockNodeInterface_Expecter) Create(ctx interface{}, node interface{}, opts interface{}) *mockNodeInterface_Create_Call {
+	return &mockNodeInterface_Create_Call{Call: _e.mock.On("Create", ctx, node, opts)}
+}
+
+func (_c *mockNodeInterface_Create_Call) Run(run func(ctx context.Context, node *corev1.Node, opts metav1.CreateOptions)) *mockNodeInterface_Create_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(*corev1.Node), args[2].(metav1.CreateOptions))
+	})
+	return _c
+}
+
+func (_c *mockNodeInterface_Create_Call) Return(_a0 *corev1.Node, _a1 error) *mockNodeInterface_Create_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *mockNodeInterface_Create_Call) RunAndReturn(run func(context.Context, *corev1.Node, metav1.CreateOptions) (*corev1.Node, error)) *mockNodeInterface_Create_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// Delete provides a mock function with given fields: ctx, name, opts
+func (_m *mockNodeInterface) Delete(ctx context.Context, name string, opts metav1.DeleteOptions) error {
+	ret := _m.Called(ctx, name, opts)
+
+	if len(ret) == 0 {
+		panic("no return value specified for Delete")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, string, metav1.DeleteOptions) error); ok {
+		r0 = rf(ctx, name, opts)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// mockNodeInterface_Delete_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'Delete'
+type mockNodeInterface_Delete_Call struct {
+	*mock.Call
+}
+
+// Delete is a helper method to define mock.On call
+//   - ctx context.Context
+//   - name string
+//   - opts metav1.DeleteOptions
+func (_e *mockNodeInterface_Expecter) Delete(ctx interface{}, name interface{}, opts interface{}) *mockNodeInterface_Delete_Call {
+	return &mockNodeInterface_Delete_Call{Call: _e.mock.On("Delete", ctx, name, opts)}
+}
+
+func (_c *mockNodeInterface_Delete_Call) Run(run func(ctx context.Context, name string, opts metav1.DeleteOptions)) *mockNodeInterface_Delete_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(string), args[2].(metav1.DeleteOptions))
+	})
+	return _c
+}
+
+func (_c *mockNodeInterface_Delete_Call) Return(_a0 error) *mockNodeInterface_Delete_Call {
+	_c.Call.Return(_a0)
+	return _c
+}
+
+func (_c *mockNodeInterface_Delete_Call) RunAndReturn(run func(context.Context, string, metav1.DeleteOptions) error) *mockNodeInterface_Delete_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// DeleteCollection provides a mock function with given fields: ctx, opts, listOpts
+func (_m *mockNodeInterface) DeleteCollection(ctx context.Context, opts metav1.DeleteOptions, listOpts metav1.ListOptions) error {
+	ret := _m.Called(ctx, opts, listOpts)
+
+	if len(ret) == 0 {
+		panic("no return value specified for DeleteCollection")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, metav1.DeleteOptions, metav1.ListOptions) error); ok {
+		r0 = rf(ctx, opts, listOpts)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// mockNodeInterface_DeleteCollection_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'DeleteCollection'
+type mockNodeInterface_DeleteCollection_Call struct {
+	*mock.Call
+}
+
+// DeleteCollection is a helper method to define mock.On call
+//   - ctx context.Context
+//   - opts metav1.DeleteOptions
+//   - listOpts metav1.ListOptions
+func (_e *mockNodeInterface_Expecter) DeleteCollection(ctx interface{}, opts interface{}, listOpts interface{}) *mockNodeInterface_DeleteCollection_Call {
+	return &mockNodeInterface_DeleteCollection_Call{Call: _e.mock.On("DeleteCollection", ctx, opts, listOpts)}
+}
+
+func (_c *mockNodeInterface_DeleteCollection_Call) Run(run func(ctx context.Context, opts metav1.DeleteOptions, listOpts metav1.ListOptions)) *mockNodeInterface_DeleteCollection_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(metav1.DeleteOptions), args[2].(metav1.ListOptions))
+	})
+	return _c
+}
+
+func (_c *mockNodeInterface_DeleteCollection_Call) Return(_a0 error) *mockNodeInterface_DeleteCollection_Call {
+	_c.Call.Return(_a0)
+	return _c
+}
+
+func (_c *mockNodeInterface_DeleteCollection_Call) RunAndReturn(run func(context.Context, metav1.DeleteOptions, metav1.ListOptions) error) *mockNodeInterface_DeleteCollection_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// Get provides a mock function with given fields: ctx, name, opts
+func (_m *mockNodeInterface) Get(ctx context.Context, name string, opts metav1.GetOptions) (*corev1.Node, error) {
+	ret := _m.Called(ctx, name, opts)
+
+	if len(ret) == 0 {
+		panic("no return value specified for Get")
+	}
+
+	var r0 *corev1.Node
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, string, metav1.GetOptions) (*corev1.Node, error)); ok {
+		return rf(ctx, name, opts)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, string, metav1.GetOptions) *corev1.Node); ok {
+		r0 = rf(ctx, name, opts)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*corev1.Node)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, string, metav1.GetOptions) error); ok {
+		r1 = rf(ctx, name, opts)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// mockNodeInterface_Get_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'Get'
+type mockNodeInterface_Get_Call struct {
+	*mock.Call
+}
+
+// Get is a helper method to define mock.On call
+//   - ctx context.Context
+//   - name string
+//   - opts metav1.GetOptions
+func (_e *mockNodeInterface_Expecter) Get(ctx interface{}, name interface{}, opts interface{}) *mockNodeInterface_Get_Call {
+	return &mockNodeInterface_Get_Call{Call: _e.mock.On("Get", ctx, name, opts)}
+}
+
+func (_c *mockNodeInterface_Get_Call) Run(run func(ctx context.Context, name string, opts metav1.GetOptions)) *mockNodeInterface_Get_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(string), args[2].(metav1.GetOptions))
+	})
+	return _c
+}
+
+func (_c *mockNodeInterface_Get_Call) Return(_a0 *corev1.Node, _a1 error) *mockNodeInterface_Get_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *mockNodeInterface_Get_Call) RunAndReturn(run func(context.Context, string, metav1.GetOptions) (*corev1.Node, error)) *mockNodeInterface_Get_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// List provides a mock function with given fields: ctx, opts
+func (_m *mockNodeInterface) List(ctx context.Context, opts metav1.ListOptions) (*corev1.NodeList, error) {
+	ret := _m.Called(ctx, opts)
+
+	if len(ret) == 0 {
+		panic("no return value specified for List")
+	}
+
+	var r0 *corev1.NodeList
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, metav1.ListOptions) (*corev1.NodeList, error)); ok {
+		return rf(ctx, opts)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, metav1.ListOptions) *corev1.NodeList); ok {
+		r0 = rf(ctx, opts)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*corev1.NodeList)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, metav1.ListOptions) error); ok {
+		r1 = rf(ctx, opts)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// mockNodeInterface_List_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'List'
+type mockNodeInterface_List_Call struct {
+	*mock.Call
+}
+
+// List is a helper method to define mock.On call
+//   - ctx context.Context
+//   - opts metav1.ListOptions
+func (_e *mockNodeInterface_Expecter) List(ctx interface{}, opts interface{}) *mockNodeInterface_List_Call {
+	return &mockNodeInterface_List_Call{Call: _e.mock.On("List", ctx, opts)}
+}
+
+func (_c *mockNodeInterface_List_Call) Run(run func(ctx context.Context, opts metav1.ListOptions)) *mockNodeInterface_List_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(metav1.ListOptions))
+	})
+	return _c
+}
+
+func (_c *mockNodeInterface_List_Call) Return(_a0 *corev1.NodeList, _a1 error) *mockNodeInterface_List_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *mockNodeInterface_List_Call) RunAndReturn(run func(context.Context, metav1.ListOptions) (*corev1.NodeList, error)) *mockNodeInterface_List_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// Patch provides a mock function with given fields: ctx, name, pt, data, opts, subresources
+func (_m *mockNodeInterface) Patch(ctx context.Context, name string, pt types.PatchType, data []byte, opts metav1.PatchOptions, subresources ...string) (*corev1.Node, error) {
+	_va := make([]interface{}, len(subresources))
+	for _i := range subresources {
+		_va[_i] = subresources[_i]
+	}
+	var _ca []interface{}
+	_ca = append(_ca, ctx, name, pt, data, opts)
+	_ca = append(_ca, _va...)
+	ret := _m.Called(_ca...)
+
+	if len(ret) == 0 {
+		panic("no return value specified for Patch")
+	}
+
+	var r0 *corev1.Node
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, string, types.PatchType, []byte, metav1.PatchOptions, ...string) (*corev1.Node, error)); ok {
+		return rf(ctx, name, pt, data, opts, subresources...)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, string, types.PatchType, []byte, metav1.PatchOptions, ...string) *corev1.Node); ok {
+		r0 = rf(ctx, name, pt, data, opts, subresources...)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*corev1.Node)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, string, types.PatchType, []byte, metav1.PatchOptions, ...string) error); ok {
+		r1 = rf(ctx, name, pt, data, opts, subresources...)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// mockNodeInterface_Patch_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'Patch'
+type mockNodeInterface_Patch_Call struct {
+	*mock.Call
+}
+
+// Patch is a helper method to define mock.On call
+//   - ctx context.Context
+//   - name string
+//   - pt types.PatchType
+//   - data []byte
+//   - opts metav1.PatchOptions
+//   - subresources ...string
+func (_e *mockNodeInterface_Expecter) Patch(ctx interface{}, name interface{}, pt interface{}, data interface{}, opts interface{}, subresources ...interface{}) *mockNodeInterface_Patch_Call {
+	return &mockNodeInterface_Patch_Call{Call: _e.mock.On("Patch",
+		append([]interface{}{ctx, name, pt, data, opts}, subresources...)...)}
+}
+
+func (_c *mockNodeInterface_Patch_Call) Run(run func(ctx context.Context, name string, pt types.PatchType, data []byte, opts metav1.PatchOptions, subresources ...string)) *mockNodeInterface_Patch_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		variadicArgs := make([]string, len(args)-5)
+		for i, a := range args[5:] {
+			if a != nil {
+				variadicArgs[i] = a.(string)
+			}
+		}
+		run(args[0].(context.Context), args[1].(string), args[2].(types.PatchType), args[3].([]byte), args[4].(metav1.PatchOptions), variadicArgs...)
+	})
+	return _c
+}
+
+func (_c *mockNodeInterface_Patch_Call) Return(result *corev1.Node, err error) *mockNodeInterface_Patch_Call {
+	_c.Call.Return(result, err)
+	return _c
+}
+
+func (_c *mockNodeInterface_Patch_Call) RunAndReturn(run func(context.Context, string, types.PatchType, []byte, metav1.PatchOptions, ...string) (*corev1.Node, error)) *mockNodeInterface_Patch_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// PatchStatus provides a mock function with given fields: ctx, nodeName, data
+func (_m *mockNodeInterface) PatchStatus(ctx context.Context, nodeName string, data []byte) (*corev1.Node, error) {
+	ret := _m.Called(ctx, nodeName, data)
+
+	if len(ret) == 0 {
+		panic("no return value specified for PatchStatus")
+	}
+
+	var r0 *corev1.Node
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, string, []byte) (*corev1.Node, error)); ok {
+		return rf(ctx, nodeName, data)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, string, []byte) *corev1.Node); ok {
+		r0 = rf(ctx, nodeName, data)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*corev1.Node)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, string, []byte) error); ok {
+		r1 = rf(ctx, nodeName, data)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// mockNodeInterface_PatchStatus_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'PatchStatus'
+type mockNodeInterface_PatchStatus_Call struct {
+	*mock.Call
+}
+
+// PatchStatus is a helper method to define mock.On call
+//   - ctx context.Context
+//   - nodeName string
+//   - data []byte
+func (_e *mockNodeInterface_Expecter) PatchStatus(ctx interface{}, nodeName interface{}, data interface{}) *mockNodeInterface_PatchStatus_Call {
+	return &mockNodeInterface_PatchStatus_Call{Call: _e.mock.On("PatchStatus", ctx, nodeName, data)}
+}
+
+func (_c *mockNodeInterface_PatchStatus_Call) Run(run func(ctx context.Context, nodeName string, data []byte)) *mockNodeInterface_PatchStatus_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(string), args[2].([]byte))
+	})
+	return _c
+}
+
+func (_c *mockNodeInterface_PatchStatus_Call) Return(_a0 *corev1.Node, _a1 error) *mockNodeInterface_PatchStatus_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *mockNodeInterface_PatchStatus_Call) RunAndReturn(run func(context.Context, string, []byte) (*corev1.Node, error)) *mockNodeInterface_PatchStatus_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// Update provides a mock function with given fields: ctx, node, opts
+func (_m *mockNodeInterface) Update(ctx context.Context, node *corev1.Node, opts metav1.UpdateOptions) (*corev1.Node, error) {
+	ret := _m.Called(ctx, node, opts)
+
+	if len(ret) == 0 {
+		panic("no return value specified for Update")
+	}
+
+	var r0 *corev1.Node
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, *corev1.Node, metav1.UpdateOptions) (*corev1.Node, error)); ok {
+		return rf(ctx, node, opts)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, *corev1.Node, metav1.UpdateOptions) *corev1.Node); ok {
+		r0 = rf(ctx, node, opts)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*corev1.Node)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, *corev1.Node, metav1.UpdateOptions) error); ok {
+		r1 = rf(ctx, node, opts)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// mockNodeInterface_Update_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'Update'
+type mockNodeInterface_Update_Call struct {
+	*mock.Call
+}
+
+// Update is a helper method to define mock.On call
+//   - ctx context.Context
+//   - node *corev1.Node
+//   - opts metav1.UpdateOptions
+func (_e *mockNodeInterface_Expecter) Update(ctx interface{}, node interface{}, opts interface{}) *mockNodeInterface_Update_Call {
+	return &mockNodeInterface_Update_Call{Call: _e.mock.On("Update", ctx, node, opts)}
+}
+
+func (_c *mockNodeInterface_Update_Call) Run(run func(ctx context.Context, node *corev1.Node, opts metav1.UpdateOptions)) *mockNodeInterface_Update_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(*corev1.Node), args[2].(metav1.UpdateOptions))
+	})
+	return _c
+}
+
+func (_c *mockNodeInterface_Update_Call) Return(_a0 *corev1.Node, _a1 error) *mockNodeInterface_Update_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *mockNodeInterface_Update_Call) RunAndReturn(run func(context.Context, *corev1.Node, metav1.UpdateOptions) (*corev1.Node, error)) *mockNodeInterface_Update_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// UpdateStatus provides a mock function with given fields: ctx, node, opts
+func (_m *mockNodeInterface) UpdateStatus(ctx context.Context, node *corev1.Node, opts metav1.UpdateOptions) (*corev1.Node, error) {
+	ret := _m.Called(ctx, node, opts)
+
+	if len(ret) == 0 {
+		panic("no return value specified for UpdateStatus")
+	}
+
+	var r0 *corev1.Node
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, *corev1.Node, metav1.UpdateOptions) (*corev1.Node, error)); ok {
+		return rf(ctx, node, opts)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, *corev1.Node, metav1.UpdateOptions) *corev1.Node); ok {
+		r0 = rf(ctx, node, opts)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*corev1.Node)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, *corev1.Node, metav1.UpdateOptions) error); ok {
+		r1 = rf(ctx, node, opts)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// mockNodeInterface_UpdateStatus_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'UpdateStatus'
+type mockNodeInterface_UpdateStatus_Call struct {
+	*mock.Call
+}
+
+// UpdateStatus is a helper method to define mock.On call
+//   - ctx context.Context
+//   - node *corev1.Node
+//   - opts metav1.UpdateOptions
+func (_e *mockNodeInterface_Expecter) UpdateStatus(ctx interface{}, node interface{}, opts interface{}) *mockNodeInterface_UpdateStatus_Call {
+	return &mockNodeInterface_UpdateStatus_Call{Call: _e.mock.On("UpdateStatus", ctx, node, opts)}
+}
+
+func (_c *mockNodeInterface_UpdateStatus_Call) Run(run func(ctx context.Context, node *corev1.Node, opts metav1.UpdateOptions)) *mockNodeInterface_UpdateStatus_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(*corev1.Node), args[2].(metav1.UpdateOptions))
+	})
+	return _c
+}
+
+func (_c *mockNodeInterface_UpdateStatus_Call) Return(_a0 *corev1.Node, _a1 error) *mockNodeInterface_UpdateStatus_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *mockNodeInterface_UpdateStatus_Call) RunAndReturn(run func(context.Context, *corev1.Node, metav1.UpdateOptions) (*corev1.Node, error)) *mockNodeInterface_UpdateStatus_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// Watch provides a mock function with given fields: ctx, opts
+func (_m *mockNodeInterface) Watch(ctx context.Context, opts metav1.ListOptions) (watch.Interface, error) {
+	ret := _m.Called(ctx, opts)
+
+	if len(ret) == 0 {
+		panic("no return value specified for Watch")
+	}
+
+	var r0 watch.Interface
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, metav1.ListOptions) (watch.Interface, error)); ok {
+		return rf(ctx, opts)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, metav1.ListOptions) watch.Interface); ok {
+		r0 = rf(ctx, opts)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(watch.Interface)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, metav1.ListOptions) error); ok {
+		r1 = rf(ctx, opts)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// mockNodeInterface_Watch_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'Watch'
+type mockNodeInterface_Watch_Call struct {
+	*mock.Call
+}
+
+// Watch is a helper method to define mock.On call
+//   - ctx context.Context
+//   - opts metav1.ListOptions
+func (_e *mockNodeInterface_Expecter) Watch(ctx interface{}, opts interface{}) *mockNodeInterface_Watch_Call {
+	return &mockNodeInterface_Watch_Call{Call: _e.mock.On("Watch", ctx, opts)}
+}
+
+func (_c *mockNodeInterface_Watch_Call) Run(run func(ctx context.Context, opts metav1.ListOptions)) *mockNodeInterface_Watch_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(metav1.ListOptions))
+	})
+	return _c
+}
+
+func (_c *mockNodeInterface_Watch_Call) Return(_a0 watch.Interface, _a1 error) *mockNodeInterface_Watch_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *mockNodeInterface_Watch_Call) RunAndReturn(run func(context.Context, metav1.ListOptions) (watch.Interface, error)) *mockNodeInterface_Watch_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// newMockNodeInterface creates a new instance of mockNodeInterface. It also registers a testing interface on the mock and a cleanup function to assert the mocks expectations.
+// The first argument is typically a *testing.T value.
+func newMockNodeInterface(t interface {
+	mock.TestingT
+	Cleanup(func())
+}) *mockNodeInterface {
+	mock := &mockNodeInterface{}
+	mock.Mock.Test(t)
+
+	t.Cleanup(func() { mock.AssertExpectations(t) })
+
+	return mock
+}
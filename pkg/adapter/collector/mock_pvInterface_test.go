@@ -0,0 +1,697 @@
+// Code generated by mockery v2.53.3. DO NOT EDIT.
+
+package collector
+
+import (
+	context "context"
+
+	corev1 "k8s.io/api/core/v1"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	mock "github.com/stretchr/testify/mock"
+
+	types "k8s.io/apimachinery/pkg/types"
+
+	v1 "k8s.io/client-go/applyconfigurations/core/v1"
+
+	watch "k8s.io/apimachinery/pkg/watch"
+)
+
+// mockPvInterface is an autogenerated mock type for the pvInterface type
+type mockPvInterface struct {
+	mock.Mock
+}
+
+type mockPvInterface_Expecter struct {
+	mock *mock.Mock
+}
+
+func (_m *mockPvInterface) EXPECT() *mockPvInterface_Expecter {
+	return &mockPvInterface_Expecter{mock: &_m.Mock}
+}
+
+// Apply provides a mock function with given fields: ctx, persistentVolume, opts
+func (_m *mockPvInterface) Apply(ctx context.Context, persistentVolume *v1.PersistentVolumeApplyConfiguration, opts metav1.ApplyOptions) (*corev1.PersistentVolume, error) {
+	ret := _m.Called(ctx, persistentVolume, opts)
+
+	if len(ret) == 0 {
+		panic("no return value specified for Apply")
+	}
+
+	var r0 *corev1.PersistentVolume
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, *v1.PersistentVolumeApplyConfiguration, metav1.ApplyOptions) (*corev1.PersistentVolume, error)); ok {
+		return rf(ctx, persistentVolume, opts)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, *v1.PersistentVolumeApplyConfiguration, metav1.ApplyOptions) *corev1.PersistentVolume); ok {
+		r0 = rf(ctx, persistentVolume, opts)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*corev1.PersistentVolume)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, *v1.PersistentVolumeApplyConfiguration, metav1.ApplyOptions) error); ok {
+		r1 = rf(ctx, persistentVolume, opts)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// mockPvInterface_Apply_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'Apply'
+type mockPvInterface_Apply_Call struct {
+	*mock.Call
+}
+
+// Apply is a helper method to define mock.On call
+//   - ctx context.Context
+//   - persistentVolume *v1.PersistentVolumeApplyConfiguration
+//   - opts metav1.ApplyOptions
+func (_e *mockPvInterface_Expecter) Apply(ctx interface{}, persistentVolume interface{}, opts interface{}) *mockPvInterface_Apply_Call {
+	return &mockPvInterface_Apply_Call{Call: _e.mock.On("Apply", ctx, persistentVolume, opts)}
+}
+
+func (_c *mockPvInterface_Apply_Call) Run(run func(ctx context.Context, persistentVolume *v1.PersistentVolumeApplyConfiguration, opts metav1.ApplyOptions)) *mockPvInterface_Apply_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(*v1.PersistentVolumeApplyConfiguration), args[2].(metav1.ApplyOptions))
+	})
+	return _c
+}
+
+func (_c *mockPvInterface_Apply_Call) Return(result *corev1.PersistentVolume, err error) *mockPvInterface_Apply_Call {
+	_c.Call.Return(result, err)
+	return _c
+}
+
+func (_c *mockPvInterface_Apply_Call) RunAndReturn(run func(context.Context, *v1.PersistentVolumeApplyConfiguration, metav1.ApplyOptions) (*corev1.PersistentVolume, error)) *mockPvInterface_Apply_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// ApplyStatus provides a mock function with given fields: ctx, persistentVolume, opts
+func (_m *mockPvInterface) ApplyStatus(ctx context.Context, persistentVolume *v1.PersistentVolumeApplyConfiguration, opts metav1.ApplyOptions) (*corev1.PersistentVolume, error) {
+	ret := _m.Called(ctx, persistentVolume, opts)
+
+	if len(ret) == 0 {
+		panic("no return value specified for ApplyStatus")
+	}
+
+	var r0 *corev1.PersistentVolume
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, *v1.PersistentVolumeApplyConfiguration, metav1.ApplyOptions) (*corev1.PersistentVolume, error)); ok {
+		return rf(ctx, persistentVolume, opts)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, *v1.PersistentVolumeApplyConfiguration, metav1.ApplyOptions) *corev1.PersistentVolume); ok {
+		r0 = rf(ctx, persistentVolume, opts)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*corev1.PersistentVolume)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, *v1.PersistentVolumeApplyConfiguration, metav1.ApplyOptions) error); ok {
+		r1 = rf(ctx, persistentVolume, opts)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// mockPvInterface_ApplyStatus_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'ApplyStatus'
+type mockPvInterface_ApplyStatus_Call struct {
+	*mock.Call
+}
+
+// ApplyStatus is a helper method to define mock.On call
+//   - ctx context.Context
+//   - persistentVolume *v1.PersistentVolumeApplyConfiguration
+//   - opts metav1.ApplyOptions
+func (_e *mockPvInterface_Expecter) ApplyStatus(ctx interface{}, persistentVolume interface{}, opts interface{}) *mockPvInterface_ApplyStatus_Call {
+	return &mockPvInterface_ApplyStatus_Call{Call: _e.mock.On("ApplyStatus", ctx, persistentVolume, opts)}
+}
+
+func (_c *mockPvInterface_ApplyStatus_Call) Run(run func(ctx context.Context, persistentVolume *v1.PersistentVolumeApplyConfiguration, opts metav1.ApplyOptions)) *mockPvInterface_ApplyStatus_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(*v1.PersistentVolumeApplyConfiguration), args[2].(metav1.ApplyOptions))
+	})
+	return _c
+}
+
+func (_c *mockPvInterface_ApplyStatus_Call) Return(result *corev1.PersistentVolume, err error) *mockPvInterface_ApplyStatus_Call {
+	_c.Call.Return(result, err)
+	return _c
+}
+
+func (_c *mockPvInterface_ApplyStatus_Call) RunAndReturn(run func(context.Context, *v1.PersistentVolumeApplyConfiguration, metav1.ApplyOptions) (*corev1.PersistentVolume, error)) *mockPvInterface_ApplyStatus_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// Create provides a mock function with given fields: ctx, persistentVolume, opts
+func (_m *mockPvInterface) Create(ctx context.Context, persistentVolume *corev1.PersistentVolume, opts metav1.CreateOptions) (*corev1.PersistentVolume, error) {
+	ret := _m.Called(ctx, persistentVolume, opts)
+
+	if len(ret) == 0 {
+		panic("no return value specified for Create")
+	}
+
+	var r0 *corev1.PersistentVolume
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, *corev1.PersistentVolume, metav1.CreateOptions) (*corev1.PersistentVolume, error)); ok {
+		return rf(ctx, persistentVolume, opts)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, *corev1.PersistentVolume, metav1.CreateOptions) *corev1.PersistentVolume); ok {
+		r0 = rf(ctx, persistentVolume, opts)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*corev1.PersistentVolume)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, *corev1.PersistentVolume, metav1.CreateOptions) error); ok {
+		r1 = rf(ctx, persistentVolume, opts)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// mockPvInterface_Create_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'Create'
+type mockPvInterface_Create_Call struct {
+	*mock.Call
+}
+
+// Create is a helper method to define mock.On call
+//   - ctx context.Context
+//   - persistentVolume *corev1.PersistentVolume
+//   - opts metav1.CreateOptions
+func (_e *mockPvInterface_Expecter) Create(ctx interface{}, persistentVolume interface{}, opts interface{}) *mockPvInterface_Create_Call {
+	return &mockPvInterface_Create_Call{Call: _e.mock.On("Create", ctx, persistentVolume, opts)}
+}
+
+func (_c *mockPvInterface_Create_Call) Run(run func(ctx context.Context, persistentVolume *corev1.PersistentVolume, opts metav1.CreateOptions)) *mockPvInterface_Create_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(*corev1.PersistentVolume), args[2].(metav1.CreateOptions))
+	})
+	return _c
+}
+
+func (_c *mockPvInterface_Create_Call) Return(_a0 *corev1.PersistentVolume, _a1 error) *mockPvInterface_Create_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *mockPvInterface_Create_Call) RunAndReturn(run func(context.Context, *corev1.PersistentVolume, metav1.CreateOptions) (*corev1.PersistentVolume, error)) *mockPvInterface_Create_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// Delete provides a mock function with given fields: ctx, name, opts
+func (_m *mockPvInterface) Delete(ctx context.Context, name string, opts metav1.DeleteOptions) error {
+	ret := _m.Called(ctx, name, opts)
+
+	if len(ret) == 0 {
+		panic("no return value specified for Delete")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, string, metav1.DeleteOptions) error); ok {
+		r0 = rf(ctx, name, opts)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// mockPvInterface_Delete_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'Delete'
+type mockPvInterface_Delete_Call struct {
+	*mock.Call
+}
+
+// Delete is a helper method to define mock.On call
+//   - ctx context.Context
+//   - name string
+//   - opts metav1.DeleteOptions
+func (_e *mockPvInterface_Expecter) Delete(ctx interface{}, name interface{}, opts interface{}) *mockPvInterface_Delete_Call {
+	return &mockPvInterface_Delete_Call{Call: _e.mock.On("Delete", ctx, name, opts)}
+}
+
+func (_c *mockPvInterface_Delete_Call) Run(run func(ctx context.Context, name string, opts metav1.DeleteOptions)) *mockPvInterface_Delete_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(string), args[2].(metav1.DeleteOptions))
+	})
+	return _c
+}
+
+func (_c *mockPvInterface_Delete_Call) Return(_a0 error) *mockPvInterface_Delete_Call {
+	_c.Call.Return(_a0)
+	return _c
+}
+
+func (_c *mockPvInterface_Delete_Call) RunAndReturn(run func(context.Context, string, metav1.DeleteOptions) error) *mockPvInterface_Delete_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// DeleteCollection provides a mock function with given fields: ctx, opts, listOpts
+func (_m *mockPvInterface) DeleteCollection(ctx context.Context, opts metav1.DeleteOptions, listOpts metav1.ListOptions) error {
+	ret := _m.Called(ctx, opts, listOpts)
+
+	if len(ret) == 0 {
+		panic("no return value specified for DeleteCollection")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, metav1.DeleteOptions, metav1.ListOptions) error); ok {
+		r0 = rf(ctx, opts, listOpts)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// mockPvInterface_DeleteCollection_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'DeleteCollection'
+type mockPvInterface_DeleteCollection_Call struct {
+	*mock.Call
+}
+
+// DeleteCollection is a helper method to define mock.On call
+//   - ctx context.Context
+//   - opts metav1.DeleteOptions
+//   - listOpts metav1.ListOptions
+func (_e *mockPvInterface_Expecter) DeleteCollection(ctx interface{}, opts interface{}, listOpts interface{}) *mockPvInterface_DeleteCollection_Call {
+	return &mockPvInterface_DeleteCollection_Call{Call: _e.mock.On("DeleteCollection", ctx, opts, listOpts)}
+}
+
+func (_c *mockPvInterface_DeleteCollection_Call) Run(run func(ctx context.Context, opts metav1.DeleteOptions, listOpts metav1.ListOptions)) *mockPvInterface_DeleteCollection_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(metav1.DeleteOptions), args[2].(metav1.ListOptions))
+	})
+	return _c
+}
+
+func (_c *mockPvInterface_DeleteCollection_Call) Return(_a0 error) *mockPvInterface_DeleteCollection_Call {
+	_c.Call.Return(_a0)
+	return _c
+}
+
+func (_c *mockPvInterface_DeleteCollection_Call) RunAndReturn(run func(context.Context, metav1.DeleteOptions, metav1.ListOptions) error) *mockPvInterface_DeleteCollection_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// Get provides a mock function with given fields: ctx, name, opts
+func (_m *mockPvInterface) Get(ctx context.Context, name string, opts metav1.GetOptions) (*corev1.PersistentVolume, error) {
+	ret := _m.Called(ctx, name, opts)
+
+	if len(ret) == 0 {
+		panic("no return value specified for Get")
+	}
+
+	var r0 *corev1.PersistentVolume
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, string, metav1.GetOptions) (*corev1.PersistentVolume, error)); ok {
+		return rf(ctx, name, opts)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, string, metav1.GetOptions) *corev1.PersistentVolume); ok {
+		r0 = rf(ctx, name, opts)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*corev1.PersistentVolume)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, string, metav1.GetOptions) error); ok {
+		r1 = rf(ctx, name, opts)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// mockPvInterface_Get_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'Get'
+type mockPvInterface_Get_Call struct {
+	*mock.Call
+}
+
+// Get is a helper method to define mock.On call
+//   - ctx context.Context
+//   - name string
+//   - opts metav1.GetOptions
+func (_e *mockPvInterface_Expecter) Get(ctx interface{}, name interface{}, opts interface{}) *mockPvInterface_Get_Call {
+	return &mockPvInterface_Get_Call{Call: _e.mock.On("Get", ctx, name, opts)}
+}
+
+func (_c *mockPvInterface_Get_Call) Run(run func(ctx context.Context, name string, opts metav1.GetOptions)) *mockPvInterface_Get_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(string), args[2].(metav1.GetOptions))
+	})
+	return _c
+}
+
+func (_c *mockPvInterface_Get_Call) Return(_a0 *corev1.PersistentVolume, _a1 error) *mockPvInterface_Get_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *mockPvInterface_Get_Call) RunAndReturn(run func(context.Context, string, metav1.GetOptions) (*corev1.PersistentVolume, error)) *mockPvInterface_Get_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// List provides a mock function with given fields: ctx, opts
+func (_m *mockPvInterface) List(ctx context.Context, opts metav1.ListOptions) (*corev1.PersistentVolumeList, error) {
+	ret := _m.Called(ctx, opts)
+
+	if len(ret) == 0 {
+		panic("no return value specified for List")
+	}
+
+	var r0 *corev1.PersistentVolumeList
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, metav1.ListOptions) (*corev1.PersistentVolumeList, error)); ok {
+		return rf(ctx, opts)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, metav1.ListOptions) *corev1.PersistentVolumeList); ok {
+		r0 = rf(ctx, opts)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*corev1.PersistentVolumeList)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, metav1.ListOptions) error); ok {
+		r1 = rf(ctx, opts)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// mockPvInterface_List_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'List'
+type mockPvInterface_List_Call struct {
+	*mock.Call
+}
+
+// List is a helper method to define mock.On call
+//   - ctx context.Context
+//   - opts metav1.ListOptions
+func (_e *mockPvInterface_Expecter) List(ctx interface{}, opts interface{}) *mockPvInterface_List_Call {
+	return &mockPvInterface_List_Call{Call: _e.mock.On("List", ctx, opts)}
+}
+
+func (_c *mockPvInterface_List_Call) Run(run func(ctx context.Context, opts metav1.ListOptions)) *mockPvInterface_List_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(metav1.ListOptions))
+	})
+	return _c
+}
+
+func (_c *mockPvInterface_List_Call) Return(_a0 *corev1.PersistentVolumeList, _a1 error) *mockPvInterface_List_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *mockPvInterface_List_Call) RunAndReturn(run func(context.Context, metav1.ListOptions) (*corev1.PersistentVolumeList, error)) *mockPvInterface_List_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// Patch provides a mock function with given fields: ctx, name, pt, data, opts, subresources
+func (_m *mockPvInterface) Patch(ctx context.Context, name string, pt types.PatchType, data []byte, opts metav1.PatchOptions, subresources ...string) (*corev1.PersistentVolume, error) {
+	_va := make([]interface{}, len(subresources))
+	for _i := range subresources {
+		_va[_i] = subresources[_i]
+	}
+	var _ca []interface{}
+	_ca = append(_ca, ctx, name, pt, data, opts)
+	_ca = append(_ca, _va...)
+	ret := _m.Called(_ca...)
+
+	if len(ret) == 0 {
+		panic("no return value specified for Patch")
+	}
+
+	var r0 *corev1.PersistentVolume
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, string, types.PatchType, []byte, metav1.PatchOptions, ...string) (*corev1.PersistentVolume, error)); ok {
+		return rf(ctx, name, pt, data, opts, subresources...)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, string, types.PatchType, []byte, metav1.PatchOptions, ...string) *corev1.PersistentVolume); ok {
+		r0 = rf(ctx, name, pt, data, opts, subresources...)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*corev1.PersistentVolume)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, string, types.PatchType, []byte, metav1.PatchOptions, ...string) error); ok {
+		r1 = rf(ctx, name, pt, data, opts, subresources...)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// mockPvInterface_Patch_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'Patch'
+type mockPvInterface_Patch_Call struct {
+	*mock.Call
+}
+
+// Patch is a helper method to define mock.On call
+//   - ctx context.Context
+//   - name string
+//   - pt types.PatchType
+//   - data []byte
+//   - opts metav1.PatchOptions
+//   - subresources ...string
+func (_e *mockPvInterface_Expecter) Patch(ctx interface{}, name interface{}, pt interface{}, data interface{}, opts interface{}, subresources ...interface{}) *mockPvInterface_Patch_Call {
+	return &mockPvInterface_Patch_Call{Call: _e.mock.On("Patch",
+		append([]interface{}{ctx, name, pt, data, opts}, subresources...)...)}
+}
+
+func (_c *mockPvInterface_Patch_Call) Run(run func(ctx context.Context, name string, pt types.PatchType, data []byte, opts metav1.PatchOptions, subresources ...string)) *mockPvInterface_Patch_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		variadicArgs := make([]string, len(args)-5)
+		for i, a := range args[5:] {
+			if a != nil {
+				variadicArgs[i] = a.(string)
+			}
+		}
+		run(args[0].(context.Context), args[1].(string), args[2].(types.PatchType), args[3].([]byte), args[4].(metav1.PatchOptions), variadicArgs...)
+	})
+	return _c
+}
+
+func (_c *mockPvInterface_Patch_Call) Return(result *corev1.PersistentVolume, err error) *mockPvInterface_Patch_Call {
+	_c.Call.Return(result, err)
+	return _c
+}
+
+func (_c *mockPvInterface_Patch_Call) RunAndReturn(run func(context.Context, string, types.PatchType, []byte, metav1.PatchOptions, ...string) (*corev1.PersistentVolume, error)) *mockPvInterface_Patch_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// Update provides a mock function with given fields: ctx, persistentVolume, opts
+func (_m *mockPvInterface) Update(ctx context.Context, persistentVolume *corev1.PersistentVolume, opts metav1.UpdateOptions) (*corev1.PersistentVolume, error) {
+	ret := _m.Called(ctx, persistentVolume, opts)
+
+	if len(ret) == 0 {
+		panic("no return value specified for Update")
+	}
+
+	var r0 *corev1.PersistentVolume
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, *corev1.PersistentVolume, metav1.UpdateOptions) (*corev1.PersistentVolume, error)); ok {
+		return rf(ctx, persistentVolume, opts)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, *corev1.PersistentVolume, metav1.UpdateOptions) *corev1.PersistentVolume); ok {
+		r0 = rf(ctx, persistentVolume, opts)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*corev1.PersistentVolume)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, *corev1.PersistentVolume, metav1.UpdateOptions) error); ok {
+		r1 = rf(ctx, persistentVolume, opts)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// mockPvInterface_Update_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'Update'
+type mockPvInterface_Update_Call struct {
+	*mock.Call
+}
+
+// Update is a helper method to define mock.On call
+//   - ctx context.Context
+//   - persistentVolume *corev1.PersistentVolume
+//   - opts metav1.UpdateOptions
+func (_e *mockPvInterface_Expecter) Update(ctx interface{}, persistentVolume interface{}, opts interface{}) *mockPvInterface_Update_Call {
+	return &mockPvInterface_Update_Call{Call: _e.mock.On("Update", ctx, persistentVolume, opts)}
+}
+
+func (_c *mockPvInterface_Update_Call) Run(run func(ctx context.Context, persistentVolume *corev1.PersistentVolume, opts metav1.UpdateOptions)) *mockPvInterface_Update_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(*corev1.PersistentVolume), args[2].(metav1.UpdateOptions))
+	})
+	return _c
+}
+
+func (_c *mockPvInterface_Update_Call) Return(_a0 *corev1.PersistentVolume, _a1 error) *mockPvInterface_Update_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *mockPvInterface_Update_Call) RunAndReturn(run func(context.Context, *corev1.PersistentVolume, metav1.UpdateOptions) (*corev1.PersistentVolume, error)) *mockPvInterface_Update_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// UpdateStatus provides a mock function with given fields: ctx, persistentVolume, opts
+func (_m *mockPvInterface) UpdateStatus(ctx context.Context, persistentVolume *corev1.PersistentVolume, opts metav1.UpdateOptions) (*corev1.PersistentVolume, error) {
+	ret := _m.Called(ctx, persistentVolume, opts)
+
+	if len(ret) == 0 {
+		panic("no return value specified for UpdateStatus")
+	}
+
+	var r0 *corev1.PersistentVolume
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, *corev1.PersistentVolume, metav1.UpdateOptions) (*corev1.PersistentVolume, error)); ok {
+		return rf(ctx, persistentVolume, opts)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, *corev1.PersistentVolume, metav1.UpdateOptions) *corev1.PersistentVolume); ok {
+		r0 = rf(ctx, persistentVolume, opts)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*corev1.PersistentVolume)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, *corev1.PersistentVolume, metav1.UpdateOptions) error); ok {
+		r1 = rf(ctx, persistentVolume, opts)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// mockPvInterface_UpdateStatus_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'UpdateStatus'
+type mockPvInterface_UpdateStatus_Call struct {
+	*mock.Call
+}
+
+// UpdateStatus is a helper method to define mock.On call
+//   - ctx context.Context
+//   - persistentVolume *corev1.PersistentVolume
+//   - opts metav1.UpdateOptions
+func (_e *mockPvInterface_Expecter) UpdateStatus(ctx interface{}, persistentVolume interface{}, opts interface{}) *mockPvInterface_UpdateStatus_Call {
+	return &mockPvInterface_UpdateStatus_Call{Call: _e.mock.On("UpdateStatus", ctx, persistentVolume, opts)}
+}
+
+func (_c *mockPvInterface_UpdateStatus_Call) Run(run func(ctx context.Context, persistentVolume *corev1.PersistentVolume, opts metav1.UpdateOptions)) *mockPvInterface_UpdateStatus_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(*corev1.PersistentVolume), args[2].(metav1.UpdateOptions))
+	})
+	return _c
+}
+
+func (_c *mockPvInterface_UpdateStatus_Call) Return(_a0 *corev1.PersistentVolume, _a1 error) *mockPvInterface_UpdateStatus_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *mockPvInterface_UpdateStatus_Call) RunAndReturn(run func(context.Context, *corev1.PersistentVolume, metav1.UpdateOptions) (*corev1.PersistentVolume, error)) *mockPvInterface_UpdateStatus_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// Watch provides a mock function with given fields: ctx, opts
+func (_m *mockPvInterface) Watch(ctx context.Context, opts metav1.ListOptions) (watch.Interface, error) {
+	ret := _m.Called(ctx, opts)
+
+	if len(ret) == 0 {
+		panic("no return value specified for Watch")
+	}
+
+	var r0 watch.Interface
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, metav1.ListOptions) (watch.Interface, error)); ok {
+		return rf(ctx, opts)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, metav1.ListOptions) watch.Interface); ok {
+		r0 = rf(ctx, opts)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(watch.Interface)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, metav1.ListOptions) error); ok {
+		r1 = rf(ctx, opts)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// mockPvInterface_Watch_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'Watch'
+type mockPvInterface_Watch_Call struct {
+	*mock.Call
+}
+
+// Watch is a helper method to define mock.On call
+//   - ctx context.Context
+//   - opts metav1.ListOptions
+func (_e *mockPvInterface_Expecter) Watch(ctx interface{}, opts interface{}) *mockPvInterface_Watch_Call {
+	return &mockPvInterface_Watch_Call{Call: _e.mock.On("Watch", ctx, opts)}
+}
+
+func (_c *mockPvInterface_Watch_Call) Run(run func(ctx context.Context, opts metav1.ListOptions)) *mockPvInterface_Watch_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(metav1.ListOptions))
+	})
+	return _c
+}
+
+func (_c *mockPvInterface_Watch_Call) Return(_a0 watch.Interface, _a1 error) *mockPvInterface_Watch_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *mockPvInterface_Watch_Call) RunAndReturn(run func(context.Context, metav1.ListOptions) (watch.Interface, error)) *mockPvInterface_Watch_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// newMockPvInterface creates a new instance of mockPvInterface. It also registers a testing interface on the mock and a cleanup function to assert the mocks expectations.
+// The first argument is typically a *testing.T value.
+func newMockPvInterface(t interface {
+	mock.TestingT
+	Cleanup(func())
+}) *mockPvInterface {
+	mock := &mockPvInterface{}
+	mock.Mock.Test(t)
+
+	t.Cleanup(func() { mock.AssertExpectations(t) })
+
+	return mock
+}
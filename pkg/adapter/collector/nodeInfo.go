@@ -56,6 +56,26 @@ func (nic *NodeInfoCollector) Collect(ctx context.Context, _ string, start, end
 	return nil
 }
 
+const (
+	// nodeInfoHardwareMetrics is the number of metrics collected with the hardwareMetricStep.
+	nodeInfoHardwareMetrics = 5
+	// nodeInfoUsageMetrics is the number of metrics collected with the usageMetricStep.
+	nodeInfoUsageMetrics = 8
+)
+
+// Estimate calculates the number of samples per node from the steps and the timeframe. The number of nodes is
+// unknown without querying prometheus. Every metric needs at least one request.
+func (nic *NodeInfoCollector) Estimate(_ context.Context, _ string, start, end time.Time) (domain.CollectorEstimate, error) {
+	samples := nodeInfoHardwareMetrics*domain.SamplesPerSeries(start, end, nic.hardwareMetricStep) +
+		nodeInfoUsageMetrics*domain.SamplesPerSeries(start, end, nic.usageMetricStep)
+
+	return domain.CollectorEstimate{
+		Items:    samples,
+		Unit:     "samples per node",
+		Requests: nodeInfoHardwareMetrics + nodeInfoUsageMetrics,
+	}, nil
+}
+
 func (nic *NodeInfoCollector) getGeneralInfo(ctx context.Context, start time.Time, end time.Time, resultChan chan<- *domain.LabeledSample) error {
 	err := nic.metricsProvider.GetNodeNames(ctx, start, end, nic.hardwareMetricStep, resultChan)
 	if err != nil {
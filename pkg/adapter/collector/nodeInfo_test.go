@@ -474,3 +474,16 @@ func TestNodeInfoCollector_Collect(t *testing.T) {
 		})
 	}
 }
+
+func TestNodeInfoCollector_Estimate(t *testing.T) {
+	// given
+	start := time.Date(2025, 1, 1, 0, 0, 0, 0, time.UTC)
+	sut := NewNodeInfoCollector(nil, 30*time.Second, time.Hour)
+
+	// when
+	estimate, err := sut.Estimate(testCtx, testNamespace, start, start.Add(time.Hour))
+
+	// then
+	require.NoError(t, err)
+	assert.Equal(t, domain.CollectorEstimate{Items: 5*2 + 8*121, Unit: "samples per node", Requests: 13}, estimate)
+}
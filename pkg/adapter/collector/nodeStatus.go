@@ -0,0 +1,129 @@
+package collector
+
+import (
+	"cmp"
+	"context"
+	"fmt"
+	"slices"
+	"time"
+
+	"github.com/cloudogu/k8s-support-archive-operator/pkg/domain"
+	v1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"sigs.k8s.io/controller-runtime/pkg/log"
+)
+
+// NodeStatusCollector reads the node health directly from the Kubernetes API.
+// In contrast to the NodeInfoCollector it does not depend on a metrics service.
+type NodeStatusCollector struct {
+	coreV1Interface coreV1Interface
+}
+
+func NewNodeStatusCollector(coreV1Interface coreV1Interface) *NodeStatusCollector {
+	return &NodeStatusCollector{coreV1Interface: coreV1Interface}
+}
+
+func (nsc *NodeStatusCollector) Name() string {
+	return string(domain.CollectorTypeNodeStatus)
+}
+
+// Collect writes the status of every node with the pods of the namespace scheduled on it. Pods of other namespaces
+// are not collected, so that the archive of a namespace does not reveal the workloads of the cluster.
+func (nsc *NodeStatusCollector) Collect(ctx context.Context, namespace string, _, _ time.Time, resultChan chan<- *domain.NodeStatus) error {
+	defer close(resultChan)
+
+	logger := log.FromContext(ctx).WithName("NodeStatusCollector.Collect")
+	nodes, err := nsc.coreV1Interface.Nodes().List(ctx, metav1.ListOptions{})
+	if err != nil {
+		return fmt.Errorf("error listing nodes: %w", err)
+	}
+
+	if len(nodes.Items) == 0 {
+		logger.Info("Node list is empty")
+		return nil
+	}
+
+	pods, err := nsc.coreV1Interface.Pods(namespace).List(ctx, metav1.ListOptions{})
+	if err != nil {
+		return fmt.Errorf("error listing pods: %w", err)
+	}
+	podsByNode := groupPodsByNode(pods.Items)
+
+	for _, node := range nodes.Items {
+		writeSaveToChannel(ctx, toNodeStatus(node, podsByNode[node.Name]), resultChan)
+	}
+
+	return nil
+}
+
+func groupPodsByNode(pods []v1.Pod) map[string][]domain.NodePod {
+	podsByNode := make(map[string][]domain.NodePod)
+	for _, pod := range pods {
+		if pod.Spec.NodeName == "" {
+			continue
+		}
+
+		podsByNode[pod.Spec.NodeName] = append(podsByNode[pod.Spec.NodeName], domain.NodePod{
+			Namespace: pod.Namespace,
+			Name:      pod.Name,
+			Phase:     string(pod.Status.Phase),
+		})
+	}
+
+	for _, nodePods := range podsByNode {
+		slices.SortFunc(nodePods, func(a, b domain.NodePod) int {
+			return cmp.Or(cmp.Compare(a.Namespace, b.Namespace), cmp.Compare(a.Name, b.Name))
+		})
+	}
+
+	return podsByNode
+}
+
+func toNodeStatus(node v1.Node, pods []domain.NodePod) *domain.NodeStatus {
+	status := &domain.NodeStatus{
+		Name:                    node.Name,
+		KubeletVersion:          node.Status.NodeInfo.KubeletVersion,
+		KernelVersion:           node.Status.NodeInfo.KernelVersion,
+		ContainerRuntimeVersion: node.Status.NodeInfo.ContainerRuntimeVersion,
+		OSImage:                 node.Status.NodeInfo.OSImage,
+		Unschedulable:           node.Spec.Unschedulable,
+		Capacity:                resourceListToMap(node.Status.Capacity),
+		Allocatable:             resourceListToMap(node.Status.Allocatable),
+		PodCount:                len(pods),
+		Pods:                    pods,
+	}
+
+	for _, condition := range node.Status.Conditions {
+		status.Conditions = append(status.Conditions, domain.NodeStatusCondition{
+			Type:               string(condition.Type),
+			Status:             string(condition.Status),
+			Reason:             condition.Reason,
+			Message:            condition.Message,
+			LastHeartbeatTime:  condition.LastHeartbeatTime.Time,
+			LastTransitionTime: condition.LastTransitionTime.Time,
+		})
+	}
+
+	for _, taint := range node.Spec.Taints {
+		status.Taints = append(status.Taints, domain.NodeTaint{
+			Key:    taint.Key,
+			Value:  taint.Value,
+			Effect: string(taint.Effect),
+		})
+	}
+
+	return status
+}
+
+func resourceListToMap(resources v1.ResourceList) map[string]string {
+	if len(resources) == 0 {
+		return nil
+	}
+
+	result := make(map[string]string, len(resources))
+	for name, quantity := range resources {
+		result[string(name)] = quantity.String()
+	}
+
+	return result
+}
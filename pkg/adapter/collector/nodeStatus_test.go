@@ -0,0 +1,165 @@
+package collector
+
+import (
+	"testing"
+	"time"
+
+	"github.com/cloudogu/k8s-support-archive-operator/pkg/domain"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	v1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/resource"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+func TestNewNodeStatusCollector(t *testing.T) {
+	// given
+	coreV1Mock := newMockCoreV1Interface(t)
+
+	// when
+	sut := NewNodeStatusCollector(coreV1Mock)
+
+	// then
+	require.NotNil(t, sut)
+	assert.Equal(t, coreV1Mock, sut.coreV1Interface)
+}
+
+func TestNodeStatusCollector_Name(t *testing.T) {
+	// given
+	sut := &NodeStatusCollector{}
+
+	// when
+	name := sut.Name()
+
+	// then
+	assert.Equal(t, "NodeStatus", name)
+}
+
+func TestNodeStatusCollector_Collect(t *testing.T) {
+	heartbeat := time.Unix(1755693772, 0).UTC()
+	node := v1.Node{
+		ObjectMeta: metav1.ObjectMeta{Name: "node-1"},
+		Spec: v1.NodeSpec{
+			Taints: []v1.Taint{{Key: "node.kubernetes.io/disk-pressure", Effect: v1.TaintEffectNoSchedule}},
+		},
+		Status: v1.NodeStatus{
+			Capacity:    v1.ResourceList{v1.ResourceCPU: resource.MustParse("4"), v1.ResourcePods: resource.MustParse("110")},
+			Allocatable: v1.ResourceList{v1.ResourceCPU: resource.MustParse("3800m")},
+			Conditions: []v1.NodeCondition{
+				{Type: v1.NodeReady, Status: v1.ConditionTrue, Reason: "KubeletReady", LastHeartbeatTime: metav1.NewTime(heartbeat), LastTransitionTime: metav1.NewTime(heartbeat)},
+				{Type: v1.NodeDiskPressure, Status: v1.ConditionTrue, Reason: "KubeletHasDiskPressure", LastHeartbeatTime: metav1.NewTime(heartbeat), LastTransitionTime: metav1.NewTime(heartbeat)},
+			},
+			NodeInfo: v1.NodeSystemInfo{
+				KubeletVersion:          "v1.33.1",
+				KernelVersion:           "6.8.0",
+				ContainerRuntimeVersion: "containerd://2.0.5",
+				OSImage:                 "Ubuntu 24.04",
+			},
+		},
+	}
+	pods := &v1.PodList{Items: []v1.Pod{
+		{ObjectMeta: metav1.ObjectMeta{Namespace: "ecosystem", Name: "ldap-0"}, Spec: v1.PodSpec{NodeName: "node-1"}, Status: v1.PodStatus{Phase: v1.PodRunning}},
+		{ObjectMeta: metav1.ObjectMeta{Namespace: "ecosystem", Name: "cas-0"}, Spec: v1.PodSpec{NodeName: "node-1"}, Status: v1.PodStatus{Phase: v1.PodRunning}},
+		{ObjectMeta: metav1.ObjectMeta{Namespace: "ecosystem", Name: "pending"}, Status: v1.PodStatus{Phase: v1.PodPending}},
+	}}
+
+	t.Run("should return error on error listing nodes", func(t *testing.T) {
+		// given
+		nodeMock := newMockNodeInterface(t)
+		nodeMock.EXPECT().List(testCtx, metav1.ListOptions{}).Return(nil, assert.AnError)
+		coreV1Mock := newMockCoreV1Interface(t)
+		coreV1Mock.EXPECT().Nodes().Return(nodeMock)
+		resultChan := make(chan *domain.NodeStatus)
+		sut := &NodeStatusCollector{coreV1Interface: coreV1Mock}
+
+		// when
+		err := sut.Collect(testCtx, testNamespace, time.Time{}, time.Time{}, resultChan)
+
+		// then
+		require.Error(t, err)
+		assert.ErrorIs(t, err, assert.AnError)
+		assert.ErrorContains(t, err, "error listing nodes")
+		_, open := <-resultChan
+		assert.False(t, open)
+	})
+
+	t.Run("should close channel if no nodes exist", func(t *testing.T) {
+		// given
+		nodeMock := newMockNodeInterface(t)
+		nodeMock.EXPECT().List(testCtx, metav1.ListOptions{}).Return(&v1.NodeList{}, nil)
+		coreV1Mock := newMockCoreV1Interface(t)
+		coreV1Mock.EXPECT().Nodes().Return(nodeMock)
+		resultChan := make(chan *domain.NodeStatus)
+		sut := &NodeStatusCollector{coreV1Interface: coreV1Mock}
+
+		// when
+		err := sut.Collect(testCtx, testNamespace, time.Time{}, time.Time{}, resultChan)
+
+		// then
+		require.NoError(t, err)
+		_, open := <-resultChan
+		assert.False(t, open)
+	})
+
+	t.Run("should return error on error listing pods", func(t *testing.T) {
+		// given
+		nodeMock := newMockNodeInterface(t)
+		nodeMock.EXPECT().List(testCtx, metav1.ListOptions{}).Return(&v1.NodeList{Items: []v1.Node{node}}, nil)
+		podMock := newMockPodInterface(t)
+		podMock.EXPECT().List(testCtx, metav1.ListOptions{}).Return(nil, assert.AnError)
+		coreV1Mock := newMockCoreV1Interface(t)
+		coreV1Mock.EXPECT().Nodes().Return(nodeMock)
+		coreV1Mock.EXPECT().Pods(testNamespace).Return(podMock)
+		resultChan := make(chan *domain.NodeStatus)
+		sut := &NodeStatusCollector{coreV1Interface: coreV1Mock}
+
+		// when
+		err := sut.Collect(testCtx, testNamespace, time.Time{}, time.Time{}, resultChan)
+
+		// then
+		require.Error(t, err)
+		assert.ErrorIs(t, err, assert.AnError)
+		assert.ErrorContains(t, err, "error listing pods")
+	})
+
+	t.Run("should write node status to channel", func(t *testing.T) {
+		// given
+		nodeMock := newMockNodeInterface(t)
+		nodeMock.EXPECT().List(testCtx, metav1.ListOptions{}).Return(&v1.NodeList{Items: []v1.Node{node}}, nil)
+		podMock := newMockPodInterface(t)
+		podMock.EXPECT().List(testCtx, metav1.ListOptions{}).Return(pods, nil)
+		coreV1Mock := newMockCoreV1Interface(t)
+		coreV1Mock.EXPECT().Nodes().Return(nodeMock)
+		coreV1Mock.EXPECT().Pods(testNamespace).Return(podMock)
+		resultChan := make(chan *domain.NodeStatus, 1)
+		sut := &NodeStatusCollector{coreV1Interface: coreV1Mock}
+
+		// when
+		err := sut.Collect(testCtx, testNamespace, time.Time{}, time.Time{}, resultChan)
+
+		// then
+		require.NoError(t, err)
+		status := <-resultChan
+		assert.Equal(t, &domain.NodeStatus{
+			Name:                    "node-1",
+			KubeletVersion:          "v1.33.1",
+			KernelVersion:           "6.8.0",
+			ContainerRuntimeVersion: "containerd://2.0.5",
+			OSImage:                 "Ubuntu 24.04",
+			Conditions: []domain.NodeStatusCondition{
+				{Type: "Ready", Status: "True", Reason: "KubeletReady", LastHeartbeatTime: heartbeat, LastTransitionTime: heartbeat},
+				{Type: "DiskPressure", Status: "True", Reason: "KubeletHasDiskPressure", LastHeartbeatTime: heartbeat, LastTransitionTime: heartbeat},
+			},
+			Taints:      []domain.NodeTaint{{Key: "node.kubernetes.io/disk-pressure", Effect: "NoSchedule"}},
+			Capacity:    map[string]string{"cpu": "4", "pods": "110"},
+			Allocatable: map[string]string{"cpu": "3800m"},
+			PodCount:    2,
+			Pods: []domain.NodePod{
+				{Namespace: "ecosystem", Name: "cas-0", Phase: "Running"},
+				{Namespace: "ecosystem", Name: "ldap-0", Phase: "Running"},
+			},
+		}, status)
+		_, open := <-resultChan
+		assert.False(t, open)
+	})
+}
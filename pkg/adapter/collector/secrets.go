@@ -53,6 +53,21 @@ func (sc *SecretCollector) Collect(ctx context.Context, namespace string, _, _ t
 	return nil
 }
 
+// Estimate censors the secrets like in Collect and measures their size.
+func (sc *SecretCollector) Estimate(ctx context.Context, namespace string, _, _ time.Time) (domain.CollectorEstimate, error) {
+	list, err := sc.coreV1Interface.Secrets(namespace).List(ctx, metav1.ListOptions{LabelSelector: labelSelector})
+	if err != nil {
+		return domain.CollectorEstimate{}, fmt.Errorf("error listing secrets: %w", err)
+	}
+
+	estimate := domain.CollectorEstimate{Items: int64(len(list.Items)), Unit: "secrets", Requests: 1}
+	for _, secret := range list.Items {
+		estimate.Bytes += yamlSize(sc.censorSecret(secret))
+	}
+
+	return estimate, nil
+}
+
 func (sc *SecretCollector) censorSecret(secret v1.Secret) *domain.SecretYaml {
 	censored := &domain.SecretYaml{
 		ApiVersion: secret.APIVersion,
@@ -107,3 +122,14 @@ func censorYAMLNode(node *yaml.Node) {
 		censorYaml(n)
 	}
 }
+
+// yamlSize returns the size of the value encoded as yaml, which is about the size the repositories write.
+// Values which cannot be encoded count as empty.
+func yamlSize(value any) int64 {
+	encoded, err := yaml.Marshal(value)
+	if err != nil {
+		return 0
+	}
+
+	return int64(len(encoded))
+}
@@ -281,3 +281,30 @@ func createSecretInterfaceMock(t *testing.T, secrets []corev1.Secret, expectedEr
 
 	return interfaceMock
 }
+
+func TestSecretCollector_Estimate(t *testing.T) {
+	t.Run("should measure censored secrets", func(t *testing.T) {
+		// given
+		sut := NewSecretCollector(createSecretInterfaceMock(t, []corev1.Secret{secret, yamlSecret}, nil))
+
+		// when
+		estimate, err := sut.Estimate(testCtx, testNamespace, time.Time{}, time.Time{})
+
+		// then
+		require.NoError(t, err)
+		expectedBytes := yamlSize(sut.censorSecret(secret)) + yamlSize(sut.censorSecret(yamlSecret))
+		assert.Equal(t, domain.CollectorEstimate{Items: 2, Unit: "secrets", Bytes: expectedBytes, Requests: 1}, estimate)
+		assert.Positive(t, estimate.Bytes)
+	})
+	t.Run("should fail to list secrets", func(t *testing.T) {
+		// given
+		sut := NewSecretCollector(createSecretInterfaceMock(t, nil, assert.AnError))
+
+		// when
+		_, err := sut.Estimate(testCtx, testNamespace, time.Time{}, time.Time{})
+
+		// then
+		require.ErrorIs(t, err, assert.AnError)
+		assert.ErrorContains(t, err, "error listing secrets")
+	})
+}
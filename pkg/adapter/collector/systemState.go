@@ -1,11 +1,13 @@
 package collector
 
 import (
+	"cmp"
 	"context"
 	"errors"
 	"fmt"
 	"path/filepath"
 	"slices"
+	"strings"
 	"time"
 
 	"gopkg.in/yaml.v3"
@@ -70,62 +72,111 @@ func (rc *SystemStateCollector) Name() string {
 func (rc *SystemStateCollector) Collect(ctx context.Context, namespace string, _, _ time.Time, resultChan chan<- *domain.UnstructuredResource) error {
 	defer close(resultChan)
 
+	resources, _, err := rc.listResources(ctx, namespace)
+	if err != nil {
+		return err
+	}
+
+	for _, resource := range resources {
+		gvk := resource.GroupVersionKind()
+		group := gvk.Group
+		if group == "" {
+			group = coreGroup
+		}
+		resultChan <- &domain.UnstructuredResource{
+			Name:    resource.GetName(),
+			Path:    filepath.Join(group, gvk.Version, gvk.Kind),
+			Content: resource.Object,
+		}
+	}
+
+	return nil
+}
+
+// Estimate counts the resources matched by the label selector and measures their size. The resources are listed
+// like in Collect because the api server offers no way to count them.
+func (rc *SystemStateCollector) Estimate(ctx context.Context, namespace string, _, _ time.Time) (domain.CollectorEstimate, error) {
+	resources, requests, err := rc.listResources(ctx, namespace)
+	if err != nil {
+		return domain.CollectorEstimate{}, err
+	}
+
+	estimate := domain.CollectorEstimate{Items: int64(len(resources)), Unit: "resources", Requests: requests}
+	for _, resource := range resources {
+		estimate.Bytes += yamlSize(resource.Object)
+	}
+
+	return estimate, nil
+}
+
+// listResources lists all resources matched by the label selector sorted by kind and name.
+// It returns the resources and the number of requests.
+func (rc *SystemStateCollector) listResources(ctx context.Context, namespace string) ([]*unstructured.Unstructured, int, error) {
 	resourceKindLists, err := rc.discoveryClient.ServerPreferredResources()
 	if err != nil {
-		return fmt.Errorf("failed to get resource kind lists from server: %w", err)
+		return nil, 0, fmt.Errorf("failed to get resource kind lists from server: %w", err)
 	}
 
 	selector, err := metav1.LabelSelectorAsSelector(rc.resourceLabelSelector)
 	if err != nil {
-		return fmt.Errorf("failed to create selector from given label selector %s: %w", rc.resourceLabelSelector, err)
+		return nil, 0, fmt.Errorf("failed to create selector from given label selector %s: %w", rc.resourceLabelSelector, err)
 	}
 
 	var errs []error
 	var resources []*unstructured.Unstructured
+	requests := 1
 	for _, resourceKindList := range resourceKindLists {
-		resourcesOfKind, listErrs := rc.listApiResourcesByLabelSelector(ctx, namespace, resourceKindList, selector, rc.excludedGVKs)
+		resourcesOfKind, listRequests, listErrs := rc.listApiResourcesByLabelSelector(ctx, namespace, resourceKindList, selector, rc.excludedGVKs)
 		resources = append(resources, resourcesOfKind...)
+		requests += listRequests
 		errs = append(errs, listErrs...)
 	}
 
 	if len(errs) != 0 {
-		return fmt.Errorf("failed to list api resources with label selector %q: %w", selector, errors.Join(errs...))
+		return nil, 0, fmt.Errorf("failed to list api resources with label selector %q: %w", selector, errors.Join(errs...))
 	}
+	sortResources(resources)
 
-	for _, resource := range resources {
-		gvk := resource.GroupVersionKind()
-		group := gvk.Group
-		if group == "" {
-			group = coreGroup
-		}
-		resultChan <- &domain.UnstructuredResource{
-			Name:    resource.GetName(),
-			Path:    filepath.Join(group, gvk.Version, gvk.Kind),
-			Content: resource.Object,
-		}
-	}
+	return resources, requests, nil
+}
 
-	return nil
+// sortResources sorts the resources by group, version, kind, namespace and name, so that they are collected in the
+// same order regardless of the order of the discovery results.
+func sortResources(resources []*unstructured.Unstructured) {
+	slices.SortFunc(resources, func(a, b *unstructured.Unstructured) int {
+		aGVK, bGVK := a.GroupVersionKind(), b.GroupVersionKind()
+		return cmp.Or(
+			strings.Compare(aGVK.Group, bGVK.Group),
+			strings.Compare(aGVK.Version, bGVK.Version),
+			strings.Compare(aGVK.Kind, bGVK.Kind),
+			strings.Compare(a.GetNamespace(), b.GetNamespace()),
+			strings.Compare(a.GetName(), b.GetName()),
+		)
+	})
 }
 
-func (rc *SystemStateCollector) listApiResourcesByLabelSelector(ctx context.Context, namespace string, list *metav1.APIResourceList, selector labels.Selector, excludedGVKs []gvkMatcher) ([]*unstructured.Unstructured, []error) {
+func (rc *SystemStateCollector) listApiResourcesByLabelSelector(ctx context.Context, namespace string, list *metav1.APIResourceList, selector labels.Selector, excludedGVKs []gvkMatcher) ([]*unstructured.Unstructured, int, []error) {
 	if len(list.APIResources) == 0 {
-		return nil, nil
+		return nil, 0, nil
 	}
 
 	gv, err := schema.ParseGroupVersion(list.GroupVersion)
 	if err != nil {
-		return nil, []error{fmt.Errorf("failed to list api resources with group version %q: %w", list.GroupVersion, err)}
+		return nil, 0, []error{fmt.Errorf("failed to list api resources with group version %q: %w", list.GroupVersion, err)}
 	}
 
 	var errs []error
 	var resources []*unstructured.Unstructured
+	var requests int
 	for _, resource := range list.APIResources {
 		if len(resource.Verbs) != 0 && slices.Contains(resource.Verbs, listVerb) {
 			resource.Group = gv.Group
 			resource.Version = gv.Version
 
-			resourcesByLabelSelector, listErr := rc.listByLabelSelector(ctx, namespace, resource, selector, excludedGVKs)
+			resourcesByLabelSelector, requested, listErr := rc.listByLabelSelector(ctx, namespace, resource, selector, excludedGVKs)
+			if requested {
+				requests++
+			}
 			if listErr != nil {
 				errs = append(errs, listErr)
 			} else {
@@ -134,17 +185,18 @@ func (rc *SystemStateCollector) listApiResourcesByLabelSelector(ctx context.Cont
 		}
 	}
 
-	return resources, errs
+	return resources, requests, errs
 }
 
-func (rc *SystemStateCollector) listByLabelSelector(ctx context.Context, namespace string, resource metav1.APIResource, labelSelector labels.Selector, excludedGVKs []gvkMatcher) ([]*unstructured.Unstructured, error) {
+// listByLabelSelector lists the resources of the kind. It returns false if the kind is excluded and was not requested.
+func (rc *SystemStateCollector) listByLabelSelector(ctx context.Context, namespace string, resource metav1.APIResource, labelSelector labels.Selector, excludedGVKs []gvkMatcher) ([]*unstructured.Unstructured, bool, error) {
 	logger := log.FromContext(ctx)
 
 	gvk := groupVersionKind(resource)
 	for _, matcher := range excludedGVKs {
 		if matcher.Matches(gvk) {
 			logger.Info(fmt.Sprintf("skipping resource %s as it is excluded", gvk))
-			return nil, nil
+			return nil, false, nil
 		}
 	}
 	listOptions := client.ListOptions{LabelSelector: &client.MatchingLabelsSelector{Selector: labelSelector}}
@@ -156,10 +208,10 @@ func (rc *SystemStateCollector) listByLabelSelector(ctx context.Context, namespa
 	objectList.SetGroupVersionKind(gvk)
 	err := rc.client.List(ctx, objectList, &listOptions)
 	if err != nil {
-		return nil, fmt.Errorf("failed to list objects in %s: %w", gvk, err)
+		return nil, true, fmt.Errorf("failed to list objects in %s: %w", gvk, err)
 	}
 
-	return sliceToPointers(objectList.Items), nil
+	return sliceToPointers(objectList.Items), true, nil
 }
 
 func groupVersionKind(resource metav1.APIResource) schema.GroupVersionKind {
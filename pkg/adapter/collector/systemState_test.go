@@ -2,6 +2,7 @@ package collector
 
 import (
 	"context"
+	"fmt"
 	"testing"
 	"time"
 
@@ -409,3 +410,91 @@ func Test_resourceCollector_Collect(t *testing.T) {
 		})
 	}
 }
+
+func TestSystemStateCollector_Estimate(t *testing.T) {
+	t.Run("should count and measure matched resources and list requests", func(t *testing.T) {
+		// given
+		clientMock := newMockK8sClient(t)
+		clientMock.EXPECT().List(
+			testCtx,
+			&unstructured.UnstructuredList{Object: map[string]interface{}{"apiVersion": "v1", "kind": "Pod"}},
+			mock.Anything,
+		).RunAndReturn(func(ctx context.Context, list client.ObjectList, option ...client.ListOption) error {
+			list.(*unstructured.UnstructuredList).Items = []unstructured.Unstructured{
+				{Object: map[string]interface{}{"kind": "Pod"}},
+				{Object: map[string]interface{}{"kind": "Pod"}},
+				{Object: map[string]interface{}{"kind": "Pod"}},
+			}
+			return nil
+		})
+		discoveryMock := newMockDiscoveryInterface(t)
+		discoveryMock.EXPECT().ServerPreferredResources().Return(
+			[]*metav1.APIResourceList{
+				{GroupVersion: "v1", APIResources: []metav1.APIResource{{Verbs: []string{"get", "list"}, Version: "v1", Kind: "Secret"}}},
+				{GroupVersion: "v1", APIResources: []metav1.APIResource{{Verbs: []string{"get", "list"}, Version: "v1", Kind: "Pod"}}},
+			}, nil)
+		sut := &SystemStateCollector{
+			client:                clientMock,
+			discoveryClient:       discoveryMock,
+			resourceLabelSelector: &metav1.LabelSelector{MatchLabels: map[string]string{"app": "ces"}},
+			excludedGVKs:          []gvkMatcher{{Version: "v1", Kind: "Secret"}},
+		}
+
+		// when
+		estimate, err := sut.Estimate(testCtx, testNamespace, time.Now(), time.Now())
+
+		// then
+		require.NoError(t, err)
+		// Every resource is encoded as "kind: Pod\n".
+		assert.Equal(t, domain.CollectorEstimate{Items: 3, Unit: "resources", Bytes: 30, Requests: 2}, estimate)
+	})
+	t.Run("should fail to get resource kinds", func(t *testing.T) {
+		// given
+		discoveryMock := newMockDiscoveryInterface(t)
+		discoveryMock.EXPECT().ServerPreferredResources().Return(nil, assert.AnError)
+		sut := &SystemStateCollector{discoveryClient: discoveryMock}
+
+		// when
+		_, err := sut.Estimate(testCtx, testNamespace, time.Now(), time.Now())
+
+		// then
+		require.ErrorIs(t, err, assert.AnError)
+	})
+}
+
+func Test_sortResources(t *testing.T) {
+	// given
+	newResource := func(apiVersion, kind, namespace, name string) *unstructured.Unstructured {
+		resource := &unstructured.Unstructured{}
+		resource.SetAPIVersion(apiVersion)
+		resource.SetKind(kind)
+		resource.SetNamespace(namespace)
+		resource.SetName(name)
+		return resource
+	}
+	resources := []*unstructured.Unstructured{
+		newResource("k8s.cloudogu.com/v2", "Dogu", testNamespace, "cas"),
+		newResource("v1", "Pod", testNamespace, "ldap"),
+		newResource("apps/v1", "Deployment", testNamespace, "cas"),
+		newResource("v1", "Pod", "", "cluster"),
+		newResource("v1", "ConfigMap", testNamespace, "config"),
+		newResource("v1", "Pod", testNamespace, "cas"),
+	}
+
+	// when
+	sortResources(resources)
+
+	// then
+	var got []string
+	for _, resource := range resources {
+		got = append(got, fmt.Sprintf("%s %s %s/%s", resource.GetAPIVersion(), resource.GetKind(), resource.GetNamespace(), resource.GetName()))
+	}
+	assert.Equal(t, []string{
+		"v1 ConfigMap test/config",
+		"v1 Pod /cluster",
+		"v1 Pod test/cas",
+		"v1 Pod test/ldap",
+		"apps/v1 Deployment test/cas",
+		"k8s.cloudogu.com/v2 Dogu test/cas",
+	}, got)
+}
@@ -3,29 +3,38 @@ package collector
 import (
 	"context"
 	"fmt"
+	"slices"
 	"strconv"
 	"time"
 
 	"github.com/cloudogu/k8s-support-archive-operator/pkg/domain"
+	v1 "k8s.io/api/core/v1"
+	k8sErrs "k8s.io/apimachinery/pkg/api/errors"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"sigs.k8s.io/controller-runtime/pkg/log"
 )
 
 const pvcVolumeMetricName = "persistentVolumeClaims"
 
+// volumeUsageThresholds contains the usage thresholds in percent whose crossings are recorded.
+var volumeUsageThresholds = []int{80, 95}
+
 type VolumesCollector struct {
 	coreV1Interface coreV1Interface
 	metricsProvider metricsProvider
+	// metricStep defines the resolution of the volume usage time series.
+	metricStep time.Duration
 }
 
-func NewVolumesCollector(coreV1Interface coreV1Interface, provider metricsProvider) *VolumesCollector {
-	return &VolumesCollector{coreV1Interface: coreV1Interface, metricsProvider: provider}
+func NewVolumesCollector(coreV1Interface coreV1Interface, provider metricsProvider, metricStep time.Duration) *VolumesCollector {
+	return &VolumesCollector{coreV1Interface: coreV1Interface, metricsProvider: provider, metricStep: metricStep}
 }
 
 func (vc *VolumesCollector) Name() string {
 	return string(domain.CollectorTypeVolumeInfo)
 }
 
-func (vc *VolumesCollector) Collect(ctx context.Context, namespace string, _, end time.Time, resultChan chan<- *domain.VolumeInfo) error {
+func (vc *VolumesCollector) Collect(ctx context.Context, namespace string, start, end time.Time, resultChan chan<- *domain.VolumeInfo) error {
 	defer close(resultChan)
 
 	list, err := vc.coreV1Interface.PersistentVolumeClaims(namespace).List(ctx, metav1.ListOptions{})
@@ -40,7 +49,7 @@ func (vc *VolumesCollector) Collect(ctx context.Context, namespace string, _, en
 	result := &domain.VolumeInfo{Name: pvcVolumeMetricName, Timestamp: end, Items: make([]domain.VolumeInfoItem, 0, len(list.Items))}
 
 	for _, pvc := range list.Items {
-		i, itemErr := vc.getOutputItem(ctx, pvc.Name, namespace, string(pvc.Status.Phase), end)
+		i, itemErr := vc.getOutputItem(ctx, pvc, namespace, start, end)
 		if itemErr != nil {
 			return fmt.Errorf("error getting output item for pvc %s: %w", pvc.Name, itemErr)
 		}
@@ -51,27 +60,154 @@ func (vc *VolumesCollector) Collect(ctx context.Context, namespace string, _, en
 	return nil
 }
 
-func (vc *VolumesCollector) getOutputItem(ctx context.Context, pvcName, namespace, phase string, timestamp time.Time) (domain.VolumeInfoItem, error) {
-	capacityBytes, err := vc.metricsProvider.GetCapacityBytesForPVC(ctx, namespace, pvcName, timestamp)
+// volumeMetrics is the number of metrics collected per volume claim.
+const volumeMetrics = 3
+
+// Estimate calculates the number of samples from the number of volume claims, the step and the timeframe.
+func (vc *VolumesCollector) Estimate(ctx context.Context, namespace string, start, end time.Time) (domain.CollectorEstimate, error) {
+	list, err := vc.coreV1Interface.PersistentVolumeClaims(namespace).List(ctx, metav1.ListOptions{})
+	if err != nil {
+		return domain.CollectorEstimate{}, fmt.Errorf("error listing pvcs: %w", err)
+	}
+
+	series := int64(volumeMetrics * len(list.Items))
+	return domain.CollectorEstimate{
+		Items:    series * domain.SamplesPerSeries(start, end, vc.metricStep),
+		Unit:     "samples",
+		Requests: 1 + int(series),
+	}, nil
+}
+
+func (vc *VolumesCollector) getOutputItem(ctx context.Context, pvc v1.PersistentVolumeClaim, namespace string, start, end time.Time) (domain.VolumeInfoItem, error) {
+	capacitySamples, err := vc.metricsProvider.GetCapacityBytesForPVC(ctx, namespace, pvc.Name, start, end, vc.metricStep)
 	if err != nil {
 		return domain.VolumeInfoItem{}, fmt.Errorf("failed to get capacity bytes: %w", err)
 	}
 
-	usedBytes, err := vc.metricsProvider.GetUsedBytesForPVC(ctx, namespace, pvcName, timestamp)
+	usedSamples, err := vc.metricsProvider.GetUsedBytesForPVC(ctx, namespace, pvc.Name, start, end, vc.metricStep)
 	if err != nil {
 		return domain.VolumeInfoItem{}, fmt.Errorf("failed to get used bytes: %w", err)
 	}
 
-	var usagePercentage string
-	if capacityBytes != 0 {
-		usagePercentage = strconv.FormatFloat(float64(usedBytes)/float64(capacityBytes)*100, 'f', 2, 64)
+	inodeSamples, err := vc.metricsProvider.GetUsedInodesForPVC(ctx, namespace, pvc.Name, start, end, vc.metricStep)
+	if err != nil {
+		return domain.VolumeInfoItem{}, fmt.Errorf("failed to get used inodes: %w", err)
 	}
 
-	return domain.VolumeInfoItem{
-		Name:            pvcName,
-		Capacity:        capacityBytes,
-		Used:            usedBytes,
-		PercentageUsage: usagePercentage,
-		Phase:           phase,
-	}, nil
+	storageClass, accessModes := vc.getStorageClassAndAccessModes(ctx, pvc)
+
+	item := domain.VolumeInfoItem{
+		Name:         pvc.Name,
+		Phase:        string(pvc.Status.Phase),
+		StorageClass: storageClass,
+		AccessModes:  accessModes,
+		Usage:        mergeVolumeUsageSamples(capacitySamples, usedSamples, inodeSamples),
+	}
+
+	if len(item.Usage) != 0 {
+		latest := item.Usage[len(item.Usage)-1]
+		item.Capacity = latest.Capacity
+		item.Used = latest.Used
+		item.InodesUsed = latest.InodesUsed
+		if latest.Capacity != 0 {
+			item.PercentageUsage = strconv.FormatFloat(latest.PercentageUsage(), 'f', 2, 64)
+		}
+	}
+	item.ThresholdCrossings = getThresholdCrossings(item.Usage, volumeUsageThresholds)
+
+	return item, nil
+}
+
+// getStorageClassAndAccessModes prefers the values of the bound persistent volume and falls back to the values of the claim.
+func (vc *VolumesCollector) getStorageClassAndAccessModes(ctx context.Context, pvc v1.PersistentVolumeClaim) (string, []string) {
+	logger := log.FromContext(ctx).WithName("VolumesCollector.getStorageClassAndAccessModes")
+
+	var storageClass string
+	if pvc.Spec.StorageClassName != nil {
+		storageClass = *pvc.Spec.StorageClassName
+	}
+	accessModes := pvc.Status.AccessModes
+	if len(accessModes) == 0 {
+		accessModes = pvc.Spec.AccessModes
+	}
+
+	if pvc.Spec.VolumeName != "" {
+		pv, err := vc.coreV1Interface.PersistentVolumes().Get(ctx, pvc.Spec.VolumeName, metav1.GetOptions{})
+		if err != nil && !k8sErrs.IsNotFound(err) {
+			logger.Error(err, "failed to get persistent volume, using values of the claim", "pv", pvc.Spec.VolumeName)
+		} else if err == nil {
+			storageClass = pv.Spec.StorageClassName
+			accessModes = pv.Spec.AccessModes
+		}
+	}
+
+	modes := make([]string, 0, len(accessModes))
+	for _, mode := range accessModes {
+		modes = append(modes, string(mode))
+	}
+
+	return storageClass, modes
+}
+
+// mergeVolumeUsageSamples combines the sample series of capacity, used bytes and used inodes by their timestamp.
+// A claim can have several series, e.g. after its pod moved to another node or if the kubelet is scraped twice, so the
+// maximum of all series is used for every timestamp independent of the order of the series.
+func mergeVolumeUsageSamples(capacitySamples, usedSamples, inodeSamples []domain.LabeledSample) []domain.VolumeUsageSample {
+	byTime := make(map[time.Time]*domain.VolumeUsageSample)
+	getSample := func(ts time.Time) *domain.VolumeUsageSample {
+		sample, ok := byTime[ts]
+		if !ok {
+			sample = &domain.VolumeUsageSample{Time: ts}
+			byTime[ts] = sample
+		}
+		return sample
+	}
+
+	for _, s := range capacitySamples {
+		sample := getSample(s.Time)
+		sample.Capacity = max(sample.Capacity, int64(s.Value))
+	}
+	for _, s := range usedSamples {
+		sample := getSample(s.Time)
+		sample.Used = max(sample.Used, int64(s.Value))
+	}
+	for _, s := range inodeSamples {
+		sample := getSample(s.Time)
+		sample.InodesUsed = max(sample.InodesUsed, int64(s.Value))
+	}
+
+	result := make([]domain.VolumeUsageSample, 0, len(byTime))
+	for _, sample := range byTime {
+		result = append(result, *sample)
+	}
+	slices.SortFunc(result, func(a, b domain.VolumeUsageSample) int {
+		return a.Time.Compare(b.Time)
+	})
+
+	return result
+}
+
+// getThresholdCrossings returns every point in time the usage rose from below to at least one of the thresholds.
+func getThresholdCrossings(usage []domain.VolumeUsageSample, thresholds []int) []domain.VolumeThresholdCrossing {
+	var crossings []domain.VolumeThresholdCrossing
+	for _, threshold := range thresholds {
+		above := false
+		for _, sample := range usage {
+			if sample.Capacity == 0 {
+				continue
+			}
+
+			isAbove := sample.PercentageUsage() >= float64(threshold)
+			if isAbove && !above {
+				crossings = append(crossings, domain.VolumeThresholdCrossing{ThresholdPercent: threshold, Time: sample.Time})
+			}
+			above = isAbove
+		}
+	}
+
+	slices.SortStableFunc(crossings, func(a, b domain.VolumeThresholdCrossing) int {
+		return a.Time.Compare(b.Time)
+	})
+
+	return crossings
 }
@@ -2,6 +2,7 @@ package collector
 
 import (
 	"context"
+	"slices"
 	"testing"
 	"time"
 
@@ -33,7 +34,37 @@ func TestVolumesCollector_Collect(t *testing.T) {
 		},
 	}
 	pvcList := &v1.PersistentVolumeClaimList{Items: []v1.PersistentVolumeClaim{pvc}}
+	storageClass := "longhorn"
+	boundPvc := v1.PersistentVolumeClaim{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      testPvcName,
+			Namespace: testNamespace,
+		},
+		Spec: v1.PersistentVolumeClaimSpec{
+			StorageClassName: &storageClass,
+			AccessModes:      []v1.PersistentVolumeAccessMode{v1.ReadWriteOnce},
+			VolumeName:       "test-pv",
+		},
+		Status: v1.PersistentVolumeClaimStatus{
+			Phase: v1.ClaimBound,
+		},
+	}
+	boundPvcList := &v1.PersistentVolumeClaimList{Items: []v1.PersistentVolumeClaim{boundPvc}}
+	pv := &v1.PersistentVolume{
+		ObjectMeta: metav1.ObjectMeta{Name: "test-pv"},
+		Spec: v1.PersistentVolumeSpec{
+			StorageClassName: "longhorn-static",
+			AccessModes:      []v1.PersistentVolumeAccessMode{v1.ReadWriteMany},
+		},
+	}
 	now := time.Now()
+	start := now.Add(-3 * time.Minute)
+	t1 := start.Add(time.Minute)
+	t2 := start.Add(2 * time.Minute)
+	step := time.Minute
+	capacitySamples := []domain.LabeledSample{{ID: testPvcName, Value: 100, Time: t1}, {ID: testPvcName, Value: 100, Time: t2}, {ID: testPvcName, Value: 100, Time: now}}
+	usedSamples := []domain.LabeledSample{{ID: testPvcName, Value: 85, Time: t1}, {ID: testPvcName, Value: 96, Time: t2}, {ID: testPvcName, Value: 50, Time: now}}
+	inodeSamples := []domain.LabeledSample{{ID: testPvcName, Value: 10, Time: t1}, {ID: testPvcName, Value: 11, Time: t2}, {ID: testPvcName, Value: 12, Time: now}}
 
 	type fields struct {
 		coreV1Interface func(t *testing.T) coreV1Interface
@@ -125,14 +156,14 @@ func TestVolumesCollector_Collect(t *testing.T) {
 				},
 				metricsProvider: func(t *testing.T) metricsProvider {
 					metricsMock := newMockMetricsProvider(t)
-					metricsMock.EXPECT().GetCapacityBytesForPVC(testCtx, testNamespace, testPvcName, now).Return(0, assert.AnError)
+					metricsMock.EXPECT().GetCapacityBytesForPVC(testCtx, testNamespace, testPvcName, start, now, step).Return(nil, assert.AnError)
 					return metricsMock
 				},
 			},
 			args: args{
 				ctx:        testCtx,
 				namespace:  testNamespace,
-				start:      time.Time{},
+				start:      start,
 				end:        now,
 				resultChan: make(chan *domain.VolumeInfo),
 			},
@@ -156,15 +187,15 @@ func TestVolumesCollector_Collect(t *testing.T) {
 				},
 				metricsProvider: func(t *testing.T) metricsProvider {
 					metricsMock := newMockMetricsProvider(t)
-					metricsMock.EXPECT().GetCapacityBytesForPVC(testCtx, testNamespace, testPvcName, now).Return(1, nil)
-					metricsMock.EXPECT().GetUsedBytesForPVC(testCtx, testNamespace, testPvcName, now).Return(0, assert.AnError)
+					metricsMock.EXPECT().GetCapacityBytesForPVC(testCtx, testNamespace, testPvcName, start, now, step).Return(capacitySamples, nil)
+					metricsMock.EXPECT().GetUsedBytesForPVC(testCtx, testNamespace, testPvcName, start, now, step).Return(nil, assert.AnError)
 					return metricsMock
 				},
 			},
 			args: args{
 				ctx:        testCtx,
 				namespace:  testNamespace,
-				start:      time.Time{},
+				start:      start,
 				end:        now,
 				resultChan: make(chan *domain.VolumeInfo),
 			},
@@ -174,6 +205,39 @@ func TestVolumesCollector_Collect(t *testing.T) {
 				assert.ErrorContains(t, err, "error getting output item for pvc test-pvc: failed to get used bytes")
 			},
 		},
+		{
+			name: "should return error on error getting used inodes",
+			fields: fields{
+				coreV1Interface: func(t *testing.T) coreV1Interface {
+					clientMock := newMockPvcInterface(t)
+					clientMock.EXPECT().List(testCtx, metav1.ListOptions{}).Return(pvcList, nil)
+
+					interfaceMock := newMockCoreV1Interface(t)
+					interfaceMock.EXPECT().PersistentVolumeClaims(testNamespace).Return(clientMock)
+
+					return interfaceMock
+				},
+				metricsProvider: func(t *testing.T) metricsProvider {
+					metricsMock := newMockMetricsProvider(t)
+					metricsMock.EXPECT().GetCapacityBytesForPVC(testCtx, testNamespace, testPvcName, start, now, step).Return(capacitySamples, nil)
+					metricsMock.EXPECT().GetUsedBytesForPVC(testCtx, testNamespace, testPvcName, start, now, step).Return(usedSamples, nil)
+					metricsMock.EXPECT().GetUsedInodesForPVC(testCtx, testNamespace, testPvcName, start, now, step).Return(nil, assert.AnError)
+					return metricsMock
+				},
+			},
+			args: args{
+				ctx:        testCtx,
+				namespace:  testNamespace,
+				start:      start,
+				end:        now,
+				resultChan: make(chan *domain.VolumeInfo),
+			},
+			wantErr: func(t *testing.T, err error) {
+				require.Error(t, err)
+				assert.ErrorIs(t, err, assert.AnError)
+				assert.ErrorContains(t, err, "error getting output item for pvc test-pvc: failed to get used inodes")
+			},
+		},
 		{
 			name: "should write to channel and close",
 			fields: fields{
@@ -188,15 +252,16 @@ func TestVolumesCollector_Collect(t *testing.T) {
 				},
 				metricsProvider: func(t *testing.T) metricsProvider {
 					metricsMock := newMockMetricsProvider(t)
-					metricsMock.EXPECT().GetCapacityBytesForPVC(testCtx, testNamespace, testPvcName, now).Return(2, nil)
-					metricsMock.EXPECT().GetUsedBytesForPVC(testCtx, testNamespace, testPvcName, now).Return(1, nil)
+					metricsMock.EXPECT().GetCapacityBytesForPVC(testCtx, testNamespace, testPvcName, start, now, step).Return(capacitySamples, nil)
+					metricsMock.EXPECT().GetUsedBytesForPVC(testCtx, testNamespace, testPvcName, start, now, step).Return(usedSamples, nil)
+					metricsMock.EXPECT().GetUsedInodesForPVC(testCtx, testNamespace, testPvcName, start, now, step).Return(inodeSamples, nil)
 					return metricsMock
 				},
 			},
 			args: args{
 				ctx:        testCtx,
 				namespace:  testNamespace,
-				start:      time.Time{},
+				start:      start,
 				end:        now,
 				resultChan: make(chan *domain.VolumeInfo),
 			},
@@ -209,10 +274,117 @@ func TestVolumesCollector_Collect(t *testing.T) {
 				Items: []domain.VolumeInfoItem{
 					{
 						Name:            testPvcName,
-						Capacity:        2,
-						Used:            1,
+						Capacity:        100,
+						Used:            50,
 						PercentageUsage: "50.00",
+						InodesUsed:      12,
 						Phase:           "Bound",
+						AccessModes:     []string{},
+						ThresholdCrossings: []domain.VolumeThresholdCrossing{
+							{ThresholdPercent: 80, Time: t1},
+							{ThresholdPercent: 95, Time: t2},
+						},
+						Usage: []domain.VolumeUsageSample{
+							{Time: t1, Capacity: 100, Used: 85, InodesUsed: 10},
+							{Time: t2, Capacity: 100, Used: 96, InodesUsed: 11},
+							{Time: now, Capacity: 100, Used: 50, InodesUsed: 12},
+						},
+					},
+				},
+			},
+		},
+		{
+			name: "should use storage class and access modes of the bound persistent volume",
+			fields: fields{
+				coreV1Interface: func(t *testing.T) coreV1Interface {
+					clientMock := newMockPvcInterface(t)
+					clientMock.EXPECT().List(testCtx, metav1.ListOptions{}).Return(boundPvcList, nil)
+
+					pvMock := newMockPvInterface(t)
+					pvMock.EXPECT().Get(testCtx, "test-pv", metav1.GetOptions{}).Return(pv, nil)
+
+					interfaceMock := newMockCoreV1Interface(t)
+					interfaceMock.EXPECT().PersistentVolumeClaims(testNamespace).Return(clientMock)
+					interfaceMock.EXPECT().PersistentVolumes().Return(pvMock)
+
+					return interfaceMock
+				},
+				metricsProvider: func(t *testing.T) metricsProvider {
+					metricsMock := newMockMetricsProvider(t)
+					metricsMock.EXPECT().GetCapacityBytesForPVC(testCtx, testNamespace, testPvcName, start, now, step).Return(nil, nil)
+					metricsMock.EXPECT().GetUsedBytesForPVC(testCtx, testNamespace, testPvcName, start, now, step).Return(nil, nil)
+					metricsMock.EXPECT().GetUsedInodesForPVC(testCtx, testNamespace, testPvcName, start, now, step).Return(nil, nil)
+					return metricsMock
+				},
+			},
+			args: args{
+				ctx:        testCtx,
+				namespace:  testNamespace,
+				start:      start,
+				end:        now,
+				resultChan: make(chan *domain.VolumeInfo),
+			},
+			wantErr: func(t *testing.T, err error) {
+				require.NoError(t, err)
+			},
+			wantData: &domain.VolumeInfo{
+				Name:      "persistentVolumeClaims",
+				Timestamp: now,
+				Items: []domain.VolumeInfoItem{
+					{
+						Name:         testPvcName,
+						Phase:        "Bound",
+						StorageClass: "longhorn-static",
+						AccessModes:  []string{"ReadWriteMany"},
+						Usage:        []domain.VolumeUsageSample{},
+					},
+				},
+			},
+		},
+		{
+			name: "should fall back to the claim if the persistent volume cannot be fetched",
+			fields: fields{
+				coreV1Interface: func(t *testing.T) coreV1Interface {
+					clientMock := newMockPvcInterface(t)
+					clientMock.EXPECT().List(testCtx, metav1.ListOptions{}).Return(boundPvcList, nil)
+
+					pvMock := newMockPvInterface(t)
+					pvMock.EXPECT().Get(testCtx, "test-pv", metav1.GetOptions{}).Return(nil, assert.AnError)
+
+					interfaceMock := newMockCoreV1Interface(t)
+					interfaceMock.EXPECT().PersistentVolumeClaims(testNamespace).Return(clientMock)
+					interfaceMock.EXPECT().PersistentVolumes().Return(pvMock)
+
+					return interfaceMock
+				},
+				metricsProvider: func(t *testing.T) metricsProvider {
+					metricsMock := newMockMetricsProvider(t)
+					metricsMock.EXPECT().GetCapacityBytesForPVC(testCtx, testNamespace, testPvcName, start, now, step).Return(nil, nil)
+					metricsMock.EXPECT().GetUsedBytesForPVC(testCtx, testNamespace, testPvcName, start, now, step).Return(nil, nil)
+					metricsMock.EXPECT().GetUsedInodesForPVC(testCtx, testNamespace, testPvcName, start, now, step).Return(nil, nil)
+					return metricsMock
+				},
+			},
+			args: args{
+				ctx:        testCtx,
+				namespace:  testNamespace,
+				start:      start,
+				end:        now,
+				resultChan: make(chan *domain.VolumeInfo),
+			},
+			wantErr: func(t *testing.T, err error) {
+				require.NoError(t, err)
+			},
+			wantData: &domain.VolumeInfo{
+				Name:      "persistentVolumeClaims",
+				Timestamp: now,
+				Items: []domain.VolumeInfoItem{
+					{
+						Name:         testPvcName,
+						Phase:        "Bound",
+						StorageClass: "longhorn",
+						AccessModes:  []string{"ReadWriteOnce"},
+						Usage:        []domain.VolumeUsageSample{},
 					},
 				},
 			},
@@ -223,6 +395,7 @@ func TestVolumesCollector_Collect(t *testing.T) {
 			vc := &VolumesCollector{
 				coreV1Interface: tt.fields.coreV1Interface(t),
 				metricsProvider: tt.fields.metricsProvider(t),
+				metricStep:      step,
 			}
 
 			group, _ := errgroup.WithContext(tt.args.ctx)
@@ -277,10 +450,76 @@ func TestNewVolumesCollector(t *testing.T) {
 	providerMock := newMockMetricsProvider(t)
 
 	// when
-	collector := NewVolumesCollector(corev1Mock, providerMock)
+	collector := NewVolumesCollector(corev1Mock, providerMock, time.Minute)
 
 	// then
 	require.NotNil(t, collector)
 	assert.Equal(t, corev1Mock, collector.coreV1Interface)
 	assert.Equal(t, providerMock, collector.metricsProvider)
+	assert.Equal(t, time.Minute, collector.metricStep)
+}
+
+func TestVolumesCollector_Estimate(t *testing.T) {
+	start := time.Date(2025, 1, 1, 0, 0, 0, 0, time.UTC)
+
+	t.Run("should calculate samples of all volume claims", func(t *testing.T) {
+		// given
+		clientMock := newMockPvcInterface(t)
+		clientMock.EXPECT().List(testCtx, metav1.ListOptions{}).Return(&v1.PersistentVolumeClaimList{Items: make([]v1.PersistentVolumeClaim, 2)}, nil)
+		interfaceMock := newMockCoreV1Interface(t)
+		interfaceMock.EXPECT().PersistentVolumeClaims(testNamespace).Return(clientMock)
+		sut := NewVolumesCollector(interfaceMock, nil, time.Minute)
+
+		// when
+		estimate, err := sut.Estimate(testCtx, testNamespace, start, start.Add(time.Hour))
+
+		// then
+		require.NoError(t, err)
+		assert.Equal(t, domain.CollectorEstimate{Items: 6 * 61, Unit: "samples", Requests: 7}, estimate)
+	})
+	t.Run("should fail to list volume claims", func(t *testing.T) {
+		// given
+		clientMock := newMockPvcInterface(t)
+		clientMock.EXPECT().List(testCtx, metav1.ListOptions{}).Return(nil, assert.AnError)
+		interfaceMock := newMockCoreV1Interface(t)
+		interfaceMock.EXPECT().PersistentVolumeClaims(testNamespace).Return(clientMock)
+		sut := NewVolumesCollector(interfaceMock, nil, time.Minute)
+
+		// when
+		_, err := sut.Estimate(testCtx, testNamespace, start, start.Add(time.Hour))
+
+		// then
+		require.ErrorIs(t, err, assert.AnError)
+	})
+}
+
+func Test_mergeVolumeUsageSamples(t *testing.T) {
+	t.Run("should use the maximum of overlapping series independent of their order", func(t *testing.T) {
+		// given
+		t1 := time.Date(2025, 1, 1, 0, 0, 0, 0, time.UTC)
+		t2 := t1.Add(time.Minute)
+		capacitySamples := []domain.LabeledSample{{ID: testPvcName, Value: 100, Time: t1}, {ID: testPvcName, Value: 100, Time: t2}}
+		usedSamples := []domain.LabeledSample{
+			{ID: testPvcName, Value: 60, Time: t1}, {ID: testPvcName, Value: 10, Time: t2},
+			{ID: testPvcName, Value: 20, Time: t1}, {ID: testPvcName, Value: 70, Time: t2},
+		}
+		inodeSamples := []domain.LabeledSample{
+			{ID: testPvcName, Value: 5, Time: t1}, {ID: testPvcName, Value: 8, Time: t2},
+			{ID: testPvcName, Value: 6, Time: t1}, {ID: testPvcName, Value: 7, Time: t2},
+		}
+		expected := []domain.VolumeUsageSample{
+			{Time: t1, Capacity: 100, Used: 60, InodesUsed: 6},
+			{Time: t2, Capacity: 100, Used: 70, InodesUsed: 8},
+		}
+
+		// when
+		merged := mergeVolumeUsageSamples(capacitySamples, usedSamples, inodeSamples)
+		slices.Reverse(usedSamples)
+		slices.Reverse(inodeSamples)
+		reversed := mergeVolumeUsageSamples(capacitySamples, usedSamples, inodeSamples)
+
+		// then
+		assert.Equal(t, expected, merged)
+		assert.Equal(t, expected, reversed)
+	})
 }
@@ -7,6 +7,7 @@ import (
 	"time"
 
 	"github.com/Masterminds/semver/v3"
+	"k8s.io/apimachinery/pkg/api/resource"
 	ctrl "sigs.k8s.io/controller-runtime"
 )
 
@@ -29,6 +30,7 @@ const (
 	metricsServiceProtocolEnvVar               = "METRICS_SERVICE_PROTOCOL"
 	nodeInfoUsageMetricStepEnvVar              = "NODE_INFO_USAGE_METRIC_STEP"
 	nodeInfoHardwareMetricStepEnvVar           = "NODE_INFO_HARDWARE_METRIC_STEP"
+	volumeInfoMetricStepEnvVar                 = "VOLUME_INFO_METRIC_STEP"
 	metricsMaxSamplesEnvVar                    = "METRICS_MAX_SAMPLES"
 	systemStateLabelSelectorsEnvVar            = "SYSTEM_STATE_LABEL_SELECTORS"
 	systemStateGvkExclusionsEnvVar             = "SYSTEM_STATE_GVK_EXCLUSIONS"
@@ -38,6 +40,32 @@ const (
 	logGatewayUrlEnvironmentVariable           = "LOG_GATEWAY_URL"
 	logGatewayUsernameEnvironmentVariable      = "LOG_GATEWAY_USERNAME"
 	logGatewayPasswordEnvironmentVariable      = "LOG_GATEWAY_PASSWORD"
+	collectorMaxRetriesEnvVar                  = "COLLECTOR_MAX_RETRIES"
+	maxConcurrentReconcilesEnvVar              = "MAX_CONCURRENT_RECONCILES"
+	supportArchiveDeadlineEnvVar               = "SUPPORT_ARCHIVE_DEADLINE"
+	timelineEnabledEnvVar                      = "TIMELINE_ENABLED"
+	defaultContentTimeframeEnvVar              = "DEFAULT_CONTENT_TIMEFRAME"
+	inMemoryArchiveMaxSizeEnvVar               = "IN_MEMORY_ARCHIVE_MAX_SIZE"
+	downloadServerEnabledEnvVar                = "DOWNLOAD_SERVER_ENABLED"
+	downloadServerPortEnvVar                   = "DOWNLOAD_SERVER_PORT"
+	downloadTokenSecretEnvVar                  = "DOWNLOAD_TOKEN_SECRET"
+	downloadTokenTTLEnvVar                     = "DOWNLOAD_TOKEN_TTL"
+	downloadTokenReviewEnabledEnvVar           = "DOWNLOAD_TOKEN_REVIEW_ENABLED"
+	webhookEnabledEnvVar                       = "WEBHOOK_ENABLED"
+	webhookPortEnvVar                          = "WEBHOOK_PORT"
+	maxContentTimeframeEnvVar                  = "MAX_CONTENT_TIMEFRAME"
+	leaderElectionEnabledEnvVar                = "LEADER_ELECTION_ENABLED"
+	podNameEnvVar                              = "POD_NAME"
+	s3EnabledEnvVar                            = "S3_ENABLED"
+	s3EndpointEnvVar                           = "S3_ENDPOINT"
+	s3BucketEnvVar                             = "S3_BUCKET"
+	s3PrefixEnvVar                             = "S3_PREFIX"
+	s3RegionEnvVar                             = "S3_REGION"
+	s3InsecureEnvVar                           = "S3_INSECURE"
+	s3AccessKeyIDEnvVar                        = "S3_ACCESS_KEY_ID"
+	s3SecretAccessKeyEnvVar                    = "S3_SECRET_ACCESS_KEY"
+	// minDownloadTokenTTL leaves enough time to renew the token before it expires.
+	minDownloadTokenTTL = 5 * time.Minute
 )
 
 var log = ctrl.Log.WithName("config")
@@ -49,6 +77,21 @@ type LogGatewayConfig struct {
 	Password string
 }
 
+// S3Config contains the connection to an S3-compatible object storage.
+type S3Config struct {
+	// Endpoint is the host and optional port of the object storage without scheme.
+	Endpoint string
+	Bucket   string
+	// Prefix is prepended to the keys of all objects, so that the bucket can be shared.
+	Prefix string
+	Region string
+	// Insecure defines if the object storage is accessed with http instead of https.
+	Insecure bool
+	// AccessKeyID and SecretAccessKey are the static credentials. If they are empty, the IAM role of the pod is used.
+	AccessKeyID     string
+	SecretAccessKey string
+}
+
 // OperatorConfig contains all configurable values for the dogu operator.
 type OperatorConfig struct {
 	// Version contains the current version of the operator
@@ -77,6 +120,8 @@ type OperatorConfig struct {
 	NodeInfoUsageMetricStep time.Duration
 	// NodeInfoHardwareMetricStep defines the step width used for hardware metrics (names, count, cores, capacities).
 	NodeInfoHardwareMetricStep time.Duration
+	// VolumeInfoMetricStep defines the step width used for the volume usage metrics.
+	VolumeInfoMetricStep time.Duration
 	// MetricsMaxSamples defines the maximum number of samples the metrics server can serve in a single request.
 	MetricsMaxSamples int
 	// SystemStateLabelSelectors defines a slice of label selectors as string in YAML format.
@@ -91,6 +136,48 @@ type OperatorConfig struct {
 	LogsEventSourceName string
 	// LogGatewayConfig contains connection configurations for the logging backend.
 	LogGatewayConfig LogGatewayConfig
+	// CollectorMaxRetries defines how often a failing collector is retried before it is skipped.
+	CollectorMaxRetries int
+	// MaxConcurrentReconciles defines how many support archives are created concurrently.
+	MaxConcurrentReconciles int
+	// SupportArchiveDeadline defines the maximum duration of the archive creation before the archive fails.
+	SupportArchiveDeadline time.Duration
+	// TimelineEnabled defines if a timeline of warnings, errors and state changes is added to the support archive.
+	TimelineEnabled bool
+	// DefaultContentTimeframe defines how far the content of an archive reaches into the past if the support archive
+	// defines no start time.
+	DefaultContentTimeframe time.Duration
+	// InMemoryArchiveMaxSize defines the maximum estimated size in bytes of the collected data of an archive which is
+	// created in memory instead of the work directory. Archives are never created in memory if it is 0.
+	InMemoryArchiveMaxSize int64
+	// DownloadServerEnabled defines if the operator serves the archives itself instead of the webserver sidecar.
+	// Downloads from the operator require a download token or, if enabled, a ServiceAccount token.
+	DownloadServerEnabled bool
+	// DownloadServerPort defines the port of the download server.
+	DownloadServerPort string
+	// DownloadTokenSecret is the key used to sign the download tokens.
+	DownloadTokenSecret string
+	// DownloadTokenTTL defines how long a download token is valid. Tokens are renewed while the archive exists.
+	DownloadTokenTTL time.Duration
+	// DownloadTokenReviewEnabled defines if ServiceAccount tokens are accepted for downloads. They are verified with a TokenReview
+	// and the ServiceAccounts are authorized with a SubjectAccessReview.
+	DownloadTokenReviewEnabled bool
+	// WebhookEnabled defines if the operator serves the admission webhook validating and defaulting support archives.
+	WebhookEnabled bool
+	// WebhookPort defines the port of the webhook server.
+	WebhookPort int
+	// MaxContentTimeframe defines the maximum length of content timeframes accepted by the webhook.
+	MaxContentTimeframe time.Duration
+	// LeaderElectionEnabled defines if multiple replicas may run. Only the elected leader reconciles support archives
+	// and every archive is claimed by a single replica.
+	LeaderElectionEnabled bool
+	// PodName identifies the replica in claims of support archives. It is only set if leader election is enabled.
+	PodName string
+	// S3Enabled defines if the collected data and the archives are stored in an S3-compatible object storage instead
+	// of the volume.
+	S3Enabled bool
+	// S3Config contains the connection to the object storage. It is only set if S3 is enabled.
+	S3Config S3Config
 }
 
 func IsStageDevelopment() bool {
@@ -131,6 +218,11 @@ func NewOperatorConfig(version string) (*OperatorConfig, error) {
 		return nil, err
 	}
 
+	err = getVolumeInfoConfig(config)
+	if err != nil {
+		return nil, err
+	}
+
 	err = getMetricsConfig(config)
 	if err != nil {
 		return nil, err
@@ -146,9 +238,54 @@ func NewOperatorConfig(version string) (*OperatorConfig, error) {
 		return nil, err
 	}
 
+	err = getArchiveCreationConfig(config)
+	if err != nil {
+		return nil, err
+	}
+
+	err = getDownloadConfig(config)
+	if err != nil {
+		return nil, err
+	}
+
+	err = getWebhookConfig(config)
+	if err != nil {
+		return nil, err
+	}
+
+	err = getLeaderElectionConfig(config)
+	if err != nil {
+		return nil, err
+	}
+
+	err = getS3Config(config)
+	if err != nil {
+		return nil, err
+	}
+
 	return config, nil
 }
 
+// NewLocalConfig creates the configuration for creating archives with the command line tool outside the cluster.
+// The values match the defaults of the helm chart. Connection settings have to be set by the caller.
+func NewLocalConfig(namespace string) *OperatorConfig {
+	return &OperatorConfig{
+		Namespace:                  namespace,
+		NodeInfoUsageMetricStep:    30 * time.Second,
+		NodeInfoHardwareMetricStep: 30 * time.Minute,
+		VolumeInfoMetricStep:       5 * time.Minute,
+		MetricsMaxSamples:          11000,
+		SystemStateLabelSelectors:  "app: ces",
+		SystemStateGvkExclusions:   "- group: \"\"\n  version: v1\n  kind: Secret\n",
+		LogsMaxQueryResultCount:    1500,
+		LogsMaxQueryTimeWindow:     24 * time.Hour,
+		LogsEventSourceName:        "loki.source.kubernetes_events",
+		SupportArchiveDeadline:     2 * time.Hour,
+		TimelineEnabled:            true,
+		DefaultContentTimeframe:    96 * time.Hour,
+	}
+}
+
 func getArchiveConfig(config *OperatorConfig) error {
 	archiveVolumeDownloadServiceName, err := getArchiveVolumeDownloadServiceName()
 	if err != nil {
@@ -220,6 +357,18 @@ func getNodeInfoConfig(config *OperatorConfig) error {
 	return nil
 }
 
+func getVolumeInfoConfig(config *OperatorConfig) error {
+	volumeInfoMetricStep, err := getDurationEnvVar(volumeInfoMetricStepEnvVar)
+	if err != nil {
+		return err
+	}
+	log.Info(fmt.Sprintf("VolumeInfo metric step: %s", volumeInfoMetricStep))
+
+	config.VolumeInfoMetricStep = volumeInfoMetricStep
+
+	return nil
+}
+
 func getMetricsConfig(config *OperatorConfig) error {
 	metricsServiceName, err := getEnvVar(metricsServiceNameEnvVar)
 	if err != nil {
@@ -253,6 +402,248 @@ func getMetricsConfig(config *OperatorConfig) error {
 	return nil
 }
 
+func getArchiveCreationConfig(config *OperatorConfig) error {
+	collectorMaxRetries, err := getIntEnvVar(collectorMaxRetriesEnvVar)
+	if err != nil {
+		return fmt.Errorf("failed to get maximum number of collector retries: %w", err)
+	}
+	if collectorMaxRetries < 0 {
+		return fmt.Errorf("maximum number of collector retries %d must not be negative", collectorMaxRetries)
+	}
+	log.Info(fmt.Sprintf("Maximum collector retries: %d", collectorMaxRetries))
+
+	maxConcurrentReconciles, err := getIntEnvVar(maxConcurrentReconcilesEnvVar)
+	if err != nil {
+		return fmt.Errorf("failed to get maximum number of concurrent reconciles: %w", err)
+	}
+	if maxConcurrentReconciles < 1 {
+		return fmt.Errorf("maximum number of concurrent reconciles %d must be at least 1", maxConcurrentReconciles)
+	}
+	log.Info(fmt.Sprintf("Maximum concurrent reconciles: %d", maxConcurrentReconciles))
+
+	supportArchiveDeadline, err := getDurationEnvVar(supportArchiveDeadlineEnvVar)
+	if err != nil {
+		return fmt.Errorf("failed to get support archive deadline: %w", err)
+	}
+	log.Info(fmt.Sprintf("Support archive deadline: %s", supportArchiveDeadline))
+
+	timelineEnabled, err := getBoolEnvVar(timelineEnabledEnvVar)
+	if err != nil {
+		return fmt.Errorf("failed to get timeline enabled flag: %w", err)
+	}
+	log.Info(fmt.Sprintf("Timeline enabled: %t", timelineEnabled))
+
+	defaultContentTimeframe, err := getDurationEnvVar(defaultContentTimeframeEnvVar)
+	if err != nil {
+		return fmt.Errorf("failed to get default content timeframe: %w", err)
+	}
+	if defaultContentTimeframe <= 0 {
+		return fmt.Errorf("default content timeframe %s must be positive", defaultContentTimeframe)
+	}
+	log.Info(fmt.Sprintf("Default content timeframe: %s", defaultContentTimeframe))
+
+	inMemoryArchiveMaxSize, err := getQuantityEnvVar(inMemoryArchiveMaxSizeEnvVar)
+	if err != nil {
+		return fmt.Errorf("failed to get maximum size of in-memory archives: %w", err)
+	}
+	if inMemoryArchiveMaxSize < 0 {
+		return fmt.Errorf("maximum size of in-memory archives %d must not be negative", inMemoryArchiveMaxSize)
+	}
+	log.Info(fmt.Sprintf("Maximum size of in-memory archives: %d bytes", inMemoryArchiveMaxSize))
+
+	config.CollectorMaxRetries = collectorMaxRetries
+	config.MaxConcurrentReconciles = maxConcurrentReconciles
+	config.SupportArchiveDeadline = supportArchiveDeadline
+	config.TimelineEnabled = timelineEnabled
+	config.DefaultContentTimeframe = defaultContentTimeframe
+	config.InMemoryArchiveMaxSize = inMemoryArchiveMaxSize
+
+	return nil
+}
+
+func getDownloadConfig(config *OperatorConfig) error {
+	downloadServerEnabled, err := getBoolEnvVar(downloadServerEnabledEnvVar)
+	if err != nil {
+		return fmt.Errorf("failed to get download server enabled flag: %w", err)
+	}
+	log.Info(fmt.Sprintf("Download server enabled: %t", downloadServerEnabled))
+	config.DownloadServerEnabled = downloadServerEnabled
+	if !downloadServerEnabled {
+		return nil
+	}
+
+	downloadServerPort, err := getEnvVar(downloadServerPortEnvVar)
+	if err != nil {
+		return fmt.Errorf("failed to get download server port: %w", err)
+	}
+	_, err = strconv.Atoi(downloadServerPort)
+	if err != nil {
+		return fmt.Errorf(errParseEnvVarFmt, downloadServerPortEnvVar, err)
+	}
+	log.Info(fmt.Sprintf("Download server port: %s", downloadServerPort))
+
+	downloadTokenSecret, err := getEnvVar(downloadTokenSecretEnvVar)
+	if err != nil {
+		return fmt.Errorf("failed to get download token secret: %w", err)
+	}
+	if downloadTokenSecret == "" {
+		return fmt.Errorf("download token secret must not be empty")
+	}
+
+	downloadTokenTTL, err := getDurationEnvVar(downloadTokenTTLEnvVar)
+	if err != nil {
+		return fmt.Errorf("failed to get download token ttl: %w", err)
+	}
+	if downloadTokenTTL < minDownloadTokenTTL {
+		return fmt.Errorf("download token ttl %s must be at least %s", downloadTokenTTL, minDownloadTokenTTL)
+	}
+	log.Info(fmt.Sprintf("Download token ttl: %s", downloadTokenTTL))
+
+	downloadTokenReviewEnabled, err := getBoolEnvVar(downloadTokenReviewEnabledEnvVar)
+	if err != nil {
+		return fmt.Errorf("failed to get download token review enabled flag: %w", err)
+	}
+	log.Info(fmt.Sprintf("Download token review enabled: %t", downloadTokenReviewEnabled))
+
+	config.DownloadServerPort = downloadServerPort
+	config.DownloadTokenSecret = downloadTokenSecret
+	config.DownloadTokenTTL = downloadTokenTTL
+	config.DownloadTokenReviewEnabled = downloadTokenReviewEnabled
+
+	return nil
+}
+
+func getWebhookConfig(config *OperatorConfig) error {
+	webhookEnabled, err := getBoolEnvVar(webhookEnabledEnvVar)
+	if err != nil {
+		return fmt.Errorf("failed to get webhook enabled flag: %w", err)
+	}
+	log.Info(fmt.Sprintf("Webhook enabled: %t", webhookEnabled))
+	config.WebhookEnabled = webhookEnabled
+	if !webhookEnabled {
+		return nil
+	}
+
+	webhookPort, err := getIntEnvVar(webhookPortEnvVar)
+	if err != nil {
+		return fmt.Errorf("failed to get webhook port: %w", err)
+	}
+	log.Info(fmt.Sprintf("Webhook port: %d", webhookPort))
+
+	maxContentTimeframe, err := getDurationEnvVar(maxContentTimeframeEnvVar)
+	if err != nil {
+		return fmt.Errorf("failed to get maximum content timeframe: %w", err)
+	}
+	if maxContentTimeframe < config.DefaultContentTimeframe {
+		return fmt.Errorf("maximum content timeframe %s must not be shorter than the default content timeframe %s", maxContentTimeframe, config.DefaultContentTimeframe)
+	}
+	log.Info(fmt.Sprintf("Maximum content timeframe: %s", maxContentTimeframe))
+
+	config.WebhookPort = webhookPort
+	config.MaxContentTimeframe = maxContentTimeframe
+
+	return nil
+}
+
+func getLeaderElectionConfig(config *OperatorConfig) error {
+	leaderElectionEnabled, err := getBoolEnvVar(leaderElectionEnabledEnvVar)
+	if err != nil {
+		return fmt.Errorf("failed to get leader election enabled flag: %w", err)
+	}
+	log.Info(fmt.Sprintf("Leader election enabled: %t", leaderElectionEnabled))
+	config.LeaderElectionEnabled = leaderElectionEnabled
+	if !leaderElectionEnabled {
+		return nil
+	}
+
+	podName, err := getEnvVar(podNameEnvVar)
+	if err != nil {
+		return fmt.Errorf("failed to get pod name: %w", err)
+	}
+	if podName == "" {
+		return fmt.Errorf("pod name must not be empty")
+	}
+	log.Info(fmt.Sprintf("Pod name: %s", podName))
+
+	config.PodName = podName
+
+	return nil
+}
+
+func getS3Config(config *OperatorConfig) error {
+	s3Enabled, err := getBoolEnvVar(s3EnabledEnvVar)
+	if err != nil {
+		return fmt.Errorf("failed to get s3 enabled flag: %w", err)
+	}
+	log.Info(fmt.Sprintf("S3 enabled: %t", s3Enabled))
+	config.S3Enabled = s3Enabled
+	if !s3Enabled {
+		return nil
+	}
+	// The webserver sidecar can only serve archives from the volume.
+	if !config.DownloadServerEnabled {
+		return fmt.Errorf("s3 requires the download server to be enabled")
+	}
+
+	endpoint, err := getEnvVar(s3EndpointEnvVar)
+	if err != nil {
+		return fmt.Errorf("failed to get s3 endpoint: %w", err)
+	}
+	if endpoint == "" {
+		return fmt.Errorf("s3 endpoint must not be empty")
+	}
+	log.Info(fmt.Sprintf("S3 endpoint: %s", endpoint))
+
+	bucket, err := getEnvVar(s3BucketEnvVar)
+	if err != nil {
+		return fmt.Errorf("failed to get s3 bucket: %w", err)
+	}
+	if bucket == "" {
+		return fmt.Errorf("s3 bucket must not be empty")
+	}
+	log.Info(fmt.Sprintf("S3 bucket: %s", bucket))
+
+	prefix, err := getEnvVar(s3PrefixEnvVar)
+	if err != nil {
+		return fmt.Errorf("failed to get s3 prefix: %w", err)
+	}
+	log.Info(fmt.Sprintf("S3 prefix: %s", prefix))
+
+	region, err := getEnvVar(s3RegionEnvVar)
+	if err != nil {
+		return fmt.Errorf("failed to get s3 region: %w", err)
+	}
+	log.Info(fmt.Sprintf("S3 region: %s", region))
+
+	insecure, err := getBoolEnvVar(s3InsecureEnvVar)
+	if err != nil {
+		return fmt.Errorf("failed to get s3 insecure flag: %w", err)
+	}
+	log.Info(fmt.Sprintf("S3 insecure: %t", insecure))
+
+	accessKeyID, err := getEnvVar(s3AccessKeyIDEnvVar)
+	if err != nil {
+		return fmt.Errorf("failed to get s3 access key id: %w", err)
+	}
+
+	secretAccessKey, err := getEnvVar(s3SecretAccessKeyEnvVar)
+	if err != nil {
+		return fmt.Errorf("failed to get s3 secret access key: %w", err)
+	}
+
+	config.S3Config = S3Config{
+		Endpoint:        endpoint,
+		Bucket:          bucket,
+		Prefix:          prefix,
+		Region:          region,
+		Insecure:        insecure,
+		AccessKeyID:     accessKeyID,
+		SecretAccessKey: secretAccessKey,
+	}
+
+	return nil
+}
+
 func getSystemStateConfig(config *OperatorConfig) error {
 	systemStateLabelsSelectors, err := getEnvVar(systemStateLabelSelectorsEnvVar)
 	if err != nil {
@@ -360,6 +751,35 @@ func getIntEnvVar(name string) (int, error) {
 	return intVal, nil
 }
 
+func getBoolEnvVar(name string) (bool, error) {
+	envVar, err := getEnvVar(name)
+	if err != nil {
+		return false, fmt.Errorf(errGetEnvVarFmt, name, err)
+	}
+
+	boolVal, err := strconv.ParseBool(envVar)
+	if err != nil {
+		return false, fmt.Errorf(errParseEnvVarFmt, name, err)
+	}
+
+	return boolVal, nil
+}
+
+// getQuantityEnvVar parses a quantity like 8Mi and returns its value.
+func getQuantityEnvVar(name string) (int64, error) {
+	envVar, err := getEnvVar(name)
+	if err != nil {
+		return 0, fmt.Errorf(errGetEnvVarFmt, name, err)
+	}
+
+	quantity, err := resource.ParseQuantity(envVar)
+	if err != nil {
+		return 0, fmt.Errorf(errParseEnvVarFmt, name, err)
+	}
+
+	return quantity.Value(), nil
+}
+
 func getEnvVar(name string) (string, error) {
 	env, found := os.LookupEnv(name)
 	if !found {
@@ -23,6 +23,7 @@ func setTestEnvVars(t *testing.T) {
 	t.Setenv("GARBAGE_COLLECTION_NUMBER_TO_KEEP", "5")
 	t.Setenv("NODE_INFO_USAGE_METRIC_STEP", "30s")
 	t.Setenv("NODE_INFO_HARDWARE_METRIC_STEP", "30m")
+	t.Setenv("VOLUME_INFO_METRIC_STEP", "5m")
 	t.Setenv("METRICS_MAX_SAMPLES", "11000")
 	t.Setenv("LOG_GATEWAY_URL", "loki")
 	t.Setenv("LOG_GATEWAY_USERNAME", "lokiU")
@@ -32,6 +33,33 @@ func setTestEnvVars(t *testing.T) {
 	t.Setenv("LOG_EVENT_SOURCE_NAME", "loki.kubernetes_events")
 	t.Setenv("SYSTEM_STATE_LABEL_SELECTORS", "app: ces")
 	t.Setenv("SYSTEM_STATE_GVK_EXCLUSIONS", "- group: apps\n  kind: Deployment\n  version: v1")
+	t.Setenv("COLLECTOR_MAX_RETRIES", "3")
+	t.Setenv("MAX_CONCURRENT_RECONCILES", "4")
+	t.Setenv("SUPPORT_ARCHIVE_DEADLINE", "2h")
+	t.Setenv("TIMELINE_ENABLED", "true")
+	t.Setenv("DEFAULT_CONTENT_TIMEFRAME", "96h")
+	t.Setenv("IN_MEMORY_ARCHIVE_MAX_SIZE", "8Mi")
+	t.Setenv("DOWNLOAD_SERVER_ENABLED", "false")
+	t.Setenv("WEBHOOK_ENABLED", "false")
+	t.Setenv("LEADER_ELECTION_ENABLED", "false")
+	t.Setenv("S3_ENABLED", "false")
+}
+
+func setS3TestEnvVars(t *testing.T) {
+	setTestEnvVars(t)
+	t.Setenv("DOWNLOAD_SERVER_ENABLED", "true")
+	t.Setenv("DOWNLOAD_SERVER_PORT", "8083")
+	t.Setenv("DOWNLOAD_TOKEN_SECRET", "secret")
+	t.Setenv("DOWNLOAD_TOKEN_TTL", "1h")
+	t.Setenv("DOWNLOAD_TOKEN_REVIEW_ENABLED", "true")
+	t.Setenv("S3_ENABLED", "true")
+	t.Setenv("S3_ENDPOINT", "s3.example.com")
+	t.Setenv("S3_BUCKET", "archives")
+	t.Setenv("S3_PREFIX", "operator")
+	t.Setenv("S3_REGION", "eu-central-1")
+	t.Setenv("S3_INSECURE", "true")
+	t.Setenv("S3_ACCESS_KEY_ID", "access")
+	t.Setenv("S3_SECRET_ACCESS_KEY", "secret")
 }
 
 func TestNewOperatorConfig(t *testing.T) {
@@ -57,6 +85,7 @@ func TestNewOperatorConfig(t *testing.T) {
 		assert.Equal(t, time.Minute*5, operatorConfig.GarbageCollectionInterval)
 		assert.Equal(t, time.Second*30, operatorConfig.NodeInfoUsageMetricStep)
 		assert.Equal(t, time.Minute*30, operatorConfig.NodeInfoHardwareMetricStep)
+		assert.Equal(t, time.Minute*5, operatorConfig.VolumeInfoMetricStep)
 		assert.Equal(t, 11000, operatorConfig.MetricsMaxSamples)
 		assert.Equal(t, "loki", operatorConfig.LogGatewayConfig.Url)
 		assert.Equal(t, "lokiU", operatorConfig.LogGatewayConfig.Username)
@@ -64,6 +93,13 @@ func TestNewOperatorConfig(t *testing.T) {
 		assert.Equal(t, 2000, operatorConfig.LogsMaxQueryResultCount)
 		assert.Equal(t, time.Hour*24, operatorConfig.LogsMaxQueryTimeWindow)
 		assert.Equal(t, "loki.kubernetes_events", operatorConfig.LogsEventSourceName)
+		assert.Equal(t, 3, operatorConfig.CollectorMaxRetries)
+		assert.Equal(t, 4, operatorConfig.MaxConcurrentReconciles)
+		assert.Equal(t, time.Hour*2, operatorConfig.SupportArchiveDeadline)
+		assert.True(t, operatorConfig.TimelineEnabled)
+		assert.Equal(t, time.Hour*96, operatorConfig.DefaultContentTimeframe)
+		assert.Equal(t, int64(8<<20), operatorConfig.InMemoryArchiveMaxSize)
+		assert.False(t, operatorConfig.DownloadServerEnabled)
 	})
 	t.Run("should succeed with stage set", func(t *testing.T) {
 		// given
@@ -188,6 +224,32 @@ func TestNewOperatorConfig(t *testing.T) {
 		require.Nil(t, operatorConfig)
 		assert.ErrorContains(t, err, "failed to parse env var [NODE_INFO_HARDWARE_METRIC_STEP]")
 	})
+	t.Run("should fail to parse volume info metric step", func(t *testing.T) {
+		// given
+		version := "0.0.0"
+		t.Setenv("NAMESPACE", "ecosystem")
+		t.Setenv("STAGE", "development")
+		t.Setenv("ARCHIVE_VOLUME_DOWNLOAD_SERVICE_NAME", "service")
+		t.Setenv("ARCHIVE_VOLUME_DOWNLOAD_SERVICE_PROTOCOL", "http")
+		t.Setenv("ARCHIVE_VOLUME_DOWNLOAD_SERVICE_PORT", "8080")
+		t.Setenv("METRICS_SERVICE_NAME", "metrics")
+		t.Setenv("METRICS_SERVICE_PORT", "8081")
+		t.Setenv("METRICS_SERVICE_PROTOCOL", "http")
+		t.Setenv("SUPPORT_ARCHIVE_SYNC_INTERVAL", "1m")
+		t.Setenv("GARBAGE_COLLECTION_INTERVAL", "5m")
+		t.Setenv("GARBAGE_COLLECTION_NUMBER_TO_KEEP", "5")
+		t.Setenv("NODE_INFO_USAGE_METRIC_STEP", "30s")
+		t.Setenv("NODE_INFO_HARDWARE_METRIC_STEP", "30m")
+		t.Setenv("VOLUME_INFO_METRIC_STEP", "not a duration")
+
+		// when
+		operatorConfig, err := NewOperatorConfig(version)
+
+		// then
+		require.Error(t, err)
+		require.Nil(t, operatorConfig)
+		assert.ErrorContains(t, err, "failed to parse env var [VOLUME_INFO_METRIC_STEP]")
+	})
 	t.Run("should fail to parse metrics max samples", func(t *testing.T) {
 		// given
 		version := "0.0.0"
@@ -204,6 +266,7 @@ func TestNewOperatorConfig(t *testing.T) {
 		t.Setenv("GARBAGE_COLLECTION_NUMBER_TO_KEEP", "5")
 		t.Setenv("NODE_INFO_USAGE_METRIC_STEP", "30s")
 		t.Setenv("NODE_INFO_HARDWARE_METRIC_STEP", "30m")
+		t.Setenv("VOLUME_INFO_METRIC_STEP", "5m")
 		t.Setenv("METRICS_MAX_SAMPLES", "not a number")
 
 		// when
@@ -215,6 +278,411 @@ func TestNewOperatorConfig(t *testing.T) {
 		assert.ErrorContains(t, err, "failed to get maximum number of metrics samples")
 	})
 
+	t.Run("should fail to parse collector max retries", func(t *testing.T) {
+		// given
+		version := "0.0.0"
+		setTestEnvVars(t)
+		t.Setenv("COLLECTOR_MAX_RETRIES", "not a number")
+
+		// when
+		operatorConfig, err := NewOperatorConfig(version)
+
+		// then
+		require.Error(t, err)
+		require.Nil(t, operatorConfig)
+		assert.ErrorContains(t, err, "failed to get maximum number of collector retries")
+	})
+	t.Run("should fail for negative collector max retries", func(t *testing.T) {
+		// given
+		version := "0.0.0"
+		setTestEnvVars(t)
+		t.Setenv("COLLECTOR_MAX_RETRIES", "-1")
+
+		// when
+		operatorConfig, err := NewOperatorConfig(version)
+
+		// then
+		require.Error(t, err)
+		require.Nil(t, operatorConfig)
+		assert.ErrorContains(t, err, "maximum number of collector retries -1 must not be negative")
+	})
+	t.Run("should fail to parse max concurrent reconciles", func(t *testing.T) {
+		// given
+		version := "0.0.0"
+		setTestEnvVars(t)
+		t.Setenv("MAX_CONCURRENT_RECONCILES", "not a number")
+
+		// when
+		operatorConfig, err := NewOperatorConfig(version)
+
+		// then
+		require.Error(t, err)
+		require.Nil(t, operatorConfig)
+		assert.ErrorContains(t, err, "failed to get maximum number of concurrent reconciles")
+	})
+	t.Run("should fail for max concurrent reconciles less than 1", func(t *testing.T) {
+		// given
+		version := "0.0.0"
+		setTestEnvVars(t)
+		t.Setenv("MAX_CONCURRENT_RECONCILES", "0")
+
+		// when
+		operatorConfig, err := NewOperatorConfig(version)
+
+		// then
+		require.Error(t, err)
+		require.Nil(t, operatorConfig)
+		assert.ErrorContains(t, err, "maximum number of concurrent reconciles 0 must be at least 1")
+	})
+	t.Run("should fail to parse support archive deadline", func(t *testing.T) {
+		// given
+		version := "0.0.0"
+		setTestEnvVars(t)
+		t.Setenv("SUPPORT_ARCHIVE_DEADLINE", "not a duration")
+
+		// when
+		operatorConfig, err := NewOperatorConfig(version)
+
+		// then
+		require.Error(t, err)
+		require.Nil(t, operatorConfig)
+		assert.ErrorContains(t, err, "failed to get support archive deadline")
+	})
+	t.Run("should fail to parse timeline enabled flag", func(t *testing.T) {
+		// given
+		version := "0.0.0"
+		setTestEnvVars(t)
+		t.Setenv("TIMELINE_ENABLED", "not a bool")
+
+		// when
+		operatorConfig, err := NewOperatorConfig(version)
+
+		// then
+		require.Error(t, err)
+		require.Nil(t, operatorConfig)
+		assert.ErrorContains(t, err, "failed to get timeline enabled flag")
+	})
+	t.Run("should fail to parse default content timeframe", func(t *testing.T) {
+		// given
+		version := "0.0.0"
+		setTestEnvVars(t)
+		t.Setenv("DEFAULT_CONTENT_TIMEFRAME", "4 days")
+
+		// when
+		operatorConfig, err := NewOperatorConfig(version)
+
+		// then
+		require.Error(t, err)
+		require.Nil(t, operatorConfig)
+		assert.ErrorContains(t, err, "failed to get default content timeframe")
+	})
+	t.Run("should fail for non-positive default content timeframe", func(t *testing.T) {
+		// given
+		version := "0.0.0"
+		setTestEnvVars(t)
+		t.Setenv("DEFAULT_CONTENT_TIMEFRAME", "0s")
+
+		// when
+		operatorConfig, err := NewOperatorConfig(version)
+
+		// then
+		require.Error(t, err)
+		require.Nil(t, operatorConfig)
+		assert.ErrorContains(t, err, "default content timeframe 0s must be positive")
+	})
+	t.Run("should fail to parse maximum size of in-memory archives", func(t *testing.T) {
+		// given
+		version := "0.0.0"
+		setTestEnvVars(t)
+		t.Setenv("IN_MEMORY_ARCHIVE_MAX_SIZE", "8 MB")
+
+		// when
+		operatorConfig, err := NewOperatorConfig(version)
+
+		// then
+		require.Error(t, err)
+		require.Nil(t, operatorConfig)
+		assert.ErrorContains(t, err, "failed to get maximum size of in-memory archives")
+	})
+	t.Run("should fail for negative maximum size of in-memory archives", func(t *testing.T) {
+		// given
+		version := "0.0.0"
+		setTestEnvVars(t)
+		t.Setenv("IN_MEMORY_ARCHIVE_MAX_SIZE", "-1Mi")
+
+		// when
+		operatorConfig, err := NewOperatorConfig(version)
+
+		// then
+		require.Error(t, err)
+		require.Nil(t, operatorConfig)
+		assert.ErrorContains(t, err, "maximum size of in-memory archives -1048576 must not be negative")
+	})
+	t.Run("should succeed with download server", func(t *testing.T) {
+		// given
+		version := "0.0.0"
+		setTestEnvVars(t)
+		t.Setenv("DOWNLOAD_SERVER_ENABLED", "true")
+		t.Setenv("DOWNLOAD_SERVER_PORT", "8083")
+		t.Setenv("DOWNLOAD_TOKEN_SECRET", "secret")
+		t.Setenv("DOWNLOAD_TOKEN_TTL", "1h")
+		t.Setenv("DOWNLOAD_TOKEN_REVIEW_ENABLED", "true")
+
+		// when
+		operatorConfig, err := NewOperatorConfig(version)
+
+		// then
+		require.NoError(t, err)
+		assert.True(t, operatorConfig.DownloadServerEnabled)
+		assert.Equal(t, "8083", operatorConfig.DownloadServerPort)
+		assert.Equal(t, "secret", operatorConfig.DownloadTokenSecret)
+		assert.Equal(t, time.Hour, operatorConfig.DownloadTokenTTL)
+		assert.True(t, operatorConfig.DownloadTokenReviewEnabled)
+	})
+	t.Run("should succeed with webhook", func(t *testing.T) {
+		// given
+		version := "0.0.0"
+		setTestEnvVars(t)
+		t.Setenv("WEBHOOK_ENABLED", "true")
+		t.Setenv("WEBHOOK_PORT", "9443")
+		t.Setenv("MAX_CONTENT_TIMEFRAME", "720h")
+
+		// when
+		operatorConfig, err := NewOperatorConfig(version)
+
+		// then
+		require.NoError(t, err)
+		assert.True(t, operatorConfig.WebhookEnabled)
+		assert.Equal(t, 9443, operatorConfig.WebhookPort)
+		assert.Equal(t, 720*time.Hour, operatorConfig.MaxContentTimeframe)
+	})
+	t.Run("should succeed with leader election", func(t *testing.T) {
+		// given
+		version := "0.0.0"
+		setTestEnvVars(t)
+		t.Setenv("LEADER_ELECTION_ENABLED", "true")
+		t.Setenv("POD_NAME", "operator-1")
+
+		// when
+		operatorConfig, err := NewOperatorConfig(version)
+
+		// then
+		require.NoError(t, err)
+		assert.True(t, operatorConfig.LeaderElectionEnabled)
+		assert.Equal(t, "operator-1", operatorConfig.PodName)
+	})
+	t.Run("should fail to parse leader election enabled flag", func(t *testing.T) {
+		// given
+		version := "0.0.0"
+		setTestEnvVars(t)
+		t.Setenv("LEADER_ELECTION_ENABLED", "not a bool")
+
+		// when
+		operatorConfig, err := NewOperatorConfig(version)
+
+		// then
+		require.Error(t, err)
+		require.Nil(t, operatorConfig)
+		assert.ErrorContains(t, err, "failed to get leader election enabled flag")
+	})
+	t.Run("should fail without pod name if leader election is enabled", func(t *testing.T) {
+		// given
+		version := "0.0.0"
+		setTestEnvVars(t)
+		t.Setenv("LEADER_ELECTION_ENABLED", "true")
+		t.Setenv("POD_NAME", "")
+
+		// when
+		operatorConfig, err := NewOperatorConfig(version)
+
+		// then
+		require.Error(t, err)
+		require.Nil(t, operatorConfig)
+		assert.ErrorContains(t, err, "pod name must not be empty")
+	})
+	t.Run("should succeed with s3", func(t *testing.T) {
+		// given
+		version := "0.0.0"
+		setS3TestEnvVars(t)
+
+		// when
+		operatorConfig, err := NewOperatorConfig(version)
+
+		// then
+		require.NoError(t, err)
+		assert.True(t, operatorConfig.S3Enabled)
+		assert.Equal(t, S3Config{
+			Endpoint:        "s3.example.com",
+			Bucket:          "archives",
+			Prefix:          "operator",
+			Region:          "eu-central-1",
+			Insecure:        true,
+			AccessKeyID:     "access",
+			SecretAccessKey: "secret",
+		}, operatorConfig.S3Config)
+	})
+	t.Run("should fail to parse s3 enabled flag", func(t *testing.T) {
+		// given
+		version := "0.0.0"
+		setTestEnvVars(t)
+		t.Setenv("S3_ENABLED", "not a bool")
+
+		// when
+		operatorConfig, err := NewOperatorConfig(version)
+
+		// then
+		require.Error(t, err)
+		require.Nil(t, operatorConfig)
+		assert.ErrorContains(t, err, "failed to get s3 enabled flag")
+	})
+	t.Run("should fail if s3 is enabled without download server", func(t *testing.T) {
+		// given
+		version := "0.0.0"
+		setS3TestEnvVars(t)
+		t.Setenv("DOWNLOAD_SERVER_ENABLED", "false")
+
+		// when
+		operatorConfig, err := NewOperatorConfig(version)
+
+		// then
+		require.Error(t, err)
+		require.Nil(t, operatorConfig)
+		assert.ErrorContains(t, err, "s3 requires the download server to be enabled")
+	})
+	t.Run("should fail on empty s3 endpoint", func(t *testing.T) {
+		// given
+		version := "0.0.0"
+		setS3TestEnvVars(t)
+		t.Setenv("S3_ENDPOINT", "")
+
+		// when
+		operatorConfig, err := NewOperatorConfig(version)
+
+		// then
+		require.Error(t, err)
+		require.Nil(t, operatorConfig)
+		assert.ErrorContains(t, err, "s3 endpoint must not be empty")
+	})
+	t.Run("should fail on empty s3 bucket", func(t *testing.T) {
+		// given
+		version := "0.0.0"
+		setS3TestEnvVars(t)
+		t.Setenv("S3_BUCKET", "")
+
+		// when
+		operatorConfig, err := NewOperatorConfig(version)
+
+		// then
+		require.Error(t, err)
+		require.Nil(t, operatorConfig)
+		assert.ErrorContains(t, err, "s3 bucket must not be empty")
+	})
+	t.Run("should fail to parse s3 insecure flag", func(t *testing.T) {
+		// given
+		version := "0.0.0"
+		setS3TestEnvVars(t)
+		t.Setenv("S3_INSECURE", "not a bool")
+
+		// when
+		operatorConfig, err := NewOperatorConfig(version)
+
+		// then
+		require.Error(t, err)
+		require.Nil(t, operatorConfig)
+		assert.ErrorContains(t, err, "failed to get s3 insecure flag")
+	})
+	t.Run("should fail to parse webhook enabled flag", func(t *testing.T) {
+		// given
+		version := "0.0.0"
+		setTestEnvVars(t)
+		t.Setenv("WEBHOOK_ENABLED", "not a bool")
+
+		// when
+		operatorConfig, err := NewOperatorConfig(version)
+
+		// then
+		require.Error(t, err)
+		require.Nil(t, operatorConfig)
+		assert.ErrorContains(t, err, "failed to get webhook enabled flag")
+	})
+	t.Run("should fail to parse webhook port", func(t *testing.T) {
+		// given
+		version := "0.0.0"
+		setTestEnvVars(t)
+		t.Setenv("WEBHOOK_ENABLED", "true")
+		t.Setenv("WEBHOOK_PORT", "https")
+
+		// when
+		operatorConfig, err := NewOperatorConfig(version)
+
+		// then
+		require.Error(t, err)
+		require.Nil(t, operatorConfig)
+		assert.ErrorContains(t, err, "failed to get webhook port")
+	})
+	t.Run("should fail if maximum content timeframe is shorter than the default", func(t *testing.T) {
+		// given
+		version := "0.0.0"
+		setTestEnvVars(t)
+		t.Setenv("WEBHOOK_ENABLED", "true")
+		t.Setenv("WEBHOOK_PORT", "9443")
+		t.Setenv("MAX_CONTENT_TIMEFRAME", "24h")
+
+		// when
+		operatorConfig, err := NewOperatorConfig(version)
+
+		// then
+		require.Error(t, err)
+		require.Nil(t, operatorConfig)
+		assert.ErrorContains(t, err, "maximum content timeframe 24h0m0s must not be shorter than the default content timeframe 96h0m0s")
+	})
+	t.Run("should fail to parse download server enabled flag", func(t *testing.T) {
+		// given
+		version := "0.0.0"
+		setTestEnvVars(t)
+		t.Setenv("DOWNLOAD_SERVER_ENABLED", "not a bool")
+
+		// when
+		operatorConfig, err := NewOperatorConfig(version)
+
+		// then
+		require.Error(t, err)
+		require.Nil(t, operatorConfig)
+		assert.ErrorContains(t, err, "failed to get download server enabled flag")
+	})
+	t.Run("should fail on empty download token secret", func(t *testing.T) {
+		// given
+		version := "0.0.0"
+		setTestEnvVars(t)
+		t.Setenv("DOWNLOAD_SERVER_ENABLED", "true")
+		t.Setenv("DOWNLOAD_SERVER_PORT", "8083")
+		t.Setenv("DOWNLOAD_TOKEN_SECRET", "")
+
+		// when
+		operatorConfig, err := NewOperatorConfig(version)
+
+		// then
+		require.Error(t, err)
+		require.Nil(t, operatorConfig)
+		assert.ErrorContains(t, err, "download token secret must not be empty")
+	})
+	t.Run("should fail on too short download token ttl", func(t *testing.T) {
+		// given
+		version := "0.0.0"
+		setTestEnvVars(t)
+		t.Setenv("DOWNLOAD_SERVER_ENABLED", "true")
+		t.Setenv("DOWNLOAD_SERVER_PORT", "8083")
+		t.Setenv("DOWNLOAD_TOKEN_SECRET", "secret")
+		t.Setenv("DOWNLOAD_TOKEN_TTL", "1m")
+
+		// when
+		operatorConfig, err := NewOperatorConfig(version)
+
+		// then
+		require.Error(t, err)
+		require.Nil(t, operatorConfig)
+		assert.ErrorContains(t, err, "download token ttl 1m0s must be at least 5m0s")
+	})
 	t.Run("fail to parse version", func(t *testing.T) {
 		// given
 		version := "0.0."
@@ -243,6 +711,22 @@ func TestNewOperatorConfig(t *testing.T) {
 	})
 }
 
+func TestNewLocalConfig(t *testing.T) {
+	// when
+	config := NewLocalConfig("ecosystem")
+
+	// then
+	assert.Equal(t, "ecosystem", config.Namespace)
+	assert.Equal(t, 30*time.Second, config.NodeInfoUsageMetricStep)
+	assert.Equal(t, 11000, config.MetricsMaxSamples)
+	assert.Equal(t, "app: ces", config.SystemStateLabelSelectors)
+	assert.Contains(t, config.SystemStateGvkExclusions, "kind: Secret")
+	assert.Equal(t, 2*time.Hour, config.SupportArchiveDeadline)
+	assert.True(t, config.TimelineEnabled)
+	assert.Equal(t, 96*time.Hour, config.DefaultContentTimeframe)
+	assert.Empty(t, config.LogGatewayConfig.Url)
+}
+
 func TestGetLogLevel(t *testing.T) {
 	tests := []struct {
 		name    string
@@ -0,0 +1,99 @@
+package download
+
+import (
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/hex"
+	"fmt"
+	"io"
+	"net/http"
+	"os"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/cloudogu/k8s-support-archive-operator/pkg/adapter/archive/file"
+)
+
+// checksumCache caches the checksums of the archives so that they are only calculated once per archive.
+// An entry is recalculated if the size or modification time of the archive changes.
+type checksumCache struct {
+	mu      sync.Mutex
+	entries map[string]checksumEntry
+}
+
+type checksumEntry struct {
+	size     int64
+	modTime  time.Time
+	checksum []byte
+}
+
+func newChecksumCache() *checksumCache {
+	return &checksumCache{entries: map[string]checksumEntry{}}
+}
+
+// get returns the SHA-256 checksum of the archive and rewinds the content to the start.
+func (c *checksumCache) get(archivePath string, info os.FileInfo, content io.ReadSeeker) ([]byte, error) {
+	c.mu.Lock()
+	entry, ok := c.entries[archivePath]
+	c.mu.Unlock()
+	if ok && entry.size == info.Size() && entry.modTime.Equal(info.ModTime()) {
+		return entry.checksum, nil
+	}
+
+	hash := sha256.New()
+	_, err := io.Copy(hash, content)
+	if err != nil {
+		return nil, err
+	}
+	_, err = content.Seek(0, io.SeekStart)
+	if err != nil {
+		return nil, fmt.Errorf("failed to rewind archive: %w", err)
+	}
+
+	checksum := hash.Sum(nil)
+	c.mu.Lock()
+	c.entries[archivePath] = checksumEntry{size: info.Size(), modTime: info.ModTime(), checksum: checksum}
+	c.mu.Unlock()
+
+	return checksum, nil
+}
+
+// manifestChecksum returns the checksum of the file from the manifest or nil if the archive has no manifest.
+func manifestChecksum(reader *file.ZipArchiveReader, filePath string) []byte {
+	manifest, err := reader.Manifest()
+	if err != nil {
+		return nil
+	}
+
+	for _, manifestFile := range manifest.Files {
+		if manifestFile.Path != filePath {
+			continue
+		}
+		checksum, err := hex.DecodeString(manifestFile.SHA256)
+		if err != nil {
+			return nil
+		}
+		return checksum
+	}
+
+	return nil
+}
+
+// setChecksumHeaders sets the hex encoded checksum as strong ETag and the checksum as Repr-Digest (RFC 9530).
+func setChecksumHeaders(w http.ResponseWriter, checksum []byte) {
+	w.Header().Set("ETag", fmt.Sprintf("%q", hex.EncodeToString(checksum)))
+	w.Header().Set("Repr-Digest", fmt.Sprintf("sha-256=:%s:", base64.StdEncoding.EncodeToString(checksum)))
+}
+
+// etagMatches reports whether the If-None-Match header contains the ETag.
+func etagMatches(ifNoneMatch string, etag string) bool {
+	for _, candidate := range strings.Split(ifNoneMatch, ",") {
+		candidate = strings.TrimPrefix(strings.TrimSpace(candidate), "W/")
+		if candidate == "*" || candidate == etag {
+			return true
+		}
+	}
+
+	return false
+}
@@ -0,0 +1,58 @@
+package download
+
+import (
+	"bytes"
+	"crypto/sha256"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestChecksumCache_get(t *testing.T) {
+	// given
+	path := filepath.Join(t.TempDir(), "archive-123.zip")
+	require.NoError(t, os.WriteFile(path, []byte("zip content"), 0644))
+	info, err := os.Stat(path)
+	require.NoError(t, err)
+	expected := sha256.Sum256([]byte("zip content"))
+	sut := newChecksumCache()
+
+	// when
+	content := bytes.NewReader([]byte("zip content"))
+	checksum, err := sut.get(path, info, content)
+
+	// then
+	require.NoError(t, err)
+	assert.Equal(t, expected[:], checksum)
+	assert.Equal(t, int64(0), content.Size()-int64(content.Len()), "content should be rewound")
+
+	t.Run("should use cached checksum for unchanged archive", func(t *testing.T) {
+		cached, err := sut.get(path, info, bytes.NewReader([]byte("other")))
+
+		require.NoError(t, err)
+		assert.Equal(t, expected[:], cached)
+	})
+	t.Run("should recalculate checksum for modified archive", func(t *testing.T) {
+		require.NoError(t, os.Chtimes(path, time.Now(), info.ModTime().Add(time.Minute)))
+		modifiedInfo, err := os.Stat(path)
+		require.NoError(t, err)
+		other := sha256.Sum256([]byte("other"))
+
+		recalculated, err := sut.get(path, modifiedInfo, bytes.NewReader([]byte("other")))
+
+		require.NoError(t, err)
+		assert.Equal(t, other[:], recalculated)
+	})
+}
+
+func TestEtagMatches(t *testing.T) {
+	assert.True(t, etagMatches(`"abc"`, `"abc"`))
+	assert.True(t, etagMatches(`"other", W/"abc"`, `"abc"`))
+	assert.True(t, etagMatches(`*`, `"abc"`))
+	assert.False(t, etagMatches(``, `"abc"`))
+	assert.False(t, etagMatches(`"other"`, `"abc"`))
+}
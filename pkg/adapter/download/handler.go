@@ -0,0 +1,333 @@
+package download
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"io/fs"
+	"mime"
+	"net/http"
+	"net/url"
+	"os"
+	"path"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/go-logr/logr"
+	authorizationv1 "k8s.io/api/authorization/v1"
+	k8sErrs "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/util/validation"
+
+	"github.com/cloudogu/k8s-support-archive-operator/pkg/adapter/archive/file"
+	"github.com/cloudogu/k8s-support-archive-operator/pkg/domain"
+)
+
+const (
+	// ListPath is the path of the json listing of all archives.
+	ListPath      = "/api/v1/archives"
+	archiveSuffix = ".zip"
+	// tokenUser is logged in the audit log for downloads authenticated with a download token.
+	tokenUser = "download-token"
+)
+
+// Handler serves the support archives at /<namespace>/<name>.zip, the same paths the webserver sidecar uses.
+// Archives support range requests and carry their SHA-256 checksum in the ETag and Repr-Digest headers.
+// Single files of an archive are served at /<namespace>/<name>.zip/<path> and all archives are listed at ListPath.
+// Every download has to be authenticated either with the download token from the status of the support archive
+// or, if service account reviews are given, with the ServiceAccount token in the Authorization header.
+// ServiceAccounts need the permission get on supportarchives/download in the namespace of the archive
+// and list for the listing, which only accepts ServiceAccount tokens. All requests are written to the audit log.
+type Handler struct {
+	supportArchives supportArchiveV1Interface
+	tokenVerifier   tokenVerifier
+	// serviceAccounts is nil if ServiceAccount tokens are not accepted.
+	serviceAccounts *ServiceAccountReviews
+	archives        archiveRepository
+	filesystem      volumeFs
+	auditLog        logr.Logger
+	checksums       *checksumCache
+	now             func() time.Time
+}
+
+func NewHandler(supportArchives supportArchiveV1Interface, tokenVerifier tokenVerifier, serviceAccounts *ServiceAccountReviews, archives archiveRepository, fs volumeFs, auditLog logr.Logger) *Handler {
+	return &Handler{
+		supportArchives: supportArchives,
+		tokenVerifier:   tokenVerifier,
+		serviceAccounts: serviceAccounts,
+		archives:        archives,
+		filesystem:      fs,
+		auditLog:        auditLog,
+		checksums:       newChecksumCache(),
+		now:             time.Now,
+	}
+}
+
+// auditResponseWriter records the status code and the number of written bytes for the audit log.
+type auditResponseWriter struct {
+	http.ResponseWriter
+	status int
+	bytes  int64
+}
+
+func (w *auditResponseWriter) WriteHeader(status int) {
+	w.status = status
+	w.ResponseWriter.WriteHeader(status)
+}
+
+func (w *auditResponseWriter) Write(p []byte) (int, error) {
+	if w.status == 0 {
+		w.status = http.StatusOK
+	}
+	n, err := w.ResponseWriter.Write(p)
+	w.bytes += int64(n)
+	return n, err
+}
+
+func (h *Handler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	auditWriter := &auditResponseWriter{ResponseWriter: w}
+	if r.URL.Path == ListPath {
+		user, reason := h.serveList(auditWriter, r)
+		h.auditLog.Info("list", "user", user, "remoteAddr", r.RemoteAddr,
+			"status", auditWriter.status, "bytes", auditWriter.bytes, "reason", reason)
+		return
+	}
+
+	entry := h.serve(auditWriter, r)
+	h.auditLog.Info("download", "namespace", entry.id.Namespace, "name", entry.id.Name, "file", entry.file, "user", entry.user,
+		"remoteAddr", r.RemoteAddr, "status", auditWriter.status, "bytes", auditWriter.bytes, "reason", entry.reason)
+}
+
+// auditEntry describes a download for the audit log.
+type auditEntry struct {
+	id domain.SupportArchiveID
+	// file is empty if the whole archive is requested.
+	file   string
+	user   string
+	reason string
+}
+
+// serve writes the response and returns the audit entry with the reason of a failure.
+func (h *Handler) serve(w http.ResponseWriter, r *http.Request) auditEntry {
+	if !allowedMethod(w, r) {
+		return auditEntry{reason: "method not allowed"}
+	}
+
+	id, filePath, ok := parseArchivePath(r.URL.Path)
+	entry := auditEntry{id: id, file: filePath}
+	if !ok {
+		http.NotFound(w, r)
+		entry.reason = "invalid path"
+		return entry
+	}
+
+	user, status, err := h.authenticate(r, id)
+	entry.user = user
+	if err != nil {
+		writeAuthError(w, status)
+		entry.reason = err.Error()
+		return entry
+	}
+
+	archivePath := h.archives.GetArchivePath(id)
+	if filePath == "" {
+		entry.reason = h.serveArchive(w, r, id, archivePath)
+	} else {
+		entry.reason = h.serveArchiveFile(w, r, archivePath, filePath)
+	}
+
+	return entry
+}
+
+// serveArchive serves the whole archive with support for range and conditional requests.
+func (h *Handler) serveArchive(w http.ResponseWriter, r *http.Request, id domain.SupportArchiveID, archivePath string) string {
+	info, err := h.filesystem.Stat(r.Context(), archivePath)
+	if os.IsNotExist(err) {
+		http.NotFound(w, r)
+		return "archive does not exist"
+	} else if err != nil {
+		http.Error(w, http.StatusText(http.StatusInternalServerError), http.StatusInternalServerError)
+		return fmt.Sprintf("failed to stat archive: %s", err)
+	}
+
+	archive, err := h.filesystem.Open(r.Context(), archivePath)
+	if os.IsNotExist(err) {
+		http.NotFound(w, r)
+		return "archive does not exist"
+	} else if err != nil {
+		http.Error(w, http.StatusText(http.StatusInternalServerError), http.StatusInternalServerError)
+		return fmt.Sprintf("failed to open archive: %s", err)
+	}
+	defer func() {
+		_ = archive.Close()
+	}()
+
+	content, ok := archive.(io.ReadSeeker)
+	if !ok {
+		http.Error(w, http.StatusText(http.StatusInternalServerError), http.StatusInternalServerError)
+		return "archive is not seekable"
+	}
+
+	checksum, err := h.checksums.get(archivePath, info, content)
+	if err != nil {
+		http.Error(w, http.StatusText(http.StatusInternalServerError), http.StatusInternalServerError)
+		return fmt.Sprintf("failed to calculate checksum of archive: %s", err)
+	}
+
+	w.Header().Set("Content-Type", "application/zip")
+	w.Header().Set("Content-Disposition", fmt.Sprintf("attachment; filename=%q", id.Name+archiveSuffix))
+	setChecksumHeaders(w, checksum)
+	// ServeContent handles HEAD, range and conditional requests.
+	http.ServeContent(w, r, "", info.ModTime(), content)
+
+	return ""
+}
+
+// serveArchiveFile serves a single file of the archive. Range requests are not supported because
+// the files are compressed, but the checksum from the manifest is used for conditional requests.
+func (h *Handler) serveArchiveFile(w http.ResponseWriter, r *http.Request, archivePath string, filePath string) string {
+	reader, err := file.OpenZipArchiveReader(r.Context(), archivePath, h.filesystem)
+	if errors.Is(err, fs.ErrNotExist) {
+		http.NotFound(w, r)
+		return "archive does not exist"
+	} else if err != nil {
+		http.Error(w, http.StatusText(http.StatusInternalServerError), http.StatusInternalServerError)
+		return err.Error()
+	}
+	defer func() {
+		_ = reader.Close()
+	}()
+
+	archiveFile, err := reader.Open(filePath)
+	if errors.Is(err, fs.ErrNotExist) {
+		http.NotFound(w, r)
+		return "file does not exist"
+	} else if err != nil {
+		http.Error(w, http.StatusText(http.StatusInternalServerError), http.StatusInternalServerError)
+		return fmt.Sprintf("failed to open file: %s", err)
+	}
+	defer func() {
+		_ = archiveFile.Close()
+	}()
+
+	info, err := archiveFile.Stat()
+	if err != nil || info.IsDir() {
+		http.NotFound(w, r)
+		return "file does not exist"
+	}
+
+	checksum := manifestChecksum(reader, filePath)
+	if checksum != nil {
+		setChecksumHeaders(w, checksum)
+		if etagMatches(r.Header.Get("If-None-Match"), w.Header().Get("ETag")) {
+			w.WriteHeader(http.StatusNotModified)
+			return ""
+		}
+	}
+
+	contentType := mime.TypeByExtension(path.Ext(filePath))
+	if contentType == "" {
+		contentType = "application/octet-stream"
+	}
+	w.Header().Set("Content-Type", contentType)
+	w.Header().Set("Content-Length", strconv.FormatInt(info.Size(), 10))
+	w.Header().Set("Content-Disposition", fmt.Sprintf("attachment; filename=%q", path.Base(filePath)))
+	w.Header().Set("Accept-Ranges", "none")
+	w.WriteHeader(http.StatusOK)
+	if r.Method == http.MethodHead {
+		return ""
+	}
+
+	_, err = h.filesystem.Copy(w, archiveFile)
+	if err != nil {
+		return fmt.Sprintf("failed to send file: %s", err)
+	}
+
+	return ""
+}
+
+// authenticate returns the user and the http status and error if the request is not allowed.
+func (h *Handler) authenticate(r *http.Request, id domain.SupportArchiveID) (string, int, error) {
+	token := r.URL.Query().Get(TokenQueryParameter)
+	if token != "" {
+		status, err := h.verifyDownloadToken(r.Context(), id, token)
+		return tokenUser, status, err
+	}
+
+	return h.reviewServiceAccount(r, downloadAttributes(id))
+}
+
+// reviewServiceAccount authenticates the bearer token of the request and checks if the ServiceAccount has the
+// permission of the attributes. ServiceAccount tokens are only accepted if service account reviews are enabled.
+func (h *Handler) reviewServiceAccount(r *http.Request, attributes authorizationv1.ResourceAttributes) (string, int, error) {
+	bearerToken, found := strings.CutPrefix(r.Header.Get("Authorization"), "Bearer ")
+	if found && h.serviceAccounts != nil {
+		return h.serviceAccounts.review(r.Context(), bearerToken, attributes)
+	}
+
+	return "", http.StatusUnauthorized, fmt.Errorf("missing credentials")
+}
+
+func (h *Handler) verifyDownloadToken(ctx context.Context, id domain.SupportArchiveID, token string) (int, error) {
+	cr, err := h.supportArchives.SupportArchives(id.Namespace).Get(ctx, id.Name, metav1.GetOptions{})
+	if k8sErrs.IsNotFound(err) {
+		return http.StatusNotFound, fmt.Errorf("support archive does not exist")
+	} else if err != nil {
+		return http.StatusInternalServerError, fmt.Errorf("failed to get support archive: %w", err)
+	}
+	if !cr.GetDeletionTimestamp().IsZero() {
+		return http.StatusNotFound, fmt.Errorf("support archive is being deleted")
+	}
+
+	err = h.tokenVerifier.Verify(id, cr.UID, token, h.now())
+	if err != nil {
+		return http.StatusForbidden, err
+	}
+
+	return http.StatusOK, nil
+}
+
+// parseArchivePath returns the support archive and the file path of a path /<namespace>/<name>.zip[/<file path>].
+// The file path is empty if the whole archive is requested.
+func parseArchivePath(urlPath string) (domain.SupportArchiveID, string, bool) {
+	namespace, archivePath, found := strings.Cut(strings.TrimPrefix(urlPath, "/"), "/")
+	fileName, filePath, hasFile := strings.Cut(archivePath, "/")
+	name, isArchive := strings.CutSuffix(fileName, archiveSuffix)
+	if !found || !isArchive {
+		return domain.SupportArchiveID{}, "", false
+	}
+
+	id := domain.SupportArchiveID{Namespace: namespace, Name: name}
+	if len(validation.IsDNS1123Label(namespace)) > 0 || len(validation.IsDNS1123Subdomain(name)) > 0 {
+		return id, "", false
+	}
+	if hasFile && !fs.ValidPath(filePath) {
+		return id, filePath, false
+	}
+
+	return id, filePath, true
+}
+
+// archiveDownloadPath returns the escaped path of the archive or of a file in the archive.
+func archiveDownloadPath(id domain.SupportArchiveID, filePath string) string {
+	downloadPath := path.Join("/", id.Namespace, id.Name+archiveSuffix, filePath)
+	return (&url.URL{Path: downloadPath}).EscapedPath()
+}
+
+func allowedMethod(w http.ResponseWriter, r *http.Request) bool {
+	if r.Method != http.MethodGet && r.Method != http.MethodHead {
+		http.Error(w, http.StatusText(http.StatusMethodNotAllowed), http.StatusMethodNotAllowed)
+		return false
+	}
+
+	return true
+}
+
+func writeAuthError(w http.ResponseWriter, status int) {
+	if status == http.StatusUnauthorized {
+		w.Header().Set("WWW-Authenticate", "Bearer")
+	}
+	http.Error(w, http.StatusText(status), status)
+}
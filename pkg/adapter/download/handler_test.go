@@ -0,0 +1,727 @@
+package download
+
+import (
+	"archive/zip"
+	"bytes"
+	"context"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/hex"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	libapi "github.com/cloudogu/k8s-support-archive-lib/api/v1"
+	"github.com/go-logr/logr"
+	"github.com/go-logr/logr/funcr"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+	authenticationv1 "k8s.io/api/authentication/v1"
+	authorizationv1 "k8s.io/api/authorization/v1"
+	k8sErrs "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+
+	"github.com/cloudogu/k8s-support-archive-operator/pkg/adapter/filesystem"
+	"github.com/cloudogu/k8s-support-archive-operator/pkg/domain"
+)
+
+const testArchivePath = "/ecosystem/archive-123.zip"
+
+// newTestAuditLog returns a logger writing all messages to the buffer.
+func newTestAuditLog(buffer *bytes.Buffer) logr.Logger {
+	return funcr.New(func(_, args string) {
+		buffer.WriteString(args + "\n")
+	}, funcr.Options{})
+}
+
+// writeTestArchiveFile creates the archive in a temporary directory and returns a path provider for it.
+func writeTestArchiveFile(t *testing.T) archiveRepository {
+	t.Helper()
+
+	path := filepath.Join(t.TempDir(), "archive-123.zip")
+	require.NoError(t, os.WriteFile(path, []byte("zip content"), 0644))
+	archives := newMockArchiveRepository(t)
+	archives.EXPECT().GetArchivePath(testID).Return(path).Maybe()
+
+	return archives
+}
+
+// writeTestZipArchive creates a zip archive with a manifest in a temporary directory and returns its path.
+func writeTestZipArchive(t *testing.T, files map[string]string) string {
+	t.Helper()
+
+	var buffer bytes.Buffer
+	zipWriter := zip.NewWriter(&buffer)
+	manifest := domain.ArchiveManifest{Namespace: testNamespace, Name: testName}
+	for name, content := range files {
+		writer, err := zipWriter.Create(name)
+		require.NoError(t, err)
+		_, err = writer.Write([]byte(content))
+		require.NoError(t, err)
+		checksum := sha256.Sum256([]byte(content))
+		manifest.Files = append(manifest.Files, domain.ManifestFile{Path: name, Size: int64(len(content)), SHA256: hex.EncodeToString(checksum[:])})
+	}
+	manifest.SortFiles()
+	writer, err := zipWriter.Create(domain.ManifestFileName)
+	require.NoError(t, err)
+	require.NoError(t, json.NewEncoder(writer).Encode(manifest))
+	require.NoError(t, zipWriter.Close())
+
+	path := filepath.Join(t.TempDir(), "archive-123.zip")
+	require.NoError(t, os.WriteFile(path, buffer.Bytes(), 0644))
+
+	return path
+}
+
+func newAuthenticatedTokenReviewsMock(t *testing.T) tokenReviewInterface {
+	t.Helper()
+
+	reviews := newMockTokenReviewInterface(t)
+	reviews.EXPECT().Create(mock.Anything, mock.Anything, metav1.CreateOptions{}).
+		Return(&authenticationv1.TokenReview{Status: authenticationv1.TokenReviewStatus{
+			Authenticated: true,
+			User:          authenticationv1.UserInfo{Username: "system:serviceaccount:ecosystem:support"},
+			Audiences:     []string{TokenAudience},
+		}}, nil)
+
+	return reviews
+}
+
+func newAccessReviewsMock(t *testing.T, attributes authorizationv1.ResourceAttributes, allowed bool) subjectAccessReviewInterface {
+	t.Helper()
+
+	reviews := newMockSubjectAccessReviewInterface(t)
+	reviews.EXPECT().Create(mock.Anything, &authorizationv1.SubjectAccessReview{Spec: authorizationv1.SubjectAccessReviewSpec{
+		ResourceAttributes: &attributes,
+		User:               "system:serviceaccount:ecosystem:support",
+	}}, metav1.CreateOptions{}).
+		Return(&authorizationv1.SubjectAccessReview{Status: authorizationv1.SubjectAccessReviewStatus{Allowed: allowed, Reason: "no RBAC policy matched"}}, nil)
+
+	return reviews
+}
+
+// newAuthorizedServiceAccountReviews returns reviews allowing the ServiceAccount the access of the attributes.
+func newAuthorizedServiceAccountReviews(t *testing.T, attributes authorizationv1.ResourceAttributes) *ServiceAccountReviews {
+	t.Helper()
+
+	return &ServiceAccountReviews{
+		TokenReviews:  newAuthenticatedTokenReviewsMock(t),
+		AccessReviews: newAccessReviewsMock(t, attributes, true),
+	}
+}
+
+func newAuthenticatedRequest(method string, target string) *http.Request {
+	request := httptest.NewRequest(method, target, nil)
+	request.Header.Set("Authorization", "Bearer sa-token")
+	return request
+}
+
+func newSupportArchivesMock(t *testing.T, cr *libapi.SupportArchive, err error) supportArchiveV1Interface {
+	t.Helper()
+
+	v1Mock := newMockSupportArchiveV1Interface(t)
+	clientMock := newMockSupportArchiveInterface(t)
+	v1Mock.EXPECT().SupportArchives(testNamespace).Return(clientMock)
+	clientMock.EXPECT().Get(mock.Anything, testName, metav1.GetOptions{}).Return(cr, err)
+
+	return v1Mock
+}
+
+func TestHandler_ServeHTTP(t *testing.T) {
+	t.Run("should serve archive with valid download token", func(t *testing.T) {
+		// given
+		verifier := newMockTokenVerifier(t)
+		verifier.EXPECT().Verify(testID, testCR.UID, "123.abc", testNow).Return(nil)
+		var auditLog bytes.Buffer
+		sut := NewHandler(newSupportArchivesMock(t, testCR, nil), verifier, nil, writeTestArchiveFile(t), filesystem.FileSystem{}, newTestAuditLog(&auditLog))
+		sut.now = func() time.Time { return testNow }
+		recorder := httptest.NewRecorder()
+
+		// when
+		sut.ServeHTTP(recorder, httptest.NewRequest(http.MethodGet, testArchivePath+"?token=123.abc", nil))
+
+		// then
+		assert.Equal(t, http.StatusOK, recorder.Code)
+		assert.Equal(t, "zip content", recorder.Body.String())
+		assert.Equal(t, "application/zip", recorder.Header().Get("Content-Type"))
+		assert.Equal(t, `attachment; filename="archive-123.zip"`, recorder.Header().Get("Content-Disposition"))
+		assert.Contains(t, auditLog.String(), `"namespace"="ecosystem" "name"="archive-123" "file"="" "user"="download-token"`)
+		assert.Contains(t, auditLog.String(), `"status"=200 "bytes"=11 "reason"=""`)
+	})
+	t.Run("should reject invalid download token", func(t *testing.T) {
+		// given
+		verifier := newMockTokenVerifier(t)
+		verifier.EXPECT().Verify(testID, testCR.UID, "123.abc", mock.Anything).Return(errExpiredToken)
+		var auditLog bytes.Buffer
+		sut := NewHandler(newSupportArchivesMock(t, testCR, nil), verifier, nil, nil, nil, newTestAuditLog(&auditLog))
+		recorder := httptest.NewRecorder()
+
+		// when
+		sut.ServeHTTP(recorder, httptest.NewRequest(http.MethodGet, testArchivePath+"?token=123.abc", nil))
+
+		// then
+		assert.Equal(t, http.StatusForbidden, recorder.Code)
+		assert.Contains(t, auditLog.String(), `"status"=403 "bytes"=10 "reason"="download token expired"`)
+	})
+	t.Run("should reject download token of deleted archive", func(t *testing.T) {
+		// given
+		notFound := k8sErrs.NewNotFound(schema.GroupResource{}, testName)
+		sut := NewHandler(newSupportArchivesMock(t, nil, notFound), nil, nil, nil, nil, logr.Discard())
+		recorder := httptest.NewRecorder()
+
+		// when
+		sut.ServeHTTP(recorder, httptest.NewRequest(http.MethodGet, testArchivePath+"?token=123.abc", nil))
+
+		// then
+		assert.Equal(t, http.StatusNotFound, recorder.Code)
+	})
+	t.Run("should reject download token of archive being deleted", func(t *testing.T) {
+		// given
+		deleting := testCR.DeepCopy()
+		deleting.DeletionTimestamp = &metav1.Time{Time: testNow}
+		sut := NewHandler(newSupportArchivesMock(t, deleting, nil), nil, nil, nil, nil, logr.Discard())
+		recorder := httptest.NewRecorder()
+
+		// when
+		sut.ServeHTTP(recorder, httptest.NewRequest(http.MethodGet, testArchivePath+"?token=123.abc", nil))
+
+		// then
+		assert.Equal(t, http.StatusNotFound, recorder.Code)
+	})
+	t.Run("should return internal server error on error getting archive", func(t *testing.T) {
+		// given
+		sut := NewHandler(newSupportArchivesMock(t, nil, assert.AnError), nil, nil, nil, nil, logr.Discard())
+		recorder := httptest.NewRecorder()
+
+		// when
+		sut.ServeHTTP(recorder, httptest.NewRequest(http.MethodGet, testArchivePath+"?token=123.abc", nil))
+
+		// then
+		assert.Equal(t, http.StatusInternalServerError, recorder.Code)
+	})
+	t.Run("should serve archive with authenticated service account token", func(t *testing.T) {
+		// given
+		tokenReviews := newMockTokenReviewInterface(t)
+		tokenReviews.EXPECT().Create(mock.Anything, &authenticationv1.TokenReview{Spec: authenticationv1.TokenReviewSpec{Token: "sa-token", Audiences: []string{TokenAudience}}}, metav1.CreateOptions{}).
+			Return(&authenticationv1.TokenReview{Status: authenticationv1.TokenReviewStatus{
+				Authenticated: true,
+				User:          authenticationv1.UserInfo{Username: "system:serviceaccount:ecosystem:support", UID: "uid-1", Groups: []string{"system:serviceaccounts"}, Extra: map[string]authenticationv1.ExtraValue{"node": {"node-1"}}},
+				Audiences:     []string{TokenAudience},
+			}}, nil)
+		accessReviews := newMockSubjectAccessReviewInterface(t)
+		accessReviews.EXPECT().Create(mock.Anything, &authorizationv1.SubjectAccessReview{Spec: authorizationv1.SubjectAccessReviewSpec{
+			ResourceAttributes: &authorizationv1.ResourceAttributes{
+				Namespace:   "ecosystem",
+				Verb:        "get",
+				Group:       "k8s.cloudogu.com",
+				Resource:    "supportarchives",
+				Subresource: "download",
+				Name:        "archive-123",
+			},
+			User:   "system:serviceaccount:ecosystem:support",
+			Groups: []string{"system:serviceaccounts"},
+			Extra:  map[string]authorizationv1.ExtraValue{"node": {"node-1"}},
+			UID:    "uid-1",
+		}}, metav1.CreateOptions{}).
+			Return(&authorizationv1.SubjectAccessReview{Status: authorizationv1.SubjectAccessReviewStatus{Allowed: true}}, nil)
+		var auditLog bytes.Buffer
+		sut := NewHandler(nil, nil, &ServiceAccountReviews{TokenReviews: tokenReviews, AccessReviews: accessReviews}, writeTestArchiveFile(t), filesystem.FileSystem{}, newTestAuditLog(&auditLog))
+		recorder := httptest.NewRecorder()
+		request := httptest.NewRequest(http.MethodGet, testArchivePath, nil)
+		request.Header.Set("Authorization", "Bearer sa-token")
+
+		// when
+		sut.ServeHTTP(recorder, request)
+
+		// then
+		assert.Equal(t, http.StatusOK, recorder.Code)
+		assert.Equal(t, "zip content", recorder.Body.String())
+		assert.Contains(t, auditLog.String(), `"user"="system:serviceaccount:ecosystem:support"`)
+	})
+	t.Run("should reject unauthenticated service account token", func(t *testing.T) {
+		// given
+		reviews := newMockTokenReviewInterface(t)
+		reviews.EXPECT().Create(mock.Anything, mock.Anything, metav1.CreateOptions{}).
+			Return(&authenticationv1.TokenReview{Status: authenticationv1.TokenReviewStatus{Error: "token expired"}}, nil)
+		sut := NewHandler(nil, nil, &ServiceAccountReviews{TokenReviews: reviews}, nil, nil, logr.Discard())
+		recorder := httptest.NewRecorder()
+		request := httptest.NewRequest(http.MethodGet, testArchivePath, nil)
+		request.Header.Set("Authorization", "Bearer sa-token")
+
+		// when
+		sut.ServeHTTP(recorder, request)
+
+		// then
+		assert.Equal(t, http.StatusUnauthorized, recorder.Code)
+		assert.Equal(t, "Bearer", recorder.Header().Get("WWW-Authenticate"))
+	})
+	t.Run("should return internal server error on error reviewing token", func(t *testing.T) {
+		// given
+		reviews := newMockTokenReviewInterface(t)
+		reviews.EXPECT().Create(mock.Anything, mock.Anything, metav1.CreateOptions{}).Return(nil, assert.AnError)
+		sut := NewHandler(nil, nil, &ServiceAccountReviews{TokenReviews: reviews}, nil, nil, logr.Discard())
+		recorder := httptest.NewRecorder()
+		request := httptest.NewRequest(http.MethodGet, testArchivePath, nil)
+		request.Header.Set("Authorization", "Bearer sa-token")
+
+		// when
+		sut.ServeHTTP(recorder, request)
+
+		// then
+		assert.Equal(t, http.StatusInternalServerError, recorder.Code)
+	})
+	t.Run("should reject service account token for other audiences", func(t *testing.T) {
+		// given
+		reviews := newMockTokenReviewInterface(t)
+		reviews.EXPECT().Create(mock.Anything, mock.Anything, metav1.CreateOptions{}).
+			Return(&authenticationv1.TokenReview{Status: authenticationv1.TokenReviewStatus{
+				Authenticated: true,
+				User:          authenticationv1.UserInfo{Username: "system:serviceaccount:ecosystem:support"},
+				Audiences:     []string{"https://kubernetes.default.svc"},
+			}}, nil)
+		var auditLog bytes.Buffer
+		sut := NewHandler(nil, nil, &ServiceAccountReviews{TokenReviews: reviews}, nil, nil, newTestAuditLog(&auditLog))
+		recorder := httptest.NewRecorder()
+
+		// when
+		sut.ServeHTTP(recorder, newAuthenticatedRequest(http.MethodGet, testArchivePath))
+
+		// then
+		assert.Equal(t, http.StatusUnauthorized, recorder.Code)
+		assert.Contains(t, auditLog.String(), `"reason"="token is not valid for audience k8s-support-archive-operator"`)
+	})
+	t.Run("should reject authenticated service account without permission to download the archive", func(t *testing.T) {
+		// given
+		reviews := &ServiceAccountReviews{
+			TokenReviews:  newAuthenticatedTokenReviewsMock(t),
+			AccessReviews: newAccessReviewsMock(t, downloadAttributes(testID), false),
+		}
+		var auditLog bytes.Buffer
+		sut := NewHandler(nil, nil, reviews, nil, nil, newTestAuditLog(&auditLog))
+		recorder := httptest.NewRecorder()
+
+		// when
+		sut.ServeHTTP(recorder, newAuthenticatedRequest(http.MethodGet, testArchivePath))
+
+		// then
+		assert.Equal(t, http.StatusForbidden, recorder.Code)
+		assert.Empty(t, recorder.Header().Get("WWW-Authenticate"))
+		assert.Contains(t, auditLog.String(), `"user"="system:serviceaccount:ecosystem:support"`)
+		assert.Contains(t, auditLog.String(), `"status"=403 "bytes"=10 "reason"="user is not allowed to get supportarchives/download in namespace \"ecosystem\": no RBAC policy matched"`)
+	})
+	t.Run("should reject authenticated service account without permission to download a file of the archive", func(t *testing.T) {
+		// given
+		reviews := &ServiceAccountReviews{
+			TokenReviews:  newAuthenticatedTokenReviewsMock(t),
+			AccessReviews: newAccessReviewsMock(t, downloadAttributes(testID), false),
+		}
+		sut := NewHandler(nil, nil, reviews, nil, nil, logr.Discard())
+		recorder := httptest.NewRecorder()
+
+		// when
+		sut.ServeHTTP(recorder, newAuthenticatedRequest(http.MethodGet, testArchivePath+"/secrets.yaml"))
+
+		// then
+		assert.Equal(t, http.StatusForbidden, recorder.Code)
+	})
+	t.Run("should return internal server error on error reviewing access", func(t *testing.T) {
+		// given
+		accessReviews := newMockSubjectAccessReviewInterface(t)
+		accessReviews.EXPECT().Create(mock.Anything, mock.Anything, metav1.CreateOptions{}).Return(nil, assert.AnError)
+		sut := NewHandler(nil, nil, &ServiceAccountReviews{TokenReviews: newAuthenticatedTokenReviewsMock(t), AccessReviews: accessReviews}, nil, nil, logr.Discard())
+		recorder := httptest.NewRecorder()
+
+		// when
+		sut.ServeHTTP(recorder, newAuthenticatedRequest(http.MethodGet, testArchivePath))
+
+		// then
+		assert.Equal(t, http.StatusInternalServerError, recorder.Code)
+	})
+	t.Run("should ignore service account token if token review is disabled", func(t *testing.T) {
+		// given
+		var auditLog bytes.Buffer
+		sut := NewHandler(nil, nil, nil, nil, nil, newTestAuditLog(&auditLog))
+		recorder := httptest.NewRecorder()
+		request := httptest.NewRequest(http.MethodGet, testArchivePath, nil)
+		request.Header.Set("Authorization", "Bearer sa-token")
+
+		// when
+		sut.ServeHTTP(recorder, request)
+
+		// then
+		assert.Equal(t, http.StatusUnauthorized, recorder.Code)
+		assert.Contains(t, auditLog.String(), `"reason"="missing credentials"`)
+	})
+	t.Run("should return not found for missing archive", func(t *testing.T) {
+		// given
+		archives := newMockArchiveRepository(t)
+		archives.EXPECT().GetArchivePath(testID).Return(filepath.Join(t.TempDir(), "missing.zip"))
+		sut := NewHandler(nil, nil, newAuthorizedServiceAccountReviews(t, downloadAttributes(testID)), archives, filesystem.FileSystem{}, logr.Discard())
+		recorder := httptest.NewRecorder()
+		request := httptest.NewRequest(http.MethodGet, testArchivePath, nil)
+		request.Header.Set("Authorization", "Bearer sa-token")
+
+		// when
+		sut.ServeHTTP(recorder, request)
+
+		// then
+		assert.Equal(t, http.StatusNotFound, recorder.Code)
+	})
+	t.Run("should return internal server error on error opening archive", func(t *testing.T) {
+		// given
+		archives := newMockArchiveRepository(t)
+		archives.EXPECT().GetArchivePath(testID).Return("/archives/ecosystem/archive-123.zip")
+		fsMock := newMockVolumeFs(t)
+		fsMock.EXPECT().Stat(mock.Anything, "/archives/ecosystem/archive-123.zip").Return(nil, nil)
+		fsMock.EXPECT().Open(mock.Anything, "/archives/ecosystem/archive-123.zip").Return(nil, assert.AnError)
+		sut := NewHandler(nil, nil, newAuthorizedServiceAccountReviews(t, downloadAttributes(testID)), archives, fsMock, logr.Discard())
+		recorder := httptest.NewRecorder()
+		request := httptest.NewRequest(http.MethodGet, testArchivePath, nil)
+		request.Header.Set("Authorization", "Bearer sa-token")
+
+		// when
+		sut.ServeHTTP(recorder, request)
+
+		// then
+		assert.Equal(t, http.StatusInternalServerError, recorder.Code)
+	})
+	t.Run("should reject requests without credentials", func(t *testing.T) {
+		// given
+		sut := NewHandler(nil, nil, nil, nil, nil, logr.Discard())
+		recorder := httptest.NewRecorder()
+
+		// when
+		sut.ServeHTTP(recorder, httptest.NewRequest(http.MethodGet, testArchivePath, nil))
+
+		// then
+		assert.Equal(t, http.StatusUnauthorized, recorder.Code)
+	})
+	t.Run("should reject other methods", func(t *testing.T) {
+		// given
+		sut := NewHandler(nil, nil, nil, nil, nil, logr.Discard())
+		recorder := httptest.NewRecorder()
+
+		// when
+		sut.ServeHTTP(recorder, httptest.NewRequest(http.MethodDelete, testArchivePath, nil))
+
+		// then
+		assert.Equal(t, http.StatusMethodNotAllowed, recorder.Code)
+	})
+	t.Run("should return not found for invalid paths", func(t *testing.T) {
+		sut := NewHandler(nil, nil, nil, nil, nil, logr.Discard())
+
+		for _, path := range []string{"/", "/ecosystem", "/ecosystem/archive-123", "/ecosystem/nested/archive-123.zip", "/Ecosystem/archive-123.zip", "/ecosystem/..zip",
+			"/ecosystem/archive-123.zip/", "/ecosystem/archive-123.zip/../other.zip", "/ecosystem/archive-123.zip/Logs//logs.log"} {
+			recorder := httptest.NewRecorder()
+
+			sut.ServeHTTP(recorder, httptest.NewRequest(http.MethodGet, path, nil))
+
+			assert.Equal(t, http.StatusNotFound, recorder.Code, path)
+		}
+	})
+}
+
+func TestHandler_ServeHTTP_archiveRanges(t *testing.T) {
+	checksum := sha256.Sum256([]byte("zip content"))
+	etag := `"` + hex.EncodeToString(checksum[:]) + `"`
+
+	t.Run("should serve requested range with checksum headers", func(t *testing.T) {
+		// given
+		sut := NewHandler(nil, nil, newAuthorizedServiceAccountReviews(t, downloadAttributes(testID)), writeTestArchiveFile(t), filesystem.FileSystem{}, logr.Discard())
+		recorder := httptest.NewRecorder()
+		request := newAuthenticatedRequest(http.MethodGet, testArchivePath)
+		request.Header.Set("Range", "bytes=4-10")
+
+		// when
+		sut.ServeHTTP(recorder, request)
+
+		// then
+		assert.Equal(t, http.StatusPartialContent, recorder.Code)
+		assert.Equal(t, "content", recorder.Body.String())
+		assert.Equal(t, "bytes 4-10/11", recorder.Header().Get("Content-Range"))
+		assert.Equal(t, "bytes", recorder.Header().Get("Accept-Ranges"))
+		assert.Equal(t, etag, recorder.Header().Get("ETag"))
+		assert.Equal(t, "sha-256=:"+base64.StdEncoding.EncodeToString(checksum[:])+":", recorder.Header().Get("Repr-Digest"))
+	})
+	t.Run("should return not modified for matching etag", func(t *testing.T) {
+		// given
+		sut := NewHandler(nil, nil, newAuthorizedServiceAccountReviews(t, downloadAttributes(testID)), writeTestArchiveFile(t), filesystem.FileSystem{}, logr.Discard())
+		recorder := httptest.NewRecorder()
+		request := newAuthenticatedRequest(http.MethodGet, testArchivePath)
+		request.Header.Set("If-None-Match", etag)
+
+		// when
+		sut.ServeHTTP(recorder, request)
+
+		// then
+		assert.Equal(t, http.StatusNotModified, recorder.Code)
+		assert.Empty(t, recorder.Body.String())
+	})
+	t.Run("should serve whole archive if range does not match etag", func(t *testing.T) {
+		// given
+		sut := NewHandler(nil, nil, newAuthorizedServiceAccountReviews(t, downloadAttributes(testID)), writeTestArchiveFile(t), filesystem.FileSystem{}, logr.Discard())
+		recorder := httptest.NewRecorder()
+		request := newAuthenticatedRequest(http.MethodGet, testArchivePath)
+		request.Header.Set("Range", "bytes=4-10")
+		request.Header.Set("If-Range", `"outdated"`)
+
+		// when
+		sut.ServeHTTP(recorder, request)
+
+		// then
+		assert.Equal(t, http.StatusOK, recorder.Code)
+		assert.Equal(t, "zip content", recorder.Body.String())
+	})
+	t.Run("should not send body for head requests", func(t *testing.T) {
+		// given
+		sut := NewHandler(nil, nil, newAuthorizedServiceAccountReviews(t, downloadAttributes(testID)), writeTestArchiveFile(t), filesystem.FileSystem{}, logr.Discard())
+		recorder := httptest.NewRecorder()
+
+		// when
+		sut.ServeHTTP(recorder, newAuthenticatedRequest(http.MethodHead, testArchivePath))
+
+		// then
+		assert.Equal(t, http.StatusOK, recorder.Code)
+		assert.Equal(t, "11", recorder.Header().Get("Content-Length"))
+		assert.Empty(t, recorder.Body.String())
+	})
+}
+
+func TestHandler_ServeHTTP_archiveFile(t *testing.T) {
+	configContent := `{"key":"value"}`
+	checksum := sha256.Sum256([]byte(configContent))
+	etag := `"` + hex.EncodeToString(checksum[:]) + `"`
+	newArchives := func(t *testing.T) archiveRepository {
+		archives := newMockArchiveRepository(t)
+		archives.EXPECT().GetArchivePath(testID).Return(writeTestZipArchive(t, map[string]string{"SystemState/config.json": configContent}))
+		return archives
+	}
+
+	t.Run("should serve file of archive with valid download token", func(t *testing.T) {
+		// given
+		verifier := newMockTokenVerifier(t)
+		verifier.EXPECT().Verify(testID, testCR.UID, "123.abc", mock.Anything).Return(nil)
+		var auditLog bytes.Buffer
+		sut := NewHandler(newSupportArchivesMock(t, testCR, nil), verifier, nil, newArchives(t), filesystem.FileSystem{}, newTestAuditLog(&auditLog))
+		recorder := httptest.NewRecorder()
+
+		// when
+		sut.ServeHTTP(recorder, httptest.NewRequest(http.MethodGet, testArchivePath+"/SystemState/config.json?token=123.abc", nil))
+
+		// then
+		assert.Equal(t, http.StatusOK, recorder.Code)
+		assert.Equal(t, configContent, recorder.Body.String())
+		assert.Equal(t, "application/json", recorder.Header().Get("Content-Type"))
+		assert.Equal(t, "15", recorder.Header().Get("Content-Length"))
+		assert.Equal(t, `attachment; filename="config.json"`, recorder.Header().Get("Content-Disposition"))
+		assert.Equal(t, "none", recorder.Header().Get("Accept-Ranges"))
+		assert.Equal(t, etag, recorder.Header().Get("ETag"))
+		assert.Contains(t, auditLog.String(), `"file"="SystemState/config.json" "user"="download-token"`)
+	})
+	t.Run("should return not modified for matching etag", func(t *testing.T) {
+		// given
+		sut := NewHandler(nil, nil, newAuthorizedServiceAccountReviews(t, downloadAttributes(testID)), newArchives(t), filesystem.FileSystem{}, logr.Discard())
+		recorder := httptest.NewRecorder()
+		request := newAuthenticatedRequest(http.MethodGet, testArchivePath+"/SystemState/config.json")
+		request.Header.Set("If-None-Match", `"other", `+etag)
+
+		// when
+		sut.ServeHTTP(recorder, request)
+
+		// then
+		assert.Equal(t, http.StatusNotModified, recorder.Code)
+		assert.Empty(t, recorder.Body.String())
+	})
+	t.Run("should return not found for missing file", func(t *testing.T) {
+		// given
+		var auditLog bytes.Buffer
+		sut := NewHandler(nil, nil, newAuthorizedServiceAccountReviews(t, downloadAttributes(testID)), newArchives(t), filesystem.FileSystem{}, newTestAuditLog(&auditLog))
+		recorder := httptest.NewRecorder()
+
+		// when
+		sut.ServeHTTP(recorder, newAuthenticatedRequest(http.MethodGet, testArchivePath+"/SystemState/missing.json"))
+
+		// then
+		assert.Equal(t, http.StatusNotFound, recorder.Code)
+		assert.Contains(t, auditLog.String(), `"reason"="file does not exist"`)
+	})
+	t.Run("should return not found for directories", func(t *testing.T) {
+		// given
+		sut := NewHandler(nil, nil, newAuthorizedServiceAccountReviews(t, downloadAttributes(testID)), newArchives(t), filesystem.FileSystem{}, logr.Discard())
+		recorder := httptest.NewRecorder()
+
+		// when
+		sut.ServeHTTP(recorder, newAuthenticatedRequest(http.MethodGet, testArchivePath+"/SystemState"))
+
+		// then
+		assert.Equal(t, http.StatusNotFound, recorder.Code)
+	})
+	t.Run("should return not found for missing archive", func(t *testing.T) {
+		// given
+		archives := newMockArchiveRepository(t)
+		archives.EXPECT().GetArchivePath(testID).Return(filepath.Join(t.TempDir(), "missing.zip"))
+		sut := NewHandler(nil, nil, newAuthorizedServiceAccountReviews(t, downloadAttributes(testID)), archives, filesystem.FileSystem{}, logr.Discard())
+		recorder := httptest.NewRecorder()
+
+		// when
+		sut.ServeHTTP(recorder, newAuthenticatedRequest(http.MethodGet, testArchivePath+"/SystemState/config.json"))
+
+		// then
+		assert.Equal(t, http.StatusNotFound, recorder.Code)
+	})
+}
+
+func TestHandler_ServeHTTP_list(t *testing.T) {
+	otherID := domain.SupportArchiveID{Namespace: "other", Name: "archive-456"}
+
+	t.Run("should list archives with per-file download links", func(t *testing.T) {
+		// given
+		archives := newMockArchiveRepository(t)
+		archives.EXPECT().List(mock.Anything).Return([]domain.SupportArchiveID{otherID, testID}, nil)
+		archives.EXPECT().GetArchivePath(testID).Return(writeTestZipArchive(t, map[string]string{"Logs/a b.log": "LOGS", "Events/events.log": "EVENTS"}))
+		archives.EXPECT().GetArchivePath(otherID).Return(writeTestZipArchive(t, nil))
+		var auditLog bytes.Buffer
+		sut := NewHandler(nil, nil, newAuthorizedServiceAccountReviews(t, listAttributes("")), archives, filesystem.FileSystem{}, newTestAuditLog(&auditLog))
+		recorder := httptest.NewRecorder()
+
+		// when
+		sut.ServeHTTP(recorder, newAuthenticatedRequest(http.MethodGet, ListPath))
+
+		// then
+		require.Equal(t, http.StatusOK, recorder.Code)
+		assert.Equal(t, "application/json", recorder.Header().Get("Content-Type"))
+		var entries []archiveListEntry
+		require.NoError(t, json.Unmarshal(recorder.Body.Bytes(), &entries))
+		require.Len(t, entries, 2)
+		assert.Equal(t, "ecosystem", entries[0].Namespace)
+		assert.Equal(t, "/ecosystem/archive-123.zip", entries[0].DownloadPath)
+		require.Len(t, entries[0].Files, 3)
+		assert.Equal(t, "Events/events.log", entries[0].Files[0].Path)
+		assert.Equal(t, int64(6), entries[0].Files[0].Size)
+		assert.Equal(t, "/ecosystem/archive-123.zip/Logs/a%20b.log", entries[0].Files[1].DownloadPath)
+		assert.NotEmpty(t, entries[0].Files[1].SHA256)
+		assert.Empty(t, entries[0].Files[2].SHA256, "manifest is not listed in itself")
+		assert.Equal(t, "/other/archive-456.zip", entries[1].DownloadPath)
+		assert.Empty(t, entries[1].Files, "archive with only a manifest has no per-file links")
+		assert.Contains(t, auditLog.String(), `"list" "user"="system:serviceaccount:ecosystem:support"`)
+	})
+	t.Run("should filter archives by namespace", func(t *testing.T) {
+		// given
+		archives := newMockArchiveRepository(t)
+		archives.EXPECT().List(mock.Anything).Return([]domain.SupportArchiveID{otherID, testID}, nil)
+		archives.EXPECT().GetArchivePath(otherID).Return(writeTestZipArchive(t, nil))
+		sut := NewHandler(nil, nil, newAuthorizedServiceAccountReviews(t, listAttributes("other")), archives, filesystem.FileSystem{}, logr.Discard())
+		recorder := httptest.NewRecorder()
+
+		// when
+		sut.ServeHTTP(recorder, newAuthenticatedRequest(http.MethodGet, ListPath+"?namespace=other"))
+
+		// then
+		require.Equal(t, http.StatusOK, recorder.Code)
+		var entries []archiveListEntry
+		require.NoError(t, json.Unmarshal(recorder.Body.Bytes(), &entries))
+		require.Len(t, entries, 1)
+		assert.Equal(t, "archive-456", entries[0].Name)
+	})
+	t.Run("should skip archives deleted while listing", func(t *testing.T) {
+		// given
+		archives := newMockArchiveRepository(t)
+		archives.EXPECT().List(mock.Anything).Return([]domain.SupportArchiveID{testID}, nil)
+		archives.EXPECT().GetArchivePath(testID).Return(filepath.Join(t.TempDir(), "missing.zip"))
+		sut := NewHandler(nil, nil, newAuthorizedServiceAccountReviews(t, listAttributes("")), archives, filesystem.FileSystem{}, logr.Discard())
+		recorder := httptest.NewRecorder()
+
+		// when
+		sut.ServeHTTP(recorder, newAuthenticatedRequest(http.MethodGet, ListPath))
+
+		// then
+		require.Equal(t, http.StatusOK, recorder.Code)
+		assert.Equal(t, "[]\n", recorder.Body.String())
+	})
+	t.Run("should return internal server error on error listing archives", func(t *testing.T) {
+		// given
+		archives := newMockArchiveRepository(t)
+		archives.EXPECT().List(mock.Anything).Return(nil, assert.AnError)
+		sut := NewHandler(nil, nil, newAuthorizedServiceAccountReviews(t, listAttributes("")), archives, filesystem.FileSystem{}, logr.Discard())
+		recorder := httptest.NewRecorder()
+
+		// when
+		sut.ServeHTTP(recorder, newAuthenticatedRequest(http.MethodGet, ListPath))
+
+		// then
+		assert.Equal(t, http.StatusInternalServerError, recorder.Code)
+	})
+	t.Run("should reject authenticated service account without permission to list all archives", func(t *testing.T) {
+		// given
+		reviews := &ServiceAccountReviews{
+			TokenReviews:  newAuthenticatedTokenReviewsMock(t),
+			AccessReviews: newAccessReviewsMock(t, listAttributes(""), false),
+		}
+		var auditLog bytes.Buffer
+		sut := NewHandler(nil, nil, reviews, nil, nil, newTestAuditLog(&auditLog))
+		recorder := httptest.NewRecorder()
+
+		// when
+		sut.ServeHTTP(recorder, newAuthenticatedRequest(http.MethodGet, ListPath))
+
+		// then
+		assert.Equal(t, http.StatusForbidden, recorder.Code)
+		assert.Contains(t, auditLog.String(), `"list" "user"="system:serviceaccount:ecosystem:support"`)
+		assert.Contains(t, auditLog.String(), `"status"=403`)
+	})
+	t.Run("should reject authenticated service account without permission to list the archives of the namespace", func(t *testing.T) {
+		// given
+		reviews := &ServiceAccountReviews{
+			TokenReviews:  newAuthenticatedTokenReviewsMock(t),
+			AccessReviews: newAccessReviewsMock(t, listAttributes("other"), false),
+		}
+		sut := NewHandler(nil, nil, reviews, nil, nil, logr.Discard())
+		recorder := httptest.NewRecorder()
+
+		// when
+		sut.ServeHTTP(recorder, newAuthenticatedRequest(http.MethodGet, ListPath+"?namespace=other"))
+
+		// then
+		assert.Equal(t, http.StatusForbidden, recorder.Code)
+	})
+	t.Run("should reject download tokens", func(t *testing.T) {
+		// given
+		sut := NewHandler(nil, nil, nil, nil, nil, logr.Discard())
+		recorder := httptest.NewRecorder()
+
+		// when
+		sut.ServeHTTP(recorder, httptest.NewRequest(http.MethodGet, ListPath+"?token=123.abc", nil))
+
+		// then
+		assert.Equal(t, http.StatusUnauthorized, recorder.Code)
+		assert.Equal(t, "Bearer", recorder.Header().Get("WWW-Authenticate"))
+	})
+}
+
+func TestServer(t *testing.T) {
+	t.Run("should serve until context is cancelled", func(t *testing.T) {
+		// given
+		sut := NewServer("0", http.NotFoundHandler())
+		ctx, cancel := context.WithCancel(context.Background())
+		cancel()
+
+		// when
+		err := sut.Start(ctx)
+
+		// then
+		require.NoError(t, err)
+		assert.False(t, sut.NeedLeaderElection())
+	})
+	t.Run("should return error if port is invalid", func(t *testing.T) {
+		err := NewServer("invalid", http.NotFoundHandler()).Start(context.Background())
+
+		require.Error(t, err)
+		assert.ErrorContains(t, err, "failed to listen on :invalid")
+	})
+}
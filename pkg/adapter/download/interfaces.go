@@ -0,0 +1,54 @@
+package download
+
+import (
+	"context"
+	"time"
+
+	libclient "github.com/cloudogu/k8s-support-archive-lib/client/v1"
+	"k8s.io/apimachinery/pkg/types"
+	authenticationv1client "k8s.io/client-go/kubernetes/typed/authentication/v1"
+	authorizationv1client "k8s.io/client-go/kubernetes/typed/authorization/v1"
+
+	"github.com/cloudogu/k8s-support-archive-operator/pkg/adapter/filesystem"
+	"github.com/cloudogu/k8s-support-archive-operator/pkg/domain"
+)
+
+type supportArchiveV1Interface interface {
+	libclient.SupportArchiveV1Interface
+}
+
+//nolint:unused
+//goland:noinspection GoUnusedType
+type supportArchiveInterface interface {
+	libclient.SupportArchiveInterface
+}
+
+type tokenReviewInterface interface {
+	authenticationv1client.TokenReviewInterface
+}
+
+type subjectAccessReviewInterface interface {
+	authorizationv1client.SubjectAccessReviewInterface
+}
+
+type tokenVerifier interface {
+	// Verify returns an error if the token is not signed for the support archive or if it is expired.
+	Verify(id domain.SupportArchiveID, uid types.UID, token string, now time.Time) error
+}
+
+type archiveRepository interface {
+	// GetArchivePath returns the path of the archive in the local filesystem.
+	GetArchivePath(id domain.SupportArchiveID) string
+	// List returns all archives in the local filesystem.
+	List(ctx context.Context) ([]domain.SupportArchiveID, error)
+}
+
+type volumeFs interface {
+	filesystem.Filesystem
+}
+
+//nolint:unused
+//goland:noinspection GoUnusedType
+type closableRWFile interface {
+	filesystem.ClosableRWFile
+}
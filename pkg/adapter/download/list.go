@@ -0,0 +1,155 @@
+package download
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io/fs"
+	"net/http"
+	"slices"
+	"strings"
+	"time"
+
+	"github.com/cloudogu/k8s-support-archive-operator/pkg/adapter/archive/file"
+	"github.com/cloudogu/k8s-support-archive-operator/pkg/domain"
+)
+
+// NamespaceQueryParameter restricts the listing to the archives of a namespace.
+const NamespaceQueryParameter = "namespace"
+
+// archiveListEntry describes an archive in the listing. Download paths are relative to the download server.
+type archiveListEntry struct {
+	Namespace    string    `json:"namespace"`
+	Name         string    `json:"name"`
+	Size         int64     `json:"size"`
+	LastModified time.Time `json:"lastModified"`
+	DownloadPath string    `json:"downloadPath"`
+	// Files is only set for archives with more than one file.
+	Files []archiveFileListEntry `json:"files,omitempty"`
+}
+
+type archiveFileListEntry struct {
+	Path string `json:"path"`
+	Size int64  `json:"size"`
+	// SHA256 is empty for archives without manifest.
+	SHA256       string `json:"sha256,omitempty"`
+	DownloadPath string `json:"downloadPath"`
+}
+
+// serveList writes the json listing of all archives and returns the authenticated user and the reason of a failure.
+// Download tokens are bound to a single archive, so only ServiceAccount tokens are accepted. They need the permission
+// to list the archives in all namespaces or, if the listing is restricted to a namespace, in this namespace.
+func (h *Handler) serveList(w http.ResponseWriter, r *http.Request) (string, string) {
+	if !allowedMethod(w, r) {
+		return "", "method not allowed"
+	}
+
+	namespace := r.URL.Query().Get(NamespaceQueryParameter)
+	user, status, err := h.reviewServiceAccount(r, listAttributes(namespace))
+	if err != nil {
+		writeAuthError(w, status)
+		return user, err.Error()
+	}
+
+	ids, err := h.archives.List(r.Context())
+	if err != nil {
+		http.Error(w, http.StatusText(http.StatusInternalServerError), http.StatusInternalServerError)
+		return user, fmt.Sprintf("failed to list archives: %s", err)
+	}
+	slices.SortFunc(ids, func(a, b domain.SupportArchiveID) int {
+		return strings.Compare(a.Namespace+"/"+a.Name, b.Namespace+"/"+b.Name)
+	})
+
+	entries := make([]archiveListEntry, 0, len(ids))
+	for _, id := range ids {
+		if namespace != "" && id.Namespace != namespace {
+			continue
+		}
+
+		entry, err := h.listArchive(r.Context(), id)
+		if errors.Is(err, fs.ErrNotExist) {
+			// the archive was deleted in the meantime
+			continue
+		} else if err != nil {
+			http.Error(w, http.StatusText(http.StatusInternalServerError), http.StatusInternalServerError)
+			return user, err.Error()
+		}
+		entries = append(entries, entry)
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(http.StatusOK)
+	if r.Method == http.MethodHead {
+		return user, ""
+	}
+
+	err = json.NewEncoder(w).Encode(entries)
+	if err != nil {
+		return user, fmt.Sprintf("failed to send listing: %s", err)
+	}
+
+	return user, ""
+}
+
+func (h *Handler) listArchive(ctx context.Context, id domain.SupportArchiveID) (archiveListEntry, error) {
+	archivePath := h.archives.GetArchivePath(id)
+	info, err := h.filesystem.Stat(ctx, archivePath)
+	if err != nil {
+		return archiveListEntry{}, fmt.Errorf("failed to stat archive %s: %w", archivePath, err)
+	}
+
+	entry := archiveListEntry{
+		Namespace:    id.Namespace,
+		Name:         id.Name,
+		Size:         info.Size(),
+		LastModified: info.ModTime().UTC(),
+		DownloadPath: archiveDownloadPath(id, ""),
+	}
+
+	files, err := listArchiveFiles(ctx, archivePath, h.filesystem)
+	if err != nil {
+		return archiveListEntry{}, err
+	}
+	if len(files) <= 1 {
+		return entry, nil
+	}
+
+	for _, archiveFile := range files {
+		entry.Files = append(entry.Files, archiveFileListEntry{
+			Path:         archiveFile.Path,
+			Size:         archiveFile.Size,
+			SHA256:       archiveFile.SHA256,
+			DownloadPath: archiveDownloadPath(id, archiveFile.Path),
+		})
+	}
+
+	return entry, nil
+}
+
+// listArchiveFiles returns the files of the archive with the checksums from the manifest if the archive has one.
+func listArchiveFiles(ctx context.Context, archivePath string, filesystem volumeFs) ([]domain.ManifestFile, error) {
+	reader, err := file.OpenZipArchiveReader(ctx, archivePath, filesystem)
+	if err != nil {
+		return nil, err
+	}
+	defer func() {
+		_ = reader.Close()
+	}()
+
+	files := reader.Files()
+	manifest, err := reader.Manifest()
+	if err != nil {
+		return files, nil
+	}
+
+	checksums := make(map[string]string, len(manifest.Files))
+	for _, manifestFile := range manifest.Files {
+		checksums[manifestFile.Path] = manifestFile.SHA256
+	}
+	for i := range files {
+		files[i].SHA256 = checksums[files[i].Path]
+	}
+
+	return files, nil
+}
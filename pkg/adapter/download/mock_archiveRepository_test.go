@@ -0,0 +1,141 @@
+// Code generated by mockery v2.53.3. DO NOT EDIT.
+
+package download
+
+import (
+	context "context"
+
+	domain "github.com/cloudogu/k8s-support-archive-operator/pkg/domain"
+	mock "github.com/stretchr/testify/mock"
+)
+
+// mockArchiveRepository is an autogenerated mock type for the archiveRepository type
+type mockArchiveRepository struct {
+	mock.Mock
+}
+
+type mockArchiveRepository_Expecter struct {
+	mock *mock.Mock
+}
+
+func (_m *mockArchiveRepository) EXPECT() *mockArchiveRepository_Expecter {
+	return &mockArchiveRepository_Expecter{mock: &_m.Mock}
+}
+
+// GetArchivePath provides a mock function with given fields: id
+func (_m *mockArchiveRepository) GetArchivePath(id domain.SupportArchiveID) string {
+	ret := _m.Called(id)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetArchivePath")
+	}
+
+	var r0 string
+	if rf, ok := ret.Get(0).(func(domain.SupportArchiveID) string); ok {
+		r0 = rf(id)
+	} else {
+		r0 = ret.Get(0).(string)
+	}
+
+	return r0
+}
+
+// mockArchiveRepository_GetArchivePath_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'GetArchivePath'
+type mockArchiveRepository_GetArchivePath_Call struct {
+	*mock.Call
+}
+
+// GetArchivePath is a helper method to define mock.On call
+//   - id domain.SupportArchiveID
+func (_e *mockArchiveRepository_Expecter) GetArchivePath(id interface{}) *mockArchiveRepository_GetArchivePath_Call {
+	return &mockArchiveRepository_GetArchivePath_Call{Call: _e.mock.On("GetArchivePath", id)}
+}
+
+func (_c *mockArchiveRepository_GetArchivePath_Call) Run(run func(id domain.SupportArchiveID)) *mockArchiveRepository_GetArchivePath_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(domain.SupportArchiveID))
+	})
+	return _c
+}
+
+func (_c *mockArchiveRepository_GetArchivePath_Call) Return(_a0 string) *mockArchiveRepository_GetArchivePath_Call {
+	_c.Call.Return(_a0)
+	return _c
+}
+
+func (_c *mockArchiveRepository_GetArchivePath_Call) RunAndReturn(run func(domain.SupportArchiveID) string) *mockArchiveRepository_GetArchivePath_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// List provides a mock function with given fields: ctx
+func (_m *mockArchiveRepository) List(ctx context.Context) ([]domain.SupportArchiveID, error) {
+	ret := _m.Called(ctx)
+
+	if len(ret) == 0 {
+		panic("no return value specified for List")
+	}
+
+	var r0 []domain.SupportArchiveID
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context) ([]domain.SupportArchiveID, error)); ok {
+		return rf(ctx)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context) []domain.SupportArchiveID); ok {
+		r0 = rf(ctx)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]domain.SupportArchiveID)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context) error); ok {
+		r1 = rf(ctx)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// mockArchiveRepository_List_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'List'
+type mockArchiveRepository_List_Call struct {
+	*mock.Call
+}
+
+// List is a helper method to define mock.On call
+//   - ctx context.Context
+func (_e *mockArchiveRepository_Expecter) List(ctx interface{}) *mockArchiveRepository_List_Call {
+	return &mockArchiveRepository_List_Call{Call: _e.mock.On("List", ctx)}
+}
+
+func (_c *mockArchiveRepository_List_Call) Run(run func(ctx context.Context)) *mockArchiveRepository_List_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context))
+	})
+	return _c
+}
+
+func (_c *mockArchiveRepository_List_Call) Return(_a0 []domain.SupportArchiveID, _a1 error) *mockArchiveRepository_List_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *mockArchiveRepository_List_Call) RunAndReturn(run func(context.Context) ([]domain.SupportArchiveID, error)) *mockArchiveRepository_List_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// newMockArchiveRepository creates a new instance of mockArchiveRepository. It also registers a testing interface on the mock and a cleanup function to assert the mocks expectations.
+// The first argument is typically a *testing.T value.
+func newMockArchiveRepository(t interface {
+	mock.TestingT
+	Cleanup(func())
+}) *mockArchiveRepository {
+	mock := &mockArchiveRepository{}
+	mock.Mock.Test(t)
+
+	t.Cleanup(func() { mock.AssertExpectations(t) })
+
+	return mock
+}
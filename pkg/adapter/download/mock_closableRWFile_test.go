@@ -0,0 +1,234 @@
+// Code generated by mockery v2.53.3. DO NOT EDIT.
+
+package download
+
+import mock "github.com/stretchr/testify/mock"
+
+// mockClosableRWFile is an autogenerated mock type for the closableRWFile type
+type mockClosableRWFile struct {
+	mock.Mock
+}
+
+type mockClosableRWFile_Expecter struct {
+	mock *mock.Mock
+}
+
+func (_m *mockClosableRWFile) EXPECT() *mockClosableRWFile_Expecter {
+	return &mockClosableRWFile_Expecter{mock: &_m.Mock}
+}
+
+// Close provides a mock function with no fields
+func (_m *mockClosableRWFile) Close() error {
+	ret := _m.Called()
+
+	if len(ret) == 0 {
+		panic("no return value specified for Close")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func() error); ok {
+		r0 = rf()
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// mockClosableRWFile_Close_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'Close'
+type mockClosableRWFile_Close_Call struct {
+	*mock.Call
+}
+
+// Close is a helper method to define mock.On call
+func (_e *mockClosableRWFile_Expecter) Close() *mockClosableRWFile_Close_Call {
+	return &mockClosableRWFile_Close_Call{Call: _e.mock.On("Close")}
+}
+
+func (_c *mockClosableRWFile_Close_Call) Run(run func()) *mockClosableRWFile_Close_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run()
+	})
+	return _c
+}
+
+func (_c *mockClosableRWFile_Close_Call) Return(_a0 error) *mockClosableRWFile_Close_Call {
+	_c.Call.Return(_a0)
+	return _c
+}
+
+func (_c *mockClosableRWFile_Close_Call) RunAndReturn(run func() error) *mockClosableRWFile_Close_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// Read provides a mock function with given fields: p
+func (_m *mockClosableRWFile) Read(p []byte) (int, error) {
+	ret := _m.Called(p)
+
+	if len(ret) == 0 {
+		panic("no return value specified for Read")
+	}
+
+	var r0 int
+	var r1 error
+	if rf, ok := ret.Get(0).(func([]byte) (int, error)); ok {
+		return rf(p)
+	}
+	if rf, ok := ret.Get(0).(func([]byte) int); ok {
+		r0 = rf(p)
+	} else {
+		r0 = ret.Get(0).(int)
+	}
+
+	if rf, ok := ret.Get(1).(func([]byte) error); ok {
+		r1 = rf(p)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// mockClosableRWFile_Read_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'Read'
+type mockClosableRWFile_Read_Call struct {
+	*mock.Call
+}
+
+// Read is a helper method to define mock.On call
+//   - p []byte
+func (_e *mockClosableRWFile_Expecter) Read(p interface{}) *mockClosableRWFile_Read_Call {
+	return &mockClosableRWFile_Read_Call{Call: _e.mock.On("Read", p)}
+}
+
+func (_c *mockClosableRWFile_Read_Call) Run(run func(p []byte)) *mockClosableRWFile_Read_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].([]byte))
+	})
+	return _c
+}
+
+func (_c *mockClosableRWFile_Read_Call) Return(n int, err error) *mockClosableRWFile_Read_Call {
+	_c.Call.Return(n, err)
+	return _c
+}
+
+func (_c *mockClosableRWFile_Read_Call) RunAndReturn(run func([]byte) (int, error)) *mockClosableRWFile_Read_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// Sync provides a mock function with no fields
+func (_m *mockClosableRWFile) Sync() error {
+	ret := _m.Called()
+
+	if len(ret) == 0 {
+		panic("no return value specified for Sync")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func() error); ok {
+		r0 = rf()
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// mockClosableRWFile_Sync_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'Sync'
+type mockClosableRWFile_Sync_Call struct {
+	*mock.Call
+}
+
+// Sync is a helper method to define mock.On call
+func (_e *mockClosableRWFile_Expecter) Sync() *mockClosableRWFile_Sync_Call {
+	return &mockClosableRWFile_Sync_Call{Call: _e.mock.On("Sync")}
+}
+
+func (_c *mockClosableRWFile_Sync_Call) Run(run func()) *mockClosableRWFile_Sync_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run()
+	})
+	return _c
+}
+
+func (_c *mockClosableRWFile_Sync_Call) Return(_a0 error) *mockClosableRWFile_Sync_Call {
+	_c.Call.Return(_a0)
+	return _c
+}
+
+func (_c *mockClosableRWFile_Sync_Call) RunAndReturn(run func() error) *mockClosableRWFile_Sync_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// Write provides a mock function with given fields: p
+func (_m *mockClosableRWFile) Write(p []byte) (int, error) {
+	ret := _m.Called(p)
+
+	if len(ret) == 0 {
+		panic("no return value specified for Write")
+	}
+
+	var r0 int
+	var r1 error
+	if rf, ok := ret.Get(0).(func([]byte) (int, error)); ok {
+		return rf(p)
+	}
+	if rf, ok := ret.Get(0).(func([]byte) int); ok {
+		r0 = rf(p)
+	} else {
+		r0 = ret.Get(0).(int)
+	}
+
+	if rf, ok := ret.Get(1).(func([]byte) error); ok {
+		r1 = rf(p)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// mockClosableRWFile_Write_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'Write'
+type mockClosableRWFile_Write_Call struct {
+	*mock.Call
+}
+
+// Write is a helper method to define mock.On call
+//   - p []byte
+func (_e *mockClosableRWFile_Expecter) Write(p interface{}) *mockClosableRWFile_Write_Call {
+	return &mockClosableRWFile_Write_Call{Call: _e.mock.On("Write", p)}
+}
+
+func (_c *mockClosableRWFile_Write_Call) Run(run func(p []byte)) *mockClosableRWFile_Write_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].([]byte))
+	})
+	return _c
+}
+
+func (_c *mockClosableRWFile_Write_Call) Return(n int, err error) *mockClosableRWFile_Write_Call {
+	_c.Call.Return(n, err)
+	return _c
+}
+
+func (_c *mockClosableRWFile_Write_Call) RunAndReturn(run func([]byte) (int, error)) *mockClosableRWFile_Write_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// newMockClosableRWFile creates a new instance of mockClosableRWFile. It also registers a testing interface on the mock and a cleanup function to assert the mocks expectations.
+// The first argument is typically a *testing.T value.
+func newMockClosableRWFile(t interface {
+	mock.TestingT
+	Cleanup(func())
+}) *mockClosableRWFile {
+	mock := &mockClosableRWFile{}
+	mock.Mock.Test(t)
+
+	t.Cleanup(func() { mock.AssertExpectations(t) })
+
+	return mock
+}
@@ -0,0 +1,99 @@
+// Code generated by mockery v2.53.3. DO NOT EDIT.
+
+package download
+
+import (
+	context "context"
+
+	mock "github.com/stretchr/testify/mock"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	v1 "k8s.io/api/authentication/v1"
+)
+
+// mockTokenReviewInterface is an autogenerated mock type for the tokenReviewInterface type
+type mockTokenReviewInterface struct {
+	mock.Mock
+}
+
+type mockTokenReviewInterface_Expecter struct {
+	mock *mock.Mock
+}
+
+func (_m *mockTokenReviewInterface) EXPECT() *mockTokenReviewInterface_Expecter {
+	return &mockTokenReviewInterface_Expecter{mock: &_m.Mock}
+}
+
+// Create provides a mock function with given fields: ctx, tokenReview, opts
+func (_m *mockTokenReviewInterface) Create(ctx context.Context, tokenReview *v1.TokenReview, opts metav1.CreateOptions) (*v1.TokenReview, error) {
+	ret := _m.Called(ctx, tokenReview, opts)
+
+	if len(ret) == 0 {
+		panic("no return value specified for Create")
+	}
+
+	var r0 *v1.TokenReview
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, *v1.TokenReview, metav1.CreateOptions) (*v1.TokenReview, error)); ok {
+		return rf(ctx, tokenReview, opts)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, *v1.TokenReview, metav1.CreateOptions) *v1.TokenReview); ok {
+		r0 = rf(ctx, tokenReview, opts)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*v1.TokenReview)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, *v1.TokenReview, metav1.CreateOptions) error); ok {
+		r1 = rf(ctx, tokenReview, opts)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// mockTokenReviewInterface_Create_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'Create'
+type mockTokenReviewInterface_Create_Call struct {
+	*mock.Call
+}
+
+// Create is a helper method to define mock.On call
+//   - ctx context.Context
+//   - tokenReview *v1.TokenReview
+//   - opts metav1.CreateOptions
+func (_e *mockTokenReviewInterface_Expecter) Create(ctx interface{}, tokenReview interface{}, opts interface{}) *mockTokenReviewInterface_Create_Call {
+	return &mockTokenReviewInterface_Create_Call{Call: _e.mock.On("Create", ctx, tokenReview, opts)}
+}
+
+func (_c *mockTokenReviewInterface_Create_Call) Run(run func(ctx context.Context, tokenReview *v1.TokenReview, opts metav1.CreateOptions)) *mockTokenReviewInterface_Create_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(*v1.TokenReview), args[2].(metav1.CreateOptions))
+	})
+	return _c
+}
+
+func (_c *mockTokenReviewInterface_Create_Call) Return(_a0 *v1.TokenReview, _a1 error) *mockTokenReviewInterface_Create_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *mockTokenReviewInterface_Create_Call) RunAndReturn(run func(context.Context, *v1.TokenReview, metav1.CreateOptions) (*v1.TokenReview, error)) *mockTokenReviewInterface_Create_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// newMockTokenReviewInterface creates a new instance of mockTokenReviewInterface. It also registers a testing interface on the mock and a cleanup function to assert the mocks expectations.
+// The first argument is typically a *testing.T value.
+func newMockTokenReviewInterface(t interface {
+	mock.TestingT
+	Cleanup(func())
+}) *mockTokenReviewInterface {
+	mock := &mockTokenReviewInterface{}
+	mock.Mock.Test(t)
+
+	t.Cleanup(func() { mock.AssertExpectations(t) })
+
+	return mock
+}
@@ -0,0 +1,88 @@
+// Code generated by mockery v2.53.3. DO NOT EDIT.
+
+package download
+
+import (
+	domain "github.com/cloudogu/k8s-support-archive-operator/pkg/domain"
+	mock "github.com/stretchr/testify/mock"
+
+	time "time"
+
+	types "k8s.io/apimachinery/pkg/types"
+)
+
+// mockTokenVerifier is an autogenerated mock type for the tokenVerifier type
+type mockTokenVerifier struct {
+	mock.Mock
+}
+
+type mockTokenVerifier_Expecter struct {
+	mock *mock.Mock
+}
+
+func (_m *mockTokenVerifier) EXPECT() *mockTokenVerifier_Expecter {
+	return &mockTokenVerifier_Expecter{mock: &_m.Mock}
+}
+
+// Verify provides a mock function with given fields: id, uid, token, now
+func (_m *mockTokenVerifier) Verify(id domain.SupportArchiveID, uid types.UID, token string, now time.Time) error {
+	ret := _m.Called(id, uid, token, now)
+
+	if len(ret) == 0 {
+		panic("no return value specified for Verify")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(domain.SupportArchiveID, types.UID, string, time.Time) error); ok {
+		r0 = rf(id, uid, token, now)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// mockTokenVerifier_Verify_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'Verify'
+type mockTokenVerifier_Verify_Call struct {
+	*mock.Call
+}
+
+// Verify is a helper method to define mock.On call
+//   - id domain.SupportArchiveID
+//   - uid types.UID
+//   - token string
+//   - now time.Time
+func (_e *mockTokenVerifier_Expecter) Verify(id interface{}, uid interface{}, token interface{}, now interface{}) *mockTokenVerifier_Verify_Call {
+	return &mockTokenVerifier_Verify_Call{Call: _e.mock.On("Verify", id, uid, token, now)}
+}
+
+func (_c *mockTokenVerifier_Verify_Call) Run(run func(id domain.SupportArchiveID, uid types.UID, token string, now time.Time)) *mockTokenVerifier_Verify_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(domain.SupportArchiveID), args[1].(types.UID), args[2].(string), args[3].(time.Time))
+	})
+	return _c
+}
+
+func (_c *mockTokenVerifier_Verify_Call) Return(_a0 error) *mockTokenVerifier_Verify_Call {
+	_c.Call.Return(_a0)
+	return _c
+}
+
+func (_c *mockTokenVerifier_Verify_Call) RunAndReturn(run func(domain.SupportArchiveID, types.UID, string, time.Time) error) *mockTokenVerifier_Verify_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// newMockTokenVerifier creates a new instance of mockTokenVerifier. It also registers a testing interface on the mock and a cleanup function to assert the mocks expectations.
+// The first argument is typically a *testing.T value.
+func newMockTokenVerifier(t interface {
+	mock.TestingT
+	Cleanup(func())
+}) *mockTokenVerifier {
+	mock := &mockTokenVerifier{}
+	mock.Mock.Test(t)
+
+	t.Cleanup(func() { mock.AssertExpectations(t) })
+
+	return mock
+}
@@ -0,0 +1,64 @@
+package download
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net"
+	"net/http"
+	"time"
+
+	"sigs.k8s.io/controller-runtime/pkg/log"
+)
+
+const (
+	readHeaderTimeout = 10 * time.Second
+	shutdownTimeout   = 10 * time.Second
+)
+
+// Server serves the support archives. It implements manager.Runnable and runs on every replica of the operator.
+type Server struct {
+	address string
+	handler http.Handler
+}
+
+func NewServer(port string, handler http.Handler) *Server {
+	return &Server{address: net.JoinHostPort("", port), handler: handler}
+}
+
+// Start serves the archives until the context is cancelled.
+func (s *Server) Start(ctx context.Context) error {
+	logger := log.FromContext(ctx).WithName("download-server")
+
+	listener, err := net.Listen("tcp", s.address)
+	if err != nil {
+		return fmt.Errorf("failed to listen on %s: %w", s.address, err)
+	}
+
+	server := &http.Server{Handler: s.handler, ReadHeaderTimeout: readHeaderTimeout}
+	shutdownErr := make(chan error, 1)
+	go func() {
+		<-ctx.Done()
+		shutdownCtx, cancel := context.WithTimeout(context.Background(), shutdownTimeout)
+		defer cancel()
+		shutdownErr <- server.Shutdown(shutdownCtx)
+	}()
+
+	logger.Info("starting download server", "address", listener.Addr().String())
+	err = server.Serve(listener)
+	if !errors.Is(err, http.ErrServerClosed) {
+		return fmt.Errorf("download server failed: %w", err)
+	}
+
+	err = <-shutdownErr
+	if err != nil {
+		return fmt.Errorf("failed to shut down download server: %w", err)
+	}
+
+	return nil
+}
+
+// NeedLeaderElection returns false because downloads are served by every replica.
+func (s *Server) NeedLeaderElection() bool {
+	return false
+}
@@ -0,0 +1,115 @@
+package download
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"slices"
+
+	libapi "github.com/cloudogu/k8s-support-archive-lib/api/v1"
+	authenticationv1 "k8s.io/api/authentication/v1"
+	authorizationv1 "k8s.io/api/authorization/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	"github.com/cloudogu/k8s-support-archive-operator/pkg/domain"
+)
+
+const (
+	// TokenAudience is the audience of the ServiceAccount tokens accepted by the download server.
+	// Tokens for other audiences, e.g. the API server, are rejected so that they cannot be replayed by other services.
+	TokenAudience = "k8s-support-archive-operator"
+	// supportArchivesResource and downloadSubresource are the resource ServiceAccounts need permissions for to download
+	// archives, e.g. get on supportarchives/download. Permissions on the support archives themselves are not enough
+	// because the archives may contain secrets.
+	supportArchivesResource = "supportarchives"
+	downloadSubresource     = "download"
+)
+
+// ServiceAccountReviews authenticates ServiceAccount tokens with TokenReviews and checks with SubjectAccessReviews
+// if the ServiceAccount is allowed to download the requested archives.
+type ServiceAccountReviews struct {
+	TokenReviews  tokenReviewInterface
+	AccessReviews subjectAccessReviewInterface
+}
+
+// review returns the user of the token and the http status and error if the user is not allowed to access the resource.
+func (s *ServiceAccountReviews) review(ctx context.Context, bearerToken string, attributes authorizationv1.ResourceAttributes) (string, int, error) {
+	user, status, err := s.authenticate(ctx, bearerToken)
+	if err != nil {
+		return "", status, err
+	}
+
+	status, err = s.authorize(ctx, user, attributes)
+	return user.Username, status, err
+}
+
+func (s *ServiceAccountReviews) authenticate(ctx context.Context, bearerToken string) (authenticationv1.UserInfo, int, error) {
+	review, err := s.TokenReviews.Create(ctx, &authenticationv1.TokenReview{
+		Spec: authenticationv1.TokenReviewSpec{Token: bearerToken, Audiences: []string{TokenAudience}},
+	}, metav1.CreateOptions{})
+	if err != nil {
+		return authenticationv1.UserInfo{}, http.StatusInternalServerError, fmt.Errorf("failed to review token: %w", err)
+	}
+	if !review.Status.Authenticated {
+		return authenticationv1.UserInfo{}, http.StatusUnauthorized, fmt.Errorf("token is not authenticated: %s", review.Status.Error)
+	}
+	// The status contains the requested audiences the token is valid for and has to be checked by the client.
+	if !slices.Contains(review.Status.Audiences, TokenAudience) {
+		return authenticationv1.UserInfo{}, http.StatusUnauthorized, fmt.Errorf("token is not valid for audience %s", TokenAudience)
+	}
+
+	return review.Status.User, http.StatusOK, nil
+}
+
+func (s *ServiceAccountReviews) authorize(ctx context.Context, user authenticationv1.UserInfo, attributes authorizationv1.ResourceAttributes) (int, error) {
+	var extra map[string]authorizationv1.ExtraValue
+	if len(user.Extra) > 0 {
+		extra = make(map[string]authorizationv1.ExtraValue, len(user.Extra))
+		for key, value := range user.Extra {
+			extra[key] = authorizationv1.ExtraValue(value)
+		}
+	}
+
+	review, err := s.AccessReviews.Create(ctx, &authorizationv1.SubjectAccessReview{
+		Spec: authorizationv1.SubjectAccessReviewSpec{
+			ResourceAttributes: &attributes,
+			User:               user.Username,
+			Groups:             user.Groups,
+			UID:                user.UID,
+			Extra:              extra,
+		},
+	}, metav1.CreateOptions{})
+	if err != nil {
+		return http.StatusInternalServerError, fmt.Errorf("failed to review access: %w", err)
+	}
+	if !review.Status.Allowed {
+		return http.StatusForbidden, fmt.Errorf("user is not allowed to %s %s/%s in namespace %q: %s",
+			attributes.Verb, attributes.Resource, attributes.Subresource, attributes.Namespace, review.Status.Reason)
+	}
+
+	return http.StatusOK, nil
+}
+
+// downloadAttributes returns the attributes a ServiceAccount needs to download the archive or its files.
+func downloadAttributes(id domain.SupportArchiveID) authorizationv1.ResourceAttributes {
+	return authorizationv1.ResourceAttributes{
+		Namespace:   id.Namespace,
+		Verb:        "get",
+		Group:       libapi.GroupVersion.Group,
+		Resource:    supportArchivesResource,
+		Subresource: downloadSubresource,
+		Name:        id.Name,
+	}
+}
+
+// listAttributes returns the attributes a ServiceAccount needs to list the archives of the namespace.
+// An empty namespace requires the permission in all namespaces.
+func listAttributes(namespace string) authorizationv1.ResourceAttributes {
+	return authorizationv1.ResourceAttributes{
+		Namespace:   namespace,
+		Verb:        "list",
+		Group:       libapi.GroupVersion.Group,
+		Resource:    supportArchivesResource,
+		Subresource: downloadSubresource,
+	}
+}
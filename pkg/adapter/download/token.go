@@ -0,0 +1,111 @@
+package download
+
+import (
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/base64"
+	"errors"
+	"fmt"
+	"net/url"
+	"strconv"
+	"strings"
+	"time"
+
+	libapi "github.com/cloudogu/k8s-support-archive-lib/api/v1"
+	"k8s.io/apimachinery/pkg/types"
+
+	"github.com/cloudogu/k8s-support-archive-operator/pkg/domain"
+)
+
+// TokenQueryParameter is the query parameter of the download url containing the download token.
+const TokenQueryParameter = "token"
+
+var (
+	errInvalidToken = errors.New("invalid download token")
+	errExpiredToken = errors.New("download token expired")
+)
+
+// TokenSigner creates and verifies download tokens.
+// A token has the format <expiry as unix seconds>.<signature> and is signed with HMAC-SHA256 over the namespace,
+// name and UID of the support archive and the expiry. The UID binds the token to the lifecycle of the custom resource,
+// so that tokens of a deleted archive are not valid for a new archive with the same name.
+type TokenSigner struct {
+	secret []byte
+	ttl    time.Duration
+}
+
+func NewTokenSigner(secret string, ttl time.Duration) *TokenSigner {
+	return &TokenSigner{secret: []byte(secret), ttl: ttl}
+}
+
+// Sign adds a new download token for the support archive to the url. An existing token is replaced.
+// The token expires at the returned time.
+func (s *TokenSigner) Sign(cr *libapi.SupportArchive, downloadURL string, now time.Time) (string, time.Time) {
+	expiresAt := now.Add(s.ttl).Truncate(time.Second)
+	id := domain.SupportArchiveID{Namespace: cr.Namespace, Name: cr.Name}
+	token := fmt.Sprintf("%d.%s", expiresAt.Unix(), s.signature(id, cr.UID, expiresAt))
+
+	parsedURL, err := url.Parse(downloadURL)
+	if err != nil {
+		// The url is created by the archive repository and always valid.
+		return downloadURL, expiresAt
+	}
+	query := parsedURL.Query()
+	query.Set(TokenQueryParameter, token)
+	parsedURL.RawQuery = query.Encode()
+
+	return parsedURL.String(), expiresAt
+}
+
+// ExpiresAt returns the expiry of the token in the download url. It returns false if the url contains no token.
+// The signature is not verified.
+func (s *TokenSigner) ExpiresAt(downloadURL string) (time.Time, bool) {
+	parsedURL, err := url.Parse(downloadURL)
+	if err != nil {
+		return time.Time{}, false
+	}
+
+	expiresAt, _, err := parseToken(parsedURL.Query().Get(TokenQueryParameter))
+	if err != nil {
+		return time.Time{}, false
+	}
+
+	return expiresAt, true
+}
+
+// Verify returns an error if the token is not signed for the support archive or if it is expired.
+func (s *TokenSigner) Verify(id domain.SupportArchiveID, uid types.UID, token string, now time.Time) error {
+	expiresAt, signature, err := parseToken(token)
+	if err != nil {
+		return err
+	}
+
+	if !hmac.Equal([]byte(signature), []byte(s.signature(id, uid, expiresAt))) {
+		return errInvalidToken
+	}
+	if !now.Before(expiresAt) {
+		return errExpiredToken
+	}
+
+	return nil
+}
+
+func (s *TokenSigner) signature(id domain.SupportArchiveID, uid types.UID, expiresAt time.Time) string {
+	mac := hmac.New(sha256.New, s.secret)
+	_, _ = fmt.Fprintf(mac, "%s/%s/%s/%d", id.Namespace, id.Name, uid, expiresAt.Unix())
+	return base64.RawURLEncoding.EncodeToString(mac.Sum(nil))
+}
+
+func parseToken(token string) (time.Time, string, error) {
+	expiry, signature, found := strings.Cut(token, ".")
+	if !found || signature == "" {
+		return time.Time{}, "", errInvalidToken
+	}
+
+	unixSeconds, err := strconv.ParseInt(expiry, 10, 64)
+	if err != nil {
+		return time.Time{}, "", errInvalidToken
+	}
+
+	return time.Unix(unixSeconds, 0), signature, nil
+}
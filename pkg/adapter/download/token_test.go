@@ -0,0 +1,111 @@
+package download
+
+import (
+	"testing"
+	"time"
+
+	libapi "github.com/cloudogu/k8s-support-archive-lib/api/v1"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	"github.com/cloudogu/k8s-support-archive-operator/pkg/domain"
+)
+
+const (
+	testNamespace = "ecosystem"
+	testName      = "archive-123"
+	testUID       = "6f1c2c9e-2b5e-4a40-9f0e-1c1f8a0a3d11"
+	testURL       = "http://webserver.ecosystem.svc.cluster.local:8080/ecosystem/archive-123.zip"
+)
+
+var (
+	testID  = domain.SupportArchiveID{Namespace: testNamespace, Name: testName}
+	testNow = time.Date(2025, 1, 1, 12, 0, 0, 0, time.UTC)
+	testCR  = &libapi.SupportArchive{ObjectMeta: metav1.ObjectMeta{Namespace: testNamespace, Name: testName, UID: testUID}}
+)
+
+func TestTokenSigner_Sign(t *testing.T) {
+	t.Run("should add token to url", func(t *testing.T) {
+		// given
+		sut := NewTokenSigner("secret", time.Hour)
+
+		// when
+		signedURL, expiresAt := sut.Sign(testCR, testURL, testNow)
+
+		// then
+		assert.Equal(t, testNow.Add(time.Hour), expiresAt)
+		assert.Regexp(t, `^`+testURL+`\?token=1735736400\.[A-Za-z0-9_-]+$`, signedURL)
+		gotExpiresAt, ok := sut.ExpiresAt(signedURL)
+		assert.True(t, ok)
+		assert.True(t, expiresAt.Equal(gotExpiresAt))
+	})
+	t.Run("should replace existing token", func(t *testing.T) {
+		// given
+		sut := NewTokenSigner("secret", time.Hour)
+		signedURL, _ := sut.Sign(testCR, testURL, testNow)
+
+		// when
+		renewedURL, expiresAt := sut.Sign(testCR, signedURL, testNow.Add(time.Hour))
+
+		// then
+		assert.Equal(t, testNow.Add(2*time.Hour), expiresAt)
+		assert.Regexp(t, `^`+testURL+`\?token=1735740000\.[A-Za-z0-9_-]+$`, renewedURL)
+	})
+}
+
+func TestTokenSigner_ExpiresAt(t *testing.T) {
+	sut := NewTokenSigner("secret", time.Hour)
+
+	for _, downloadURL := range []string{testURL, testURL + "?token=abc", testURL + "?token=abc.def", "%"} {
+		_, ok := sut.ExpiresAt(downloadURL)
+		assert.False(t, ok, downloadURL)
+	}
+}
+
+func TestTokenSigner_Verify(t *testing.T) {
+	sut := NewTokenSigner("secret", time.Hour)
+	signedURL, _ := sut.Sign(testCR, testURL, testNow)
+	token := signedURL[len(testURL+"?token="):]
+
+	t.Run("should accept valid token", func(t *testing.T) {
+		err := sut.Verify(testID, testUID, token, testNow.Add(time.Minute))
+
+		assert.NoError(t, err)
+	})
+	t.Run("should reject expired token", func(t *testing.T) {
+		err := sut.Verify(testID, testUID, token, testNow.Add(time.Hour))
+
+		assert.ErrorIs(t, err, errExpiredToken)
+	})
+	t.Run("should reject token of other archive", func(t *testing.T) {
+		err := sut.Verify(domain.SupportArchiveID{Namespace: testNamespace, Name: "other"}, testUID, token, testNow)
+
+		assert.ErrorIs(t, err, errInvalidToken)
+	})
+	t.Run("should reject token of recreated archive", func(t *testing.T) {
+		err := sut.Verify(testID, "other-uid", token, testNow)
+
+		assert.ErrorIs(t, err, errInvalidToken)
+	})
+	t.Run("should reject token signed with other secret", func(t *testing.T) {
+		err := NewTokenSigner("other", time.Hour).Verify(testID, testUID, token, testNow)
+
+		assert.ErrorIs(t, err, errInvalidToken)
+	})
+	t.Run("should reject token with changed expiry", func(t *testing.T) {
+		_, signature, err := parseToken(token)
+		require.NoError(t, err)
+
+		err = sut.Verify(testID, testUID, "1735740000."+signature, testNow)
+
+		assert.ErrorIs(t, err, errInvalidToken)
+	})
+	t.Run("should reject malformed token", func(t *testing.T) {
+		for _, malformed := range []string{"", "abc", "abc.def", "123."} {
+			err := sut.Verify(testID, testUID, malformed, testNow)
+
+			assert.ErrorIs(t, err, errInvalidToken, malformed)
+		}
+	})
+}
@@ -1,6 +1,7 @@
 package filesystem
 
 import (
+	"context"
 	"io"
 	"io/fs"
 	"os"
@@ -11,42 +12,44 @@ type ClosableRWFile interface {
 	Close() error
 	Write(p []byte) (n int, err error)
 	Read(p []byte) (n int, err error)
+	Sync() error
 }
 
 type Filesystem interface {
-	Stat(name string) (os.FileInfo, error)
-	MkdirAll(path string, perm os.FileMode) error
-	Create(name string) (ClosableRWFile, error)
-	Open(path string) (ClosableRWFile, error)
-	OpenFile(path string, flag int, perm os.FileMode) (ClosableRWFile, error)
+	Stat(ctx context.Context, name string) (os.FileInfo, error)
+	MkdirAll(ctx context.Context, path string, perm os.FileMode) error
+	Create(ctx context.Context, name string) (ClosableRWFile, error)
+	Open(ctx context.Context, path string) (ClosableRWFile, error)
+	OpenFile(ctx context.Context, path string, flag int, perm os.FileMode) (ClosableRWFile, error)
 	ReadAll(r io.Reader) ([]byte, error)
-	WriteFile(name string, data []byte, perm os.FileMode) error
-	Remove(name string) error
-	RemoveAll(path string) error
-	ReadDir(name string) ([]os.DirEntry, error)
+	WriteFile(ctx context.Context, name string, data []byte, perm os.FileMode) error
+	Remove(ctx context.Context, name string) error
+	Rename(ctx context.Context, oldPath, newPath string) error
+	RemoveAll(ctx context.Context, path string) error
+	ReadDir(ctx context.Context, name string) ([]os.DirEntry, error)
 	Copy(dst io.Writer, src io.Reader) (written int64, err error)
-	WalkDir(root string, fn fs.WalkDirFunc) error
+	WalkDir(ctx context.Context, root string, fn fs.WalkDirFunc) error
 }
 
 type FileSystem struct{}
 
-func (f FileSystem) Stat(name string) (os.FileInfo, error) {
+func (f FileSystem) Stat(_ context.Context, name string) (os.FileInfo, error) {
 	return os.Stat(name)
 }
 
-func (f FileSystem) MkdirAll(path string, perm os.FileMode) error {
+func (f FileSystem) MkdirAll(_ context.Context, path string, perm os.FileMode) error {
 	return os.MkdirAll(path, perm)
 }
 
-func (f FileSystem) Create(name string) (ClosableRWFile, error) {
+func (f FileSystem) Create(_ context.Context, name string) (ClosableRWFile, error) {
 	return os.Create(name)
 }
 
-func (f FileSystem) OpenFile(path string, flag int, perm os.FileMode) (ClosableRWFile, error) {
+func (f FileSystem) OpenFile(_ context.Context, path string, flag int, perm os.FileMode) (ClosableRWFile, error) {
 	return os.OpenFile(path, flag, perm)
 }
 
-func (f FileSystem) Open(path string) (ClosableRWFile, error) {
+func (f FileSystem) Open(_ context.Context, path string) (ClosableRWFile, error) {
 	return os.Open(path)
 }
 
@@ -54,7 +57,7 @@ func (f FileSystem) Copy(dst io.Writer, src io.Reader) (written int64, err error
 	return io.Copy(dst, src)
 }
 
-func (f FileSystem) WalkDir(root string, fn fs.WalkDirFunc) error {
+func (f FileSystem) WalkDir(_ context.Context, root string, fn fs.WalkDirFunc) error {
 	return filepath.WalkDir(root, fn)
 }
 
@@ -62,18 +65,22 @@ func (f FileSystem) ReadAll(r io.Reader) ([]byte, error) {
 	return io.ReadAll(r)
 }
 
-func (f FileSystem) WriteFile(path string, data []byte, perm os.FileMode) error {
+func (f FileSystem) WriteFile(_ context.Context, path string, data []byte, perm os.FileMode) error {
 	return os.WriteFile(path, data, perm)
 }
 
-func (f FileSystem) Remove(path string) error {
+func (f FileSystem) Remove(_ context.Context, path string) error {
 	return os.Remove(path)
 }
 
-func (f FileSystem) RemoveAll(path string) error {
+func (f FileSystem) Rename(_ context.Context, oldPath, newPath string) error {
+	return os.Rename(oldPath, newPath)
+}
+
+func (f FileSystem) RemoveAll(_ context.Context, path string) error {
 	return os.RemoveAll(path)
 }
 
-func (f FileSystem) ReadDir(path string) ([]fs.DirEntry, error) {
+func (f FileSystem) ReadDir(_ context.Context, path string) ([]fs.DirEntry, error) {
 	return os.ReadDir(path)
 }
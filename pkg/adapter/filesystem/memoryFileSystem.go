@@ -0,0 +1,377 @@
+package filesystem
+
+import (
+	"context"
+	"io"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"slices"
+	"strings"
+	"sync"
+	"syscall"
+	"time"
+)
+
+// MemoryFileSystem keeps the files in memory. It is used for small support archives, so that the collected data is
+// not written to the volume before it is packaged.
+//
+// Like on the local filesystem, files can only be created in existing directories and open files are changed directly.
+type MemoryFileSystem struct {
+	mu    sync.RWMutex
+	nodes map[string]*memoryNode
+}
+
+type memoryNode struct {
+	dir     bool
+	data    []byte
+	modTime time.Time
+}
+
+func NewMemoryFileSystem() *MemoryFileSystem {
+	return &MemoryFileSystem{nodes: make(map[string]*memoryNode)}
+}
+
+func (m *MemoryFileSystem) Stat(_ context.Context, name string) (os.FileInfo, error) {
+	m.mu.RLock()
+	defer m.mu.RUnlock()
+
+	name = filepath.Clean(name)
+	node, ok := m.lookup(name)
+	if !ok {
+		return nil, &fs.PathError{Op: "stat", Path: name, Err: fs.ErrNotExist}
+	}
+
+	return node.info(name), nil
+}
+
+func (m *MemoryFileSystem) MkdirAll(_ context.Context, path string, _ os.FileMode) error {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	path = filepath.Clean(path)
+	var missing []string
+	for dir := path; !isRoot(dir); dir = filepath.Dir(dir) {
+		node, ok := m.nodes[dir]
+		if ok && !node.dir {
+			return &fs.PathError{Op: "mkdir", Path: dir, Err: syscall.ENOTDIR}
+		}
+		if ok {
+			break
+		}
+		missing = append(missing, dir)
+	}
+
+	for _, dir := range missing {
+		m.nodes[dir] = &memoryNode{dir: true, modTime: time.Now()}
+	}
+
+	return nil
+}
+
+func (m *MemoryFileSystem) Create(ctx context.Context, name string) (ClosableRWFile, error) {
+	return m.OpenFile(ctx, name, os.O_RDWR|os.O_CREATE|os.O_TRUNC, 0666)
+}
+
+func (m *MemoryFileSystem) Open(ctx context.Context, path string) (ClosableRWFile, error) {
+	return m.OpenFile(ctx, path, os.O_RDONLY, 0)
+}
+
+func (m *MemoryFileSystem) OpenFile(_ context.Context, path string, flag int, _ os.FileMode) (ClosableRWFile, error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	path = filepath.Clean(path)
+	writable := flag&(os.O_WRONLY|os.O_RDWR) != 0
+	node, ok := m.lookup(path)
+	switch {
+	case ok && flag&os.O_CREATE != 0 && flag&os.O_EXCL != 0:
+		return nil, &fs.PathError{Op: "open", Path: path, Err: fs.ErrExist}
+	case ok && node.dir && writable:
+		return nil, &fs.PathError{Op: "open", Path: path, Err: syscall.EISDIR}
+	case ok && writable && flag&os.O_TRUNC != 0:
+		node.data = nil
+		node.modTime = time.Now()
+	case !ok && flag&os.O_CREATE == 0:
+		return nil, &fs.PathError{Op: "open", Path: path, Err: fs.ErrNotExist}
+	case !ok:
+		err := m.checkParent("open", path)
+		if err != nil {
+			return nil, err
+		}
+		node = &memoryNode{modTime: time.Now()}
+		m.nodes[path] = node
+	}
+
+	return &memoryFile{
+		fileSystem: m,
+		node:       node,
+		name:       path,
+		readable:   flag&os.O_WRONLY == 0,
+		writable:   writable,
+		append:     flag&os.O_APPEND != 0,
+	}, nil
+}
+
+func (m *MemoryFileSystem) ReadAll(r io.Reader) ([]byte, error) {
+	return io.ReadAll(r)
+}
+
+func (m *MemoryFileSystem) WriteFile(ctx context.Context, name string, data []byte, perm os.FileMode) error {
+	file, err := m.OpenFile(ctx, name, os.O_WRONLY|os.O_CREATE|os.O_TRUNC, perm)
+	if err != nil {
+		return err
+	}
+	defer func() { _ = file.Close() }()
+
+	_, err = file.Write(data)
+	return err
+}
+
+// Remove removes the file or the empty directory.
+func (m *MemoryFileSystem) Remove(_ context.Context, name string) error {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	name = filepath.Clean(name)
+	node, ok := m.nodes[name]
+	if !ok {
+		return &fs.PathError{Op: "remove", Path: name, Err: fs.ErrNotExist}
+	}
+	if node.dir && len(m.children(name)) > 0 {
+		return &fs.PathError{Op: "remove", Path: name, Err: syscall.ENOTEMPTY}
+	}
+	delete(m.nodes, name)
+
+	return nil
+}
+
+// Rename moves the file or the directory with all its children. An existing file at newPath is replaced.
+func (m *MemoryFileSystem) Rename(_ context.Context, oldPath, newPath string) error {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	oldPath = filepath.Clean(oldPath)
+	newPath = filepath.Clean(newPath)
+	node, ok := m.nodes[oldPath]
+	if !ok {
+		return &os.LinkError{Op: "rename", Old: oldPath, New: newPath, Err: fs.ErrNotExist}
+	}
+	if target, exists := m.nodes[newPath]; exists && target.dir {
+		return &os.LinkError{Op: "rename", Old: oldPath, New: newPath, Err: fs.ErrExist}
+	}
+	err := m.checkParent("rename", newPath)
+	if err != nil {
+		return err
+	}
+
+	if node.dir {
+		for name, child := range m.nodes {
+			if rel, below := strings.CutPrefix(name, oldPath+string(filepath.Separator)); below {
+				delete(m.nodes, name)
+				m.nodes[filepath.Join(newPath, rel)] = child
+			}
+		}
+	}
+	delete(m.nodes, oldPath)
+	m.nodes[newPath] = node
+
+	return nil
+}
+
+// RemoveAll removes the file or the directory with all its children. A missing path is no error.
+func (m *MemoryFileSystem) RemoveAll(_ context.Context, path string) error {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	path = filepath.Clean(path)
+	for name := range m.nodes {
+		if name == path || strings.HasPrefix(name, path+string(filepath.Separator)) || isRoot(path) {
+			delete(m.nodes, name)
+		}
+	}
+
+	return nil
+}
+
+// ReadDir returns the direct children of the directory sorted by name.
+func (m *MemoryFileSystem) ReadDir(_ context.Context, name string) ([]os.DirEntry, error) {
+	m.mu.RLock()
+	defer m.mu.RUnlock()
+
+	name = filepath.Clean(name)
+	node, ok := m.lookup(name)
+	if !ok {
+		return nil, &fs.PathError{Op: "readdir", Path: name, Err: fs.ErrNotExist}
+	}
+	if !node.dir {
+		return nil, &fs.PathError{Op: "readdir", Path: name, Err: syscall.ENOTDIR}
+	}
+
+	children := m.children(name)
+	entries := make([]os.DirEntry, 0, len(children))
+	for _, child := range children {
+		entries = append(entries, fs.FileInfoToDirEntry(m.nodes[child].info(child)))
+	}
+	slices.SortFunc(entries, func(a, b os.DirEntry) int {
+		return strings.Compare(a.Name(), b.Name())
+	})
+
+	return entries, nil
+}
+
+func (m *MemoryFileSystem) Copy(dst io.Writer, src io.Reader) (written int64, err error) {
+	return io.Copy(dst, src)
+}
+
+func (m *MemoryFileSystem) WalkDir(ctx context.Context, root string, fn fs.WalkDirFunc) error {
+	return walkDir(ctx, m, root, fn)
+}
+
+// lookup returns the node of the cleaned path. The root directory always exists.
+func (m *MemoryFileSystem) lookup(name string) (*memoryNode, bool) {
+	if isRoot(name) {
+		return &memoryNode{dir: true}, true
+	}
+
+	node, ok := m.nodes[name]
+	return node, ok
+}
+
+func (m *MemoryFileSystem) checkParent(op, name string) error {
+	parent, ok := m.lookup(filepath.Dir(name))
+	if !ok {
+		return &fs.PathError{Op: op, Path: name, Err: fs.ErrNotExist}
+	}
+	if !parent.dir {
+		return &fs.PathError{Op: op, Path: name, Err: syscall.ENOTDIR}
+	}
+
+	return nil
+}
+
+// children returns the paths of the direct children of the directory.
+func (m *MemoryFileSystem) children(dir string) []string {
+	var result []string
+	for name := range m.nodes {
+		if name != dir && filepath.Dir(name) == dir {
+			result = append(result, name)
+		}
+	}
+
+	return result
+}
+
+func (n *memoryNode) info(name string) os.FileInfo {
+	return &fileInfo{name: filepath.Base(name), size: int64(len(n.data)), modTime: n.modTime, dir: n.dir}
+}
+
+func isRoot(name string) bool {
+	return filepath.Dir(name) == name
+}
+
+// memoryFile is an open file of the MemoryFileSystem. It reads and writes the content of the file directly.
+type memoryFile struct {
+	fileSystem *MemoryFileSystem
+	node       *memoryNode
+	name       string
+	offset     int64
+	readable   bool
+	writable   bool
+	append     bool
+	closed     bool
+}
+
+func (f *memoryFile) Read(p []byte) (int, error) {
+	f.fileSystem.mu.Lock()
+	defer f.fileSystem.mu.Unlock()
+
+	err := f.check("read", f.readable)
+	if err != nil {
+		return 0, err
+	}
+	if f.node.dir {
+		return 0, &fs.PathError{Op: "read", Path: f.name, Err: syscall.EISDIR}
+	}
+	if f.offset >= int64(len(f.node.data)) {
+		return 0, io.EOF
+	}
+
+	n := copy(p, f.node.data[f.offset:])
+	f.offset += int64(n)
+	return n, nil
+}
+
+func (f *memoryFile) Write(p []byte) (int, error) {
+	f.fileSystem.mu.Lock()
+	defer f.fileSystem.mu.Unlock()
+
+	err := f.check("write", f.writable)
+	if err != nil {
+		return 0, err
+	}
+	if f.append {
+		f.offset = int64(len(f.node.data))
+	}
+
+	end := f.offset + int64(len(p))
+	if end > int64(len(f.node.data)) {
+		f.node.data = slices.Grow(f.node.data, int(end)-len(f.node.data))[:end]
+	}
+	copy(f.node.data[f.offset:], p)
+	f.offset = end
+	f.node.modTime = time.Now()
+
+	return len(p), nil
+}
+
+// Seek sets the offset for the next Read or Write.
+func (f *memoryFile) Seek(offset int64, whence int) (int64, error) {
+	f.fileSystem.mu.Lock()
+	defer f.fileSystem.mu.Unlock()
+
+	err := f.check("seek", true)
+	if err != nil {
+		return 0, err
+	}
+
+	switch whence {
+	case io.SeekCurrent:
+		offset += f.offset
+	case io.SeekEnd:
+		offset += int64(len(f.node.data))
+	default:
+	}
+	if offset < 0 {
+		return 0, &fs.PathError{Op: "seek", Path: f.name, Err: fs.ErrInvalid}
+	}
+	f.offset = offset
+
+	return offset, nil
+}
+
+// Sync does nothing, because the content is written directly.
+func (f *memoryFile) Sync() error {
+	return f.check("sync", true)
+}
+
+func (f *memoryFile) Close() error {
+	err := f.check("close", true)
+	if err != nil {
+		return err
+	}
+	f.closed = true
+
+	return nil
+}
+
+func (f *memoryFile) check(op string, permitted bool) error {
+	if f.closed {
+		return &fs.PathError{Op: op, Path: f.name, Err: fs.ErrClosed}
+	}
+	if !permitted {
+		return &fs.PathError{Op: op, Path: f.name, Err: fs.ErrPermission}
+	}
+
+	return nil
+}
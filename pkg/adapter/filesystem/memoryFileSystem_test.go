@@ -0,0 +1,358 @@
+package filesystem
+
+import (
+	"io"
+	"io/fs"
+	"os"
+	"syscall"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func readTestFile(t *testing.T, sut *MemoryFileSystem, name string) string {
+	t.Helper()
+
+	file, err := sut.Open(testCtx, name)
+	require.NoError(t, err)
+	defer func() {
+		_ = file.Close()
+	}()
+	content, err := io.ReadAll(file)
+	require.NoError(t, err)
+
+	return string(content)
+}
+
+func TestMemoryFileSystem_MkdirAll(t *testing.T) {
+	t.Run("should create all missing directories", func(t *testing.T) {
+		// given
+		sut := NewMemoryFileSystem()
+
+		// when
+		err := sut.MkdirAll(testCtx, "/data/work/ns", 0755)
+
+		// then
+		require.NoError(t, err)
+		for _, dir := range []string{"/data", "/data/work", "/data/work/ns"} {
+			info, statErr := sut.Stat(testCtx, dir)
+			require.NoError(t, statErr)
+			assert.True(t, info.IsDir(), dir)
+		}
+	})
+	t.Run("should fail if a parent is a file", func(t *testing.T) {
+		// given
+		sut := NewMemoryFileSystem()
+		require.NoError(t, sut.WriteFile(testCtx, "/data", []byte("file"), 0644))
+
+		// when
+		err := sut.MkdirAll(testCtx, "/data/work", 0755)
+
+		// then
+		require.ErrorIs(t, err, syscall.ENOTDIR)
+	})
+}
+
+func TestMemoryFileSystem_OpenFile(t *testing.T) {
+	t.Run("should write and read file", func(t *testing.T) {
+		// given
+		sut := NewMemoryFileSystem()
+		require.NoError(t, sut.MkdirAll(testCtx, "/data", 0755))
+
+		// when
+		file, err := sut.Create(testCtx, "/data/file.txt")
+		require.NoError(t, err)
+		_, err = file.Write([]byte("content"))
+		require.NoError(t, err)
+		require.NoError(t, file.Sync())
+		require.NoError(t, file.Close())
+
+		// then
+		assert.Equal(t, "content", readTestFile(t, sut, "/data/file.txt"))
+		info, err := sut.Stat(testCtx, "/data/file.txt")
+		require.NoError(t, err)
+		assert.Equal(t, int64(7), info.Size())
+		assert.Equal(t, "file.txt", info.Name())
+	})
+	t.Run("should append to existing file", func(t *testing.T) {
+		// given
+		sut := NewMemoryFileSystem()
+		require.NoError(t, sut.WriteFile(testCtx, "/file.txt", []byte("first\n"), 0644))
+
+		// when
+		file, err := sut.OpenFile(testCtx, "/file.txt", os.O_WRONLY|os.O_APPEND|os.O_CREATE, 0644)
+		require.NoError(t, err)
+		_, err = file.Write([]byte("second\n"))
+		require.NoError(t, err)
+		require.NoError(t, file.Close())
+
+		// then
+		assert.Equal(t, "first\nsecond\n", readTestFile(t, sut, "/file.txt"))
+	})
+	t.Run("should overwrite from the start without append", func(t *testing.T) {
+		// given
+		sut := NewMemoryFileSystem()
+		require.NoError(t, sut.WriteFile(testCtx, "/file.txt", []byte("content"), 0644))
+
+		// when
+		file, err := sut.OpenFile(testCtx, "/file.txt", os.O_RDWR, 0644)
+		require.NoError(t, err)
+		_, err = file.Write([]byte("C"))
+		require.NoError(t, err)
+		rest, err := io.ReadAll(file)
+		require.NoError(t, err)
+		require.NoError(t, file.Close())
+
+		// then
+		assert.Equal(t, "ontent", string(rest))
+		assert.Equal(t, "Content", readTestFile(t, sut, "/file.txt"))
+	})
+	t.Run("should truncate existing file", func(t *testing.T) {
+		// given
+		sut := NewMemoryFileSystem()
+		require.NoError(t, sut.WriteFile(testCtx, "/file.txt", []byte("old content"), 0644))
+
+		// when
+		err := sut.WriteFile(testCtx, "/file.txt", []byte("new"), 0644)
+
+		// then
+		require.NoError(t, err)
+		assert.Equal(t, "new", readTestFile(t, sut, "/file.txt"))
+	})
+	t.Run("should seek", func(t *testing.T) {
+		// given
+		sut := NewMemoryFileSystem()
+		require.NoError(t, sut.WriteFile(testCtx, "/file.txt", []byte("content"), 0644))
+		file, err := sut.Open(testCtx, "/file.txt")
+		require.NoError(t, err)
+
+		// when
+		offset, err := file.(io.Seeker).Seek(-4, io.SeekEnd)
+
+		// then
+		require.NoError(t, err)
+		assert.Equal(t, int64(3), offset)
+		rest, err := io.ReadAll(file)
+		require.NoError(t, err)
+		assert.Equal(t, "tent", string(rest))
+	})
+	t.Run("should fail for missing file without create", func(t *testing.T) {
+		// given
+		sut := NewMemoryFileSystem()
+
+		// when
+		_, err := sut.Open(testCtx, "/missing.txt")
+
+		// then
+		require.ErrorIs(t, err, fs.ErrNotExist)
+	})
+	t.Run("should fail for missing parent directory", func(t *testing.T) {
+		// given
+		sut := NewMemoryFileSystem()
+
+		// when
+		_, err := sut.Create(testCtx, "/data/file.txt")
+
+		// then
+		require.ErrorIs(t, err, fs.ErrNotExist)
+	})
+	t.Run("should fail for existing file with exclusive flag", func(t *testing.T) {
+		// given
+		sut := NewMemoryFileSystem()
+		require.NoError(t, sut.WriteFile(testCtx, "/file.txt", []byte("content"), 0644))
+
+		// when
+		_, err := sut.OpenFile(testCtx, "/file.txt", os.O_WRONLY|os.O_CREATE|os.O_EXCL, 0644)
+
+		// then
+		require.ErrorIs(t, err, fs.ErrExist)
+	})
+	t.Run("should fail to write read-only file", func(t *testing.T) {
+		// given
+		sut := NewMemoryFileSystem()
+		require.NoError(t, sut.WriteFile(testCtx, "/file.txt", []byte("content"), 0644))
+		file, err := sut.Open(testCtx, "/file.txt")
+		require.NoError(t, err)
+
+		// when
+		_, err = file.Write([]byte("other"))
+
+		// then
+		require.ErrorIs(t, err, fs.ErrPermission)
+	})
+	t.Run("should fail to read closed file", func(t *testing.T) {
+		// given
+		sut := NewMemoryFileSystem()
+		require.NoError(t, sut.WriteFile(testCtx, "/file.txt", []byte("content"), 0644))
+		file, err := sut.Open(testCtx, "/file.txt")
+		require.NoError(t, err)
+		require.NoError(t, file.Close())
+
+		// when
+		_, err = file.Read(make([]byte, 1))
+
+		// then
+		require.ErrorIs(t, err, fs.ErrClosed)
+	})
+}
+
+func TestMemoryFileSystem_ReadDir(t *testing.T) {
+	t.Run("should return sorted direct children", func(t *testing.T) {
+		// given
+		sut := NewMemoryFileSystem()
+		require.NoError(t, sut.MkdirAll(testCtx, "/data/b/nested", 0755))
+		require.NoError(t, sut.WriteFile(testCtx, "/data/c.txt", []byte("c"), 0644))
+		require.NoError(t, sut.WriteFile(testCtx, "/data/a.txt", []byte("a"), 0644))
+
+		// when
+		entries, err := sut.ReadDir(testCtx, "/data")
+
+		// then
+		require.NoError(t, err)
+		require.Len(t, entries, 3)
+		assert.Equal(t, "a.txt", entries[0].Name())
+		assert.False(t, entries[0].IsDir())
+		assert.Equal(t, "b", entries[1].Name())
+		assert.True(t, entries[1].IsDir())
+		assert.Equal(t, "c.txt", entries[2].Name())
+	})
+	t.Run("should fail for missing directory", func(t *testing.T) {
+		// given
+		sut := NewMemoryFileSystem()
+
+		// when
+		_, err := sut.ReadDir(testCtx, "/missing")
+
+		// then
+		require.ErrorIs(t, err, fs.ErrNotExist)
+	})
+}
+
+func TestMemoryFileSystem_WalkDir(t *testing.T) {
+	// given
+	sut := NewMemoryFileSystem()
+	require.NoError(t, sut.MkdirAll(testCtx, "/data/skipped", 0755))
+	require.NoError(t, sut.MkdirAll(testCtx, "/data/ns", 0755))
+	require.NoError(t, sut.WriteFile(testCtx, "/data/ns/b.txt", []byte("b"), 0644))
+	require.NoError(t, sut.WriteFile(testCtx, "/data/ns/a.txt", []byte("a"), 0644))
+	require.NoError(t, sut.WriteFile(testCtx, "/data/skipped/c.txt", []byte("c"), 0644))
+
+	// when
+	var walked []string
+	err := sut.WalkDir(testCtx, "/data", func(path string, d fs.DirEntry, err error) error {
+		if err != nil {
+			return err
+		}
+		if d.IsDir() && d.Name() == "skipped" {
+			return fs.SkipDir
+		}
+		walked = append(walked, path)
+		return nil
+	})
+
+	// then
+	require.NoError(t, err)
+	assert.Equal(t, []string{"/data", "/data/ns", "/data/ns/a.txt", "/data/ns/b.txt"}, walked)
+}
+
+func TestMemoryFileSystem_Remove(t *testing.T) {
+	t.Run("should remove file", func(t *testing.T) {
+		// given
+		sut := NewMemoryFileSystem()
+		require.NoError(t, sut.WriteFile(testCtx, "/file.txt", []byte("content"), 0644))
+
+		// when
+		err := sut.Remove(testCtx, "/file.txt")
+
+		// then
+		require.NoError(t, err)
+		_, err = sut.Stat(testCtx, "/file.txt")
+		assert.ErrorIs(t, err, fs.ErrNotExist)
+	})
+	t.Run("should fail for directory with children", func(t *testing.T) {
+		// given
+		sut := NewMemoryFileSystem()
+		require.NoError(t, sut.MkdirAll(testCtx, "/data", 0755))
+		require.NoError(t, sut.WriteFile(testCtx, "/data/file.txt", []byte("content"), 0644))
+
+		// when
+		err := sut.Remove(testCtx, "/data")
+
+		// then
+		require.ErrorIs(t, err, syscall.ENOTEMPTY)
+	})
+	t.Run("should fail for missing file", func(t *testing.T) {
+		// given
+		sut := NewMemoryFileSystem()
+
+		// when
+		err := sut.Remove(testCtx, "/missing.txt")
+
+		// then
+		require.ErrorIs(t, err, fs.ErrNotExist)
+	})
+}
+
+func TestMemoryFileSystem_RemoveAll(t *testing.T) {
+	// given
+	sut := NewMemoryFileSystem()
+	require.NoError(t, sut.MkdirAll(testCtx, "/data/work/ns", 0755))
+	require.NoError(t, sut.WriteFile(testCtx, "/data/work/ns/file.txt", []byte("content"), 0644))
+	require.NoError(t, sut.WriteFile(testCtx, "/data/work-other.txt", []byte("content"), 0644))
+
+	// when
+	err := sut.RemoveAll(testCtx, "/data/work")
+
+	// then
+	require.NoError(t, err)
+	_, err = sut.Stat(testCtx, "/data/work/ns/file.txt")
+	assert.ErrorIs(t, err, fs.ErrNotExist)
+	_, err = sut.Stat(testCtx, "/data/work")
+	assert.ErrorIs(t, err, fs.ErrNotExist)
+	assert.Equal(t, "content", readTestFile(t, sut, "/data/work-other.txt"))
+	assert.NoError(t, sut.RemoveAll(testCtx, "/missing"))
+}
+
+func TestMemoryFileSystem_Rename(t *testing.T) {
+	t.Run("should replace existing file", func(t *testing.T) {
+		// given
+		sut := NewMemoryFileSystem()
+		require.NoError(t, sut.WriteFile(testCtx, "/file.txt.tmp", []byte("new"), 0644))
+		require.NoError(t, sut.WriteFile(testCtx, "/file.txt", []byte("old"), 0644))
+
+		// when
+		err := sut.Rename(testCtx, "/file.txt.tmp", "/file.txt")
+
+		// then
+		require.NoError(t, err)
+		assert.Equal(t, "new", readTestFile(t, sut, "/file.txt"))
+		_, err = sut.Stat(testCtx, "/file.txt.tmp")
+		assert.ErrorIs(t, err, fs.ErrNotExist)
+	})
+	t.Run("should move directory with children", func(t *testing.T) {
+		// given
+		sut := NewMemoryFileSystem()
+		require.NoError(t, sut.MkdirAll(testCtx, "/old/nested", 0755))
+		require.NoError(t, sut.WriteFile(testCtx, "/old/nested/file.txt", []byte("content"), 0644))
+
+		// when
+		err := sut.Rename(testCtx, "/old", "/new")
+
+		// then
+		require.NoError(t, err)
+		assert.Equal(t, "content", readTestFile(t, sut, "/new/nested/file.txt"))
+		_, err = sut.Stat(testCtx, "/old/nested")
+		assert.ErrorIs(t, err, fs.ErrNotExist)
+	})
+	t.Run("should fail for missing file", func(t *testing.T) {
+		// given
+		sut := NewMemoryFileSystem()
+
+		// when
+		err := sut.Rename(testCtx, "/missing.txt", "/file.txt")
+
+		// then
+		require.ErrorIs(t, err, fs.ErrNotExist)
+	})
+}
@@ -118,6 +118,51 @@ func (_c *MockClosableRWFile_Read_Call) RunAndReturn(run func([]byte) (int, erro
 	return _c
 }
 
+// Sync provides a mock function with no fields
+func (_m *MockClosableRWFile) Sync() error {
+	ret := _m.Called()
+
+	if len(ret) == 0 {
+		panic("no return value specified for Sync")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func() error); ok {
+		r0 = rf()
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// MockClosableRWFile_Sync_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'Sync'
+type MockClosableRWFile_Sync_Call struct {
+	*mock.Call
+}
+
+// Sync is a helper method to define mock.On call
+func (_e *MockClosableRWFile_Expecter) Sync() *MockClosableRWFile_Sync_Call {
+	return &MockClosableRWFile_Sync_Call{Call: _e.mock.On("Sync")}
+}
+
+func (_c *MockClosableRWFile_Sync_Call) Run(run func()) *MockClosableRWFile_Sync_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run()
+	})
+	return _c
+}
+
+func (_c *MockClosableRWFile_Sync_Call) Return(_a0 error) *MockClosableRWFile_Sync_Call {
+	_c.Call.Return(_a0)
+	return _c
+}
+
+func (_c *MockClosableRWFile_Sync_Call) RunAndReturn(run func() error) *MockClosableRWFile_Sync_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
 // Write provides a mock function with given fields: p
 func (_m *MockClosableRWFile) Write(p []byte) (int, error) {
 	ret := _m.Called(p)
@@ -1,11 +1,13 @@
-// Code generated by mockery v2.53.3. DO NOT EDIT.
+// Code generated by mockery v2.53.7. DO NOT EDIT.
 
 package filesystem
 
 import (
-	io "io"
+	context "context"
 	fs "io/fs"
 
+	io "io"
+
 	mock "github.com/stretchr/testify/mock"
 )
 
@@ -79,9 +81,9 @@ func (_c *MockFilesystem_Copy_Call) RunAndReturn(run func(io.Writer, io.Reader)
 	return _c
 }
 
-// Create provides a mock function with given fields: name
-func (_m *MockFilesystem) Create(name string) (ClosableRWFile, error) {
-	ret := _m.Called(name)
+// Create provides a mock function with given fields: ctx, name
+func (_m *MockFilesystem) Create(ctx context.Context, name string) (ClosableRWFile, error) {
+	ret := _m.Called(ctx, name)
 
 	if len(ret) == 0 {
 		panic("no return value specified for Create")
@@ -89,19 +91,19 @@ func (_m *MockFilesystem) Create(name string) (ClosableRWFile, error) {
 
 	var r0 ClosableRWFile
 	var r1 error
-	if rf, ok := ret.Get(0).(func(string) (ClosableRWFile, error)); ok {
-		return rf(name)
+	if rf, ok := ret.Get(0).(func(context.Context, string) (ClosableRWFile, error)); ok {
+		return rf(ctx, name)
 	}
-	if rf, ok := ret.Get(0).(func(string) ClosableRWFile); ok {
-		r0 = rf(name)
+	if rf, ok := ret.Get(0).(func(context.Context, string) ClosableRWFile); ok {
+		r0 = rf(ctx, name)
 	} else {
 		if ret.Get(0) != nil {
 			r0 = ret.Get(0).(ClosableRWFile)
 		}
 	}
 
-	if rf, ok := ret.Get(1).(func(string) error); ok {
-		r1 = rf(name)
+	if rf, ok := ret.Get(1).(func(context.Context, string) error); ok {
+		r1 = rf(ctx, name)
 	} else {
 		r1 = ret.Error(1)
 	}
@@ -115,14 +117,15 @@ type MockFilesystem_Create_Call struct {
 }
 
 // Create is a helper method to define mock.On call
+//   - ctx context.Context
 //   - name string
-func (_e *MockFilesystem_Expecter) Create(name interface{}) *MockFilesystem_Create_Call {
-	return &MockFilesystem_Create_Call{Call: _e.mock.On("Create", name)}
+func (_e *MockFilesystem_Expecter) Create(ctx interface{}, name interface{}) *MockFilesystem_Create_Call {
+	return &MockFilesystem_Create_Call{Call: _e.mock.On("Create", ctx, name)}
 }
 
-func (_c *MockFilesystem_Create_Call) Run(run func(name string)) *MockFilesystem_Create_Call {
+func (_c *MockFilesystem_Create_Call) Run(run func(ctx context.Context, name string)) *MockFilesystem_Create_Call {
 	_c.Call.Run(func(args mock.Arguments) {
-		run(args[0].(string))
+		run(args[0].(context.Context), args[1].(string))
 	})
 	return _c
 }
@@ -132,22 +135,22 @@ func (_c *MockFilesystem_Create_Call) Return(_a0 ClosableRWFile, _a1 error) *Moc
 	return _c
 }
 
-func (_c *MockFilesystem_Create_Call) RunAndReturn(run func(string) (ClosableRWFile, error)) *MockFilesystem_Create_Call {
+func (_c *MockFilesystem_Create_Call) RunAndReturn(run func(context.Context, string) (ClosableRWFile, error)) *MockFilesystem_Create_Call {
 	_c.Call.Return(run)
 	return _c
 }
 
-// MkdirAll provides a mock function with given fields: path, perm
-func (_m *MockFilesystem) MkdirAll(path string, perm fs.FileMode) error {
-	ret := _m.Called(path, perm)
+// MkdirAll provides a mock function with given fields: ctx, path, perm
+func (_m *MockFilesystem) MkdirAll(ctx context.Context, path string, perm fs.FileMode) error {
+	ret := _m.Called(ctx, path, perm)
 
 	if len(ret) == 0 {
 		panic("no return value specified for MkdirAll")
 	}
 
 	var r0 error
-	if rf, ok := ret.Get(0).(func(string, fs.FileMode) error); ok {
-		r0 = rf(path, perm)
+	if rf, ok := ret.Get(0).(func(context.Context, string, fs.FileMode) error); ok {
+		r0 = rf(ctx, path, perm)
 	} else {
 		r0 = ret.Error(0)
 	}
@@ -161,15 +164,16 @@ type MockFilesystem_MkdirAll_Call struct {
 }
 
 // MkdirAll is a helper method to define mock.On call
+//   - ctx context.Context
 //   - path string
 //   - perm fs.FileMode
-func (_e *MockFilesystem_Expecter) MkdirAll(path interface{}, perm interface{}) *MockFilesystem_MkdirAll_Call {
-	return &MockFilesystem_MkdirAll_Call{Call: _e.mock.On("MkdirAll", path, perm)}
+func (_e *MockFilesystem_Expecter) MkdirAll(ctx interface{}, path interface{}, perm interface{}) *MockFilesystem_MkdirAll_Call {
+	return &MockFilesystem_MkdirAll_Call{Call: _e.mock.On("MkdirAll", ctx, path, perm)}
 }
 
-func (_c *MockFilesystem_MkdirAll_Call) Run(run func(path string, perm fs.FileMode)) *MockFilesystem_MkdirAll_Call {
+func (_c *MockFilesystem_MkdirAll_Call) Run(run func(ctx context.Context, path string, perm fs.FileMode)) *MockFilesystem_MkdirAll_Call {
 	_c.Call.Run(func(args mock.Arguments) {
-		run(args[0].(string), args[1].(fs.FileMode))
+		run(args[0].(context.Context), args[1].(string), args[2].(fs.FileMode))
 	})
 	return _c
 }
@@ -179,14 +183,14 @@ func (_c *MockFilesystem_MkdirAll_Call) Return(_a0 error) *MockFilesystem_MkdirA
 	return _c
 }
 
-func (_c *MockFilesystem_MkdirAll_Call) RunAndReturn(run func(string, fs.FileMode) error) *MockFilesystem_MkdirAll_Call {
+func (_c *MockFilesystem_MkdirAll_Call) RunAndReturn(run func(context.Context, string, fs.FileMode) error) *MockFilesystem_MkdirAll_Call {
 	_c.Call.Return(run)
 	return _c
 }
 
-// Open provides a mock function with given fields: path
-func (_m *MockFilesystem) Open(path string) (ClosableRWFile, error) {
-	ret := _m.Called(path)
+// Open provides a mock function with given fields: ctx, path
+func (_m *MockFilesystem) Open(ctx context.Context, path string) (ClosableRWFile, error) {
+	ret := _m.Called(ctx, path)
 
 	if len(ret) == 0 {
 		panic("no return value specified for Open")
@@ -194,19 +198,19 @@ func (_m *MockFilesystem) Open(path string) (ClosableRWFile, error) {
 
 	var r0 ClosableRWFile
 	var r1 error
-	if rf, ok := ret.Get(0).(func(string) (ClosableRWFile, error)); ok {
-		return rf(path)
+	if rf, ok := ret.Get(0).(func(context.Context, string) (ClosableRWFile, error)); ok {
+		return rf(ctx, path)
 	}
-	if rf, ok := ret.Get(0).(func(string) ClosableRWFile); ok {
-		r0 = rf(path)
+	if rf, ok := ret.Get(0).(func(context.Context, string) ClosableRWFile); ok {
+		r0 = rf(ctx, path)
 	} else {
 		if ret.Get(0) != nil {
 			r0 = ret.Get(0).(ClosableRWFile)
 		}
 	}
 
-	if rf, ok := ret.Get(1).(func(string) error); ok {
-		r1 = rf(path)
+	if rf, ok := ret.Get(1).(func(context.Context, string) error); ok {
+		r1 = rf(ctx, path)
 	} else {
 		r1 = ret.Error(1)
 	}
@@ -220,14 +224,15 @@ type MockFilesystem_Open_Call struct {
 }
 
 // Open is a helper method to define mock.On call
+//   - ctx context.Context
 //   - path string
-func (_e *MockFilesystem_Expecter) Open(path interface{}) *MockFilesystem_Open_Call {
-	return &MockFilesystem_Open_Call{Call: _e.mock.On("Open", path)}
+func (_e *MockFilesystem_Expecter) Open(ctx interface{}, path interface{}) *MockFilesystem_Open_Call {
+	return &MockFilesystem_Open_Call{Call: _e.mock.On("Open", ctx, path)}
 }
 
-func (_c *MockFilesystem_Open_Call) Run(run func(path string)) *MockFilesystem_Open_Call {
+func (_c *MockFilesystem_Open_Call) Run(run func(ctx context.Context, path string)) *MockFilesystem_Open_Call {
 	_c.Call.Run(func(args mock.Arguments) {
-		run(args[0].(string))
+		run(args[0].(context.Context), args[1].(string))
 	})
 	return _c
 }
@@ -237,14 +242,14 @@ func (_c *MockFilesystem_Open_Call) Return(_a0 ClosableRWFile, _a1 error) *MockF
 	return _c
 }
 
-func (_c *MockFilesystem_Open_Call) RunAndReturn(run func(string) (ClosableRWFile, error)) *MockFilesystem_Open_Call {
+func (_c *MockFilesystem_Open_Call) RunAndReturn(run func(context.Context, string) (ClosableRWFile, error)) *MockFilesystem_Open_Call {
 	_c.Call.Return(run)
 	return _c
 }
 
-// OpenFile provides a mock function with given fields: path, flag, perm
-func (_m *MockFilesystem) OpenFile(path string, flag int, perm fs.FileMode) (ClosableRWFile, error) {
-	ret := _m.Called(path, flag, perm)
+// OpenFile provides a mock function with given fields: ctx, path, flag, perm
+func (_m *MockFilesystem) OpenFile(ctx context.Context, path string, flag int, perm fs.FileMode) (ClosableRWFile, error) {
+	ret := _m.Called(ctx, path, flag, perm)
 
 	if len(ret) == 0 {
 		panic("no return value specified for OpenFile")
@@ -252,19 +257,19 @@ func (_m *MockFilesystem) OpenFile(path string, flag int, perm fs.FileMode) (Clo
 
 	var r0 ClosableRWFile
 	var r1 error
-	if rf, ok := ret.Get(0).(func(string, int, fs.FileMode) (ClosableRWFile, error)); ok {
-		return rf(path, flag, perm)
+	if rf, ok := ret.Get(0).(func(context.Context, string, int, fs.FileMode) (ClosableRWFile, error)); ok {
+		return rf(ctx, path, flag, perm)
 	}
-	if rf, ok := ret.Get(0).(func(string, int, fs.FileMode) ClosableRWFile); ok {
-		r0 = rf(path, flag, perm)
+	if rf, ok := ret.Get(0).(func(context.Context, string, int, fs.FileMode) ClosableRWFile); ok {
+		r0 = rf(ctx, path, flag, perm)
 	} else {
 		if ret.Get(0) != nil {
 			r0 = ret.Get(0).(ClosableRWFile)
 		}
 	}
 
-	if rf, ok := ret.Get(1).(func(string, int, fs.FileMode) error); ok {
-		r1 = rf(path, flag, perm)
+	if rf, ok := ret.Get(1).(func(context.Context, string, int, fs.FileMode) error); ok {
+		r1 = rf(ctx, path, flag, perm)
 	} else {
 		r1 = ret.Error(1)
 	}
@@ -278,16 +283,17 @@ type MockFilesystem_OpenFile_Call struct {
 }
 
 // OpenFile is a helper method to define mock.On call
+//   - ctx context.Context
 //   - path string
 //   - flag int
 //   - perm fs.FileMode
-func (_e *MockFilesystem_Expecter) OpenFile(path interface{}, flag interface{}, perm interface{}) *MockFilesystem_OpenFile_Call {
-	return &MockFilesystem_OpenFile_Call{Call: _e.mock.On("OpenFile", path, flag, perm)}
+func (_e *MockFilesystem_Expecter) OpenFile(ctx interface{}, path interface{}, flag interface{}, perm interface{}) *MockFilesystem_OpenFile_Call {
+	return &MockFilesystem_OpenFile_Call{Call: _e.mock.On("OpenFile", ctx, path, flag, perm)}
 }
 
-func (_c *MockFilesystem_OpenFile_Call) Run(run func(path string, flag int, perm fs.FileMode)) *MockFilesystem_OpenFile_Call {
+func (_c *MockFilesystem_OpenFile_Call) Run(run func(ctx context.Context, path string, flag int, perm fs.FileMode)) *MockFilesystem_OpenFile_Call {
 	_c.Call.Run(func(args mock.Arguments) {
-		run(args[0].(string), args[1].(int), args[2].(fs.FileMode))
+		run(args[0].(context.Context), args[1].(string), args[2].(int), args[3].(fs.FileMode))
 	})
 	return _c
 }
@@ -297,7 +303,7 @@ func (_c *MockFilesystem_OpenFile_Call) Return(_a0 ClosableRWFile, _a1 error) *M
 	return _c
 }
 
-func (_c *MockFilesystem_OpenFile_Call) RunAndReturn(run func(string, int, fs.FileMode) (ClosableRWFile, error)) *MockFilesystem_OpenFile_Call {
+func (_c *MockFilesystem_OpenFile_Call) RunAndReturn(run func(context.Context, string, int, fs.FileMode) (ClosableRWFile, error)) *MockFilesystem_OpenFile_Call {
 	_c.Call.Return(run)
 	return _c
 }
@@ -360,9 +366,9 @@ func (_c *MockFilesystem_ReadAll_Call) RunAndReturn(run func(io.Reader) ([]byte,
 	return _c
 }
 
-// ReadDir provides a mock function with given fields: name
-func (_m *MockFilesystem) ReadDir(name string) ([]fs.DirEntry, error) {
-	ret := _m.Called(name)
+// ReadDir provides a mock function with given fields: ctx, name
+func (_m *MockFilesystem) ReadDir(ctx context.Context, name string) ([]fs.DirEntry, error) {
+	ret := _m.Called(ctx, name)
 
 	if len(ret) == 0 {
 		panic("no return value specified for ReadDir")
@@ -370,19 +376,19 @@ func (_m *MockFilesystem) ReadDir(name string) ([]fs.DirEntry, error) {
 
 	var r0 []fs.DirEntry
 	var r1 error
-	if rf, ok := ret.Get(0).(func(string) ([]fs.DirEntry, error)); ok {
-		return rf(name)
+	if rf, ok := ret.Get(0).(func(context.Context, string) ([]fs.DirEntry, error)); ok {
+		return rf(ctx, name)
 	}
-	if rf, ok := ret.Get(0).(func(string) []fs.DirEntry); ok {
-		r0 = rf(name)
+	if rf, ok := ret.Get(0).(func(context.Context, string) []fs.DirEntry); ok {
+		r0 = rf(ctx, name)
 	} else {
 		if ret.Get(0) != nil {
 			r0 = ret.Get(0).([]fs.DirEntry)
 		}
 	}
 
-	if rf, ok := ret.Get(1).(func(string) error); ok {
-		r1 = rf(name)
+	if rf, ok := ret.Get(1).(func(context.Context, string) error); ok {
+		r1 = rf(ctx, name)
 	} else {
 		r1 = ret.Error(1)
 	}
@@ -396,14 +402,15 @@ type MockFilesystem_ReadDir_Call struct {
 }
 
 // ReadDir is a helper method to define mock.On call
+//   - ctx context.Context
 //   - name string
-func (_e *MockFilesystem_Expecter) ReadDir(name interface{}) *MockFilesystem_ReadDir_Call {
-	return &MockFilesystem_ReadDir_Call{Call: _e.mock.On("ReadDir", name)}
+func (_e *MockFilesystem_Expecter) ReadDir(ctx interface{}, name interface{}) *MockFilesystem_ReadDir_Call {
+	return &MockFilesystem_ReadDir_Call{Call: _e.mock.On("ReadDir", ctx, name)}
 }
 
-func (_c *MockFilesystem_ReadDir_Call) Run(run func(name string)) *MockFilesystem_ReadDir_Call {
+func (_c *MockFilesystem_ReadDir_Call) Run(run func(ctx context.Context, name string)) *MockFilesystem_ReadDir_Call {
 	_c.Call.Run(func(args mock.Arguments) {
-		run(args[0].(string))
+		run(args[0].(context.Context), args[1].(string))
 	})
 	return _c
 }
@@ -413,22 +420,22 @@ func (_c *MockFilesystem_ReadDir_Call) Return(_a0 []fs.DirEntry, _a1 error) *Moc
 	return _c
 }
 
-func (_c *MockFilesystem_ReadDir_Call) RunAndReturn(run func(string) ([]fs.DirEntry, error)) *MockFilesystem_ReadDir_Call {
+func (_c *MockFilesystem_ReadDir_Call) RunAndReturn(run func(context.Context, string) ([]fs.DirEntry, error)) *MockFilesystem_ReadDir_Call {
 	_c.Call.Return(run)
 	return _c
 }
 
-// Remove provides a mock function with given fields: name
-func (_m *MockFilesystem) Remove(name string) error {
-	ret := _m.Called(name)
+// Remove provides a mock function with given fields: ctx, name
+func (_m *MockFilesystem) Remove(ctx context.Context, name string) error {
+	ret := _m.Called(ctx, name)
 
 	if len(ret) == 0 {
 		panic("no return value specified for Remove")
 	}
 
 	var r0 error
-	if rf, ok := ret.Get(0).(func(string) error); ok {
-		r0 = rf(name)
+	if rf, ok := ret.Get(0).(func(context.Context, string) error); ok {
+		r0 = rf(ctx, name)
 	} else {
 		r0 = ret.Error(0)
 	}
@@ -442,14 +449,15 @@ type MockFilesystem_Remove_Call struct {
 }
 
 // Remove is a helper method to define mock.On call
+//   - ctx context.Context
 //   - name string
-func (_e *MockFilesystem_Expecter) Remove(name interface{}) *MockFilesystem_Remove_Call {
-	return &MockFilesystem_Remove_Call{Call: _e.mock.On("Remove", name)}
+func (_e *MockFilesystem_Expecter) Remove(ctx interface{}, name interface{}) *MockFilesystem_Remove_Call {
+	return &MockFilesystem_Remove_Call{Call: _e.mock.On("Remove", ctx, name)}
 }
 
-func (_c *MockFilesystem_Remove_Call) Run(run func(name string)) *MockFilesystem_Remove_Call {
+func (_c *MockFilesystem_Remove_Call) Run(run func(ctx context.Context, name string)) *MockFilesystem_Remove_Call {
 	_c.Call.Run(func(args mock.Arguments) {
-		run(args[0].(string))
+		run(args[0].(context.Context), args[1].(string))
 	})
 	return _c
 }
@@ -459,22 +467,22 @@ func (_c *MockFilesystem_Remove_Call) Return(_a0 error) *MockFilesystem_Remove_C
 	return _c
 }
 
-func (_c *MockFilesystem_Remove_Call) RunAndReturn(run func(string) error) *MockFilesystem_Remove_Call {
+func (_c *MockFilesystem_Remove_Call) RunAndReturn(run func(context.Context, string) error) *MockFilesystem_Remove_Call {
 	_c.Call.Return(run)
 	return _c
 }
 
-// RemoveAll provides a mock function with given fields: path
-func (_m *MockFilesystem) RemoveAll(path string) error {
-	ret := _m.Called(path)
+// RemoveAll provides a mock function with given fields: ctx, path
+func (_m *MockFilesystem) RemoveAll(ctx context.Context, path string) error {
+	ret := _m.Called(ctx, path)
 
 	if len(ret) == 0 {
 		panic("no return value specified for RemoveAll")
 	}
 
 	var r0 error
-	if rf, ok := ret.Get(0).(func(string) error); ok {
-		r0 = rf(path)
+	if rf, ok := ret.Get(0).(func(context.Context, string) error); ok {
+		r0 = rf(ctx, path)
 	} else {
 		r0 = ret.Error(0)
 	}
@@ -488,14 +496,15 @@ type MockFilesystem_RemoveAll_Call struct {
 }
 
 // RemoveAll is a helper method to define mock.On call
+//   - ctx context.Context
 //   - path string
-func (_e *MockFilesystem_Expecter) RemoveAll(path interface{}) *MockFilesystem_RemoveAll_Call {
-	return &MockFilesystem_RemoveAll_Call{Call: _e.mock.On("RemoveAll", path)}
+func (_e *MockFilesystem_Expecter) RemoveAll(ctx interface{}, path interface{}) *MockFilesystem_RemoveAll_Call {
+	return &MockFilesystem_RemoveAll_Call{Call: _e.mock.On("RemoveAll", ctx, path)}
 }
 
-func (_c *MockFilesystem_RemoveAll_Call) Run(run func(path string)) *MockFilesystem_RemoveAll_Call {
+func (_c *MockFilesystem_RemoveAll_Call) Run(run func(ctx context.Context, path string)) *MockFilesystem_RemoveAll_Call {
 	_c.Call.Run(func(args mock.Arguments) {
-		run(args[0].(string))
+		run(args[0].(context.Context), args[1].(string))
 	})
 	return _c
 }
@@ -505,14 +514,62 @@ func (_c *MockFilesystem_RemoveAll_Call) Return(_a0 error) *MockFilesystem_Remov
 	return _c
 }
 
-func (_c *MockFilesystem_RemoveAll_Call) RunAndReturn(run func(string) error) *MockFilesystem_RemoveAll_Call {
+func (_c *MockFilesystem_RemoveAll_Call) RunAndReturn(run func(context.Context, string) error) *MockFilesystem_RemoveAll_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// Rename provides a mock function with given fields: ctx, oldPath, newPath
+func (_m *MockFilesystem) Rename(ctx context.Context, oldPath string, newPath string) error {
+	ret := _m.Called(ctx, oldPath, newPath)
+
+	if len(ret) == 0 {
+		panic("no return value specified for Rename")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, string, string) error); ok {
+		r0 = rf(ctx, oldPath, newPath)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// MockFilesystem_Rename_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'Rename'
+type MockFilesystem_Rename_Call struct {
+	*mock.Call
+}
+
+// Rename is a helper method to define mock.On call
+//   - ctx context.Context
+//   - oldPath string
+//   - newPath string
+func (_e *MockFilesystem_Expecter) Rename(ctx interface{}, oldPath interface{}, newPath interface{}) *MockFilesystem_Rename_Call {
+	return &MockFilesystem_Rename_Call{Call: _e.mock.On("Rename", ctx, oldPath, newPath)}
+}
+
+func (_c *MockFilesystem_Rename_Call) Run(run func(ctx context.Context, oldPath string, newPath string)) *MockFilesystem_Rename_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(string), args[2].(string))
+	})
+	return _c
+}
+
+func (_c *MockFilesystem_Rename_Call) Return(_a0 error) *MockFilesystem_Rename_Call {
+	_c.Call.Return(_a0)
+	return _c
+}
+
+func (_c *MockFilesystem_Rename_Call) RunAndReturn(run func(context.Context, string, string) error) *MockFilesystem_Rename_Call {
 	_c.Call.Return(run)
 	return _c
 }
 
-// Stat provides a mock function with given fields: name
-func (_m *MockFilesystem) Stat(name string) (fs.FileInfo, error) {
-	ret := _m.Called(name)
+// Stat provides a mock function with given fields: ctx, name
+func (_m *MockFilesystem) Stat(ctx context.Context, name string) (fs.FileInfo, error) {
+	ret := _m.Called(ctx, name)
 
 	if len(ret) == 0 {
 		panic("no return value specified for Stat")
@@ -520,19 +577,19 @@ func (_m *MockFilesystem) Stat(name string) (fs.FileInfo, error) {
 
 	var r0 fs.FileInfo
 	var r1 error
-	if rf, ok := ret.Get(0).(func(string) (fs.FileInfo, error)); ok {
-		return rf(name)
+	if rf, ok := ret.Get(0).(func(context.Context, string) (fs.FileInfo, error)); ok {
+		return rf(ctx, name)
 	}
-	if rf, ok := ret.Get(0).(func(string) fs.FileInfo); ok {
-		r0 = rf(name)
+	if rf, ok := ret.Get(0).(func(context.Context, string) fs.FileInfo); ok {
+		r0 = rf(ctx, name)
 	} else {
 		if ret.Get(0) != nil {
 			r0 = ret.Get(0).(fs.FileInfo)
 		}
 	}
 
-	if rf, ok := ret.Get(1).(func(string) error); ok {
-		r1 = rf(name)
+	if rf, ok := ret.Get(1).(func(context.Context, string) error); ok {
+		r1 = rf(ctx, name)
 	} else {
 		r1 = ret.Error(1)
 	}
@@ -546,14 +603,15 @@ type MockFilesystem_Stat_Call struct {
 }
 
 // Stat is a helper method to define mock.On call
+//   - ctx context.Context
 //   - name string
-func (_e *MockFilesystem_Expecter) Stat(name interface{}) *MockFilesystem_Stat_Call {
-	return &MockFilesystem_Stat_Call{Call: _e.mock.On("Stat", name)}
+func (_e *MockFilesystem_Expecter) Stat(ctx interface{}, name interface{}) *MockFilesystem_Stat_Call {
+	return &MockFilesystem_Stat_Call{Call: _e.mock.On("Stat", ctx, name)}
 }
 
-func (_c *MockFilesystem_Stat_Call) Run(run func(name string)) *MockFilesystem_Stat_Call {
+func (_c *MockFilesystem_Stat_Call) Run(run func(ctx context.Context, name string)) *MockFilesystem_Stat_Call {
 	_c.Call.Run(func(args mock.Arguments) {
-		run(args[0].(string))
+		run(args[0].(context.Context), args[1].(string))
 	})
 	return _c
 }
@@ -563,22 +621,22 @@ func (_c *MockFilesystem_Stat_Call) Return(_a0 fs.FileInfo, _a1 error) *MockFile
 	return _c
 }
 
-func (_c *MockFilesystem_Stat_Call) RunAndReturn(run func(string) (fs.FileInfo, error)) *MockFilesystem_Stat_Call {
+func (_c *MockFilesystem_Stat_Call) RunAndReturn(run func(context.Context, string) (fs.FileInfo, error)) *MockFilesystem_Stat_Call {
 	_c.Call.Return(run)
 	return _c
 }
 
-// WalkDir provides a mock function with given fields: root, fn
-func (_m *MockFilesystem) WalkDir(root string, fn fs.WalkDirFunc) error {
-	ret := _m.Called(root, fn)
+// WalkDir provides a mock function with given fields: ctx, root, fn
+func (_m *MockFilesystem) WalkDir(ctx context.Context, root string, fn fs.WalkDirFunc) error {
+	ret := _m.Called(ctx, root, fn)
 
 	if len(ret) == 0 {
 		panic("no return value specified for WalkDir")
 	}
 
 	var r0 error
-	if rf, ok := ret.Get(0).(func(string, fs.WalkDirFunc) error); ok {
-		r0 = rf(root, fn)
+	if rf, ok := ret.Get(0).(func(context.Context, string, fs.WalkDirFunc) error); ok {
+		r0 = rf(ctx, root, fn)
 	} else {
 		r0 = ret.Error(0)
 	}
@@ -592,15 +650,16 @@ type MockFilesystem_WalkDir_Call struct {
 }
 
 // WalkDir is a helper method to define mock.On call
+//   - ctx context.Context
 //   - root string
 //   - fn fs.WalkDirFunc
-func (_e *MockFilesystem_Expecter) WalkDir(root interface{}, fn interface{}) *MockFilesystem_WalkDir_Call {
-	return &MockFilesystem_WalkDir_Call{Call: _e.mock.On("WalkDir", root, fn)}
+func (_e *MockFilesystem_Expecter) WalkDir(ctx interface{}, root interface{}, fn interface{}) *MockFilesystem_WalkDir_Call {
+	return &MockFilesystem_WalkDir_Call{Call: _e.mock.On("WalkDir", ctx, root, fn)}
 }
 
-func (_c *MockFilesystem_WalkDir_Call) Run(run func(root string, fn fs.WalkDirFunc)) *MockFilesystem_WalkDir_Call {
+func (_c *MockFilesystem_WalkDir_Call) Run(run func(ctx context.Context, root string, fn fs.WalkDirFunc)) *MockFilesystem_WalkDir_Call {
 	_c.Call.Run(func(args mock.Arguments) {
-		run(args[0].(string), args[1].(fs.WalkDirFunc))
+		run(args[0].(context.Context), args[1].(string), args[2].(fs.WalkDirFunc))
 	})
 	return _c
 }
@@ -610,22 +669,22 @@ func (_c *MockFilesystem_WalkDir_Call) Return(_a0 error) *MockFilesystem_WalkDir
 	return _c
 }
 
-func (_c *MockFilesystem_WalkDir_Call) RunAndReturn(run func(string, fs.WalkDirFunc) error) *MockFilesystem_WalkDir_Call {
+func (_c *MockFilesystem_WalkDir_Call) RunAndReturn(run func(context.Context, string, fs.WalkDirFunc) error) *MockFilesystem_WalkDir_Call {
 	_c.Call.Return(run)
 	return _c
 }
 
-// WriteFile provides a mock function with given fields: name, data, perm
-func (_m *MockFilesystem) WriteFile(name string, data []byte, perm fs.FileMode) error {
-	ret := _m.Called(name, data, perm)
+// WriteFile provides a mock function with given fields: ctx, name, data, perm
+func (_m *MockFilesystem) WriteFile(ctx context.Context, name string, data []byte, perm fs.FileMode) error {
+	ret := _m.Called(ctx, name, data, perm)
 
 	if len(ret) == 0 {
 		panic("no return value specified for WriteFile")
 	}
 
 	var r0 error
-	if rf, ok := ret.Get(0).(func(string, []byte, fs.FileMode) error); ok {
-		r0 = rf(name, data, perm)
+	if rf, ok := ret.Get(0).(func(context.Context, string, []byte, fs.FileMode) error); ok {
+		r0 = rf(ctx, name, data, perm)
 	} else {
 		r0 = ret.Error(0)
 	}
@@ -639,16 +698,17 @@ type MockFilesystem_WriteFile_Call struct {
 }
 
 // WriteFile is a helper method to define mock.On call
+//   - ctx context.Context
 //   - name string
 //   - data []byte
 //   - perm fs.FileMode
-func (_e *MockFilesystem_Expecter) WriteFile(name interface{}, data interface{}, perm interface{}) *MockFilesystem_WriteFile_Call {
-	return &MockFilesystem_WriteFile_Call{Call: _e.mock.On("WriteFile", name, data, perm)}
+func (_e *MockFilesystem_Expecter) WriteFile(ctx interface{}, name interface{}, data interface{}, perm interface{}) *MockFilesystem_WriteFile_Call {
+	return &MockFilesystem_WriteFile_Call{Call: _e.mock.On("WriteFile", ctx, name, data, perm)}
 }
 
-func (_c *MockFilesystem_WriteFile_Call) Run(run func(name string, data []byte, perm fs.FileMode)) *MockFilesystem_WriteFile_Call {
+func (_c *MockFilesystem_WriteFile_Call) Run(run func(ctx context.Context, name string, data []byte, perm fs.FileMode)) *MockFilesystem_WriteFile_Call {
 	_c.Call.Run(func(args mock.Arguments) {
-		run(args[0].(string), args[1].([]byte), args[2].(fs.FileMode))
+		run(args[0].(context.Context), args[1].(string), args[2].([]byte), args[3].(fs.FileMode))
 	})
 	return _c
 }
@@ -658,7 +718,7 @@ func (_c *MockFilesystem_WriteFile_Call) Return(_a0 error) *MockFilesystem_Write
 	return _c
 }
 
-func (_c *MockFilesystem_WriteFile_Call) RunAndReturn(run func(string, []byte, fs.FileMode) error) *MockFilesystem_WriteFile_Call {
+func (_c *MockFilesystem_WriteFile_Call) RunAndReturn(run func(context.Context, string, []byte, fs.FileMode) error) *MockFilesystem_WriteFile_Call {
 	_c.Call.Return(run)
 	return _c
 }
@@ -0,0 +1,429 @@
+package filesystem
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"io/fs"
+	"os"
+	"path"
+	"path/filepath"
+	"slices"
+	"strings"
+	"syscall"
+
+	"github.com/minio/minio-go/v7"
+	"github.com/minio/minio-go/v7/pkg/credentials"
+
+	"github.com/cloudogu/k8s-support-archive-operator/pkg/adapter/config"
+)
+
+const (
+	s3NoSuchKeyCode = "NoSuchKey"
+	// maxCopyObjectSize is the largest object that can be copied with a single request.
+	maxCopyObjectSize = 5 << 30
+)
+
+// S3FileSystem stores the files as objects in a bucket of an S3-compatible object storage. The path of a file is used
+// as object key below the configured prefix.
+//
+// Objects cannot be appended, so written files are spooled to a local temporary file and uploaded on Sync and Close.
+// Directories only exist implicitly as prefixes of object keys. Therefore, MkdirAll does nothing, reading a directory
+// without objects returns no entries and removing it succeeds.
+type S3FileSystem struct {
+	client   *minio.Client
+	bucket   string
+	prefix   string
+	spoolDir string
+}
+
+// NewS3FileSystem connects to the object storage and checks that the bucket exists. Written files are spooled to
+// spoolDir until they are uploaded. The spoolDir must not be shared with other processes.
+func NewS3FileSystem(ctx context.Context, s3Config config.S3Config, spoolDir string) (*S3FileSystem, error) {
+	creds := credentials.NewStaticV4(s3Config.AccessKeyID, s3Config.SecretAccessKey, "")
+	if s3Config.AccessKeyID == "" {
+		// Use the IAM role of the pod, e.g. from a web identity token.
+		creds = credentials.NewIAM("")
+	}
+
+	client, err := minio.New(s3Config.Endpoint, &minio.Options{
+		Creds:  creds,
+		Secure: !s3Config.Insecure,
+		Region: s3Config.Region,
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to create s3 client for %s: %w", s3Config.Endpoint, err)
+	}
+
+	exists, err := client.BucketExists(ctx, s3Config.Bucket)
+	if err != nil {
+		return nil, fmt.Errorf("failed to check if bucket %s exists: %w", s3Config.Bucket, err)
+	}
+	if !exists {
+		return nil, fmt.Errorf("bucket %s does not exist", s3Config.Bucket)
+	}
+
+	// Spool files of a previous run were not uploaded completely and are removed.
+	err = os.RemoveAll(spoolDir)
+	if err != nil {
+		return nil, fmt.Errorf("failed to remove spool directory %s: %w", spoolDir, err)
+	}
+	err = os.MkdirAll(spoolDir, 0755)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create spool directory %s: %w", spoolDir, err)
+	}
+
+	return &S3FileSystem{
+		client:   client,
+		bucket:   s3Config.Bucket,
+		prefix:   strings.Trim(s3Config.Prefix, "/"),
+		spoolDir: spoolDir,
+	}, nil
+}
+
+func (s *S3FileSystem) Stat(ctx context.Context, name string) (os.FileInfo, error) {
+	info, err := s.client.StatObject(ctx, s.bucket, s.key(name), minio.StatObjectOptions{})
+	if err == nil {
+		return &fileInfo{name: path.Base(filepath.ToSlash(name)), size: info.Size, modTime: info.LastModified}, nil
+	}
+	if !isNoSuchKey(err) {
+		return nil, &fs.PathError{Op: "stat", Path: name, Err: err}
+	}
+
+	isDir, err := s.hasObjectsBelow(ctx, name)
+	if err != nil {
+		return nil, &fs.PathError{Op: "stat", Path: name, Err: err}
+	}
+	if !isDir {
+		return nil, &fs.PathError{Op: "stat", Path: name, Err: fs.ErrNotExist}
+	}
+
+	return &fileInfo{name: path.Base(filepath.ToSlash(name)), dir: true}, nil
+}
+
+// MkdirAll does nothing, because directories only exist as prefixes of object keys.
+func (s *S3FileSystem) MkdirAll(context.Context, string, os.FileMode) error {
+	return nil
+}
+
+func (s *S3FileSystem) Create(ctx context.Context, name string) (ClosableRWFile, error) {
+	return s.OpenFile(ctx, name, os.O_RDWR|os.O_CREATE|os.O_TRUNC, 0666)
+}
+
+func (s *S3FileSystem) Open(ctx context.Context, name string) (ClosableRWFile, error) {
+	// GetObject does not fail before the first read, so missing objects are detected beforehand.
+	_, err := s.client.StatObject(ctx, s.bucket, s.key(name), minio.StatObjectOptions{})
+	if err != nil {
+		return nil, s.pathError("open", name, err)
+	}
+
+	object, err := s.client.GetObject(ctx, s.bucket, s.key(name), minio.GetObjectOptions{})
+	if err != nil {
+		return nil, s.pathError("open", name, err)
+	}
+
+	return &s3ReadFile{Object: object, name: name}, nil
+}
+
+// OpenFile opens the object for reading if the flags do not contain os.O_WRONLY or os.O_RDWR. Otherwise, the content
+// of the object is copied to a spool file unless os.O_TRUNC is set. The spool file is uploaded on Sync and Close.
+func (s *S3FileSystem) OpenFile(ctx context.Context, name string, flag int, _ os.FileMode) (ClosableRWFile, error) {
+	if flag&(os.O_WRONLY|os.O_RDWR) == 0 {
+		return s.Open(ctx, name)
+	}
+
+	key := s.key(name)
+	_, err := s.client.StatObject(ctx, s.bucket, key, minio.StatObjectOptions{})
+	exists := err == nil
+	if err != nil && !isNoSuchKey(err) {
+		return nil, &fs.PathError{Op: "open", Path: name, Err: err}
+	}
+	if exists && flag&os.O_CREATE != 0 && flag&os.O_EXCL != 0 {
+		return nil, &fs.PathError{Op: "open", Path: name, Err: fs.ErrExist}
+	}
+	if !exists && flag&os.O_CREATE == 0 {
+		return nil, &fs.PathError{Op: "open", Path: name, Err: fs.ErrNotExist}
+	}
+
+	spool, err := os.CreateTemp(s.spoolDir, "object-*")
+	if err != nil {
+		return nil, fmt.Errorf("failed to create spool file for %s: %w", name, err)
+	}
+	file := &s3WriteFile{File: spool, fileSystem: s, ctx: ctx, name: name, pending: !exists || flag&os.O_TRUNC != 0}
+
+	if exists && flag&os.O_TRUNC == 0 {
+		err = file.download(flag&os.O_APPEND != 0)
+		if err != nil {
+			return nil, errors.Join(err, file.discard())
+		}
+	}
+
+	return file, nil
+}
+
+func (s *S3FileSystem) ReadAll(r io.Reader) ([]byte, error) {
+	return io.ReadAll(r)
+}
+
+func (s *S3FileSystem) WriteFile(ctx context.Context, name string, data []byte, _ os.FileMode) error {
+	err := s.putObject(ctx, name, bytes.NewReader(data), int64(len(data)))
+	if err != nil {
+		return &fs.PathError{Op: "write", Path: name, Err: err}
+	}
+
+	return nil
+}
+
+// Remove removes the object. Paths without an object are treated as directories, which can only be removed if there
+// are no objects below them.
+func (s *S3FileSystem) Remove(ctx context.Context, name string) error {
+	_, err := s.client.StatObject(ctx, s.bucket, s.key(name), minio.StatObjectOptions{})
+	if err == nil {
+		return s.removeObject(ctx, name)
+	}
+	if !isNoSuchKey(err) {
+		return &fs.PathError{Op: "remove", Path: name, Err: err}
+	}
+
+	notEmpty, err := s.hasObjectsBelow(ctx, name)
+	if err != nil {
+		return &fs.PathError{Op: "remove", Path: name, Err: err}
+	}
+	if notEmpty {
+		return &fs.PathError{Op: "remove", Path: name, Err: syscall.ENOTEMPTY}
+	}
+
+	return nil
+}
+
+// Rename copies the object to the new key and removes the old object. The copy is not atomic, but the object at the
+// new key is only visible after it was copied completely.
+func (s *S3FileSystem) Rename(ctx context.Context, oldPath, newPath string) error {
+	dst := minio.CopyDestOptions{Bucket: s.bucket, Object: s.key(newPath)}
+	src := minio.CopySrcOptions{Bucket: s.bucket, Object: s.key(oldPath)}
+	info, err := s.client.StatObject(ctx, s.bucket, src.Object, minio.StatObjectOptions{})
+	if err == nil {
+		if info.Size <= maxCopyObjectSize {
+			_, err = s.client.CopyObject(ctx, dst, src)
+		} else {
+			_, err = s.client.ComposeObject(ctx, dst, src)
+		}
+	}
+	if isNoSuchKey(err) {
+		err = fs.ErrNotExist
+	}
+	if err != nil {
+		return &os.LinkError{Op: "rename", Old: oldPath, New: newPath, Err: err}
+	}
+
+	return s.removeObject(ctx, oldPath)
+}
+
+func (s *S3FileSystem) putObject(ctx context.Context, name string, content io.Reader, size int64) error {
+	// Empty bodies are streamed without a content length if they are signed over plain HTTP,
+	// which is rejected by some S3-compatible servers.
+	opts := minio.PutObjectOptions{DisableContentSha256: size == 0}
+	_, err := s.client.PutObject(ctx, s.bucket, s.key(name), content, size, opts)
+	return err
+}
+
+// RemoveAll removes the object and all objects below the path.
+func (s *S3FileSystem) RemoveAll(ctx context.Context, name string) error {
+	objects := make(chan minio.ObjectInfo)
+	var listErr error
+	go func() {
+		defer close(objects)
+		if key := s.key(name); key != "" {
+			objects <- minio.ObjectInfo{Key: key}
+		}
+		for object := range s.client.ListObjects(ctx, s.bucket, minio.ListObjectsOptions{Prefix: s.dirPrefix(name), Recursive: true}) {
+			if object.Err != nil {
+				listErr = object.Err
+				return
+			}
+			objects <- object
+		}
+	}()
+
+	var multiErr []error
+	for removeErr := range s.client.RemoveObjects(ctx, s.bucket, objects, minio.RemoveObjectsOptions{}) {
+		multiErr = append(multiErr, fmt.Errorf("failed to remove object %s: %w", removeErr.ObjectName, removeErr.Err))
+	}
+	if listErr != nil {
+		multiErr = append(multiErr, fmt.Errorf("failed to list objects: %w", listErr))
+	}
+	if len(multiErr) > 0 {
+		return &fs.PathError{Op: "removeall", Path: name, Err: errors.Join(multiErr...)}
+	}
+
+	return nil
+}
+
+// ReadDir returns the objects and the prefixes directly below the path sorted by name.
+func (s *S3FileSystem) ReadDir(ctx context.Context, name string) ([]os.DirEntry, error) {
+	prefix := s.dirPrefix(name)
+
+	var entries []os.DirEntry
+	for object := range s.client.ListObjects(ctx, s.bucket, minio.ListObjectsOptions{Prefix: prefix}) {
+		if object.Err != nil {
+			return nil, &fs.PathError{Op: "readdir", Path: name, Err: object.Err}
+		}
+
+		entryName := strings.TrimPrefix(object.Key, prefix)
+		if entryName == "" {
+			// Some tools create empty objects as directory markers.
+			continue
+		}
+		if strings.HasSuffix(entryName, "/") {
+			entries = append(entries, fs.FileInfoToDirEntry(&fileInfo{name: strings.TrimSuffix(entryName, "/"), dir: true}))
+			continue
+		}
+		entries = append(entries, fs.FileInfoToDirEntry(&fileInfo{name: entryName, size: object.Size, modTime: object.LastModified}))
+	}
+
+	slices.SortFunc(entries, func(a, b os.DirEntry) int {
+		return strings.Compare(a.Name(), b.Name())
+	})
+
+	return entries, nil
+}
+
+func (s *S3FileSystem) Copy(dst io.Writer, src io.Reader) (written int64, err error) {
+	return io.Copy(dst, src)
+}
+
+// WalkDir walks the file tree like filepath.WalkDir. The entries of a directory are listed when the directory is
+// visited.
+func (s *S3FileSystem) WalkDir(ctx context.Context, root string, fn fs.WalkDirFunc) error {
+	return walkDir(ctx, s, root, fn)
+}
+
+func (s *S3FileSystem) removeObject(ctx context.Context, name string) error {
+	err := s.client.RemoveObject(ctx, s.bucket, s.key(name), minio.RemoveObjectOptions{})
+	if err != nil {
+		return &fs.PathError{Op: "remove", Path: name, Err: err}
+	}
+
+	return nil
+}
+
+func (s *S3FileSystem) hasObjectsBelow(ctx context.Context, name string) (bool, error) {
+	for object := range s.client.ListObjects(ctx, s.bucket, minio.ListObjectsOptions{Prefix: s.dirPrefix(name), MaxKeys: 1}) {
+		if object.Err != nil {
+			return false, object.Err
+		}
+		return true, nil
+	}
+
+	return false, nil
+}
+
+// key returns the object key for the path.
+func (s *S3FileSystem) key(name string) string {
+	return path.Join(s.prefix, strings.TrimPrefix(filepath.ToSlash(filepath.Clean(name)), "/"))
+}
+
+// dirPrefix returns the prefix of all object keys below the path.
+func (s *S3FileSystem) dirPrefix(name string) string {
+	key := s.key(name)
+	if key == "" {
+		return ""
+	}
+
+	return key + "/"
+}
+
+// pathError converts missing objects to fs.ErrNotExist, so that os.IsNotExist can be used for all filesystems.
+func (s *S3FileSystem) pathError(op, name string, err error) error {
+	if isNoSuchKey(err) {
+		err = fs.ErrNotExist
+	}
+
+	return &fs.PathError{Op: op, Path: name, Err: err}
+}
+
+func isNoSuchKey(err error) bool {
+	return minio.ToErrorResponse(err).Code == s3NoSuchKeyCode
+}
+
+// s3ReadFile reads an object. It supports seeking and reading at offsets with range requests.
+type s3ReadFile struct {
+	*minio.Object
+	name string
+}
+
+func (f *s3ReadFile) Write([]byte) (int, error) {
+	return 0, &fs.PathError{Op: "write", Path: f.name, Err: fs.ErrPermission}
+}
+
+func (f *s3ReadFile) Sync() error {
+	return nil
+}
+
+// s3WriteFile spools the written content to a local file and uploads it as object.
+type s3WriteFile struct {
+	*os.File
+	fileSystem *S3FileSystem
+	// ctx is the context of OpenFile. It is used for the download and the uploads on Sync and Close.
+	ctx  context.Context
+	name string
+	// pending is true if the content of the spool file differs from the object.
+	pending bool
+}
+
+func (f *s3WriteFile) Write(p []byte) (int, error) {
+	f.pending = true
+	return f.File.Write(p)
+}
+
+// Sync uploads the spool file if it changed since the last upload.
+func (f *s3WriteFile) Sync() error {
+	if !f.pending {
+		return nil
+	}
+
+	info, err := f.File.Stat()
+	if err != nil {
+		return fmt.Errorf("failed to stat spool file of %s: %w", f.name, err)
+	}
+
+	err = f.fileSystem.putObject(f.ctx, f.name, io.NewSectionReader(f.File, 0, info.Size()), info.Size())
+	if err != nil {
+		return &fs.PathError{Op: "sync", Path: f.name, Err: err}
+	}
+	f.pending = false
+
+	return nil
+}
+
+// Close uploads the spool file and removes it.
+func (f *s3WriteFile) Close() error {
+	return errors.Join(f.Sync(), f.discard())
+}
+
+func (f *s3WriteFile) download(toEnd bool) error {
+	object, err := f.fileSystem.client.GetObject(f.ctx, f.fileSystem.bucket, f.fileSystem.key(f.name), minio.GetObjectOptions{})
+	if err != nil {
+		return f.fileSystem.pathError("open", f.name, err)
+	}
+	defer func() {
+		_ = object.Close()
+	}()
+
+	_, err = io.Copy(f.File, object)
+	if err != nil {
+		return f.fileSystem.pathError("open", f.name, err)
+	}
+	if toEnd {
+		return nil
+	}
+
+	_, err = f.File.Seek(0, io.SeekStart)
+	return err
+}
+
+func (f *s3WriteFile) discard() error {
+	return errors.Join(f.File.Close(), os.Remove(f.File.Name()))
+}
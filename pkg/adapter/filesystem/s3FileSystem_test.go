@@ -0,0 +1,482 @@
+package filesystem
+
+import (
+	"context"
+	"io"
+	"io/fs"
+	"net/http/httptest"
+	"net/url"
+	"os"
+	"path/filepath"
+	"syscall"
+	"testing"
+
+	"github.com/johannesboyne/gofakes3"
+	"github.com/johannesboyne/gofakes3/backend/s3mem"
+	"github.com/minio/minio-go/v7"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/cloudogu/k8s-support-archive-operator/pkg/adapter/config"
+)
+
+const testBucket = "archives"
+
+var testCtx = context.Background()
+
+// newTestS3FileSystem returns a filesystem backed by an in-memory object storage.
+func newTestS3FileSystem(t *testing.T, prefix string) *S3FileSystem {
+	t.Helper()
+
+	backend := s3mem.New()
+	require.NoError(t, backend.CreateBucket(testBucket))
+	server := httptest.NewServer(gofakes3.New(backend).Server())
+	t.Cleanup(server.Close)
+	serverURL, err := url.Parse(server.URL)
+	require.NoError(t, err)
+
+	s3Config := config.S3Config{
+		Endpoint:        serverURL.Host,
+		Bucket:          testBucket,
+		Prefix:          prefix,
+		Region:          "us-east-1",
+		Insecure:        true,
+		AccessKeyID:     "access",
+		SecretAccessKey: "secret",
+	}
+	s3FileSystem, err := NewS3FileSystem(testCtx, s3Config, filepath.Join(t.TempDir(), "spool"))
+	require.NoError(t, err)
+
+	return s3FileSystem
+}
+
+func writeTestObject(t *testing.T, sut *S3FileSystem, name, content string) {
+	t.Helper()
+	require.NoError(t, sut.WriteFile(testCtx, name, []byte(content), 0644))
+}
+
+func readTestObject(t *testing.T, sut *S3FileSystem, name string) string {
+	t.Helper()
+
+	file, err := sut.Open(testCtx, name)
+	require.NoError(t, err)
+	defer func() {
+		_ = file.Close()
+	}()
+	content, err := io.ReadAll(file)
+	require.NoError(t, err)
+
+	return string(content)
+}
+
+func TestNewS3FileSystem(t *testing.T) {
+	t.Run("should fail if the bucket does not exist", func(t *testing.T) {
+		// given
+		server := httptest.NewServer(gofakes3.New(s3mem.New()).Server())
+		defer server.Close()
+		serverURL, err := url.Parse(server.URL)
+		require.NoError(t, err)
+		s3Config := config.S3Config{Endpoint: serverURL.Host, Bucket: testBucket, Insecure: true, AccessKeyID: "access", SecretAccessKey: "secret"}
+
+		// when
+		_, err = NewS3FileSystem(testCtx, s3Config, t.TempDir())
+
+		// then
+		assert.ErrorContains(t, err, "bucket archives does not exist")
+	})
+	t.Run("should remove spool files of a previous run", func(t *testing.T) {
+		// given
+		backend := s3mem.New()
+		require.NoError(t, backend.CreateBucket(testBucket))
+		server := httptest.NewServer(gofakes3.New(backend).Server())
+		defer server.Close()
+		serverURL, err := url.Parse(server.URL)
+		require.NoError(t, err)
+		spoolDir := t.TempDir()
+		require.NoError(t, os.WriteFile(filepath.Join(spoolDir, "object-1"), []byte("stale"), 0644))
+		s3Config := config.S3Config{Endpoint: serverURL.Host, Bucket: testBucket, Insecure: true, AccessKeyID: "access", SecretAccessKey: "secret"}
+
+		// when
+		_, err = NewS3FileSystem(testCtx, s3Config, spoolDir)
+
+		// then
+		require.NoError(t, err)
+		entries, err := os.ReadDir(spoolDir)
+		require.NoError(t, err)
+		assert.Empty(t, entries)
+	})
+}
+
+func TestS3FileSystem_OpenFile(t *testing.T) {
+	t.Run("should upload written file on sync and close", func(t *testing.T) {
+		// given
+		sut := newTestS3FileSystem(t, "")
+
+		// when
+		file, err := sut.Create(testCtx, "/data/work/ns/archive/Logs/logs.log")
+		require.NoError(t, err)
+		_, err = file.Write([]byte("LOGS\n"))
+		require.NoError(t, err)
+		require.NoError(t, file.Sync())
+		synced := readTestObject(t, sut, "/data/work/ns/archive/Logs/logs.log")
+		_, err = file.Write([]byte("line\n"))
+		require.NoError(t, err)
+		require.NoError(t, file.Close())
+
+		// then
+		assert.Equal(t, "LOGS\n", synced)
+		assert.Equal(t, "LOGS\nline\n", readTestObject(t, sut, "/data/work/ns/archive/Logs/logs.log"))
+		spoolEntries, err := os.ReadDir(sut.spoolDir)
+		require.NoError(t, err)
+		assert.Empty(t, spoolEntries)
+	})
+	t.Run("should not upload written file if the context is canceled", func(t *testing.T) {
+		// given
+		sut := newTestS3FileSystem(t, "")
+		ctx, cancel := context.WithCancel(testCtx)
+		file, err := sut.Create(ctx, "/data/logs.log")
+		require.NoError(t, err)
+		_, err = file.Write([]byte("LOGS\n"))
+		require.NoError(t, err)
+
+		// when
+		cancel()
+		err = file.Close()
+
+		// then
+		require.Error(t, err)
+		assert.ErrorIs(t, err, context.Canceled)
+		_, err = sut.Stat(testCtx, "/data/logs.log")
+		assert.ErrorIs(t, err, fs.ErrNotExist)
+		spoolEntries, err := os.ReadDir(sut.spoolDir)
+		require.NoError(t, err)
+		assert.Empty(t, spoolEntries)
+	})
+	t.Run("should create empty object", func(t *testing.T) {
+		// given
+		sut := newTestS3FileSystem(t, "")
+
+		// when
+		file, err := sut.OpenFile(testCtx, "/data/empty", os.O_CREATE|os.O_WRONLY, 0644)
+		require.NoError(t, err)
+		require.NoError(t, file.Close())
+
+		// then
+		info, err := sut.Stat(testCtx, "/data/empty")
+		require.NoError(t, err)
+		assert.Zero(t, info.Size())
+	})
+	t.Run("should append to existing object", func(t *testing.T) {
+		// given
+		sut := newTestS3FileSystem(t, "")
+		writeTestObject(t, sut, "/data/logs.log", "LOGS\n")
+
+		// when
+		file, err := sut.OpenFile(testCtx, "/data/logs.log", os.O_APPEND|os.O_CREATE|os.O_WRONLY, 0666)
+		require.NoError(t, err)
+		_, err = file.Write([]byte("line\n"))
+		require.NoError(t, err)
+		require.NoError(t, file.Close())
+
+		// then
+		assert.Equal(t, "LOGS\nline\n", readTestObject(t, sut, "/data/logs.log"))
+	})
+	t.Run("should truncate existing object", func(t *testing.T) {
+		// given
+		sut := newTestS3FileSystem(t, "")
+		writeTestObject(t, sut, "/data/events.yaml", "old content")
+
+		// when
+		file, err := sut.OpenFile(testCtx, "/data/events.yaml", os.O_TRUNC|os.O_CREATE|os.O_WRONLY, 0644)
+		require.NoError(t, err)
+		_, err = file.Write([]byte("new"))
+		require.NoError(t, err)
+		require.NoError(t, file.Close())
+
+		// then
+		assert.Equal(t, "new", readTestObject(t, sut, "/data/events.yaml"))
+	})
+	t.Run("should not upload unchanged object", func(t *testing.T) {
+		// given
+		sut := newTestS3FileSystem(t, "")
+		writeTestObject(t, sut, "/data/state", "state")
+		before, err := sut.Stat(testCtx, "/data/state")
+		require.NoError(t, err)
+
+		// when
+		file, err := sut.OpenFile(testCtx, "/data/state", os.O_RDWR, 0644)
+		require.NoError(t, err)
+		content, err := io.ReadAll(file)
+		require.NoError(t, err)
+		require.NoError(t, file.Close())
+
+		// then
+		assert.Equal(t, "state", string(content))
+		after, err := sut.Stat(testCtx, "/data/state")
+		require.NoError(t, err)
+		assert.Equal(t, before.ModTime(), after.ModTime())
+	})
+	t.Run("should fail for missing object without create flag", func(t *testing.T) {
+		// given
+		sut := newTestS3FileSystem(t, "")
+
+		// when
+		_, err := sut.OpenFile(testCtx, "/data/missing", os.O_WRONLY, 0644)
+
+		// then
+		assert.True(t, os.IsNotExist(err))
+	})
+	t.Run("should fail for existing object with exclusive flag", func(t *testing.T) {
+		// given
+		sut := newTestS3FileSystem(t, "")
+		writeTestObject(t, sut, "/data/existing", "content")
+
+		// when
+		_, err := sut.OpenFile(testCtx, "/data/existing", os.O_CREATE|os.O_EXCL|os.O_WRONLY, 0644)
+
+		// then
+		assert.True(t, os.IsExist(err))
+	})
+}
+
+func TestS3FileSystem_Open(t *testing.T) {
+	t.Run("should read object with offsets", func(t *testing.T) {
+		// given
+		sut := newTestS3FileSystem(t, "")
+		writeTestObject(t, sut, "/data/archive.zip", "0123456789")
+
+		// when
+		file, err := sut.Open(testCtx, "/data/archive.zip")
+		require.NoError(t, err)
+		defer func() {
+			_ = file.Close()
+		}()
+
+		// then
+		buffer := make([]byte, 3)
+		_, err = file.(io.ReaderAt).ReadAt(buffer, 4)
+		require.NoError(t, err)
+		assert.Equal(t, "456", string(buffer))
+		_, err = file.(io.Seeker).Seek(8, io.SeekStart)
+		require.NoError(t, err)
+		rest, err := io.ReadAll(file)
+		require.NoError(t, err)
+		assert.Equal(t, "89", string(rest))
+		_, err = file.Write([]byte("content"))
+		assert.ErrorIs(t, err, fs.ErrPermission)
+	})
+	t.Run("should fail for missing object", func(t *testing.T) {
+		// given
+		sut := newTestS3FileSystem(t, "")
+
+		// when
+		_, err := sut.Open(testCtx, "/data/missing")
+
+		// then
+		assert.True(t, os.IsNotExist(err))
+	})
+}
+
+func TestS3FileSystem_Stat(t *testing.T) {
+	// given
+	sut := newTestS3FileSystem(t, "")
+	writeTestObject(t, sut, "/data/support-archives/ns/archive.zip", "content")
+
+	t.Run("should return object", func(t *testing.T) {
+		info, err := sut.Stat(testCtx, "/data/support-archives/ns/archive.zip")
+
+		require.NoError(t, err)
+		assert.Equal(t, "archive.zip", info.Name())
+		assert.Equal(t, int64(7), info.Size())
+		assert.False(t, info.IsDir())
+		assert.False(t, info.ModTime().IsZero())
+	})
+	t.Run("should return prefix as directory", func(t *testing.T) {
+		info, err := sut.Stat(testCtx, "/data/support-archives/ns")
+
+		require.NoError(t, err)
+		assert.Equal(t, "ns", info.Name())
+		assert.True(t, info.IsDir())
+	})
+	t.Run("should fail for missing path", func(t *testing.T) {
+		_, err := sut.Stat(testCtx, "/data/support-archives/other")
+
+		assert.True(t, os.IsNotExist(err))
+	})
+}
+
+func TestS3FileSystem_ReadDir(t *testing.T) {
+	// given
+	sut := newTestS3FileSystem(t, "")
+	writeTestObject(t, sut, "/data/work/b.txt", "b")
+	writeTestObject(t, sut, "/data/work/a/file.txt", "a")
+	writeTestObject(t, sut, "/data/workspace.txt", "other")
+
+	t.Run("should list objects and prefixes sorted by name", func(t *testing.T) {
+		entries, err := sut.ReadDir(testCtx, "/data/work")
+
+		require.NoError(t, err)
+		require.Len(t, entries, 2)
+		assert.Equal(t, "a", entries[0].Name())
+		assert.True(t, entries[0].IsDir())
+		assert.Equal(t, "b.txt", entries[1].Name())
+		assert.False(t, entries[1].IsDir())
+	})
+	t.Run("should return no entries for path without objects", func(t *testing.T) {
+		entries, err := sut.ReadDir(testCtx, "/data/missing")
+
+		require.NoError(t, err)
+		assert.Empty(t, entries)
+	})
+}
+
+func TestS3FileSystem_WalkDir(t *testing.T) {
+	// given
+	sut := newTestS3FileSystem(t, "")
+	writeTestObject(t, sut, "/data/work/ns/archive/Logs/logs.log", "logs")
+	writeTestObject(t, sut, "/data/work/ns/archive/Events/events.yaml", "events")
+	writeTestObject(t, sut, "/data/work/ns/other/Logs/logs.log", "logs")
+
+	t.Run("should visit all paths in lexical order", func(t *testing.T) {
+		var paths []string
+		err := sut.WalkDir(testCtx, "/data/work/ns", func(path string, d fs.DirEntry, err error) error {
+			require.NoError(t, err)
+			paths = append(paths, path)
+			return nil
+		})
+
+		require.NoError(t, err)
+		assert.Equal(t, []string{
+			"/data/work/ns",
+			"/data/work/ns/archive",
+			"/data/work/ns/archive/Events",
+			"/data/work/ns/archive/Events/events.yaml",
+			"/data/work/ns/archive/Logs",
+			"/data/work/ns/archive/Logs/logs.log",
+			"/data/work/ns/other",
+			"/data/work/ns/other/Logs",
+			"/data/work/ns/other/Logs/logs.log",
+		}, paths)
+	})
+	t.Run("should skip directories", func(t *testing.T) {
+		var paths []string
+		err := sut.WalkDir(testCtx, "/data/work/ns", func(path string, d fs.DirEntry, err error) error {
+			if d.IsDir() && d.Name() == "archive" {
+				return fs.SkipDir
+			}
+			if !d.IsDir() {
+				paths = append(paths, path)
+			}
+			return nil
+		})
+
+		require.NoError(t, err)
+		assert.Equal(t, []string{"/data/work/ns/other/Logs/logs.log"}, paths)
+	})
+	t.Run("should pass error for missing root", func(t *testing.T) {
+		err := sut.WalkDir(testCtx, "/data/missing", func(path string, d fs.DirEntry, err error) error {
+			assert.Equal(t, "/data/missing", path)
+			assert.Nil(t, d)
+			return err
+		})
+
+		assert.True(t, os.IsNotExist(err))
+	})
+}
+
+func TestS3FileSystem_Remove(t *testing.T) {
+	t.Run("should remove object", func(t *testing.T) {
+		// given
+		sut := newTestS3FileSystem(t, "")
+		writeTestObject(t, sut, "/data/ns/archive.zip", "content")
+
+		// when
+		err := sut.Remove(testCtx, "/data/ns/archive.zip")
+
+		// then
+		require.NoError(t, err)
+		_, err = sut.Stat(testCtx, "/data/ns/archive.zip")
+		assert.True(t, os.IsNotExist(err))
+	})
+	t.Run("should fail for directory with objects", func(t *testing.T) {
+		// given
+		sut := newTestS3FileSystem(t, "")
+		writeTestObject(t, sut, "/data/ns/archive.zip", "content")
+
+		// when
+		err := sut.Remove(testCtx, "/data/ns")
+
+		// then
+		assert.ErrorIs(t, err, syscall.ENOTEMPTY)
+	})
+	t.Run("should succeed for empty directory", func(t *testing.T) {
+		// given
+		sut := newTestS3FileSystem(t, "")
+
+		// when
+		err := sut.Remove(testCtx, "/data/ns")
+
+		// then
+		assert.NoError(t, err)
+	})
+}
+
+func TestS3FileSystem_RemoveAll(t *testing.T) {
+	// given
+	sut := newTestS3FileSystem(t, "")
+	writeTestObject(t, sut, "/data/work/ns/archive/Logs/logs.log", "logs")
+	writeTestObject(t, sut, "/data/work/ns/archive/Events/events.yaml", "events")
+	writeTestObject(t, sut, "/data/work/ns/archive-2/Logs/logs.log", "logs")
+
+	// when
+	err := sut.RemoveAll(testCtx, "/data/work/ns/archive")
+
+	// then
+	require.NoError(t, err)
+	_, err = sut.Stat(testCtx, "/data/work/ns/archive")
+	assert.True(t, os.IsNotExist(err))
+	assert.Equal(t, "logs", readTestObject(t, sut, "/data/work/ns/archive-2/Logs/logs.log"))
+}
+
+func TestS3FileSystem_Rename(t *testing.T) {
+	t.Run("should move object", func(t *testing.T) {
+		// given
+		sut := newTestS3FileSystem(t, "")
+		writeTestObject(t, sut, "/data/ns/archive.zip.tmp", "content")
+
+		// when
+		err := sut.Rename(testCtx, "/data/ns/archive.zip.tmp", "/data/ns/archive.zip")
+
+		// then
+		require.NoError(t, err)
+		assert.Equal(t, "content", readTestObject(t, sut, "/data/ns/archive.zip"))
+		_, err = sut.Stat(testCtx, "/data/ns/archive.zip.tmp")
+		assert.True(t, os.IsNotExist(err))
+	})
+	t.Run("should fail for missing object", func(t *testing.T) {
+		// given
+		sut := newTestS3FileSystem(t, "")
+
+		// when
+		err := sut.Rename(testCtx, "/data/missing", "/data/other")
+
+		// then
+		assert.True(t, os.IsNotExist(err))
+	})
+}
+
+func TestS3FileSystem_prefix(t *testing.T) {
+	// given
+	sut := newTestS3FileSystem(t, "/operator/")
+
+	// when
+	writeTestObject(t, sut, "/data/work/file.txt", "content")
+
+	// then
+	_, err := sut.client.StatObject(testCtx, testBucket, "operator/data/work/file.txt", minio.StatObjectOptions{})
+	require.NoError(t, err)
+	entries, err := sut.ReadDir(testCtx, "/")
+	require.NoError(t, err)
+	require.Len(t, entries, 1)
+	assert.Equal(t, "data", entries[0].Name())
+}
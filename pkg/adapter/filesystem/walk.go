@@ -0,0 +1,100 @@
+package filesystem
+
+import (
+	"context"
+	"errors"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"time"
+)
+
+// walkableFs is implemented by the filesystems which walk their directories like filepath.WalkDir.
+type walkableFs interface {
+	Stat(ctx context.Context, name string) (os.FileInfo, error)
+	ReadDir(ctx context.Context, name string) ([]os.DirEntry, error)
+}
+
+// walkDir walks the file tree at root like filepath.WalkDir, including the handling of fs.SkipDir and fs.SkipAll.
+func walkDir(ctx context.Context, fsys walkableFs, root string, fn fs.WalkDirFunc) error {
+	info, err := fsys.Stat(ctx, root)
+	if err != nil {
+		err = fn(root, nil, err)
+	} else {
+		err = walkDirEntry(ctx, fsys, root, fs.FileInfoToDirEntry(info), fn)
+	}
+	if errors.Is(err, fs.SkipDir) || errors.Is(err, fs.SkipAll) {
+		return nil
+	}
+
+	return err
+}
+
+func walkDirEntry(ctx context.Context, fsys walkableFs, name string, entry fs.DirEntry, fn fs.WalkDirFunc) error {
+	err := fn(name, entry, nil)
+	if err != nil || !entry.IsDir() {
+		if errors.Is(err, fs.SkipDir) && entry.IsDir() {
+			err = nil
+		}
+		return err
+	}
+
+	entries, err := fsys.ReadDir(ctx, name)
+	if err != nil {
+		err = fn(name, entry, err)
+		if err != nil {
+			if errors.Is(err, fs.SkipDir) {
+				err = nil
+			}
+			return err
+		}
+	}
+
+	for _, child := range entries {
+		err = walkDirEntry(ctx, fsys, filepath.Join(name, child.Name()), child, fn)
+		if err != nil {
+			if errors.Is(err, fs.SkipDir) {
+				break
+			}
+			return err
+		}
+	}
+
+	return nil
+}
+
+// fileInfo describes files and directories which do not exist on the local filesystem.
+type fileInfo struct {
+	name    string
+	size    int64
+	modTime time.Time
+	dir     bool
+}
+
+func (i *fileInfo) Name() string {
+	return i.name
+}
+
+func (i *fileInfo) Size() int64 {
+	return i.size
+}
+
+func (i *fileInfo) Mode() fs.FileMode {
+	if i.dir {
+		return fs.ModeDir | 0755
+	}
+
+	return 0644
+}
+
+func (i *fileInfo) ModTime() time.Time {
+	return i.modTime
+}
+
+func (i *fileInfo) IsDir() bool {
+	return i.dir
+}
+
+func (i *fileInfo) Sys() any {
+	return nil
+}
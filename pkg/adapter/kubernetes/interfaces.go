@@ -34,3 +34,9 @@ type createArchiveHandler interface {
 type deleteArchiveHandler interface {
 	Delete(ctx context.Context, id domain.SupportArchiveID) error
 }
+
+type claimHandler interface {
+	// Claim claims the support archive for this replica. The returned context is canceled if the claim is lost.
+	// If another replica holds the claim, the context is nil and retryAfter contains the time until it expires.
+	Claim(ctx context.Context, cr *v1.SupportArchive) (claimCtx context.Context, release context.CancelFunc, retryAfter time.Duration, err error)
+}
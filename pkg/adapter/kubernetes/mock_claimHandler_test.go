@@ -0,0 +1,114 @@
+// Code generated by mockery v2.53.3. DO NOT EDIT.
+
+package kubernetes
+
+import (
+	context "context"
+	time "time"
+
+	mock "github.com/stretchr/testify/mock"
+
+	v1 "github.com/cloudogu/k8s-support-archive-lib/api/v1"
+)
+
+// mockClaimHandler is an autogenerated mock type for the claimHandler type
+type mockClaimHandler struct {
+	mock.Mock
+}
+
+type mockClaimHandler_Expecter struct {
+	mock *mock.Mock
+}
+
+func (_m *mockClaimHandler) EXPECT() *mockClaimHandler_Expecter {
+	return &mockClaimHandler_Expecter{mock: &_m.Mock}
+}
+
+// Claim provides a mock function with given fields: ctx, cr
+func (_m *mockClaimHandler) Claim(ctx context.Context, cr *v1.SupportArchive) (context.Context, context.CancelFunc, time.Duration, error) {
+	ret := _m.Called(ctx, cr)
+
+	if len(ret) == 0 {
+		panic("no return value specified for Claim")
+	}
+
+	var r0 context.Context
+	var r1 context.CancelFunc
+	var r2 time.Duration
+	var r3 error
+	if rf, ok := ret.Get(0).(func(context.Context, *v1.SupportArchive) (context.Context, context.CancelFunc, time.Duration, error)); ok {
+		return rf(ctx, cr)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, *v1.SupportArchive) context.Context); ok {
+		r0 = rf(ctx, cr)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(context.Context)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, *v1.SupportArchive) context.CancelFunc); ok {
+		r1 = rf(ctx, cr)
+	} else {
+		if ret.Get(1) != nil {
+			r1 = ret.Get(1).(context.CancelFunc)
+		}
+	}
+
+	if rf, ok := ret.Get(2).(func(context.Context, *v1.SupportArchive) time.Duration); ok {
+		r2 = rf(ctx, cr)
+	} else {
+		r2 = ret.Get(2).(time.Duration)
+	}
+
+	if rf, ok := ret.Get(3).(func(context.Context, *v1.SupportArchive) error); ok {
+		r3 = rf(ctx, cr)
+	} else {
+		r3 = ret.Error(3)
+	}
+
+	return r0, r1, r2, r3
+}
+
+// mockClaimHandler_Claim_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'Claim'
+type mockClaimHandler_Claim_Call struct {
+	*mock.Call
+}
+
+// Claim is a helper method to define mock.On call
+//   - ctx context.Context
+//   - cr *v1.SupportArchive
+func (_e *mockClaimHandler_Expecter) Claim(ctx interface{}, cr interface{}) *mockClaimHandler_Claim_Call {
+	return &mockClaimHandler_Claim_Call{Call: _e.mock.On("Claim", ctx, cr)}
+}
+
+func (_c *mockClaimHandler_Claim_Call) Run(run func(ctx context.Context, cr *v1.SupportArchive)) *mockClaimHandler_Claim_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(*v1.SupportArchive))
+	})
+	return _c
+}
+
+func (_c *mockClaimHandler_Claim_Call) Return(claimCtx context.Context, release context.CancelFunc, retryAfter time.Duration, err error) *mockClaimHandler_Claim_Call {
+	_c.Call.Return(claimCtx, release, retryAfter, err)
+	return _c
+}
+
+func (_c *mockClaimHandler_Claim_Call) RunAndReturn(run func(context.Context, *v1.SupportArchive) (context.Context, context.CancelFunc, time.Duration, error)) *mockClaimHandler_Claim_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// newMockClaimHandler creates a new instance of mockClaimHandler. It also registers a testing interface on the mock and a cleanup function to assert the mocks expectations.
+// The first argument is typically a *testing.T value.
+func newMockClaimHandler(t interface {
+	mock.TestingT
+	Cleanup(func())
+}) *mockClaimHandler {
+	mock := &mockClaimHandler{}
+	mock.Mock.Test(t)
+
+	t.Cleanup(func() { mock.AssertExpectations(t) })
+
+	return mock
+}
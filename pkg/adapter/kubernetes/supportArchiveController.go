@@ -24,13 +24,17 @@ type SupportArchiveReconciler struct {
 	client        supportArchiveV1Interface
 	createHandler createArchiveHandler
 	deleteHandler deleteArchiveHandler
+	// claimHandler claims support archives before they are created if multiple replicas share the archive volume.
+	// It is nil if only a single replica runs.
+	claimHandler claimHandler
 }
 
-func NewSupportArchiveReconciler(client supportArchiveV1Interface, createHandler createArchiveHandler, deleteHandler deleteArchiveHandler) *SupportArchiveReconciler {
+func NewSupportArchiveReconciler(client supportArchiveV1Interface, createHandler createArchiveHandler, deleteHandler deleteArchiveHandler, claimHandler claimHandler) *SupportArchiveReconciler {
 	return &SupportArchiveReconciler{
 		client:        client,
 		createHandler: createHandler,
 		deleteHandler: deleteHandler,
+		claimHandler:  claimHandler,
 	}
 }
 
@@ -55,6 +59,19 @@ func (s *SupportArchiveReconciler) Reconcile(ctx context.Context, req ctrl.Reque
 		return ctrl.Result{}, nil
 	}
 
+	if s.claimHandler != nil {
+		claimCtx, release, retryAfter, claimErr := s.claimHandler.Claim(ctx, cr)
+		if claimErr != nil {
+			return ctrl.Result{}, claimErr
+		}
+		if claimCtx == nil {
+			logger.Info(fmt.Sprintf("Support archive %q is claimed by another replica", req.NamespacedName))
+			return ctrl.Result{RequeueAfter: retryAfter}, nil
+		}
+		defer release()
+		ctx = claimCtx
+	}
+
 	requeueAfter, err := s.createHandler.HandleArchiveRequest(ctx, cr)
 	return ctrl.Result{RequeueAfter: requeueAfter}, err
 }
@@ -67,14 +84,28 @@ func (s *SupportArchiveReconciler) SetupWithManager(mgr ctrl.Manager, externalEv
 
 	controllerOptions := mgr.GetControllerOptions()
 	options := controller.TypedOptions[reconcile.Request]{
-		SkipNameValidation: controllerOptions.SkipNameValidation,
-		RecoverPanic:       controllerOptions.RecoverPanic,
-		NeedLeaderElection: controllerOptions.NeedLeaderElection,
+		SkipNameValidation:      controllerOptions.SkipNameValidation,
+		RecoverPanic:            controllerOptions.RecoverPanic,
+		NeedLeaderElection:      controllerOptions.NeedLeaderElection,
+		MaxConcurrentReconciles: controllerOptions.MaxConcurrentReconciles,
 	}
 	return ctrl.NewControllerManagedBy(mgr).
-		WithEventFilter(predicate.GenerationChangedPredicate{}).
+		WithEventFilter(predicate.Or(predicate.GenerationChangedPredicate{}, reconcileAnnotationsChangedPredicate())).
 		WithOptions(options).
 		For(&libv1.SupportArchive{}).
 		WatchesRawSource(source.Channel(externalEvents, &handler.EnqueueRequestForObject{})).
 		Complete(s)
 }
+
+// reconcileAnnotationsChangedPredicate triggers the reconciliation if relative content timeframes, refreshes or dry runs
+// are changed with annotations, because changes of annotations do not change the generation.
+func reconcileAnnotationsChangedPredicate() predicate.Predicate {
+	return predicate.Funcs{
+		UpdateFunc: func(e event.UpdateEvent) bool {
+			if e.ObjectOld == nil || e.ObjectNew == nil {
+				return false
+			}
+			return annotationsChanged(e.ObjectOld, e.ObjectNew, domain.ReconcileAnnotations)
+		},
+	}
+}
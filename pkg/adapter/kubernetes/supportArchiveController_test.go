@@ -42,7 +42,7 @@ func TestNewSupportArchiveOperator(t *testing.T) {
 		mockClient := newMockSupportArchiveV1Interface(t)
 
 		// when
-		doguManager := NewSupportArchiveReconciler(mockClient, newMockCreateArchiveHandler(t), newMockDeleteArchiveHandler(t))
+		doguManager := NewSupportArchiveReconciler(mockClient, newMockCreateArchiveHandler(t), newMockDeleteArchiveHandler(t), newMockClaimHandler(t))
 
 		// then
 		require.NotNil(t, doguManager)
@@ -85,6 +85,32 @@ func TestSupportArchiveReconciler_SetupWithManager(t *testing.T) {
 	})
 }
 
+func Test_timeframeAnnotationsChangedPredicate(t *testing.T) {
+	sut := reconcileAnnotationsChangedPredicate()
+	oldCR := &v1.SupportArchive{ObjectMeta: metav1.ObjectMeta{Annotations: map[string]string{domain.LastAnnotation: "6h"}}}
+
+	t.Run("should trigger on changed timeframe annotation", func(t *testing.T) {
+		newCR := &v1.SupportArchive{ObjectMeta: metav1.ObjectMeta{Annotations: map[string]string{domain.LastAnnotation: "24h"}}}
+
+		assert.True(t, sut.Update(event.UpdateEvent{ObjectOld: oldCR, ObjectNew: newCR}))
+	})
+	t.Run("should trigger on refresh", func(t *testing.T) {
+		newCR := &v1.SupportArchive{ObjectMeta: metav1.ObjectMeta{Annotations: map[string]string{domain.LastAnnotation: "6h", domain.RefreshAnnotation: "1"}}}
+
+		assert.True(t, sut.Update(event.UpdateEvent{ObjectOld: oldCR, ObjectNew: newCR}))
+	})
+	t.Run("should trigger on removed dry run", func(t *testing.T) {
+		dryRunCR := &v1.SupportArchive{ObjectMeta: metav1.ObjectMeta{Annotations: map[string]string{domain.LastAnnotation: "6h", domain.DryRunAnnotation: "true"}}}
+
+		assert.True(t, sut.Update(event.UpdateEvent{ObjectOld: dryRunCR, ObjectNew: oldCR}))
+	})
+	t.Run("should not trigger on other annotations", func(t *testing.T) {
+		newCR := &v1.SupportArchive{ObjectMeta: metav1.ObjectMeta{Annotations: map[string]string{domain.LastAnnotation: "6h", "other": "value"}}}
+
+		assert.False(t, sut.Update(event.UpdateEvent{ObjectOld: oldCR, ObjectNew: newCR}))
+	})
+}
+
 func createScheme(t *testing.T) *runtime.Scheme {
 	t.Helper()
 
@@ -140,6 +166,81 @@ func TestSupportArchiveReconciler_Reconcile(t *testing.T) {
 		assert.Equal(t, ctrl.Result{RequeueAfter: time.Nanosecond}, actual)
 	})
 
+	t.Run("should create archive with the context of the claim", func(t *testing.T) {
+		// given
+		request := ctrl.Request{NamespacedName: types.NamespacedName{Name: testSupportArchive, Namespace: testNamespace}}
+		mockV1Interface := newMockSupportArchiveV1Interface(t)
+		mockInterface := newMockSupportArchiveInterface(t)
+		mockV1Interface.EXPECT().SupportArchives(testNamespace).Return(mockInterface)
+		mockInterface.EXPECT().Get(testCtx, testSupportArchive, metav1.GetOptions{}).Return(archiveCr, nil)
+		claimCtx, release := context.WithCancel(testCtx)
+		claimHandlerMock := newMockClaimHandler(t)
+		claimHandlerMock.EXPECT().Claim(testCtx, archiveCr).Return(claimCtx, release, 0, nil)
+		archiveHandlerMock := newMockCreateArchiveHandler(t)
+		archiveHandlerMock.EXPECT().HandleArchiveRequest(claimCtx, archiveCr).Return(time.Nanosecond, nil)
+
+		sut := &SupportArchiveReconciler{
+			client:        mockV1Interface,
+			createHandler: archiveHandlerMock,
+			claimHandler:  claimHandlerMock,
+		}
+
+		// when
+		actual, err := sut.Reconcile(testCtx, request)
+
+		// then
+		require.NoError(t, err)
+		assert.Equal(t, ctrl.Result{RequeueAfter: time.Nanosecond}, actual)
+		assert.ErrorIs(t, claimCtx.Err(), context.Canceled, "claim should be released")
+	})
+
+	t.Run("should requeue if archive is claimed by another replica", func(t *testing.T) {
+		// given
+		request := ctrl.Request{NamespacedName: types.NamespacedName{Name: testSupportArchive, Namespace: testNamespace}}
+		mockV1Interface := newMockSupportArchiveV1Interface(t)
+		mockInterface := newMockSupportArchiveInterface(t)
+		mockV1Interface.EXPECT().SupportArchives(testNamespace).Return(mockInterface)
+		mockInterface.EXPECT().Get(testCtx, testSupportArchive, metav1.GetOptions{}).Return(archiveCr, nil)
+		claimHandlerMock := newMockClaimHandler(t)
+		claimHandlerMock.EXPECT().Claim(testCtx, archiveCr).Return(nil, nil, 30*time.Second, nil)
+
+		sut := &SupportArchiveReconciler{
+			client:        mockV1Interface,
+			createHandler: newMockCreateArchiveHandler(t),
+			claimHandler:  claimHandlerMock,
+		}
+
+		// when
+		actual, err := sut.Reconcile(testCtx, request)
+
+		// then
+		require.NoError(t, err)
+		assert.Equal(t, ctrl.Result{RequeueAfter: 30 * time.Second}, actual)
+	})
+
+	t.Run("should return error on claim error", func(t *testing.T) {
+		// given
+		request := ctrl.Request{NamespacedName: types.NamespacedName{Name: testSupportArchive, Namespace: testNamespace}}
+		mockV1Interface := newMockSupportArchiveV1Interface(t)
+		mockInterface := newMockSupportArchiveInterface(t)
+		mockV1Interface.EXPECT().SupportArchives(testNamespace).Return(mockInterface)
+		mockInterface.EXPECT().Get(testCtx, testSupportArchive, metav1.GetOptions{}).Return(archiveCr, nil)
+		claimHandlerMock := newMockClaimHandler(t)
+		claimHandlerMock.EXPECT().Claim(testCtx, archiveCr).Return(nil, nil, 0, assert.AnError)
+
+		sut := &SupportArchiveReconciler{
+			client:        mockV1Interface,
+			createHandler: newMockCreateArchiveHandler(t),
+			claimHandler:  claimHandlerMock,
+		}
+
+		// when
+		_, err := sut.Reconcile(testCtx, request)
+
+		// then
+		require.ErrorIs(t, err, assert.AnError)
+	})
+
 	t.Run("should not requeue if archive handler completed archive creation", func(t *testing.T) {
 		// given
 		request := ctrl.Request{NamespacedName: types.NamespacedName{Name: testSupportArchive, Namespace: testNamespace}}
@@ -0,0 +1,196 @@
+package kubernetes
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	libv1 "github.com/cloudogu/k8s-support-archive-lib/api/v1"
+	"github.com/cloudogu/k8s-support-archive-operator/pkg/domain"
+
+	"k8s.io/apimachinery/pkg/api/equality"
+	k8sErrs "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/apimachinery/pkg/util/validation/field"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/log"
+	"sigs.k8s.io/controller-runtime/pkg/webhook/admission"
+)
+
+var supportArchiveGroupKind = schema.GroupKind{Group: "k8s.cloudogu.com", Kind: "SupportArchive"}
+
+// The annotations changing the collected data are handled like the spec.
+var (
+	timeframeAnnotations = []string{domain.LastAnnotation, domain.CollectorTimeframesAnnotation}
+	specAnnotations      = append([]string{domain.DryRunAnnotation}, timeframeAnnotations...)
+)
+
+var (
+	timeframePath   = field.NewPath("spec", "contentTimeframe")
+	annotationsPath = field.NewPath("metadata", "annotations")
+)
+
+// SupportArchiveWebhook defaults and validates support archives at admission, so that invalid specs are rejected
+// before the reconciliation.
+type SupportArchiveWebhook struct {
+	// defaultContentTimeframe is used for missing start times like in the archive creation.
+	defaultContentTimeframe time.Duration
+	// maxContentTimeframe is the maximum length of all content timeframes.
+	maxContentTimeframe time.Duration
+	now                 func() time.Time
+}
+
+func NewSupportArchiveWebhook(defaultContentTimeframe, maxContentTimeframe time.Duration) *SupportArchiveWebhook {
+	return &SupportArchiveWebhook{
+		defaultContentTimeframe: defaultContentTimeframe,
+		maxContentTimeframe:     maxContentTimeframe,
+		now:                     time.Now,
+	}
+}
+
+// SetupWithManager registers the defaulting and validating webhook at the webhook server of the manager.
+func (w *SupportArchiveWebhook) SetupWithManager(mgr ctrl.Manager) error {
+	if mgr == nil {
+		return errors.New("must provide a non-nil Manager")
+	}
+
+	return ctrl.NewWebhookManagedBy(mgr).
+		For(&libv1.SupportArchive{}).
+		WithDefaulter(w).
+		WithValidator(w).
+		Complete()
+}
+
+// Default sets missing start and end times of the content timeframe and shortens timeframes exceeding the maximum.
+// Relative timeframes of the annotations are not changed. The defaulting is only registered for the creation because
+// the content timeframe is immutable.
+func (w *SupportArchiveWebhook) Default(ctx context.Context, obj runtime.Object) error {
+	cr, ok := obj.(*libv1.SupportArchive)
+	if !ok {
+		return fmt.Errorf("expected a support archive but got %T", obj)
+	}
+
+	timeframes, err := domain.ResolveContentTimeframes(cr, w.defaultContentTimeframe, w.now())
+	if err != nil {
+		// invalid timeframes are rejected by the validation
+		return nil
+	}
+
+	timeframe := &cr.Spec.ContentTimeframe
+	if timeframe.EndTime.IsZero() {
+		timeframe.EndTime = metav1.NewTime(timeframes.Default.End)
+	}
+	if timeframe.StartTime.IsZero() {
+		timeframe.StartTime = metav1.NewTime(timeframes.Default.Start)
+	}
+	maxStart := timeframe.EndTime.Add(-w.maxContentTimeframe)
+	if timeframe.StartTime.Time.Before(maxStart) {
+		log.FromContext(ctx).Info("shortening content timeframe to the maximum", "maximum", w.maxContentTimeframe)
+		timeframe.StartTime = metav1.NewTime(maxStart)
+	}
+
+	return nil
+}
+
+// ValidateCreate rejects support archives without content and with impossible or too long content timeframes.
+func (w *SupportArchiveWebhook) ValidateCreate(_ context.Context, obj runtime.Object) (admission.Warnings, error) {
+	cr, ok := obj.(*libv1.SupportArchive)
+	if !ok {
+		return nil, fmt.Errorf("expected a support archive but got %T", obj)
+	}
+
+	return nil, w.toInvalidError(cr, w.validate(cr))
+}
+
+// ValidateUpdate rejects changes of the spec while the archive is collected. Other changes of the spec are validated
+// like on creation. Changes of the metadata, e.g. finalizers, are always allowed.
+func (w *SupportArchiveWebhook) ValidateUpdate(_ context.Context, oldObj, newObj runtime.Object) (admission.Warnings, error) {
+	oldCR, ok := oldObj.(*libv1.SupportArchive)
+	if !ok {
+		return nil, fmt.Errorf("expected a support archive but got %T", oldObj)
+	}
+	newCR, ok := newObj.(*libv1.SupportArchive)
+	if !ok {
+		return nil, fmt.Errorf("expected a support archive but got %T", newObj)
+	}
+
+	if !newCR.GetDeletionTimestamp().IsZero() || !specChanged(oldCR, newCR) {
+		return nil, nil
+	}
+
+	phase := domain.GetArchivePhase(oldCR.Status)
+	if phase == domain.ArchivePhaseCollecting || phase == domain.ArchivePhasePackaging {
+		return nil, w.toInvalidError(newCR, field.ErrorList{
+			field.Forbidden(field.NewPath("spec"), fmt.Sprintf("the spec must not be changed while the archive is in phase %s", phase)),
+		})
+	}
+
+	return nil, w.toInvalidError(newCR, w.validate(newCR))
+}
+
+// ValidateDelete allows every deletion.
+func (w *SupportArchiveWebhook) ValidateDelete(context.Context, runtime.Object) (admission.Warnings, error) {
+	return nil, nil
+}
+
+func (w *SupportArchiveWebhook) validate(cr *libv1.SupportArchive) field.ErrorList {
+	var errs field.ErrorList
+	excluded := cr.Spec.ExcludedContents
+	if excluded.Logs && excluded.VolumeInfo && excluded.SystemInfo && excluded.SensitiveData && excluded.Events && excluded.SystemState {
+		errs = append(errs, field.Invalid(field.NewPath("spec", "excludedContents"), excluded, "at least one content must not be excluded"))
+	}
+
+	now := w.now()
+	timeframes, err := domain.ResolveContentTimeframes(cr, w.defaultContentTimeframe, now)
+	if err != nil {
+		return append(errs, field.Invalid(timeframePath, cr.Spec.ContentTimeframe, err.Error()))
+	}
+
+	defaultPath := timeframePath
+	if _, ok := cr.GetAnnotations()[domain.LastAnnotation]; ok {
+		defaultPath = annotationsPath.Key(domain.LastAnnotation)
+	}
+	if timeframes.Default.Start.After(now) {
+		errs = append(errs, field.Invalid(defaultPath, timeframes.Default.String(), "the content timeframe must not start in the future"))
+	}
+	errs = append(errs, w.validateLength(defaultPath, timeframes.Default)...)
+	for _, timeframe := range timeframes.Collectors {
+		errs = append(errs, w.validateLength(annotationsPath.Key(domain.CollectorTimeframesAnnotation), timeframe)...)
+	}
+
+	return errs
+}
+
+func (w *SupportArchiveWebhook) validateLength(path *field.Path, timeframe domain.Timeframe) field.ErrorList {
+	length := timeframe.End.Sub(timeframe.Start)
+	if length > w.maxContentTimeframe {
+		return field.ErrorList{field.Invalid(path, timeframe.String(), fmt.Sprintf("the timeframe of %s exceeds the maximum of %s", length, w.maxContentTimeframe))}
+	}
+
+	return nil
+}
+
+func (w *SupportArchiveWebhook) toInvalidError(cr *libv1.SupportArchive, errs field.ErrorList) error {
+	if len(errs) == 0 {
+		return nil
+	}
+
+	return k8sErrs.NewInvalid(supportArchiveGroupKind, cr.GetName(), errs)
+}
+
+func specChanged(oldCR, newCR *libv1.SupportArchive) bool {
+	return !equality.Semantic.DeepEqual(oldCR.Spec, newCR.Spec) || annotationsChanged(oldCR, newCR, specAnnotations)
+}
+
+func annotationsChanged(oldObj, newObj metav1.Object, annotations []string) bool {
+	for _, annotation := range annotations {
+		if oldObj.GetAnnotations()[annotation] != newObj.GetAnnotations()[annotation] {
+			return true
+		}
+	}
+
+	return false
+}
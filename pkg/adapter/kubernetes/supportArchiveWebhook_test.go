@@ -0,0 +1,289 @@
+package kubernetes
+
+import (
+	"testing"
+	"time"
+
+	v1 "github.com/cloudogu/k8s-support-archive-lib/api/v1"
+	"github.com/cloudogu/k8s-support-archive-operator/pkg/domain"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	k8sErrs "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/rest"
+	"sigs.k8s.io/controller-runtime/pkg/webhook"
+)
+
+var testWebhookNow = time.Date(2025, 3, 10, 12, 0, 0, 0, time.UTC)
+
+func newTestWebhook() *SupportArchiveWebhook {
+	sut := NewSupportArchiveWebhook(96*time.Hour, 720*time.Hour)
+	sut.now = func() time.Time {
+		return testWebhookNow
+	}
+
+	return sut
+}
+
+func newWebhookTestCR(start, end time.Time) *v1.SupportArchive {
+	return &v1.SupportArchive{
+		ObjectMeta: metav1.ObjectMeta{Name: testSupportArchive, Namespace: testNamespace},
+		Spec: v1.SupportArchiveSpec{
+			ContentTimeframe: v1.ContentTimeframe{StartTime: metav1.NewTime(start), EndTime: metav1.NewTime(end)},
+		},
+	}
+}
+
+func withPhase(cr *v1.SupportArchive, phase domain.ArchivePhase) *v1.SupportArchive {
+	domain.SetArchivePhase(&cr.Status, phase, cr.Generation, testWebhookNow)
+	return cr
+}
+
+func TestNewSupportArchiveWebhook(t *testing.T) {
+	sut := NewSupportArchiveWebhook(96*time.Hour, 720*time.Hour)
+
+	assert.Equal(t, 96*time.Hour, sut.defaultContentTimeframe)
+	assert.Equal(t, 720*time.Hour, sut.maxContentTimeframe)
+	assert.NotNil(t, sut.now)
+}
+
+func TestSupportArchiveWebhook_SetupWithManager(t *testing.T) {
+	t.Run("should fail without manager", func(t *testing.T) {
+		err := newTestWebhook().SetupWithManager(nil)
+
+		require.Error(t, err)
+		assert.ErrorContains(t, err, "must provide a non-nil Manager")
+	})
+	t.Run("should register webhooks", func(t *testing.T) {
+		// given
+		ctrlManMock := newMockControllerManager(t)
+		ctrlManMock.EXPECT().GetConfig().Return(&rest.Config{})
+		ctrlManMock.EXPECT().GetScheme().Return(createScheme(t))
+		ctrlManMock.EXPECT().GetWebhookServer().Return(webhook.NewServer(webhook.Options{}))
+
+		// when
+		err := newTestWebhook().SetupWithManager(ctrlManMock)
+
+		// then
+		require.NoError(t, err)
+	})
+}
+
+func TestSupportArchiveWebhook_Default(t *testing.T) {
+	t.Run("should set missing timeframe to the default timeframe up to now", func(t *testing.T) {
+		// given
+		cr := newWebhookTestCR(time.Time{}, time.Time{})
+
+		// when
+		err := newTestWebhook().Default(testCtx, cr)
+
+		// then
+		require.NoError(t, err)
+		assert.Equal(t, metav1.NewTime(testWebhookNow.Add(-96*time.Hour)), cr.Spec.ContentTimeframe.StartTime)
+		assert.Equal(t, metav1.NewTime(testWebhookNow), cr.Spec.ContentTimeframe.EndTime)
+	})
+	t.Run("should set missing start relative to the end", func(t *testing.T) {
+		// given
+		end := testWebhookNow.Add(-time.Hour)
+		cr := newWebhookTestCR(time.Time{}, end)
+
+		// when
+		err := newTestWebhook().Default(testCtx, cr)
+
+		// then
+		require.NoError(t, err)
+		assert.Equal(t, metav1.NewTime(end.Add(-96*time.Hour)), cr.Spec.ContentTimeframe.StartTime)
+		assert.Equal(t, metav1.NewTime(end), cr.Spec.ContentTimeframe.EndTime)
+	})
+	t.Run("should shorten timeframe to the maximum", func(t *testing.T) {
+		// given
+		cr := newWebhookTestCR(testWebhookNow.Add(-2000*time.Hour), testWebhookNow)
+
+		// when
+		err := newTestWebhook().Default(testCtx, cr)
+
+		// then
+		require.NoError(t, err)
+		assert.Equal(t, metav1.NewTime(testWebhookNow.Add(-720*time.Hour)), cr.Spec.ContentTimeframe.StartTime)
+	})
+	t.Run("should not change invalid timeframe", func(t *testing.T) {
+		// given
+		cr := newWebhookTestCR(testWebhookNow, testWebhookNow.Add(-time.Hour))
+
+		// when
+		err := newTestWebhook().Default(testCtx, cr)
+
+		// then
+		require.NoError(t, err)
+		assert.Equal(t, metav1.NewTime(testWebhookNow), cr.Spec.ContentTimeframe.StartTime)
+	})
+	t.Run("should fail for other objects", func(t *testing.T) {
+		err := newTestWebhook().Default(testCtx, &v1.SupportArchiveList{})
+
+		require.Error(t, err)
+		assert.ErrorContains(t, err, "expected a support archive but got *v1.SupportArchiveList")
+	})
+}
+
+func TestSupportArchiveWebhook_ValidateCreate(t *testing.T) {
+	validCR := newWebhookTestCR(testWebhookNow.Add(-24*time.Hour), testWebhookNow)
+
+	t.Run("should accept valid support archive", func(t *testing.T) {
+		warnings, err := newTestWebhook().ValidateCreate(testCtx, validCR)
+
+		require.NoError(t, err)
+		assert.Empty(t, warnings)
+	})
+	t.Run("should reject support archive without content", func(t *testing.T) {
+		// given
+		cr := validCR.DeepCopy()
+		cr.Spec.ExcludedContents = v1.ExcludedContents{SystemState: true, SensitiveData: true, Events: true, Logs: true, VolumeInfo: true, SystemInfo: true}
+
+		// when
+		_, err := newTestWebhook().ValidateCreate(testCtx, cr)
+
+		// then
+		require.Error(t, err)
+		assert.True(t, k8sErrs.IsInvalid(err))
+		assert.ErrorContains(t, err, "spec.excludedContents")
+		assert.ErrorContains(t, err, "at least one content must not be excluded")
+	})
+	t.Run("should reject end before start", func(t *testing.T) {
+		_, err := newTestWebhook().ValidateCreate(testCtx, newWebhookTestCR(testWebhookNow, testWebhookNow.Add(-time.Hour)))
+
+		require.Error(t, err)
+		assert.True(t, k8sErrs.IsInvalid(err))
+		assert.ErrorContains(t, err, "spec.contentTimeframe")
+		assert.ErrorContains(t, err, "is not before its end")
+	})
+	t.Run("should reject start in the future", func(t *testing.T) {
+		_, err := newTestWebhook().ValidateCreate(testCtx, newWebhookTestCR(testWebhookNow.Add(time.Hour), testWebhookNow.Add(2*time.Hour)))
+
+		require.Error(t, err)
+		assert.ErrorContains(t, err, "the content timeframe must not start in the future")
+	})
+	t.Run("should reject timeframe exceeding the maximum", func(t *testing.T) {
+		_, err := newTestWebhook().ValidateCreate(testCtx, newWebhookTestCR(testWebhookNow.Add(-721*time.Hour), testWebhookNow))
+
+		require.Error(t, err)
+		assert.ErrorContains(t, err, "spec.contentTimeframe")
+		assert.ErrorContains(t, err, "the timeframe of 721h0m0s exceeds the maximum of 720h0m0s")
+	})
+	t.Run("should reject relative timeframes exceeding the maximum", func(t *testing.T) {
+		// given
+		cr := validCR.DeepCopy()
+		cr.Annotations = map[string]string{domain.LastAnnotation: "31d", domain.CollectorTimeframesAnnotation: "metrics=60d"}
+
+		// when
+		_, err := newTestWebhook().ValidateCreate(testCtx, cr)
+
+		// then
+		require.Error(t, err)
+		assert.ErrorContains(t, err, "metadata.annotations[k8s.cloudogu.com/support-archive-last]")
+		assert.ErrorContains(t, err, "metadata.annotations[k8s.cloudogu.com/support-archive-collector-timeframes]")
+		assert.ErrorContains(t, err, "exceeds the maximum of 720h0m0s")
+	})
+	t.Run("should reject invalid annotation", func(t *testing.T) {
+		// given
+		cr := validCR.DeepCopy()
+		cr.Annotations = map[string]string{domain.CollectorTimeframesAnnotation: "secrets=1h"}
+
+		// when
+		_, err := newTestWebhook().ValidateCreate(testCtx, cr)
+
+		// then
+		require.Error(t, err)
+		assert.ErrorContains(t, err, `unknown collector "secrets"`)
+	})
+	t.Run("should fail for other objects", func(t *testing.T) {
+		_, err := newTestWebhook().ValidateCreate(testCtx, &v1.SupportArchiveList{})
+
+		require.Error(t, err)
+		assert.ErrorContains(t, err, "expected a support archive")
+	})
+}
+
+func TestSupportArchiveWebhook_ValidateUpdate(t *testing.T) {
+	validCR := newWebhookTestCR(testWebhookNow.Add(-24*time.Hour), testWebhookNow)
+
+	t.Run("should accept metadata changes while collecting", func(t *testing.T) {
+		// given
+		oldCR := withPhase(validCR.DeepCopy(), domain.ArchivePhaseCollecting)
+		newCR := oldCR.DeepCopy()
+		newCR.Finalizers = []string{"cloudogu-support-archive-finalizer"}
+
+		// when
+		_, err := newTestWebhook().ValidateUpdate(testCtx, oldCR, newCR)
+
+		// then
+		require.NoError(t, err)
+	})
+	t.Run("should reject spec changes while collecting", func(t *testing.T) {
+		// given
+		oldCR := withPhase(validCR.DeepCopy(), domain.ArchivePhaseCollecting)
+		newCR := oldCR.DeepCopy()
+		newCR.Spec.ExcludedContents.Logs = true
+
+		// when
+		_, err := newTestWebhook().ValidateUpdate(testCtx, oldCR, newCR)
+
+		// then
+		require.Error(t, err)
+		assert.True(t, k8sErrs.IsInvalid(err))
+		assert.ErrorContains(t, err, "the spec must not be changed while the archive is in phase Collecting")
+	})
+	t.Run("should reject timeframe annotation changes while packaging", func(t *testing.T) {
+		// given
+		oldCR := withPhase(validCR.DeepCopy(), domain.ArchivePhasePackaging)
+		newCR := oldCR.DeepCopy()
+		newCR.Annotations = map[string]string{domain.CollectorTimeframesAnnotation: "logs=1h"}
+
+		// when
+		_, err := newTestWebhook().ValidateUpdate(testCtx, oldCR, newCR)
+
+		// then
+		require.Error(t, err)
+		assert.ErrorContains(t, err, "the spec must not be changed while the archive is in phase Packaging")
+	})
+	t.Run("should accept deletion while collecting", func(t *testing.T) {
+		// given
+		oldCR := withPhase(validCR.DeepCopy(), domain.ArchivePhaseCollecting)
+		newCR := oldCR.DeepCopy()
+		newCR.Spec.ExcludedContents.Logs = true
+		now := metav1.NewTime(testWebhookNow)
+		newCR.DeletionTimestamp = &now
+
+		// when
+		_, err := newTestWebhook().ValidateUpdate(testCtx, oldCR, newCR)
+
+		// then
+		require.NoError(t, err)
+	})
+	t.Run("should validate spec changes of finished archives", func(t *testing.T) {
+		// given
+		oldCR := withPhase(validCR.DeepCopy(), domain.ArchivePhaseSucceeded)
+		newCR := oldCR.DeepCopy()
+		newCR.Spec.ExcludedContents = v1.ExcludedContents{SystemState: true, SensitiveData: true, Events: true, Logs: true, VolumeInfo: true, SystemInfo: true}
+
+		// when
+		_, err := newTestWebhook().ValidateUpdate(testCtx, oldCR, newCR)
+
+		// then
+		require.Error(t, err)
+		assert.ErrorContains(t, err, "at least one content must not be excluded")
+	})
+	t.Run("should fail for other objects", func(t *testing.T) {
+		_, err := newTestWebhook().ValidateUpdate(testCtx, validCR, &v1.SupportArchiveList{})
+
+		require.Error(t, err)
+		assert.ErrorContains(t, err, "expected a support archive")
+	})
+}
+
+func TestSupportArchiveWebhook_ValidateDelete(t *testing.T) {
+	warnings, err := newTestWebhook().ValidateDelete(testCtx, withPhase(newWebhookTestCR(testWebhookNow, testWebhookNow), domain.ArchivePhaseCollecting))
+
+	require.NoError(t, err)
+	assert.Empty(t, warnings)
+}
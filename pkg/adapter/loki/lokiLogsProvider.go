@@ -6,6 +6,7 @@ import (
 	"encoding/json"
 	"fmt"
 	"io"
+	"math"
 	"net/http"
 	"net/url"
 	"strconv"
@@ -22,6 +23,7 @@ import (
 const (
 	loggerName         = "LokiLogsProvider"
 	lokiQueryrangePath = "loki/api/v1/query_range"
+	lokiQueryPath      = "loki/api/v1/query"
 )
 
 type LokiLogsProvider struct {
@@ -49,6 +51,38 @@ type queryLogsResult struct {
 	Values [][]string        `json:"values"`
 }
 
+// queryVectorResponse is the response of an instant query with a vector result.
+type queryVectorResponse struct {
+	Data struct {
+		ResultType string `json:"resultType"`
+		Result     []struct {
+			// Value contains the timestamp and the value as string.
+			Value []any `json:"value"`
+		} `json:"result"`
+	} `json:"data"`
+}
+
+// sum adds the values of all results. The values are rounded because loki returns them as floats.
+func (r *queryVectorResponse) sum() (int64, error) {
+	var sum float64
+	for _, result := range r.Data.Result {
+		if len(result.Value) != 2 {
+			return 0, fmt.Errorf("invalid vector value %v", result.Value)
+		}
+		value, ok := result.Value[1].(string)
+		if !ok {
+			return 0, fmt.Errorf("invalid vector value %v", result.Value)
+		}
+		parsed, err := strconv.ParseFloat(value, 64)
+		if err != nil {
+			return 0, fmt.Errorf("parse vector value %q: %w", value, err)
+		}
+		sum += parsed
+	}
+
+	return int64(math.Round(sum)), nil
+}
+
 type queryLogsStream struct {
 	LogLevel  string `json:"detected_level"`
 	Namespace string `json:"namespace"`
@@ -177,8 +211,6 @@ func httpToDomainLogLine(timestamp, line string, stream map[string]string) (doma
 }
 
 func (lp *LokiLogsProvider) httpFindLogs(ctx context.Context, start, end time.Time, namespace string, returnType ReturnType) (*queryLogsResponse, error) {
-	logger := log.FromContext(ctx).WithName(loggerName)
-
 	query, err := returnType.GetQuery(namespace, lp.logEventSourceName)
 	if err != nil {
 		return nil, err
@@ -189,15 +221,88 @@ func (lp *LokiLogsProvider) httpFindLogs(ctx context.Context, start, end time.Ti
 		return nil, fmt.Errorf("building logs query: %w", err)
 	}
 
+	var result *queryLogsResponse
+	err = lp.httpGet(ctx, query, func(body io.Reader) error {
+		var parseErr error
+		result, parseErr = parseQueryLogsResponse(body)
+		return parseErr
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return result, nil
+}
+
+// EstimateLogs counts the log lines and their bytes per time window with count_over_time and bytes_over_time queries
+// instead of fetching them. Every time window needs one request per maximum result count log lines.
+func (lp *LokiLogsProvider) EstimateLogs(ctx context.Context, start, end time.Time, namespace string) (domain.CollectorEstimate, error) {
+	selector, err := onlyLogs.GetQuery(namespace, lp.logEventSourceName)
+	if err != nil {
+		return domain.CollectorEstimate{}, err
+	}
+
+	estimate := domain.CollectorEstimate{Unit: "log lines"}
+	var windowStart time.Time
+	windowEnd := start
+	for windowEnd.Before(end) {
+		windowStart, windowEnd = findLogsNextTimeWindow(windowEnd, end, lp.maxQueryTimeWindow)
+		rangeSelector := fmt.Sprintf("%s[%dms]", selector, windowEnd.Sub(windowStart).Milliseconds())
+
+		lines, err := lp.httpQuerySum(ctx, fmt.Sprintf("sum(count_over_time(%s))", rangeSelector), windowEnd)
+		if err != nil {
+			return domain.CollectorEstimate{}, fmt.Errorf("counting logs: %w", err)
+		}
+		bytes, err := lp.httpQuerySum(ctx, fmt.Sprintf("sum(bytes_over_time(%s))", rangeSelector), windowEnd)
+		if err != nil {
+			return domain.CollectorEstimate{}, fmt.Errorf("counting log bytes: %w", err)
+		}
+
+		estimate.Items += lines
+		estimate.Bytes += bytes
+		estimate.Requests++
+		if lp.maxQueryResultCount > 0 {
+			estimate.Requests += int(lines / int64(lp.maxQueryResultCount))
+		}
+	}
+
+	return estimate, nil
+}
+
+// httpQuerySum executes the instant query and returns the value of the single result or 0 for an empty result.
+func (lp *LokiLogsProvider) httpQuerySum(ctx context.Context, query string, at time.Time) (int64, error) {
+	queryURL, err := buildInstantHttpQuery(lp.serviceURL, query, at)
+	if err != nil {
+		return 0, fmt.Errorf("building instant query: %w", err)
+	}
+
+	response := &queryVectorResponse{}
+	err = lp.httpGet(ctx, queryURL, func(body io.Reader) error {
+		decodeErr := json.NewDecoder(body).Decode(response)
+		if decodeErr != nil {
+			return fmt.Errorf("decode instant query http response: %w", decodeErr)
+		}
+		return nil
+	})
+	if err != nil {
+		return 0, err
+	}
+
+	return response.sum()
+}
+
+func (lp *LokiLogsProvider) httpGet(ctx context.Context, query string, parse func(body io.Reader) error) error {
+	logger := log.FromContext(ctx).WithName(loggerName)
+
 	req, err := http.NewRequestWithContext(ctx, http.MethodGet, query, nil)
 	if err != nil {
-		return nil, fmt.Errorf("create http request for query %q: %w", query, err)
+		return fmt.Errorf("create http request for query %q: %w", query, err)
 	}
 	req.SetBasicAuth(lp.username, lp.password)
 	// nolint:bodyclose
 	resp, err := lp.httpClient.Do(req)
 	if err != nil {
-		return nil, fmt.Errorf("call loki http api: %w", err)
+		return fmt.Errorf("call loki http api: %w", err)
 	}
 
 	defer func(body io.ReadCloser) {
@@ -208,10 +313,10 @@ func (lp *LokiLogsProvider) httpFindLogs(ctx context.Context, start, end time.Ti
 	}(resp.Body)
 
 	if resp.StatusCode != http.StatusOK {
-		return nil, extractErrorFromResponse(resp)
+		return extractErrorFromResponse(resp)
 	}
 
-	return parseQueryLogsResponse(resp.Body)
+	return parse(resp.Body)
 }
 
 func buildFindLogsHttpQuery(serviceURL, query string, start, end time.Time, maxQueryResultCount int) (string, error) {
@@ -233,6 +338,21 @@ func buildFindLogsHttpQuery(serviceURL, query string, start, end time.Time, maxQ
 	return baseUrl.String(), nil
 }
 
+func buildInstantHttpQuery(serviceURL, query string, at time.Time) (string, error) {
+	baseUrl, err := url.Parse(serviceURL)
+	if err != nil {
+		return "", fmt.Errorf("parse service URL: %w", err)
+	}
+	baseUrl = baseUrl.JoinPath(lokiQueryPath)
+
+	params := baseUrl.Query()
+	params.Set("query", query)
+	params.Set("time", fmt.Sprintf("%d", at.UnixNano()))
+	baseUrl.RawQuery = params.Encode()
+
+	return baseUrl.String(), nil
+}
+
 func extractErrorFromResponse(resp *http.Response) error {
 	body, err := io.ReadAll(resp.Body)
 	if err != nil || len(body) == 0 {
@@ -0,0 +1,101 @@
+package loki
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/cloudogu/k8s-support-archive-operator/pkg/domain"
+)
+
+func newInstantQueryResponse(value string) string {
+	return fmt.Sprintf(`{"status":"success","data":{"resultType":"vector","result":[{"metric":{},"value":[1700000000,"%s"]}]}}`, value)
+}
+
+func TestLokiLogsProvider_EstimateLogs(t *testing.T) {
+	t.Run("should count lines and bytes per time window", func(t *testing.T) {
+		// given
+		endTime := testStartTime.Add(time.Hour * 24 * 15)
+		var queries []string
+		var times []string
+		server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			assert.Equal(t, "/loki/api/v1/query", r.URL.Path)
+			query := r.URL.Query().Get("query")
+			queries = append(queries, query)
+			times = append(times, r.URL.Query().Get("time"))
+			if strings.HasPrefix(query, "sum(count_over_time(") {
+				_, _ = w.Write([]byte(newInstantQueryResponse("7")))
+			} else {
+				_, _ = w.Write([]byte(newInstantQueryResponse("1024.4")))
+			}
+		}))
+		defer server.Close()
+		sut := newTestLokiLogsProviderWithLimits(server.Client(), server.URL, 10, 3)
+
+		// when
+		estimate, err := sut.EstimateLogs(context.TODO(), testStartTime, endTime, "aNamespace")
+
+		// then
+		require.NoError(t, err)
+		assert.Equal(t, domain.CollectorEstimate{Items: 14, Unit: "log lines", Bytes: 2048, Requests: 6}, estimate)
+		require.Len(t, queries, 4)
+		assert.Equal(t, `sum(count_over_time({namespace="aNamespace", job!=""}[864000000ms]))`, queries[0])
+		assert.Equal(t, `sum(bytes_over_time({namespace="aNamespace", job!=""}[864000000ms]))`, queries[1])
+		assert.Equal(t, `sum(count_over_time({namespace="aNamespace", job!=""}[432000000ms]))`, queries[2])
+		assert.Equal(t, fmt.Sprintf("%d", testStartTime.Add(time.Hour*24*10).UnixNano()), times[0])
+		assert.Equal(t, fmt.Sprintf("%d", endTime.UnixNano()), times[2])
+	})
+	t.Run("should treat empty result as zero", func(t *testing.T) {
+		// given
+		server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			_, _ = w.Write([]byte(`{"status":"success","data":{"resultType":"vector","result":[]}}`))
+		}))
+		defer server.Close()
+		sut := newTestLokiLogsProviderWithLimits(server.Client(), server.URL, 10, 3)
+
+		// when
+		estimate, err := sut.EstimateLogs(context.TODO(), testStartTime, testStartTime.Add(time.Hour), "aNamespace")
+
+		// then
+		require.NoError(t, err)
+		assert.Equal(t, domain.CollectorEstimate{Unit: "log lines", Requests: 1}, estimate)
+	})
+	t.Run("should return error if query fails", func(t *testing.T) {
+		// given
+		server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			w.WriteHeader(http.StatusBadRequest)
+			_, _ = w.Write([]byte("invalid query"))
+		}))
+		defer server.Close()
+		sut := newTestLokiLogsProviderWithLimits(server.Client(), server.URL, 10, 3)
+
+		// when
+		_, err := sut.EstimateLogs(context.TODO(), testStartTime, testStartTime.Add(time.Hour), "aNamespace")
+
+		// then
+		require.Error(t, err)
+		assert.ErrorContains(t, err, "counting logs: http request failed with status: 400 Bad Request, body: invalid query")
+	})
+	t.Run("should return error for invalid value", func(t *testing.T) {
+		// given
+		server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			_, _ = w.Write([]byte(newInstantQueryResponse("NaN-value")))
+		}))
+		defer server.Close()
+		sut := newTestLokiLogsProviderWithLimits(server.Client(), server.URL, 10, 3)
+
+		// when
+		_, err := sut.EstimateLogs(context.TODO(), testStartTime, testStartTime.Add(time.Hour), "aNamespace")
+
+		// then
+		require.Error(t, err)
+		assert.ErrorContains(t, err, `parse vector value "NaN-value"`)
+	})
+}
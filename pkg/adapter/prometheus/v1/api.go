@@ -4,7 +4,6 @@ import (
 	"context"
 	"errors"
 	"fmt"
-	"strconv"
 	"time"
 
 	"github.com/cloudogu/k8s-support-archive-operator/pkg/domain"
@@ -23,16 +22,16 @@ func NewPrometheusMetricsV1API(client client, maxSamples int) *PrometheusMetrics
 	return &PrometheusMetricsV1API{v1API: v1.NewAPI(client), maxSamples: maxSamples}
 }
 
-func (p *PrometheusMetricsV1API) GetCapacityBytesForPVC(ctx context.Context, namespace, pvcName string, ts time.Time) (int64, error) {
-	query := fmt.Sprintf(capacityBytesQueryFmt, namespace, pvcName)
-
-	return p.queryInt64(ctx, query, ts)
+func (p *PrometheusMetricsV1API) GetCapacityBytesForPVC(ctx context.Context, namespace, pvcName string, start, end time.Time, step time.Duration) ([]domain.LabeledSample, error) {
+	return p.queryRangeForPVC(ctx, pvcCapacityBytesMetric, capacityBytesQueryFmt, namespace, pvcName, start, end, step)
 }
 
-func (p *PrometheusMetricsV1API) GetUsedBytesForPVC(ctx context.Context, namespace, pvcName string, ts time.Time) (int64, error) {
-	query := fmt.Sprintf(usedBytesQueryFmt, namespace, pvcName)
+func (p *PrometheusMetricsV1API) GetUsedBytesForPVC(ctx context.Context, namespace, pvcName string, start, end time.Time, step time.Duration) ([]domain.LabeledSample, error) {
+	return p.queryRangeForPVC(ctx, pvcUsedBytesMetric, usedBytesQueryFmt, namespace, pvcName, start, end, step)
+}
 
-	return p.queryInt64(ctx, query, ts)
+func (p *PrometheusMetricsV1API) GetUsedInodesForPVC(ctx context.Context, namespace, pvcName string, start, end time.Time, step time.Duration) ([]domain.LabeledSample, error) {
+	return p.queryRangeForPVC(ctx, pvcUsedInodesMetric, usedInodesQueryFmt, namespace, pvcName, start, end, step)
 }
 
 func (p *PrometheusMetricsV1API) GetNodeCount(ctx context.Context, start, end time.Time, step time.Duration, resultChan chan<- *domain.LabeledSample) error {
@@ -87,24 +86,6 @@ func (p *PrometheusMetricsV1API) GetNodeNetworkContainerBytesSend(ctx context.Co
 	return p.queryRange(ctx, nodeNetworkContainerBytesSentMetric, start, end, step, resultChan, p.maxSamples)
 }
 
-func (p *PrometheusMetricsV1API) queryInt64(ctx context.Context, query string, ts time.Time) (int64, error) {
-	result, err := p.query(ctx, query, ts)
-	if err != nil {
-		return 0, err
-	}
-
-	if result == "" {
-		return 0, nil
-	}
-
-	bytesInt, err := strconv.ParseInt(result, 10, 64)
-	if err != nil {
-		return 0, fmt.Errorf("failed to parse string %s to int64: %w", result, err)
-	}
-
-	return bytesInt, nil
-}
-
 func (p *PrometheusMetricsV1API) query(ctx context.Context, query string, ts time.Time) (string, error) {
 	logger := log.FromContext(ctx).WithName("PrometheusMetricsV1API.query")
 	value, warnings, err := p.Query(ctx, query, ts)
@@ -118,6 +99,19 @@ func (p *PrometheusMetricsV1API) query(ctx context.Context, query string, ts tim
 }
 
 func (p *PrometheusMetricsV1API) queryRange(ctx context.Context, metric metric, start, end time.Time, step time.Duration, resultChan chan<- *domain.LabeledSample, pageSampleSize int) error {
+	query, err := metric.getQuery()
+	if err != nil {
+		return err
+	}
+
+	return p.queryRangePages(ctx, query, start, end, step, pageSampleSize, func(value model.Value) error {
+		return writeMatrixToChannel(value, metric, resultChan)
+	})
+}
+
+// queryRangePages splits the range query into pages with at most pageSampleSize samples per series
+// and calls handlePage with the result of each page.
+func (p *PrometheusMetricsV1API) queryRangePages(ctx context.Context, query string, start, end time.Time, step time.Duration, pageSampleSize int, handlePage func(value model.Value) error) error {
 	logger := log.FromContext(ctx).WithName("PrometheusMetricsV1API.queryRange")
 
 	pageStart := start
@@ -139,10 +133,6 @@ func (p *PrometheusMetricsV1API) queryRange(ctx context.Context, metric metric,
 			Step:  step,
 		}
 
-		query, err := metric.getQuery()
-		if err != nil {
-			return err
-		}
 		logger.Info("do range query", "query", query, "start", start, "end", end, "step", step, "pageIndex", pageIndex, "lastPage", lastPage)
 		value, warnings, pageErr := p.QueryRange(ctx, query, r)
 		if pageErr != nil {
@@ -150,8 +140,7 @@ func (p *PrometheusMetricsV1API) queryRange(ctx context.Context, metric metric,
 		}
 		logWarnings(logger, warnings)
 
-		// write to channel
-		err = writeMatrixToChannel(value, metric, resultChan)
+		err := handlePage(value)
 		if err != nil {
 			return err
 		}
@@ -167,6 +156,36 @@ func (p *PrometheusMetricsV1API) queryRange(ctx context.Context, metric metric,
 	return nil
 }
 
+func (p *PrometheusMetricsV1API) queryRangeForPVC(ctx context.Context, metric metric, queryFmt, namespace, pvcName string, start, end time.Time, step time.Duration) ([]domain.LabeledSample, error) {
+	query := fmt.Sprintf(queryFmt, namespace, pvcName)
+
+	var samples []domain.LabeledSample
+	err := p.queryRangePages(ctx, query, start, end, step, p.maxSamples, func(value model.Value) error {
+		matrix, ok := value.(model.Matrix)
+		if !ok {
+			return fmt.Errorf("invalid value type: %T", value)
+		}
+
+		for _, sampleStream := range matrix {
+			for _, sample := range sampleStream.Values {
+				samples = append(samples, domain.LabeledSample{
+					MetricName: string(metric),
+					ID:         pvcName,
+					Value:      float64(sample.Value),
+					Time:       sample.Timestamp.Time(),
+				})
+			}
+		}
+
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return samples, nil
+}
+
 func writeMatrixToChannel(value model.Value, metric metric, ch chan<- *domain.LabeledSample) error {
 	matrix, ok := value.(model.Matrix)
 	if !ok {
@@ -24,80 +24,63 @@ var (
 )
 
 func TestPrometheusMetricsV1API_GetUsedBytesForPVC(t *testing.T) {
-	now := time.Now()
-	vectorValue := model.Vector{&model.Sample{Value: 1}}
+	end := time.Now()
+	start := end.Add(-time.Hour)
+	step := time.Minute
+	testRange := v1.Range{Start: start, End: end, Step: step}
+	sampleTime := time.Unix(1, 0)
+	matrix := model.Matrix{&model.SampleStream{Values: []model.SamplePair{{Timestamp: model.Time(sampleTime.UnixMilli()), Value: 1}}}}
 
-	type fields struct {
-		v1API func(t *testing.T) v1API
-	}
-	type args struct {
-		ctx       context.Context
-		namespace string
-		pvcName   string
-		ts        time.Time
-	}
-	tests := []struct {
-		name    string
-		fields  fields
-		args    args
-		want    int64
-		wantErr func(t *testing.T, err error)
-	}{
-		{
-			name: "success",
-			fields: fields{
-				v1API: func(t *testing.T) v1API {
-					apiMock := newMockV1API(t)
-					apiMock.EXPECT().Query(testCtx, "kubelet_volume_stats_used_bytes{namespace=\"test\", persistentvolumeclaim=\"test-pvc\"}", now).Return(vectorValue, nil, nil)
+	// given
+	apiMock := newMockV1API(t)
+	apiMock.EXPECT().QueryRange(testCtx, "kubelet_volume_stats_used_bytes{namespace=\"test\", persistentvolumeclaim=\"test-pvc\"}", testRange).Return(matrix, nil, nil)
+	p := &PrometheusMetricsV1API{v1API: apiMock, maxSamples: 11000}
 
-					return apiMock
-				},
-			},
-			args: args{
-				ctx:       testCtx,
-				namespace: testNamespace,
-				pvcName:   testPvcName,
-				ts:        now,
-			},
-			want: 1,
-			wantErr: func(t *testing.T, err error) {
-				require.NoError(t, err)
-			},
-		},
-	}
-	for _, tt := range tests {
-		t.Run(tt.name, func(t *testing.T) {
-			p := &PrometheusMetricsV1API{
-				v1API: tt.fields.v1API(t),
-			}
-			got, err := p.GetUsedBytesForPVC(tt.args.ctx, tt.args.namespace, tt.args.pvcName, tt.args.ts)
-			tt.wantErr(t, err)
-			if got != tt.want {
-				t.Errorf("GetUsesBytesForPVC() got = %v, want %v", got, tt.want)
-			}
-		})
-	}
+	// when
+	got, err := p.GetUsedBytesForPVC(testCtx, testNamespace, testPvcName, start, end, step)
+
+	// then
+	require.NoError(t, err)
+	assert.Equal(t, []domain.LabeledSample{{MetricName: "usedBytes", ID: testPvcName, Value: 1, Time: sampleTime}}, got)
+}
+
+func TestPrometheusMetricsV1API_GetUsedInodesForPVC(t *testing.T) {
+	end := time.Now()
+	start := end.Add(-time.Hour)
+	step := time.Minute
+	testRange := v1.Range{Start: start, End: end, Step: step}
+	sampleTime := time.Unix(1, 0)
+	matrix := model.Matrix{&model.SampleStream{Values: []model.SamplePair{{Timestamp: model.Time(sampleTime.UnixMilli()), Value: 42}}}}
+
+	// given
+	apiMock := newMockV1API(t)
+	apiMock.EXPECT().QueryRange(testCtx, "kubelet_volume_stats_inodes_used{namespace=\"test\", persistentvolumeclaim=\"test-pvc\"}", testRange).Return(matrix, nil, nil)
+	p := &PrometheusMetricsV1API{v1API: apiMock, maxSamples: 11000}
+
+	// when
+	got, err := p.GetUsedInodesForPVC(testCtx, testNamespace, testPvcName, start, end, step)
+
+	// then
+	require.NoError(t, err)
+	assert.Equal(t, []domain.LabeledSample{{MetricName: "usedInodes", ID: testPvcName, Value: 42, Time: sampleTime}}, got)
 }
 
 func TestPrometheusMetricsV1API_GetCapacityBytesForPVC(t *testing.T) {
-	now := time.Now()
-	vectorValue := model.Vector{&model.Sample{Value: 1}}
-	emptyVectorValue := model.Vector{}
+	end := time.Now()
+	start := end.Add(-time.Hour)
+	step := time.Minute
+	testRange := v1.Range{Start: start, End: end, Step: step}
+	sampleTime := time.Unix(1, 0)
+	matrix := model.Matrix{&model.SampleStream{Values: []model.SamplePair{{Timestamp: model.Time(sampleTime.UnixMilli()), Value: 1}}}}
+	query := "kubelet_volume_stats_capacity_bytes{namespace=\"test\", persistentvolumeclaim=\"test-pvc\"}"
 
 	type fields struct {
 		v1API func(t *testing.T) v1API
 	}
-	type args struct {
-		ctx       context.Context
-		namespace string
-		pvcName   string
-		ts        time.Time
-	}
 	tests := []struct {
 		name    string
 		fields  fields
-		args    args
-		want    int64
+		want    []domain.LabeledSample
 		wantErr func(t *testing.T, err error)
 	}{
 		{
@@ -105,39 +88,27 @@ func TestPrometheusMetricsV1API_GetCapacityBytesForPVC(t *testing.T) {
 			fields: fields{
 				v1API: func(t *testing.T) v1API {
 					apiMock := newMockV1API(t)
-					apiMock.EXPECT().Query(testCtx, "kubelet_volume_stats_capacity_bytes{namespace=\"test\", persistentvolumeclaim=\"test-pvc\"}", now).Return(vectorValue, nil, nil)
+					apiMock.EXPECT().QueryRange(testCtx, query, testRange).Return(matrix, nil, nil)
 
 					return apiMock
 				},
 			},
-			args: args{
-				ctx:       testCtx,
-				namespace: testNamespace,
-				pvcName:   testPvcName,
-				ts:        now,
-			},
-			want: 1,
+			want: []domain.LabeledSample{{MetricName: "capacityBytes", ID: testPvcName, Value: 1, Time: sampleTime}},
 			wantErr: func(t *testing.T, err error) {
 				require.NoError(t, err)
 			},
 		},
 		{
-			name: "should return 0 on empty value",
+			name: "should return nil on empty matrix",
 			fields: fields{
 				v1API: func(t *testing.T) v1API {
 					apiMock := newMockV1API(t)
-					apiMock.EXPECT().Query(testCtx, "kubelet_volume_stats_capacity_bytes{namespace=\"test\", persistentvolumeclaim=\"test-pvc\"}", now).Return(emptyVectorValue, nil, nil)
+					apiMock.EXPECT().QueryRange(testCtx, query, testRange).Return(model.Matrix{}, nil, nil)
 
 					return apiMock
 				},
 			},
-			args: args{
-				ctx:       testCtx,
-				namespace: testNamespace,
-				pvcName:   testPvcName,
-				ts:        now,
-			},
-			want: 0,
+			want: nil,
 			wantErr: func(t *testing.T, err error) {
 				require.NoError(t, err)
 			},
@@ -147,56 +118,44 @@ func TestPrometheusMetricsV1API_GetCapacityBytesForPVC(t *testing.T) {
 			fields: fields{
 				v1API: func(t *testing.T) v1API {
 					apiMock := newMockV1API(t)
-					apiMock.EXPECT().Query(testCtx, "kubelet_volume_stats_capacity_bytes{namespace=\"test\", persistentvolumeclaim=\"test-pvc\"}", now).Return(nil, nil, assert.AnError)
+					apiMock.EXPECT().QueryRange(testCtx, query, testRange).Return(nil, nil, assert.AnError)
 
 					return apiMock
 				},
 			},
-			args: args{
-				ctx:       testCtx,
-				namespace: testNamespace,
-				pvcName:   testPvcName,
-				ts:        now,
-			},
-			want: 0,
+			want: nil,
 			wantErr: func(t *testing.T, err error) {
 				require.Error(t, err)
 				assert.ErrorIs(t, err, assert.AnError)
+				assert.ErrorContains(t, err, "metric range error")
 			},
 		},
 		{
-			name: "should return error on parsing error",
+			name: "should return error on invalid value type",
 			fields: fields{
 				v1API: func(t *testing.T) v1API {
 					apiMock := newMockV1API(t)
-					apiMock.EXPECT().Query(testCtx, "kubelet_volume_stats_capacity_bytes{namespace=\"test\", persistentvolumeclaim=\"test-pvc\"}", now).Return(model.Vector{&model.Sample{Value: 1.5}}, nil, nil)
+					apiMock.EXPECT().QueryRange(testCtx, query, testRange).Return(model.Vector{}, nil, nil)
 
 					return apiMock
 				},
 			},
-			args: args{
-				ctx:       testCtx,
-				namespace: testNamespace,
-				pvcName:   testPvcName,
-				ts:        now,
-			},
-			want: 0,
+			want: nil,
 			wantErr: func(t *testing.T, err error) {
 				require.Error(t, err)
-				assert.ErrorContains(t, err, "failed to parse string 1.5 to int64")
+				assert.ErrorContains(t, err, "invalid value type: model.Vector")
 			},
 		},
 	}
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
 			p := &PrometheusMetricsV1API{
-				v1API: tt.fields.v1API(t),
+				v1API:      tt.fields.v1API(t),
+				maxSamples: 11000,
 			}
-			got, err := p.GetCapacityBytesForPVC(tt.args.ctx, tt.args.namespace, tt.args.pvcName, tt.args.ts)
+			got, err := p.GetCapacityBytesForPVC(testCtx, testNamespace, testPvcName, start, end, step)
 			tt.wantErr(t, err)
-			if got != tt.want {
-				t.Errorf("GetCapacityBytesForPVC() got = %v, want %v", got, tt.want)
-			}
+			assert.Equal(t, tt.want, got)
 		})
 	}
 }
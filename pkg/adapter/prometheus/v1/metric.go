@@ -7,6 +7,13 @@ import (
 const (
 	capacityBytesQueryFmt = "kubelet_volume_stats_capacity_bytes{namespace=\"%s\", persistentvolumeclaim=\"%s\"}"
 	usedBytesQueryFmt     = "kubelet_volume_stats_used_bytes{namespace=\"%s\", persistentvolumeclaim=\"%s\"}"
+	usedInodesQueryFmt    = "kubelet_volume_stats_inodes_used{namespace=\"%s\", persistentvolumeclaim=\"%s\"}"
+)
+
+const (
+	pvcCapacityBytesMetric = "capacityBytes"
+	pvcUsedBytesMetric     = "usedBytes"
+	pvcUsedInodesMetric    = "usedInodes"
 )
 
 const (
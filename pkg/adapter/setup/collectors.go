@@ -0,0 +1,94 @@
+// Package setup wires the collectors and file repositories. It is shared by the operator and the command line tool.
+package setup
+
+import (
+	"fmt"
+	"net/http"
+
+	"k8s.io/client-go/kubernetes"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	"github.com/cloudogu/k8s-support-archive-operator/pkg/adapter/archive/file"
+	"github.com/cloudogu/k8s-support-archive-operator/pkg/adapter/collector"
+	"github.com/cloudogu/k8s-support-archive-operator/pkg/adapter/config"
+	"github.com/cloudogu/k8s-support-archive-operator/pkg/adapter/filesystem"
+	"github.com/cloudogu/k8s-support-archive-operator/pkg/adapter/loki"
+	"github.com/cloudogu/k8s-support-archive-operator/pkg/adapter/prometheus"
+	v1 "github.com/cloudogu/k8s-support-archive-operator/pkg/adapter/prometheus/v1"
+	"github.com/cloudogu/k8s-support-archive-operator/pkg/domain"
+	"github.com/cloudogu/k8s-support-archive-operator/pkg/usecase"
+)
+
+// Clients contains the clients used by the collectors.
+type Clients struct {
+	Kubernetes kubernetes.Interface
+	// Generic is used to collect resources of arbitrary kinds.
+	Generic client.Client
+}
+
+// NewCollectorMapping creates all collectors with their file repositories in the work directory.
+// The metrics of nodes and volumes are queried from the prometheus at metricsAddress.
+func NewCollectorMapping(clients Clients, operatorConfig *config.OperatorConfig, metricsAddress, workPath string, fs filesystem.Filesystem) (usecase.CollectorMapping, error) {
+	// TODO Implement ServiceAccount for Prometheus. Create secret in Prometheus Chart and use it?
+	metricsClient, err := prometheus.GetClient(metricsAddress, "")
+	if err != nil {
+		return nil, fmt.Errorf("unable to create prometheus client: %w", err)
+	}
+	metricsCollector := v1.NewPrometheusMetricsV1API(metricsClient, operatorConfig.MetricsMaxSamples)
+	coreV1 := clients.Kubernetes.CoreV1()
+
+	volumesCollector := collector.NewVolumesCollector(coreV1, metricsCollector, operatorConfig.VolumeInfoMetricStep)
+	volumeRepository := file.NewVolumesFileRepository(workPath, fs)
+
+	nodeInfoCollector := collector.NewNodeInfoCollector(
+		metricsCollector,
+		operatorConfig.NodeInfoUsageMetricStep,
+		operatorConfig.NodeInfoHardwareMetricStep,
+	)
+	nodeInfoRepository := file.NewNodeInfoFileRepository(workPath, fs)
+
+	nodeStatusCollector := collector.NewNodeStatusCollector(coreV1)
+	nodeStatusRepository := file.NewNodeStatusFileRepository(workPath, fs)
+
+	secretsCollector := collector.NewSecretCollector(coreV1)
+	secretRepository := file.NewSecretsFileRepository(workPath, fs)
+
+	systemStateCollector, err := collector.NewSystemStateCollector(clients.Generic, clients.Kubernetes.Discovery(), operatorConfig.SystemStateLabelSelectors, operatorConfig.SystemStateGvkExclusions)
+	if err != nil {
+		return nil, err
+	}
+	systemStateRepository := file.NewSystemStateFileRepository(workPath, fs)
+
+	helmReleaseCollector := collector.NewHelmReleaseCollector(coreV1)
+	helmReleaseRepository := file.NewHelmReleaseFileRepository(workPath, fs)
+
+	logProvider := loki.NewLokiLogsProvider(http.DefaultClient, operatorConfig)
+	eventsCollector := collector.NewEventsCollector(clients.Kubernetes.EventsV1(), logProvider)
+	eventsRepository := file.NewEventFileRepository(workPath, fs)
+
+	logCollector := collector.NewLogCollector(logProvider)
+	logRepository := file.NewLogFileRepository(workPath, fs)
+
+	mapping := make(usecase.CollectorMapping)
+	mapping[domain.CollectorTypeLog] = usecase.CollectorAndRepository{Collector: logCollector, Repository: logRepository}
+	mapping[domain.CollectorTypeVolumeInfo] = usecase.CollectorAndRepository{Collector: volumesCollector, Repository: volumeRepository}
+	mapping[domain.CollectorTypeNodeInfo] = usecase.CollectorAndRepository{Collector: nodeInfoCollector, Repository: nodeInfoRepository}
+	mapping[domain.CollectorTypeNodeStatus] = usecase.CollectorAndRepository{Collector: nodeStatusCollector, Repository: nodeStatusRepository}
+	mapping[domain.CollectorTypeSecret] = usecase.CollectorAndRepository{Collector: secretsCollector, Repository: secretRepository}
+	mapping[domain.CollectorTypeEvents] = usecase.CollectorAndRepository{Collector: eventsCollector, Repository: eventsRepository}
+	mapping[domain.CollectorTypeSystemState] = usecase.CollectorAndRepository{Collector: systemStateCollector, Repository: systemStateRepository}
+	mapping[domain.CollectorTypeHelmRelease] = usecase.CollectorAndRepository{Collector: helmReleaseCollector, Repository: helmReleaseRepository}
+
+	return mapping, nil
+}
+
+// NewPostProcessingRepositories creates the repositories for the results of the post-processing in the work directory.
+func NewPostProcessingRepositories(workPath string, fs filesystem.Filesystem) usecase.PostProcessingRepositories {
+	return usecase.PostProcessingRepositories{
+		CollectedDataReader: file.NewCollectedDataReader(workPath, fs),
+		Timeline:            file.NewTimelineFileRepository(workPath, fs),
+		Findings:            file.NewFindingsFileRepository(workPath, fs),
+		Summary:             file.NewSummaryFileRepository(workPath, fs),
+		Diff:                file.NewDiffFileRepository(workPath, fs),
+	}
+}
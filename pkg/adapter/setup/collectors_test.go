@@ -0,0 +1,70 @@
+package setup
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"k8s.io/client-go/kubernetes/fake"
+
+	"github.com/cloudogu/k8s-support-archive-operator/pkg/adapter/config"
+	"github.com/cloudogu/k8s-support-archive-operator/pkg/adapter/filesystem"
+	"github.com/cloudogu/k8s-support-archive-operator/pkg/domain"
+)
+
+// baseCollectorRepository contains the methods the archive creation asserts on every repository at runtime.
+type baseCollectorRepository interface {
+	Delete(ctx context.Context, id domain.SupportArchiveID) error
+	IsCollected(ctx context.Context, id domain.SupportArchiveID) (bool, error)
+	Skip(ctx context.Context, id domain.SupportArchiveID, reason string) error
+	IsSkipped(ctx context.Context, id domain.SupportArchiveID) (bool, string, error)
+	SetSpecHash(ctx context.Context, id domain.SupportArchiveID, hash string) error
+	GetSpecHash(ctx context.Context, id domain.SupportArchiveID) (string, error)
+	SetFailures(ctx context.Context, id domain.SupportArchiveID, failures int) error
+	GetFailures(ctx context.Context, id domain.SupportArchiveID) (int, error)
+	Stream(ctx context.Context, id domain.SupportArchiveID, stream *domain.Stream) error
+}
+
+func TestNewCollectorMapping(t *testing.T) {
+	t.Run("should create all collectors", func(t *testing.T) {
+		// given
+		clients := Clients{Kubernetes: fake.NewClientset()}
+
+		// when
+		mapping, err := NewCollectorMapping(clients, config.NewLocalConfig("ecosystem"), "http://localhost:9090", "/work", filesystem.FileSystem{})
+
+		// then
+		require.NoError(t, err)
+		assert.Len(t, mapping, 8)
+		for _, col := range []domain.CollectorType{
+			domain.CollectorTypeLog, domain.CollectorTypeVolumeInfo, domain.CollectorTypeNodeInfo, domain.CollectorTypeNodeStatus,
+			domain.CollectorTypeSecret, domain.CollectorTypeEvents, domain.CollectorTypeSystemState, domain.CollectorTypeHelmRelease,
+		} {
+			assert.NotNil(t, mapping[col].Collector, col)
+			assert.Implements(t, (*baseCollectorRepository)(nil), mapping[col].Repository, col)
+		}
+	})
+	t.Run("should return error on invalid label selectors", func(t *testing.T) {
+		// given
+		clients := Clients{Kubernetes: fake.NewClientset()}
+		operatorConfig := config.NewLocalConfig("ecosystem")
+		operatorConfig.SystemStateLabelSelectors = "- invalid"
+
+		// when
+		_, err := NewCollectorMapping(clients, operatorConfig, "http://localhost:9090", "/work", filesystem.FileSystem{})
+
+		// then
+		require.Error(t, err)
+	})
+}
+
+func TestNewPostProcessingRepositories(t *testing.T) {
+	repositories := NewPostProcessingRepositories("/work", filesystem.FileSystem{})
+
+	assert.NotNil(t, repositories.CollectedDataReader)
+	assert.NotNil(t, repositories.Timeline)
+	assert.NotNil(t, repositories.Findings)
+	assert.NotNil(t, repositories.Summary)
+	assert.NotNil(t, repositories.Diff)
+}
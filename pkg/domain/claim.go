@@ -0,0 +1,54 @@
+package domain
+
+import (
+	"encoding/json"
+	"fmt"
+	"time"
+)
+
+// ClaimAnnotation contains the replica of the operator that creates the archive as json. It is set by the operator,
+// so that replicas sharing the archive volume never write the same archive.
+const ClaimAnnotation = "k8s.cloudogu.com/support-archive-claim"
+
+// ArchiveClaim reserves the archive creation for a single replica until it expires.
+type ArchiveClaim struct {
+	// Holder is the name of the pod holding the claim.
+	Holder    string    `json:"holder"`
+	ExpiresAt time.Time `json:"expiresAt"`
+}
+
+// GetArchiveClaim returns the claim from the annotations. It returns nil if the archive was never claimed.
+func GetArchiveClaim(annotations map[string]string) (*ArchiveClaim, error) {
+	value, ok := annotations[ClaimAnnotation]
+	if !ok || value == "" {
+		return nil, nil
+	}
+
+	claim := &ArchiveClaim{}
+	err := json.Unmarshal([]byte(value), claim)
+	if err != nil {
+		return nil, fmt.Errorf("invalid annotation %s: %w", ClaimAnnotation, err)
+	}
+
+	return claim, nil
+}
+
+// IsHeldBy returns true if the holder holds the claim. It ignores the expiry.
+func (c *ArchiveClaim) IsHeldBy(holder string) bool {
+	return c != nil && c.Holder == holder
+}
+
+// IsExpired returns true if the claim can be taken over by another replica.
+func (c *ArchiveClaim) IsExpired(now time.Time) bool {
+	return c == nil || !now.Before(c.ExpiresAt)
+}
+
+// Annotation returns the value of the ClaimAnnotation for the claim.
+func (c *ArchiveClaim) Annotation() (string, error) {
+	value, err := json.Marshal(c)
+	if err != nil {
+		return "", fmt.Errorf("failed to marshal claim: %w", err)
+	}
+
+	return string(value), nil
+}
@@ -0,0 +1,50 @@
+package domain
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestGetArchiveClaim(t *testing.T) {
+	t.Run("should return nil without annotation", func(t *testing.T) {
+		claim, err := GetArchiveClaim(nil)
+
+		require.NoError(t, err)
+		assert.Nil(t, claim)
+	})
+	t.Run("should parse claim", func(t *testing.T) {
+		annotations := map[string]string{ClaimAnnotation: `{"holder":"operator-1","expiresAt":"2025-01-05T00:00:00Z"}`}
+
+		claim, err := GetArchiveClaim(annotations)
+
+		require.NoError(t, err)
+		assert.Equal(t, &ArchiveClaim{Holder: "operator-1", ExpiresAt: time.Date(2025, 1, 5, 0, 0, 0, 0, time.UTC)}, claim)
+	})
+	t.Run("should fail for invalid claim", func(t *testing.T) {
+		_, err := GetArchiveClaim(map[string]string{ClaimAnnotation: "invalid"})
+
+		assert.ErrorContains(t, err, "invalid annotation k8s.cloudogu.com/support-archive-claim")
+	})
+}
+
+func TestArchiveClaim(t *testing.T) {
+	expiresAt := time.Date(2025, 1, 5, 0, 0, 0, 0, time.UTC)
+	claim := &ArchiveClaim{Holder: "operator-1", ExpiresAt: expiresAt}
+
+	assert.True(t, claim.IsHeldBy("operator-1"))
+	assert.False(t, claim.IsHeldBy("operator-2"))
+	assert.False(t, (*ArchiveClaim)(nil).IsHeldBy("operator-1"))
+
+	assert.False(t, claim.IsExpired(expiresAt.Add(-time.Second)))
+	assert.True(t, claim.IsExpired(expiresAt))
+	assert.True(t, (*ArchiveClaim)(nil).IsExpired(expiresAt))
+
+	value, err := claim.Annotation()
+	require.NoError(t, err)
+	parsed, err := GetArchiveClaim(map[string]string{ClaimAnnotation: value})
+	require.NoError(t, err)
+	assert.Equal(t, claim, parsed)
+}
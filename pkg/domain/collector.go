@@ -11,6 +11,21 @@ const (
 	CollectorTypeSecret      CollectorType = "Resources/Secrets"
 	CollectorTypeSystemState CollectorType = "Resources/SystemState"
 	CollectorTypeEvents      CollectorType = "Events"
+	CollectorTypeNodeStatus  CollectorType = "NodeStatus"
+	CollectorTypeHelmRelease CollectorType = "Resources/HelmReleases"
+)
+
+// ArchiveErrorsDir is no collector. It is the directory in the archive containing the reasons for skipped collectors.
+const ArchiveErrorsDir CollectorType = "errors"
+
+// ArchiveRootDir is no collector. It is used for files in the root directory of the archive, e.g. the timeline.
+const ArchiveRootDir CollectorType = ""
+
+const (
+	// ConditionNodeStatusFetched is not part of the lib because the node status collector is specific to this operator.
+	ConditionNodeStatusFetched = "NodeStatusFetched"
+	// ConditionHelmReleasesFetched is not part of the lib because the helm release collector is specific to this operator.
+	ConditionHelmReleasesFetched = "HelmReleasesFetched"
 )
 
 func (c CollectorType) GetConditionType() string {
@@ -27,11 +42,15 @@ func (c CollectorType) GetConditionType() string {
 		return libapi.ConditionEventsFetched
 	case CollectorTypeSystemState:
 		return libapi.ConditionSystemStateFetched
+	case CollectorTypeNodeStatus:
+		return ConditionNodeStatusFetched
+	case CollectorTypeHelmRelease:
+		return ConditionHelmReleasesFetched
 	default:
 		return ""
 	}
 }
 
 type CollectorUnionDataType interface {
-	LogLine | VolumeInfo | LabeledSample | SecretYaml | UnstructuredResource
+	LogLine | VolumeInfo | LabeledSample | SecretYaml | UnstructuredResource | NodeStatus | HelmRelease | Event
 }
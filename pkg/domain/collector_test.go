@@ -30,6 +30,16 @@ func TestCollectorType_GetConditionType(t *testing.T) {
 			c:    "Events",
 			want: "EventsFetched",
 		},
+		{
+			name: "type node status",
+			c:    "NodeStatus",
+			want: "NodeStatusFetched",
+		},
+		{
+			name: "type helm releases",
+			c:    "Resources/HelmReleases",
+			want: "HelmReleasesFetched",
+		},
 		{
 			name: "anything else",
 			c:    "blablabla",
@@ -0,0 +1,121 @@
+package domain
+
+const (
+	// CompareToAnnotation references a support archive in the same namespace. If it is set, the archive contains a
+	// report of the differences to the referenced archive. The spec of the support archive is part of the lib, so the
+	// reference is kept in an annotation.
+	CompareToAnnotation = "k8s.cloudogu.com/support-archive-compare-to"
+)
+
+const (
+	// DiffMarkdownFileName is the name of the human-readable diff report in the root directory of the archive.
+	DiffMarkdownFileName = "diff.md"
+	// DiffJSONFileName is the name of the machine-readable diff report in the root directory of the archive.
+	DiffJSONFileName = "diff.json"
+)
+
+// GetCompareTo returns the archive referenced by the CompareToAnnotation. It returns nil if no other archive is
+// referenced.
+func GetCompareTo(id SupportArchiveID, annotations map[string]string) *SupportArchiveID {
+	name := annotations[CompareToAnnotation]
+	if name == "" || name == id.Name {
+		return nil
+	}
+
+	return &SupportArchiveID{Namespace: id.Namespace, Name: name}
+}
+
+// ArchiveContent contains the parts of an archive which are compared with another archive.
+type ArchiveContent struct {
+	Resources []ArchiveResource
+	Secrets   []ArchiveSecret
+	Volumes   []VolumeInfo
+	// NodeInfo contains the samples of all node metrics.
+	NodeInfo []LabeledSample
+}
+
+// ArchiveResource is a resource of the system state.
+type ArchiveResource struct {
+	// Path is the file of the resource relative to the root of the archive.
+	Path    string
+	Content map[string]any
+}
+
+// ArchiveSecret contains the keys of a secret. The values are censored in the archive.
+type ArchiveSecret struct {
+	// Path is the file of the secret relative to the root of the archive.
+	Path string
+	Keys []string
+}
+
+type DiffChange string
+
+const (
+	DiffChangeAdded   DiffChange = "added"
+	DiffChangeRemoved DiffChange = "removed"
+	DiffChangeChanged DiffChange = "changed"
+)
+
+// ArchiveDiff contains the differences of an archive to the archive it is compared with.
+type ArchiveDiff struct {
+	// Reference identifies the archive which the differences refer to, e.g. its name or file.
+	Reference string              `json:"reference"`
+	Resources []ResourceDiff      `json:"resources"`
+	Secrets   []SecretDiff        `json:"secrets"`
+	Volumes   []VolumeUsageDelta  `json:"volumes"`
+	Nodes     NodeCountDelta      `json:"nodes"`
+	Capacity  []NodeCapacityDelta `json:"capacity"`
+}
+
+// IsEmpty returns true if both archives contain the same compared data.
+func (d *ArchiveDiff) IsEmpty() bool {
+	return len(d.Resources) == 0 && len(d.Secrets) == 0 && len(d.Volumes) == 0 && len(d.Capacity) == 0 &&
+		d.Nodes.Before == d.Nodes.After
+}
+
+// ResourceDiff is a resource of the system state which was added, removed or changed.
+type ResourceDiff struct {
+	Path   string     `json:"path"`
+	Change DiffChange `json:"change"`
+	// Fields contains the changed fields of changed resources, e.g. spec.replicas. Lists are compared as a whole.
+	Fields []string `json:"fields,omitempty"`
+}
+
+// SecretDiff is a secret which was added, removed or whose keys changed. Values are not compared because they are
+// censored in the archive.
+type SecretDiff struct {
+	Path        string     `json:"path"`
+	Change      DiffChange `json:"change"`
+	AddedKeys   []string   `json:"addedKeys,omitempty"`
+	RemovedKeys []string   `json:"removedKeys,omitempty"`
+}
+
+// VolumeUsageDelta is a persistent volume claim whose latest usage or capacity changed.
+type VolumeUsageDelta struct {
+	Name           string     `json:"name"`
+	Change         DiffChange `json:"change"`
+	UsedBefore     int64      `json:"usedBefore"`
+	UsedAfter      int64      `json:"usedAfter"`
+	CapacityBefore int64      `json:"capacityBefore"`
+	CapacityAfter  int64      `json:"capacityAfter"`
+}
+
+// UsedDelta returns the change of the used bytes. It is negative if the usage decreased.
+func (d VolumeUsageDelta) UsedDelta() int64 {
+	return d.UsedAfter - d.UsedBefore
+}
+
+// NodeCountDelta contains the latest number of nodes of both archives.
+type NodeCountDelta struct {
+	Before int `json:"before"`
+	After  int `json:"after"`
+}
+
+// NodeCapacityDelta is a capacity metric of a node whose latest value changed, e.g. the number of cpu cores.
+type NodeCapacityDelta struct {
+	Node   string     `json:"node"`
+	Metric string     `json:"metric"`
+	Change DiffChange `json:"change"`
+	Before float64    `json:"before"`
+	After  float64    `json:"after"`
+}
@@ -0,0 +1,32 @@
+package domain
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestGetCompareTo(t *testing.T) {
+	id := SupportArchiveID{Namespace: "ecosystem", Name: "archive-2"}
+	tests := []struct {
+		name        string
+		annotations map[string]string
+		want        *SupportArchiveID
+	}{
+		{name: "no annotations", annotations: nil, want: nil},
+		{name: "empty reference", annotations: map[string]string{CompareToAnnotation: ""}, want: nil},
+		{name: "reference to itself", annotations: map[string]string{CompareToAnnotation: "archive-2"}, want: nil},
+		{name: "reference in same namespace", annotations: map[string]string{CompareToAnnotation: "archive-1"}, want: &SupportArchiveID{Namespace: "ecosystem", Name: "archive-1"}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.want, GetCompareTo(id, tt.annotations))
+		})
+	}
+}
+
+func TestArchiveDiff_IsEmpty(t *testing.T) {
+	assert.True(t, (&ArchiveDiff{Nodes: NodeCountDelta{Before: 2, After: 2}}).IsEmpty())
+	assert.False(t, (&ArchiveDiff{Nodes: NodeCountDelta{Before: 2, After: 3}}).IsEmpty())
+	assert.False(t, (&ArchiveDiff{Secrets: []SecretDiff{{Path: "Resources/Secrets/ldap.yaml"}}}).IsEmpty())
+}
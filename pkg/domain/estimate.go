@@ -0,0 +1,67 @@
+package domain
+
+import (
+	"fmt"
+	"strings"
+	"time"
+)
+
+// DryRunAnnotation marks a support archive as dry run if set to "true".
+// The spec of the support archive is part of the lib and has no field for it.
+// In a dry run, the collectors estimate the amount of data instead of collecting it and no archive is created.
+const DryRunAnnotation = "k8s.cloudogu.com/support-archive-dry-run"
+
+// IsDryRun returns true if the annotations mark the support archive as dry run.
+func IsDryRun(annotations map[string]string) bool {
+	return strings.EqualFold(annotations[DryRunAnnotation], "true")
+}
+
+// CollectorEstimate is the estimated amount of data a collector would collect for the content timeframe.
+type CollectorEstimate struct {
+	// Items is the number of collected items, e.g. log lines or samples.
+	Items int64
+	// Unit describes the items, e.g. "log lines".
+	Unit string
+	// Bytes is the estimated size of the collected data. It is 0 if the size is unknown.
+	Bytes int64
+	// Requests is the number of requests the collector would send. It indicates how long the collection takes.
+	Requests int
+}
+
+func (e CollectorEstimate) String() string {
+	builder := strings.Builder{}
+	builder.WriteString(fmt.Sprintf("about %d %s", e.Items, e.Unit))
+	if e.Bytes > 0 {
+		builder.WriteString(fmt.Sprintf(" (%s)", FormatBytes(e.Bytes)))
+	}
+	builder.WriteString(fmt.Sprintf(" in %d requests", e.Requests))
+
+	return builder.String()
+}
+
+// SamplesPerSeries returns the number of samples of a range query from start to end with the given step.
+func SamplesPerSeries(start, end time.Time, step time.Duration) int64 {
+	if step <= 0 || end.Before(start) {
+		return 0
+	}
+
+	return int64(end.Sub(start)/step) + 1
+}
+
+// FormatBytes formats the bytes with binary prefixes, e.g. 1.5 KiB.
+func FormatBytes(bytes int64) string {
+	const unit = 1024
+	if bytes < unit {
+		return fmt.Sprintf("%d B", bytes)
+	}
+
+	value := float64(bytes)
+	units := []string{"KiB", "MiB", "GiB", "TiB", "PiB"}
+	i := -1
+	for value >= unit && i < len(units)-1 {
+		value /= unit
+		i++
+	}
+
+	return fmt.Sprintf("%.1f %s", value, units[i])
+}
@@ -0,0 +1,35 @@
+package domain
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestIsDryRun(t *testing.T) {
+	assert.True(t, IsDryRun(map[string]string{DryRunAnnotation: "true"}))
+	assert.True(t, IsDryRun(map[string]string{DryRunAnnotation: "True"}))
+	assert.False(t, IsDryRun(map[string]string{DryRunAnnotation: "false"}))
+	assert.False(t, IsDryRun(nil))
+}
+
+func TestCollectorEstimate_String(t *testing.T) {
+	assert.Equal(t, "about 1200 log lines (1.5 KiB) in 3 requests", CollectorEstimate{Items: 1200, Unit: "log lines", Bytes: 1536, Requests: 3}.String())
+	assert.Equal(t, "about 42 resources in 7 requests", CollectorEstimate{Items: 42, Unit: "resources", Requests: 7}.String())
+}
+
+func TestSamplesPerSeries(t *testing.T) {
+	start := time.Date(2025, 1, 1, 0, 0, 0, 0, time.UTC)
+
+	assert.Equal(t, int64(25), SamplesPerSeries(start, start.Add(24*time.Hour), time.Hour))
+	assert.Equal(t, int64(1), SamplesPerSeries(start, start, time.Hour))
+	assert.Equal(t, int64(0), SamplesPerSeries(start, start.Add(-time.Hour), time.Hour))
+	assert.Equal(t, int64(0), SamplesPerSeries(start, start.Add(time.Hour), 0))
+}
+
+func TestFormatBytes(t *testing.T) {
+	assert.Equal(t, "512 B", FormatBytes(512))
+	assert.Equal(t, "1.5 KiB", FormatBytes(1536))
+	assert.Equal(t, "3.0 MiB", FormatBytes(3*1024*1024))
+}
@@ -0,0 +1,43 @@
+package domain
+
+import (
+	"fmt"
+	"time"
+)
+
+const (
+	EventSourceKubernetesAPI = "KubernetesAPI"
+	EventSourceLoki          = "Loki"
+)
+
+// Event is a deduplicated kubernetes event.
+// All occurrences of the same event are summarized by their count and their first and last timestamps.
+type Event struct {
+	Namespace           string      `yaml:"namespace"`
+	InvolvedObject      EventObject `yaml:"involvedObject"`
+	Reason              string      `yaml:"reason,omitempty"`
+	Type                string      `yaml:"type,omitempty"`
+	Note                string      `yaml:"note,omitempty"`
+	ReportingController string      `yaml:"reportingController,omitempty"`
+	Count               int         `yaml:"count"`
+	FirstTimestamp      time.Time   `yaml:"firstTimestamp"`
+	LastTimestamp       time.Time   `yaml:"lastTimestamp"`
+	// Sources contains where the event was found, e.g. in the Kubernetes API or in the event history of Loki.
+	Sources []string `yaml:"sources"`
+}
+
+// EventObject references the object an event is about.
+type EventObject struct {
+	Kind      string `yaml:"kind,omitempty"`
+	Namespace string `yaml:"namespace,omitempty"`
+	Name      string `yaml:"name,omitempty"`
+}
+
+func (o EventObject) String() string {
+	return fmt.Sprintf("%s/%s", o.Kind, o.Name)
+}
+
+// Key identifies occurrences of the same event.
+func (e Event) Key() string {
+	return fmt.Sprintf("%s|%s|%s|%s|%s|%s", e.InvolvedObject.Kind, e.InvolvedObject.Namespace, e.InvolvedObject.Name, e.Reason, e.Type, e.Note)
+}
@@ -0,0 +1,29 @@
+package domain
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestEventObject_String(t *testing.T) {
+	assert.Equal(t, "Pod/ldap-0", EventObject{Kind: "Pod", Namespace: "ecosystem", Name: "ldap-0"}.String())
+}
+
+func TestEvent_Key(t *testing.T) {
+	event := Event{InvolvedObject: EventObject{Kind: "Pod", Namespace: "ecosystem", Name: "ldap-0"}, Reason: "BackOff", Type: "Warning", Note: "Back-off"}
+
+	t.Run("should ignore count, timestamps and sources", func(t *testing.T) {
+		other := event
+		other.Count = 3
+		other.Sources = []string{EventSourceLoki}
+
+		assert.Equal(t, event.Key(), other.Key())
+	})
+	t.Run("should differ for other reason", func(t *testing.T) {
+		other := event
+		other.Reason = "Pulled"
+
+		assert.NotEqual(t, event.Key(), other.Key())
+	})
+}
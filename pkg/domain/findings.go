@@ -0,0 +1,89 @@
+package domain
+
+import (
+	"sort"
+	"time"
+)
+
+const (
+	// FindingsMarkdownFileName is the name of the human-readable findings report in the root directory of the archive.
+	FindingsMarkdownFileName = "findings.md"
+	// FindingsJSONFileName is the name of the machine-readable findings report in the root directory of the archive.
+	FindingsJSONFileName = "findings.json"
+)
+
+type FindingSeverity string
+
+const (
+	FindingSeverityInfo     FindingSeverity = "Info"
+	FindingSeverityWarning  FindingSeverity = "Warning"
+	FindingSeverityCritical FindingSeverity = "Critical"
+)
+
+// Rank orders the severities from info (0) to critical (2).
+func (s FindingSeverity) Rank() int {
+	switch s {
+	case FindingSeverityCritical:
+		return 2
+	case FindingSeverityWarning:
+		return 1
+	default:
+		return 0
+	}
+}
+
+// Finding is the result of a rule that detected a potential problem in the collected data.
+type Finding struct {
+	Rule     string          `json:"rule"`
+	Severity FindingSeverity `json:"severity"`
+	// Object references the affected object, e.g. Pod/ldap-0 or a node name.
+	Object  string `json:"object,omitempty"`
+	Message string `json:"message"`
+}
+
+// SortFindings sorts the findings by descending severity, rule and object.
+func SortFindings(findings []Finding) {
+	sort.SliceStable(findings, func(i, j int) bool {
+		if findings[i].Severity != findings[j].Severity {
+			return findings[i].Severity.Rank() > findings[j].Severity.Rank()
+		}
+		if findings[i].Rule != findings[j].Rule {
+			return findings[i].Rule < findings[j].Rule
+		}
+		return findings[i].Object < findings[j].Object
+	})
+}
+
+// CollectedData contains the collected data of an archive which is needed to analyze it.
+// Data of collectors that were excluded or skipped is empty.
+// Large data is aggregated while reading, so that its size does not grow with the length of the content timeframe.
+type CollectedData struct {
+	// Start and End define the content timeframe of the archive.
+	Start   time.Time
+	End     time.Time
+	Volumes []VolumeInfo
+	// NodeInfo contains the samples of all node metrics. The samples of long timeframes are reduced to the minimum and
+	// maximum per period, so that peaks are kept.
+	NodeInfo  []LabeledSample
+	Resources []ResourceStatus
+	// LogTimeframe is the content timeframe of the logs. It differs from Start and End if the logs have a
+	// collector-specific timeframe.
+	LogTimeframe Timeframe
+	// ErrorLogsPerMinute contains the number of error log lines per minute of the LogTimeframe.
+	ErrorLogsPerMinute []int
+	// Events contains the warning events.
+	Events []*Event
+}
+
+// AddErrorLog counts an error log line. Log lines outside the LogTimeframe are ignored.
+func (d *CollectedData) AddErrorLog(timestamp time.Time) {
+	if timestamp.Before(d.LogTimeframe.Start) || !timestamp.Before(d.LogTimeframe.End) {
+		return
+	}
+
+	minute := int(timestamp.Sub(d.LogTimeframe.Start) / time.Minute)
+	if minute >= len(d.ErrorLogsPerMinute) {
+		d.ErrorLogsPerMinute = append(d.ErrorLogsPerMinute, make([]int, minute+1-len(d.ErrorLogsPerMinute))...)
+	}
+	d.ErrorLogsPerMinute[minute]++
+}
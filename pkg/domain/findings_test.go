@@ -0,0 +1,21 @@
+package domain
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestCollectedData_AddErrorLog(t *testing.T) {
+	start := time.Date(2025, 1, 1, 0, 0, 0, 0, time.UTC)
+	data := &CollectedData{LogTimeframe: Timeframe{Start: start, End: start.Add(time.Hour)}}
+
+	data.AddErrorLog(start)
+	data.AddErrorLog(start.Add(59 * time.Second))
+	data.AddErrorLog(start.Add(3 * time.Minute))
+	data.AddErrorLog(start.Add(-time.Second))
+	data.AddErrorLog(start.Add(time.Hour))
+
+	assert.Equal(t, []int{2, 0, 0, 1}, data.ErrorLogsPerMinute)
+}
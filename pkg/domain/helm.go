@@ -0,0 +1,28 @@
+package domain
+
+// HelmRelease contains the decoded information of the latest revision of a Helm release.
+type HelmRelease struct {
+	Name         string                `yaml:"name"`
+	Namespace    string                `yaml:"namespace"`
+	Chart        string                `yaml:"chart"`
+	ChartVersion string                `yaml:"chartVersion"`
+	AppVersion   string                `yaml:"appVersion,omitempty"`
+	Status       string                `yaml:"status"`
+	Revision     int                   `yaml:"revision"`
+	Updated      string                `yaml:"updated,omitempty"`
+	Description  string                `yaml:"description,omitempty"`
+	History      []HelmReleaseRevision `yaml:"history,omitempty"`
+	// Values contains the computed values of the release, i.e. the chart values merged with the user supplied values.
+	// Sensitive values are censored.
+	Values map[string]any `yaml:"values,omitempty"`
+}
+
+// HelmReleaseRevision summarizes a single revision of a Helm release.
+type HelmReleaseRevision struct {
+	Revision     int    `yaml:"revision"`
+	Status       string `yaml:"status"`
+	ChartVersion string `yaml:"chartVersion"`
+	AppVersion   string `yaml:"appVersion,omitempty"`
+	Updated      string `yaml:"updated,omitempty"`
+	Description  string `yaml:"description,omitempty"`
+}
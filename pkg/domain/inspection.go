@@ -0,0 +1,105 @@
+package domain
+
+import (
+	"slices"
+	"strings"
+	"time"
+)
+
+// ArchiveLogLine is a parsed line of the log file of an archive. Fields missing in the line are empty.
+type ArchiveLogLine struct {
+	Time      time.Time
+	Namespace string
+	Pod       string
+	Container string
+	Level     string
+	Message   string
+}
+
+// LogFilter selects log lines of an archive. Empty fields match every line.
+type LogFilter struct {
+	// Pod matches all pods starting with the given name, e.g. "ldap" matches "ldap-0".
+	Pod   string
+	Level string
+	Since time.Time
+	Until time.Time
+}
+
+func (f LogFilter) Matches(line ArchiveLogLine) bool {
+	if f.Pod != "" && !strings.HasPrefix(line.Pod, f.Pod) {
+		return false
+	}
+	if f.Level != "" && !strings.EqualFold(f.Level, line.Level) {
+		return false
+	}
+	if !f.Since.IsZero() && line.Time.Before(f.Since) {
+		return false
+	}
+	if !f.Until.IsZero() && line.Time.After(f.Until) {
+		return false
+	}
+
+	return true
+}
+
+// MetricSummary aggregates all samples of a metric for one ID, e.g. a node.
+type MetricSummary struct {
+	MetricName string
+	ID         string
+	Count      int
+	Min        float64
+	Max        float64
+	Avg        float64
+	Last       float64
+	LastTime   time.Time
+}
+
+// SummarizeSamples returns one summary per metric and ID, sorted by metric name and ID.
+func SummarizeSamples(samples []LabeledSample) []MetricSummary {
+	summaries := make(map[[2]string]*MetricSummary)
+	sums := make(map[[2]string]float64)
+	for _, sample := range samples {
+		key := [2]string{sample.MetricName, sample.ID}
+		summary, ok := summaries[key]
+		if !ok {
+			summary = &MetricSummary{MetricName: sample.MetricName, ID: sample.ID, Min: sample.Value, Max: sample.Value}
+			summaries[key] = summary
+		}
+		summary.Count++
+		summary.Min = min(summary.Min, sample.Value)
+		summary.Max = max(summary.Max, sample.Value)
+		sums[key] += sample.Value
+		if !sample.Time.Before(summary.LastTime) {
+			summary.Last = sample.Value
+			summary.LastTime = sample.Time
+		}
+	}
+
+	result := make([]MetricSummary, 0, len(summaries))
+	for key, summary := range summaries {
+		summary.Avg = sums[key] / float64(summary.Count)
+		result = append(result, *summary)
+	}
+	slices.SortFunc(result, func(a, b MetricSummary) int {
+		if c := strings.Compare(a.MetricName, b.MetricName); c != 0 {
+			return c
+		}
+		return strings.Compare(a.ID, b.ID)
+	})
+
+	return result
+}
+
+// ManifestVerification is the result of comparing the files of an archive with its manifest.
+type ManifestVerification struct {
+	// Mismatched contains files whose size or checksum differ from the manifest.
+	Mismatched []string
+	// Missing contains files listed in the manifest but not contained in the archive.
+	Missing []string
+	// Unlisted contains files contained in the archive but not listed in the manifest.
+	Unlisted []string
+}
+
+func (v ManifestVerification) Valid() bool {
+	return len(v.Mismatched) == 0 && len(v.Missing) == 0 && len(v.Unlisted) == 0
+}
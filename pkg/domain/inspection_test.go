@@ -0,0 +1,46 @@
+package domain
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestLogFilter_Matches(t *testing.T) {
+	start := time.Date(2025, 1, 1, 0, 0, 0, 0, time.UTC)
+	line := ArchiveLogLine{Time: start, Pod: "ldap-0", Level: "error"}
+
+	assert.True(t, LogFilter{}.Matches(line))
+	assert.True(t, LogFilter{Pod: "ldap", Level: "ERROR", Since: start, Until: start}.Matches(line))
+	assert.False(t, LogFilter{Pod: "cas"}.Matches(line))
+	assert.False(t, LogFilter{Level: "warn"}.Matches(line))
+	assert.False(t, LogFilter{Since: start.Add(time.Second)}.Matches(line))
+	assert.False(t, LogFilter{Until: start.Add(-time.Second)}.Matches(line))
+}
+
+func TestSummarizeSamples(t *testing.T) {
+	// given
+	start := time.Date(2025, 1, 1, 0, 0, 0, 0, time.UTC)
+	samples := []LabeledSample{
+		{MetricName: "ramUsedRelative", ID: "node-2", Value: 10, Time: start},
+		{MetricName: "ramUsedRelative", ID: "node-1", Value: 30, Time: start.Add(time.Minute)},
+		{MetricName: "ramUsedRelative", ID: "node-1", Value: 50, Time: start},
+		{MetricName: "cpuUsageRelative", ID: "node-1", Value: 1, Time: start},
+	}
+
+	// when
+	summaries := SummarizeSamples(samples)
+
+	// then
+	assert.Equal(t, []MetricSummary{
+		{MetricName: "cpuUsageRelative", ID: "node-1", Count: 1, Min: 1, Max: 1, Avg: 1, Last: 1, LastTime: start},
+		{MetricName: "ramUsedRelative", ID: "node-1", Count: 2, Min: 30, Max: 50, Avg: 40, Last: 30, LastTime: start.Add(time.Minute)},
+		{MetricName: "ramUsedRelative", ID: "node-2", Count: 1, Min: 10, Max: 10, Avg: 10, Last: 10, LastTime: start},
+	}, summaries)
+}
+
+func TestManifestVerification_Valid(t *testing.T) {
+	assert.True(t, ManifestVerification{}.Valid())
+	assert.False(t, ManifestVerification{Missing: []string{"Logs/logs.log"}}.Valid())
+}
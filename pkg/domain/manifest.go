@@ -0,0 +1,32 @@
+package domain
+
+import (
+	"sort"
+	"time"
+)
+
+// ManifestFileName is the name of the manifest in the root directory of the archive.
+const ManifestFileName = "manifest.json"
+
+// ArchiveManifest lists all files of an archive with their checksums. The manifest itself is not listed.
+// CreatedAt is the end of the content timeframe, so that identical data results in an identical manifest.
+type ArchiveManifest struct {
+	Namespace string         `json:"namespace"`
+	Name      string         `json:"name"`
+	CreatedAt time.Time      `json:"createdAt"`
+	Files     []ManifestFile `json:"files"`
+}
+
+type ManifestFile struct {
+	// Path is the slash separated path of the file in the archive.
+	Path   string `json:"path"`
+	Size   int64  `json:"size"`
+	SHA256 string `json:"sha256"`
+}
+
+// SortFiles sorts the files by path.
+func (m *ArchiveManifest) SortFiles() {
+	sort.Slice(m.Files, func(i, j int) bool {
+		return m.Files[i].Path < m.Files[j].Path
+	})
+}
@@ -12,11 +12,41 @@ type VolumeInfo struct {
 }
 
 type VolumeInfoItem struct {
-	Name            string `yaml:"name"`
-	Capacity        int64  `yaml:"capacity"`
-	Used            int64  `yaml:"used"`
-	PercentageUsage string `yaml:"percentageUsage"`
-	Phase           string `yaml:"phase"`
+	Name            string   `yaml:"name"`
+	Capacity        int64    `yaml:"capacity"`
+	Used            int64    `yaml:"used"`
+	PercentageUsage string   `yaml:"percentageUsage"`
+	InodesUsed      int64    `yaml:"inodesUsed"`
+	Phase           string   `yaml:"phase"`
+	StorageClass    string   `yaml:"storageClass,omitempty"`
+	AccessModes     []string `yaml:"accessModes,omitempty"`
+	// ThresholdCrossings contains the points in time the usage of the volume exceeded a threshold.
+	ThresholdCrossings []VolumeThresholdCrossing `yaml:"thresholdCrossings,omitempty"`
+	// Usage contains the usage of the volume over the whole content timeframe.
+	Usage []VolumeUsageSample `yaml:"usage,omitempty"`
+}
+
+type VolumeUsageSample struct {
+	Time       time.Time `yaml:"time"`
+	Capacity   int64     `yaml:"capacity"`
+	Used       int64     `yaml:"used"`
+	InodesUsed int64     `yaml:"inodesUsed"`
+}
+
+// PercentageUsage returns the used bytes relative to the capacity in percent.
+// It returns 0 if the capacity is unknown.
+func (s VolumeUsageSample) PercentageUsage() float64 {
+	if s.Capacity == 0 {
+		return 0
+	}
+
+	return float64(s.Used) / float64(s.Capacity) * 100
+}
+
+type VolumeThresholdCrossing struct {
+	// ThresholdPercent is the crossed usage threshold in percent.
+	ThresholdPercent int       `yaml:"thresholdPercent"`
+	Time             time.Time `yaml:"time"`
 }
 
 type LabeledSample struct {
@@ -60,3 +60,14 @@ func TestLabeledSample_GetRow(t *testing.T) {
 		})
 	}
 }
+
+func TestVolumeUsageSample_PercentageUsage(t *testing.T) {
+	t.Run("should return usage relative to capacity", func(t *testing.T) {
+		sut := VolumeUsageSample{Capacity: 200, Used: 50}
+		assert.Equal(t, float64(25), sut.PercentageUsage())
+	})
+	t.Run("should return 0 on unknown capacity", func(t *testing.T) {
+		sut := VolumeUsageSample{Used: 50}
+		assert.Equal(t, float64(0), sut.PercentageUsage())
+	})
+}
@@ -0,0 +1,41 @@
+package domain
+
+import "time"
+
+// NodeStatus contains the health and version information of a node read from the Kubernetes API.
+type NodeStatus struct {
+	Name                    string                `yaml:"name"`
+	KubeletVersion          string                `yaml:"kubeletVersion"`
+	KernelVersion           string                `yaml:"kernelVersion"`
+	ContainerRuntimeVersion string                `yaml:"containerRuntimeVersion"`
+	OSImage                 string                `yaml:"osImage,omitempty"`
+	Unschedulable           bool                  `yaml:"unschedulable"`
+	Conditions              []NodeStatusCondition `yaml:"conditions,omitempty"`
+	Taints                  []NodeTaint           `yaml:"taints,omitempty"`
+	Capacity                map[string]string     `yaml:"capacity,omitempty"`
+	Allocatable             map[string]string     `yaml:"allocatable,omitempty"`
+	// PodCount and Pods only contain the pods of the namespace of the support archive.
+	PodCount int       `yaml:"podCount"`
+	Pods     []NodePod `yaml:"pods,omitempty"`
+}
+
+type NodeStatusCondition struct {
+	Type               string    `yaml:"type"`
+	Status             string    `yaml:"status"`
+	Reason             string    `yaml:"reason,omitempty"`
+	Message            string    `yaml:"message,omitempty"`
+	LastHeartbeatTime  time.Time `yaml:"lastHeartbeatTime"`
+	LastTransitionTime time.Time `yaml:"lastTransitionTime"`
+}
+
+type NodeTaint struct {
+	Key    string `yaml:"key"`
+	Value  string `yaml:"value,omitempty"`
+	Effect string `yaml:"effect"`
+}
+
+type NodePod struct {
+	Namespace string `yaml:"namespace"`
+	Name      string `yaml:"name"`
+	Phase     string `yaml:"phase"`
+}
@@ -0,0 +1,162 @@
+package domain
+
+import (
+	"fmt"
+	"slices"
+	"strings"
+	"time"
+
+	libapi "github.com/cloudogu/k8s-support-archive-lib/api/v1"
+	"k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+// ArchivePhase summarizes the state of a support archive in a single value.
+type ArchivePhase string
+
+const (
+	ArchivePhasePending    ArchivePhase = "Pending"
+	ArchivePhaseCollecting ArchivePhase = "Collecting"
+	ArchivePhasePackaging  ArchivePhase = "Packaging"
+	ArchivePhaseSucceeded  ArchivePhase = "Succeeded"
+	ArchivePhaseFailed     ArchivePhase = "Failed"
+	// ArchivePhaseEstimated is the final phase of a dry run. The estimates are reported as collector conditions.
+	// The phase is left if the dry run annotation is removed.
+	ArchivePhaseEstimated ArchivePhase = "Estimated"
+)
+
+// The status of the support archive has no fields for the phase and timestamps.
+// Therefore, they are reported as conditions like the pod does it with its lifecycle conditions.
+const (
+	// ConditionPhase contains the current phase as reason, e.g. to be used in printer columns
+	// with the JSONPath .status.conditions[?(@.type=="Phase")].reason.
+	ConditionPhase = "Phase"
+	// ConditionCollectionStarted has the start of the archive creation as last transition time.
+	ConditionCollectionStarted = "CollectionStarted"
+	// ConditionCollectionFinished has the end of the archive creation as last transition time.
+	// It is set if the phase is final.
+	ConditionCollectionFinished = "CollectionFinished"
+	// ConditionObservedAnnotations contains the values of the ReconcileAnnotations the phase was set with as message.
+	// Changes of annotations do not change the generation, so they are recorded like the observed generation.
+	ConditionObservedAnnotations = "ObservedAnnotations"
+)
+
+// ReconcileAnnotations change the support archive like changes of the spec because the spec is part of the lib and
+// has no fields for them.
+var ReconcileAnnotations = []string{RefreshAnnotation, DryRunAnnotation, LastAnnotation, CollectorTimeframesAnnotation}
+
+// IsFinal returns true if the phase will not change anymore.
+func (p ArchivePhase) IsFinal() bool {
+	return p == ArchivePhaseSucceeded || p == ArchivePhaseFailed || p == ArchivePhaseEstimated
+}
+
+// GetArchivePhase returns the phase from the status conditions. It is pending if no phase was set yet.
+func GetArchivePhase(status libapi.SupportArchiveStatus) ArchivePhase {
+	condition := meta.FindStatusCondition(status.Conditions, ConditionPhase)
+	if condition == nil {
+		return ArchivePhasePending
+	}
+
+	return ArchivePhase(condition.Reason)
+}
+
+// IsPhaseOutdated returns true if the generation or one of the ReconcileAnnotations changed since the phase was set,
+// e.g. because the user fixed the spec of a failed archive. Annotations are only compared if they were recorded.
+func IsPhaseOutdated(status libapi.SupportArchiveStatus, generation int64, annotations map[string]string) bool {
+	phase := meta.FindStatusCondition(status.Conditions, ConditionPhase)
+	if phase == nil {
+		return false
+	}
+	if phase.ObservedGeneration != generation {
+		return true
+	}
+
+	observed := meta.FindStatusCondition(status.Conditions, ConditionObservedAnnotations)
+	return observed != nil && observed.Message != observedAnnotationsMessage(annotations)
+}
+
+// SetObservedAnnotations records the values of the ReconcileAnnotations, see IsPhaseOutdated.
+func SetObservedAnnotations(status *libapi.SupportArchiveStatus, generation int64, annotations map[string]string) {
+	meta.SetStatusCondition(&status.Conditions, metav1.Condition{
+		Type:               ConditionObservedAnnotations,
+		Status:             metav1.ConditionTrue,
+		ObservedGeneration: generation,
+		LastTransitionTime: metav1.Now(),
+		Reason:             "Observed",
+		Message:            observedAnnotationsMessage(annotations),
+	})
+}
+
+func observedAnnotationsMessage(annotations map[string]string) string {
+	var observed []string
+	for _, annotation := range slices.Sorted(slices.Values(ReconcileAnnotations)) {
+		if value, ok := annotations[annotation]; ok {
+			observed = append(observed, fmt.Sprintf("%s=%s", annotation, value))
+		}
+	}
+	if len(observed) == 0 {
+		return "No annotations observed"
+	}
+
+	return strings.Join(observed, ", ")
+}
+
+// GetStartTime returns the start of the archive creation or the fallback if the creation has not started yet.
+func GetStartTime(status libapi.SupportArchiveStatus, fallback time.Time) time.Time {
+	condition := meta.FindStatusCondition(status.Conditions, ConditionCollectionStarted)
+	if condition == nil {
+		return fallback
+	}
+
+	return condition.LastTransitionTime.Time
+}
+
+// SetArchivePhase sets the phase together with the start and, for final phases, the finish time of the archive creation.
+// An already existing start time is not overwritten.
+func SetArchivePhase(status *libapi.SupportArchiveStatus, phase ArchivePhase, generation int64, startTime time.Time) {
+	meta.SetStatusCondition(&status.Conditions, metav1.Condition{
+		Type:               ConditionCollectionStarted,
+		Status:             metav1.ConditionTrue,
+		ObservedGeneration: generation,
+		LastTransitionTime: metav1.NewTime(startTime),
+		Reason:             "CollectionStarted",
+		Message:            fmt.Sprintf("Started creating the support archive at %s", startTime.Format(time.RFC3339)),
+	})
+
+	meta.SetStatusCondition(&status.Conditions, metav1.Condition{
+		Type:               ConditionPhase,
+		Status:             metav1.ConditionTrue,
+		ObservedGeneration: generation,
+		LastTransitionTime: metav1.Now(),
+		Reason:             string(phase),
+		Message:            fmt.Sprintf("The support archive is in phase %s", phase),
+	})
+
+	if phase.IsFinal() {
+		finishTime := time.Now()
+		meta.SetStatusCondition(&status.Conditions, metav1.Condition{
+			Type:               ConditionCollectionFinished,
+			Status:             metav1.ConditionTrue,
+			ObservedGeneration: generation,
+			LastTransitionTime: metav1.NewTime(finishTime),
+			Reason:             string(phase),
+			Message:            fmt.Sprintf("Finished creating the support archive at %s", finishTime.Format(time.RFC3339)),
+		})
+	}
+}
+
+// ResetArchivePhase sets the phase back to pending and removes the start and finish time, so that the archive creation
+// starts again, e.g. to rebuild an archive after a change of the spec.
+func ResetArchivePhase(status *libapi.SupportArchiveStatus, generation int64) {
+	meta.RemoveStatusCondition(&status.Conditions, ConditionCollectionStarted)
+	meta.RemoveStatusCondition(&status.Conditions, ConditionCollectionFinished)
+	meta.RemoveStatusCondition(&status.Conditions, ConditionObservedAnnotations)
+	meta.SetStatusCondition(&status.Conditions, metav1.Condition{
+		Type:               ConditionPhase,
+		Status:             metav1.ConditionTrue,
+		ObservedGeneration: generation,
+		LastTransitionTime: metav1.Now(),
+		Reason:             string(ArchivePhasePending),
+		Message:            fmt.Sprintf("The support archive is in phase %s", ArchivePhasePending),
+	})
+}
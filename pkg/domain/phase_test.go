@@ -0,0 +1,123 @@
+package domain
+
+import (
+	"testing"
+	"time"
+
+	libapi "github.com/cloudogu/k8s-support-archive-lib/api/v1"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+func TestGetArchivePhase(t *testing.T) {
+	t.Run("should return pending without phase condition", func(t *testing.T) {
+		assert.Equal(t, ArchivePhasePending, GetArchivePhase(libapi.SupportArchiveStatus{}))
+	})
+	t.Run("should return phase from condition", func(t *testing.T) {
+		status := libapi.SupportArchiveStatus{Conditions: []metav1.Condition{{Type: ConditionPhase, Reason: string(ArchivePhasePackaging)}}}
+		assert.Equal(t, ArchivePhasePackaging, GetArchivePhase(status))
+	})
+}
+
+func TestGetStartTime(t *testing.T) {
+	fallback := time.Date(2025, 1, 1, 0, 0, 0, 0, time.UTC)
+	t.Run("should return fallback if not started", func(t *testing.T) {
+		assert.Equal(t, fallback, GetStartTime(libapi.SupportArchiveStatus{}, fallback))
+	})
+	t.Run("should return time of started condition", func(t *testing.T) {
+		startTime := fallback.Add(-time.Hour)
+		status := libapi.SupportArchiveStatus{Conditions: []metav1.Condition{{Type: ConditionCollectionStarted, LastTransitionTime: metav1.NewTime(startTime)}}}
+		assert.Equal(t, startTime, GetStartTime(status, fallback))
+	})
+}
+
+func TestSetArchivePhase(t *testing.T) {
+	startTime := time.Now().Add(-time.Hour).Truncate(time.Second)
+
+	t.Run("should set phase and start time", func(t *testing.T) {
+		status := &libapi.SupportArchiveStatus{}
+
+		SetArchivePhase(status, ArchivePhaseCollecting, 2, startTime)
+
+		assert.Equal(t, ArchivePhaseCollecting, GetArchivePhase(*status))
+		assert.Equal(t, startTime, GetStartTime(*status, time.Now()))
+		phase := meta.FindStatusCondition(status.Conditions, ConditionPhase)
+		require.NotNil(t, phase)
+		assert.Equal(t, int64(2), phase.ObservedGeneration)
+		assert.Nil(t, meta.FindStatusCondition(status.Conditions, ConditionCollectionFinished))
+	})
+	t.Run("should keep start time and set finish time for final phase", func(t *testing.T) {
+		status := &libapi.SupportArchiveStatus{}
+		SetArchivePhase(status, ArchivePhaseCollecting, 1, startTime)
+
+		SetArchivePhase(status, ArchivePhaseSucceeded, 1, time.Now())
+
+		assert.Equal(t, ArchivePhaseSucceeded, GetArchivePhase(*status))
+		assert.Equal(t, startTime, GetStartTime(*status, time.Now()))
+		finished := meta.FindStatusCondition(status.Conditions, ConditionCollectionFinished)
+		require.NotNil(t, finished)
+		assert.Equal(t, string(ArchivePhaseSucceeded), finished.Reason)
+	})
+}
+
+func TestResetArchivePhase(t *testing.T) {
+	status := &libapi.SupportArchiveStatus{}
+	SetArchivePhase(status, ArchivePhaseSucceeded, 1, time.Now().Add(-time.Hour))
+	now := time.Now()
+
+	ResetArchivePhase(status, 2)
+
+	assert.Equal(t, ArchivePhasePending, GetArchivePhase(*status))
+	assert.Equal(t, now, GetStartTime(*status, now))
+	assert.Nil(t, meta.FindStatusCondition(status.Conditions, ConditionCollectionFinished))
+	assert.Equal(t, int64(2), meta.FindStatusCondition(status.Conditions, ConditionPhase).ObservedGeneration)
+}
+
+func TestIsPhaseOutdated(t *testing.T) {
+	annotations := map[string]string{LastAnnotation: "2h", "other": "value"}
+	status := libapi.SupportArchiveStatus{}
+	SetArchivePhase(&status, ArchivePhaseFailed, 1, time.Now())
+	SetObservedAnnotations(&status, 1, annotations)
+
+	assert.False(t, IsPhaseOutdated(status, 1, annotations))
+	assert.False(t, IsPhaseOutdated(status, 1, map[string]string{LastAnnotation: "2h"}))
+	assert.True(t, IsPhaseOutdated(status, 2, annotations))
+	assert.True(t, IsPhaseOutdated(status, 1, map[string]string{LastAnnotation: "4h"}))
+	assert.True(t, IsPhaseOutdated(status, 1, nil))
+	assert.False(t, IsPhaseOutdated(libapi.SupportArchiveStatus{}, 1, nil))
+
+	t.Run("should compare only generation if no annotations were observed", func(t *testing.T) {
+		withoutAnnotations := libapi.SupportArchiveStatus{}
+		SetArchivePhase(&withoutAnnotations, ArchivePhaseFailed, 1, time.Now())
+
+		assert.False(t, IsPhaseOutdated(withoutAnnotations, 1, annotations))
+		assert.True(t, IsPhaseOutdated(withoutAnnotations, 2, annotations))
+	})
+}
+
+func TestSetObservedAnnotations(t *testing.T) {
+	status := &libapi.SupportArchiveStatus{}
+
+	SetObservedAnnotations(status, 1, map[string]string{CollectorTimeframesAnnotation: "logs=1h", LastAnnotation: "2h", "other": "value"})
+
+	condition := meta.FindStatusCondition(status.Conditions, ConditionObservedAnnotations)
+	require.NotNil(t, condition)
+	assert.Equal(t, "k8s.cloudogu.com/support-archive-collector-timeframes=logs=1h, k8s.cloudogu.com/support-archive-last=2h", condition.Message)
+
+	SetObservedAnnotations(status, 1, nil)
+	assert.Equal(t, "No annotations observed", meta.FindStatusCondition(status.Conditions, ConditionObservedAnnotations).Message)
+
+	ResetArchivePhase(status, 1)
+	assert.Nil(t, meta.FindStatusCondition(status.Conditions, ConditionObservedAnnotations))
+}
+
+func TestArchivePhase_IsFinal(t *testing.T) {
+	assert.False(t, ArchivePhasePending.IsFinal())
+	assert.False(t, ArchivePhaseCollecting.IsFinal())
+	assert.False(t, ArchivePhasePackaging.IsFinal())
+	assert.True(t, ArchivePhaseSucceeded.IsFinal())
+	assert.True(t, ArchivePhaseFailed.IsFinal())
+	assert.True(t, ArchivePhaseEstimated.IsFinal())
+}
@@ -0,0 +1,79 @@
+package domain
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/url"
+	"time"
+)
+
+// The status of the support archive is part of the lib, so the state of refreshes is kept in annotations, too.
+const (
+	// RefreshAnnotation requests to create the archive again if its value changes, e.g. to the current timestamp.
+	RefreshAnnotation = "k8s.cloudogu.com/support-archive-refresh"
+	// RefreshedAnnotation contains the value of the last handled RefreshAnnotation. It is set by the operator.
+	// Absolute content timeframes of refreshed archives are moved to end at the start of the archive creation.
+	RefreshedAnnotation = "k8s.cloudogu.com/support-archive-refreshed"
+	// DownloadHistoryAnnotation contains the download paths of archives replaced by a refresh as json list.
+	// It is set by the operator.
+	DownloadHistoryAnnotation = "k8s.cloudogu.com/support-archive-download-history"
+	// maxDownloadHistoryEntries limits the download history to keep the annotation small.
+	maxDownloadHistoryEntries = 10
+)
+
+// DownloadHistoryEntry is a download path of an archive that was replaced by a refresh.
+type DownloadHistoryEntry struct {
+	// DownloadPath is the download path without download token.
+	DownloadPath string    `json:"downloadPath"`
+	ReplacedAt   time.Time `json:"replacedAt"`
+}
+
+// IsRefreshRequested returns true if the annotations contain a refresh that was not handled yet.
+func IsRefreshRequested(annotations map[string]string) bool {
+	refresh := annotations[RefreshAnnotation]
+	return refresh != "" && refresh != annotations[RefreshedAnnotation]
+}
+
+// GetDownloadHistory returns the download history from the annotations, the oldest entry first.
+func GetDownloadHistory(annotations map[string]string) ([]DownloadHistoryEntry, error) {
+	value, ok := annotations[DownloadHistoryAnnotation]
+	if !ok || value == "" {
+		return nil, nil
+	}
+
+	var history []DownloadHistoryEntry
+	err := json.Unmarshal([]byte(value), &history)
+	if err != nil {
+		return nil, fmt.Errorf("invalid annotation %s: %w", DownloadHistoryAnnotation, err)
+	}
+
+	return history, nil
+}
+
+// AddToDownloadHistory appends the download path without its download token to the history.
+// Only the latest entries are kept.
+func AddToDownloadHistory(history []DownloadHistoryEntry, downloadPath string, replacedAt time.Time) []DownloadHistoryEntry {
+	if downloadPath == "" {
+		return history
+	}
+
+	history = append(history, DownloadHistoryEntry{DownloadPath: withoutDownloadToken(downloadPath), ReplacedAt: replacedAt.UTC().Truncate(time.Second)})
+	if len(history) > maxDownloadHistoryEntries {
+		history = history[len(history)-maxDownloadHistoryEntries:]
+	}
+
+	return history
+}
+
+func withoutDownloadToken(downloadPath string) string {
+	parsed, err := url.Parse(downloadPath)
+	if err != nil {
+		return downloadPath
+	}
+
+	query := parsed.Query()
+	// the download server expects download tokens in this query parameter
+	query.Del("token")
+	parsed.RawQuery = query.Encode()
+	return parsed.String()
+}
@@ -0,0 +1,63 @@
+package domain
+
+import (
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestIsRefreshRequested(t *testing.T) {
+	assert.False(t, IsRefreshRequested(nil))
+	assert.False(t, IsRefreshRequested(map[string]string{RefreshAnnotation: ""}))
+	assert.True(t, IsRefreshRequested(map[string]string{RefreshAnnotation: "2025-01-05T00:00:00Z"}))
+	assert.True(t, IsRefreshRequested(map[string]string{RefreshAnnotation: "2025-01-05T00:00:00Z", RefreshedAnnotation: "2025-01-04T00:00:00Z"}))
+	assert.False(t, IsRefreshRequested(map[string]string{RefreshAnnotation: "2025-01-05T00:00:00Z", RefreshedAnnotation: "2025-01-05T00:00:00Z"}))
+}
+
+func TestGetDownloadHistory(t *testing.T) {
+	t.Run("should return empty history without annotation", func(t *testing.T) {
+		history, err := GetDownloadHistory(nil)
+
+		require.NoError(t, err)
+		assert.Empty(t, history)
+	})
+	t.Run("should parse history", func(t *testing.T) {
+		annotations := map[string]string{DownloadHistoryAnnotation: `[{"downloadPath":"http://server/ns/name.zip","replacedAt":"2025-01-05T00:00:00Z"}]`}
+
+		history, err := GetDownloadHistory(annotations)
+
+		require.NoError(t, err)
+		assert.Equal(t, []DownloadHistoryEntry{{DownloadPath: "http://server/ns/name.zip", ReplacedAt: time.Date(2025, 1, 5, 0, 0, 0, 0, time.UTC)}}, history)
+	})
+	t.Run("should fail for invalid history", func(t *testing.T) {
+		_, err := GetDownloadHistory(map[string]string{DownloadHistoryAnnotation: "invalid"})
+
+		assert.ErrorContains(t, err, "invalid annotation k8s.cloudogu.com/support-archive-download-history")
+	})
+}
+
+func TestAddToDownloadHistory(t *testing.T) {
+	replacedAt := time.Date(2025, 1, 5, 0, 0, 0, 999, time.UTC)
+
+	t.Run("should add download path without token", func(t *testing.T) {
+		history := AddToDownloadHistory(nil, "http://server/ns/name.zip?token=secret", replacedAt)
+
+		assert.Equal(t, []DownloadHistoryEntry{{DownloadPath: "http://server/ns/name.zip", ReplacedAt: replacedAt.Truncate(time.Second)}}, history)
+	})
+	t.Run("should ignore empty download path", func(t *testing.T) {
+		assert.Empty(t, AddToDownloadHistory(nil, "", replacedAt))
+	})
+	t.Run("should only keep the latest entries", func(t *testing.T) {
+		var history []DownloadHistoryEntry
+		for i := 0; i < 12; i++ {
+			history = AddToDownloadHistory(history, fmt.Sprintf("http://server/%d.zip", i), replacedAt)
+		}
+
+		require.Len(t, history, 10)
+		assert.Equal(t, "http://server/2.zip", history[0].DownloadPath)
+		assert.Equal(t, "http://server/11.zip", history[9].DownloadPath)
+	})
+}
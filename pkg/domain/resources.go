@@ -1,8 +1,67 @@
 package domain
 
+import (
+	"fmt"
+	"slices"
+	"time"
+)
+
 type UnstructuredResource struct {
 	Name string `yaml:"name,omitempty"`
 	// Path represents e.g. gvk in kubernetes
 	Path    string                 `json:"path,omitempty"`
 	Content map[string]interface{} `yaml:"content,omitempty"`
 }
+
+// negativePolarityConditions are abnormal if their status is true.
+var negativePolarityConditions = []string{"MemoryPressure", "DiskPressure", "PIDPressure", "NetworkUnavailable"}
+
+// ResourceStatus contains the parts of a resource's status that are relevant to analyze the system state.
+type ResourceStatus struct {
+	// Path is the file of the resource relative to the root of the archive.
+	Path       string
+	Kind       string
+	Namespace  string
+	Name       string
+	Phase      string
+	Conditions []ResourceCondition
+	Containers []ContainerStatus
+}
+
+// Object references the resource, e.g. Pod/ldap-0.
+func (r ResourceStatus) Object() string {
+	return fmt.Sprintf("%s/%s", r.Kind, r.Name)
+}
+
+type ResourceCondition struct {
+	Type               string
+	Status             string
+	Reason             string
+	Message            string
+	LastTransitionTime time.Time
+}
+
+// IsAbnormal returns true if the condition does not have its normal status.
+// Most conditions are normal if they are true. Conditions like MemoryPressure are normal if they are false.
+func (c ResourceCondition) IsAbnormal() bool {
+	normal := "True"
+	if slices.Contains(negativePolarityConditions, c.Type) {
+		normal = "False"
+	}
+
+	return c.Status != normal
+}
+
+type ContainerStatus struct {
+	Name         string
+	RestartCount int
+	// Terminations contains the current and the last termination of the container.
+	Terminations []ContainerTermination
+}
+
+type ContainerTermination struct {
+	ExitCode   int
+	Reason     string
+	Message    string
+	FinishedAt time.Time
+}
@@ -0,0 +1,19 @@
+package domain
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestResourceCondition_IsAbnormal(t *testing.T) {
+	assert.False(t, ResourceCondition{Type: "Ready", Status: "True"}.IsAbnormal())
+	assert.True(t, ResourceCondition{Type: "Ready", Status: "False"}.IsAbnormal())
+	assert.True(t, ResourceCondition{Type: "Ready", Status: "Unknown"}.IsAbnormal())
+	assert.False(t, ResourceCondition{Type: "MemoryPressure", Status: "False"}.IsAbnormal())
+	assert.True(t, ResourceCondition{Type: "MemoryPressure", Status: "True"}.IsAbnormal())
+}
+
+func TestResourceStatus_Object(t *testing.T) {
+	assert.Equal(t, "Pod/ldap-0", ResourceStatus{Kind: "Pod", Namespace: "ecosystem", Name: "ldap-0"}.Object())
+}
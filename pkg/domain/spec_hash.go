@@ -0,0 +1,48 @@
+package domain
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+	"slices"
+	"strings"
+)
+
+// CollectorSpecHash returns the hash of the parts of the support archive spec which change the data of the collector.
+// Collected data is only valid as long as the hash stays the same.
+// Collectors of logs, events and metrics depend on their resolved content timeframe. The other collectors fetch the
+// current state of the cluster and do not depend on the spec apart from being excluded.
+func CollectorSpecHash(collectorType CollectorType, timeframes ContentTimeframes) string {
+	spec := strings.Builder{}
+	spec.WriteString(fmt.Sprintf("collector=%s\n", collectorType))
+	if usesContentTimeframe(collectorType) {
+		spec.WriteString(fmt.Sprintf("timeframe=%s\n", timeframes.For(collectorType)))
+	}
+
+	hash := sha256.Sum256([]byte(spec.String()))
+	return hex.EncodeToString(hash[:])
+}
+
+func usesContentTimeframe(collectorType CollectorType) bool {
+	for _, collectors := range collectorTimeframeKeys {
+		for _, col := range collectors {
+			if col == collectorType {
+				return true
+			}
+		}
+	}
+
+	return false
+}
+
+// ArchiveSpecHash combines the spec hashes of all collectors of the archive. It changes if a collector is added or
+// removed, too.
+func ArchiveSpecHash(collectors []CollectorType, timeframes ContentTimeframes) string {
+	spec := strings.Builder{}
+	for _, col := range slices.Sorted(slices.Values(collectors)) {
+		spec.WriteString(fmt.Sprintf("%s\n", CollectorSpecHash(col, timeframes)))
+	}
+
+	hash := sha256.Sum256([]byte(spec.String()))
+	return hex.EncodeToString(hash[:])
+}
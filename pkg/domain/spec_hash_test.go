@@ -0,0 +1,57 @@
+package domain
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestCollectorSpecHash(t *testing.T) {
+	end := time.Date(2025, 1, 5, 0, 0, 0, 0, time.UTC)
+	timeframes := ContentTimeframes{
+		Default:    Timeframe{Start: end.Add(-96 * time.Hour), End: end},
+		Collectors: map[CollectorType]Timeframe{CollectorTypeLog: {Start: end.Add(-24 * time.Hour), End: end}},
+	}
+	changedTimeframes := ContentTimeframes{Default: Timeframe{Start: end.Add(-48 * time.Hour), End: end}}
+
+	t.Run("should be stable for the same timeframe", func(t *testing.T) {
+		assert.Equal(t, CollectorSpecHash(CollectorTypeEvents, timeframes), CollectorSpecHash(CollectorTypeEvents, timeframes))
+	})
+	t.Run("should differ between collectors", func(t *testing.T) {
+		assert.NotEqual(t, CollectorSpecHash(CollectorTypeEvents, timeframes), CollectorSpecHash(CollectorTypeNodeInfo, timeframes))
+	})
+	t.Run("should change with the timeframe of the collector", func(t *testing.T) {
+		assert.NotEqual(t, CollectorSpecHash(CollectorTypeEvents, timeframes), CollectorSpecHash(CollectorTypeEvents, changedTimeframes))
+		assert.NotEqual(t, CollectorSpecHash(CollectorTypeLog, timeframes), CollectorSpecHash(CollectorTypeLog, changedTimeframes))
+	})
+	t.Run("should not change with the timeframe for collectors of the current state", func(t *testing.T) {
+		assert.Equal(t, CollectorSpecHash(CollectorTypeSystemState, timeframes), CollectorSpecHash(CollectorTypeSystemState, changedTimeframes))
+		assert.Equal(t, CollectorSpecHash(CollectorTypeSecret, timeframes), CollectorSpecHash(CollectorTypeSecret, changedTimeframes))
+	})
+}
+
+func TestArchiveSpecHash(t *testing.T) {
+	end := time.Date(2025, 1, 5, 0, 0, 0, 0, time.UTC)
+	timeframes := ContentTimeframes{Default: Timeframe{Start: end.Add(-96 * time.Hour), End: end}}
+	changedTimeframes := ContentTimeframes{Default: Timeframe{Start: end.Add(-48 * time.Hour), End: end}}
+
+	t.Run("should not depend on the order of the collectors", func(t *testing.T) {
+		assert.Equal(t,
+			ArchiveSpecHash([]CollectorType{CollectorTypeSecret, CollectorTypeSystemState}, timeframes),
+			ArchiveSpecHash([]CollectorType{CollectorTypeSystemState, CollectorTypeSecret}, timeframes),
+		)
+	})
+	t.Run("should change if a collector is removed", func(t *testing.T) {
+		assert.NotEqual(t,
+			ArchiveSpecHash([]CollectorType{CollectorTypeSecret, CollectorTypeSystemState}, timeframes),
+			ArchiveSpecHash([]CollectorType{CollectorTypeSystemState}, timeframes),
+		)
+	})
+	t.Run("should change with the timeframe of a collector", func(t *testing.T) {
+		assert.NotEqual(t,
+			ArchiveSpecHash([]CollectorType{CollectorTypeEvents}, timeframes),
+			ArchiveSpecHash([]CollectorType{CollectorTypeEvents}, changedTimeframes),
+		)
+	})
+}
@@ -0,0 +1,28 @@
+package domain
+
+import "time"
+
+// SummaryFileName is the name of the html summary in the root directory of the archive.
+const SummaryFileName = "index.html"
+
+// ArchiveSummary contains the data of the html summary which gives a first overview of the archive.
+// It is created at the end of the content timeframe, which does not change when the archive is packaged again.
+type ArchiveSummary struct {
+	ID        SupportArchiveID
+	CreatedAt time.Time
+	// Collectors contains the collected and skipped collectors sorted by type.
+	Collectors []CollectorSummary
+	Findings   []Finding
+	Data       *CollectedData
+}
+
+type CollectorSummary struct {
+	Type CollectorType
+	// SkippedReason is set if the collector was skipped instead of adding its data to the archive.
+	SkippedReason string
+}
+
+// Skipped returns true if the data of the collector is missing in the archive.
+func (c CollectorSummary) Skipped() bool {
+	return c.SkippedReason != ""
+}
@@ -0,0 +1,196 @@
+package domain
+
+import (
+	"fmt"
+	"slices"
+	"strconv"
+	"strings"
+	"time"
+
+	libapi "github.com/cloudogu/k8s-support-archive-lib/api/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+// The spec of the support archive is part of the lib and only has an absolute content timeframe.
+// Relative timeframes are defined with annotations.
+const (
+	// LastAnnotation defines a relative content timeframe up to now, e.g. "6h" or "7d".
+	// It replaces the content timeframe of the spec.
+	LastAnnotation = "k8s.cloudogu.com/support-archive-last"
+	// CollectorTimeframesAnnotation defines relative timeframes for single collectors as comma separated list,
+	// e.g. "logs=24h,metrics=7d". The timeframes end at the end of the content timeframe.
+	CollectorTimeframesAnnotation = "k8s.cloudogu.com/support-archive-collector-timeframes"
+	// ConditionContentTimeframe contains the resolved absolute content timeframes in its message.
+	ConditionContentTimeframe = "ContentTimeframe"
+)
+
+// collectorTimeframeKeys maps the keys of the CollectorTimeframesAnnotation to the collectors.
+var collectorTimeframeKeys = map[string][]CollectorType{
+	"logs":       {CollectorTypeLog},
+	"events":     {CollectorTypeEvents},
+	"metrics":    {CollectorTypeNodeInfo, CollectorTypeVolumeInfo},
+	"nodeInfo":   {CollectorTypeNodeInfo},
+	"volumeInfo": {CollectorTypeVolumeInfo},
+}
+
+// Timeframe is an absolute timeframe of collected data.
+type Timeframe struct {
+	Start time.Time
+	End   time.Time
+}
+
+func (t Timeframe) String() string {
+	return fmt.Sprintf("%s - %s", t.Start.UTC().Format(time.RFC3339), t.End.UTC().Format(time.RFC3339))
+}
+
+// ContentTimeframes contains the resolved absolute timeframes of a support archive.
+type ContentTimeframes struct {
+	// Default is the content timeframe of the archive. It applies to all collectors without a specific timeframe.
+	Default Timeframe
+	// Collectors contains the collector-specific timeframes.
+	Collectors map[CollectorType]Timeframe
+}
+
+// For returns the timeframe of the collector.
+func (t ContentTimeframes) For(collectorType CollectorType) Timeframe {
+	timeframe, ok := t.Collectors[collectorType]
+	if !ok {
+		return t.Default
+	}
+
+	return timeframe
+}
+
+// Condition returns the condition reporting the resolved timeframes.
+func (t ContentTimeframes) Condition(generation int64) metav1.Condition {
+	message := strings.Builder{}
+	message.WriteString(fmt.Sprintf("Content timeframe %s", t.Default))
+	collectors := make([]CollectorType, 0, len(t.Collectors))
+	for col := range t.Collectors {
+		collectors = append(collectors, col)
+	}
+	slices.Sort(collectors)
+	for _, col := range collectors {
+		message.WriteString(fmt.Sprintf(", %s: %s", col, t.Collectors[col]))
+	}
+
+	return metav1.Condition{
+		Type:               ConditionContentTimeframe,
+		Status:             metav1.ConditionTrue,
+		ObservedGeneration: generation,
+		LastTransitionTime: metav1.Now(),
+		Reason:             "Resolved",
+		Message:            message.String(),
+	}
+}
+
+// ResolveContentTimeframes resolves the absolute timeframes of the support archive.
+// A LastAnnotation replaces the timeframe of the spec with the duration up to now. Without annotation, the spec is used
+// and missing start and end times default to now minus the default timeframe and now.
+// The timeframe of the spec is moved to end at now if the archive was refreshed, see RefreshedAnnotation.
+// Collector-specific timeframes of the CollectorTimeframesAnnotation end at the end of the content timeframe.
+// Now has to be the same for every reconciliation of an archive, e.g. the start of the archive creation, so that
+// all collectors use the same timeframe. It is truncated to seconds because it is persisted in a condition.
+func ResolveContentTimeframes(cr *libapi.SupportArchive, defaultTimeframe time.Duration, now time.Time) (ContentTimeframes, error) {
+	now = now.Truncate(time.Second)
+	annotations := cr.GetAnnotations()
+
+	timeframe := Timeframe{Start: cr.Spec.ContentTimeframe.StartTime.Time, End: cr.Spec.ContentTimeframe.EndTime.Time}
+	last, hasLast := annotations[LastAnnotation]
+	if hasLast {
+		duration, err := ParseRelativeDuration(last)
+		if err != nil {
+			return ContentTimeframes{}, fmt.Errorf("invalid annotation %s: %w", LastAnnotation, err)
+		}
+		timeframe = Timeframe{Start: now.Add(-duration), End: now}
+	}
+	if timeframe.End.IsZero() {
+		timeframe.End = now
+	}
+	if timeframe.Start.IsZero() {
+		timeframe.Start = timeframe.End.Add(-defaultTimeframe)
+	}
+	if _, refreshed := annotations[RefreshedAnnotation]; refreshed && !hasLast {
+		timeframe = Timeframe{Start: now.Add(-timeframe.End.Sub(timeframe.Start)), End: now}
+	}
+	if !timeframe.Start.Before(timeframe.End) {
+		return ContentTimeframes{}, fmt.Errorf("start of content timeframe %s is not before its end", timeframe)
+	}
+
+	collectors, err := parseCollectorTimeframes(annotations[CollectorTimeframesAnnotation], timeframe.End)
+	if err != nil {
+		return ContentTimeframes{}, fmt.Errorf("invalid annotation %s: %w", CollectorTimeframesAnnotation, err)
+	}
+
+	return ContentTimeframes{Default: timeframe, Collectors: collectors}, nil
+}
+
+func parseCollectorTimeframes(value string, end time.Time) (map[CollectorType]Timeframe, error) {
+	result := map[CollectorType]Timeframe{}
+	for _, entry := range strings.Split(value, ",") {
+		entry = strings.TrimSpace(entry)
+		if entry == "" {
+			continue
+		}
+
+		key, durationValue, found := strings.Cut(entry, "=")
+		if !found {
+			return nil, fmt.Errorf("entry %q is not in the format <collector>=<duration>", entry)
+		}
+		collectors, ok := collectorTimeframeKeys[strings.TrimSpace(key)]
+		if !ok {
+			return nil, fmt.Errorf("unknown collector %q, valid collectors are %s", key, strings.Join(sortedCollectorTimeframeKeys(), ", "))
+		}
+		duration, err := ParseRelativeDuration(durationValue)
+		if err != nil {
+			return nil, err
+		}
+
+		for _, col := range collectors {
+			result[col] = Timeframe{Start: end.Add(-duration), End: end}
+		}
+	}
+
+	return result, nil
+}
+
+func sortedCollectorTimeframeKeys() []string {
+	keys := make([]string, 0, len(collectorTimeframeKeys))
+	for key := range collectorTimeframeKeys {
+		keys = append(keys, key)
+	}
+	slices.Sort(keys)
+
+	return keys
+}
+
+// ParseRelativeDuration parses a positive duration like time.ParseDuration with additional support for days,
+// e.g. "7d" or "1d12h".
+func ParseRelativeDuration(value string) (time.Duration, error) {
+	value = strings.TrimSpace(value)
+	var duration time.Duration
+	days, rest, found := strings.Cut(value, "d")
+	if !found {
+		rest = value
+	} else {
+		dayCount, err := strconv.Atoi(days)
+		if err != nil {
+			return 0, fmt.Errorf("invalid duration %q: %w", value, err)
+		}
+		duration = time.Duration(dayCount) * 24 * time.Hour
+	}
+
+	if rest != "" {
+		parsed, err := time.ParseDuration(rest)
+		if err != nil {
+			return 0, fmt.Errorf("invalid duration %q: %w", value, err)
+		}
+		duration += parsed
+	}
+
+	if duration <= 0 {
+		return 0, fmt.Errorf("duration %q must be positive", value)
+	}
+
+	return duration, nil
+}
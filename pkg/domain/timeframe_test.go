@@ -0,0 +1,159 @@
+package domain
+
+import (
+	"testing"
+	"time"
+
+	libapi "github.com/cloudogu/k8s-support-archive-lib/api/v1"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+var testNow = time.Date(2025, 3, 10, 12, 30, 15, 999, time.UTC)
+
+func newTimeframeCR(annotations map[string]string, start, end time.Time) *libapi.SupportArchive {
+	return &libapi.SupportArchive{
+		ObjectMeta: metav1.ObjectMeta{Annotations: annotations},
+		Spec: libapi.SupportArchiveSpec{ContentTimeframe: libapi.ContentTimeframe{
+			StartTime: metav1.NewTime(start),
+			EndTime:   metav1.NewTime(end),
+		}},
+	}
+}
+
+func TestResolveContentTimeframes(t *testing.T) {
+	now := testNow.Truncate(time.Second)
+
+	t.Run("should use timeframe of the spec", func(t *testing.T) {
+		start := time.Date(2025, 1, 1, 0, 0, 0, 0, time.UTC)
+		end := time.Date(2025, 1, 5, 0, 0, 0, 0, time.UTC)
+
+		timeframes, err := ResolveContentTimeframes(newTimeframeCR(nil, start, end), 96*time.Hour, testNow)
+
+		require.NoError(t, err)
+		assert.Equal(t, Timeframe{Start: start, End: end}, timeframes.Default)
+		assert.Equal(t, Timeframe{Start: start, End: end}, timeframes.For(CollectorTypeLog))
+	})
+	t.Run("should default to the default timeframe up to now", func(t *testing.T) {
+		timeframes, err := ResolveContentTimeframes(newTimeframeCR(nil, time.Time{}, time.Time{}), 96*time.Hour, testNow)
+
+		require.NoError(t, err)
+		assert.Equal(t, Timeframe{Start: now.Add(-96 * time.Hour), End: now}, timeframes.Default)
+	})
+	t.Run("should default the start relative to the end of the spec", func(t *testing.T) {
+		end := time.Date(2025, 1, 5, 0, 0, 0, 0, time.UTC)
+
+		timeframes, err := ResolveContentTimeframes(newTimeframeCR(nil, time.Time{}, end), 24*time.Hour, testNow)
+
+		require.NoError(t, err)
+		assert.Equal(t, Timeframe{Start: end.Add(-24 * time.Hour), End: end}, timeframes.Default)
+	})
+	t.Run("should replace the spec with the last annotation", func(t *testing.T) {
+		cr := newTimeframeCR(map[string]string{LastAnnotation: "6h"}, time.Date(2025, 1, 1, 0, 0, 0, 0, time.UTC), time.Date(2025, 1, 5, 0, 0, 0, 0, time.UTC))
+
+		timeframes, err := ResolveContentTimeframes(cr, 96*time.Hour, testNow)
+
+		require.NoError(t, err)
+		assert.Equal(t, Timeframe{Start: now.Add(-6 * time.Hour), End: now}, timeframes.Default)
+	})
+	t.Run("should move timeframe of the spec to end at now after a refresh", func(t *testing.T) {
+		cr := newTimeframeCR(map[string]string{RefreshedAnnotation: "1"}, time.Date(2025, 1, 1, 0, 0, 0, 0, time.UTC), time.Date(2025, 1, 5, 0, 0, 0, 0, time.UTC))
+
+		timeframes, err := ResolveContentTimeframes(cr, 24*time.Hour, testNow)
+
+		require.NoError(t, err)
+		assert.Equal(t, Timeframe{Start: now.Add(-96 * time.Hour), End: now}, timeframes.Default)
+	})
+	t.Run("should resolve collector timeframes up to the end of the content timeframe", func(t *testing.T) {
+		cr := newTimeframeCR(map[string]string{LastAnnotation: "6h", CollectorTimeframesAnnotation: "logs=24h, metrics=7d"}, time.Time{}, time.Time{})
+
+		timeframes, err := ResolveContentTimeframes(cr, 96*time.Hour, testNow)
+
+		require.NoError(t, err)
+		assert.Equal(t, Timeframe{Start: now.Add(-24 * time.Hour), End: now}, timeframes.For(CollectorTypeLog))
+		assert.Equal(t, Timeframe{Start: now.Add(-7 * 24 * time.Hour), End: now}, timeframes.For(CollectorTypeNodeInfo))
+		assert.Equal(t, Timeframe{Start: now.Add(-7 * 24 * time.Hour), End: now}, timeframes.For(CollectorTypeVolumeInfo))
+		assert.Equal(t, Timeframe{Start: now.Add(-6 * time.Hour), End: now}, timeframes.For(CollectorTypeEvents))
+	})
+	t.Run("should fail for invalid last annotation", func(t *testing.T) {
+		cr := newTimeframeCR(map[string]string{LastAnnotation: "yesterday"}, time.Time{}, time.Time{})
+
+		_, err := ResolveContentTimeframes(cr, 96*time.Hour, testNow)
+
+		require.Error(t, err)
+		assert.ErrorContains(t, err, "invalid annotation k8s.cloudogu.com/support-archive-last")
+	})
+	t.Run("should fail for unknown collector", func(t *testing.T) {
+		cr := newTimeframeCR(map[string]string{CollectorTimeframesAnnotation: "secrets=1h"}, time.Time{}, time.Time{})
+
+		_, err := ResolveContentTimeframes(cr, 96*time.Hour, testNow)
+
+		require.Error(t, err)
+		assert.ErrorContains(t, err, `unknown collector "secrets", valid collectors are events, logs, metrics, nodeInfo, volumeInfo`)
+	})
+	t.Run("should fail for collector timeframe without duration", func(t *testing.T) {
+		cr := newTimeframeCR(map[string]string{CollectorTimeframesAnnotation: "logs"}, time.Time{}, time.Time{})
+
+		_, err := ResolveContentTimeframes(cr, 96*time.Hour, testNow)
+
+		require.Error(t, err)
+		assert.ErrorContains(t, err, `entry "logs" is not in the format <collector>=<duration>`)
+	})
+	t.Run("should fail if start is not before end", func(t *testing.T) {
+		start := time.Date(2025, 1, 5, 0, 0, 0, 0, time.UTC)
+
+		_, err := ResolveContentTimeframes(newTimeframeCR(nil, start, start), 96*time.Hour, testNow)
+
+		require.Error(t, err)
+		assert.ErrorContains(t, err, "start of content timeframe 2025-01-05T00:00:00Z - 2025-01-05T00:00:00Z is not before its end")
+	})
+}
+
+func TestContentTimeframes_Condition(t *testing.T) {
+	start := time.Date(2025, 1, 1, 0, 0, 0, 0, time.UTC)
+	end := time.Date(2025, 1, 5, 0, 0, 0, 0, time.UTC)
+	timeframes := ContentTimeframes{
+		Default: Timeframe{Start: start, End: end},
+		Collectors: map[CollectorType]Timeframe{
+			CollectorTypeNodeInfo: {Start: end.Add(-7 * 24 * time.Hour), End: end},
+			CollectorTypeLog:      {Start: end.Add(-24 * time.Hour), End: end},
+		},
+	}
+
+	condition := timeframes.Condition(3)
+
+	assert.Equal(t, ConditionContentTimeframe, condition.Type)
+	assert.Equal(t, metav1.ConditionTrue, condition.Status)
+	assert.Equal(t, int64(3), condition.ObservedGeneration)
+	assert.Equal(t, "Resolved", condition.Reason)
+	assert.Equal(t, "Content timeframe 2025-01-01T00:00:00Z - 2025-01-05T00:00:00Z, Logs: 2025-01-04T00:00:00Z - 2025-01-05T00:00:00Z, NodeInfo: 2024-12-29T00:00:00Z - 2025-01-05T00:00:00Z", condition.Message)
+}
+
+func TestParseRelativeDuration(t *testing.T) {
+	tests := []struct {
+		value string
+		want  time.Duration
+	}{
+		{value: "6h", want: 6 * time.Hour},
+		{value: "90m", want: 90 * time.Minute},
+		{value: "7d", want: 7 * 24 * time.Hour},
+		{value: " 1d12h ", want: 36 * time.Hour},
+	}
+	for _, tt := range tests {
+		t.Run(tt.value, func(t *testing.T) {
+			got, err := ParseRelativeDuration(tt.value)
+
+			require.NoError(t, err)
+			assert.Equal(t, tt.want, got)
+		})
+	}
+
+	for _, value := range []string{"", "0s", "-1h", "xd", "1d2x", "1w"} {
+		t.Run("invalid "+value, func(t *testing.T) {
+			_, err := ParseRelativeDuration(value)
+
+			assert.Error(t, err)
+		})
+	}
+}
@@ -0,0 +1,46 @@
+package domain
+
+import (
+	"sort"
+	"time"
+)
+
+// TimelineFileName is the name of the timeline file in the root directory of the archive.
+const TimelineFileName = "timeline.jsonl"
+
+type TimelineSource string
+
+const (
+	TimelineSourceLog                  TimelineSource = "Log"
+	TimelineSourceEvent                TimelineSource = "Event"
+	TimelineSourceContainerTermination TimelineSource = "ContainerTermination"
+	TimelineSourceCondition            TimelineSource = "Condition"
+)
+
+type TimelineSeverity string
+
+const (
+	TimelineSeverityInfo    TimelineSeverity = "Info"
+	TimelineSeverityWarning TimelineSeverity = "Warning"
+	TimelineSeverityError   TimelineSeverity = "Error"
+)
+
+// TimelineEntry is a single incident of the timeline. The timeline merges entries from different collectors,
+// so that they do not have to be cross-referenced by hand.
+type TimelineEntry struct {
+	Timestamp time.Time        `json:"timestamp"`
+	Source    TimelineSource   `json:"source"`
+	Severity  TimelineSeverity `json:"severity"`
+	Namespace string           `json:"namespace,omitempty"`
+	// Object references the affected object, e.g. Pod/ldap-0.
+	Object  string `json:"object,omitempty"`
+	Reason  string `json:"reason,omitempty"`
+	Message string `json:"message"`
+}
+
+// SortTimeline sorts the entries chronologically. Entries with the same timestamp keep their order.
+func SortTimeline(entries []*TimelineEntry) {
+	sort.SliceStable(entries, func(i, j int) bool {
+		return entries[i].Timestamp.Before(entries[j].Timestamp)
+	})
+}
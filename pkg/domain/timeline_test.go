@@ -0,0 +1,20 @@
+package domain
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestSortTimeline(t *testing.T) {
+	now := time.Now()
+	first := &TimelineEntry{Timestamp: now.Add(-time.Hour), Source: TimelineSourceLog}
+	second := &TimelineEntry{Timestamp: now, Source: TimelineSourceEvent}
+	third := &TimelineEntry{Timestamp: now, Source: TimelineSourceCondition}
+	entries := []*TimelineEntry{second, third, first}
+
+	SortTimeline(entries)
+
+	assert.Equal(t, []*TimelineEntry{first, second, third}, entries)
+}
@@ -0,0 +1,257 @@
+package usecase
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/cloudogu/k8s-support-archive-operator/pkg/domain"
+)
+
+const (
+	ruleNameVolumeUsage       = "VolumeUsage"
+	ruleNameNodeMemoryUsage   = "NodeMemoryUsage"
+	ruleNamePodRestarts       = "PodRestarts"
+	ruleNameAbnormalCondition = "AbnormalCondition"
+	ruleNameErrorLogSpike     = "ErrorLogSpike"
+)
+
+const (
+	volumeUsageWarningPercent  = 90
+	volumeUsageCriticalPercent = 95
+
+	nodeRAMUsedRelativeMetric = "ramUsedRelative"
+	nodeMemoryWarningPercent  = 90
+	nodeMemoryCriticalPercent = 95
+
+	podRestartsWarningThreshold  = 3
+	podRestartsCriticalThreshold = 10
+
+	errorLogSpikeBucketSize     = 10 * time.Minute
+	errorLogSpikeMinimumCount   = 10
+	errorLogSpikeFactorOverMean = 5
+
+	kindPod                   = "Pod"
+	kindPersistentVolumeClaim = "PersistentVolumeClaim"
+	kindNode                  = "Node"
+	podPhaseSucceeded         = "Succeeded"
+)
+
+// AnalysisRule detects potential problems in the collected data.
+// New rules are added to defaultAnalysisRules.
+type AnalysisRule struct {
+	// Name is set as rule of all findings of the rule.
+	Name     string
+	Evaluate func(data *domain.CollectedData) []domain.Finding
+}
+
+var defaultAnalysisRules = []AnalysisRule{
+	{Name: ruleNameVolumeUsage, Evaluate: evaluateVolumeUsage},
+	{Name: ruleNameNodeMemoryUsage, Evaluate: evaluateNodeMemoryUsage},
+	{Name: ruleNamePodRestarts, Evaluate: evaluatePodRestarts},
+	{Name: ruleNameAbnormalCondition, Evaluate: evaluateAbnormalConditions},
+	{Name: ruleNameErrorLogSpike, Evaluate: evaluateErrorLogSpikes},
+}
+
+// evaluateAnalysisRules returns the sorted findings of all rules.
+func evaluateAnalysisRules(data *domain.CollectedData, rules []AnalysisRule) []domain.Finding {
+	var findings []domain.Finding
+	for _, rule := range rules {
+		for _, finding := range rule.Evaluate(data) {
+			finding.Rule = rule.Name
+			findings = append(findings, finding)
+		}
+	}
+	domain.SortFindings(findings)
+
+	return findings
+}
+
+func usageSeverity(percent float64, warning float64, critical float64) domain.FindingSeverity {
+	switch {
+	case percent >= critical:
+		return domain.FindingSeverityCritical
+	case percent >= warning:
+		return domain.FindingSeverityWarning
+	default:
+		return ""
+	}
+}
+
+// evaluateVolumeUsage reports persistent volume claims that are almost full.
+func evaluateVolumeUsage(data *domain.CollectedData) []domain.Finding {
+	var findings []domain.Finding
+	for _, volumeInfo := range data.Volumes {
+		for _, item := range volumeInfo.Items {
+			if item.Capacity <= 0 {
+				continue
+			}
+
+			percent := float64(item.Used) / float64(item.Capacity) * 100
+			severity := usageSeverity(percent, volumeUsageWarningPercent, volumeUsageCriticalPercent)
+			if severity == "" {
+				continue
+			}
+			findings = append(findings, domain.Finding{
+				Severity: severity,
+				Object:   fmt.Sprintf("%s/%s", kindPersistentVolumeClaim, item.Name),
+				Message:  fmt.Sprintf("The volume is %.1f%% full (%d of %d bytes used)", percent, item.Used, item.Capacity),
+			})
+		}
+	}
+
+	return findings
+}
+
+// evaluateNodeMemoryUsage reports nodes whose memory usage was high during the content timeframe.
+func evaluateNodeMemoryUsage(data *domain.CollectedData) []domain.Finding {
+	maxUsage := make(map[string]domain.LabeledSample)
+	for _, sample := range data.NodeInfo {
+		if sample.MetricName != nodeRAMUsedRelativeMetric {
+			continue
+		}
+		current, ok := maxUsage[sample.ID]
+		if !ok || sample.Value > current.Value {
+			maxUsage[sample.ID] = sample
+		}
+	}
+
+	var findings []domain.Finding
+	for node, sample := range maxUsage {
+		severity := usageSeverity(sample.Value, nodeMemoryWarningPercent, nodeMemoryCriticalPercent)
+		if severity == "" {
+			continue
+		}
+		findings = append(findings, domain.Finding{
+			Severity: severity,
+			Object:   fmt.Sprintf("%s/%s", kindNode, node),
+			Message:  fmt.Sprintf("The memory usage reached %.1f%% at %s", sample.Value, sample.Time.UTC().Format(time.RFC3339)),
+		})
+	}
+
+	return findings
+}
+
+// evaluatePodRestarts reports containers that were restarted repeatedly.
+func evaluatePodRestarts(data *domain.CollectedData) []domain.Finding {
+	var findings []domain.Finding
+	for _, resource := range data.Resources {
+		if resource.Kind != kindPod {
+			continue
+		}
+
+		for _, container := range resource.Containers {
+			severity := usageSeverity(float64(container.RestartCount), podRestartsWarningThreshold, podRestartsCriticalThreshold)
+			if severity == "" {
+				continue
+			}
+			message := fmt.Sprintf("Container %s was restarted %d times", container.Name, container.RestartCount)
+			if len(container.Terminations) > 0 {
+				// The termination of the current state comes first and is the most recent one.
+				last := container.Terminations[0]
+				message = fmt.Sprintf("%s, last termination with exit code %d", message, last.ExitCode)
+				if last.Reason != "" {
+					message = fmt.Sprintf("%s (%s)", message, last.Reason)
+				}
+			}
+			findings = append(findings, domain.Finding{
+				Severity: severity,
+				Object:   namespacedObject(resource),
+				Message:  message,
+			})
+		}
+	}
+
+	return findings
+}
+
+// evaluateAbnormalConditions reports resources with conditions indicating a problem, e.g. Ready=False.
+// Conditions of completed pods are ignored because they are not ready by design.
+func evaluateAbnormalConditions(data *domain.CollectedData) []domain.Finding {
+	var findings []domain.Finding
+	for _, resource := range data.Resources {
+		if resource.Kind == kindPod && resource.Phase == podPhaseSucceeded {
+			continue
+		}
+
+		for _, condition := range resource.Conditions {
+			if !condition.IsAbnormal() {
+				continue
+			}
+
+			message := fmt.Sprintf("Condition %s is %s", condition.Type, condition.Status)
+			if condition.Reason != "" {
+				message = fmt.Sprintf("%s (%s)", message, condition.Reason)
+			}
+			if condition.Message != "" {
+				message = fmt.Sprintf("%s: %s", message, condition.Message)
+			}
+			findings = append(findings, domain.Finding{
+				Severity: domain.FindingSeverityWarning,
+				Object:   namespacedObject(resource),
+				Message:  message,
+			})
+		}
+	}
+
+	return findings
+}
+
+func namespacedObject(resource domain.ResourceStatus) string {
+	if resource.Namespace == "" {
+		return resource.Object()
+	}
+
+	return fmt.Sprintf("%s/%s", resource.Namespace, resource.Object())
+}
+
+// evaluateErrorLogSpikes divides the content timeframe of the logs into buckets and reports periods
+// whose number of error log lines is much higher than the average.
+// Consecutive buckets with spikes are reported as a single finding.
+func evaluateErrorLogSpikes(data *domain.CollectedData) []domain.Finding {
+	logs := data.LogTimeframe
+	if !logs.End.After(logs.Start) {
+		return nil
+	}
+
+	bucketCount := int((logs.End.Sub(logs.Start) + errorLogSpikeBucketSize - 1) / errorLogSpikeBucketSize)
+	buckets := make([]int, bucketCount)
+	total := 0
+	for minute, count := range data.ErrorLogsPerMinute {
+		buckets[int(time.Duration(minute)*time.Minute/errorLogSpikeBucketSize)] += count
+		total += count
+	}
+	if total == 0 {
+		return nil
+	}
+
+	mean := float64(total) / float64(bucketCount)
+	threshold := max(float64(errorLogSpikeMinimumCount), errorLogSpikeFactorOverMean*mean)
+
+	var findings []domain.Finding
+	for i := 0; i < bucketCount; i++ {
+		if float64(buckets[i]) < threshold {
+			continue
+		}
+
+		first := i
+		for i+1 < bucketCount && float64(buckets[i+1]) >= threshold {
+			i++
+		}
+		count := 0
+		for _, bucket := range buckets[first : i+1] {
+			count += bucket
+		}
+		spikeStart := logs.Start.Add(time.Duration(first) * errorLogSpikeBucketSize)
+		spikeEnd := logs.Start.Add(time.Duration(i+1) * errorLogSpikeBucketSize)
+		if spikeEnd.After(logs.End) {
+			spikeEnd = logs.End
+		}
+		findings = append(findings, domain.Finding{
+			Severity: domain.FindingSeverityWarning,
+			Message: fmt.Sprintf("%d error log lines between %s and %s, the average is %.1f per %s",
+				count, spikeStart.UTC().Format(time.RFC3339), spikeEnd.UTC().Format(time.RFC3339), mean, errorLogSpikeBucketSize),
+		})
+	}
+
+	return findings
+}
@@ -0,0 +1,194 @@
+package usecase
+
+import (
+	"testing"
+	"time"
+
+	"github.com/cloudogu/k8s-support-archive-operator/pkg/domain"
+	"github.com/stretchr/testify/assert"
+)
+
+var testAnalysisStart = time.Date(2025, 1, 1, 0, 0, 0, 0, time.UTC)
+
+func Test_evaluateAnalysisRules(t *testing.T) {
+	t.Run("should set rule name and sort findings", func(t *testing.T) {
+		// given
+		rules := []AnalysisRule{
+			{Name: "Info", Evaluate: func(_ *domain.CollectedData) []domain.Finding {
+				return []domain.Finding{{Severity: domain.FindingSeverityInfo, Message: "info"}}
+			}},
+			{Name: "Critical", Evaluate: func(_ *domain.CollectedData) []domain.Finding {
+				return []domain.Finding{{Severity: domain.FindingSeverityCritical, Message: "critical"}}
+			}},
+			{Name: "Nothing", Evaluate: func(_ *domain.CollectedData) []domain.Finding {
+				return nil
+			}},
+		}
+
+		// when
+		findings := evaluateAnalysisRules(&domain.CollectedData{}, rules)
+
+		// then
+		assert.Equal(t, []domain.Finding{
+			{Rule: "Critical", Severity: domain.FindingSeverityCritical, Message: "critical"},
+			{Rule: "Info", Severity: domain.FindingSeverityInfo, Message: "info"},
+		}, findings)
+	})
+	t.Run("should not find anything in empty data with default rules", func(t *testing.T) {
+		assert.Empty(t, evaluateAnalysisRules(&domain.CollectedData{}, defaultAnalysisRules))
+	})
+}
+
+func Test_evaluateVolumeUsage(t *testing.T) {
+	// given
+	data := &domain.CollectedData{Volumes: []domain.VolumeInfo{{Items: []domain.VolumeInfoItem{
+		{Name: "normal", Capacity: 100, Used: 50},
+		{Name: "almost-full", Capacity: 100, Used: 90},
+		{Name: "full", Capacity: 100, Used: 99},
+		{Name: "unknown-capacity", Capacity: 0, Used: 10},
+	}}}}
+
+	// when
+	findings := evaluateVolumeUsage(data)
+
+	// then
+	assert.Equal(t, []domain.Finding{
+		{Severity: domain.FindingSeverityWarning, Object: "PersistentVolumeClaim/almost-full", Message: "The volume is 90.0% full (90 of 100 bytes used)"},
+		{Severity: domain.FindingSeverityCritical, Object: "PersistentVolumeClaim/full", Message: "The volume is 99.0% full (99 of 100 bytes used)"},
+	}, findings)
+}
+
+func Test_evaluateNodeMemoryUsage(t *testing.T) {
+	// given
+	data := &domain.CollectedData{NodeInfo: []domain.LabeledSample{
+		{MetricName: nodeRAMUsedRelativeMetric, ID: "node-1", Value: 50, Time: testAnalysisStart},
+		{MetricName: nodeRAMUsedRelativeMetric, ID: "node-1", Value: 92.5, Time: testAnalysisStart.Add(time.Minute)},
+		{MetricName: nodeRAMUsedRelativeMetric, ID: "node-1", Value: 91, Time: testAnalysisStart.Add(2 * time.Minute)},
+		{MetricName: nodeRAMUsedRelativeMetric, ID: "node-2", Value: 80, Time: testAnalysisStart},
+		{MetricName: "cpuUsageRelative", ID: "node-2", Value: 100, Time: testAnalysisStart},
+	}}
+
+	// when
+	findings := evaluateNodeMemoryUsage(data)
+
+	// then
+	assert.Equal(t, []domain.Finding{
+		{Severity: domain.FindingSeverityWarning, Object: "Node/node-1", Message: "The memory usage reached 92.5% at 2025-01-01T00:01:00Z"},
+	}, findings)
+}
+
+func Test_evaluatePodRestarts(t *testing.T) {
+	// given
+	data := &domain.CollectedData{Resources: []domain.ResourceStatus{
+		{Kind: "Pod", Namespace: "ecosystem", Name: "ldap-0", Containers: []domain.ContainerStatus{
+			{Name: "ldap", RestartCount: 12, Terminations: []domain.ContainerTermination{{ExitCode: 137, Reason: "OOMKilled"}, {ExitCode: 1, Reason: "Error"}}},
+			{Name: "sidecar", RestartCount: 3},
+			{Name: "exporter", RestartCount: 1},
+		}},
+		{Kind: "Deployment", Namespace: "ecosystem", Name: "ldap", Containers: []domain.ContainerStatus{{Name: "ldap", RestartCount: 20}}},
+	}}
+
+	// when
+	findings := evaluatePodRestarts(data)
+
+	// then
+	assert.Equal(t, []domain.Finding{
+		{Severity: domain.FindingSeverityCritical, Object: "ecosystem/Pod/ldap-0", Message: "Container ldap was restarted 12 times, last termination with exit code 137 (OOMKilled)"},
+		{Severity: domain.FindingSeverityWarning, Object: "ecosystem/Pod/ldap-0", Message: "Container sidecar was restarted 3 times"},
+	}, findings)
+}
+
+func Test_evaluateAbnormalConditions(t *testing.T) {
+	// given
+	data := &domain.CollectedData{Resources: []domain.ResourceStatus{
+		{Kind: "Pod", Namespace: "ecosystem", Name: "ldap-0", Phase: "Running", Conditions: []domain.ResourceCondition{
+			{Type: "Ready", Status: "False", Reason: "ContainersNotReady", Message: "containers with unready status: [ldap]"},
+			{Type: "PodScheduled", Status: "True"},
+		}},
+		{Kind: "Pod", Namespace: "ecosystem", Name: "job-abc", Phase: "Succeeded", Conditions: []domain.ResourceCondition{
+			{Type: "Ready", Status: "False", Reason: "PodCompleted"},
+		}},
+		{Kind: "Node", Name: "node-1", Conditions: []domain.ResourceCondition{
+			{Type: "MemoryPressure", Status: "True"},
+		}},
+	}}
+
+	// when
+	findings := evaluateAbnormalConditions(data)
+
+	// then
+	assert.Equal(t, []domain.Finding{
+		{Severity: domain.FindingSeverityWarning, Object: "ecosystem/Pod/ldap-0", Message: "Condition Ready is False (ContainersNotReady): containers with unready status: [ldap]"},
+		{Severity: domain.FindingSeverityWarning, Object: "Node/node-1", Message: "Condition MemoryPressure is True"},
+	}, findings)
+}
+
+func Test_evaluateErrorLogSpikes(t *testing.T) {
+	addErrorLines := func(data *domain.CollectedData, start time.Time, count int) {
+		for i := 0; i < count; i++ {
+			data.AddErrorLog(start.Add(time.Duration(i) * time.Second))
+		}
+	}
+
+	t.Run("should report consecutive buckets with spikes as one finding", func(t *testing.T) {
+		// given
+		data := &domain.CollectedData{LogTimeframe: domain.Timeframe{Start: testAnalysisStart, End: testAnalysisStart.Add(5 * time.Hour)}}
+		addErrorLines(data, testAnalysisStart, 2)
+		addErrorLines(data, testAnalysisStart.Add(30*time.Minute), 40)
+		addErrorLines(data, testAnalysisStart.Add(40*time.Minute), 30)
+
+		// when
+		findings := evaluateErrorLogSpikes(data)
+
+		// then
+		assert.Equal(t, []domain.Finding{
+			{Severity: domain.FindingSeverityWarning, Message: "70 error log lines between 2025-01-01T00:30:00Z and 2025-01-01T00:50:00Z, the average is 2.4 per 10m0s"},
+		}, findings)
+	})
+	t.Run("should divide the timeframe of the logs if it differs from the content timeframe", func(t *testing.T) {
+		// given
+		logStart := testAnalysisStart.Add(-24 * time.Hour)
+		data := &domain.CollectedData{
+			Start:        testAnalysisStart,
+			End:          testAnalysisStart.Add(time.Hour),
+			LogTimeframe: domain.Timeframe{Start: logStart, End: testAnalysisStart.Add(time.Hour)},
+		}
+		addErrorLines(data, logStart.Add(time.Hour), 30)
+		addErrorLines(data, testAnalysisStart, 5)
+
+		// when
+		findings := evaluateErrorLogSpikes(data)
+
+		// then
+		assert.Equal(t, []domain.Finding{
+			{Severity: domain.FindingSeverityWarning, Message: "30 error log lines between 2024-12-31T01:00:00Z and 2024-12-31T01:10:00Z, the average is 0.2 per 10m0s"},
+		}, findings)
+	})
+	t.Run("should not report evenly distributed errors", func(t *testing.T) {
+		// given
+		data := &domain.CollectedData{LogTimeframe: domain.Timeframe{Start: testAnalysisStart, End: testAnalysisStart.Add(time.Hour)}}
+		for i := 0; i < 6; i++ {
+			addErrorLines(data, testAnalysisStart.Add(time.Duration(i)*10*time.Minute), 20)
+		}
+
+		// when
+		findings := evaluateErrorLogSpikes(data)
+
+		// then
+		assert.Empty(t, findings)
+	})
+	t.Run("should not report a small number of errors", func(t *testing.T) {
+		// given
+		data := &domain.CollectedData{LogTimeframe: domain.Timeframe{Start: testAnalysisStart, End: testAnalysisStart.Add(24 * time.Hour)}}
+		addErrorLines(data, testAnalysisStart, 9)
+
+		// when
+		findings := evaluateErrorLogSpikes(data)
+
+		// then
+		assert.Empty(t, findings)
+	})
+	t.Run("should ignore empty timeframes", func(t *testing.T) {
+		assert.Empty(t, evaluateErrorLogSpikes(&domain.CollectedData{LogTimeframe: domain.Timeframe{Start: testAnalysisStart, End: testAnalysisStart}, ErrorLogsPerMinute: []int{50}}))
+	})
+}
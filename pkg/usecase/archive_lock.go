@@ -0,0 +1,69 @@
+package usecase
+
+import (
+	"context"
+	"fmt"
+	"sync"
+
+	"github.com/cloudogu/k8s-support-archive-operator/pkg/domain"
+)
+
+// ArchiveLocks serializes the work on a single support archive, e.g. the creation by the reconciler and the deletion
+// by the sync or the garbage collection, while different archives are processed concurrently.
+type ArchiveLocks struct {
+	mu    sync.Mutex
+	locks map[domain.SupportArchiveID]*archiveLock
+}
+
+type archiveLock struct {
+	// held contains an element while the lock is held, so that waiting for the lock can be canceled.
+	held chan struct{}
+	// refs counts the holder and the waiting callers. The lock is removed if it is not referenced anymore.
+	refs int
+}
+
+func NewArchiveLocks() *ArchiveLocks {
+	return &ArchiveLocks{locks: make(map[domain.SupportArchiveID]*archiveLock)}
+}
+
+// Lock blocks until the support archive is not locked anymore or the context is done.
+// The returned function unlocks the archive and must be called exactly once.
+func (a *ArchiveLocks) Lock(ctx context.Context, id domain.SupportArchiveID) (unlock func(), err error) {
+	lock := a.reference(id)
+
+	select {
+	case lock.held <- struct{}{}:
+		return func() {
+			<-lock.held
+			a.release(id)
+		}, nil
+	case <-ctx.Done():
+		a.release(id)
+		return nil, fmt.Errorf("failed to lock support archive %s/%s: %w", id.Namespace, id.Name, ctx.Err())
+	}
+}
+
+func (a *ArchiveLocks) reference(id domain.SupportArchiveID) *archiveLock {
+	a.mu.Lock()
+	defer a.mu.Unlock()
+
+	lock, ok := a.locks[id]
+	if !ok {
+		lock = &archiveLock{held: make(chan struct{}, 1)}
+		a.locks[id] = lock
+	}
+	lock.refs++
+
+	return lock
+}
+
+func (a *ArchiveLocks) release(id domain.SupportArchiveID) {
+	a.mu.Lock()
+	defer a.mu.Unlock()
+
+	lock := a.locks[id]
+	lock.refs--
+	if lock.refs == 0 {
+		delete(a.locks, id)
+	}
+}
@@ -0,0 +1,78 @@
+package usecase
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/cloudogu/k8s-support-archive-operator/pkg/domain"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestArchiveLocks_Lock(t *testing.T) {
+	otherID := domain.SupportArchiveID{Namespace: testArchiveNamespace, Name: "other"}
+
+	t.Run("should wait until the archive is unlocked", func(t *testing.T) {
+		// given
+		sut := NewArchiveLocks()
+		unlock, err := sut.Lock(testCtx, testID)
+		require.NoError(t, err)
+
+		// when
+		locked := make(chan func())
+		go func() {
+			secondUnlock, lockErr := sut.Lock(testCtx, testID)
+			assert.NoError(t, lockErr)
+			locked <- secondUnlock
+		}()
+
+		// then
+		select {
+		case <-locked:
+			t.Fatal("archive was locked twice")
+		case <-time.After(50 * time.Millisecond):
+		}
+		unlock()
+		select {
+		case secondUnlock := <-locked:
+			secondUnlock()
+		case <-time.After(time.Second):
+			t.Fatal("archive was not unlocked")
+		}
+		assert.Empty(t, sut.locks)
+	})
+	t.Run("should lock different archives independently", func(t *testing.T) {
+		// given
+		sut := NewArchiveLocks()
+		unlock, err := sut.Lock(testCtx, testID)
+		require.NoError(t, err)
+		defer unlock()
+
+		// when
+		otherUnlock, err := sut.Lock(testCtx, otherID)
+
+		// then
+		require.NoError(t, err)
+		otherUnlock()
+		assert.Len(t, sut.locks, 1)
+	})
+	t.Run("should stop waiting if the context is done", func(t *testing.T) {
+		// given
+		sut := NewArchiveLocks()
+		unlock, err := sut.Lock(testCtx, testID)
+		require.NoError(t, err)
+		ctx, cancel := context.WithCancel(testCtx)
+		cancel()
+
+		// when
+		_, err = sut.Lock(ctx, testID)
+
+		// then
+		require.ErrorIs(t, err, context.Canceled)
+		assert.ErrorContains(t, err, "failed to lock support archive test-namespace/test-archive")
+		unlock()
+		assert.Empty(t, sut.locks)
+	})
+}
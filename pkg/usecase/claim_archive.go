@@ -0,0 +1,164 @@
+package usecase
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"time"
+
+	libapi "github.com/cloudogu/k8s-support-archive-lib/api/v1"
+	"github.com/cloudogu/k8s-support-archive-operator/pkg/domain"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+	"sigs.k8s.io/controller-runtime/pkg/log"
+)
+
+var errClaimLost = errors.New("claim of the support archive was lost")
+
+// ClaimArchiveUseCase claims support archives for a single replica of the operator with the domain.ClaimAnnotation.
+// Leader election restricts the start of reconciliations to one replica, but not their end: the leader releases the
+// lease as soon as it is stopped, e.g. during a rolling update, and finishes running reconciliations within the
+// graceful shutdown timeout. A collector may write for minutes, so the new leader could start the same archive on the
+// shared volume in the meantime. The claim makes the new leader wait until the former leader finished or the claim
+// expired. Claims are only written while an archive is reconciled and at most once per renewal interval.
+type ClaimArchiveUseCase struct {
+	supportArchivesInterface supportArchiveV1Interface
+	// holder identifies the replica, e.g. by the name of the pod.
+	holder string
+	// duration defines how long a claim is valid without renewal. Claims are renewed after a third of the duration.
+	duration time.Duration
+	now      func() time.Time
+}
+
+func NewClaimArchiveUseCase(supportArchivesInterface supportArchiveV1Interface, holder string, duration time.Duration) *ClaimArchiveUseCase {
+	return &ClaimArchiveUseCase{
+		supportArchivesInterface: supportArchivesInterface,
+		holder:                   holder,
+		duration:                 duration,
+		now:                      time.Now,
+	}
+}
+
+// Claim claims the support archive for this replica if it is not claimed by another replica or the claim expired.
+// The claim is renewed in the background until release is called. The returned context is canceled if the claim is
+// lost, e.g. because it could not be renewed before it expired, so that the archive creation stops writing.
+// If another replica holds the claim, the returned context is nil and retryAfter contains the time until it expires.
+func (c *ClaimArchiveUseCase) Claim(ctx context.Context, cr *libapi.SupportArchive) (claimCtx context.Context, release context.CancelFunc, retryAfter time.Duration, err error) {
+	logger := log.FromContext(ctx).WithName("ClaimArchiveUseCase.Claim")
+
+	now := c.now()
+	claim, err := domain.GetArchiveClaim(cr.GetAnnotations())
+	if err != nil {
+		logger.Error(err, "replacing invalid claim")
+		claim = nil
+	}
+
+	if !claim.IsHeldBy(c.holder) && !claim.IsExpired(now) {
+		return nil, nil, claim.ExpiresAt.Sub(now), nil
+	}
+
+	var expiresAt time.Time
+	if claim.IsHeldBy(c.holder) && !c.needsRenewal(claim, now) {
+		expiresAt = claim.ExpiresAt
+	} else {
+		expiresAt, err = c.patchClaim(ctx, cr, now)
+		if err != nil {
+			return nil, nil, 0, fmt.Errorf("failed to claim support archive %s/%s: %w", cr.Namespace, cr.Name, err)
+		}
+	}
+
+	claimCtx, cancel := context.WithCancelCause(ctx)
+	go c.keepClaimed(claimCtx, cancel, domain.SupportArchiveID{Namespace: cr.Namespace, Name: cr.Name}, expiresAt)
+
+	return claimCtx, func() { cancel(context.Canceled) }, 0, nil
+}
+
+func (c *ClaimArchiveUseCase) needsRenewal(claim *domain.ArchiveClaim, now time.Time) bool {
+	return claim.ExpiresAt.Sub(now) < c.renewInterval()*2
+}
+
+func (c *ClaimArchiveUseCase) renewInterval() time.Duration {
+	return c.duration / 3
+}
+
+// keepClaimed renews the claim until the context is done. It cancels the context if the claim is lost or expired
+// without renewal. The renewal is required because a single collector may run longer than the claim duration, and a
+// claim that expires while the former leader still writes would let the new leader start the archive as well.
+func (c *ClaimArchiveUseCase) keepClaimed(ctx context.Context, cancel context.CancelCauseFunc, id domain.SupportArchiveID, expiresAt time.Time) {
+	logger := log.FromContext(ctx).WithName("ClaimArchiveUseCase.keepClaimed")
+
+	ticker := time.NewTicker(c.renewInterval())
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+			renewedExpiry, err := c.renew(ctx, id)
+			if err == nil {
+				expiresAt = renewedExpiry
+				continue
+			}
+			if ctx.Err() != nil {
+				return
+			}
+
+			if errors.Is(err, errClaimLost) || c.now().After(expiresAt) {
+				logger.Error(err, fmt.Sprintf("stopping work on support archive %s/%s", id.Namespace, id.Name))
+				cancel(fmt.Errorf("%w: %w", errClaimLost, err))
+				return
+			}
+			logger.Error(err, fmt.Sprintf("failed to renew claim of support archive %s/%s, retrying", id.Namespace, id.Name))
+		}
+	}
+}
+
+func (c *ClaimArchiveUseCase) renew(ctx context.Context, id domain.SupportArchiveID) (time.Time, error) {
+	current, err := c.supportArchivesInterface.SupportArchives(id.Namespace).Get(ctx, id.Name, metav1.GetOptions{})
+	if err != nil {
+		return time.Time{}, fmt.Errorf("failed to get support archive %s/%s: %w", id.Namespace, id.Name, err)
+	}
+
+	claim, err := domain.GetArchiveClaim(current.GetAnnotations())
+	if err != nil {
+		return time.Time{}, fmt.Errorf("%w: %w", errClaimLost, err)
+	}
+	if !claim.IsHeldBy(c.holder) {
+		return time.Time{}, fmt.Errorf("%w: support archive %s/%s is claimed by another replica", errClaimLost, id.Namespace, id.Name)
+	}
+
+	expiresAt, err := c.patchClaim(ctx, current, c.now())
+	if err != nil {
+		return time.Time{}, fmt.Errorf("failed to renew claim: %w", err)
+	}
+
+	return expiresAt, nil
+}
+
+// patchClaim sets the claim of this replica. The patch contains the resource version of the support archive, so it
+// fails with a conflict if another replica changed the claim in the meantime.
+func (c *ClaimArchiveUseCase) patchClaim(ctx context.Context, cr *libapi.SupportArchive, now time.Time) (time.Time, error) {
+	claim := &domain.ArchiveClaim{Holder: c.holder, ExpiresAt: now.Add(c.duration).UTC().Truncate(time.Second)}
+	value, err := claim.Annotation()
+	if err != nil {
+		return time.Time{}, err
+	}
+
+	patch, err := json.Marshal(map[string]any{"metadata": map[string]any{
+		"resourceVersion": cr.ResourceVersion,
+		"annotations":     map[string]string{domain.ClaimAnnotation: value},
+	}})
+	if err != nil {
+		return time.Time{}, fmt.Errorf("failed to marshal claim patch: %w", err)
+	}
+
+	_, err = c.supportArchivesInterface.SupportArchives(cr.Namespace).Patch(ctx, cr.Name, types.MergePatchType, patch, metav1.PatchOptions{})
+	if err != nil {
+		return time.Time{}, fmt.Errorf("failed to patch claim: %w", err)
+	}
+
+	return claim.ExpiresAt, nil
+}
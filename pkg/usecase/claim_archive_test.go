@@ -0,0 +1,244 @@
+package usecase
+
+import (
+	"context"
+	"encoding/json"
+	"testing"
+	"time"
+
+	libapi "github.com/cloudogu/k8s-support-archive-lib/api/v1"
+	"github.com/cloudogu/k8s-support-archive-operator/pkg/domain"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+)
+
+const testHolder = "operator-1"
+
+var testClaimNow = time.Date(2025, 1, 5, 0, 0, 0, 0, time.UTC)
+
+func claimedCR(holder string, expiresAt time.Time) *libapi.SupportArchive {
+	cr := &libapi.SupportArchive{ObjectMeta: metav1.ObjectMeta{Name: testArchiveName, Namespace: testArchiveNamespace, ResourceVersion: "42"}}
+	if holder != "" {
+		value, _ := (&domain.ArchiveClaim{Holder: holder, ExpiresAt: expiresAt}).Annotation()
+		cr.Annotations = map[string]string{domain.ClaimAnnotation: value}
+	}
+
+	return cr
+}
+
+func newTestClaimUseCase(t *testing.T, clientMock *mockSupportArchiveInterface, duration time.Duration) *ClaimArchiveUseCase {
+	interfaceMock := newMockSupportArchiveV1Interface(t)
+	interfaceMock.EXPECT().SupportArchives(testArchiveNamespace).Return(clientMock).Maybe()
+
+	useCase := NewClaimArchiveUseCase(interfaceMock, testHolder, duration)
+	useCase.now = func() time.Time { return testClaimNow }
+	return useCase
+}
+
+func expectClaimPatch(t *testing.T, clientMock *mockSupportArchiveInterface, resourceVersion string, expiresAt time.Time) {
+	clientMock.EXPECT().Patch(mock.Anything, testArchiveName, types.MergePatchType, mock.Anything, metav1.PatchOptions{}).Return(nil, nil).Run(func(_ context.Context, _ string, _ types.PatchType, data []byte, _ metav1.PatchOptions, _ ...string) {
+		var patch struct {
+			Metadata struct {
+				ResourceVersion string            `json:"resourceVersion"`
+				Annotations     map[string]string `json:"annotations"`
+			} `json:"metadata"`
+		}
+		require.NoError(t, json.Unmarshal(data, &patch))
+		assert.Equal(t, resourceVersion, patch.Metadata.ResourceVersion)
+		claim, err := domain.GetArchiveClaim(patch.Metadata.Annotations)
+		require.NoError(t, err)
+		assert.Equal(t, &domain.ArchiveClaim{Holder: testHolder, ExpiresAt: expiresAt}, claim)
+	}).Once()
+}
+
+func TestNewClaimArchiveUseCase(t *testing.T) {
+	useCase := NewClaimArchiveUseCase(newMockSupportArchiveV1Interface(t), testHolder, time.Minute)
+
+	assert.Equal(t, testHolder, useCase.holder)
+	assert.Equal(t, time.Minute, useCase.duration)
+	assert.NotNil(t, useCase.now)
+}
+
+func TestClaimArchiveUseCase_Claim(t *testing.T) {
+	t.Run("should claim unclaimed archive", func(t *testing.T) {
+		// given
+		clientMock := newMockSupportArchiveInterface(t)
+		expectClaimPatch(t, clientMock, "42", testClaimNow.Add(time.Hour))
+		sut := newTestClaimUseCase(t, clientMock, time.Hour)
+
+		// when
+		claimCtx, release, retryAfter, err := sut.Claim(testCtx, claimedCR("", time.Time{}))
+
+		// then
+		require.NoError(t, err)
+		require.NotNil(t, claimCtx)
+		assert.Zero(t, retryAfter)
+		release()
+		assert.ErrorIs(t, claimCtx.Err(), context.Canceled)
+	})
+	t.Run("should take over expired claim of another replica", func(t *testing.T) {
+		// given
+		clientMock := newMockSupportArchiveInterface(t)
+		expectClaimPatch(t, clientMock, "42", testClaimNow.Add(time.Hour))
+		sut := newTestClaimUseCase(t, clientMock, time.Hour)
+
+		// when
+		claimCtx, release, _, err := sut.Claim(testCtx, claimedCR("operator-2", testClaimNow))
+
+		// then
+		require.NoError(t, err)
+		require.NotNil(t, claimCtx)
+		release()
+	})
+	t.Run("should replace invalid claim", func(t *testing.T) {
+		// given
+		clientMock := newMockSupportArchiveInterface(t)
+		expectClaimPatch(t, clientMock, "42", testClaimNow.Add(time.Hour))
+		sut := newTestClaimUseCase(t, clientMock, time.Hour)
+		cr := claimedCR("", time.Time{})
+		cr.Annotations = map[string]string{domain.ClaimAnnotation: "invalid"}
+
+		// when
+		claimCtx, release, _, err := sut.Claim(testCtx, cr)
+
+		// then
+		require.NoError(t, err)
+		require.NotNil(t, claimCtx)
+		release()
+	})
+	t.Run("should not patch recently renewed own claim", func(t *testing.T) {
+		// given
+		sut := newTestClaimUseCase(t, newMockSupportArchiveInterface(t), time.Hour)
+
+		// when
+		claimCtx, release, _, err := sut.Claim(testCtx, claimedCR(testHolder, testClaimNow.Add(50*time.Minute)))
+
+		// then
+		require.NoError(t, err)
+		require.NotNil(t, claimCtx)
+		release()
+	})
+	t.Run("should renew own claim before it expires", func(t *testing.T) {
+		// given
+		clientMock := newMockSupportArchiveInterface(t)
+		expectClaimPatch(t, clientMock, "42", testClaimNow.Add(time.Hour))
+		sut := newTestClaimUseCase(t, clientMock, time.Hour)
+
+		// when
+		claimCtx, release, _, err := sut.Claim(testCtx, claimedCR(testHolder, testClaimNow.Add(10*time.Minute)))
+
+		// then
+		require.NoError(t, err)
+		require.NotNil(t, claimCtx)
+		release()
+	})
+	t.Run("should return time until the claim of another replica expires", func(t *testing.T) {
+		// given
+		sut := newTestClaimUseCase(t, newMockSupportArchiveInterface(t), time.Hour)
+
+		// when
+		claimCtx, release, retryAfter, err := sut.Claim(testCtx, claimedCR("operator-2", testClaimNow.Add(20*time.Second)))
+
+		// then
+		require.NoError(t, err)
+		assert.Nil(t, claimCtx)
+		assert.Nil(t, release)
+		assert.Equal(t, 20*time.Second, retryAfter)
+	})
+	t.Run("should fail to patch claim", func(t *testing.T) {
+		// given
+		clientMock := newMockSupportArchiveInterface(t)
+		clientMock.EXPECT().Patch(testCtx, testArchiveName, types.MergePatchType, mock.Anything, metav1.PatchOptions{}).Return(nil, assert.AnError)
+		sut := newTestClaimUseCase(t, clientMock, time.Hour)
+
+		// when
+		_, _, _, err := sut.Claim(testCtx, claimedCR("", time.Time{}))
+
+		// then
+		require.ErrorIs(t, err, assert.AnError)
+		assert.ErrorContains(t, err, "failed to claim support archive test-namespace/test-archive")
+	})
+}
+
+func TestClaimArchiveUseCase_keepClaimed(t *testing.T) {
+	t.Run("should renew claim", func(t *testing.T) {
+		// given
+		duration := 30 * time.Millisecond
+		clientMock := newMockSupportArchiveInterface(t)
+		current := claimedCR(testHolder, testClaimNow.Add(duration))
+		current.ResourceVersion = "43"
+		clientMock.EXPECT().Get(mock.Anything, testArchiveName, metav1.GetOptions{}).Return(current, nil)
+		renewed := make(chan struct{})
+		clientMock.EXPECT().Patch(mock.Anything, testArchiveName, types.MergePatchType, mock.Anything, metav1.PatchOptions{}).Return(nil, nil).Run(func(_ context.Context, _ string, _ types.PatchType, data []byte, _ metav1.PatchOptions, _ ...string) {
+			assert.Contains(t, string(data), `"resourceVersion":"43"`)
+			select {
+			case renewed <- struct{}{}:
+			default:
+			}
+		})
+		sut := newTestClaimUseCase(t, clientMock, duration)
+
+		// when
+		claimCtx, release, _, err := sut.Claim(testCtx, claimedCR(testHolder, testClaimNow.Add(duration)))
+		require.NoError(t, err)
+		defer release()
+
+		// then
+		select {
+		case <-renewed:
+		case <-time.After(time.Second):
+			t.Fatal("claim was not renewed")
+		}
+		assert.NoError(t, claimCtx.Err())
+	})
+	t.Run("should cancel context if another replica took over the claim", func(t *testing.T) {
+		// given
+		duration := 30 * time.Millisecond
+		clientMock := newMockSupportArchiveInterface(t)
+		clientMock.EXPECT().Get(mock.Anything, testArchiveName, metav1.GetOptions{}).Return(claimedCR("operator-2", testClaimNow.Add(time.Hour)), nil)
+		sut := newTestClaimUseCase(t, clientMock, duration)
+
+		// when
+		claimCtx, release, _, err := sut.Claim(testCtx, claimedCR(testHolder, testClaimNow.Add(duration)))
+		require.NoError(t, err)
+		defer release()
+
+		// then
+		select {
+		case <-claimCtx.Done():
+		case <-time.After(time.Second):
+			t.Fatal("context was not canceled")
+		}
+		assert.ErrorIs(t, context.Cause(claimCtx), errClaimLost)
+	})
+	t.Run("should keep context if renewal fails before the claim expires", func(t *testing.T) {
+		// given
+		duration := 30 * time.Millisecond
+		clientMock := newMockSupportArchiveInterface(t)
+		failed := make(chan struct{})
+		clientMock.EXPECT().Get(mock.Anything, testArchiveName, metav1.GetOptions{}).Return(nil, assert.AnError).Run(func(_ context.Context, _ string, _ metav1.GetOptions) {
+			select {
+			case failed <- struct{}{}:
+			default:
+			}
+		})
+		sut := newTestClaimUseCase(t, clientMock, duration)
+
+		// when
+		claimCtx, release, _, err := sut.Claim(testCtx, claimedCR(testHolder, testClaimNow.Add(time.Hour)))
+		require.NoError(t, err)
+		defer release()
+
+		// then
+		select {
+		case <-failed:
+		case <-time.After(time.Second):
+			t.Fatal("claim was not renewed")
+		}
+		assert.NoError(t, claimCtx.Err())
+	})
+}
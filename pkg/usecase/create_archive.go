@@ -2,8 +2,12 @@ package usecase
 
 import (
 	"context"
+	"encoding/json"
 	"errors"
 	"fmt"
+	"io"
+	"slices"
+	"strings"
 	"time"
 
 	"golang.org/x/sync/errgroup"
@@ -13,16 +17,17 @@ import (
 
 	"k8s.io/apimachinery/pkg/api/meta"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
 	"sigs.k8s.io/controller-runtime/pkg/log"
 )
 
 const (
-	defaultContentTimeFrame = time.Hour * 24 * 4
+	// downloadTokenRenewalMargin defines how long before its expiry the download token on the status is renewed.
+	downloadTokenRenewalMargin = time.Minute
 )
 
-var (
-	emptyTime = metav1.NewTime(time.Time{})
-)
+// errAbortedCollection is reported for collectors whose failures were counted without a result of the collection.
+var errAbortedCollection = errors.New("the collection was aborted")
 
 type CollectorAndRepository struct {
 	// We have to use any here because of the different data types.
@@ -42,6 +47,7 @@ func (cm CollectorMapping) getRequiredCollectorMapping(cr *libapi.SupportArchive
 	}
 	if !cr.Spec.ExcludedContents.SystemInfo {
 		mapping[domain.CollectorTypeNodeInfo] = cm[domain.CollectorTypeNodeInfo]
+		mapping[domain.CollectorTypeNodeStatus] = cm[domain.CollectorTypeNodeStatus]
 	}
 	if !cr.Spec.ExcludedContents.SensitiveData {
 		mapping[domain.CollectorTypeSecret] = cm[domain.CollectorTypeSecret]
@@ -51,115 +57,891 @@ func (cm CollectorMapping) getRequiredCollectorMapping(cr *libapi.SupportArchive
 	}
 	if !cr.Spec.ExcludedContents.SystemState {
 		mapping[domain.CollectorTypeSystemState] = cm[domain.CollectorTypeSystemState]
+		mapping[domain.CollectorTypeHelmRelease] = cm[domain.CollectorTypeHelmRelease]
+		if cr.Spec.ExcludedContents.SensitiveData {
+			mapping[domain.CollectorTypeHelmRelease] = withoutSensitiveData(cm[domain.CollectorTypeHelmRelease])
+		}
 	}
 
 	return mapping
 }
 
+// withoutSensitiveData replaces the collector with its variant without sensitive data if it collects sensitive data
+// besides its main data.
+func withoutSensitiveData(colRepo CollectorAndRepository) CollectorAndRepository {
+	if excluder, ok := colRepo.Collector.(sensitiveDataExcluder); ok {
+		colRepo.Collector = excluder.WithoutSensitiveData()
+	}
+
+	return colRepo
+}
+
+// InMemoryArchives contains the collectors with repositories which keep the collected data in memory. Small support
+// archives are created with them, so that the collected data is not written to the work directory before packaging.
+type InMemoryArchives struct {
+	CollectorMapping CollectorMapping
+	PostProcessing   PostProcessingRepositories
+	// MaxSize is the maximum estimated size of the collected data of an archive created in memory.
+	MaxSize int64
+}
+
 type CreateArchiveUseCase struct {
 	supportArchivesInterface supportArchiveV1Interface
 	supportArchiveRepository supportArchiveRepository
+	postProcessing           PostProcessingRepositories
 	collectorMapping         CollectorMapping
+	// collectorMaxRetries defines how often a failing collector is retried before it is skipped.
+	collectorMaxRetries int
+	// archiveDeadline defines the maximum duration of the archive creation before the archive fails.
+	archiveDeadline time.Duration
+	// defaultContentTimeframe defines how far the content reaches into the past if the archive defines no start time.
+	defaultContentTimeframe time.Duration
+	// timelineEnabled defines if the timeline is built from the collected data and added to the archive.
+	timelineEnabled bool
+	// downloadURLSigner adds expiring download tokens to the download url on the status. It is nil if downloads
+	// do not require tokens.
+	downloadURLSigner downloadURLSigner
+	// archiveLocks prevents that an archive is deleted while it is created.
+	archiveLocks *ArchiveLocks
+	// inMemory creates small archives with the repositories of the InMemoryArchives. It is nil if disabled.
+	inMemory *CreateArchiveUseCase
+	// inMemoryMaxSize is the maximum estimated size of the collected data of an archive created in memory.
+	inMemoryMaxSize int64
+	// inMemoryArchiveRepository records the spec hash of archives created in memory. It is nil if archives are never
+	// created in memory, e.g. for local archives.
+	inMemoryArchiveRepository inMemoryArchiveRepository
+}
+
+// CreateArchiveOptions contains the settings and the optional dependencies of the CreateArchiveUseCase.
+type CreateArchiveOptions struct {
+	// CollectorMaxRetries defines how often a failing collector is retried before it is skipped.
+	CollectorMaxRetries int
+	// ArchiveDeadline defines the maximum duration of the archive creation before the archive fails.
+	ArchiveDeadline time.Duration
+	// DefaultContentTimeframe defines how far the content reaches into the past if the archive defines no start time.
+	DefaultContentTimeframe time.Duration
+	// TimelineEnabled defines if the timeline is built from the collected data and added to the archive.
+	TimelineEnabled bool
+	// DownloadURLSigner adds expiring download tokens to the download url on the status. It is nil if downloads do
+	// not require tokens.
+	DownloadURLSigner downloadURLSigner
+	// InMemoryArchives contains the repositories to create small archives in memory. It is nil if disabled.
+	InMemoryArchives *InMemoryArchives
+	// InMemoryArchiveRepository records the spec hash of archives created in memory. It is also required if
+	// InMemoryArchives is nil, so that archives created in memory before are still recognized.
+	InMemoryArchiveRepository inMemoryArchiveRepository
 }
 
-func NewCreateArchiveUseCase(supportArchivesInterface supportArchiveV1Interface, collectorMapping CollectorMapping, supportArchiveRepository supportArchiveRepository) *CreateArchiveUseCase {
-	return &CreateArchiveUseCase{
-		supportArchivesInterface: supportArchivesInterface,
-		supportArchiveRepository: supportArchiveRepository,
-		collectorMapping:         collectorMapping,
+func NewCreateArchiveUseCase(supportArchivesInterface supportArchiveV1Interface, collectorMapping CollectorMapping, supportArchiveRepository supportArchiveRepository, postProcessing PostProcessingRepositories, archiveLocks *ArchiveLocks, options CreateArchiveOptions) *CreateArchiveUseCase {
+	useCase := &CreateArchiveUseCase{
+		supportArchivesInterface:  supportArchivesInterface,
+		supportArchiveRepository:  supportArchiveRepository,
+		postProcessing:            postProcessing,
+		collectorMapping:          collectorMapping,
+		collectorMaxRetries:       options.CollectorMaxRetries,
+		archiveDeadline:           options.ArchiveDeadline,
+		defaultContentTimeframe:   options.DefaultContentTimeframe,
+		timelineEnabled:           options.TimelineEnabled,
+		downloadURLSigner:         options.DownloadURLSigner,
+		archiveLocks:              archiveLocks,
+		inMemoryArchiveRepository: options.InMemoryArchiveRepository,
+	}
+	if options.InMemoryArchives != nil {
+		inMemoryOptions := options
+		inMemoryOptions.InMemoryArchives = nil
+		inMemoryOptions.InMemoryArchiveRepository = nil
+		useCase.inMemory = NewCreateArchiveUseCase(supportArchivesInterface, options.InMemoryArchives.CollectorMapping, supportArchiveRepository, options.InMemoryArchives.PostProcessing, archiveLocks, inMemoryOptions)
+		useCase.inMemoryMaxSize = options.InMemoryArchives.MaxSize
 	}
+
+	return useCase
+}
+
+// NewLocalCreateArchiveUseCase creates the use case for CreateLocalArchive, which neither reads nor updates the custom
+// resource in the cluster. Only the deadline, the default content timeframe and the timeline of the options are used.
+func NewLocalCreateArchiveUseCase(collectorMapping CollectorMapping, supportArchiveRepository supportArchiveRepository, postProcessing PostProcessingRepositories, options CreateArchiveOptions) *CreateArchiveUseCase {
+	return NewCreateArchiveUseCase(nil, collectorMapping, supportArchiveRepository, postProcessing, NewArchiveLocks(), CreateArchiveOptions{
+		ArchiveDeadline:         options.ArchiveDeadline,
+		DefaultContentTimeframe: options.DefaultContentTimeframe,
+		TimelineEnabled:         options.TimelineEnabled,
+	})
 }
 
 // HandleArchiveRequest processes the support archive custom resource.
 // It reads the actual state and executes the next data collector.
 // If there are remaining collectors after execution, the method returns (true, nil) to indicate a necessary requeue.
 // If there are no remaining collectors, the method returns (false, nil).
+// A collector failing more often than the configured maximum retries is skipped so that the archive can be created
+// partially. The reason for skipping is added to the archive.
+// The phase of the archive is updated with every status update. If the archive is not created before the deadline,
+// the archive creation fails permanently.
+// If download tokens are enabled, the token on the status is renewed before it expires as long as the archive exists.
+// Support archives marked as dry run are only estimated, see estimateArchive. The archive is created after the dry run
+// annotation is removed.
+// The content timeframes are resolved relative to the start of the archive creation, so that relative timeframes stay
+// the same for all collectors. An invalid timeframe fails the archive creation permanently until the spec or one of the
+// domain.ReconcileAnnotations changes.
+// Every collector records the hash of the spec it was executed with. Collectors whose hash does not match the current
+// spec are executed again. An existing archive is deleted and the archive creation restarts after such a change.
+// A refresh requested with the domain.RefreshAnnotation restarts the archive creation in every phase, see refreshArchive.
+// Different archives can be handled concurrently. The archive is locked while it is handled, see ArchiveLocks.
+// Small archives are created in memory within a single reconciliation, see createArchiveInMemory.
 func (c *CreateArchiveUseCase) HandleArchiveRequest(ctx context.Context, cr *libapi.SupportArchive) (time.Duration, error) {
 	logger := log.FromContext(ctx).WithName("CreateArchiveUseCase.HandleArchiveRequest")
 
+	unlock, err := c.archiveLocks.Lock(ctx, domain.SupportArchiveID{Namespace: cr.Namespace, Name: cr.Name})
+	if err != nil {
+		return 0, err
+	}
+	defer unlock()
+
+	if domain.IsRefreshRequested(cr.GetAnnotations()) {
+		return c.refreshArchive(ctx, cr)
+	}
+
+	switch domain.GetArchivePhase(cr.Status) {
+	case domain.ArchivePhaseFailed:
+		if domain.IsPhaseOutdated(cr.Status, cr.Generation, cr.GetAnnotations()) {
+			logger.Info("restarting failed archive creation because the spec changed")
+			return time.Nanosecond, c.resetStatus(ctx, cr)
+		}
+		logger.Info("archive creation failed permanently")
+		return 0, nil
+	case domain.ArchivePhaseEstimated:
+		if !domain.IsDryRun(cr.GetAnnotations()) || domain.IsPhaseOutdated(cr.Status, cr.Generation, cr.GetAnnotations()) {
+			logger.Info("restarting estimated archive because the dry run was ended or the spec changed")
+			return time.Nanosecond, c.resetStatus(ctx, cr)
+		}
+		logger.Info("archive was estimated in a dry run")
+		return 0, nil
+	default:
+	}
+
+	startTime := domain.GetStartTime(cr.Status, time.Now())
+	if domain.IsDryRun(cr.GetAnnotations()) {
+		return 0, c.estimateArchive(ctx, cr, startTime)
+	}
+
 	id := domain.SupportArchiveID{
 		Namespace: cr.GetNamespace(),
 		Name:      cr.GetName(),
 	}
+	timeframes, err := c.resolveContentTimeframes(cr, startTime)
+	if err != nil {
+		return 0, c.failArchiveWithCondition(ctx, cr, getInvalidTimeframeArchiveCreatedCondition(err), startTime)
+	}
+
+	deadline := startTime.Add(c.archiveDeadline)
 	requiredCollectorMapping := c.collectorMapping.getRequiredCollectorMapping(cr)
-	completedCollectorList, err := c.getAlreadyExecutedCollectors(ctx, id, requiredCollectorMapping)
+	specHash, err := c.getInMemorySpecHash(ctx, id)
+	if err != nil {
+		return 0, err
+	}
+	if specHash != "" {
+		return c.handleInMemoryArchive(ctx, cr, id, specHash, requiredCollectorMapping, timeframes)
+	}
+	// All collectors are checked because the data of no longer required collectors has to be found for the cleanup.
+	executedCollectorList, err := c.getAlreadyExecutedCollectors(ctx, id, c.collectorMapping)
 	if err != nil {
 		return 0, fmt.Errorf("could not get already executed collectors: %w", err)
 	}
 	// If the user changes required collectors, we have to clean up old unused data.
-	c.deleteUnusedRepositoryData(ctx, id, requiredCollectorMapping, completedCollectorList)
+	removedCollectors := c.deleteUnusedRepositoryData(ctx, id, requiredCollectorMapping, executedCollectorList)
+	// If the user changes the spec, e.g. the content timeframe, we have to collect the affected data again.
+	completedCollectorList, staleCollectors, err := c.invalidateStaleCollectors(ctx, id, requiredCollectorMapping, executedCollectorList, timeframes)
+	if err != nil {
+		return 0, fmt.Errorf("could not invalidate stale collectors: %w", err)
+	}
 
 	collectorsToExecute := getCollectorTypesToExecute(requiredCollectorMapping, completedCollectorList)
 	exists, err := c.supportArchiveRepository.Exists(ctx, id)
 	if err != nil {
 		return 0, fmt.Errorf("could not check if the support archive exists: %w", err)
 	}
-	if len(collectorsToExecute) == 0 && !exists {
+	if exists && (len(collectorsToExecute) > 0 || len(removedCollectors) > 0) {
+		logger.Info("rebuilding archive because the spec changed", "staleCollectors", staleCollectors, "removedCollectors", removedCollectors)
+		return c.rebuildArchive(ctx, cr, id)
+	}
+	if len(collectorsToExecute) == 0 && exists {
+		logger.Info("archive exists")
+		return c.renewDownloadToken(ctx, cr)
+	}
+
+	if time.Now().After(deadline) {
+		return 0, c.failArchive(ctx, cr, startTime)
+	}
+
+	if len(executedCollectorList) == 0 && !exists {
+		created, renewAfter, inMemoryErr := c.createArchiveInMemory(ctx, cr, id, timeframes, startTime, deadline)
+		if created || inMemoryErr != nil {
+			return renewAfter, inMemoryErr
+		}
+	}
+
+	if len(collectorsToExecute) == 0 {
 		logger.Info("all collectors are executed")
-		url, createErr := c.createArchive(ctx, id, requiredCollectorMapping)
+		phaseErr := c.updatePhase(ctx, cr, domain.ArchivePhasePackaging, startTime)
+		if phaseErr != nil {
+			logger.Error(phaseErr, "could not update phase")
+		}
+
+		compareTo := domain.GetCompareTo(id, cr.GetAnnotations())
+		url, skippedCollectors, createErr := c.createArchive(ctx, id, requiredCollectorMapping, timeframes, compareTo)
 		if createErr != nil {
 			return 0, fmt.Errorf("could not create archive: %w", createErr)
 		}
-		statusErr := c.updateFinalStatus(ctx, cr, url)
+		renewAfter, statusErr := c.updateFinalStatus(ctx, cr, url, skippedCollectors, startTime)
 		if statusErr != nil {
 			return 0, fmt.Errorf("could not update status: %w", statusErr)
 		}
 
-		return 0, nil
-	} else if len(collectorsToExecute) == 0 {
-		logger.Info("archive exists")
-		return 0, nil
+		return renewAfter, nil
 	}
 
 	nextCollector := collectorsToExecute[0]
+	// The spec hash is recorded before the collector marks its data as collected, so that completed data always has
+	// a spec hash.
+	err = c.recordSpecHash(ctx, id, nextCollector, timeframes)
+	if err != nil {
+		return 0, err
+	}
+
+	failures, err := c.getCollectorFailures(ctx, id, nextCollector)
+	if err != nil {
+		return 0, err
+	}
+	if failures > c.collectorMaxRetries {
+		// the previous attempts could not handle their failure, e.g. because the operator crashed during the collection
+		return c.skipFailingCollector(ctx, cr, id, nextCollector, failures, errAbortedCollection, timeframes, startTime)
+	}
+	// Every attempt is counted as failure before the collector is executed, so that attempts crashing the operator
+	// are counted as well. Successfully collected data is marked as done and not collected again.
+	failures++
+	err = c.setCollectorFailures(ctx, id, nextCollector, failures)
+	if err != nil {
+		return 0, err
+	}
+
+	collectorCtx, cancel := context.WithDeadline(ctx, deadline)
+	defer cancel()
+	err = c.executeNextCollector(collectorCtx, id, nextCollector, timeframes.For(nextCollector))
+	if err != nil && time.Now().After(deadline) {
+		logger.Error(err, "collector was aborted because the deadline exceeded", "collector", nextCollector)
+		return 0, c.failArchive(ctx, cr, startTime)
+	} else if err != nil {
+		return c.handleCollectorFailure(ctx, cr, id, nextCollector, failures, err, timeframes, startTime)
+	}
+
+	conditionErr := c.setConditionForCollector(ctx, cr, nextCollector, nil, startTime)
+	if conditionErr != nil {
+		logger.Error(conditionErr, "could not add collector condition")
+	}
+
+	return time.Nanosecond, nil
+}
+
+// CreateLocalArchive executes all required collectors of the given support archive one after another and creates the
+// archive without reading or updating the custom resource in the cluster. It is used to create archives from the
+// command line if the operator is not available. Failing collectors are skipped immediately.
+// The collected data is deleted afterward. The method returns the reasons of skipped collectors.
+func (c *CreateArchiveUseCase) CreateLocalArchive(ctx context.Context, cr *libapi.SupportArchive) (map[domain.CollectorType]string, error) {
+	logger := log.FromContext(ctx).WithName("CreateArchiveUseCase.CreateLocalArchive")
+
+	id := domain.SupportArchiveID{
+		Namespace: cr.GetNamespace(),
+		Name:      cr.GetName(),
+	}
+	requiredCollectorMapping := c.collectorMapping.getRequiredCollectorMapping(cr)
+	defer c.deleteCollectedData(ctx, id, requiredCollectorMapping)
+
+	deadlineCtx, cancel := context.WithTimeout(ctx, c.archiveDeadline)
+	defer cancel()
+
+	timeframes, err := c.resolveContentTimeframes(cr, time.Now())
+	if err != nil {
+		return nil, err
+	}
+	for _, col := range sortedCollectorMappingTypes(requiredCollectorMapping) {
+		logger.Info("executing collector", "collector", col, "timeframe", timeframes.For(col))
+		err := c.executeNextCollector(deadlineCtx, id, col, timeframes.For(col))
+		if err != nil && deadlineCtx.Err() != nil {
+			return nil, fmt.Errorf("archive creation exceeded the deadline of %s: %w", c.archiveDeadline, err)
+		} else if err != nil {
+			logger.Error(err, "skipping failed collector", "collector", col)
+			skipErr := c.skipCollector(deadlineCtx, id, col, fmt.Sprintf("Collector %s failed: %s", col, err.Error()))
+			if skipErr != nil {
+				return nil, errors.Join(err, skipErr)
+			}
+		}
+	}
+
+	// Packaging is not limited by the deadline, like in HandleArchiveRequest.
+	compareTo := domain.GetCompareTo(id, cr.GetAnnotations())
+	_, skippedCollectors, err := c.createArchive(ctx, id, requiredCollectorMapping, timeframes, compareTo)
+	if err != nil {
+		return nil, fmt.Errorf("could not create archive: %w", err)
+	}
+
+	return skippedCollectors, nil
+}
+
+// createArchiveInMemory creates the archive with the repositories of the InMemoryArchives if the estimated size of
+// the collected data does not exceed the maximum. All collectors are executed within this reconciliation and the
+// collected data is removed after packaging. The spec hash of the archive is recorded in the inMemoryArchiveRepository.
+// It returns false if the archive is not created in memory, e.g. because a collector cannot estimate its size or
+// fails, so that the archive is created in the work directory instead.
+func (c *CreateArchiveUseCase) createArchiveInMemory(ctx context.Context, cr *libapi.SupportArchive, id domain.SupportArchiveID, timeframes domain.ContentTimeframes, startTime, deadline time.Time) (bool, time.Duration, error) {
+	logger := log.FromContext(ctx).WithName("CreateArchiveUseCase.createArchiveInMemory")
+	if c.inMemory == nil || c.inMemoryArchiveRepository == nil {
+		return false, 0, nil
+	}
+
+	requiredCollectorMapping := c.inMemory.collectorMapping.getRequiredCollectorMapping(cr)
+	size, known := estimateArchiveSize(ctx, cr.Namespace, requiredCollectorMapping, timeframes)
+	if !known || size > c.inMemoryMaxSize {
+		logger.Info("archive is not created in memory", "sizeKnown", known, "estimatedSize", size)
+		return false, 0, nil
+	}
+
+	logger.Info("creating archive in memory", "estimatedSize", domain.FormatBytes(size))
+	url, err := c.inMemory.collectAndCreateArchive(ctx, id, requiredCollectorMapping, timeframes, deadline, domain.GetCompareTo(id, cr.GetAnnotations()))
+	if err != nil {
+		logger.Error(err, "could not create archive in memory, collecting the data in the work directory")
+		return false, 0, nil
+	}
 
-	start, end := getContentTimeframe(cr)
-	err = c.executeNextCollector(ctx, id, nextCollector, start, end)
-	conditionErr := c.setConditionForCollector(ctx, cr, nextCollector, err)
+	collectors := sortedCollectorMappingTypes(requiredCollectorMapping)
+	specHash := domain.ArchiveSpecHash(collectors, timeframes)
+	err = c.inMemoryArchiveRepository.SetSpecHash(ctx, id, specHash)
 	if err != nil {
+		return true, 0, fmt.Errorf("could not record spec hash of archive created in memory: %w", err)
+	}
+	for _, col := range collectors {
+		conditionErr := c.setConditionForCollector(ctx, cr, col, nil, startTime)
 		if conditionErr != nil {
-			logger.Error(err, "could not add collector condition")
+			logger.Error(conditionErr, "could not add collector condition")
+		}
+	}
+
+	renewAfter, err := c.updateFinalStatus(ctx, cr, url, nil, startTime)
+	if err != nil {
+		return true, 0, fmt.Errorf("could not update status: %w", err)
+	}
+
+	return true, renewAfter, nil
+}
+
+// collectAndCreateArchive executes all given collectors one after another and creates the archive.
+// The collected data is deleted afterward.
+func (c *CreateArchiveUseCase) collectAndCreateArchive(ctx context.Context, id domain.SupportArchiveID, requiredCollectorMapping CollectorMapping, timeframes domain.ContentTimeframes, deadline time.Time, compareTo *domain.SupportArchiveID) (string, error) {
+	defer c.deleteCollectedData(ctx, id, requiredCollectorMapping)
+
+	collectorCtx, cancel := context.WithDeadline(ctx, deadline)
+	defer cancel()
+	for _, col := range sortedCollectorMappingTypes(requiredCollectorMapping) {
+		err := c.executeNextCollector(collectorCtx, id, col, timeframes.For(col))
+		if err != nil {
+			return "", err
 		}
-		return 0, fmt.Errorf("could not execute next collector: %w", err)
+	}
+
+	url, _, err := c.createArchive(ctx, id, requiredCollectorMapping, timeframes, compareTo)
+	if err != nil {
+		return "", fmt.Errorf("could not create archive: %w", err)
+	}
+
+	return url, nil
+}
+
+// handleInMemoryArchive renews the download token of an archive created in memory as long as it matches the spec.
+// Otherwise, the archive is deleted and the archive creation restarts.
+func (c *CreateArchiveUseCase) handleInMemoryArchive(ctx context.Context, cr *libapi.SupportArchive, id domain.SupportArchiveID, specHash string, requiredCollectorMapping CollectorMapping, timeframes domain.ContentTimeframes) (time.Duration, error) {
+	logger := log.FromContext(ctx).WithName("CreateArchiveUseCase.handleInMemoryArchive")
+
+	exists, err := c.supportArchiveRepository.Exists(ctx, id)
+	if err != nil {
+		return 0, fmt.Errorf("could not check if the support archive exists: %w", err)
+	}
+	if exists && specHash == domain.ArchiveSpecHash(sortedCollectorMappingTypes(requiredCollectorMapping), timeframes) {
+		logger.Info("archive exists")
+		return c.renewDownloadToken(ctx, cr)
+	}
+
+	logger.Info("rebuilding archive created in memory", "exists", exists)
+	_, err = c.rebuildArchive(ctx, cr, id)
+	if err != nil {
+		return 0, err
+	}
+	err = c.inMemoryArchiveRepository.Delete(ctx, id)
+	if err != nil {
+		return 0, fmt.Errorf("could not delete spec hash of archive created in memory: %w", err)
 	}
 
 	return time.Nanosecond, nil
 }
 
-func getContentTimeframe(cr *libapi.SupportArchive) (start metav1.Time, end metav1.Time) {
-	now := time.Now()
-	startTime := cr.Spec.ContentTimeframe.StartTime
-	if startTime.Equal(&emptyTime) {
-		startTime = metav1.NewTime(now.Truncate(defaultContentTimeFrame))
+// getInMemorySpecHash returns the spec hash of the archive if it was created in memory, otherwise an empty string.
+func (c *CreateArchiveUseCase) getInMemorySpecHash(ctx context.Context, id domain.SupportArchiveID) (string, error) {
+	if c.inMemoryArchiveRepository == nil {
+		return "", nil
+	}
+
+	specHash, err := c.inMemoryArchiveRepository.GetSpecHash(ctx, id)
+	if err != nil {
+		return "", fmt.Errorf("could not get spec hash of archive created in memory: %w", err)
+	}
+
+	return specHash, nil
+}
+
+// estimateArchiveSize sums the estimated sizes of the data of the collectors.
+// It returns false if a collector cannot estimate the size of its data.
+func estimateArchiveSize(ctx context.Context, namespace string, collectors CollectorMapping, timeframes domain.ContentTimeframes) (int64, bool) {
+	logger := log.FromContext(ctx).WithName("CreateArchiveUseCase.estimateArchiveSize")
+
+	var size int64
+	for _, col := range sortedCollectorMappingTypes(collectors) {
+		collectorEstimator, ok := collectors[col].Collector.(estimator)
+		if !ok {
+			return 0, false
+		}
+
+		timeframe := timeframes.For(col)
+		estimate, err := collectorEstimator.Estimate(ctx, namespace, timeframe.Start, timeframe.End)
+		if err != nil {
+			logger.Error(err, "could not estimate collector", "collector", col)
+			return 0, false
+		}
+		if estimate.Bytes == 0 && estimate.Items > 0 {
+			return 0, false
+		}
+		size += estimate.Bytes
+	}
+
+	return size, true
+}
+
+// estimateArchive lets all required collectors estimate their data instead of collecting it.
+// The estimates are reported as collector conditions and the archive ends in the phase Estimated.
+// No data is collected and no archive is created.
+func (c *CreateArchiveUseCase) estimateArchive(ctx context.Context, cr *libapi.SupportArchive, startTime time.Time) error {
+	logger := log.FromContext(ctx).WithName("CreateArchiveUseCase.estimateArchive")
+
+	timeframes, err := c.resolveContentTimeframes(cr, startTime)
+	if err != nil {
+		return c.failArchiveWithCondition(ctx, cr, getInvalidTimeframeArchiveCreatedCondition(err), startTime)
+	}
+
+	deadlineCtx, cancel := context.WithDeadline(ctx, startTime.Add(c.archiveDeadline))
+	defer cancel()
+
+	requiredCollectorMapping := c.collectorMapping.getRequiredCollectorMapping(cr)
+	conditions := make([]metav1.Condition, 0, len(requiredCollectorMapping))
+	for _, col := range sortedCollectorMappingTypes(requiredCollectorMapping) {
+		logger.Info("estimating collector", "collector", col)
+		timeframe := timeframes.For(col)
+		conditions = append(conditions, c.estimateCollector(deadlineCtx, cr.Namespace, col, timeframe.Start, timeframe.End))
+	}
+
+	client := c.supportArchivesInterface.SupportArchives(cr.Namespace)
+	_, err = client.UpdateStatusWithRetry(ctx, cr, func(status libapi.SupportArchiveStatus) libapi.SupportArchiveStatus {
+		for _, condition := range conditions {
+			meta.SetStatusCondition(&status.Conditions, condition)
+		}
+		c.setArchivePhase(&status, cr, domain.ArchivePhaseEstimated, startTime)
+		return status
+	}, metav1.UpdateOptions{})
+	if err != nil {
+		return fmt.Errorf("failed to set estimates for archive %s/%s: %w", cr.Namespace, cr.Name, err)
+	}
+
+	return nil
+}
+
+// estimateCollector returns the condition with the estimate of the collector.
+// Collectors without estimates and failing estimates are reported in the condition, too.
+func (c *CreateArchiveUseCase) estimateCollector(ctx context.Context, namespace string, collectorType domain.CollectorType, start, end time.Time) metav1.Condition {
+	collectorEstimator, ok := c.collectorMapping[collectorType].Collector.(estimator)
+	if !ok {
+		return getEstimatedCollectorCondition(collectorType, "NoEstimate", fmt.Sprintf("Collector %s does not support estimates", collectorType))
+	}
+
+	estimate, err := collectorEstimator.Estimate(ctx, namespace, start, end)
+	if err != nil {
+		return getEstimatedCollectorCondition(collectorType, "EstimationFailed", fmt.Sprintf("Collector %s failed to estimate: %s", collectorType, err.Error()))
+	}
+
+	return getEstimatedCollectorCondition(collectorType, "Estimated", fmt.Sprintf("Collector %s would collect %s", collectorType, estimate))
+}
+
+func (c *CreateArchiveUseCase) skipCollector(ctx context.Context, id domain.SupportArchiveID, collectorType domain.CollectorType, reason string) error {
+	baseRepo, err := getBaseRepositoryForCollector(collectorType, c.collectorMapping)
+	if err != nil {
+		return err
+	}
+
+	err = baseRepo.Skip(ctx, id, reason)
+	if err != nil {
+		return fmt.Errorf("could not skip collector %s: %w", collectorType, err)
+	}
+
+	return nil
+}
+
+// deleteCollectedData removes the data of all given collectors from the work directory.
+func (c *CreateArchiveUseCase) deleteCollectedData(ctx context.Context, id domain.SupportArchiveID, collectors CollectorMapping) {
+	logger := log.FromContext(ctx).WithName("CreateArchiveUseCase.deleteCollectedData")
+	for col := range collectors {
+		err := deleteCollectorRepositoryData(ctx, id, col, c.collectorMapping)
+		if err != nil {
+			logger.Error(err, "failed to remove collected data", "collector", col)
+		}
+	}
+}
+
+func sortedCollectorMappingTypes(mapping CollectorMapping) []domain.CollectorType {
+	result := make([]domain.CollectorType, 0, len(mapping))
+	for col := range mapping {
+		result = append(result, col)
+	}
+	slices.Sort(result)
+
+	return result
+}
+
+// failArchive marks the archive creation as permanently failed because the deadline exceeded.
+func (c *CreateArchiveUseCase) failArchive(ctx context.Context, cr *libapi.SupportArchive, startTime time.Time) error {
+	logger := log.FromContext(ctx).WithName("CreateArchiveUseCase.failArchive")
+	logger.Info("archive creation exceeded the deadline", "deadline", c.archiveDeadline)
+
+	return c.failArchiveWithCondition(ctx, cr, getDeadlineExceededArchiveCreatedCondition(c.archiveDeadline), startTime)
+}
+
+// failArchiveWithCondition marks the archive creation as permanently failed with the reason of the condition.
+func (c *CreateArchiveUseCase) failArchiveWithCondition(ctx context.Context, cr *libapi.SupportArchive, condition metav1.Condition, startTime time.Time) error {
+	client := c.supportArchivesInterface.SupportArchives(cr.Namespace)
+	_, err := client.UpdateStatusWithRetry(ctx, cr, func(status libapi.SupportArchiveStatus) libapi.SupportArchiveStatus {
+		meta.SetStatusCondition(&status.Conditions, condition)
+		c.setArchivePhase(&status, cr, domain.ArchivePhaseFailed, startTime)
+		return status
+	}, metav1.UpdateOptions{})
+	if err != nil {
+		return fmt.Errorf("failed to set failed status for archive %s/%s: %w", cr.Namespace, cr.Name, err)
+	}
+
+	return nil
+}
+
+func (c *CreateArchiveUseCase) updatePhase(ctx context.Context, cr *libapi.SupportArchive, phase domain.ArchivePhase, startTime time.Time) error {
+	client := c.supportArchivesInterface.SupportArchives(cr.Namespace)
+	_, err := client.UpdateStatusWithRetry(ctx, cr, func(status libapi.SupportArchiveStatus) libapi.SupportArchiveStatus {
+		c.setArchivePhase(&status, cr, phase, startTime)
+		return status
+	}, metav1.UpdateOptions{})
+	if err != nil {
+		return fmt.Errorf("failed to set phase %s for archive %s/%s: %w", phase, cr.Namespace, cr.Name, err)
+	}
+
+	return nil
+}
+
+// handleCollectorFailure returns the collector error to retry the collector as long as the maximum retries are not exceeded.
+// Afterward, the collector is skipped and the reconciliation continues with the next collector.
+func (c *CreateArchiveUseCase) handleCollectorFailure(ctx context.Context, cr *libapi.SupportArchive, id domain.SupportArchiveID, collectorType domain.CollectorType, failures int, collectorErr error, timeframes domain.ContentTimeframes, startTime time.Time) (time.Duration, error) {
+	logger := log.FromContext(ctx).WithName("CreateArchiveUseCase.handleCollectorFailure")
+
+	if failures > c.collectorMaxRetries {
+		return c.skipFailingCollector(ctx, cr, id, collectorType, failures, collectorErr, timeframes, startTime)
+	}
+
+	// the repository may have removed the count together with the incomplete data
+	err := c.setCollectorFailures(ctx, id, collectorType, failures)
+	if err != nil {
+		return 0, errors.Join(collectorErr, err)
+	}
+
+	conditionErr := c.setConditionForCollector(ctx, cr, collectorType, collectorErr, startTime)
+	if conditionErr != nil {
+		logger.Error(conditionErr, "could not add collector condition")
+	}
+	return 0, fmt.Errorf("could not execute next collector: %w", collectorErr)
+}
+
+// skipFailingCollector skips a collector which exceeded the maximum retries. Skipping removes the data of the
+// collector together with the count of its failures.
+func (c *CreateArchiveUseCase) skipFailingCollector(ctx context.Context, cr *libapi.SupportArchive, id domain.SupportArchiveID, collectorType domain.CollectorType, failures int, collectorErr error, timeframes domain.ContentTimeframes, startTime time.Time) (time.Duration, error) {
+	logger := log.FromContext(ctx).WithName("CreateArchiveUseCase.skipFailingCollector")
+
+	logger.Info("skipping collector because it exceeded the maximum retries", "collector", collectorType, "failures", failures)
+	reason := fmt.Sprintf("Collector %s was skipped after %d failed attempts. Last error: %s", collectorType, failures, collectorErr.Error())
+	err := c.skipCollector(ctx, id, collectorType, reason)
+	if err != nil {
+		return 0, errors.Join(collectorErr, err)
+	}
+	err = c.recordSpecHash(ctx, id, collectorType, timeframes)
+	if err != nil {
+		return 0, errors.Join(collectorErr, err)
+	}
+
+	conditionErr := c.setCollectorCondition(ctx, cr, collectorType, getSkippedCollectorCondition(collectorType, reason), startTime)
+	if conditionErr != nil {
+		logger.Error(conditionErr, "could not add collector condition")
+	}
+
+	return time.Nanosecond, nil
+}
+
+func (c *CreateArchiveUseCase) getCollectorFailures(ctx context.Context, id domain.SupportArchiveID, collectorType domain.CollectorType) (int, error) {
+	baseRepo, err := getBaseRepositoryForCollector(collectorType, c.collectorMapping)
+	if err != nil {
+		return 0, err
+	}
+
+	failures, err := baseRepo.GetFailures(ctx, id)
+	if err != nil {
+		return 0, fmt.Errorf("could not get failures of collector %s: %w", collectorType, err)
+	}
+
+	return failures, nil
+}
+
+func (c *CreateArchiveUseCase) setCollectorFailures(ctx context.Context, id domain.SupportArchiveID, collectorType domain.CollectorType, failures int) error {
+	baseRepo, err := getBaseRepositoryForCollector(collectorType, c.collectorMapping)
+	if err != nil {
+		return err
+	}
+
+	err = baseRepo.SetFailures(ctx, id, failures)
+	if err != nil {
+		return fmt.Errorf("could not record failures of collector %s: %w", collectorType, err)
+	}
+
+	return nil
+}
+
+// resolveContentTimeframes resolves the absolute content timeframes relative to the start of the archive creation.
+func (c *CreateArchiveUseCase) resolveContentTimeframes(cr *libapi.SupportArchive, startTime time.Time) (domain.ContentTimeframes, error) {
+	timeframes, err := domain.ResolveContentTimeframes(cr, c.defaultContentTimeframe, startTime)
+	if err != nil {
+		return domain.ContentTimeframes{}, fmt.Errorf("invalid content timeframe of archive %s/%s: %w", cr.Namespace, cr.Name, err)
 	}
 
-	endTime := cr.Spec.ContentTimeframe.EndTime
-	if endTime.Equal(&emptyTime) {
-		endTime = metav1.NewTime(now)
+	return timeframes, nil
+}
+
+// setArchivePhase sets the phase together with the resolved content timeframes, so that the status shows the absolute
+// timeframes of relative and collector-specific timeframes. The observed annotations are recorded to detect changes
+// of final phases, see domain.IsPhaseOutdated.
+func (c *CreateArchiveUseCase) setArchivePhase(status *libapi.SupportArchiveStatus, cr *libapi.SupportArchive, phase domain.ArchivePhase, startTime time.Time) {
+	domain.SetArchivePhase(status, phase, cr.Generation, startTime)
+	domain.SetObservedAnnotations(status, cr.Generation, cr.GetAnnotations())
+
+	timeframes, err := c.resolveContentTimeframes(cr, startTime)
+	if err != nil {
+		meta.SetStatusCondition(&status.Conditions, metav1.Condition{
+			Type:               domain.ConditionContentTimeframe,
+			Status:             metav1.ConditionFalse,
+			ObservedGeneration: cr.Generation,
+			LastTransitionTime: metav1.Now(),
+			Reason:             "Invalid",
+			Message:            err.Error(),
+		})
+		return
 	}
-	return startTime, endTime
+	meta.SetStatusCondition(&status.Conditions, timeframes.Condition(cr.Generation))
 }
 
-func (c *CreateArchiveUseCase) deleteUnusedRepositoryData(ctx context.Context, id domain.SupportArchiveID, requiredCollectorMapping CollectorMapping, executedCollectors []domain.CollectorType) {
+// deleteUnusedRepositoryData removes the data of executed collectors which are no longer required.
+// It returns the removed collectors.
+func (c *CreateArchiveUseCase) deleteUnusedRepositoryData(ctx context.Context, id domain.SupportArchiveID, requiredCollectorMapping CollectorMapping, executedCollectors []domain.CollectorType) []domain.CollectorType {
 	logger := log.FromContext(ctx).WithName("CreateArchiveUseCase.deleteUnusedRepositoryData")
+	var removedCollectors []domain.CollectorType
 	for _, col := range executedCollectors {
 		_, ok := requiredCollectorMapping[col]
 		if ok {
 			continue
 		}
+		removedCollectors = append(removedCollectors, col)
 		err := deleteCollectorRepositoryData(ctx, id, col, c.collectorMapping)
 		if err != nil {
 			logger.Error(err, "failed remove no longer required repository data", "collector", col)
 		}
 	}
+
+	return removedCollectors
+}
+
+// invalidateStaleCollectors deletes the data of executed collectors whose spec hash does not match the current spec,
+// so that they are executed again. It returns the remaining executed collectors and the invalidated collectors.
+// Data without a recorded spec hash is stale, too, because the spec it was collected with is unknown.
+func (c *CreateArchiveUseCase) invalidateStaleCollectors(ctx context.Context, id domain.SupportArchiveID, requiredCollectorMapping CollectorMapping, executedCollectors []domain.CollectorType, timeframes domain.ContentTimeframes) ([]domain.CollectorType, []domain.CollectorType, error) {
+	logger := log.FromContext(ctx).WithName("CreateArchiveUseCase.invalidateStaleCollectors")
+	var validCollectors, staleCollectors []domain.CollectorType
+	for _, col := range executedCollectors {
+		if _, ok := requiredCollectorMapping[col]; !ok {
+			continue
+		}
+
+		baseRepo, err := getBaseRepositoryForCollector(col, c.collectorMapping)
+		if err != nil {
+			return nil, nil, err
+		}
+		hash, err := baseRepo.GetSpecHash(ctx, id)
+		if err != nil {
+			return nil, nil, fmt.Errorf("failed to get spec hash of collector %s: %w", col, err)
+		}
+		if hash == domain.CollectorSpecHash(col, timeframes) {
+			validCollectors = append(validCollectors, col)
+			continue
+		}
+
+		logger.Info("collected data does not match the spec anymore", "collector", col)
+		err = deleteCollectorRepositoryData(ctx, id, col, c.collectorMapping)
+		if err != nil {
+			return nil, nil, fmt.Errorf("failed to delete stale data of collector %s: %w", col, err)
+		}
+		staleCollectors = append(staleCollectors, col)
+	}
+
+	return validCollectors, staleCollectors, nil
+}
+
+// recordSpecHash records the spec hash of the collector. If the hash cannot be recorded, the data of the collector
+// is deleted, so that the collector is executed again instead of keeping data of an unknown spec.
+func (c *CreateArchiveUseCase) recordSpecHash(ctx context.Context, id domain.SupportArchiveID, collectorType domain.CollectorType, timeframes domain.ContentTimeframes) error {
+	baseRepo, err := getBaseRepositoryForCollector(collectorType, c.collectorMapping)
+	if err != nil {
+		return err
+	}
+
+	err = baseRepo.SetSpecHash(ctx, id, domain.CollectorSpecHash(collectorType, timeframes))
+	if err != nil {
+		err = fmt.Errorf("could not record spec hash of collector %s: %w", collectorType, err)
+		deleteErr := deleteCollectorRepositoryData(ctx, id, collectorType, c.collectorMapping)
+		if deleteErr != nil {
+			return errors.Join(err, deleteErr)
+		}
+		return err
+	}
+
+	return nil
+}
+
+// rebuildArchive deletes an archive which does not match the spec anymore and restarts the archive creation.
+// The status is reset first, so that a failed deletion is retried with the next reconciliation.
+func (c *CreateArchiveUseCase) rebuildArchive(ctx context.Context, cr *libapi.SupportArchive, id domain.SupportArchiveID) (time.Duration, error) {
+	err := c.resetStatus(ctx, cr)
+	if err != nil {
+		return 0, err
+	}
+
+	err = c.supportArchiveRepository.Delete(ctx, id)
+	if err != nil {
+		return 0, fmt.Errorf("failed to delete outdated archive %s/%s: %w", cr.Namespace, cr.Name, err)
+	}
+
+	return time.Nanosecond, nil
+}
+
+// refreshArchive deletes the archive and the data of all collectors and restarts the archive creation.
+// The content timeframe of the refreshed archive ends at the start of the new archive creation.
+// The refresh is marked as handled with the domain.RefreshedAnnotation after the cleanup, so that a failed cleanup is
+// retried. The download path of the replaced archive is added to the download history.
+func (c *CreateArchiveUseCase) refreshArchive(ctx context.Context, cr *libapi.SupportArchive) (time.Duration, error) {
+	logger := log.FromContext(ctx).WithName("CreateArchiveUseCase.refreshArchive")
+	logger.Info("refreshing archive", "refresh", cr.GetAnnotations()[domain.RefreshAnnotation])
+	id := domain.SupportArchiveID{
+		Namespace: cr.GetNamespace(),
+		Name:      cr.GetName(),
+	}
+
+	history, err := domain.GetDownloadHistory(cr.GetAnnotations())
+	if err != nil {
+		logger.Error(err, "replacing invalid download history")
+	}
+	history = domain.AddToDownloadHistory(history, cr.Status.DownloadPath, time.Now())
+
+	err = deleteArchiveData(ctx, id, c.collectorMapping, c.supportArchiveRepository, c.inMemoryArchiveRepository)
+	if err != nil {
+		return 0, fmt.Errorf("could not delete archive %s/%s for refresh: %w", cr.Namespace, cr.Name, err)
+	}
+
+	err = c.resetStatus(ctx, cr)
+	if err != nil {
+		return 0, err
+	}
+
+	err = c.markRefreshed(ctx, cr, history)
+	if err != nil {
+		return 0, err
+	}
+
+	return time.Nanosecond, nil
+}
+
+// markRefreshed sets the handled refresh and the download history as annotations.
+func (c *CreateArchiveUseCase) markRefreshed(ctx context.Context, cr *libapi.SupportArchive, history []domain.DownloadHistoryEntry) error {
+	annotations := map[string]any{
+		domain.RefreshedAnnotation: cr.GetAnnotations()[domain.RefreshAnnotation],
+	}
+	if len(history) > 0 {
+		historyJSON, err := json.Marshal(history)
+		if err != nil {
+			return fmt.Errorf("failed to marshal download history: %w", err)
+		}
+		annotations[domain.DownloadHistoryAnnotation] = string(historyJSON)
+	}
+
+	patch, err := json.Marshal(map[string]any{"metadata": map[string]any{"annotations": annotations}})
+	if err != nil {
+		return fmt.Errorf("failed to marshal refresh patch: %w", err)
+	}
+
+	_, err = c.supportArchivesInterface.SupportArchives(cr.Namespace).Patch(ctx, cr.Name, types.MergePatchType, patch, metav1.PatchOptions{})
+	if err != nil {
+		return fmt.Errorf("failed to mark archive %s/%s as refreshed: %w", cr.Namespace, cr.Name, err)
+	}
+
+	return nil
+}
+
+// resetStatus removes the phase, the download path and the errors of the last archive creation.
+func (c *CreateArchiveUseCase) resetStatus(ctx context.Context, cr *libapi.SupportArchive) error {
+	client := c.supportArchivesInterface.SupportArchives(cr.Namespace)
+	_, err := client.UpdateStatusWithRetry(ctx, cr, func(status libapi.SupportArchiveStatus) libapi.SupportArchiveStatus {
+		domain.ResetArchivePhase(&status, cr.Generation)
+		meta.RemoveStatusCondition(&status.Conditions, libapi.ConditionSupportArchiveCreated)
+		status.DownloadPath = ""
+		status.Errors = nil
+		return status
+	}, metav1.UpdateOptions{})
+	if err != nil {
+		return fmt.Errorf("failed to reset status of archive %s/%s: %w", cr.Namespace, cr.Name, err)
+	}
+
+	return nil
 }
 
-func (c *CreateArchiveUseCase) createArchive(ctx context.Context, id domain.SupportArchiveID, requiredCollectors CollectorMapping) (string, error) {
+// createArchive creates the archive from all collected data.
+// It returns the reasons of skipped collectors which are added to the archive instead of the collected data.
+// The results of the post-processing, like the findings of the analysis, are added to the root of the archive.
+func (c *CreateArchiveUseCase) createArchive(ctx context.Context, id domain.SupportArchiveID, requiredCollectors CollectorMapping, timeframes domain.ContentTimeframes, compareTo *domain.SupportArchiveID) (string, map[domain.CollectorType]string, error) {
 	logger := log.FromContext(ctx).WithName("CreateArchiveUseCase.createArchive")
 	streamMap := make(map[domain.CollectorType]*domain.Stream)
+	skippedCollectors, err := c.getSkippedCollectors(ctx, id, requiredCollectors)
+	if err != nil {
+		return "", nil, err
+	}
 
 	errGroup, errCtx := errgroup.WithContext(ctx)
+	var collectedCollectors []domain.CollectorType
 	for col := range requiredCollectors {
+		if _, skipped := skippedCollectors[col]; skipped {
+			logger.Info("collector was skipped", "collector", col)
+			continue
+		}
+		collectedCollectors = append(collectedCollectors, col)
+
 		var stream *domain.Stream
 		var err error
 		logger.Info("collecting stream for collector", "collector", col)
@@ -173,34 +955,86 @@ func (c *CreateArchiveUseCase) createArchive(ctx context.Context, id domain.Supp
 		case domain.CollectorTypeSecret:
 			stream, err = fetchRepoAndStreamWithErrorGroup[domain.SecretYaml](errCtx, errGroup, col, c.collectorMapping, id)
 		case domain.CollectorTypeEvents:
-			stream, err = fetchRepoAndStreamWithErrorGroup[domain.LogLine](errCtx, errGroup, col, c.collectorMapping, id)
+			stream, err = fetchRepoAndStreamWithErrorGroup[domain.Event](errCtx, errGroup, col, c.collectorMapping, id)
 		case domain.CollectorTypeSystemState:
 			stream, err = fetchRepoAndStreamWithErrorGroup[domain.UnstructuredResource](errCtx, errGroup, col, c.collectorMapping, id)
+		case domain.CollectorTypeNodeStatus:
+			stream, err = fetchRepoAndStreamWithErrorGroup[domain.NodeStatus](errCtx, errGroup, col, c.collectorMapping, id)
+		case domain.CollectorTypeHelmRelease:
+			stream, err = fetchRepoAndStreamWithErrorGroup[domain.HelmRelease](errCtx, errGroup, col, c.collectorMapping, id)
 		default:
-			return "", errors.New("invalid collector type")
+			return "", nil, errors.New("invalid collector type")
 		}
 
 		if err != nil {
-			return "", err
+			return "", nil, err
 		}
 
 		streamMap[col] = stream
 	}
 
+	if len(skippedCollectors) > 0 {
+		streamMap[domain.ArchiveErrorsDir] = newSkippedCollectorsStream(skippedCollectors)
+	}
+
+	defer c.deletePostProcessingResults(ctx, id)
+	slices.Sort(collectedCollectors)
+	rootStream := c.postProcess(errCtx, errGroup, id, collectedCollectors, skippedCollectors, timeframes, compareTo)
+	if rootStream != nil {
+		streamMap[domain.ArchiveRootDir] = rootStream
+	}
+
 	var url string
 	errGroup.Go(func() error {
 		var createErr error
-		url, createErr = c.supportArchiveRepository.Create(errCtx, id, streamMap)
+		url, createErr = c.supportArchiveRepository.Create(errCtx, id, streamMap, timeframes.Default.End)
 		return createErr
 	})
 
-	err := errGroup.Wait()
+	err = errGroup.Wait()
 	if err != nil {
-		return "", fmt.Errorf("error creating support archive: %w", err)
+		return "", nil, fmt.Errorf("error creating support archive: %w", err)
 	}
 	logger.Info("Created support archive successfully")
 
-	return url, nil
+	return url, skippedCollectors, nil
+}
+
+func (c *CreateArchiveUseCase) getSkippedCollectors(ctx context.Context, id domain.SupportArchiveID, requiredCollectors CollectorMapping) (map[domain.CollectorType]string, error) {
+	skippedCollectors := make(map[domain.CollectorType]string)
+	for col := range requiredCollectors {
+		baseRepo, err := getBaseRepositoryForCollector(col, c.collectorMapping)
+		if err != nil {
+			return nil, err
+		}
+
+		skipped, reason, err := baseRepo.IsSkipped(ctx, id)
+		if err != nil {
+			return nil, fmt.Errorf("failed to determine if collector %s is skipped: %w", col, err)
+		}
+		if skipped {
+			skippedCollectors[col] = reason
+		}
+	}
+
+	return skippedCollectors, nil
+}
+
+// newSkippedCollectorsStream creates a stream with an explanation file for each skipped collector sorted by collector.
+func newSkippedCollectorsStream(skippedCollectors map[domain.CollectorType]string) *domain.Stream {
+	data := make(chan domain.StreamData, len(skippedCollectors))
+	for _, col := range sortedCollectorTypes(skippedCollectors) {
+		reason := skippedCollectors[col]
+		data <- domain.StreamData{
+			ID: fmt.Sprintf("%s.txt", col),
+			StreamConstructor: func() (io.Reader, domain.CloseStreamFunc, error) {
+				return strings.NewReader(reason), func() error { return nil }, nil
+			},
+		}
+	}
+	close(data)
+
+	return &domain.Stream{Data: data}
 }
 
 func fetchRepoAndStreamWithErrorGroup[DATATYPE domain.CollectorUnionDataType](errCtx context.Context, group *errgroup.Group, col domain.CollectorType, collectorMapping CollectorMapping, id domain.SupportArchiveID) (*domain.Stream, error) {
@@ -224,9 +1058,7 @@ func fetchRepoAndStreamWithErrorGroup[DATATYPE domain.CollectorUnionDataType](er
 	return stream, nil
 }
 
-func (c *CreateArchiveUseCase) setConditionForCollector(ctx context.Context, cr *libapi.SupportArchive, collectorType domain.CollectorType, err error) error {
-	logger := log.FromContext(ctx).WithName("CreateArchiveUseCase.setConditionForCollector")
-	client := c.supportArchivesInterface.SupportArchives(cr.Namespace)
+func (c *CreateArchiveUseCase) setConditionForCollector(ctx context.Context, cr *libapi.SupportArchive, collectorType domain.CollectorType, err error, startTime time.Time) error {
 	var condition metav1.Condition
 	if err == nil {
 		condition = getSuccessfulCollectorCondition(collectorType)
@@ -234,8 +1066,15 @@ func (c *CreateArchiveUseCase) setConditionForCollector(ctx context.Context, cr
 		condition = getErrorCollectorCondition(collectorType, err)
 	}
 
-	_, err = client.UpdateStatusWithRetry(ctx, cr, func(status libapi.SupportArchiveStatus) libapi.SupportArchiveStatus {
+	return c.setCollectorCondition(ctx, cr, collectorType, condition, startTime)
+}
+
+func (c *CreateArchiveUseCase) setCollectorCondition(ctx context.Context, cr *libapi.SupportArchive, collectorType domain.CollectorType, condition metav1.Condition, startTime time.Time) error {
+	logger := log.FromContext(ctx).WithName("CreateArchiveUseCase.setCollectorCondition")
+	client := c.supportArchivesInterface.SupportArchives(cr.Namespace)
+	_, err := client.UpdateStatusWithRetry(ctx, cr, func(status libapi.SupportArchiveStatus) libapi.SupportArchiveStatus {
 		meta.SetStatusCondition(&status.Conditions, condition)
+		c.setArchivePhase(&status, cr, domain.ArchivePhaseCollecting, startTime)
 		return status
 	}, metav1.UpdateOptions{})
 	if err != nil {
@@ -245,23 +1084,77 @@ func (c *CreateArchiveUseCase) setConditionForCollector(ctx context.Context, cr
 	return nil
 }
 
-func (c *CreateArchiveUseCase) updateFinalStatus(ctx context.Context, cr *libapi.SupportArchive, url string) error {
+// updateFinalStatus sets the download url and the succeeded phase. It returns the duration after which the download
+// token has to be renewed or 0 if download tokens are disabled.
+func (c *CreateArchiveUseCase) updateFinalStatus(ctx context.Context, cr *libapi.SupportArchive, url string, skippedCollectors map[domain.CollectorType]string, startTime time.Time) (time.Duration, error) {
 	logger := log.FromContext(ctx).WithName("CreateArchiveUseCase.updateFinalStatus")
 	client := c.supportArchivesInterface.SupportArchives(cr.Namespace)
+	condition := getSuccessfulArchiveCreatedCondition(url)
+	var skipErrors []string
+	if len(skippedCollectors) > 0 {
+		condition = getPartiallyArchiveCreatedCondition(url, skippedCollectors)
+		for _, col := range sortedCollectorTypes(skippedCollectors) {
+			skipErrors = append(skipErrors, skippedCollectors[col])
+		}
+	}
+
+	// The condition contains the url without token because conditions are not updated when the token is renewed.
+	downloadPath, renewAfter := c.signDownloadURL(cr, url, time.Now())
 	_, err := client.UpdateStatusWithRetry(ctx, cr, func(status libapi.SupportArchiveStatus) libapi.SupportArchiveStatus {
-		meta.SetStatusCondition(&status.Conditions, getSuccessfulArchiveCreatedCondition(url))
-		status.DownloadPath = url
+		meta.SetStatusCondition(&status.Conditions, condition)
+		c.setArchivePhase(&status, cr, domain.ArchivePhaseSucceeded, startTime)
+		status.Errors = skipErrors
+		status.DownloadPath = downloadPath
 		return status
 	}, metav1.UpdateOptions{})
 	if err != nil {
-		return fmt.Errorf("failed to set status for archive %s/%s: %w", cr.Namespace, cr.Name, err)
+		return 0, fmt.Errorf("failed to set status for archive %s/%s: %w", cr.Namespace, cr.Name, err)
 	}
 	logger.Info("Successfully set download url for archive for target", "url", url)
 
-	return nil
+	return renewAfter, nil
+}
+
+// signDownloadURL adds a download token to the url if download tokens are enabled.
+// It returns the url and the duration after which the token has to be renewed.
+func (c *CreateArchiveUseCase) signDownloadURL(cr *libapi.SupportArchive, url string, now time.Time) (string, time.Duration) {
+	if c.downloadURLSigner == nil {
+		return url, 0
+	}
+
+	signedURL, expiresAt := c.downloadURLSigner.Sign(cr, url, now)
+	return signedURL, expiresAt.Add(-downloadTokenRenewalMargin).Sub(now)
+}
+
+// renewDownloadToken replaces the download token on the status if it expires soon.
+// It returns the duration after which the token has to be renewed again.
+func (c *CreateArchiveUseCase) renewDownloadToken(ctx context.Context, cr *libapi.SupportArchive) (time.Duration, error) {
+	logger := log.FromContext(ctx).WithName("CreateArchiveUseCase.renewDownloadToken")
+	if c.downloadURLSigner == nil || cr.Status.DownloadPath == "" {
+		return 0, nil
+	}
+
+	now := time.Now()
+	expiresAt, hasToken := c.downloadURLSigner.ExpiresAt(cr.Status.DownloadPath)
+	renewAt := expiresAt.Add(-downloadTokenRenewalMargin)
+	if hasToken && now.Before(renewAt) {
+		return renewAt.Sub(now), nil
+	}
+
+	downloadPath, renewAfter := c.signDownloadURL(cr, cr.Status.DownloadPath, now)
+	_, err := c.supportArchivesInterface.SupportArchives(cr.Namespace).UpdateStatusWithRetry(ctx, cr, func(status libapi.SupportArchiveStatus) libapi.SupportArchiveStatus {
+		status.DownloadPath = downloadPath
+		return status
+	}, metav1.UpdateOptions{})
+	if err != nil {
+		return 0, fmt.Errorf("failed to renew download token of archive %s/%s: %w", cr.Namespace, cr.Name, err)
+	}
+	logger.Info("Renewed download token")
+
+	return renewAfter, nil
 }
 
-func (c *CreateArchiveUseCase) executeNextCollector(ctx context.Context, id domain.SupportArchiveID, next domain.CollectorType, startTime metav1.Time, endTime metav1.Time) error {
+func (c *CreateArchiveUseCase) executeNextCollector(ctx context.Context, id domain.SupportArchiveID, next domain.CollectorType, timeframe domain.Timeframe) error {
 	var err error
 	switch next {
 	case domain.CollectorTypeLog:
@@ -270,42 +1163,56 @@ func (c *CreateArchiveUseCase) executeNextCollector(ctx context.Context, id doma
 			return typeErr
 		}
 
-		err = startCollector(ctx, id, startTime.Time, endTime.Time, col, repo)
+		err = startCollector(ctx, id, timeframe.Start, timeframe.End, col, repo)
 	case domain.CollectorTypeVolumeInfo:
 		col, repo, typeErr := getCollectorAndRepositoryForType[domain.VolumeInfo](next, c.collectorMapping)
 		if typeErr != nil {
 			return typeErr
 		}
 
-		err = startCollector(ctx, id, startTime.Time, endTime.Time, col, repo)
+		err = startCollector(ctx, id, timeframe.Start, timeframe.End, col, repo)
 	case domain.CollectorTypeSecret:
 		col, repo, typeErr := getCollectorAndRepositoryForType[domain.SecretYaml](next, c.collectorMapping)
 		if typeErr != nil {
 			return typeErr
 		}
 
-		err = startCollector(ctx, id, startTime.Time, endTime.Time, col, repo)
+		err = startCollector(ctx, id, timeframe.Start, timeframe.End, col, repo)
 	case domain.CollectorTypeNodeInfo:
 		col, repo, typeErr := getCollectorAndRepositoryForType[domain.LabeledSample](next, c.collectorMapping)
 		if typeErr != nil {
 			return typeErr
 		}
 
-		err = startCollector(ctx, id, startTime.Time, endTime.Time, col, repo)
+		err = startCollector(ctx, id, timeframe.Start, timeframe.End, col, repo)
 	case domain.CollectorTypeEvents:
-		col, repo, typeErr := getCollectorAndRepositoryForType[domain.LogLine](next, c.collectorMapping)
+		col, repo, typeErr := getCollectorAndRepositoryForType[domain.Event](next, c.collectorMapping)
 		if typeErr != nil {
 			return typeErr
 		}
 
-		err = startCollector(ctx, id, startTime.Time, endTime.Time, col, repo)
+		err = startCollector(ctx, id, timeframe.Start, timeframe.End, col, repo)
 	case domain.CollectorTypeSystemState:
 		col, repo, typeErr := getCollectorAndRepositoryForType[domain.UnstructuredResource](next, c.collectorMapping)
 		if typeErr != nil {
 			return typeErr
 		}
 
-		err = startCollector(ctx, id, startTime.Time, endTime.Time, col, repo)
+		err = startCollector(ctx, id, timeframe.Start, timeframe.End, col, repo)
+	case domain.CollectorTypeNodeStatus:
+		col, repo, typeErr := getCollectorAndRepositoryForType[domain.NodeStatus](next, c.collectorMapping)
+		if typeErr != nil {
+			return typeErr
+		}
+
+		err = startCollector(ctx, id, timeframe.Start, timeframe.End, col, repo)
+	case domain.CollectorTypeHelmRelease:
+		col, repo, typeErr := getCollectorAndRepositoryForType[domain.HelmRelease](next, c.collectorMapping)
+		if typeErr != nil {
+			return typeErr
+		}
+
+		err = startCollector(ctx, id, timeframe.Start, timeframe.End, col, repo)
 	default:
 		return fmt.Errorf("collector type %s is not supported", next)
 	}
@@ -368,6 +1275,13 @@ func (c *CreateArchiveUseCase) getAlreadyExecutedCollectors(ctx context.Context,
 		if err != nil {
 			return nil, fmt.Errorf("failed to determine if collector %s is already finished: %w", colType, err)
 		}
+		if !finished {
+			// Skipped collectors are treated as executed because they are not retried anymore.
+			finished, _, err = baseRepo.IsSkipped(ctx, id)
+			if err != nil {
+				return nil, fmt.Errorf("failed to determine if collector %s is skipped: %w", colType, err)
+			}
+		}
 		if !finished {
 			logger.Info(fmt.Sprintf("collector %s is not finished", colType))
 			continue
@@ -408,6 +1322,51 @@ func getSuccessfulArchiveCreatedCondition(downloadURL string) metav1.Condition {
 	}
 }
 
+func getDeadlineExceededArchiveCreatedCondition(deadline time.Duration) metav1.Condition {
+	return metav1.Condition{
+		Type:               libapi.ConditionSupportArchiveCreated,
+		Status:             metav1.ConditionFalse,
+		LastTransitionTime: metav1.Time{Time: time.Now()},
+		Reason:             "DeadlineExceeded",
+		Message:            fmt.Sprintf("The support archive was not created within the deadline of %s", deadline),
+	}
+}
+
+func getInvalidTimeframeArchiveCreatedCondition(err error) metav1.Condition {
+	return metav1.Condition{
+		Type:               libapi.ConditionSupportArchiveCreated,
+		Status:             metav1.ConditionFalse,
+		LastTransitionTime: metav1.Time{Time: time.Now()},
+		Reason:             "InvalidContentTimeframe",
+		Message:            err.Error(),
+	}
+}
+
+func getPartiallyArchiveCreatedCondition(downloadURL string, skippedCollectors map[domain.CollectorType]string) metav1.Condition {
+	var skipped []string
+	for _, col := range sortedCollectorTypes(skippedCollectors) {
+		skipped = append(skipped, string(col))
+	}
+
+	return metav1.Condition{
+		Type:               libapi.ConditionSupportArchiveCreated,
+		Status:             metav1.ConditionTrue,
+		LastTransitionTime: metav1.Time{Time: time.Now()},
+		Reason:             "PartialSuccess",
+		Message:            fmt.Sprintf("It is available for download under following url: %s. Skipped collectors: %s", downloadURL, strings.Join(skipped, ", ")),
+	}
+}
+
+func sortedCollectorTypes(collectors map[domain.CollectorType]string) []domain.CollectorType {
+	result := make([]domain.CollectorType, 0, len(collectors))
+	for col := range collectors {
+		result = append(result, col)
+	}
+	slices.Sort(result)
+
+	return result
+}
+
 func getSuccessfulCollectorCondition(collectorType domain.CollectorType) metav1.Condition {
 	return metav1.Condition{
 		Type:               collectorType.GetConditionType(),
@@ -428,6 +1387,27 @@ func getErrorCollectorCondition(collectorType domain.CollectorType, err error) m
 	}
 }
 
+func getSkippedCollectorCondition(collectorType domain.CollectorType, reason string) metav1.Condition {
+	return metav1.Condition{
+		Type:               collectorType.GetConditionType(),
+		Status:             metav1.ConditionFalse,
+		LastTransitionTime: metav1.Time{Time: time.Now()},
+		Reason:             "CollectorSkipped",
+		Message:            reason,
+	}
+}
+
+// getEstimatedCollectorCondition is false because nothing is fetched in a dry run.
+func getEstimatedCollectorCondition(collectorType domain.CollectorType, reason, message string) metav1.Condition {
+	return metav1.Condition{
+		Type:               collectorType.GetConditionType(),
+		Status:             metav1.ConditionFalse,
+		LastTransitionTime: metav1.Time{Time: time.Now()},
+		Reason:             reason,
+		Message:            message,
+	}
+}
+
 func streamFromRepository[DATATYPE domain.CollectorUnionDataType](ctx context.Context, repository collectorRepository[DATATYPE], id domain.SupportArchiveID, stream *domain.Stream) error {
 	isCollected, err := repository.IsCollected(ctx, id)
 	if err != nil {
@@ -2,12 +2,17 @@ package usecase
 
 import (
 	"context"
+	"encoding/json"
+	"fmt"
 	libapi "github.com/cloudogu/k8s-support-archive-lib/api/v1"
 	"github.com/cloudogu/k8s-support-archive-operator/pkg/domain"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/mock"
 	"github.com/stretchr/testify/require"
+	"io"
+	"k8s.io/apimachinery/pkg/api/meta"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
 	"testing"
 	"time"
 )
@@ -20,11 +25,34 @@ const (
 
 var testCtx = context.Background()
 
+var (
+	// testLogSpecHash is the spec hash of the log collector of the testLogCR.
+	testLogSpecHash  = domain.CollectorSpecHash(domain.CollectorTypeLog, domain.ContentTimeframes{Default: domain.Timeframe{Start: testStart, End: testEnd}})
+	testStartedLogCR = &libapi.SupportArchive{
+		ObjectMeta: testLogCR.ObjectMeta,
+		Spec:       testLogCR.Spec,
+		Status: libapi.SupportArchiveStatus{Conditions: []metav1.Condition{
+			{Type: domain.ConditionCollectionStarted, Status: metav1.ConditionTrue, LastTransitionTime: metav1.NewTime(time.Now().Add(-time.Hour))},
+			{Type: domain.ConditionPhase, Status: metav1.ConditionTrue, Reason: string(domain.ArchivePhaseCollecting)},
+		}},
+	}
+	testFailedLogCR = &libapi.SupportArchive{
+		ObjectMeta: testLogCR.ObjectMeta,
+		Spec:       testLogCR.Spec,
+		Status: libapi.SupportArchiveStatus{Conditions: []metav1.Condition{
+			{Type: domain.ConditionPhase, Status: metav1.ConditionTrue, Reason: string(domain.ArchivePhaseFailed)},
+		}},
+	}
+)
+
 func TestCreateArchiveUseCase_HandleArchiveRequest(t *testing.T) {
 	type fields struct {
 		supportArchivesInterface func(t *testing.T) supportArchiveV1Interface
 		supportArchiveRepository func(t *testing.T) supportArchiveRepository
 		collectorMapping         func(t *testing.T) CollectorMapping
+		postProcessing           func(t *testing.T) PostProcessingRepositories
+		collectorMaxRetries      int
+		archiveDeadline          time.Duration
 	}
 	type args struct {
 		ctx context.Context
@@ -37,6 +65,62 @@ func TestCreateArchiveUseCase_HandleArchiveRequest(t *testing.T) {
 		want    time.Duration
 		wantErr func(t *testing.T, err error)
 	}{
+		{
+			name: "should do nothing if the archive creation failed permanently",
+			args: args{
+				ctx: testCtx,
+				cr:  testFailedLogCR,
+			},
+			want: 0,
+			wantErr: func(t *testing.T, err error) {
+				require.NoError(t, err)
+			},
+		},
+		{
+			name: "should fail archive if the deadline exceeded",
+			fields: fields{
+				collectorMapping: func(t *testing.T) CollectorMapping {
+					collectorMapping := CollectorMapping{}
+					logRepository := newMockCollectorRepository[domain.LogLine](t)
+					logRepository.EXPECT().IsCollected(testCtx, testID).Return(false, nil)
+					logRepository.EXPECT().IsSkipped(testCtx, testID).Return(false, "", nil)
+
+					collectorMapping[domain.CollectorTypeLog] = CollectorAndRepository{Repository: logRepository, Collector: newMockCollector[domain.LogLine](t)}
+					return collectorMapping
+				},
+				supportArchiveRepository: func(t *testing.T) supportArchiveRepository {
+					repoMock := newMockSupportArchiveRepository(t)
+					repoMock.EXPECT().Exists(testCtx, testID).Return(false, nil)
+					return repoMock
+				},
+				supportArchivesInterface: func(t *testing.T) supportArchiveV1Interface {
+					interfaceMock := newMockSupportArchiveV1Interface(t)
+					clientMock := newMockSupportArchiveInterface(t)
+					interfaceMock.EXPECT().SupportArchives(testArchiveNamespace).Return(clientMock)
+					clientMock.EXPECT().UpdateStatusWithRetry(testCtx, testStartedLogCR, mock.Anything, metav1.UpdateOptions{}).Return(nil, nil).Run(func(ctx context.Context, cr *libapi.SupportArchive, modifyStatusFn func(libapi.SupportArchiveStatus) libapi.SupportArchiveStatus, opts metav1.UpdateOptions) {
+						updatedCRStatus := modifyStatusFn(cr.Status)
+						assert.Equal(t, domain.ArchivePhaseFailed, domain.GetArchivePhase(updatedCRStatus))
+						condition := meta.FindStatusCondition(updatedCRStatus.Conditions, libapi.ConditionSupportArchiveCreated)
+						require.NotNil(t, condition)
+						assert.Equal(t, metav1.ConditionFalse, condition.Status)
+						assert.Equal(t, "DeadlineExceeded", condition.Reason)
+						started := meta.FindStatusCondition(updatedCRStatus.Conditions, domain.ConditionCollectionStarted)
+						require.NotNil(t, started)
+						assert.True(t, started.LastTransitionTime.Equal(&testStartedLogCR.Status.Conditions[0].LastTransitionTime))
+					})
+					return interfaceMock
+				},
+				archiveDeadline: time.Minute,
+			},
+			args: args{
+				ctx: testCtx,
+				cr:  testStartedLogCR,
+			},
+			want: 0,
+			wantErr: func(t *testing.T, err error) {
+				require.NoError(t, err)
+			},
+		},
 		{
 			name: "should return false if archive already exists",
 			fields: fields{
@@ -44,6 +128,7 @@ func TestCreateArchiveUseCase_HandleArchiveRequest(t *testing.T) {
 					collectorMapping := CollectorMapping{}
 					logRepository := newMockCollectorRepository[domain.LogLine](t)
 					logRepository.EXPECT().IsCollected(testCtx, testID).Return(true, nil)
+					logRepository.EXPECT().GetSpecHash(testCtx, testID).Return(testLogSpecHash, nil)
 
 					collectorMapping[domain.CollectorTypeLog] = CollectorAndRepository{Repository: logRepository}
 					return collectorMapping
@@ -93,6 +178,7 @@ func TestCreateArchiveUseCase_HandleArchiveRequest(t *testing.T) {
 					collectorMapping := CollectorMapping{}
 					logRepository := newMockCollectorRepository[domain.LogLine](t)
 					logRepository.EXPECT().IsCollected(testCtx, testID).Return(true, nil)
+					logRepository.EXPECT().GetSpecHash(testCtx, testID).Return(testLogSpecHash, nil)
 
 					collectorMapping[domain.CollectorTypeLog] = CollectorAndRepository{Repository: logRepository}
 					return collectorMapping
@@ -121,7 +207,11 @@ func TestCreateArchiveUseCase_HandleArchiveRequest(t *testing.T) {
 					collectorMapping := CollectorMapping{}
 					logRepository := newMockCollectorRepository[domain.LogLine](t)
 					logRepository.EXPECT().IsCollected(testCtx, testID).Return(false, nil)
+					logRepository.EXPECT().IsSkipped(testCtx, testID).Return(false, "", nil)
 					logRepository.EXPECT().Create(mock.AnythingOfType("*context.cancelCtx"), testID, mock.AnythingOfType("<-chan *domain.LogLine")).Return(nil)
+					logRepository.EXPECT().SetSpecHash(testCtx, testID, mock.AnythingOfType("string")).Return(nil)
+					logRepository.EXPECT().GetFailures(testCtx, testID).Return(0, nil)
+					logRepository.EXPECT().SetFailures(testCtx, testID, 1).Return(nil)
 					logCollector := newMockCollector[domain.LogLine](t)
 					logCollector.EXPECT().Collect(mock.AnythingOfType("*context.cancelCtx"), testArchiveNamespace, mock.Anything, mock.Anything, mock.AnythingOfType("chan<- *domain.LogLine")).Return(nil)
 
@@ -139,6 +229,8 @@ func TestCreateArchiveUseCase_HandleArchiveRequest(t *testing.T) {
 					interfaceMock.EXPECT().SupportArchives(testArchiveNamespace).Return(clientMock)
 					clientMock.EXPECT().UpdateStatusWithRetry(testCtx, testLogCR, mock.Anything, metav1.UpdateOptions{}).Return(nil, nil).Run(func(ctx context.Context, cr *libapi.SupportArchive, modifyStatusFn func(libapi.SupportArchiveStatus) libapi.SupportArchiveStatus, opts metav1.UpdateOptions) {
 						updatedCRStatus := modifyStatusFn(cr.Status)
+						assert.Equal(t, domain.ArchivePhaseCollecting, domain.GetArchivePhase(updatedCRStatus))
+						assert.NotNil(t, meta.FindStatusCondition(updatedCRStatus.Conditions, domain.ConditionCollectionStarted))
 						for _, cond := range updatedCRStatus.Conditions {
 							if cond.Type == "LogsFetched" && cond.Status == ("True") {
 								return
@@ -165,6 +257,10 @@ func TestCreateArchiveUseCase_HandleArchiveRequest(t *testing.T) {
 					collectorMapping := CollectorMapping{}
 					logRepository := newMockCollectorRepository[domain.LogLine](t)
 					logRepository.EXPECT().IsCollected(testCtx, testID).Return(false, nil)
+					logRepository.EXPECT().IsSkipped(testCtx, testID).Return(false, "", nil)
+					logRepository.EXPECT().SetSpecHash(testCtx, testID, testLogSpecHash).Return(nil)
+					logRepository.EXPECT().GetFailures(testCtx, testID).Return(0, nil)
+					logRepository.EXPECT().SetFailures(testCtx, testID, 1).Return(nil).Times(2)
 					logRepository.EXPECT().Create(mock.AnythingOfType("*context.cancelCtx"), testID, mock.AnythingOfType("<-chan *domain.LogLine")).Return(nil)
 					logCollector := newMockCollector[domain.LogLine](t)
 					logCollector.EXPECT().Name().Return("Logs")
@@ -193,6 +289,7 @@ func TestCreateArchiveUseCase_HandleArchiveRequest(t *testing.T) {
 					})
 					return interfaceMock
 				},
+				collectorMaxRetries: 3,
 			},
 			args: args{
 				ctx: testCtx,
@@ -204,6 +301,266 @@ func TestCreateArchiveUseCase_HandleArchiveRequest(t *testing.T) {
 				assert.ErrorContains(t, err, "could not execute next collector: failed to execute collector Logs: error from error group Logs")
 			},
 		},
+		{
+			name: "should skip collector and requeue if maximum retries are exceeded",
+			fields: fields{
+				collectorMapping: func(t *testing.T) CollectorMapping {
+					collectorMapping := CollectorMapping{}
+					logRepository := newMockCollectorRepository[domain.LogLine](t)
+					logRepository.EXPECT().IsCollected(testCtx, testID).Return(false, nil)
+					logRepository.EXPECT().IsSkipped(testCtx, testID).Return(false, "", nil)
+					logRepository.EXPECT().Create(mock.AnythingOfType("*context.cancelCtx"), testID, mock.AnythingOfType("<-chan *domain.LogLine")).Return(nil)
+					logRepository.EXPECT().GetFailures(testCtx, testID).Return(3, nil)
+					logRepository.EXPECT().SetFailures(testCtx, testID, 4).Return(nil)
+					logRepository.EXPECT().Skip(testCtx, testID, mock.AnythingOfType("string")).Return(nil).Run(func(ctx context.Context, id domain.SupportArchiveID, reason string) {
+						assert.Contains(t, reason, "Collector Logs was skipped after 4 failed attempts")
+						assert.Contains(t, reason, assert.AnError.Error())
+					})
+					logRepository.EXPECT().SetSpecHash(testCtx, testID, mock.AnythingOfType("string")).Return(nil)
+					logCollector := newMockCollector[domain.LogLine](t)
+					logCollector.EXPECT().Name().Return("Logs")
+					logCollector.EXPECT().Collect(mock.AnythingOfType("*context.cancelCtx"), testArchiveNamespace, mock.Anything, mock.Anything, mock.AnythingOfType("chan<- *domain.LogLine")).Return(assert.AnError)
+
+					collectorMapping[domain.CollectorTypeLog] = CollectorAndRepository{Repository: logRepository, Collector: logCollector}
+					return collectorMapping
+				},
+				supportArchiveRepository: func(t *testing.T) supportArchiveRepository {
+					repoMock := newMockSupportArchiveRepository(t)
+					repoMock.EXPECT().Exists(testCtx, testID).Return(false, nil)
+					return repoMock
+				},
+				supportArchivesInterface: func(t *testing.T) supportArchiveV1Interface {
+					interfaceMock := newMockSupportArchiveV1Interface(t)
+					clientMock := newMockSupportArchiveInterface(t)
+					interfaceMock.EXPECT().SupportArchives(testArchiveNamespace).Return(clientMock)
+					clientMock.EXPECT().UpdateStatusWithRetry(testCtx, testLogCR, mock.Anything, metav1.UpdateOptions{}).Return(nil, nil).Run(func(ctx context.Context, cr *libapi.SupportArchive, modifyStatusFn func(libapi.SupportArchiveStatus) libapi.SupportArchiveStatus, opts metav1.UpdateOptions) {
+						updatedCRStatus := modifyStatusFn(cr.Status)
+						for _, cond := range updatedCRStatus.Conditions {
+							if cond.Type == "LogsFetched" && cond.Status == ("False") && cond.Reason == "CollectorSkipped" {
+								return
+							}
+						}
+						t.FailNow()
+					})
+					return interfaceMock
+				},
+				collectorMaxRetries: 3,
+			},
+			args: args{
+				ctx: testCtx,
+				cr:  testLogCR,
+			},
+			want: time.Nanosecond,
+			wantErr: func(t *testing.T, err error) {
+				require.NoError(t, err)
+			},
+		},
+		{
+			name: "should return error on error skipping collector",
+			fields: fields{
+				collectorMapping: func(t *testing.T) CollectorMapping {
+					collectorMapping := CollectorMapping{}
+					logRepository := newMockCollectorRepository[domain.LogLine](t)
+					logRepository.EXPECT().IsCollected(testCtx, testID).Return(false, nil)
+					logRepository.EXPECT().IsSkipped(testCtx, testID).Return(false, "", nil)
+					logRepository.EXPECT().SetSpecHash(testCtx, testID, testLogSpecHash).Return(nil)
+					logRepository.EXPECT().GetFailures(testCtx, testID).Return(0, nil)
+					logRepository.EXPECT().SetFailures(testCtx, testID, 1).Return(nil)
+					logRepository.EXPECT().Create(mock.AnythingOfType("*context.cancelCtx"), testID, mock.AnythingOfType("<-chan *domain.LogLine")).Return(nil)
+					logRepository.EXPECT().Skip(testCtx, testID, mock.AnythingOfType("string")).Return(assert.AnError)
+					logCollector := newMockCollector[domain.LogLine](t)
+					logCollector.EXPECT().Name().Return("Logs")
+					logCollector.EXPECT().Collect(mock.AnythingOfType("*context.cancelCtx"), testArchiveNamespace, mock.Anything, mock.Anything, mock.AnythingOfType("chan<- *domain.LogLine")).Return(assert.AnError)
+
+					collectorMapping[domain.CollectorTypeLog] = CollectorAndRepository{Repository: logRepository, Collector: logCollector}
+					return collectorMapping
+				},
+				supportArchiveRepository: func(t *testing.T) supportArchiveRepository {
+					repoMock := newMockSupportArchiveRepository(t)
+					repoMock.EXPECT().Exists(testCtx, testID).Return(false, nil)
+					return repoMock
+				},
+			},
+			args: args{
+				ctx: testCtx,
+				cr:  testLogCR,
+			},
+			want: 0,
+			wantErr: func(t *testing.T, err error) {
+				require.Error(t, err)
+				assert.ErrorIs(t, err, assert.AnError)
+				assert.ErrorContains(t, err, "could not skip collector Logs")
+			},
+		},
+		{
+			name: "should skip collector without executing it if aborted attempts exceeded the maximum retries",
+			fields: fields{
+				collectorMapping: func(t *testing.T) CollectorMapping {
+					collectorMapping := CollectorMapping{}
+					logRepository := newMockCollectorRepository[domain.LogLine](t)
+					logRepository.EXPECT().IsCollected(testCtx, testID).Return(false, nil)
+					logRepository.EXPECT().IsSkipped(testCtx, testID).Return(false, "", nil)
+					logRepository.EXPECT().SetSpecHash(testCtx, testID, testLogSpecHash).Return(nil).Times(2)
+					logRepository.EXPECT().GetFailures(testCtx, testID).Return(4, nil)
+					logRepository.EXPECT().Skip(testCtx, testID, mock.AnythingOfType("string")).Return(nil).Run(func(ctx context.Context, id domain.SupportArchiveID, reason string) {
+						assert.Equal(t, "Collector Logs was skipped after 4 failed attempts. Last error: the collection was aborted", reason)
+					})
+
+					collectorMapping[domain.CollectorTypeLog] = CollectorAndRepository{Repository: logRepository, Collector: newMockCollector[domain.LogLine](t)}
+					return collectorMapping
+				},
+				supportArchiveRepository: func(t *testing.T) supportArchiveRepository {
+					repoMock := newMockSupportArchiveRepository(t)
+					repoMock.EXPECT().Exists(testCtx, testID).Return(false, nil)
+					return repoMock
+				},
+				supportArchivesInterface: func(t *testing.T) supportArchiveV1Interface {
+					interfaceMock := newMockSupportArchiveV1Interface(t)
+					clientMock := newMockSupportArchiveInterface(t)
+					interfaceMock.EXPECT().SupportArchives(testArchiveNamespace).Return(clientMock)
+					clientMock.EXPECT().UpdateStatusWithRetry(testCtx, testLogCR, mock.Anything, metav1.UpdateOptions{}).Return(nil, nil)
+					return interfaceMock
+				},
+				collectorMaxRetries: 3,
+			},
+			args: args{
+				ctx: testCtx,
+				cr:  testLogCR,
+			},
+			want: time.Nanosecond,
+			wantErr: func(t *testing.T, err error) {
+				require.NoError(t, err)
+			},
+		},
+		{
+			name: "should return error on error getting collector failures",
+			fields: fields{
+				collectorMapping: func(t *testing.T) CollectorMapping {
+					collectorMapping := CollectorMapping{}
+					logRepository := newMockCollectorRepository[domain.LogLine](t)
+					logRepository.EXPECT().IsCollected(testCtx, testID).Return(false, nil)
+					logRepository.EXPECT().IsSkipped(testCtx, testID).Return(false, "", nil)
+					logRepository.EXPECT().SetSpecHash(testCtx, testID, testLogSpecHash).Return(nil)
+					logRepository.EXPECT().GetFailures(testCtx, testID).Return(0, assert.AnError)
+
+					collectorMapping[domain.CollectorTypeLog] = CollectorAndRepository{Repository: logRepository, Collector: newMockCollector[domain.LogLine](t)}
+					return collectorMapping
+				},
+				supportArchiveRepository: func(t *testing.T) supportArchiveRepository {
+					repoMock := newMockSupportArchiveRepository(t)
+					repoMock.EXPECT().Exists(testCtx, testID).Return(false, nil)
+					return repoMock
+				},
+			},
+			args: args{
+				ctx: testCtx,
+				cr:  testLogCR,
+			},
+			want: 0,
+			wantErr: func(t *testing.T, err error) {
+				require.Error(t, err)
+				assert.ErrorIs(t, err, assert.AnError)
+				assert.ErrorContains(t, err, "could not get failures of collector Logs")
+			},
+		},
+		{
+			name: "should not execute collector if the attempt cannot be counted",
+			fields: fields{
+				collectorMapping: func(t *testing.T) CollectorMapping {
+					collectorMapping := CollectorMapping{}
+					logRepository := newMockCollectorRepository[domain.LogLine](t)
+					logRepository.EXPECT().IsCollected(testCtx, testID).Return(false, nil)
+					logRepository.EXPECT().IsSkipped(testCtx, testID).Return(false, "", nil)
+					logRepository.EXPECT().SetSpecHash(testCtx, testID, testLogSpecHash).Return(nil)
+					logRepository.EXPECT().GetFailures(testCtx, testID).Return(1, nil)
+					logRepository.EXPECT().SetFailures(testCtx, testID, 2).Return(assert.AnError)
+
+					collectorMapping[domain.CollectorTypeLog] = CollectorAndRepository{Repository: logRepository, Collector: newMockCollector[domain.LogLine](t)}
+					return collectorMapping
+				},
+				supportArchiveRepository: func(t *testing.T) supportArchiveRepository {
+					repoMock := newMockSupportArchiveRepository(t)
+					repoMock.EXPECT().Exists(testCtx, testID).Return(false, nil)
+					return repoMock
+				},
+				collectorMaxRetries: 3,
+			},
+			args: args{
+				ctx: testCtx,
+				cr:  testLogCR,
+			},
+			want: 0,
+			wantErr: func(t *testing.T, err error) {
+				require.Error(t, err)
+				assert.ErrorIs(t, err, assert.AnError)
+				assert.ErrorContains(t, err, "could not record failures of collector Logs")
+			},
+		},
+		{
+			name: "should create partial archive if a collector was skipped",
+			fields: fields{
+				collectorMapping: func(t *testing.T) CollectorMapping {
+					collectorMapping := CollectorMapping{}
+					logRepository := newMockCollectorRepository[domain.LogLine](t)
+					logRepository.EXPECT().IsCollected(testCtx, testID).Return(false, nil)
+					logRepository.EXPECT().IsSkipped(testCtx, testID).Return(true, "log error", nil)
+					logRepository.EXPECT().GetSpecHash(testCtx, testID).Return(testLogSpecHash, nil)
+
+					collectorMapping[domain.CollectorTypeLog] = CollectorAndRepository{Repository: logRepository, Collector: newMockCollector[domain.LogLine](t)}
+					return collectorMapping
+				},
+				postProcessing: newEmptyPostProcessingMocks,
+				supportArchiveRepository: func(t *testing.T) supportArchiveRepository {
+					repoMock := newMockSupportArchiveRepository(t)
+					repoMock.EXPECT().Exists(testCtx, testID).Return(false, nil)
+					repoMock.EXPECT().Create(mock.AnythingOfType("*context.cancelCtx"), testID, mock.AnythingOfType("map[domain.CollectorType]*domain.Stream"), mock.Anything).Return(testURL, nil).Run(func(ctx context.Context, id domain.SupportArchiveID, streams map[domain.CollectorType]*domain.Stream, _ time.Time) {
+						_, ok := streams[domain.CollectorTypeLog]
+						assert.False(t, ok)
+						errorStream, ok := streams[domain.ArchiveErrorsDir]
+						require.True(t, ok)
+						data := <-errorStream.Data
+						assert.Equal(t, "Logs.txt", data.ID)
+						reader, closeFn, err := data.StreamConstructor()
+						require.NoError(t, err)
+						content, err := io.ReadAll(reader)
+						require.NoError(t, err)
+						assert.Equal(t, "log error", string(content))
+						require.NoError(t, closeFn())
+					})
+					return repoMock
+				},
+				supportArchivesInterface: func(t *testing.T) supportArchiveV1Interface {
+					interfaceMock := newMockSupportArchiveV1Interface(t)
+					clientMock := newMockSupportArchiveInterface(t)
+					interfaceMock.EXPECT().SupportArchives(testArchiveNamespace).Return(clientMock)
+					clientMock.EXPECT().UpdateStatusWithRetry(testCtx, testLogCR, mock.Anything, metav1.UpdateOptions{}).Return(nil, nil).Run(func(ctx context.Context, cr *libapi.SupportArchive, modifyStatusFn func(libapi.SupportArchiveStatus) libapi.SupportArchiveStatus, opts metav1.UpdateOptions) {
+						updatedCRStatus := modifyStatusFn(cr.Status)
+						assert.Equal(t, domain.ArchivePhasePackaging, domain.GetArchivePhase(updatedCRStatus))
+					}).Once()
+					clientMock.EXPECT().UpdateStatusWithRetry(testCtx, testLogCR, mock.Anything, metav1.UpdateOptions{}).Return(nil, nil).Run(func(ctx context.Context, cr *libapi.SupportArchive, modifyStatusFn func(libapi.SupportArchiveStatus) libapi.SupportArchiveStatus, opts metav1.UpdateOptions) {
+						updatedCRStatus := modifyStatusFn(cr.Status)
+						assert.Equal(t, testURL, updatedCRStatus.DownloadPath)
+						assert.Equal(t, domain.ArchivePhaseSucceeded, domain.GetArchivePhase(updatedCRStatus))
+						assert.NotNil(t, meta.FindStatusCondition(updatedCRStatus.Conditions, domain.ConditionCollectionFinished))
+						assert.Equal(t, []string{"log error"}, updatedCRStatus.Errors)
+						condition := meta.FindStatusCondition(updatedCRStatus.Conditions, libapi.ConditionSupportArchiveCreated)
+						require.NotNil(t, condition)
+						assert.Equal(t, metav1.ConditionTrue, condition.Status)
+						assert.Equal(t, "PartialSuccess", condition.Reason)
+						assert.Contains(t, condition.Message, "Skipped collectors: Logs")
+					})
+					return interfaceMock
+				},
+			},
+			args: args{
+				ctx: testCtx,
+				cr:  testLogCR,
+			},
+			want: 0,
+			wantErr: func(t *testing.T, err error) {
+				require.NoError(t, err)
+			},
+		},
 		{
 			name: "should create archive and update status",
 			fields: fields{
@@ -212,16 +569,19 @@ func TestCreateArchiveUseCase_HandleArchiveRequest(t *testing.T) {
 					logCollector := newMockCollector[domain.LogLine](t)
 					logRepository := newMockCollectorRepository[domain.LogLine](t)
 					logRepository.EXPECT().IsCollected(testCtx, testID).Return(true, nil)
+					logRepository.EXPECT().GetSpecHash(testCtx, testID).Return(testLogSpecHash, nil)
+					logRepository.EXPECT().IsSkipped(testCtx, testID).Return(false, "", nil)
 					logRepository.EXPECT().IsCollected(mock.AnythingOfType("*context.cancelCtx"), testID).Return(true, nil)
 					logRepository.EXPECT().Stream(mock.AnythingOfType("*context.cancelCtx"), testID, mock.AnythingOfType("*domain.Stream")).Return(nil)
 
 					collectorMapping[domain.CollectorTypeLog] = CollectorAndRepository{Repository: logRepository, Collector: logCollector}
 					return collectorMapping
 				},
+				postProcessing: newEmptyPostProcessingMocks,
 				supportArchiveRepository: func(t *testing.T) supportArchiveRepository {
 					repoMock := newMockSupportArchiveRepository(t)
 					repoMock.EXPECT().Exists(testCtx, testID).Return(false, nil)
-					repoMock.EXPECT().Create(mock.AnythingOfType("*context.cancelCtx"), testID, mock.AnythingOfType("map[domain.CollectorType]*domain.Stream")).Return(testURL, nil).Run(func(ctx context.Context, id domain.SupportArchiveID, streams map[domain.CollectorType]*domain.Stream) {
+					repoMock.EXPECT().Create(mock.AnythingOfType("*context.cancelCtx"), testID, mock.AnythingOfType("map[domain.CollectorType]*domain.Stream"), mock.Anything).Return(testURL, nil).Run(func(ctx context.Context, id domain.SupportArchiveID, streams map[domain.CollectorType]*domain.Stream, _ time.Time) {
 						logStream, ok := streams[domain.CollectorTypeLog]
 						require.True(t, ok)
 						require.NotNil(t, logStream)
@@ -232,9 +592,15 @@ func TestCreateArchiveUseCase_HandleArchiveRequest(t *testing.T) {
 					interfaceMock := newMockSupportArchiveV1Interface(t)
 					clientMock := newMockSupportArchiveInterface(t)
 					interfaceMock.EXPECT().SupportArchives(testArchiveNamespace).Return(clientMock)
+					clientMock.EXPECT().UpdateStatusWithRetry(testCtx, testLogCR, mock.Anything, metav1.UpdateOptions{}).Return(nil, nil).Run(func(ctx context.Context, cr *libapi.SupportArchive, modifyStatusFn func(libapi.SupportArchiveStatus) libapi.SupportArchiveStatus, opts metav1.UpdateOptions) {
+						updatedCRStatus := modifyStatusFn(cr.Status)
+						assert.Equal(t, domain.ArchivePhasePackaging, domain.GetArchivePhase(updatedCRStatus))
+					}).Once()
 					clientMock.EXPECT().UpdateStatusWithRetry(testCtx, testLogCR, mock.Anything, metav1.UpdateOptions{}).Return(nil, nil).Run(func(ctx context.Context, cr *libapi.SupportArchive, modifyStatusFn func(libapi.SupportArchiveStatus) libapi.SupportArchiveStatus, opts metav1.UpdateOptions) {
 						updatedCRStatus := modifyStatusFn(cr.Status)
 						assert.Equal(t, testURL, updatedCRStatus.DownloadPath)
+						assert.Equal(t, domain.ArchivePhaseSucceeded, domain.GetArchivePhase(updatedCRStatus))
+						assert.NotNil(t, meta.FindStatusCondition(updatedCRStatus.Conditions, domain.ConditionCollectionFinished))
 						foundCondition := false
 						for _, conditions := range updatedCRStatus.Conditions {
 							if conditions.Type == libapi.ConditionSupportArchiveCreated && conditions.Status == metav1.ConditionTrue {
@@ -273,10 +639,25 @@ func TestCreateArchiveUseCase_HandleArchiveRequest(t *testing.T) {
 				collectorMapping = tt.fields.collectorMapping(t)
 			}
 
+			archiveDeadline := tt.fields.archiveDeadline
+			if archiveDeadline == 0 {
+				archiveDeadline = time.Hour
+			}
+
+			var postProcessing PostProcessingRepositories
+			if tt.fields.postProcessing != nil {
+				postProcessing = tt.fields.postProcessing(t)
+			}
+
 			c := &CreateArchiveUseCase{
 				supportArchivesInterface: crMock,
 				supportArchiveRepository: repoMock,
 				collectorMapping:         collectorMapping,
+				postProcessing:           postProcessing,
+				collectorMaxRetries:      tt.fields.collectorMaxRetries,
+				archiveDeadline:          archiveDeadline,
+				defaultContentTimeframe:  96 * time.Hour,
+				archiveLocks:             NewArchiveLocks(),
 			}
 			got, err := c.HandleArchiveRequest(tt.args.ctx, tt.args.cr)
 			tt.wantErr(t, err)
@@ -290,28 +671,385 @@ func TestNewCreateArchiveUseCase(t *testing.T) {
 	v1Mock := newMockSupportArchiveV1Interface(t)
 	mapping := CollectorMapping{}
 	repoMock := newMockSupportArchiveRepository(t)
+	postProcessing := PostProcessingRepositories{
+		CollectedDataReader: newMockCollectedDataReader(t),
+		Timeline:            newMockTimelineRepository(t),
+		Findings:            newMockFindingsRepository(t),
+		Summary:             newMockSummaryRepository(t),
+		Diff:                newMockDiffRepository(t),
+	}
 
 	// when
-	useCase := NewCreateArchiveUseCase(v1Mock, mapping, repoMock)
+	signerMock := newMockDownloadURLSigner(t)
+	useCase := NewCreateArchiveUseCase(v1Mock, mapping, repoMock, postProcessing, NewArchiveLocks(), CreateArchiveOptions{
+		CollectorMaxRetries:     3,
+		ArchiveDeadline:         time.Hour,
+		DefaultContentTimeframe: 96 * time.Hour,
+		TimelineEnabled:         true,
+		DownloadURLSigner:       signerMock,
+	})
 
 	// then
 	require.NotNil(t, useCase)
 	assert.Equal(t, v1Mock, useCase.supportArchivesInterface)
 	assert.Equal(t, mapping, useCase.collectorMapping)
 	assert.Equal(t, repoMock, useCase.supportArchiveRepository)
+	assert.Equal(t, 3, useCase.collectorMaxRetries)
+	assert.Equal(t, time.Hour, useCase.archiveDeadline)
+	assert.Equal(t, 96*time.Hour, useCase.defaultContentTimeframe)
+	assert.Equal(t, postProcessing, useCase.postProcessing)
+	assert.True(t, useCase.timelineEnabled)
+	assert.Equal(t, signerMock, useCase.downloadURLSigner)
+	assert.NotNil(t, useCase.archiveLocks)
+	assert.Nil(t, useCase.inMemory)
 }
 
-func TestCreateArchiveUseCase_updateFinalStatus(t *testing.T) {
-	type fields struct {
-		supportArchivesInterface func(t *testing.T) supportArchiveV1Interface
-	}
-	type args struct {
-		ctx context.Context
-		cr  *libapi.SupportArchive
-		url string
-	}
-	tests := []struct {
-		name    string
+func TestNewLocalCreateArchiveUseCase(t *testing.T) {
+	// given
+	mapping := CollectorMapping{}
+	repoMock := newMockSupportArchiveRepository(t)
+	postProcessing := PostProcessingRepositories{CollectedDataReader: newMockCollectedDataReader(t)}
+
+	// when
+	useCase := NewLocalCreateArchiveUseCase(mapping, repoMock, postProcessing, CreateArchiveOptions{
+		CollectorMaxRetries:       3,
+		ArchiveDeadline:           time.Hour,
+		DefaultContentTimeframe:   96 * time.Hour,
+		TimelineEnabled:           true,
+		DownloadURLSigner:         newMockDownloadURLSigner(t),
+		InMemoryArchives:          &InMemoryArchives{MaxSize: 1},
+		InMemoryArchiveRepository: newMockInMemoryArchiveRepository(t),
+	})
+
+	// then
+	require.NotNil(t, useCase)
+	assert.Nil(t, useCase.supportArchivesInterface)
+	assert.Equal(t, repoMock, useCase.supportArchiveRepository)
+	assert.Zero(t, useCase.collectorMaxRetries)
+	assert.Equal(t, time.Hour, useCase.archiveDeadline)
+	assert.Equal(t, 96*time.Hour, useCase.defaultContentTimeframe)
+	assert.True(t, useCase.timelineEnabled)
+	assert.Nil(t, useCase.downloadURLSigner)
+	assert.NotNil(t, useCase.archiveLocks)
+	assert.Nil(t, useCase.inMemory)
+	assert.Nil(t, useCase.inMemoryArchiveRepository)
+}
+
+// newEmptyPostProcessingMocks returns post-processing repositories for a disabled timeline, no findings and an empty summary.
+func newEmptyPostProcessingMocks(t *testing.T) PostProcessingRepositories {
+	readerMock := newMockCollectedDataReader(t)
+	readerMock.EXPECT().Read(mock.AnythingOfType("*context.cancelCtx"), testID, mock.Anything, mock.Anything).Return(&domain.CollectedData{}, nil)
+	findingsMock := newMockFindingsRepository(t)
+	findingsMock.EXPECT().Create(mock.AnythingOfType("*context.cancelCtx"), testID, []domain.Finding(nil)).Return(nil)
+	findingsMock.EXPECT().Stream(mock.AnythingOfType("*context.cancelCtx"), testID, mock.AnythingOfType("*domain.Stream")).RunAndReturn(func(_ context.Context, _ domain.SupportArchiveID, stream *domain.Stream) error {
+		close(stream.Data)
+		return nil
+	})
+	findingsMock.EXPECT().Delete(testCtx, testID).Return(nil)
+	timelineMock := newMockTimelineRepository(t)
+	timelineMock.EXPECT().Delete(testCtx, testID).Return(nil)
+
+	return PostProcessingRepositories{CollectedDataReader: readerMock, Timeline: timelineMock, Findings: findingsMock, Summary: newEmptySummaryMock(t), Diff: newEmptyDiffMock(t)}
+}
+
+// newEmptyDiffMock returns a diff repository for archives without a reference to compare with.
+func newEmptyDiffMock(t *testing.T) *mockDiffRepository {
+	diffMock := newMockDiffRepository(t)
+	diffMock.EXPECT().Delete(testCtx, testID).Return(nil)
+
+	return diffMock
+}
+
+func newEmptySummaryMock(t *testing.T) *mockSummaryRepository {
+	summaryMock := newMockSummaryRepository(t)
+	summaryMock.EXPECT().Create(mock.AnythingOfType("*context.cancelCtx"), testID, mock.AnythingOfType("*domain.ArchiveSummary")).Return(nil)
+	summaryMock.EXPECT().Stream(mock.AnythingOfType("*context.cancelCtx"), testID, mock.AnythingOfType("*domain.Stream")).RunAndReturn(func(_ context.Context, _ domain.SupportArchiveID, stream *domain.Stream) error {
+		close(stream.Data)
+		return nil
+	})
+	summaryMock.EXPECT().Delete(testCtx, testID).Return(nil)
+
+	return summaryMock
+}
+
+func streamDataWithID(id string) func(context.Context, domain.SupportArchiveID, *domain.Stream) error {
+	return func(_ context.Context, _ domain.SupportArchiveID, stream *domain.Stream) error {
+		stream.Data <- domain.StreamData{ID: id}
+		close(stream.Data)
+		return nil
+	}
+}
+
+func readStreamIDs(stream *domain.Stream) []string {
+	var ids []string
+	for data := range stream.Data {
+		ids = append(ids, data.ID)
+	}
+
+	return ids
+}
+
+func TestCreateArchiveUseCase_createArchive(t *testing.T) {
+	start := time.Date(2025, 1, 1, 0, 0, 0, 0, time.UTC)
+	end := start.Add(time.Hour)
+	timeframes := domain.ContentTimeframes{
+		Default:    domain.Timeframe{Start: start, End: end},
+		Collectors: map[domain.CollectorType]domain.Timeframe{domain.CollectorTypeLog: {Start: start.Add(-time.Hour), End: end}},
+	}
+	logMapping := func(t *testing.T) CollectorMapping {
+		logRepository := newMockCollectorRepository[domain.LogLine](t)
+		logRepository.EXPECT().IsSkipped(testCtx, testID).Return(false, "", nil)
+		logRepository.EXPECT().IsCollected(mock.AnythingOfType("*context.cancelCtx"), testID).Return(true, nil)
+		logRepository.EXPECT().Stream(mock.AnythingOfType("*context.cancelCtx"), testID, mock.AnythingOfType("*domain.Stream")).RunAndReturn(func(_ context.Context, _ domain.SupportArchiveID, stream *domain.Stream) error {
+			close(stream.Data)
+			return nil
+		})
+
+		return CollectorMapping{domain.CollectorTypeLog: {Repository: logRepository, Collector: newMockCollector[domain.LogLine](t)}}
+	}
+
+	t.Run("should add timeline, findings and summary to the root of the archive", func(t *testing.T) {
+		// given
+		timelineMock := newMockTimelineRepository(t)
+		timelineMock.EXPECT().Create(mock.AnythingOfType("*context.cancelCtx"), testID, []domain.CollectorType{domain.CollectorTypeLog}).Return(nil)
+		timelineMock.EXPECT().Stream(mock.AnythingOfType("*context.cancelCtx"), testID, mock.AnythingOfType("*domain.Stream")).RunAndReturn(streamDataWithID(domain.TimelineFileName))
+		timelineMock.EXPECT().Delete(testCtx, testID).Return(nil)
+		readerMock := newMockCollectedDataReader(t)
+		readerMock.EXPECT().Read(mock.AnythingOfType("*context.cancelCtx"), testID, []domain.CollectorType{domain.CollectorTypeLog}, timeframes).Return(&domain.CollectedData{
+			Start:   start,
+			End:     end,
+			Volumes: []domain.VolumeInfo{{Items: []domain.VolumeInfoItem{{Name: "ldap-data", Capacity: 100, Used: 96}}}},
+		}, nil)
+		findingsMock := newMockFindingsRepository(t)
+		findingsMock.EXPECT().Create(mock.AnythingOfType("*context.cancelCtx"), testID, []domain.Finding{{
+			Rule:     ruleNameVolumeUsage,
+			Severity: domain.FindingSeverityCritical,
+			Object:   "PersistentVolumeClaim/ldap-data",
+			Message:  "The volume is 96.0% full (96 of 100 bytes used)",
+		}}).Return(nil)
+		findingsMock.EXPECT().Stream(mock.AnythingOfType("*context.cancelCtx"), testID, mock.AnythingOfType("*domain.Stream")).RunAndReturn(streamDataWithID(domain.FindingsMarkdownFileName))
+		findingsMock.EXPECT().Delete(testCtx, testID).Return(nil)
+		summaryMock := newMockSummaryRepository(t)
+		summaryMock.EXPECT().Create(mock.AnythingOfType("*context.cancelCtx"), testID, mock.AnythingOfType("*domain.ArchiveSummary")).RunAndReturn(func(_ context.Context, _ domain.SupportArchiveID, summary *domain.ArchiveSummary) error {
+			assert.Equal(t, testID, summary.ID)
+			assert.Equal(t, []domain.CollectorSummary{{Type: domain.CollectorTypeLog}}, summary.Collectors)
+			assert.Len(t, summary.Findings, 1)
+			assert.Equal(t, start, summary.Data.Start)
+			assert.Equal(t, end, summary.Data.End)
+			return nil
+		})
+		summaryMock.EXPECT().Stream(mock.AnythingOfType("*context.cancelCtx"), testID, mock.AnythingOfType("*domain.Stream")).RunAndReturn(streamDataWithID(domain.SummaryFileName))
+		summaryMock.EXPECT().Delete(testCtx, testID).Return(nil)
+		repoMock := newMockSupportArchiveRepository(t)
+		repoMock.EXPECT().Create(mock.AnythingOfType("*context.cancelCtx"), testID, mock.Anything, end).RunAndReturn(func(_ context.Context, _ domain.SupportArchiveID, streams map[domain.CollectorType]*domain.Stream, _ time.Time) (string, error) {
+			assert.Len(t, streams, 2)
+			require.NotNil(t, streams[domain.ArchiveRootDir])
+			assert.Equal(t, []string{domain.TimelineFileName, domain.FindingsMarkdownFileName, domain.SummaryFileName}, readStreamIDs(streams[domain.ArchiveRootDir]))
+			assert.Empty(t, readStreamIDs(streams[domain.CollectorTypeLog]))
+			return testURL, nil
+		})
+		mapping := logMapping(t)
+		sut := &CreateArchiveUseCase{
+			collectorMapping:         mapping,
+			supportArchiveRepository: repoMock,
+			postProcessing:           PostProcessingRepositories{CollectedDataReader: readerMock, Timeline: timelineMock, Findings: findingsMock, Summary: summaryMock, Diff: newEmptyDiffMock(t)},
+			timelineEnabled:          true,
+		}
+
+		// when
+		url, skipped, err := sut.createArchive(testCtx, testID, mapping, timeframes, nil)
+
+		// then
+		require.NoError(t, err)
+		assert.Equal(t, testURL, url)
+		assert.Empty(t, skipped)
+	})
+
+	t.Run("should create archive without timeline on error creating timeline", func(t *testing.T) {
+		// given
+		readerMock := newMockCollectedDataReader(t)
+		readerMock.EXPECT().Read(mock.AnythingOfType("*context.cancelCtx"), testID, []domain.CollectorType{domain.CollectorTypeLog}, timeframes).Return(&domain.CollectedData{}, nil)
+		findingsMock := newMockFindingsRepository(t)
+		findingsMock.EXPECT().Create(mock.AnythingOfType("*context.cancelCtx"), testID, []domain.Finding(nil)).Return(nil)
+		findingsMock.EXPECT().Stream(mock.AnythingOfType("*context.cancelCtx"), testID, mock.AnythingOfType("*domain.Stream")).RunAndReturn(func(_ context.Context, _ domain.SupportArchiveID, stream *domain.Stream) error {
+			close(stream.Data)
+			return nil
+		})
+		findingsMock.EXPECT().Delete(testCtx, testID).Return(nil)
+		timelineMock := newMockTimelineRepository(t)
+		timelineMock.EXPECT().Create(mock.AnythingOfType("*context.cancelCtx"), testID, []domain.CollectorType{domain.CollectorTypeLog}).Return(assert.AnError)
+		timelineMock.EXPECT().Delete(testCtx, testID).Return(assert.AnError)
+		postProcessing := PostProcessingRepositories{CollectedDataReader: readerMock, Timeline: timelineMock, Findings: findingsMock, Summary: newEmptySummaryMock(t), Diff: newEmptyDiffMock(t)}
+		repoMock := newMockSupportArchiveRepository(t)
+		repoMock.EXPECT().Create(mock.AnythingOfType("*context.cancelCtx"), testID, mock.Anything, end).RunAndReturn(func(_ context.Context, _ domain.SupportArchiveID, streams map[domain.CollectorType]*domain.Stream, _ time.Time) (string, error) {
+			assert.Len(t, streams, 2)
+			assert.Empty(t, readStreamIDs(streams[domain.ArchiveRootDir]))
+			assert.Empty(t, readStreamIDs(streams[domain.CollectorTypeLog]))
+			return testURL, nil
+		})
+		mapping := logMapping(t)
+		sut := &CreateArchiveUseCase{collectorMapping: mapping, supportArchiveRepository: repoMock, postProcessing: postProcessing, timelineEnabled: true}
+
+		// when
+		url, _, err := sut.createArchive(testCtx, testID, mapping, timeframes, nil)
+
+		// then
+		require.NoError(t, err)
+		assert.Equal(t, testURL, url)
+	})
+
+	t.Run("should create archive without post-processing results on error reading collected data", func(t *testing.T) {
+		// given
+		readerMock := newMockCollectedDataReader(t)
+		readerMock.EXPECT().Read(mock.AnythingOfType("*context.cancelCtx"), testID, []domain.CollectorType{domain.CollectorTypeLog}, timeframes).Return(nil, assert.AnError)
+		findingsMock := newMockFindingsRepository(t)
+		findingsMock.EXPECT().Delete(testCtx, testID).Return(nil)
+		timelineMock := newMockTimelineRepository(t)
+		timelineMock.EXPECT().Delete(testCtx, testID).Return(nil)
+		summaryMock := newMockSummaryRepository(t)
+		summaryMock.EXPECT().Delete(testCtx, testID).Return(nil)
+		repoMock := newMockSupportArchiveRepository(t)
+		repoMock.EXPECT().Create(mock.AnythingOfType("*context.cancelCtx"), testID, mock.Anything, end).RunAndReturn(func(_ context.Context, _ domain.SupportArchiveID, streams map[domain.CollectorType]*domain.Stream, _ time.Time) (string, error) {
+			assert.Len(t, streams, 1)
+			assert.Empty(t, readStreamIDs(streams[domain.CollectorTypeLog]))
+			return testURL, nil
+		})
+		mapping := logMapping(t)
+		sut := &CreateArchiveUseCase{
+			collectorMapping:         mapping,
+			supportArchiveRepository: repoMock,
+			postProcessing:           PostProcessingRepositories{CollectedDataReader: readerMock, Timeline: timelineMock, Findings: findingsMock, Summary: summaryMock, Diff: newEmptyDiffMock(t)},
+		}
+
+		// when
+		url, _, err := sut.createArchive(testCtx, testID, mapping, timeframes, nil)
+
+		// then
+		require.NoError(t, err)
+		assert.Equal(t, testURL, url)
+	})
+
+	t.Run("should create archive with summary but without findings on error creating findings", func(t *testing.T) {
+		// given
+		readerMock := newMockCollectedDataReader(t)
+		readerMock.EXPECT().Read(mock.AnythingOfType("*context.cancelCtx"), testID, []domain.CollectorType{domain.CollectorTypeLog}, timeframes).Return(&domain.CollectedData{}, nil)
+		findingsMock := newMockFindingsRepository(t)
+		findingsMock.EXPECT().Create(mock.AnythingOfType("*context.cancelCtx"), testID, []domain.Finding(nil)).Return(assert.AnError)
+		findingsMock.EXPECT().Delete(testCtx, testID).Return(nil)
+		timelineMock := newMockTimelineRepository(t)
+		timelineMock.EXPECT().Delete(testCtx, testID).Return(nil)
+		repoMock := newMockSupportArchiveRepository(t)
+		repoMock.EXPECT().Create(mock.AnythingOfType("*context.cancelCtx"), testID, mock.Anything, end).RunAndReturn(func(_ context.Context, _ domain.SupportArchiveID, streams map[domain.CollectorType]*domain.Stream, _ time.Time) (string, error) {
+			assert.Len(t, streams, 2)
+			assert.Empty(t, readStreamIDs(streams[domain.ArchiveRootDir]))
+			assert.Empty(t, readStreamIDs(streams[domain.CollectorTypeLog]))
+			return testURL, nil
+		})
+		mapping := logMapping(t)
+		sut := &CreateArchiveUseCase{
+			collectorMapping:         mapping,
+			supportArchiveRepository: repoMock,
+			postProcessing:           PostProcessingRepositories{CollectedDataReader: readerMock, Timeline: timelineMock, Findings: findingsMock, Summary: newEmptySummaryMock(t), Diff: newEmptyDiffMock(t)},
+		}
+
+		// when
+		url, _, err := sut.createArchive(testCtx, testID, mapping, timeframes, nil)
+
+		// then
+		require.NoError(t, err)
+		assert.Equal(t, testURL, url)
+	})
+
+	t.Run("should return error on error streaming findings", func(t *testing.T) {
+		// given
+		readerMock := newMockCollectedDataReader(t)
+		readerMock.EXPECT().Read(mock.AnythingOfType("*context.cancelCtx"), testID, []domain.CollectorType{domain.CollectorTypeLog}, timeframes).Return(&domain.CollectedData{}, nil)
+		findingsMock := newMockFindingsRepository(t)
+		findingsMock.EXPECT().Create(mock.AnythingOfType("*context.cancelCtx"), testID, []domain.Finding(nil)).Return(nil)
+		findingsMock.EXPECT().Stream(mock.AnythingOfType("*context.cancelCtx"), testID, mock.AnythingOfType("*domain.Stream")).Return(assert.AnError)
+		findingsMock.EXPECT().Delete(testCtx, testID).Return(nil)
+		timelineMock := newMockTimelineRepository(t)
+		timelineMock.EXPECT().Delete(testCtx, testID).Return(nil)
+		postProcessing := PostProcessingRepositories{CollectedDataReader: readerMock, Timeline: timelineMock, Findings: findingsMock, Summary: newEmptySummaryMock(t), Diff: newEmptyDiffMock(t)}
+		repoMock := newMockSupportArchiveRepository(t)
+		repoMock.EXPECT().Create(mock.AnythingOfType("*context.cancelCtx"), testID, mock.Anything, end).RunAndReturn(func(ctx context.Context, _ domain.SupportArchiveID, streams map[domain.CollectorType]*domain.Stream, _ time.Time) (string, error) {
+			<-ctx.Done()
+			return "", ctx.Err()
+		})
+		mapping := logMapping(t)
+		sut := &CreateArchiveUseCase{collectorMapping: mapping, supportArchiveRepository: repoMock, postProcessing: postProcessing}
+
+		// when
+		_, _, err := sut.createArchive(testCtx, testID, mapping, timeframes, nil)
+
+		// then
+		require.Error(t, err)
+		assert.ErrorIs(t, err, assert.AnError)
+		assert.ErrorContains(t, err, "could not stream findings")
+	})
+
+	t.Run("should add diff to the root of the archive if another archive is referenced", func(t *testing.T) {
+		// given
+		compareTo := domain.SupportArchiveID{Namespace: testID.Namespace, Name: "archive-1"}
+		before := &domain.ArchiveContent{Secrets: []domain.ArchiveSecret{{Path: "Resources/Secrets/ldap.yaml", Keys: []string{"user"}}}}
+		after := &domain.ArchiveContent{}
+		postProcessing := newEmptyPostProcessingMocks(t)
+		postProcessing.CollectedDataReader.(*mockCollectedDataReader).EXPECT().ReadContent(mock.AnythingOfType("*context.cancelCtx"), testID).Return(after, nil)
+		diffMock := postProcessing.Diff.(*mockDiffRepository)
+		diffMock.EXPECT().Create(mock.AnythingOfType("*context.cancelCtx"), testID, DiffArchives("archive-1", before, after)).Return(nil)
+		diffMock.EXPECT().Stream(mock.AnythingOfType("*context.cancelCtx"), testID, mock.AnythingOfType("*domain.Stream")).RunAndReturn(streamDataWithID(domain.DiffJSONFileName))
+		repoMock := newMockSupportArchiveRepository(t)
+		repoMock.EXPECT().ReadContent(mock.AnythingOfType("*context.cancelCtx"), compareTo).Return(before, nil)
+		repoMock.EXPECT().Create(mock.AnythingOfType("*context.cancelCtx"), testID, mock.Anything, end).RunAndReturn(func(_ context.Context, _ domain.SupportArchiveID, streams map[domain.CollectorType]*domain.Stream, _ time.Time) (string, error) {
+			assert.Equal(t, []string{domain.DiffJSONFileName}, readStreamIDs(streams[domain.ArchiveRootDir]))
+			assert.Empty(t, readStreamIDs(streams[domain.CollectorTypeLog]))
+			return testURL, nil
+		})
+		mapping := logMapping(t)
+		sut := &CreateArchiveUseCase{collectorMapping: mapping, supportArchiveRepository: repoMock, postProcessing: postProcessing}
+
+		// when
+		url, _, err := sut.createArchive(testCtx, testID, mapping, timeframes, &compareTo)
+
+		// then
+		require.NoError(t, err)
+		assert.Equal(t, testURL, url)
+	})
+
+	t.Run("should create archive without diff on error reading the referenced archive", func(t *testing.T) {
+		// given
+		compareTo := domain.SupportArchiveID{Namespace: testID.Namespace, Name: "archive-1"}
+		postProcessing := newEmptyPostProcessingMocks(t)
+		repoMock := newMockSupportArchiveRepository(t)
+		repoMock.EXPECT().ReadContent(mock.AnythingOfType("*context.cancelCtx"), compareTo).Return(nil, assert.AnError)
+		repoMock.EXPECT().Create(mock.AnythingOfType("*context.cancelCtx"), testID, mock.Anything, end).RunAndReturn(func(_ context.Context, _ domain.SupportArchiveID, streams map[domain.CollectorType]*domain.Stream, _ time.Time) (string, error) {
+			assert.Empty(t, readStreamIDs(streams[domain.ArchiveRootDir]))
+			assert.Empty(t, readStreamIDs(streams[domain.CollectorTypeLog]))
+			return testURL, nil
+		})
+		mapping := logMapping(t)
+		sut := &CreateArchiveUseCase{collectorMapping: mapping, supportArchiveRepository: repoMock, postProcessing: postProcessing}
+
+		// when
+		url, _, err := sut.createArchive(testCtx, testID, mapping, timeframes, &compareTo)
+
+		// then
+		require.NoError(t, err)
+		assert.Equal(t, testURL, url)
+	})
+}
+
+func TestCreateArchiveUseCase_updateFinalStatus(t *testing.T) {
+	type fields struct {
+		supportArchivesInterface func(t *testing.T) supportArchiveV1Interface
+	}
+	type args struct {
+		ctx context.Context
+		cr  *libapi.SupportArchive
+		url string
+	}
+	tests := []struct {
+		name    string
 		fields  fields
 		args    args
 		wantErr func(t *testing.T, err error)
@@ -345,11 +1083,108 @@ func TestCreateArchiveUseCase_updateFinalStatus(t *testing.T) {
 			c := &CreateArchiveUseCase{
 				supportArchivesInterface: tt.fields.supportArchivesInterface(t),
 			}
-			tt.wantErr(t, c.updateFinalStatus(tt.args.ctx, tt.args.cr, tt.args.url))
+			_, err := c.updateFinalStatus(tt.args.ctx, tt.args.cr, tt.args.url, nil, time.Now())
+			tt.wantErr(t, err)
 		})
 	}
 }
 
+func TestCreateArchiveUseCase_updateFinalStatusWithDownloadToken(t *testing.T) {
+	// given
+	signerMock := newMockDownloadURLSigner(t)
+	signerMock.EXPECT().Sign(testLogCR, testURL, mock.AnythingOfType("time.Time")).RunAndReturn(func(_ *libapi.SupportArchive, url string, now time.Time) (string, time.Time) {
+		return url + "?token=123", now.Add(time.Hour)
+	})
+	interfaceMock := newMockSupportArchiveV1Interface(t)
+	clientMock := newMockSupportArchiveInterface(t)
+	interfaceMock.EXPECT().SupportArchives(testArchiveNamespace).Return(clientMock)
+	clientMock.EXPECT().UpdateStatusWithRetry(testCtx, testLogCR, mock.Anything, metav1.UpdateOptions{}).Return(nil, nil).Run(func(_ context.Context, cr *libapi.SupportArchive, modifyStatusFn func(libapi.SupportArchiveStatus) libapi.SupportArchiveStatus, _ metav1.UpdateOptions) {
+		status := modifyStatusFn(cr.Status)
+		assert.Equal(t, testURL+"?token=123", status.DownloadPath)
+		condition := meta.FindStatusCondition(status.Conditions, libapi.ConditionSupportArchiveCreated)
+		require.NotNil(t, condition)
+		assert.NotContains(t, condition.Message, "token")
+	})
+	sut := &CreateArchiveUseCase{supportArchivesInterface: interfaceMock, downloadURLSigner: signerMock}
+
+	// when
+	renewAfter, err := sut.updateFinalStatus(testCtx, testLogCR, testURL, nil, time.Now())
+
+	// then
+	require.NoError(t, err)
+	assert.InDelta(t, float64(time.Hour-downloadTokenRenewalMargin), float64(renewAfter), float64(time.Second))
+}
+
+func TestCreateArchiveUseCase_renewDownloadToken(t *testing.T) {
+	signedCR := testLogCR.DeepCopy()
+	signedCR.Status.DownloadPath = testURL + "?token=123"
+
+	t.Run("should do nothing if download tokens are disabled", func(t *testing.T) {
+		sut := &CreateArchiveUseCase{}
+
+		renewAfter, err := sut.renewDownloadToken(testCtx, signedCR)
+
+		require.NoError(t, err)
+		assert.Zero(t, renewAfter)
+	})
+	t.Run("should requeue until renewal if token is valid", func(t *testing.T) {
+		// given
+		signerMock := newMockDownloadURLSigner(t)
+		signerMock.EXPECT().ExpiresAt(signedCR.Status.DownloadPath).Return(time.Now().Add(time.Hour), true)
+		sut := &CreateArchiveUseCase{downloadURLSigner: signerMock}
+
+		// when
+		renewAfter, err := sut.renewDownloadToken(testCtx, signedCR)
+
+		// then
+		require.NoError(t, err)
+		assert.InDelta(t, float64(time.Hour-downloadTokenRenewalMargin), float64(renewAfter), float64(time.Second))
+	})
+	t.Run("should renew token which expires soon", func(t *testing.T) {
+		// given
+		signerMock := newMockDownloadURLSigner(t)
+		signerMock.EXPECT().ExpiresAt(signedCR.Status.DownloadPath).Return(time.Now().Add(time.Second), true)
+		signerMock.EXPECT().Sign(signedCR, signedCR.Status.DownloadPath, mock.AnythingOfType("time.Time")).RunAndReturn(func(_ *libapi.SupportArchive, _ string, now time.Time) (string, time.Time) {
+			return testURL + "?token=456", now.Add(time.Hour)
+		})
+		interfaceMock := newMockSupportArchiveV1Interface(t)
+		clientMock := newMockSupportArchiveInterface(t)
+		interfaceMock.EXPECT().SupportArchives(testArchiveNamespace).Return(clientMock)
+		clientMock.EXPECT().UpdateStatusWithRetry(testCtx, signedCR, mock.Anything, metav1.UpdateOptions{}).Return(nil, nil).Run(func(_ context.Context, cr *libapi.SupportArchive, modifyStatusFn func(libapi.SupportArchiveStatus) libapi.SupportArchiveStatus, _ metav1.UpdateOptions) {
+			assert.Equal(t, testURL+"?token=456", modifyStatusFn(cr.Status).DownloadPath)
+		})
+		sut := &CreateArchiveUseCase{supportArchivesInterface: interfaceMock, downloadURLSigner: signerMock}
+
+		// when
+		renewAfter, err := sut.renewDownloadToken(testCtx, signedCR)
+
+		// then
+		require.NoError(t, err)
+		assert.InDelta(t, float64(time.Hour-downloadTokenRenewalMargin), float64(renewAfter), float64(time.Second))
+	})
+	t.Run("should add token to url of archive created without tokens", func(t *testing.T) {
+		// given
+		unsignedCR := testLogCR.DeepCopy()
+		unsignedCR.Status.DownloadPath = testURL
+		signerMock := newMockDownloadURLSigner(t)
+		signerMock.EXPECT().ExpiresAt(testURL).Return(time.Time{}, false)
+		signerMock.EXPECT().Sign(unsignedCR, testURL, mock.AnythingOfType("time.Time")).Return(testURL+"?token=456", time.Now().Add(time.Hour))
+		interfaceMock := newMockSupportArchiveV1Interface(t)
+		clientMock := newMockSupportArchiveInterface(t)
+		interfaceMock.EXPECT().SupportArchives(testArchiveNamespace).Return(clientMock)
+		clientMock.EXPECT().UpdateStatusWithRetry(testCtx, unsignedCR, mock.Anything, metav1.UpdateOptions{}).Return(nil, assert.AnError)
+		sut := &CreateArchiveUseCase{supportArchivesInterface: interfaceMock, downloadURLSigner: signerMock}
+
+		// when
+		_, err := sut.renewDownloadToken(testCtx, unsignedCR)
+
+		// then
+		require.Error(t, err)
+		assert.ErrorIs(t, err, assert.AnError)
+		assert.ErrorContains(t, err, "failed to renew download token of archive test-namespace/test-archive")
+	})
+}
+
 func Test_streamFromRepository(t *testing.T) {
 	type args[DATATYPE any] struct {
 		ctx        context.Context
@@ -417,15 +1252,19 @@ func TestCollectorMapping_getRequiredCollectorMapping(t *testing.T) {
 		logColRepo := CollectorAndRepository{Collector: "logs"}
 		volumeColRepo := CollectorAndRepository{Collector: "volume"}
 		nodeColRepo := CollectorAndRepository{Collector: "node"}
+		nodeStatusColRepo := CollectorAndRepository{Collector: "nodeStatus"}
 		secretColRepo := CollectorAndRepository{Collector: "logs"}
 		systemStateColRepo := CollectorAndRepository{Collector: "logs"}
+		helmReleaseColRepo := CollectorAndRepository{Collector: "helmRelease"}
 
 		sut := &CollectorMapping{
 			domain.CollectorTypeLog:         logColRepo,
 			domain.CollectorTypeVolumeInfo:  volumeColRepo,
 			domain.CollectorTypeNodeInfo:    nodeColRepo,
+			domain.CollectorTypeNodeStatus:  nodeStatusColRepo,
 			domain.CollectorTypeSecret:      secretColRepo,
 			domain.CollectorTypeSystemState: systemStateColRepo,
+			domain.CollectorTypeHelmRelease: helmReleaseColRepo,
 		}
 
 		// when
@@ -436,8 +1275,10 @@ func TestCollectorMapping_getRequiredCollectorMapping(t *testing.T) {
 		assert.Equal(t, logColRepo, mapping[domain.CollectorTypeLog])
 		assert.Equal(t, volumeColRepo, mapping[domain.CollectorTypeVolumeInfo])
 		assert.Equal(t, nodeColRepo, mapping[domain.CollectorTypeNodeInfo])
+		assert.Equal(t, nodeStatusColRepo, mapping[domain.CollectorTypeNodeStatus])
 		assert.Equal(t, secretColRepo, mapping[domain.CollectorTypeSecret])
 		assert.Equal(t, systemStateColRepo, mapping[domain.CollectorTypeSystemState])
+		assert.Equal(t, helmReleaseColRepo, mapping[domain.CollectorTypeHelmRelease])
 	})
 
 	t.Run("should not add collector to mapping if excluded", func(t *testing.T) {
@@ -458,15 +1299,19 @@ func TestCollectorMapping_getRequiredCollectorMapping(t *testing.T) {
 		logColRepo := CollectorAndRepository{Collector: "logs"}
 		volumeColRepo := CollectorAndRepository{Collector: "volume"}
 		nodeColRepo := CollectorAndRepository{Collector: "node"}
+		nodeStatusColRepo := CollectorAndRepository{Collector: "nodeStatus"}
 		secretColRepo := CollectorAndRepository{Collector: "logs"}
 		systemStateColRepo := CollectorAndRepository{Collector: "logs"}
+		helmReleaseColRepo := CollectorAndRepository{Collector: "helmRelease"}
 
 		sut := &CollectorMapping{
 			domain.CollectorTypeLog:         logColRepo,
 			domain.CollectorTypeVolumeInfo:  volumeColRepo,
 			domain.CollectorTypeNodeInfo:    nodeColRepo,
+			domain.CollectorTypeNodeStatus:  nodeStatusColRepo,
 			domain.CollectorTypeSecret:      secretColRepo,
 			domain.CollectorTypeSystemState: systemStateColRepo,
+			domain.CollectorTypeHelmRelease: helmReleaseColRepo,
 		}
 
 		// when
@@ -476,4 +1321,986 @@ func TestCollectorMapping_getRequiredCollectorMapping(t *testing.T) {
 		require.NotNil(t, mapping)
 		assert.Len(t, mapping, 0)
 	})
+	t.Run("should collect helm releases without sensitive data if sensitive data is excluded", func(t *testing.T) {
+		// given
+		cr := &libapi.SupportArchive{Spec: libapi.SupportArchiveSpec{ExcludedContents: libapi.ExcludedContents{SensitiveData: true}}}
+
+		withoutValues := newMockCollector[domain.HelmRelease](t)
+		excluder := newMockSensitiveDataExcluder(t)
+		excluder.EXPECT().WithoutSensitiveData().Return(withoutValues)
+		sut := &CollectorMapping{
+			domain.CollectorTypeSecret:      {Collector: "secret"},
+			domain.CollectorTypeSystemState: {Collector: "systemState"},
+			domain.CollectorTypeHelmRelease: {Collector: excluder, Repository: "helmRelease"},
+		}
+
+		// when
+		mapping := sut.getRequiredCollectorMapping(cr)
+
+		// then
+		assert.NotContains(t, mapping, domain.CollectorTypeSecret)
+		assert.Equal(t, CollectorAndRepository{Collector: withoutValues, Repository: "helmRelease"}, mapping[domain.CollectorTypeHelmRelease])
+	})
+}
+
+func TestCreateArchiveUseCase_HandleArchiveRequest_dryRun(t *testing.T) {
+	dryRunCR := testLogCR.DeepCopy()
+	dryRunCR.Annotations = map[string]string{domain.DryRunAnnotation: "true"}
+	dryRunCR.Spec.ExcludedContents.SystemState = false
+	dryRunCR.Spec.ContentTimeframe = libapi.ContentTimeframe{
+		StartTime: metav1.NewTime(time.Date(2025, 1, 1, 0, 0, 0, 0, time.UTC)),
+		EndTime:   metav1.NewTime(time.Date(2025, 1, 5, 0, 0, 0, 0, time.UTC)),
+	}
+
+	t.Run("should report estimates as collector conditions without collecting", func(t *testing.T) {
+		// given
+		logEstimator := newMockEstimator(t)
+		logEstimator.EXPECT().Estimate(mock.Anything, testArchiveNamespace, dryRunCR.Spec.ContentTimeframe.StartTime.Time, dryRunCR.Spec.ContentTimeframe.EndTime.Time).
+			Return(domain.CollectorEstimate{Items: 1000, Unit: "log lines", Bytes: 2048, Requests: 2}, nil)
+		systemStateEstimator := newMockEstimator(t)
+		systemStateEstimator.EXPECT().Estimate(mock.Anything, testArchiveNamespace, mock.Anything, mock.Anything).Return(domain.CollectorEstimate{}, assert.AnError)
+		collectorMapping := CollectorMapping{
+			domain.CollectorTypeLog:         {Collector: logEstimator},
+			domain.CollectorTypeSystemState: {Collector: systemStateEstimator},
+			domain.CollectorTypeHelmRelease: {Collector: newMockCollector[domain.HelmRelease](t)},
+		}
+
+		interfaceMock := newMockSupportArchiveV1Interface(t)
+		clientMock := newMockSupportArchiveInterface(t)
+		interfaceMock.EXPECT().SupportArchives(testArchiveNamespace).Return(clientMock)
+		clientMock.EXPECT().UpdateStatusWithRetry(testCtx, dryRunCR, mock.Anything, metav1.UpdateOptions{}).Return(nil, nil).Run(func(_ context.Context, cr *libapi.SupportArchive, modifyStatusFn func(libapi.SupportArchiveStatus) libapi.SupportArchiveStatus, _ metav1.UpdateOptions) {
+			status := modifyStatusFn(cr.Status)
+			assert.Equal(t, domain.ArchivePhaseEstimated, domain.GetArchivePhase(status))
+			assert.Empty(t, status.DownloadPath)
+
+			logs := meta.FindStatusCondition(status.Conditions, libapi.ConditionLogsFetched)
+			require.NotNil(t, logs)
+			assert.Equal(t, metav1.ConditionFalse, logs.Status)
+			assert.Equal(t, "Estimated", logs.Reason)
+			assert.Equal(t, "Collector Logs would collect about 1000 log lines (2.0 KiB) in 2 requests", logs.Message)
+
+			systemState := meta.FindStatusCondition(status.Conditions, libapi.ConditionSystemStateFetched)
+			require.NotNil(t, systemState)
+			assert.Equal(t, "EstimationFailed", systemState.Reason)
+			assert.Contains(t, systemState.Message, assert.AnError.Error())
+
+			helm := meta.FindStatusCondition(status.Conditions, domain.ConditionHelmReleasesFetched)
+			require.NotNil(t, helm)
+			assert.Equal(t, "NoEstimate", helm.Reason)
+		})
+		sut := NewCreateArchiveUseCase(interfaceMock, collectorMapping, nil, PostProcessingRepositories{}, NewArchiveLocks(), CreateArchiveOptions{ArchiveDeadline: time.Hour, DefaultContentTimeframe: 96 * time.Hour})
+
+		// when
+		requeueAfter, err := sut.HandleArchiveRequest(testCtx, dryRunCR)
+
+		// then
+		require.NoError(t, err)
+		assert.Zero(t, requeueAfter)
+	})
+	t.Run("should do nothing if the archive was estimated", func(t *testing.T) {
+		// given
+		estimatedCR := dryRunCR.DeepCopy()
+		estimatedCR.Status.Conditions = []metav1.Condition{{Type: domain.ConditionPhase, Status: metav1.ConditionTrue, Reason: string(domain.ArchivePhaseEstimated)}}
+		sut := NewCreateArchiveUseCase(nil, CollectorMapping{}, nil, PostProcessingRepositories{}, NewArchiveLocks(), CreateArchiveOptions{ArchiveDeadline: time.Hour, DefaultContentTimeframe: 96 * time.Hour})
+
+		// when
+		requeueAfter, err := sut.HandleArchiveRequest(testCtx, estimatedCR)
+
+		// then
+		require.NoError(t, err)
+		assert.Zero(t, requeueAfter)
+	})
+	t.Run("should restart estimated archive if the dry run annotation was removed", func(t *testing.T) {
+		// given
+		estimatedCR := dryRunCR.DeepCopy()
+		domain.SetArchivePhase(&estimatedCR.Status, domain.ArchivePhaseEstimated, estimatedCR.Generation, time.Now())
+		domain.SetObservedAnnotations(&estimatedCR.Status, estimatedCR.Generation, estimatedCR.Annotations)
+		delete(estimatedCR.Annotations, domain.DryRunAnnotation)
+
+		interfaceMock := newMockSupportArchiveV1Interface(t)
+		clientMock := newMockSupportArchiveInterface(t)
+		interfaceMock.EXPECT().SupportArchives(testArchiveNamespace).Return(clientMock)
+		clientMock.EXPECT().UpdateStatusWithRetry(testCtx, estimatedCR, mock.Anything, metav1.UpdateOptions{}).Return(nil, nil).Run(func(_ context.Context, cr *libapi.SupportArchive, modifyStatusFn func(libapi.SupportArchiveStatus) libapi.SupportArchiveStatus, _ metav1.UpdateOptions) {
+			status := modifyStatusFn(cr.Status)
+			assert.Equal(t, domain.ArchivePhasePending, domain.GetArchivePhase(status))
+		})
+		sut := NewCreateArchiveUseCase(interfaceMock, CollectorMapping{}, nil, PostProcessingRepositories{}, NewArchiveLocks(), CreateArchiveOptions{ArchiveDeadline: time.Hour, DefaultContentTimeframe: 96 * time.Hour})
+
+		// when
+		requeueAfter, err := sut.HandleArchiveRequest(testCtx, estimatedCR)
+
+		// then
+		require.NoError(t, err)
+		assert.Equal(t, time.Nanosecond, requeueAfter)
+	})
+	t.Run("should estimate again if the timeframe of the dry run changed", func(t *testing.T) {
+		// given
+		estimatedCR := dryRunCR.DeepCopy()
+		estimatedCR.Annotations = map[string]string{domain.DryRunAnnotation: "true", domain.LastAnnotation: "1h"}
+		domain.SetArchivePhase(&estimatedCR.Status, domain.ArchivePhaseEstimated, estimatedCR.Generation, time.Now())
+		domain.SetObservedAnnotations(&estimatedCR.Status, estimatedCR.Generation, estimatedCR.Annotations)
+		estimatedCR.Annotations[domain.LastAnnotation] = "2h"
+
+		interfaceMock := newMockSupportArchiveV1Interface(t)
+		clientMock := newMockSupportArchiveInterface(t)
+		interfaceMock.EXPECT().SupportArchives(testArchiveNamespace).Return(clientMock)
+		clientMock.EXPECT().UpdateStatusWithRetry(testCtx, estimatedCR, mock.Anything, metav1.UpdateOptions{}).Return(nil, nil)
+		sut := NewCreateArchiveUseCase(interfaceMock, CollectorMapping{}, nil, PostProcessingRepositories{}, NewArchiveLocks(), CreateArchiveOptions{ArchiveDeadline: time.Hour, DefaultContentTimeframe: 96 * time.Hour})
+
+		// when
+		requeueAfter, err := sut.HandleArchiveRequest(testCtx, estimatedCR)
+
+		// then
+		require.NoError(t, err)
+		assert.Equal(t, time.Nanosecond, requeueAfter)
+	})
+	t.Run("should return error if status update fails", func(t *testing.T) {
+		// given
+		interfaceMock := newMockSupportArchiveV1Interface(t)
+		clientMock := newMockSupportArchiveInterface(t)
+		interfaceMock.EXPECT().SupportArchives(testArchiveNamespace).Return(clientMock)
+		clientMock.EXPECT().UpdateStatusWithRetry(testCtx, dryRunCR, mock.Anything, metav1.UpdateOptions{}).Return(nil, assert.AnError)
+		sut := NewCreateArchiveUseCase(interfaceMock, CollectorMapping{}, nil, PostProcessingRepositories{}, NewArchiveLocks(), CreateArchiveOptions{ArchiveDeadline: time.Hour, DefaultContentTimeframe: 96 * time.Hour})
+
+		// when
+		_, err := sut.HandleArchiveRequest(testCtx, dryRunCR)
+
+		// then
+		require.ErrorIs(t, err, assert.AnError)
+		assert.ErrorContains(t, err, "failed to set estimates for archive test-namespace/test-archive")
+	})
+}
+
+func TestCreateArchiveUseCase_HandleArchiveRequest_contentTimeframe(t *testing.T) {
+	startTime := time.Now().Truncate(time.Second).Add(-time.Minute)
+	startedStatus := libapi.SupportArchiveStatus{}
+	domain.SetArchivePhase(&startedStatus, domain.ArchivePhaseCollecting, 0, startTime)
+
+	t.Run("should collect with collector timeframe relative to the start of the archive creation", func(t *testing.T) {
+		// given
+		cr := testLogCR.DeepCopy()
+		cr.Annotations = map[string]string{domain.LastAnnotation: "6h", domain.CollectorTimeframesAnnotation: "logs=1h"}
+		cr.Status = startedStatus
+
+		logRepository := newMockCollectorRepository[domain.LogLine](t)
+		logRepository.EXPECT().IsCollected(testCtx, testID).Return(false, nil)
+		logRepository.EXPECT().IsSkipped(testCtx, testID).Return(false, "", nil)
+		logRepository.EXPECT().Create(mock.AnythingOfType("*context.cancelCtx"), testID, mock.AnythingOfType("<-chan *domain.LogLine")).Return(nil)
+		expectedHash := domain.CollectorSpecHash(domain.CollectorTypeLog, domain.ContentTimeframes{Collectors: map[domain.CollectorType]domain.Timeframe{
+			domain.CollectorTypeLog: {Start: startTime.Add(-time.Hour), End: startTime},
+		}})
+		logRepository.EXPECT().SetSpecHash(testCtx, testID, expectedHash).Return(nil)
+		logRepository.EXPECT().GetFailures(testCtx, testID).Return(0, nil)
+		logRepository.EXPECT().SetFailures(testCtx, testID, 1).Return(nil)
+		logCollector := newMockCollector[domain.LogLine](t)
+		logCollector.EXPECT().Collect(mock.AnythingOfType("*context.cancelCtx"), testArchiveNamespace, startTime.Add(-time.Hour), startTime, mock.AnythingOfType("chan<- *domain.LogLine")).Return(nil)
+		collectorMapping := CollectorMapping{domain.CollectorTypeLog: {Collector: logCollector, Repository: logRepository}}
+		repoMock := newMockSupportArchiveRepository(t)
+		repoMock.EXPECT().Exists(testCtx, testID).Return(false, nil)
+
+		interfaceMock := newMockSupportArchiveV1Interface(t)
+		clientMock := newMockSupportArchiveInterface(t)
+		interfaceMock.EXPECT().SupportArchives(testArchiveNamespace).Return(clientMock)
+		clientMock.EXPECT().UpdateStatusWithRetry(testCtx, cr, mock.Anything, metav1.UpdateOptions{}).Return(nil, nil).Run(func(_ context.Context, cr *libapi.SupportArchive, modifyStatusFn func(libapi.SupportArchiveStatus) libapi.SupportArchiveStatus, _ metav1.UpdateOptions) {
+			status := modifyStatusFn(cr.Status)
+			condition := meta.FindStatusCondition(status.Conditions, domain.ConditionContentTimeframe)
+			require.NotNil(t, condition)
+			assert.Equal(t, metav1.ConditionTrue, condition.Status)
+			expected := fmt.Sprintf("Content timeframe %s, Logs: %s",
+				domain.Timeframe{Start: startTime.Add(-6 * time.Hour), End: startTime},
+				domain.Timeframe{Start: startTime.Add(-time.Hour), End: startTime})
+			assert.Equal(t, expected, condition.Message)
+		})
+		sut := NewCreateArchiveUseCase(interfaceMock, collectorMapping, repoMock, PostProcessingRepositories{}, NewArchiveLocks(), CreateArchiveOptions{ArchiveDeadline: time.Hour, DefaultContentTimeframe: 96 * time.Hour})
+
+		// when
+		requeueAfter, err := sut.HandleArchiveRequest(testCtx, cr)
+
+		// then
+		require.NoError(t, err)
+		assert.Equal(t, time.Nanosecond, requeueAfter)
+	})
+	t.Run("should fail archive permanently for invalid timeframe", func(t *testing.T) {
+		// given
+		cr := testLogCR.DeepCopy()
+		cr.Annotations = map[string]string{domain.CollectorTimeframesAnnotation: "logs=forever"}
+
+		logRepository := newMockCollectorRepository[domain.LogLine](t)
+		collectorMapping := CollectorMapping{domain.CollectorTypeLog: {Collector: newMockCollector[domain.LogLine](t), Repository: logRepository}}
+		repoMock := newMockSupportArchiveRepository(t)
+
+		interfaceMock := newMockSupportArchiveV1Interface(t)
+		clientMock := newMockSupportArchiveInterface(t)
+		interfaceMock.EXPECT().SupportArchives(testArchiveNamespace).Return(clientMock)
+		clientMock.EXPECT().UpdateStatusWithRetry(testCtx, cr, mock.Anything, metav1.UpdateOptions{}).Return(nil, nil).Run(func(_ context.Context, cr *libapi.SupportArchive, modifyStatusFn func(libapi.SupportArchiveStatus) libapi.SupportArchiveStatus, _ metav1.UpdateOptions) {
+			status := modifyStatusFn(cr.Status)
+			assert.Equal(t, domain.ArchivePhaseFailed, domain.GetArchivePhase(status))
+
+			created := meta.FindStatusCondition(status.Conditions, libapi.ConditionSupportArchiveCreated)
+			require.NotNil(t, created)
+			assert.Equal(t, "InvalidContentTimeframe", created.Reason)
+			assert.Contains(t, created.Message, `invalid annotation k8s.cloudogu.com/support-archive-collector-timeframes: invalid duration "forever"`)
+
+			timeframe := meta.FindStatusCondition(status.Conditions, domain.ConditionContentTimeframe)
+			require.NotNil(t, timeframe)
+			assert.Equal(t, metav1.ConditionFalse, timeframe.Status)
+			assert.Equal(t, "Invalid", timeframe.Reason)
+		})
+		sut := NewCreateArchiveUseCase(interfaceMock, collectorMapping, repoMock, PostProcessingRepositories{}, NewArchiveLocks(), CreateArchiveOptions{ArchiveDeadline: time.Hour, DefaultContentTimeframe: 96 * time.Hour})
+
+		// when
+		requeueAfter, err := sut.HandleArchiveRequest(testCtx, cr)
+
+		// then
+		require.NoError(t, err)
+		assert.Zero(t, requeueAfter)
+	})
+	t.Run("should restart failed archive if the timeframe annotation changed", func(t *testing.T) {
+		// given
+		cr := testLogCR.DeepCopy()
+		cr.Annotations = map[string]string{domain.CollectorTimeframesAnnotation: "logs=forever"}
+		domain.SetArchivePhase(&cr.Status, domain.ArchivePhaseFailed, cr.Generation, time.Now())
+		domain.SetObservedAnnotations(&cr.Status, cr.Generation, cr.Annotations)
+		cr.Annotations[domain.CollectorTimeframesAnnotation] = "logs=1h"
+
+		interfaceMock := newMockSupportArchiveV1Interface(t)
+		clientMock := newMockSupportArchiveInterface(t)
+		interfaceMock.EXPECT().SupportArchives(testArchiveNamespace).Return(clientMock)
+		clientMock.EXPECT().UpdateStatusWithRetry(testCtx, cr, mock.Anything, metav1.UpdateOptions{}).Return(nil, nil).Run(func(_ context.Context, cr *libapi.SupportArchive, modifyStatusFn func(libapi.SupportArchiveStatus) libapi.SupportArchiveStatus, _ metav1.UpdateOptions) {
+			status := modifyStatusFn(cr.Status)
+			assert.Equal(t, domain.ArchivePhasePending, domain.GetArchivePhase(status))
+			assert.Nil(t, meta.FindStatusCondition(status.Conditions, domain.ConditionCollectionStarted))
+		})
+		sut := NewCreateArchiveUseCase(interfaceMock, CollectorMapping{}, newMockSupportArchiveRepository(t), PostProcessingRepositories{}, NewArchiveLocks(), CreateArchiveOptions{ArchiveDeadline: time.Hour, DefaultContentTimeframe: 96 * time.Hour})
+
+		// when
+		requeueAfter, err := sut.HandleArchiveRequest(testCtx, cr)
+
+		// then
+		require.NoError(t, err)
+		assert.Equal(t, time.Nanosecond, requeueAfter)
+	})
+	t.Run("should restart failed archive if the generation changed", func(t *testing.T) {
+		// given
+		cr := testLogCR.DeepCopy()
+		domain.SetArchivePhase(&cr.Status, domain.ArchivePhaseFailed, 1, time.Now())
+		cr.Generation = 2
+
+		interfaceMock := newMockSupportArchiveV1Interface(t)
+		clientMock := newMockSupportArchiveInterface(t)
+		interfaceMock.EXPECT().SupportArchives(testArchiveNamespace).Return(clientMock)
+		clientMock.EXPECT().UpdateStatusWithRetry(testCtx, cr, mock.Anything, metav1.UpdateOptions{}).Return(nil, assert.AnError)
+		sut := NewCreateArchiveUseCase(interfaceMock, CollectorMapping{}, newMockSupportArchiveRepository(t), PostProcessingRepositories{}, NewArchiveLocks(), CreateArchiveOptions{ArchiveDeadline: time.Hour, DefaultContentTimeframe: 96 * time.Hour})
+
+		// when
+		_, err := sut.HandleArchiveRequest(testCtx, cr)
+
+		// then
+		require.ErrorIs(t, err, assert.AnError)
+		assert.ErrorContains(t, err, "failed to reset status of archive test-namespace/test-archive")
+	})
+}
+
+func TestCreateArchiveUseCase_HandleArchiveRequest_specChange(t *testing.T) {
+	start := time.Date(2025, 1, 1, 0, 0, 0, 0, time.UTC)
+	end := time.Date(2025, 1, 5, 0, 0, 0, 0, time.UTC)
+	newCR := func() *libapi.SupportArchive {
+		cr := testLogCR.DeepCopy()
+		cr.Spec.ContentTimeframe = libapi.ContentTimeframe{StartTime: metav1.NewTime(start), EndTime: metav1.NewTime(end)}
+		return cr
+	}
+	currentHash := domain.CollectorSpecHash(domain.CollectorTypeLog, domain.ContentTimeframes{Default: domain.Timeframe{Start: start, End: end}})
+
+	t.Run("should keep collected data of the current spec", func(t *testing.T) {
+		// given
+		cr := newCR()
+		logRepository := newMockCollectorRepository[domain.LogLine](t)
+		logRepository.EXPECT().IsCollected(testCtx, testID).Return(true, nil)
+		logRepository.EXPECT().GetSpecHash(testCtx, testID).Return(currentHash, nil)
+		collectorMapping := CollectorMapping{domain.CollectorTypeLog: {Collector: newMockCollector[domain.LogLine](t), Repository: logRepository}}
+		repoMock := newMockSupportArchiveRepository(t)
+		repoMock.EXPECT().Exists(testCtx, testID).Return(true, nil)
+		sut := NewCreateArchiveUseCase(newMockSupportArchiveV1Interface(t), collectorMapping, repoMock, PostProcessingRepositories{}, NewArchiveLocks(), CreateArchiveOptions{ArchiveDeadline: time.Hour, DefaultContentTimeframe: 96 * time.Hour})
+
+		// when
+		requeueAfter, err := sut.HandleArchiveRequest(testCtx, cr)
+
+		// then
+		require.NoError(t, err)
+		assert.Zero(t, requeueAfter)
+	})
+	t.Run("should execute collector again if its data was collected with another spec", func(t *testing.T) {
+		// given
+		cr := newCR()
+		logRepository := newMockCollectorRepository[domain.LogLine](t)
+		logRepository.EXPECT().IsCollected(testCtx, testID).Return(true, nil)
+		logRepository.EXPECT().GetSpecHash(testCtx, testID).Return("outdated", nil)
+		logRepository.EXPECT().Delete(testCtx, testID).Return(nil)
+		logRepository.EXPECT().Create(mock.AnythingOfType("*context.cancelCtx"), testID, mock.AnythingOfType("<-chan *domain.LogLine")).Return(nil)
+		logRepository.EXPECT().SetSpecHash(testCtx, testID, currentHash).Return(nil)
+		logRepository.EXPECT().GetFailures(testCtx, testID).Return(0, nil)
+		logRepository.EXPECT().SetFailures(testCtx, testID, 1).Return(nil)
+		logCollector := newMockCollector[domain.LogLine](t)
+		logCollector.EXPECT().Collect(mock.AnythingOfType("*context.cancelCtx"), testArchiveNamespace, start, end, mock.AnythingOfType("chan<- *domain.LogLine")).Return(nil)
+		collectorMapping := CollectorMapping{domain.CollectorTypeLog: {Collector: logCollector, Repository: logRepository}}
+		repoMock := newMockSupportArchiveRepository(t)
+		repoMock.EXPECT().Exists(testCtx, testID).Return(false, nil)
+
+		interfaceMock := newMockSupportArchiveV1Interface(t)
+		clientMock := newMockSupportArchiveInterface(t)
+		interfaceMock.EXPECT().SupportArchives(testArchiveNamespace).Return(clientMock)
+		clientMock.EXPECT().UpdateStatusWithRetry(testCtx, cr, mock.Anything, metav1.UpdateOptions{}).Return(nil, nil)
+		sut := NewCreateArchiveUseCase(interfaceMock, collectorMapping, repoMock, PostProcessingRepositories{}, NewArchiveLocks(), CreateArchiveOptions{ArchiveDeadline: time.Hour, DefaultContentTimeframe: 96 * time.Hour})
+
+		// when
+		requeueAfter, err := sut.HandleArchiveRequest(testCtx, cr)
+
+		// then
+		require.NoError(t, err)
+		assert.Equal(t, time.Nanosecond, requeueAfter)
+	})
+	t.Run("should rebuild archive if its data has no spec hash", func(t *testing.T) {
+		// given
+		cr := newCR()
+		logRepository := newMockCollectorRepository[domain.LogLine](t)
+		logRepository.EXPECT().IsCollected(testCtx, testID).Return(true, nil)
+		logRepository.EXPECT().GetSpecHash(testCtx, testID).Return("", nil)
+		logRepository.EXPECT().Delete(testCtx, testID).Return(nil)
+		collectorMapping := CollectorMapping{domain.CollectorTypeLog: {Collector: newMockCollector[domain.LogLine](t), Repository: logRepository}}
+		repoMock := newMockSupportArchiveRepository(t)
+		repoMock.EXPECT().Exists(testCtx, testID).Return(true, nil)
+		repoMock.EXPECT().Delete(testCtx, testID).Return(nil)
+
+		interfaceMock := newMockSupportArchiveV1Interface(t)
+		clientMock := newMockSupportArchiveInterface(t)
+		interfaceMock.EXPECT().SupportArchives(testArchiveNamespace).Return(clientMock)
+		clientMock.EXPECT().UpdateStatusWithRetry(testCtx, cr, mock.Anything, metav1.UpdateOptions{}).Return(nil, nil)
+		sut := NewCreateArchiveUseCase(interfaceMock, collectorMapping, repoMock, PostProcessingRepositories{}, NewArchiveLocks(), CreateArchiveOptions{ArchiveDeadline: time.Hour, DefaultContentTimeframe: 96 * time.Hour})
+
+		// when
+		requeueAfter, err := sut.HandleArchiveRequest(testCtx, cr)
+
+		// then
+		require.NoError(t, err)
+		assert.Equal(t, time.Nanosecond, requeueAfter)
+	})
+	t.Run("should not execute collector if the spec hash cannot be recorded", func(t *testing.T) {
+		// given
+		cr := newCR()
+		logRepository := newMockCollectorRepository[domain.LogLine](t)
+		logRepository.EXPECT().IsCollected(testCtx, testID).Return(false, nil)
+		logRepository.EXPECT().IsSkipped(testCtx, testID).Return(false, "", nil)
+		logRepository.EXPECT().SetSpecHash(testCtx, testID, currentHash).Return(assert.AnError)
+		logRepository.EXPECT().Delete(testCtx, testID).Return(nil)
+		collectorMapping := CollectorMapping{domain.CollectorTypeLog: {Collector: newMockCollector[domain.LogLine](t), Repository: logRepository}}
+		repoMock := newMockSupportArchiveRepository(t)
+		repoMock.EXPECT().Exists(testCtx, testID).Return(false, nil)
+		sut := NewCreateArchiveUseCase(newMockSupportArchiveV1Interface(t), collectorMapping, repoMock, PostProcessingRepositories{}, NewArchiveLocks(), CreateArchiveOptions{ArchiveDeadline: time.Hour, DefaultContentTimeframe: 96 * time.Hour})
+
+		// when
+		_, err := sut.HandleArchiveRequest(testCtx, cr)
+
+		// then
+		require.Error(t, err)
+		assert.ErrorIs(t, err, assert.AnError)
+		assert.ErrorContains(t, err, "could not record spec hash of collector Logs")
+	})
+	t.Run("should return error on error getting spec hash", func(t *testing.T) {
+		// given
+		cr := newCR()
+		logRepository := newMockCollectorRepository[domain.LogLine](t)
+		logRepository.EXPECT().IsCollected(testCtx, testID).Return(true, nil)
+		logRepository.EXPECT().GetSpecHash(testCtx, testID).Return("", assert.AnError)
+		collectorMapping := CollectorMapping{domain.CollectorTypeLog: {Collector: newMockCollector[domain.LogLine](t), Repository: logRepository}}
+		sut := NewCreateArchiveUseCase(newMockSupportArchiveV1Interface(t), collectorMapping, newMockSupportArchiveRepository(t), PostProcessingRepositories{}, NewArchiveLocks(), CreateArchiveOptions{ArchiveDeadline: time.Hour, DefaultContentTimeframe: 96 * time.Hour})
+
+		// when
+		_, err := sut.HandleArchiveRequest(testCtx, cr)
+
+		// then
+		require.Error(t, err)
+		assert.ErrorIs(t, err, assert.AnError)
+		assert.ErrorContains(t, err, "could not invalidate stale collectors: failed to get spec hash of collector Logs")
+	})
+	t.Run("should rebuild existing archive if collected data does not match the spec", func(t *testing.T) {
+		// given
+		cr := newCR()
+		cr.Generation = 2
+		domain.SetArchivePhase(&cr.Status, domain.ArchivePhaseSucceeded, 1, start)
+		cr.Status.Conditions = append(cr.Status.Conditions, getSuccessfulArchiveCreatedCondition(testURL))
+		cr.Status.DownloadPath = testURL
+
+		logRepository := newMockCollectorRepository[domain.LogLine](t)
+		logRepository.EXPECT().IsCollected(testCtx, testID).Return(true, nil)
+		logRepository.EXPECT().GetSpecHash(testCtx, testID).Return("outdated", nil)
+		logRepository.EXPECT().Delete(testCtx, testID).Return(nil)
+		collectorMapping := CollectorMapping{domain.CollectorTypeLog: {Collector: newMockCollector[domain.LogLine](t), Repository: logRepository}}
+		repoMock := newMockSupportArchiveRepository(t)
+		repoMock.EXPECT().Exists(testCtx, testID).Return(true, nil)
+		repoMock.EXPECT().Delete(testCtx, testID).Return(nil)
+
+		interfaceMock := newMockSupportArchiveV1Interface(t)
+		clientMock := newMockSupportArchiveInterface(t)
+		interfaceMock.EXPECT().SupportArchives(testArchiveNamespace).Return(clientMock)
+		clientMock.EXPECT().UpdateStatusWithRetry(testCtx, cr, mock.Anything, metav1.UpdateOptions{}).Return(nil, nil).Run(func(_ context.Context, cr *libapi.SupportArchive, modifyStatusFn func(libapi.SupportArchiveStatus) libapi.SupportArchiveStatus, _ metav1.UpdateOptions) {
+			status := modifyStatusFn(cr.Status)
+			assert.Equal(t, domain.ArchivePhasePending, domain.GetArchivePhase(status))
+			assert.Nil(t, meta.FindStatusCondition(status.Conditions, domain.ConditionCollectionStarted))
+			assert.Nil(t, meta.FindStatusCondition(status.Conditions, domain.ConditionCollectionFinished))
+			assert.Nil(t, meta.FindStatusCondition(status.Conditions, libapi.ConditionSupportArchiveCreated))
+			assert.Empty(t, status.DownloadPath)
+		})
+		sut := NewCreateArchiveUseCase(interfaceMock, collectorMapping, repoMock, PostProcessingRepositories{}, NewArchiveLocks(), CreateArchiveOptions{ArchiveDeadline: time.Hour, DefaultContentTimeframe: 96 * time.Hour})
+
+		// when
+		requeueAfter, err := sut.HandleArchiveRequest(testCtx, cr)
+
+		// then
+		require.NoError(t, err)
+		assert.Equal(t, time.Nanosecond, requeueAfter)
+	})
+	t.Run("should rebuild existing archive if a collector was excluded", func(t *testing.T) {
+		// given
+		cr := newCR()
+		logRepository := newMockCollectorRepository[domain.LogLine](t)
+		logRepository.EXPECT().IsCollected(testCtx, testID).Return(true, nil)
+		logRepository.EXPECT().GetSpecHash(testCtx, testID).Return(currentHash, nil)
+		eventRepository := newMockCollectorRepository[domain.Event](t)
+		eventRepository.EXPECT().IsCollected(testCtx, testID).Return(true, nil)
+		eventRepository.EXPECT().Delete(testCtx, testID).Return(nil)
+		collectorMapping := CollectorMapping{
+			domain.CollectorTypeLog:    {Collector: newMockCollector[domain.LogLine](t), Repository: logRepository},
+			domain.CollectorTypeEvents: {Collector: newMockCollector[domain.Event](t), Repository: eventRepository},
+		}
+		repoMock := newMockSupportArchiveRepository(t)
+		repoMock.EXPECT().Exists(testCtx, testID).Return(true, nil)
+		repoMock.EXPECT().Delete(testCtx, testID).Return(nil)
+
+		interfaceMock := newMockSupportArchiveV1Interface(t)
+		clientMock := newMockSupportArchiveInterface(t)
+		interfaceMock.EXPECT().SupportArchives(testArchiveNamespace).Return(clientMock)
+		clientMock.EXPECT().UpdateStatusWithRetry(testCtx, cr, mock.Anything, metav1.UpdateOptions{}).Return(nil, nil)
+		sut := NewCreateArchiveUseCase(interfaceMock, collectorMapping, repoMock, PostProcessingRepositories{}, NewArchiveLocks(), CreateArchiveOptions{ArchiveDeadline: time.Hour, DefaultContentTimeframe: 96 * time.Hour})
+
+		// when
+		requeueAfter, err := sut.HandleArchiveRequest(testCtx, cr)
+
+		// then
+		require.NoError(t, err)
+		assert.Equal(t, time.Nanosecond, requeueAfter)
+	})
+	t.Run("should return error on error resetting the status for a rebuild", func(t *testing.T) {
+		// given
+		cr := newCR()
+		logRepository := newMockCollectorRepository[domain.LogLine](t)
+		logRepository.EXPECT().IsCollected(testCtx, testID).Return(true, nil)
+		logRepository.EXPECT().GetSpecHash(testCtx, testID).Return("outdated", nil)
+		logRepository.EXPECT().Delete(testCtx, testID).Return(nil)
+		collectorMapping := CollectorMapping{domain.CollectorTypeLog: {Collector: newMockCollector[domain.LogLine](t), Repository: logRepository}}
+		repoMock := newMockSupportArchiveRepository(t)
+		repoMock.EXPECT().Exists(testCtx, testID).Return(true, nil)
+
+		interfaceMock := newMockSupportArchiveV1Interface(t)
+		clientMock := newMockSupportArchiveInterface(t)
+		interfaceMock.EXPECT().SupportArchives(testArchiveNamespace).Return(clientMock)
+		clientMock.EXPECT().UpdateStatusWithRetry(testCtx, cr, mock.Anything, metav1.UpdateOptions{}).Return(nil, assert.AnError)
+		sut := NewCreateArchiveUseCase(interfaceMock, collectorMapping, repoMock, PostProcessingRepositories{}, NewArchiveLocks(), CreateArchiveOptions{ArchiveDeadline: time.Hour, DefaultContentTimeframe: 96 * time.Hour})
+
+		// when
+		_, err := sut.HandleArchiveRequest(testCtx, cr)
+
+		// then
+		require.Error(t, err)
+		assert.ErrorIs(t, err, assert.AnError)
+		assert.ErrorContains(t, err, "failed to reset status of archive test-namespace/test-archive")
+	})
+}
+
+func TestCreateArchiveUseCase_HandleArchiveRequest_refresh(t *testing.T) {
+	newRefreshCR := func() *libapi.SupportArchive {
+		cr := testLogCR.DeepCopy()
+		cr.Annotations = map[string]string{
+			domain.RefreshAnnotation:         "2025-01-06T00:00:00Z",
+			domain.RefreshedAnnotation:       "2025-01-05T00:00:00Z",
+			domain.DownloadHistoryAnnotation: `[{"downloadPath":"http://server/old.zip","replacedAt":"2025-01-05T00:00:00Z"}]`,
+		}
+		domain.SetArchivePhase(&cr.Status, domain.ArchivePhaseSucceeded, 0, time.Now().Add(-time.Hour))
+		cr.Status.DownloadPath = testURL + "?token=secret"
+		return cr
+	}
+
+	t.Run("should delete archive, reset status and mark refresh as handled", func(t *testing.T) {
+		// given
+		cr := newRefreshCR()
+		logRepository := newMockCollectorRepository[domain.LogLine](t)
+		logRepository.EXPECT().Delete(testCtx, testID).Return(nil)
+		collectorMapping := CollectorMapping{domain.CollectorTypeLog: {Collector: newMockCollector[domain.LogLine](t), Repository: logRepository}}
+		repoMock := newMockSupportArchiveRepository(t)
+		repoMock.EXPECT().Delete(testCtx, testID).Return(nil)
+
+		interfaceMock := newMockSupportArchiveV1Interface(t)
+		clientMock := newMockSupportArchiveInterface(t)
+		interfaceMock.EXPECT().SupportArchives(testArchiveNamespace).Return(clientMock)
+		clientMock.EXPECT().UpdateStatusWithRetry(testCtx, cr, mock.Anything, metav1.UpdateOptions{}).Return(nil, nil).Run(func(_ context.Context, cr *libapi.SupportArchive, modifyStatusFn func(libapi.SupportArchiveStatus) libapi.SupportArchiveStatus, _ metav1.UpdateOptions) {
+			status := modifyStatusFn(cr.Status)
+			assert.Equal(t, domain.ArchivePhasePending, domain.GetArchivePhase(status))
+			assert.Nil(t, meta.FindStatusCondition(status.Conditions, domain.ConditionCollectionStarted))
+			assert.Empty(t, status.DownloadPath)
+		})
+		clientMock.EXPECT().Patch(testCtx, testArchiveName, types.MergePatchType, mock.Anything, metav1.PatchOptions{}).Return(nil, nil).Run(func(_ context.Context, _ string, _ types.PatchType, data []byte, _ metav1.PatchOptions, _ ...string) {
+			patched := &libapi.SupportArchive{}
+			require.NoError(t, json.Unmarshal(data, patched))
+			assert.Equal(t, "2025-01-06T00:00:00Z", patched.Annotations[domain.RefreshedAnnotation])
+			history, err := domain.GetDownloadHistory(patched.Annotations)
+			require.NoError(t, err)
+			require.Len(t, history, 2)
+			assert.Equal(t, "http://server/old.zip", history[0].DownloadPath)
+			assert.Equal(t, testURL, history[1].DownloadPath)
+		})
+		sut := NewCreateArchiveUseCase(interfaceMock, collectorMapping, repoMock, PostProcessingRepositories{}, NewArchiveLocks(), CreateArchiveOptions{ArchiveDeadline: time.Hour, DefaultContentTimeframe: 96 * time.Hour})
+
+		// when
+		requeueAfter, err := sut.HandleArchiveRequest(testCtx, cr)
+
+		// then
+		require.NoError(t, err)
+		assert.Equal(t, time.Nanosecond, requeueAfter)
+	})
+	t.Run("should refresh failed archive and return error on error deleting archive", func(t *testing.T) {
+		// given
+		cr := newRefreshCR()
+		domain.SetArchivePhase(&cr.Status, domain.ArchivePhaseFailed, 0, time.Now())
+		logRepository := newMockCollectorRepository[domain.LogLine](t)
+		logRepository.EXPECT().Delete(testCtx, testID).Return(nil)
+		collectorMapping := CollectorMapping{domain.CollectorTypeLog: {Collector: newMockCollector[domain.LogLine](t), Repository: logRepository}}
+		repoMock := newMockSupportArchiveRepository(t)
+		repoMock.EXPECT().Delete(testCtx, testID).Return(assert.AnError)
+		sut := NewCreateArchiveUseCase(newMockSupportArchiveV1Interface(t), collectorMapping, repoMock, PostProcessingRepositories{}, NewArchiveLocks(), CreateArchiveOptions{ArchiveDeadline: time.Hour, DefaultContentTimeframe: 96 * time.Hour})
+
+		// when
+		_, err := sut.HandleArchiveRequest(testCtx, cr)
+
+		// then
+		require.Error(t, err)
+		assert.ErrorIs(t, err, assert.AnError)
+		assert.ErrorContains(t, err, "could not delete archive test-namespace/test-archive for refresh")
+	})
+	t.Run("should return error on error marking refresh as handled", func(t *testing.T) {
+		// given
+		cr := newRefreshCR()
+		logRepository := newMockCollectorRepository[domain.LogLine](t)
+		logRepository.EXPECT().Delete(testCtx, testID).Return(nil)
+		collectorMapping := CollectorMapping{domain.CollectorTypeLog: {Collector: newMockCollector[domain.LogLine](t), Repository: logRepository}}
+		repoMock := newMockSupportArchiveRepository(t)
+		repoMock.EXPECT().Delete(testCtx, testID).Return(nil)
+
+		interfaceMock := newMockSupportArchiveV1Interface(t)
+		clientMock := newMockSupportArchiveInterface(t)
+		interfaceMock.EXPECT().SupportArchives(testArchiveNamespace).Return(clientMock)
+		clientMock.EXPECT().UpdateStatusWithRetry(testCtx, cr, mock.Anything, metav1.UpdateOptions{}).Return(nil, nil)
+		clientMock.EXPECT().Patch(testCtx, testArchiveName, types.MergePatchType, mock.Anything, metav1.PatchOptions{}).Return(nil, assert.AnError)
+		sut := NewCreateArchiveUseCase(interfaceMock, collectorMapping, repoMock, PostProcessingRepositories{}, NewArchiveLocks(), CreateArchiveOptions{ArchiveDeadline: time.Hour, DefaultContentTimeframe: 96 * time.Hour})
+
+		// when
+		_, err := sut.HandleArchiveRequest(testCtx, cr)
+
+		// then
+		require.Error(t, err)
+		assert.ErrorIs(t, err, assert.AnError)
+		assert.ErrorContains(t, err, "failed to mark archive test-namespace/test-archive as refreshed")
+	})
+	t.Run("should not refresh twice", func(t *testing.T) {
+		// given
+		cr := newRefreshCR()
+		cr.Annotations[domain.RefreshedAnnotation] = cr.Annotations[domain.RefreshAnnotation]
+		domain.SetArchivePhase(&cr.Status, domain.ArchivePhaseFailed, 0, time.Now())
+		sut := NewCreateArchiveUseCase(newMockSupportArchiveV1Interface(t), CollectorMapping{}, newMockSupportArchiveRepository(t), PostProcessingRepositories{}, NewArchiveLocks(), CreateArchiveOptions{ArchiveDeadline: time.Hour, DefaultContentTimeframe: 96 * time.Hour})
+
+		// when
+		requeueAfter, err := sut.HandleArchiveRequest(testCtx, cr)
+
+		// then
+		require.NoError(t, err)
+		assert.Zero(t, requeueAfter)
+	})
+}
+
+// estimatingLogCollector is a log collector which can estimate the size of its data.
+type estimatingLogCollector struct {
+	*mockCollector[domain.LogLine]
+	*mockEstimator
+}
+
+func TestCreateArchiveUseCase_HandleArchiveRequest_inMemory(t *testing.T) {
+	start := time.Date(2025, 1, 1, 0, 0, 0, 0, time.UTC)
+	end := time.Date(2025, 1, 5, 0, 0, 0, 0, time.UTC)
+	newCR := func() *libapi.SupportArchive {
+		cr := testLogCR.DeepCopy()
+		cr.Spec.ContentTimeframe = libapi.ContentTimeframe{StartTime: metav1.NewTime(start), EndTime: metav1.NewTime(end)}
+		return cr
+	}
+	archiveHash := domain.ArchiveSpecHash([]domain.CollectorType{domain.CollectorTypeLog}, domain.ContentTimeframes{Default: domain.Timeframe{Start: start, End: end}})
+	newVolumeMapping := func(t *testing.T) (CollectorMapping, *mockCollectorRepository[domain.LogLine]) {
+		logRepository := newMockCollectorRepository[domain.LogLine](t)
+		logRepository.EXPECT().IsCollected(testCtx, testID).Return(false, nil)
+		logRepository.EXPECT().IsSkipped(testCtx, testID).Return(false, "", nil)
+		return CollectorMapping{domain.CollectorTypeLog: {Collector: newMockCollector[domain.LogLine](t), Repository: logRepository}}, logRepository
+	}
+	newEstimatingCollector := func(t *testing.T, bytes int64) estimatingLogCollector {
+		logEstimator := newMockEstimator(t)
+		logEstimator.EXPECT().Estimate(testCtx, testArchiveNamespace, start, end).Return(domain.CollectorEstimate{Items: 10, Unit: "log lines", Bytes: bytes, Requests: 1}, nil)
+		return estimatingLogCollector{mockCollector: newMockCollector[domain.LogLine](t), mockEstimator: logEstimator}
+	}
+	expectCollectionInWorkDirectory := func(t *testing.T, mapping CollectorMapping, logRepository *mockCollectorRepository[domain.LogLine]) {
+		logCollector := newMockCollector[domain.LogLine](t)
+		logCollector.EXPECT().Collect(mock.Anything, testArchiveNamespace, start, end, mock.Anything).Return(nil)
+		logCollector.EXPECT().Name().Return("Logs").Maybe()
+		mapping[domain.CollectorTypeLog] = CollectorAndRepository{Collector: logCollector, Repository: logRepository}
+		logRepository.EXPECT().Create(mock.Anything, testID, mock.Anything).Return(nil)
+		logRepository.EXPECT().SetSpecHash(testCtx, testID, mock.Anything).Return(nil)
+		logRepository.EXPECT().GetFailures(testCtx, testID).Return(0, nil)
+		logRepository.EXPECT().SetFailures(testCtx, testID, 1).Return(nil)
+	}
+
+	t.Run("should create small archive in memory", func(t *testing.T) {
+		// given
+		cr := newCR()
+		volumeMapping, _ := newVolumeMapping(t)
+		logCollector := newEstimatingCollector(t, 100)
+		logCollector.mockCollector.EXPECT().Collect(mock.Anything, testArchiveNamespace, start, end, mock.Anything).Return(nil)
+		logCollector.mockCollector.EXPECT().Name().Return("Logs").Maybe()
+		memoryRepository := newMockCollectorRepository[domain.LogLine](t)
+		memoryRepository.EXPECT().Create(mock.Anything, testID, mock.Anything).Return(nil)
+		memoryRepository.EXPECT().IsSkipped(testCtx, testID).Return(false, "", nil)
+		memoryRepository.EXPECT().IsCollected(mock.AnythingOfType("*context.cancelCtx"), testID).Return(true, nil)
+		memoryRepository.EXPECT().Stream(mock.AnythingOfType("*context.cancelCtx"), testID, mock.AnythingOfType("*domain.Stream")).RunAndReturn(streamDataWithID("logs.log"))
+		memoryRepository.EXPECT().Delete(testCtx, testID).Return(nil)
+		inMemoryArchives := &InMemoryArchives{
+			CollectorMapping: CollectorMapping{domain.CollectorTypeLog: {Collector: logCollector, Repository: memoryRepository}},
+			PostProcessing:   newEmptyPostProcessingMocks(t),
+			MaxSize:          1000,
+		}
+
+		repoMock := newMockSupportArchiveRepository(t)
+		repoMock.EXPECT().Exists(testCtx, testID).Return(false, nil)
+		repoMock.EXPECT().Create(mock.AnythingOfType("*context.cancelCtx"), testID, mock.Anything, mock.Anything).RunAndReturn(func(_ context.Context, _ domain.SupportArchiveID, streams map[domain.CollectorType]*domain.Stream, _ time.Time) (string, error) {
+			assert.Equal(t, []string{"logs.log"}, readStreamIDs(streams[domain.CollectorTypeLog]))
+			readStreamIDs(streams[domain.ArchiveRootDir])
+			return testURL, nil
+		})
+
+		interfaceMock := newMockSupportArchiveV1Interface(t)
+		clientMock := newMockSupportArchiveInterface(t)
+		interfaceMock.EXPECT().SupportArchives(testArchiveNamespace).Return(clientMock)
+		var statuses []libapi.SupportArchiveStatus
+		clientMock.EXPECT().UpdateStatusWithRetry(testCtx, cr, mock.Anything, metav1.UpdateOptions{}).Return(nil, nil).Run(func(_ context.Context, cr *libapi.SupportArchive, modifyStatusFn func(libapi.SupportArchiveStatus) libapi.SupportArchiveStatus, _ metav1.UpdateOptions) {
+			statuses = append(statuses, modifyStatusFn(cr.Status))
+		})
+		inMemoryRepository := newMockInMemoryArchiveRepository(t)
+		inMemoryRepository.EXPECT().GetSpecHash(testCtx, testID).Return("", nil)
+		inMemoryRepository.EXPECT().SetSpecHash(testCtx, testID, archiveHash).Return(nil)
+		sut := NewCreateArchiveUseCase(interfaceMock, volumeMapping, repoMock, PostProcessingRepositories{}, NewArchiveLocks(), CreateArchiveOptions{ArchiveDeadline: time.Hour, DefaultContentTimeframe: 96 * time.Hour, InMemoryArchives: inMemoryArchives, InMemoryArchiveRepository: inMemoryRepository})
+
+		// when
+		requeueAfter, err := sut.HandleArchiveRequest(testCtx, cr)
+
+		// then
+		require.NoError(t, err)
+		assert.Zero(t, requeueAfter)
+		require.Len(t, statuses, 2)
+		logs := meta.FindStatusCondition(statuses[0].Conditions, libapi.ConditionLogsFetched)
+		require.NotNil(t, logs)
+		assert.Equal(t, metav1.ConditionTrue, logs.Status)
+		assert.Equal(t, domain.ArchivePhaseSucceeded, domain.GetArchivePhase(statuses[1]))
+		assert.Equal(t, testURL, statuses[1].DownloadPath)
+	})
+	t.Run("should collect in the work directory if the estimated size exceeds the maximum", func(t *testing.T) {
+		// given
+		cr := newCR()
+		volumeMapping, logRepository := newVolumeMapping(t)
+		expectCollectionInWorkDirectory(t, volumeMapping, logRepository)
+		inMemoryArchives := &InMemoryArchives{
+			CollectorMapping: CollectorMapping{domain.CollectorTypeLog: {Collector: newEstimatingCollector(t, 2000), Repository: newMockCollectorRepository[domain.LogLine](t)}},
+			MaxSize:          1000,
+		}
+		repoMock := newMockSupportArchiveRepository(t)
+		repoMock.EXPECT().Exists(testCtx, testID).Return(false, nil)
+
+		interfaceMock := newMockSupportArchiveV1Interface(t)
+		clientMock := newMockSupportArchiveInterface(t)
+		interfaceMock.EXPECT().SupportArchives(testArchiveNamespace).Return(clientMock)
+		clientMock.EXPECT().UpdateStatusWithRetry(testCtx, cr, mock.Anything, metav1.UpdateOptions{}).Return(nil, nil)
+		inMemoryRepository := newMockInMemoryArchiveRepository(t)
+		inMemoryRepository.EXPECT().GetSpecHash(testCtx, testID).Return("", nil)
+		sut := NewCreateArchiveUseCase(interfaceMock, volumeMapping, repoMock, PostProcessingRepositories{}, NewArchiveLocks(), CreateArchiveOptions{ArchiveDeadline: time.Hour, DefaultContentTimeframe: 96 * time.Hour, InMemoryArchives: inMemoryArchives, InMemoryArchiveRepository: inMemoryRepository})
+
+		// when
+		requeueAfter, err := sut.HandleArchiveRequest(testCtx, cr)
+
+		// then
+		require.NoError(t, err)
+		assert.Equal(t, time.Nanosecond, requeueAfter)
+	})
+	t.Run("should collect in the work directory if the size cannot be estimated", func(t *testing.T) {
+		// given
+		cr := newCR()
+		volumeMapping, logRepository := newVolumeMapping(t)
+		expectCollectionInWorkDirectory(t, volumeMapping, logRepository)
+		inMemoryArchives := &InMemoryArchives{
+			CollectorMapping: CollectorMapping{domain.CollectorTypeLog: {Collector: newMockCollector[domain.LogLine](t), Repository: newMockCollectorRepository[domain.LogLine](t)}},
+			MaxSize:          1000,
+		}
+		repoMock := newMockSupportArchiveRepository(t)
+		repoMock.EXPECT().Exists(testCtx, testID).Return(false, nil)
+
+		interfaceMock := newMockSupportArchiveV1Interface(t)
+		clientMock := newMockSupportArchiveInterface(t)
+		interfaceMock.EXPECT().SupportArchives(testArchiveNamespace).Return(clientMock)
+		clientMock.EXPECT().UpdateStatusWithRetry(testCtx, cr, mock.Anything, metav1.UpdateOptions{}).Return(nil, nil)
+		inMemoryRepository := newMockInMemoryArchiveRepository(t)
+		inMemoryRepository.EXPECT().GetSpecHash(testCtx, testID).Return("", nil)
+		sut := NewCreateArchiveUseCase(interfaceMock, volumeMapping, repoMock, PostProcessingRepositories{}, NewArchiveLocks(), CreateArchiveOptions{ArchiveDeadline: time.Hour, DefaultContentTimeframe: 96 * time.Hour, InMemoryArchives: inMemoryArchives, InMemoryArchiveRepository: inMemoryRepository})
+
+		// when
+		requeueAfter, err := sut.HandleArchiveRequest(testCtx, cr)
+
+		// then
+		require.NoError(t, err)
+		assert.Equal(t, time.Nanosecond, requeueAfter)
+	})
+	t.Run("should collect in the work directory if a collector fails in memory", func(t *testing.T) {
+		// given
+		cr := newCR()
+		volumeMapping, logRepository := newVolumeMapping(t)
+		expectCollectionInWorkDirectory(t, volumeMapping, logRepository)
+		logCollector := newEstimatingCollector(t, 100)
+		logCollector.mockCollector.EXPECT().Collect(mock.Anything, testArchiveNamespace, start, end, mock.Anything).Return(assert.AnError)
+		logCollector.mockCollector.EXPECT().Name().Return("Logs").Maybe()
+		memoryRepository := newMockCollectorRepository[domain.LogLine](t)
+		memoryRepository.EXPECT().Create(mock.Anything, testID, mock.Anything).Return(nil).Maybe()
+		memoryRepository.EXPECT().Delete(testCtx, testID).Return(nil)
+		inMemoryArchives := &InMemoryArchives{
+			CollectorMapping: CollectorMapping{domain.CollectorTypeLog: {Collector: logCollector, Repository: memoryRepository}},
+			MaxSize:          1000,
+		}
+		repoMock := newMockSupportArchiveRepository(t)
+		repoMock.EXPECT().Exists(testCtx, testID).Return(false, nil)
+
+		interfaceMock := newMockSupportArchiveV1Interface(t)
+		clientMock := newMockSupportArchiveInterface(t)
+		interfaceMock.EXPECT().SupportArchives(testArchiveNamespace).Return(clientMock)
+		clientMock.EXPECT().UpdateStatusWithRetry(testCtx, cr, mock.Anything, metav1.UpdateOptions{}).Return(nil, nil)
+		inMemoryRepository := newMockInMemoryArchiveRepository(t)
+		inMemoryRepository.EXPECT().GetSpecHash(testCtx, testID).Return("", nil)
+		sut := NewCreateArchiveUseCase(interfaceMock, volumeMapping, repoMock, PostProcessingRepositories{}, NewArchiveLocks(), CreateArchiveOptions{ArchiveDeadline: time.Hour, DefaultContentTimeframe: 96 * time.Hour, InMemoryArchives: inMemoryArchives, InMemoryArchiveRepository: inMemoryRepository})
+
+		// when
+		requeueAfter, err := sut.HandleArchiveRequest(testCtx, cr)
+
+		// then
+		require.NoError(t, err)
+		assert.Equal(t, time.Nanosecond, requeueAfter)
+	})
+	t.Run("should keep archive created in memory if it matches the spec", func(t *testing.T) {
+		// given
+		cr := newCR()
+		inMemoryRepository := newMockInMemoryArchiveRepository(t)
+		inMemoryRepository.EXPECT().GetSpecHash(testCtx, testID).Return(archiveHash, nil)
+		repoMock := newMockSupportArchiveRepository(t)
+		repoMock.EXPECT().Exists(testCtx, testID).Return(true, nil)
+		collectorMapping := CollectorMapping{domain.CollectorTypeLog: {Collector: newMockCollector[domain.LogLine](t), Repository: newMockCollectorRepository[domain.LogLine](t)}}
+		sut := NewCreateArchiveUseCase(newMockSupportArchiveV1Interface(t), collectorMapping, repoMock, PostProcessingRepositories{}, NewArchiveLocks(), CreateArchiveOptions{ArchiveDeadline: time.Hour, DefaultContentTimeframe: 96 * time.Hour, InMemoryArchiveRepository: inMemoryRepository})
+
+		// when
+		requeueAfter, err := sut.HandleArchiveRequest(testCtx, cr)
+
+		// then
+		require.NoError(t, err)
+		assert.Zero(t, requeueAfter)
+	})
+	t.Run("should rebuild archive created in memory if the spec changed", func(t *testing.T) {
+		// given
+		cr := newCR()
+		inMemoryRepository := newMockInMemoryArchiveRepository(t)
+		inMemoryRepository.EXPECT().GetSpecHash(testCtx, testID).Return("outdated", nil)
+		cr.Status.DownloadPath = testURL
+		repoMock := newMockSupportArchiveRepository(t)
+		repoMock.EXPECT().Exists(testCtx, testID).Return(true, nil)
+		repoMock.EXPECT().Delete(testCtx, testID).Return(nil)
+		collectorMapping := CollectorMapping{domain.CollectorTypeLog: {Collector: newMockCollector[domain.LogLine](t), Repository: newMockCollectorRepository[domain.LogLine](t)}}
+
+		interfaceMock := newMockSupportArchiveV1Interface(t)
+		clientMock := newMockSupportArchiveInterface(t)
+		interfaceMock.EXPECT().SupportArchives(testArchiveNamespace).Return(clientMock)
+		clientMock.EXPECT().UpdateStatusWithRetry(testCtx, cr, mock.Anything, metav1.UpdateOptions{}).Return(nil, nil).Run(func(_ context.Context, cr *libapi.SupportArchive, modifyStatusFn func(libapi.SupportArchiveStatus) libapi.SupportArchiveStatus, _ metav1.UpdateOptions) {
+			status := modifyStatusFn(cr.Status)
+			assert.Equal(t, domain.ArchivePhasePending, domain.GetArchivePhase(status))
+			assert.Empty(t, status.DownloadPath)
+		})
+		inMemoryRepository.EXPECT().Delete(testCtx, testID).Return(nil)
+		sut := NewCreateArchiveUseCase(interfaceMock, collectorMapping, repoMock, PostProcessingRepositories{}, NewArchiveLocks(), CreateArchiveOptions{ArchiveDeadline: time.Hour, DefaultContentTimeframe: 96 * time.Hour, InMemoryArchiveRepository: inMemoryRepository})
+
+		// when
+		requeueAfter, err := sut.HandleArchiveRequest(testCtx, cr)
+
+		// then
+		require.NoError(t, err)
+		assert.Equal(t, time.Nanosecond, requeueAfter)
+	})
+	t.Run("should recreate archive created in memory if it is missing", func(t *testing.T) {
+		// given
+		cr := newCR()
+		inMemoryRepository := newMockInMemoryArchiveRepository(t)
+		inMemoryRepository.EXPECT().GetSpecHash(testCtx, testID).Return(archiveHash, nil)
+		repoMock := newMockSupportArchiveRepository(t)
+		repoMock.EXPECT().Exists(testCtx, testID).Return(false, nil)
+		repoMock.EXPECT().Delete(testCtx, testID).Return(nil)
+		collectorMapping := CollectorMapping{domain.CollectorTypeLog: {Collector: newMockCollector[domain.LogLine](t), Repository: newMockCollectorRepository[domain.LogLine](t)}}
+
+		interfaceMock := newMockSupportArchiveV1Interface(t)
+		clientMock := newMockSupportArchiveInterface(t)
+		interfaceMock.EXPECT().SupportArchives(testArchiveNamespace).Return(clientMock)
+		clientMock.EXPECT().UpdateStatusWithRetry(testCtx, cr, mock.Anything, metav1.UpdateOptions{}).Return(nil, nil)
+		inMemoryRepository.EXPECT().Delete(testCtx, testID).Return(assert.AnError)
+		sut := NewCreateArchiveUseCase(interfaceMock, collectorMapping, repoMock, PostProcessingRepositories{}, NewArchiveLocks(), CreateArchiveOptions{ArchiveDeadline: time.Hour, DefaultContentTimeframe: 96 * time.Hour, InMemoryArchiveRepository: inMemoryRepository})
+
+		// when
+		_, err := sut.HandleArchiveRequest(testCtx, cr)
+
+		// then
+		require.Error(t, err)
+		assert.ErrorIs(t, err, assert.AnError)
+		assert.ErrorContains(t, err, "could not delete spec hash of archive created in memory")
+	})
+	t.Run("should return error if the spec hash of the archive created in memory cannot be read", func(t *testing.T) {
+		// given
+		cr := newCR()
+		inMemoryRepository := newMockInMemoryArchiveRepository(t)
+		inMemoryRepository.EXPECT().GetSpecHash(testCtx, testID).Return("", assert.AnError)
+		collectorMapping := CollectorMapping{domain.CollectorTypeLog: {Collector: newMockCollector[domain.LogLine](t), Repository: newMockCollectorRepository[domain.LogLine](t)}}
+		sut := NewCreateArchiveUseCase(newMockSupportArchiveV1Interface(t), collectorMapping, newMockSupportArchiveRepository(t), PostProcessingRepositories{}, NewArchiveLocks(), CreateArchiveOptions{ArchiveDeadline: time.Hour, DefaultContentTimeframe: 96 * time.Hour, InMemoryArchiveRepository: inMemoryRepository})
+
+		// when
+		_, err := sut.HandleArchiveRequest(testCtx, cr)
+
+		// then
+		require.Error(t, err)
+		assert.ErrorIs(t, err, assert.AnError)
+		assert.ErrorContains(t, err, "could not get spec hash of archive created in memory")
+	})
+}
+
+func TestCreateArchiveUseCase_CreateLocalArchive(t *testing.T) {
+	t.Run("should execute collectors, create archive and delete collected data", func(t *testing.T) {
+		// given
+		logCollector := newMockCollector[domain.LogLine](t)
+		logCollector.EXPECT().Collect(mock.AnythingOfType("*context.cancelCtx"), testArchiveNamespace, mock.Anything, mock.Anything, mock.AnythingOfType("chan<- *domain.LogLine")).Return(nil)
+		logCollector.EXPECT().Name().Return("Logs").Maybe()
+		logRepository := newMockCollectorRepository[domain.LogLine](t)
+		logRepository.EXPECT().Create(mock.AnythingOfType("*context.cancelCtx"), testID, mock.AnythingOfType("<-chan *domain.LogLine")).Return(nil)
+		logRepository.EXPECT().IsSkipped(testCtx, testID).Return(false, "", nil)
+		logRepository.EXPECT().IsCollected(mock.AnythingOfType("*context.cancelCtx"), testID).Return(true, nil)
+		logRepository.EXPECT().Stream(mock.AnythingOfType("*context.cancelCtx"), testID, mock.AnythingOfType("*domain.Stream")).RunAndReturn(streamDataWithID("logs.log"))
+		logRepository.EXPECT().Delete(testCtx, testID).Return(nil)
+		repoMock := newMockSupportArchiveRepository(t)
+		repoMock.EXPECT().Create(mock.AnythingOfType("*context.cancelCtx"), testID, mock.Anything, mock.Anything).RunAndReturn(func(_ context.Context, _ domain.SupportArchiveID, streams map[domain.CollectorType]*domain.Stream, _ time.Time) (string, error) {
+			assert.Equal(t, []string{"logs.log"}, readStreamIDs(streams[domain.CollectorTypeLog]))
+			readStreamIDs(streams[domain.ArchiveRootDir])
+			return testURL, nil
+		})
+		mapping := CollectorMapping{domain.CollectorTypeLog: {Collector: logCollector, Repository: logRepository}}
+		sut := NewLocalCreateArchiveUseCase(mapping, repoMock, newEmptyPostProcessingMocks(t), CreateArchiveOptions{ArchiveDeadline: time.Hour, DefaultContentTimeframe: 96 * time.Hour})
+
+		// when
+		skipped, err := sut.CreateLocalArchive(testCtx, testLogCR)
+
+		// then
+		require.NoError(t, err)
+		assert.Empty(t, skipped)
+	})
+	t.Run("should skip failing collector immediately", func(t *testing.T) {
+		// given
+		logCollector := newMockCollector[domain.LogLine](t)
+		logCollector.EXPECT().Collect(mock.AnythingOfType("*context.cancelCtx"), testArchiveNamespace, mock.Anything, mock.Anything, mock.AnythingOfType("chan<- *domain.LogLine")).Return(assert.AnError)
+		logCollector.EXPECT().Name().Return("Logs")
+		logRepository := newMockCollectorRepository[domain.LogLine](t)
+		logRepository.EXPECT().Create(mock.AnythingOfType("*context.cancelCtx"), testID, mock.AnythingOfType("<-chan *domain.LogLine")).Return(nil)
+		logRepository.EXPECT().Skip(mock.AnythingOfType("*context.timerCtx"), testID, mock.AnythingOfType("string")).Return(nil)
+		logRepository.EXPECT().IsSkipped(testCtx, testID).Return(true, "Collector Logs failed", nil)
+		logRepository.EXPECT().Delete(testCtx, testID).Return(nil)
+		repoMock := newMockSupportArchiveRepository(t)
+		repoMock.EXPECT().Create(mock.AnythingOfType("*context.cancelCtx"), testID, mock.Anything, mock.Anything).RunAndReturn(func(_ context.Context, _ domain.SupportArchiveID, streams map[domain.CollectorType]*domain.Stream, _ time.Time) (string, error) {
+			assert.NotContains(t, streams, domain.CollectorTypeLog)
+			assert.Equal(t, []string{"Logs.txt"}, readStreamIDs(streams[domain.ArchiveErrorsDir]))
+			readStreamIDs(streams[domain.ArchiveRootDir])
+			return testURL, nil
+		})
+		mapping := CollectorMapping{domain.CollectorTypeLog: {Collector: logCollector, Repository: logRepository}}
+		sut := NewLocalCreateArchiveUseCase(mapping, repoMock, newEmptyPostProcessingMocks(t), CreateArchiveOptions{ArchiveDeadline: time.Hour, DefaultContentTimeframe: 96 * time.Hour})
+
+		// when
+		skipped, err := sut.CreateLocalArchive(testCtx, testLogCR)
+
+		// then
+		require.NoError(t, err)
+		assert.Equal(t, map[domain.CollectorType]string{domain.CollectorTypeLog: "Collector Logs failed"}, skipped)
+	})
+	t.Run("should return error if collector cannot be skipped", func(t *testing.T) {
+		// given
+		logCollector := newMockCollector[domain.LogLine](t)
+		logCollector.EXPECT().Collect(mock.AnythingOfType("*context.cancelCtx"), testArchiveNamespace, mock.Anything, mock.Anything, mock.AnythingOfType("chan<- *domain.LogLine")).Return(assert.AnError)
+		logCollector.EXPECT().Name().Return("Logs")
+		logRepository := newMockCollectorRepository[domain.LogLine](t)
+		logRepository.EXPECT().Create(mock.AnythingOfType("*context.cancelCtx"), testID, mock.AnythingOfType("<-chan *domain.LogLine")).Return(nil)
+		logRepository.EXPECT().Skip(mock.AnythingOfType("*context.timerCtx"), testID, mock.AnythingOfType("string")).Return(assert.AnError)
+		logRepository.EXPECT().Delete(testCtx, testID).Return(nil)
+		mapping := CollectorMapping{domain.CollectorTypeLog: {Collector: logCollector, Repository: logRepository}}
+		sut := NewLocalCreateArchiveUseCase(mapping, newMockSupportArchiveRepository(t), PostProcessingRepositories{}, CreateArchiveOptions{ArchiveDeadline: time.Hour, DefaultContentTimeframe: 96 * time.Hour})
+
+		// when
+		_, err := sut.CreateLocalArchive(testCtx, testLogCR)
+
+		// then
+		require.Error(t, err)
+		assert.ErrorIs(t, err, assert.AnError)
+		assert.ErrorContains(t, err, "could not skip collector Logs")
+	})
+	t.Run("should return error for invalid timeframe", func(t *testing.T) {
+		// given
+		cr := testLogCR.DeepCopy()
+		cr.Annotations = map[string]string{domain.LastAnnotation: "0s"}
+		logRepository := newMockCollectorRepository[domain.LogLine](t)
+		logRepository.EXPECT().Delete(testCtx, testID).Return(nil)
+		mapping := CollectorMapping{domain.CollectorTypeLog: {Collector: newMockCollector[domain.LogLine](t), Repository: logRepository}}
+		sut := NewLocalCreateArchiveUseCase(mapping, newMockSupportArchiveRepository(t), PostProcessingRepositories{}, CreateArchiveOptions{ArchiveDeadline: time.Hour, DefaultContentTimeframe: 96 * time.Hour})
+
+		// when
+		_, err := sut.CreateLocalArchive(testCtx, cr)
+
+		// then
+		require.Error(t, err)
+		assert.ErrorContains(t, err, "invalid content timeframe of archive test-namespace/test-archive")
+	})
+	t.Run("should return error if the deadline exceeded", func(t *testing.T) {
+		// given
+		logCollector := newMockCollector[domain.LogLine](t)
+		logCollector.EXPECT().Collect(mock.AnythingOfType("*context.cancelCtx"), testArchiveNamespace, mock.Anything, mock.Anything, mock.AnythingOfType("chan<- *domain.LogLine")).RunAndReturn(func(ctx context.Context, _ string, _, _ time.Time, _ chan<- *domain.LogLine) error {
+			<-ctx.Done()
+			return ctx.Err()
+		})
+		logCollector.EXPECT().Name().Return("Logs")
+		logRepository := newMockCollectorRepository[domain.LogLine](t)
+		logRepository.EXPECT().Create(mock.AnythingOfType("*context.cancelCtx"), testID, mock.AnythingOfType("<-chan *domain.LogLine")).Return(nil)
+		logRepository.EXPECT().Delete(testCtx, testID).Return(nil)
+		mapping := CollectorMapping{domain.CollectorTypeLog: {Collector: logCollector, Repository: logRepository}}
+		sut := NewLocalCreateArchiveUseCase(mapping, newMockSupportArchiveRepository(t), PostProcessingRepositories{}, CreateArchiveOptions{ArchiveDeadline: time.Millisecond, DefaultContentTimeframe: 96 * time.Hour})
+
+		// when
+		_, err := sut.CreateLocalArchive(testCtx, testLogCR)
+
+		// then
+		require.Error(t, err)
+		assert.ErrorIs(t, err, context.DeadlineExceeded)
+		assert.ErrorContains(t, err, "archive creation exceeded the deadline of 1ms")
+	})
 }
@@ -11,30 +11,55 @@ import (
 type DeleteArchiveUseCase struct {
 	supportArchiveRepository supportArchiveRepository
 	collectorMapping         CollectorMapping
+	archiveLocks             *ArchiveLocks
+	// inMemoryArchiveRepository is nil if archives are never created in memory.
+	inMemoryArchiveRepository inMemoryArchiveRepository
 }
 
-func NewDeleteArchiveUseCase(collectorMapping CollectorMapping, supportArchiveRepository supportArchiveRepository) *DeleteArchiveUseCase {
+func NewDeleteArchiveUseCase(collectorMapping CollectorMapping, supportArchiveRepository supportArchiveRepository, archiveLocks *ArchiveLocks, inMemoryArchiveRepository inMemoryArchiveRepository) *DeleteArchiveUseCase {
 	return &DeleteArchiveUseCase{
-		supportArchiveRepository: supportArchiveRepository,
-		collectorMapping:         collectorMapping,
+		supportArchiveRepository:  supportArchiveRepository,
+		collectorMapping:          collectorMapping,
+		archiveLocks:              archiveLocks,
+		inMemoryArchiveRepository: inMemoryArchiveRepository,
 	}
 }
 
+// Delete deletes the support archive and the data of all collectors. It waits until the archive is not handled anymore.
 func (d *DeleteArchiveUseCase) Delete(ctx context.Context, id domain.SupportArchiveID) error {
+	unlock, err := d.archiveLocks.Lock(ctx, id)
+	if err != nil {
+		return err
+	}
+	defer unlock()
+
+	return deleteArchiveData(ctx, id, d.collectorMapping, d.supportArchiveRepository, d.inMemoryArchiveRepository)
+}
+
+// deleteArchiveData deletes the support archive, the data of all collectors and the spec hash of an archive created
+// in memory.
+func deleteArchiveData(ctx context.Context, id domain.SupportArchiveID, collectorMapping CollectorMapping, supportArchiveRepository supportArchiveRepository, inMemoryArchiveRepository inMemoryArchiveRepository) error {
 	var multiErr []error
 	// Always try to delete all collector files to avoid zombie data.
-	for col := range d.collectorMapping {
-		err := deleteCollectorRepositoryData(ctx, id, col, d.collectorMapping)
+	for col := range collectorMapping {
+		err := deleteCollectorRepositoryData(ctx, id, col, collectorMapping)
 		if err != nil {
 			multiErr = append(multiErr, err)
 		}
 	}
 
-	err := d.supportArchiveRepository.Delete(ctx, id)
+	err := supportArchiveRepository.Delete(ctx, id)
 	if err != nil {
 		multiErr = append(multiErr, fmt.Errorf("failed to delete support archive: %w", err))
 	}
 
+	if inMemoryArchiveRepository != nil {
+		err = inMemoryArchiveRepository.Delete(ctx, id)
+		if err != nil {
+			multiErr = append(multiErr, fmt.Errorf("failed to delete spec hash of archive created in memory: %w", err))
+		}
+	}
+
 	return errors.Join(multiErr...)
 }
 
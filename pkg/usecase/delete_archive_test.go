@@ -3,6 +3,7 @@ package usecase
 import (
 	"context"
 	"testing"
+	"time"
 
 	libapi "github.com/cloudogu/k8s-support-archive-lib/api/v1"
 	"github.com/cloudogu/k8s-support-archive-operator/pkg/domain"
@@ -12,18 +13,24 @@ import (
 )
 
 var (
-	testID = domain.SupportArchiveID{
+	testStart = time.Date(2025, 1, 1, 0, 0, 0, 0, time.UTC)
+	testEnd   = time.Date(2025, 1, 5, 0, 0, 0, 0, time.UTC)
+	testID    = domain.SupportArchiveID{
 		Namespace: testArchiveNamespace,
 		Name:      testArchiveName,
 	}
 
-	testLogCR = &libapi.SupportArchive{ObjectMeta: metav1.ObjectMeta{Namespace: testArchiveNamespace, Name: testArchiveName}, Spec: libapi.SupportArchiveSpec{ExcludedContents: libapi.ExcludedContents{VolumeInfo: true, SystemState: true, SensitiveData: true, Events: true, SystemInfo: true}}}
+	testLogCR = &libapi.SupportArchive{ObjectMeta: metav1.ObjectMeta{Namespace: testArchiveNamespace, Name: testArchiveName}, Spec: libapi.SupportArchiveSpec{
+		ExcludedContents: libapi.ExcludedContents{VolumeInfo: true, SystemState: true, SensitiveData: true, Events: true, SystemInfo: true},
+		ContentTimeframe: libapi.ContentTimeframe{StartTime: metav1.NewTime(testStart), EndTime: metav1.NewTime(testEnd)},
+	}}
 )
 
 func TestDeleteArchiveUseCase_Delete(t *testing.T) {
 	type fields struct {
-		supportArchiveRepository func(t *testing.T) supportArchiveRepository
-		collectorMapping         func(t *testing.T) CollectorMapping
+		supportArchiveRepository  func(t *testing.T) supportArchiveRepository
+		collectorMapping          func(t *testing.T) CollectorMapping
+		inMemoryArchiveRepository func(t *testing.T) inMemoryArchiveRepository
 	}
 	type args struct {
 		ctx context.Context
@@ -61,6 +68,12 @@ func TestDeleteArchiveUseCase_Delete(t *testing.T) {
 
 					return mapping
 				},
+				inMemoryArchiveRepository: func(t *testing.T) inMemoryArchiveRepository {
+					repoMock := newMockInMemoryArchiveRepository(t)
+					repoMock.EXPECT().Delete(testCtx, testID).Return(nil)
+
+					return repoMock
+				},
 			},
 			args: args{
 				ctx: context.Background(),
@@ -91,6 +104,12 @@ func TestDeleteArchiveUseCase_Delete(t *testing.T) {
 
 					return mapping
 				},
+				inMemoryArchiveRepository: func(t *testing.T) inMemoryArchiveRepository {
+					repoMock := newMockInMemoryArchiveRepository(t)
+					repoMock.EXPECT().Delete(testCtx, testID).Return(assert.AnError)
+
+					return repoMock
+				},
 			},
 			args: args{
 				ctx: context.Background(),
@@ -100,14 +119,17 @@ func TestDeleteArchiveUseCase_Delete(t *testing.T) {
 				require.Error(t, err)
 				assert.ErrorContains(t, err, "failed to delete Logs collector repository")
 				assert.ErrorContains(t, err, "failed to delete support archive")
+				assert.ErrorContains(t, err, "failed to delete spec hash of archive created in memory")
 			},
 		},
 	}
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
 			d := &DeleteArchiveUseCase{
-				supportArchiveRepository: tt.fields.supportArchiveRepository(t),
-				collectorMapping:         tt.fields.collectorMapping(t),
+				supportArchiveRepository:  tt.fields.supportArchiveRepository(t),
+				collectorMapping:          tt.fields.collectorMapping(t),
+				archiveLocks:              NewArchiveLocks(),
+				inMemoryArchiveRepository: tt.fields.inMemoryArchiveRepository(t),
 			}
 			tt.wantErr(t, d.Delete(tt.args.ctx, tt.args.id))
 		})
@@ -118,12 +140,16 @@ func TestNewDeleteArchiveUseCase(t *testing.T) {
 	// given
 	repoMock := newMockSupportArchiveRepository(t)
 	mapping := CollectorMapping{}
+	locks := NewArchiveLocks()
+	inMemoryRepoMock := newMockInMemoryArchiveRepository(t)
 
 	// when
-	result := NewDeleteArchiveUseCase(mapping, repoMock)
+	result := NewDeleteArchiveUseCase(mapping, repoMock, locks, inMemoryRepoMock)
 
 	// then
 	require.NotNil(t, result)
 	assert.Equal(t, repoMock, result.supportArchiveRepository)
 	assert.Equal(t, mapping, result.collectorMapping)
+	assert.Same(t, locks, result.archiveLocks)
+	assert.Equal(t, inMemoryRepoMock, result.inMemoryArchiveRepository)
 }
@@ -0,0 +1,296 @@
+package usecase
+
+import (
+	"maps"
+	"reflect"
+	"slices"
+	"time"
+
+	"github.com/cloudogu/k8s-support-archive-operator/pkg/domain"
+)
+
+const (
+	nodeCountMetric = "count"
+)
+
+// nodeCapacityMetrics are compared per node. Usage metrics are not compared because they change all the time.
+var nodeCapacityMetrics = []string{"cpuCores", "ramTotalBytes", "storageTotalBytes"}
+
+// ignoredResourceFields change on every update of a resource without a change of the resource itself.
+// Timestamps are ignored anywhere in the resource.
+var ignoredResourceFields = [][]string{
+	{"metadata", "managedFields"},
+	{"metadata", "resourceVersion"},
+}
+
+// DiffArchives compares the content of an archive with the content of the referenced archive, e.g. an older archive
+// of the same system. Secrets are compared by their keys and volumes and nodes by their latest metrics.
+// The differences are sorted, so that the same content results in the same diff.
+func DiffArchives(reference string, before, after *domain.ArchiveContent) *domain.ArchiveDiff {
+	return &domain.ArchiveDiff{
+		Reference: reference,
+		Resources: diffResources(before.Resources, after.Resources),
+		Secrets:   diffSecrets(before.Secrets, after.Secrets),
+		Volumes:   diffVolumes(before.Volumes, after.Volumes),
+		Nodes:     domain.NodeCountDelta{Before: latestNodeCount(before.NodeInfo), After: latestNodeCount(after.NodeInfo)},
+		Capacity:  diffNodeCapacity(before.NodeInfo, after.NodeInfo),
+	}
+}
+
+func diffResources(before, after []domain.ArchiveResource) []domain.ResourceDiff {
+	beforeByPath := make(map[string]map[string]any, len(before))
+	for _, resource := range before {
+		beforeByPath[resource.Path] = resource.Content
+	}
+	afterByPath := make(map[string]map[string]any, len(after))
+	for _, resource := range after {
+		afterByPath[resource.Path] = resource.Content
+	}
+
+	diffs := make([]domain.ResourceDiff, 0)
+	for _, path := range sortedUnion(beforeByPath, afterByPath) {
+		beforeContent, inBefore := beforeByPath[path]
+		afterContent, inAfter := afterByPath[path]
+		switch {
+		case !inBefore:
+			diffs = append(diffs, domain.ResourceDiff{Path: path, Change: domain.DiffChangeAdded})
+		case !inAfter:
+			diffs = append(diffs, domain.ResourceDiff{Path: path, Change: domain.DiffChangeRemoved})
+		default:
+			fields := changedFields("", withoutIgnoredFields(beforeContent), withoutIgnoredFields(afterContent))
+			if len(fields) > 0 {
+				diffs = append(diffs, domain.ResourceDiff{Path: path, Change: domain.DiffChangeChanged, Fields: fields})
+			}
+		}
+	}
+
+	return diffs
+}
+
+// withoutIgnoredFields returns a copy of the resource content without the ignoredResourceFields and timestamps.
+func withoutIgnoredFields(content map[string]any) any {
+	cleaned, _ := withoutTimestamps(content).(map[string]any)
+	for _, field := range ignoredResourceFields {
+		parent := cleaned
+		for _, name := range field[:len(field)-1] {
+			parent, _ = parent[name].(map[string]any)
+		}
+		delete(parent, field[len(field)-1])
+	}
+
+	return cleaned
+}
+
+// withoutTimestamps copies the value without the fields of maps whose value is a timestamp.
+func withoutTimestamps(value any) any {
+	switch typed := value.(type) {
+	case map[string]any:
+		cleaned := make(map[string]any, len(typed))
+		for key, fieldValue := range typed {
+			if !isTimestamp(fieldValue) {
+				cleaned[key] = withoutTimestamps(fieldValue)
+			}
+		}
+		return cleaned
+	case []any:
+		cleaned := make([]any, 0, len(typed))
+		for _, element := range typed {
+			cleaned = append(cleaned, withoutTimestamps(element))
+		}
+		return cleaned
+	default:
+		return value
+	}
+}
+
+// isTimestamp accepts RFC3339 strings as well as already decoded timestamps.
+func isTimestamp(value any) bool {
+	switch typed := value.(type) {
+	case time.Time:
+		return true
+	case string:
+		_, err := time.Parse(time.RFC3339, typed)
+		return err == nil
+	default:
+		return false
+	}
+}
+
+// changedFields returns the dot separated paths of all changed fields, e.g. spec.replicas.
+// Maps are compared field by field and all other values as a whole.
+func changedFields(path string, before, after any) []string {
+	beforeMap, beforeIsMap := before.(map[string]any)
+	afterMap, afterIsMap := after.(map[string]any)
+	if !beforeIsMap || !afterIsMap {
+		if reflect.DeepEqual(before, after) {
+			return nil
+		}
+		return []string{path}
+	}
+
+	var fields []string
+	for _, key := range sortedUnion(beforeMap, afterMap) {
+		fieldPath := key
+		if path != "" {
+			fieldPath = path + "." + key
+		}
+		fields = append(fields, changedFields(fieldPath, beforeMap[key], afterMap[key])...)
+	}
+
+	return fields
+}
+
+func diffSecrets(before, after []domain.ArchiveSecret) []domain.SecretDiff {
+	beforeByPath := make(map[string][]string, len(before))
+	for _, secret := range before {
+		beforeByPath[secret.Path] = secret.Keys
+	}
+	afterByPath := make(map[string][]string, len(after))
+	for _, secret := range after {
+		afterByPath[secret.Path] = secret.Keys
+	}
+
+	diffs := make([]domain.SecretDiff, 0)
+	for _, path := range sortedUnion(beforeByPath, afterByPath) {
+		beforeKeys, inBefore := beforeByPath[path]
+		afterKeys, inAfter := afterByPath[path]
+		diff := domain.SecretDiff{
+			Path:        path,
+			Change:      domain.DiffChangeChanged,
+			AddedKeys:   missingStrings(afterKeys, beforeKeys),
+			RemovedKeys: missingStrings(beforeKeys, afterKeys),
+		}
+		switch {
+		case !inBefore:
+			diff.Change = domain.DiffChangeAdded
+		case !inAfter:
+			diff.Change = domain.DiffChangeRemoved
+		case len(diff.AddedKeys) == 0 && len(diff.RemovedKeys) == 0:
+			continue
+		}
+		diffs = append(diffs, diff)
+	}
+
+	return diffs
+}
+
+// missingStrings returns the sorted values of source which are missing in other.
+func missingStrings(source, other []string) []string {
+	var missing []string
+	for _, value := range source {
+		if !slices.Contains(other, value) {
+			missing = append(missing, value)
+		}
+	}
+	slices.Sort(missing)
+
+	return missing
+}
+
+func diffVolumes(before, after []domain.VolumeInfo) []domain.VolumeUsageDelta {
+	beforeByName := volumeItemsByName(before)
+	afterByName := volumeItemsByName(after)
+
+	deltas := make([]domain.VolumeUsageDelta, 0)
+	for _, name := range sortedUnion(beforeByName, afterByName) {
+		beforeItem, inBefore := beforeByName[name]
+		afterItem, inAfter := afterByName[name]
+		delta := domain.VolumeUsageDelta{
+			Name:           name,
+			Change:         domain.DiffChangeChanged,
+			UsedBefore:     beforeItem.Used,
+			UsedAfter:      afterItem.Used,
+			CapacityBefore: beforeItem.Capacity,
+			CapacityAfter:  afterItem.Capacity,
+		}
+		switch {
+		case !inBefore:
+			delta.Change = domain.DiffChangeAdded
+		case !inAfter:
+			delta.Change = domain.DiffChangeRemoved
+		case delta.UsedBefore == delta.UsedAfter && delta.CapacityBefore == delta.CapacityAfter:
+			continue
+		}
+		deltas = append(deltas, delta)
+	}
+
+	return deltas
+}
+
+func volumeItemsByName(volumes []domain.VolumeInfo) map[string]domain.VolumeInfoItem {
+	items := make(map[string]domain.VolumeInfoItem)
+	for _, volume := range volumes {
+		for _, item := range volume.Items {
+			items[item.Name] = item
+		}
+	}
+
+	return items
+}
+
+// latestNodeCount returns the last value of the node count metric or 0 if the metric was not collected.
+func latestNodeCount(samples []domain.LabeledSample) int {
+	for _, summary := range domain.SummarizeSamples(samples) {
+		if summary.MetricName == nodeCountMetric {
+			return int(summary.Last)
+		}
+	}
+
+	return 0
+}
+
+func diffNodeCapacity(before, after []domain.LabeledSample) []domain.NodeCapacityDelta {
+	beforeByNode := latestNodeCapacities(before)
+	afterByNode := latestNodeCapacities(after)
+
+	deltas := make([]domain.NodeCapacityDelta, 0)
+	for _, node := range sortedUnion(beforeByNode, afterByNode) {
+		for _, metric := range nodeCapacityMetrics {
+			beforeValue, inBefore := beforeByNode[node][metric]
+			afterValue, inAfter := afterByNode[node][metric]
+			delta := domain.NodeCapacityDelta{Node: node, Metric: metric, Change: domain.DiffChangeChanged, Before: beforeValue, After: afterValue}
+			switch {
+			case !inBefore && !inAfter:
+				continue
+			case !inBefore:
+				delta.Change = domain.DiffChangeAdded
+			case !inAfter:
+				delta.Change = domain.DiffChangeRemoved
+			case beforeValue == afterValue:
+				continue
+			}
+			deltas = append(deltas, delta)
+		}
+	}
+
+	return deltas
+}
+
+// latestNodeCapacities returns the last values of the nodeCapacityMetrics by node and metric name.
+func latestNodeCapacities(samples []domain.LabeledSample) map[string]map[string]float64 {
+	capacities := make(map[string]map[string]float64)
+	for _, summary := range domain.SummarizeSamples(samples) {
+		if !slices.Contains(nodeCapacityMetrics, summary.MetricName) {
+			continue
+		}
+		if capacities[summary.ID] == nil {
+			capacities[summary.ID] = make(map[string]float64)
+		}
+		capacities[summary.ID][summary.MetricName] = summary.Last
+	}
+
+	return capacities
+}
+
+// sortedUnion returns the sorted keys of both maps without duplicates.
+func sortedUnion[V1, V2 any](first map[string]V1, second map[string]V2) []string {
+	keys := slices.Collect(maps.Keys(first))
+	for key := range second {
+		if _, ok := first[key]; !ok {
+			keys = append(keys, key)
+		}
+	}
+	slices.Sort(keys)
+
+	return keys
+}
@@ -0,0 +1,136 @@
+package usecase
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/cloudogu/k8s-support-archive-operator/pkg/domain"
+)
+
+func TestDiffArchives(t *testing.T) {
+	before := &domain.ArchiveContent{
+		Resources: []domain.ArchiveResource{
+			{Path: "Resources/SystemState/apps/v1/Deployment/ldap.yaml", Content: map[string]any{
+				"metadata": map[string]any{"name": "ldap", "resourceVersion": "1", "creationTimestamp": "2025-01-01T00:00:00Z", "managedFields": []any{"a"}},
+				"spec":     map[string]any{"replicas": 1, "template": map[string]any{"image": "ldap:1.0"}},
+			}},
+			{Path: "Resources/SystemState/core/v1/Pod/ldap-0.yaml", Content: map[string]any{
+				"metadata": map[string]any{"name": "ldap-0", "resourceVersion": "2"},
+				"status":   map[string]any{"phase": "Running", "startTime": "2025-01-01T00:00:00Z"},
+			}},
+			{Path: "Resources/SystemState/core/v1/Pod/cas-0.yaml", Content: map[string]any{}},
+		},
+		Secrets: []domain.ArchiveSecret{
+			{Path: "Resources/Secrets/ldap.yaml", Keys: []string{"password", "user"}},
+			{Path: "Resources/Secrets/cas.yaml", Keys: []string{"key"}},
+			{Path: "Resources/Secrets/old.yaml", Keys: []string{"key"}},
+		},
+		Volumes: []domain.VolumeInfo{{Name: "persistentVolumeClaims", Items: []domain.VolumeInfoItem{
+			{Name: "ldap-data", Used: 100, Capacity: 1000},
+			{Name: "cas-data", Used: 200, Capacity: 1000},
+			{Name: "old-data", Used: 300, Capacity: 1000},
+		}}},
+		NodeInfo: []domain.LabeledSample{
+			{MetricName: "count", Value: 3, Time: time.Date(2025, 1, 1, 0, 0, 0, 0, time.UTC)},
+			{MetricName: "count", Value: 2, Time: time.Date(2025, 1, 1, 1, 0, 0, 0, time.UTC)},
+			{MetricName: "cpuCores", ID: "node-1", Value: 4},
+			{MetricName: "ramTotalBytes", ID: "node-1", Value: 8},
+			{MetricName: "cpuCores", ID: "node-2", Value: 2},
+			{MetricName: "cpuUsageRelative", ID: "node-1", Value: 50},
+		},
+	}
+	after := &domain.ArchiveContent{
+		Resources: []domain.ArchiveResource{
+			{Path: "Resources/SystemState/apps/v1/Deployment/ldap.yaml", Content: map[string]any{
+				"metadata": map[string]any{"name": "ldap", "resourceVersion": "5", "creationTimestamp": "2025-01-01T00:00:00Z", "managedFields": []any{"b"}, "labels": map[string]any{"app": "ldap"}},
+				"spec":     map[string]any{"replicas": 2, "template": map[string]any{"image": "ldap:1.0"}},
+			}},
+			{Path: "Resources/SystemState/core/v1/Pod/ldap-0.yaml", Content: map[string]any{
+				"metadata": map[string]any{"name": "ldap-0", "resourceVersion": "3"},
+				"status":   map[string]any{"phase": "Running", "startTime": time.Date(2025, 1, 2, 0, 0, 0, 0, time.UTC)},
+			}},
+			{Path: "Resources/SystemState/core/v1/Pod/cas-1.yaml", Content: map[string]any{}},
+		},
+		Secrets: []domain.ArchiveSecret{
+			{Path: "Resources/Secrets/ldap.yaml", Keys: []string{"admin", "user"}},
+			{Path: "Resources/Secrets/cas.yaml", Keys: []string{"key"}},
+			{Path: "Resources/Secrets/new.yaml", Keys: []string{"key"}},
+		},
+		Volumes: []domain.VolumeInfo{{Name: "persistentVolumeClaims", Items: []domain.VolumeInfoItem{
+			{Name: "ldap-data", Used: 150, Capacity: 1000},
+			{Name: "cas-data", Used: 200, Capacity: 1000},
+			{Name: "new-data", Used: 10, Capacity: 500},
+		}}},
+		NodeInfo: []domain.LabeledSample{
+			{MetricName: "count", Value: 3},
+			{MetricName: "cpuCores", ID: "node-1", Value: 8},
+			{MetricName: "ramTotalBytes", ID: "node-1", Value: 8},
+			{MetricName: "cpuCores", ID: "node-3", Value: 2},
+			{MetricName: "cpuUsageRelative", ID: "node-1", Value: 90},
+		},
+	}
+
+	diff := DiffArchives("archive-1", before, after)
+
+	assert.Equal(t, &domain.ArchiveDiff{
+		Reference: "archive-1",
+		Resources: []domain.ResourceDiff{
+			{Path: "Resources/SystemState/apps/v1/Deployment/ldap.yaml", Change: domain.DiffChangeChanged, Fields: []string{"metadata.labels", "spec.replicas"}},
+			{Path: "Resources/SystemState/core/v1/Pod/cas-0.yaml", Change: domain.DiffChangeRemoved},
+			{Path: "Resources/SystemState/core/v1/Pod/cas-1.yaml", Change: domain.DiffChangeAdded},
+		},
+		Secrets: []domain.SecretDiff{
+			{Path: "Resources/Secrets/ldap.yaml", Change: domain.DiffChangeChanged, AddedKeys: []string{"admin"}, RemovedKeys: []string{"password"}},
+			{Path: "Resources/Secrets/new.yaml", Change: domain.DiffChangeAdded, AddedKeys: []string{"key"}},
+			{Path: "Resources/Secrets/old.yaml", Change: domain.DiffChangeRemoved, RemovedKeys: []string{"key"}},
+		},
+		Volumes: []domain.VolumeUsageDelta{
+			{Name: "ldap-data", Change: domain.DiffChangeChanged, UsedBefore: 100, UsedAfter: 150, CapacityBefore: 1000, CapacityAfter: 1000},
+			{Name: "new-data", Change: domain.DiffChangeAdded, UsedAfter: 10, CapacityAfter: 500},
+			{Name: "old-data", Change: domain.DiffChangeRemoved, UsedBefore: 300, CapacityBefore: 1000},
+		},
+		Nodes: domain.NodeCountDelta{Before: 2, After: 3},
+		Capacity: []domain.NodeCapacityDelta{
+			{Node: "node-1", Metric: "cpuCores", Change: domain.DiffChangeChanged, Before: 4, After: 8},
+			{Node: "node-2", Metric: "cpuCores", Change: domain.DiffChangeRemoved, Before: 2},
+			{Node: "node-3", Metric: "cpuCores", Change: domain.DiffChangeAdded, After: 2},
+		},
+	}, diff)
+	assert.False(t, diff.IsEmpty())
+}
+
+func TestDiffArchives_empty(t *testing.T) {
+	content := &domain.ArchiveContent{
+		Resources: []domain.ArchiveResource{{Path: "Resources/SystemState/core/v1/Pod/ldap-0.yaml", Content: map[string]any{"kind": "Pod"}}},
+		Secrets:   []domain.ArchiveSecret{{Path: "Resources/Secrets/ldap.yaml", Keys: []string{"user"}}},
+	}
+
+	diff := DiffArchives("archive-1", content, content)
+
+	assert.True(t, diff.IsEmpty())
+	assert.Empty(t, diff.Resources)
+	assert.NotNil(t, diff.Resources)
+	assert.NotNil(t, diff.Secrets)
+	assert.NotNil(t, diff.Volumes)
+	assert.NotNil(t, diff.Capacity)
+}
+
+func Test_withoutIgnoredFields(t *testing.T) {
+	content := map[string]any{
+		"metadata": map[string]any{"name": "ldap", "resourceVersion": "1", "managedFields": []any{}},
+		"status": map[string]any{"conditions": []any{
+			map[string]any{"type": "Ready", "lastTransitionTime": "2025-01-01T00:00:00Z"},
+		}},
+	}
+
+	cleaned := withoutIgnoredFields(content)
+
+	assert.Equal(t, map[string]any{
+		"metadata": map[string]any{"name": "ldap"},
+		"status":   map[string]any{"conditions": []any{map[string]any{"type": "Ready"}}},
+	}, cleaned)
+	// the original content is not changed
+	assert.Contains(t, content["metadata"], "resourceVersion")
+}
@@ -4,6 +4,7 @@ import (
 	"context"
 	"time"
 
+	libapi "github.com/cloudogu/k8s-support-archive-lib/api/v1"
 	libclient "github.com/cloudogu/k8s-support-archive-lib/client/v1"
 	"github.com/cloudogu/k8s-support-archive-operator/pkg/domain"
 )
@@ -13,9 +14,32 @@ type collector[DATATYPE domain.CollectorUnionDataType] interface {
 	Name() string
 }
 
+// estimator is implemented by collectors which can estimate the amount of data they would collect in a dry run.
+type estimator interface {
+	Estimate(ctx context.Context, namespace string, startTime, endTime time.Time) (domain.CollectorEstimate, error)
+}
+
+// sensitiveDataExcluder is implemented by collectors which collect sensitive data besides their main data, e.g. the
+// values of helm releases. The returned collector collects the same data without the sensitive parts.
+type sensitiveDataExcluder interface {
+	WithoutSensitiveData() any
+}
+
 type baseCollectorRepository interface {
 	Delete(ctx context.Context, id domain.SupportArchiveID) error
 	IsCollected(ctx context.Context, id domain.SupportArchiveID) (bool, error)
+	// Skip removes partially collected data and marks the collector as skipped with the given reason.
+	Skip(ctx context.Context, id domain.SupportArchiveID, reason string) error
+	// IsSkipped returns true and the reason if the collector was skipped.
+	IsSkipped(ctx context.Context, id domain.SupportArchiveID) (bool, string, error)
+	// SetSpecHash records the hash of the spec the collector data was collected with.
+	SetSpecHash(ctx context.Context, id domain.SupportArchiveID, hash string) error
+	// GetSpecHash returns the recorded hash of the spec or an empty string if no hash was recorded.
+	GetSpecHash(ctx context.Context, id domain.SupportArchiveID) (string, error)
+	// SetFailures records how often the collection failed.
+	SetFailures(ctx context.Context, id domain.SupportArchiveID, failures int) error
+	// GetFailures returns the recorded count of failed collections or 0 if no failures were recorded.
+	GetFailures(ctx context.Context, id domain.SupportArchiveID) (int, error)
 	Stream(ctx context.Context, id domain.SupportArchiveID, stream *domain.Stream) error
 }
 
@@ -24,14 +48,71 @@ type collectorRepository[DATATYPE domain.CollectorUnionDataType] interface {
 	Create(ctx context.Context, id domain.SupportArchiveID, data <-chan *DATATYPE) error
 }
 
+// postProcessingRepository contains the results of a post-processing step which are added to the root of the archive.
+type postProcessingRepository interface {
+	Delete(ctx context.Context, id domain.SupportArchiveID) error
+	Stream(ctx context.Context, id domain.SupportArchiveID, stream *domain.Stream) error
+}
+
+type timelineRepository interface {
+	postProcessingRepository
+	// Create builds the timeline from the collected data of the given collectors.
+	Create(ctx context.Context, id domain.SupportArchiveID, collectors []domain.CollectorType) error
+}
+
+type findingsRepository interface {
+	postProcessingRepository
+	// Create writes the findings of the archive analysis.
+	Create(ctx context.Context, id domain.SupportArchiveID, findings []domain.Finding) error
+}
+
+type summaryRepository interface {
+	postProcessingRepository
+	// Create writes the html summary of the archive.
+	Create(ctx context.Context, id domain.SupportArchiveID, summary *domain.ArchiveSummary) error
+}
+
+type diffRepository interface {
+	postProcessingRepository
+	// Create writes the differences to another archive.
+	Create(ctx context.Context, id domain.SupportArchiveID, diff *domain.ArchiveDiff) error
+}
+
+type collectedDataReader interface {
+	// Read returns the collected data of the given collectors within the content timeframe.
+	Read(ctx context.Context, id domain.SupportArchiveID, collectors []domain.CollectorType, timeframes domain.ContentTimeframes) (*domain.CollectedData, error)
+	// ReadContent returns the collected data which is compared with another archive.
+	ReadContent(ctx context.Context, id domain.SupportArchiveID) (*domain.ArchiveContent, error)
+}
+
+// inMemoryArchiveRepository records the spec hash of archives created in memory. These archives keep no collected data
+// in the work directory, so the hash replaces the spec hashes recorded for the collectors.
+type inMemoryArchiveRepository interface {
+	// SetSpecHash records the hash of the spec the archive was created with.
+	SetSpecHash(ctx context.Context, id domain.SupportArchiveID, hash string) error
+	// GetSpecHash returns the recorded hash of the spec or an empty string if the archive was not created in memory.
+	GetSpecHash(ctx context.Context, id domain.SupportArchiveID) (string, error)
+	Delete(ctx context.Context, id domain.SupportArchiveID) error
+}
+
 type supportArchiveRepository interface {
 	// Create builds the support archive for the provided streams.
 	// The stream itself contains a constructor with a Close Func.
 	// The func must be called by the repository after reading the stream or when an error occurs to avoid resource exhaustion.
-	Create(ctx context.Context, id domain.SupportArchiveID, streams map[domain.CollectorType]*domain.Stream) (url string, err error)
+	// All entries are dated to the end of the collection, so that identical data results in an identical archive.
+	Create(ctx context.Context, id domain.SupportArchiveID, streams map[domain.CollectorType]*domain.Stream, collectionEnd time.Time) (url string, err error)
 	Delete(ctx context.Context, id domain.SupportArchiveID) error
 	Exists(ctx context.Context, id domain.SupportArchiveID) (bool, error)
 	List(ctx context.Context) ([]domain.SupportArchiveID, error)
+	// ReadContent returns the data of the archive which is compared with another archive.
+	ReadContent(ctx context.Context, id domain.SupportArchiveID) (*domain.ArchiveContent, error)
+}
+
+type downloadURLSigner interface {
+	// Sign adds a download token for the support archive to the url. The token expires at the returned time.
+	Sign(cr *libapi.SupportArchive, url string, now time.Time) (string, time.Time)
+	// ExpiresAt returns the expiry of the token in the url. It returns false if the url contains no token.
+	ExpiresAt(url string) (time.Time, bool)
 }
 
 type supportArchiveV1Interface interface {
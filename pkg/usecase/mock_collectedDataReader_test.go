@@ -0,0 +1,157 @@
+// Code generated by mockery v2.53.3. DO NOT EDIT.
+
+package usecase
+
+import (
+	context "context"
+
+	domain "github.com/cloudogu/k8s-support-archive-operator/pkg/domain"
+	mock "github.com/stretchr/testify/mock"
+)
+
+// mockCollectedDataReader is an autogenerated mock type for the collectedDataReader type
+type mockCollectedDataReader struct {
+	mock.Mock
+}
+
+type mockCollectedDataReader_Expecter struct {
+	mock *mock.Mock
+}
+
+func (_m *mockCollectedDataReader) EXPECT() *mockCollectedDataReader_Expecter {
+	return &mockCollectedDataReader_Expecter{mock: &_m.Mock}
+}
+
+// Read provides a mock function with given fields: ctx, id, collectors, timeframes
+func (_m *mockCollectedDataReader) Read(ctx context.Context, id domain.SupportArchiveID, collectors []domain.CollectorType, timeframes domain.ContentTimeframes) (*domain.CollectedData, error) {
+	ret := _m.Called(ctx, id, collectors, timeframes)
+
+	if len(ret) == 0 {
+		panic("no return value specified for Read")
+	}
+
+	var r0 *domain.CollectedData
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, domain.SupportArchiveID, []domain.CollectorType, domain.ContentTimeframes) (*domain.CollectedData, error)); ok {
+		return rf(ctx, id, collectors, timeframes)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, domain.SupportArchiveID, []domain.CollectorType, domain.ContentTimeframes) *domain.CollectedData); ok {
+		r0 = rf(ctx, id, collectors, timeframes)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*domain.CollectedData)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, domain.SupportArchiveID, []domain.CollectorType, domain.ContentTimeframes) error); ok {
+		r1 = rf(ctx, id, collectors, timeframes)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// mockCollectedDataReader_Read_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'Read'
+type mockCollectedDataReader_Read_Call struct {
+	*mock.Call
+}
+
+// Read is a helper method to define mock.On call
+//   - ctx context.Context
+//   - id domain.SupportArchiveID
+//   - collectors []domain.CollectorType
+//   - timeframes domain.ContentTimeframes
+func (_e *mockCollectedDataReader_Expecter) Read(ctx interface{}, id interface{}, collectors interface{}, timeframes interface{}) *mockCollectedDataReader_Read_Call {
+	return &mockCollectedDataReader_Read_Call{Call: _e.mock.On("Read", ctx, id, collectors, timeframes)}
+}
+
+func (_c *mockCollectedDataReader_Read_Call) Run(run func(ctx context.Context, id domain.SupportArchiveID, collectors []domain.CollectorType, timeframes domain.ContentTimeframes)) *mockCollectedDataReader_Read_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(domain.SupportArchiveID), args[2].([]domain.CollectorType), args[3].(domain.ContentTimeframes))
+	})
+	return _c
+}
+
+func (_c *mockCollectedDataReader_Read_Call) Return(_a0 *domain.CollectedData, _a1 error) *mockCollectedDataReader_Read_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *mockCollectedDataReader_Read_Call) RunAndReturn(run func(context.Context, domain.SupportArchiveID, []domain.CollectorType, domain.ContentTimeframes) (*domain.CollectedData, error)) *mockCollectedDataReader_Read_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// ReadContent provides a mock function with given fields: ctx, id
+func (_m *mockCollectedDataReader) ReadContent(ctx context.Context, id domain.SupportArchiveID) (*domain.ArchiveContent, error) {
+	ret := _m.Called(ctx, id)
+
+	if len(ret) == 0 {
+		panic("no return value specified for ReadContent")
+	}
+
+	var r0 *domain.ArchiveContent
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, domain.SupportArchiveID) (*domain.ArchiveContent, error)); ok {
+		return rf(ctx, id)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, domain.SupportArchiveID) *domain.ArchiveContent); ok {
+		r0 = rf(ctx, id)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*domain.ArchiveContent)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, domain.SupportArchiveID) error); ok {
+		r1 = rf(ctx, id)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// mockCollectedDataReader_ReadContent_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'ReadContent'
+type mockCollectedDataReader_ReadContent_Call struct {
+	*mock.Call
+}
+
+// ReadContent is a helper method to define mock.On call
+//   - ctx context.Context
+//   - id domain.SupportArchiveID
+func (_e *mockCollectedDataReader_Expecter) ReadContent(ctx interface{}, id interface{}) *mockCollectedDataReader_ReadContent_Call {
+	return &mockCollectedDataReader_ReadContent_Call{Call: _e.mock.On("ReadContent", ctx, id)}
+}
+
+func (_c *mockCollectedDataReader_ReadContent_Call) Run(run func(ctx context.Context, id domain.SupportArchiveID)) *mockCollectedDataReader_ReadContent_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(domain.SupportArchiveID))
+	})
+	return _c
+}
+
+func (_c *mockCollectedDataReader_ReadContent_Call) Return(_a0 *domain.ArchiveContent, _a1 error) *mockCollectedDataReader_ReadContent_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *mockCollectedDataReader_ReadContent_Call) RunAndReturn(run func(context.Context, domain.SupportArchiveID) (*domain.ArchiveContent, error)) *mockCollectedDataReader_ReadContent_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// newMockCollectedDataReader creates a new instance of mockCollectedDataReader. It also registers a testing interface on the mock and a cleanup function to assert the mocks expectations.
+// The first argument is typically a *testing.T value.
+func newMockCollectedDataReader(t interface {
+	mock.TestingT
+	Cleanup(func())
+}) *mockCollectedDataReader {
+	mock := &mockCollectedDataReader{}
+	mock.Mock.Test(t)
+
+	t.Cleanup(func() { mock.AssertExpectations(t) })
+
+	return mock
+}
@@ -117,6 +117,120 @@ func (_c *mockCollectorRepository_Delete_Call[DATATYPE]) RunAndReturn(run func(c
 	return _c
 }
 
+// GetFailures provides a mock function with given fields: ctx, id
+func (_m *mockCollectorRepository[DATATYPE]) GetFailures(ctx context.Context, id domain.SupportArchiveID) (int, error) {
+	ret := _m.Called(ctx, id)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetFailures")
+	}
+
+	var r0 int
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, domain.SupportArchiveID) (int, error)); ok {
+		return rf(ctx, id)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, domain.SupportArchiveID) int); ok {
+		r0 = rf(ctx, id)
+	} else {
+		r0 = ret.Get(0).(int)
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, domain.SupportArchiveID) error); ok {
+		r1 = rf(ctx, id)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// mockCollectorRepository_GetFailures_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'GetFailures'
+type mockCollectorRepository_GetFailures_Call[DATATYPE domain.CollectorUnionDataType] struct {
+	*mock.Call
+}
+
+// GetFailures is a helper method to define mock.On call
+//   - ctx context.Context
+//   - id domain.SupportArchiveID
+func (_e *mockCollectorRepository_Expecter[DATATYPE]) GetFailures(ctx interface{}, id interface{}) *mockCollectorRepository_GetFailures_Call[DATATYPE] {
+	return &mockCollectorRepository_GetFailures_Call[DATATYPE]{Call: _e.mock.On("GetFailures", ctx, id)}
+}
+
+func (_c *mockCollectorRepository_GetFailures_Call[DATATYPE]) Run(run func(ctx context.Context, id domain.SupportArchiveID)) *mockCollectorRepository_GetFailures_Call[DATATYPE] {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(domain.SupportArchiveID))
+	})
+	return _c
+}
+
+func (_c *mockCollectorRepository_GetFailures_Call[DATATYPE]) Return(_a0 int, _a1 error) *mockCollectorRepository_GetFailures_Call[DATATYPE] {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *mockCollectorRepository_GetFailures_Call[DATATYPE]) RunAndReturn(run func(context.Context, domain.SupportArchiveID) (int, error)) *mockCollectorRepository_GetFailures_Call[DATATYPE] {
+	_c.Call.Return(run)
+	return _c
+}
+
+// GetSpecHash provides a mock function with given fields: ctx, id
+func (_m *mockCollectorRepository[DATATYPE]) GetSpecHash(ctx context.Context, id domain.SupportArchiveID) (string, error) {
+	ret := _m.Called(ctx, id)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetSpecHash")
+	}
+
+	var r0 string
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, domain.SupportArchiveID) (string, error)); ok {
+		return rf(ctx, id)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, domain.SupportArchiveID) string); ok {
+		r0 = rf(ctx, id)
+	} else {
+		r0 = ret.Get(0).(string)
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, domain.SupportArchiveID) error); ok {
+		r1 = rf(ctx, id)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// mockCollectorRepository_GetSpecHash_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'GetSpecHash'
+type mockCollectorRepository_GetSpecHash_Call[DATATYPE domain.CollectorUnionDataType] struct {
+	*mock.Call
+}
+
+// GetSpecHash is a helper method to define mock.On call
+//   - ctx context.Context
+//   - id domain.SupportArchiveID
+func (_e *mockCollectorRepository_Expecter[DATATYPE]) GetSpecHash(ctx interface{}, id interface{}) *mockCollectorRepository_GetSpecHash_Call[DATATYPE] {
+	return &mockCollectorRepository_GetSpecHash_Call[DATATYPE]{Call: _e.mock.On("GetSpecHash", ctx, id)}
+}
+
+func (_c *mockCollectorRepository_GetSpecHash_Call[DATATYPE]) Run(run func(ctx context.Context, id domain.SupportArchiveID)) *mockCollectorRepository_GetSpecHash_Call[DATATYPE] {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(domain.SupportArchiveID))
+	})
+	return _c
+}
+
+func (_c *mockCollectorRepository_GetSpecHash_Call[DATATYPE]) Return(_a0 string, _a1 error) *mockCollectorRepository_GetSpecHash_Call[DATATYPE] {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *mockCollectorRepository_GetSpecHash_Call[DATATYPE]) RunAndReturn(run func(context.Context, domain.SupportArchiveID) (string, error)) *mockCollectorRepository_GetSpecHash_Call[DATATYPE] {
+	_c.Call.Return(run)
+	return _c
+}
+
 // IsCollected provides a mock function with given fields: ctx, id
 func (_m *mockCollectorRepository[DATATYPE]) IsCollected(ctx context.Context, id domain.SupportArchiveID) (bool, error) {
 	ret := _m.Called(ctx, id)
@@ -174,6 +288,214 @@ func (_c *mockCollectorRepository_IsCollected_Call[DATATYPE]) RunAndReturn(run f
 	return _c
 }
 
+// IsSkipped provides a mock function with given fields: ctx, id
+func (_m *mockCollectorRepository[DATATYPE]) IsSkipped(ctx context.Context, id domain.SupportArchiveID) (bool, string, error) {
+	ret := _m.Called(ctx, id)
+
+	if len(ret) == 0 {
+		panic("no return value specified for IsSkipped")
+	}
+
+	var r0 bool
+	var r1 string
+	var r2 error
+	if rf, ok := ret.Get(0).(func(context.Context, domain.SupportArchiveID) (bool, string, error)); ok {
+		return rf(ctx, id)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, domain.SupportArchiveID) bool); ok {
+		r0 = rf(ctx, id)
+	} else {
+		r0 = ret.Get(0).(bool)
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, domain.SupportArchiveID) string); ok {
+		r1 = rf(ctx, id)
+	} else {
+		r1 = ret.Get(1).(string)
+	}
+
+	if rf, ok := ret.Get(2).(func(context.Context, domain.SupportArchiveID) error); ok {
+		r2 = rf(ctx, id)
+	} else {
+		r2 = ret.Error(2)
+	}
+
+	return r0, r1, r2
+}
+
+// mockCollectorRepository_IsSkipped_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'IsSkipped'
+type mockCollectorRepository_IsSkipped_Call[DATATYPE domain.CollectorUnionDataType] struct {
+	*mock.Call
+}
+
+// IsSkipped is a helper method to define mock.On call
+//   - ctx context.Context
+//   - id domain.SupportArchiveID
+func (_e *mockCollectorRepository_Expecter[DATATYPE]) IsSkipped(ctx interface{}, id interface{}) *mockCollectorRepository_IsSkipped_Call[DATATYPE] {
+	return &mockCollectorRepository_IsSkipped_Call[DATATYPE]{Call: _e.mock.On("IsSkipped", ctx, id)}
+}
+
+func (_c *mockCollectorRepository_IsSkipped_Call[DATATYPE]) Run(run func(ctx context.Context, id domain.SupportArchiveID)) *mockCollectorRepository_IsSkipped_Call[DATATYPE] {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(domain.SupportArchiveID))
+	})
+	return _c
+}
+
+func (_c *mockCollectorRepository_IsSkipped_Call[DATATYPE]) Return(_a0 bool, _a1 string, _a2 error) *mockCollectorRepository_IsSkipped_Call[DATATYPE] {
+	_c.Call.Return(_a0, _a1, _a2)
+	return _c
+}
+
+func (_c *mockCollectorRepository_IsSkipped_Call[DATATYPE]) RunAndReturn(run func(context.Context, domain.SupportArchiveID) (bool, string, error)) *mockCollectorRepository_IsSkipped_Call[DATATYPE] {
+	_c.Call.Return(run)
+	return _c
+}
+
+// SetFailures provides a mock function with given fields: ctx, id, failures
+func (_m *mockCollectorRepository[DATATYPE]) SetFailures(ctx context.Context, id domain.SupportArchiveID, failures int) error {
+	ret := _m.Called(ctx, id, failures)
+
+	if len(ret) == 0 {
+		panic("no return value specified for SetFailures")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, domain.SupportArchiveID, int) error); ok {
+		r0 = rf(ctx, id, failures)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// mockCollectorRepository_SetFailures_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'SetFailures'
+type mockCollectorRepository_SetFailures_Call[DATATYPE domain.CollectorUnionDataType] struct {
+	*mock.Call
+}
+
+// SetFailures is a helper method to define mock.On call
+//   - ctx context.Context
+//   - id domain.SupportArchiveID
+//   - failures int
+func (_e *mockCollectorRepository_Expecter[DATATYPE]) SetFailures(ctx interface{}, id interface{}, failures interface{}) *mockCollectorRepository_SetFailures_Call[DATATYPE] {
+	return &mockCollectorRepository_SetFailures_Call[DATATYPE]{Call: _e.mock.On("SetFailures", ctx, id, failures)}
+}
+
+func (_c *mockCollectorRepository_SetFailures_Call[DATATYPE]) Run(run func(ctx context.Context, id domain.SupportArchiveID, failures int)) *mockCollectorRepository_SetFailures_Call[DATATYPE] {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(domain.SupportArchiveID), args[2].(int))
+	})
+	return _c
+}
+
+func (_c *mockCollectorRepository_SetFailures_Call[DATATYPE]) Return(_a0 error) *mockCollectorRepository_SetFailures_Call[DATATYPE] {
+	_c.Call.Return(_a0)
+	return _c
+}
+
+func (_c *mockCollectorRepository_SetFailures_Call[DATATYPE]) RunAndReturn(run func(context.Context, domain.SupportArchiveID, int) error) *mockCollectorRepository_SetFailures_Call[DATATYPE] {
+	_c.Call.Return(run)
+	return _c
+}
+
+// SetSpecHash provides a mock function with given fields: ctx, id, hash
+func (_m *mockCollectorRepository[DATATYPE]) SetSpecHash(ctx context.Context, id domain.SupportArchiveID, hash string) error {
+	ret := _m.Called(ctx, id, hash)
+
+	if len(ret) == 0 {
+		panic("no return value specified for SetSpecHash")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, domain.SupportArchiveID, string) error); ok {
+		r0 = rf(ctx, id, hash)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// mockCollectorRepository_SetSpecHash_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'SetSpecHash'
+type mockCollectorRepository_SetSpecHash_Call[DATATYPE domain.CollectorUnionDataType] struct {
+	*mock.Call
+}
+
+// SetSpecHash is a helper method to define mock.On call
+//   - ctx context.Context
+//   - id domain.SupportArchiveID
+//   - hash string
+func (_e *mockCollectorRepository_Expecter[DATATYPE]) SetSpecHash(ctx interface{}, id interface{}, hash interface{}) *mockCollectorRepository_SetSpecHash_Call[DATATYPE] {
+	return &mockCollectorRepository_SetSpecHash_Call[DATATYPE]{Call: _e.mock.On("SetSpecHash", ctx, id, hash)}
+}
+
+func (_c *mockCollectorRepository_SetSpecHash_Call[DATATYPE]) Run(run func(ctx context.Context, id domain.SupportArchiveID, hash string)) *mockCollectorRepository_SetSpecHash_Call[DATATYPE] {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(domain.SupportArchiveID), args[2].(string))
+	})
+	return _c
+}
+
+func (_c *mockCollectorRepository_SetSpecHash_Call[DATATYPE]) Return(_a0 error) *mockCollectorRepository_SetSpecHash_Call[DATATYPE] {
+	_c.Call.Return(_a0)
+	return _c
+}
+
+func (_c *mockCollectorRepository_SetSpecHash_Call[DATATYPE]) RunAndReturn(run func(context.Context, domain.SupportArchiveID, string) error) *mockCollectorRepository_SetSpecHash_Call[DATATYPE] {
+	_c.Call.Return(run)
+	return _c
+}
+
+// Skip provides a mock function with given fields: ctx, id, reason
+func (_m *mockCollectorRepository[DATATYPE]) Skip(ctx context.Context, id domain.SupportArchiveID, reason string) error {
+	ret := _m.Called(ctx, id, reason)
+
+	if len(ret) == 0 {
+		panic("no return value specified for Skip")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, domain.SupportArchiveID, string) error); ok {
+		r0 = rf(ctx, id, reason)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// mockCollectorRepository_Skip_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'Skip'
+type mockCollectorRepository_Skip_Call[DATATYPE domain.CollectorUnionDataType] struct {
+	*mock.Call
+}
+
+// Skip is a helper method to define mock.On call
+//   - ctx context.Context
+//   - id domain.SupportArchiveID
+//   - reason string
+func (_e *mockCollectorRepository_Expecter[DATATYPE]) Skip(ctx interface{}, id interface{}, reason interface{}) *mockCollectorRepository_Skip_Call[DATATYPE] {
+	return &mockCollectorRepository_Skip_Call[DATATYPE]{Call: _e.mock.On("Skip", ctx, id, reason)}
+}
+
+func (_c *mockCollectorRepository_Skip_Call[DATATYPE]) Run(run func(ctx context.Context, id domain.SupportArchiveID, reason string)) *mockCollectorRepository_Skip_Call[DATATYPE] {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(domain.SupportArchiveID), args[2].(string))
+	})
+	return _c
+}
+
+func (_c *mockCollectorRepository_Skip_Call[DATATYPE]) Return(_a0 error) *mockCollectorRepository_Skip_Call[DATATYPE] {
+	_c.Call.Return(_a0)
+	return _c
+}
+
+func (_c *mockCollectorRepository_Skip_Call[DATATYPE]) RunAndReturn(run func(context.Context, domain.SupportArchiveID, string) error) *mockCollectorRepository_Skip_Call[DATATYPE] {
+	_c.Call.Return(run)
+	return _c
+}
+
 // Stream provides a mock function with given fields: ctx, id, stream
 func (_m *mockCollectorRepository[DATATYPE]) Stream(ctx context.Context, id domain.SupportArchiveID, stream *domain.Stream) error {
 	ret := _m.Called(ctx, id, stream)
@@ -0,0 +1,180 @@
+// Code generated by mockery v2.53.3. DO NOT EDIT.
+
+package usecase
+
+import (
+	context "context"
+
+	domain "github.com/cloudogu/k8s-support-archive-operator/pkg/domain"
+	mock "github.com/stretchr/testify/mock"
+)
+
+// mockDiffRepository is an autogenerated mock type for the diffRepository type
+type mockDiffRepository struct {
+	mock.Mock
+}
+
+type mockDiffRepository_Expecter struct {
+	mock *mock.Mock
+}
+
+func (_m *mockDiffRepository) EXPECT() *mockDiffRepository_Expecter {
+	return &mockDiffRepository_Expecter{mock: &_m.Mock}
+}
+
+// Create provides a mock function with given fields: ctx, id, diff
+func (_m *mockDiffRepository) Create(ctx context.Context, id domain.SupportArchiveID, diff *domain.ArchiveDiff) error {
+	ret := _m.Called(ctx, id, diff)
+
+	if len(ret) == 0 {
+		panic("no return value specified for Create")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, domain.SupportArchiveID, *domain.ArchiveDiff) error); ok {
+		r0 = rf(ctx, id, diff)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// mockDiffRepository_Create_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'Create'
+type mockDiffRepository_Create_Call struct {
+	*mock.Call
+}
+
+// Create is a helper method to define mock.On call
+//   - ctx context.Context
+//   - id domain.SupportArchiveID
+//   - diff *domain.ArchiveDiff
+func (_e *mockDiffRepository_Expecter) Create(ctx interface{}, id interface{}, diff interface{}) *mockDiffRepository_Create_Call {
+	return &mockDiffRepository_Create_Call{Call: _e.mock.On("Create", ctx, id, diff)}
+}
+
+func (_c *mockDiffRepository_Create_Call) Run(run func(ctx context.Context, id domain.SupportArchiveID, diff *domain.ArchiveDiff)) *mockDiffRepository_Create_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(domain.SupportArchiveID), args[2].(*domain.ArchiveDiff))
+	})
+	return _c
+}
+
+func (_c *mockDiffRepository_Create_Call) Return(_a0 error) *mockDiffRepository_Create_Call {
+	_c.Call.Return(_a0)
+	return _c
+}
+
+func (_c *mockDiffRepository_Create_Call) RunAndReturn(run func(context.Context, domain.SupportArchiveID, *domain.ArchiveDiff) error) *mockDiffRepository_Create_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// Delete provides a mock function with given fields: ctx, id
+func (_m *mockDiffRepository) Delete(ctx context.Context, id domain.SupportArchiveID) error {
+	ret := _m.Called(ctx, id)
+
+	if len(ret) == 0 {
+		panic("no return value specified for Delete")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, domain.SupportArchiveID) error); ok {
+		r0 = rf(ctx, id)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// mockDiffRepository_Delete_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'Delete'
+type mockDiffRepository_Delete_Call struct {
+	*mock.Call
+}
+
+// Delete is a helper method to define mock.On call
+//   - ctx context.Context
+//   - id domain.SupportArchiveID
+func (_e *mockDiffRepository_Expecter) Delete(ctx interface{}, id interface{}) *mockDiffRepository_Delete_Call {
+	return &mockDiffRepository_Delete_Call{Call: _e.mock.On("Delete", ctx, id)}
+}
+
+func (_c *mockDiffRepository_Delete_Call) Run(run func(ctx context.Context, id domain.SupportArchiveID)) *mockDiffRepository_Delete_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(domain.SupportArchiveID))
+	})
+	return _c
+}
+
+func (_c *mockDiffRepository_Delete_Call) Return(_a0 error) *mockDiffRepository_Delete_Call {
+	_c.Call.Return(_a0)
+	return _c
+}
+
+func (_c *mockDiffRepository_Delete_Call) RunAndReturn(run func(context.Context, domain.SupportArchiveID) error) *mockDiffRepository_Delete_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// Stream provides a mock function with given fields: ctx, id, stream
+func (_m *mockDiffRepository) Stream(ctx context.Context, id domain.SupportArchiveID, stream *domain.Stream) error {
+	ret := _m.Called(ctx, id, stream)
+
+	if len(ret) == 0 {
+		panic("no return value specified for Stream")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, domain.SupportArchiveID, *domain.Stream) error); ok {
+		r0 = rf(ctx, id, stream)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// mockDiffRepository_Stream_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'Stream'
+type mockDiffRepository_Stream_Call struct {
+	*mock.Call
+}
+
+// Stream is a helper method to define mock.On call
+//   - ctx context.Context
+//   - id domain.SupportArchiveID
+//   - stream *domain.Stream
+func (_e *mockDiffRepository_Expecter) Stream(ctx interface{}, id interface{}, stream interface{}) *mockDiffRepository_Stream_Call {
+	return &mockDiffRepository_Stream_Call{Call: _e.mock.On("Stream", ctx, id, stream)}
+}
+
+func (_c *mockDiffRepository_Stream_Call) Run(run func(ctx context.Context, id domain.SupportArchiveID, stream *domain.Stream)) *mockDiffRepository_Stream_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(domain.SupportArchiveID), args[2].(*domain.Stream))
+	})
+	return _c
+}
+
+func (_c *mockDiffRepository_Stream_Call) Return(_a0 error) *mockDiffRepository_Stream_Call {
+	_c.Call.Return(_a0)
+	return _c
+}
+
+func (_c *mockDiffRepository_Stream_Call) RunAndReturn(run func(context.Context, domain.SupportArchiveID, *domain.Stream) error) *mockDiffRepository_Stream_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// newMockDiffRepository creates a new instance of mockDiffRepository. It also registers a testing interface on the mock and a cleanup function to assert the mocks expectations.
+// The first argument is typically a *testing.T value.
+func newMockDiffRepository(t interface {
+	mock.TestingT
+	Cleanup(func())
+}) *mockDiffRepository {
+	mock := &mockDiffRepository{}
+	mock.Mock.Test(t)
+
+	t.Cleanup(func() { mock.AssertExpectations(t) })
+
+	return mock
+}
@@ -0,0 +1,98 @@
+// Code generated by mockery v2.53.3. DO NOT EDIT.
+
+package usecase
+
+import (
+	context "context"
+
+	domain "github.com/cloudogu/k8s-support-archive-operator/pkg/domain"
+	mock "github.com/stretchr/testify/mock"
+
+	time "time"
+)
+
+// mockEstimator is an autogenerated mock type for the estimator type
+type mockEstimator struct {
+	mock.Mock
+}
+
+type mockEstimator_Expecter struct {
+	mock *mock.Mock
+}
+
+func (_m *mockEstimator) EXPECT() *mockEstimator_Expecter {
+	return &mockEstimator_Expecter{mock: &_m.Mock}
+}
+
+// Estimate provides a mock function with given fields: ctx, namespace, startTime, endTime
+func (_m *mockEstimator) Estimate(ctx context.Context, namespace string, startTime time.Time, endTime time.Time) (domain.CollectorEstimate, error) {
+	ret := _m.Called(ctx, namespace, startTime, endTime)
+
+	if len(ret) == 0 {
+		panic("no return value specified for Estimate")
+	}
+
+	var r0 domain.CollectorEstimate
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, string, time.Time, time.Time) (domain.CollectorEstimate, error)); ok {
+		return rf(ctx, namespace, startTime, endTime)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, string, time.Time, time.Time) domain.CollectorEstimate); ok {
+		r0 = rf(ctx, namespace, startTime, endTime)
+	} else {
+		r0 = ret.Get(0).(domain.CollectorEstimate)
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, string, time.Time, time.Time) error); ok {
+		r1 = rf(ctx, namespace, startTime, endTime)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// mockEstimator_Estimate_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'Estimate'
+type mockEstimator_Estimate_Call struct {
+	*mock.Call
+}
+
+// Estimate is a helper method to define mock.On call
+//   - ctx context.Context
+//   - namespace string
+//   - startTime time.Time
+//   - endTime time.Time
+func (_e *mockEstimator_Expecter) Estimate(ctx interface{}, namespace interface{}, startTime interface{}, endTime interface{}) *mockEstimator_Estimate_Call {
+	return &mockEstimator_Estimate_Call{Call: _e.mock.On("Estimate", ctx, namespace, startTime, endTime)}
+}
+
+func (_c *mockEstimator_Estimate_Call) Run(run func(ctx context.Context, namespace string, startTime time.Time, endTime time.Time)) *mockEstimator_Estimate_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(string), args[2].(time.Time), args[3].(time.Time))
+	})
+	return _c
+}
+
+func (_c *mockEstimator_Estimate_Call) Return(_a0 domain.CollectorEstimate, _a1 error) *mockEstimator_Estimate_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *mockEstimator_Estimate_Call) RunAndReturn(run func(context.Context, string, time.Time, time.Time) (domain.CollectorEstimate, error)) *mockEstimator_Estimate_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// newMockEstimator creates a new instance of mockEstimator. It also registers a testing interface on the mock and a cleanup function to assert the mocks expectations.
+// The first argument is typically a *testing.T value.
+func newMockEstimator(t interface {
+	mock.TestingT
+	Cleanup(func())
+}) *mockEstimator {
+	mock := &mockEstimator{}
+	mock.Mock.Test(t)
+
+	t.Cleanup(func() { mock.AssertExpectations(t) })
+
+	return mock
+}
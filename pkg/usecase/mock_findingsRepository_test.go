@@ -0,0 +1,180 @@
+// Code generated by mockery v2.53.3. DO NOT EDIT.
+
+package usecase
+
+import (
+	context "context"
+
+	domain "github.com/cloudogu/k8s-support-archive-operator/pkg/domain"
+	mock "github.com/stretchr/testify/mock"
+)
+
+// mockFindingsRepository is an autogenerated mock type for the findingsRepository type
+type mockFindingsRepository struct {
+	mock.Mock
+}
+
+type mockFindingsRepository_Expecter struct {
+	mock *mock.Mock
+}
+
+func (_m *mockFindingsRepository) EXPECT() *mockFindingsRepository_Expecter {
+	return &mockFindingsRepository_Expecter{mock: &_m.Mock}
+}
+
+// Create provides a mock function with given fields: ctx, id, findings
+func (_m *mockFindingsRepository) Create(ctx context.Context, id domain.SupportArchiveID, findings []domain.Finding) error {
+	ret := _m.Called(ctx, id, findings)
+
+	if len(ret) == 0 {
+		panic("no return value specified for Create")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, domain.SupportArchiveID, []domain.Finding) error); ok {
+		r0 = rf(ctx, id, findings)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// mockFindingsRepository_Create_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'Create'
+type mockFindingsRepository_Create_Call struct {
+	*mock.Call
+}
+
+// Create is a helper method to define mock.On call
+//   - ctx context.Context
+//   - id domain.SupportArchiveID
+//   - findings []domain.Finding
+func (_e *mockFindingsRepository_Expecter) Create(ctx interface{}, id interface{}, findings interface{}) *mockFindingsRepository_Create_Call {
+	return &mockFindingsRepository_Create_Call{Call: _e.mock.On("Create", ctx, id, findings)}
+}
+
+func (_c *mockFindingsRepository_Create_Call) Run(run func(ctx context.Context, id domain.SupportArchiveID, findings []domain.Finding)) *mockFindingsRepository_Create_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(domain.SupportArchiveID), args[2].([]domain.Finding))
+	})
+	return _c
+}
+
+func (_c *mockFindingsRepository_Create_Call) Return(_a0 error) *mockFindingsRepository_Create_Call {
+	_c.Call.Return(_a0)
+	return _c
+}
+
+func (_c *mockFindingsRepository_Create_Call) RunAndReturn(run func(context.Context, domain.SupportArchiveID, []domain.Finding) error) *mockFindingsRepository_Create_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// Delete provides a mock function with given fields: ctx, id
+func (_m *mockFindingsRepository) Delete(ctx context.Context, id domain.SupportArchiveID) error {
+	ret := _m.Called(ctx, id)
+
+	if len(ret) == 0 {
+		panic("no return value specified for Delete")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, domain.SupportArchiveID) error); ok {
+		r0 = rf(ctx, id)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// mockFindingsRepository_Delete_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'Delete'
+type mockFindingsRepository_Delete_Call struct {
+	*mock.Call
+}
+
+// Delete is a helper method to define mock.On call
+//   - ctx context.Context
+//   - id domain.SupportArchiveID
+func (_e *mockFindingsRepository_Expecter) Delete(ctx interface{}, id interface{}) *mockFindingsRepository_Delete_Call {
+	return &mockFindingsRepository_Delete_Call{Call: _e.mock.On("Delete", ctx, id)}
+}
+
+func (_c *mockFindingsRepository_Delete_Call) Run(run func(ctx context.Context, id domain.SupportArchiveID)) *mockFindingsRepository_Delete_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(domain.SupportArchiveID))
+	})
+	return _c
+}
+
+func (_c *mockFindingsRepository_Delete_Call) Return(_a0 error) *mockFindingsRepository_Delete_Call {
+	_c.Call.Return(_a0)
+	return _c
+}
+
+func (_c *mockFindingsRepository_Delete_Call) RunAndReturn(run func(context.Context, domain.SupportArchiveID) error) *mockFindingsRepository_Delete_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// Stream provides a mock function with given fields: ctx, id, stream
+func (_m *mockFindingsRepository) Stream(ctx context.Context, id domain.SupportArchiveID, stream *domain.Stream) error {
+	ret := _m.Called(ctx, id, stream)
+
+	if len(ret) == 0 {
+		panic("no return value specified for Stream")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, domain.SupportArchiveID, *domain.Stream) error); ok {
+		r0 = rf(ctx, id, stream)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// mockFindingsRepository_Stream_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'Stream'
+type mockFindingsRepository_Stream_Call struct {
+	*mock.Call
+}
+
+// Stream is a helper method to define mock.On call
+//   - ctx context.Context
+//   - id domain.SupportArchiveID
+//   - stream *domain.Stream
+func (_e *mockFindingsRepository_Expecter) Stream(ctx interface{}, id interface{}, stream interface{}) *mockFindingsRepository_Stream_Call {
+	return &mockFindingsRepository_Stream_Call{Call: _e.mock.On("Stream", ctx, id, stream)}
+}
+
+func (_c *mockFindingsRepository_Stream_Call) Run(run func(ctx context.Context, id domain.SupportArchiveID, stream *domain.Stream)) *mockFindingsRepository_Stream_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(domain.SupportArchiveID), args[2].(*domain.Stream))
+	})
+	return _c
+}
+
+func (_c *mockFindingsRepository_Stream_Call) Return(_a0 error) *mockFindingsRepository_Stream_Call {
+	_c.Call.Return(_a0)
+	return _c
+}
+
+func (_c *mockFindingsRepository_Stream_Call) RunAndReturn(run func(context.Context, domain.SupportArchiveID, *domain.Stream) error) *mockFindingsRepository_Stream_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// newMockFindingsRepository creates a new instance of mockFindingsRepository. It also registers a testing interface on the mock and a cleanup function to assert the mocks expectations.
+// The first argument is typically a *testing.T value.
+func newMockFindingsRepository(t interface {
+	mock.TestingT
+	Cleanup(func())
+}) *mockFindingsRepository {
+	mock := &mockFindingsRepository{}
+	mock.Mock.Test(t)
+
+	t.Cleanup(func() { mock.AssertExpectations(t) })
+
+	return mock
+}
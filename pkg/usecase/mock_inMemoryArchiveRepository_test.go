@@ -0,0 +1,189 @@
+// Code generated by mockery v2.53.3. DO NOT EDIT.
+
+package usecase
+
+import (
+	context "context"
+
+	domain "github.com/cloudogu/k8s-support-archive-operator/pkg/domain"
+	mock "github.com/stretchr/testify/mock"
+)
+
+// mockInMemoryArchiveRepository is an autogenerated mock type for the inMemoryArchiveRepository type
+type mockInMemoryArchiveRepository struct {
+	mock.Mock
+}
+
+type mockInMemoryArchiveRepository_Expecter struct {
+	mock *mock.Mock
+}
+
+func (_m *mockInMemoryArchiveRepository) EXPECT() *mockInMemoryArchiveRepository_Expecter {
+	return &mockInMemoryArchiveRepository_Expecter{mock: &_m.Mock}
+}
+
+// Delete provides a mock function with given fields: ctx, id
+func (_m *mockInMemoryArchiveRepository) Delete(ctx context.Context, id domain.SupportArchiveID) error {
+	ret := _m.Called(ctx, id)
+
+	if len(ret) == 0 {
+		panic("no return value specified for Delete")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, domain.SupportArchiveID) error); ok {
+		r0 = rf(ctx, id)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// mockInMemoryArchiveRepository_Delete_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'Delete'
+type mockInMemoryArchiveRepository_Delete_Call struct {
+	*mock.Call
+}
+
+// Delete is a helper method to define mock.On call
+//   - ctx context.Context
+//   - id domain.SupportArchiveID
+func (_e *mockInMemoryArchiveRepository_Expecter) Delete(ctx interface{}, id interface{}) *mockInMemoryArchiveRepository_Delete_Call {
+	return &mockInMemoryArchiveRepository_Delete_Call{Call: _e.mock.On("Delete", ctx, id)}
+}
+
+func (_c *mockInMemoryArchiveRepository_Delete_Call) Run(run func(ctx context.Context, id domain.SupportArchiveID)) *mockInMemoryArchiveRepository_Delete_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(domain.SupportArchiveID))
+	})
+	return _c
+}
+
+func (_c *mockInMemoryArchiveRepository_Delete_Call) Return(_a0 error) *mockInMemoryArchiveRepository_Delete_Call {
+	_c.Call.Return(_a0)
+	return _c
+}
+
+func (_c *mockInMemoryArchiveRepository_Delete_Call) RunAndReturn(run func(context.Context, domain.SupportArchiveID) error) *mockInMemoryArchiveRepository_Delete_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// GetSpecHash provides a mock function with given fields: ctx, id
+func (_m *mockInMemoryArchiveRepository) GetSpecHash(ctx context.Context, id domain.SupportArchiveID) (string, error) {
+	ret := _m.Called(ctx, id)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetSpecHash")
+	}
+
+	var r0 string
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, domain.SupportArchiveID) (string, error)); ok {
+		return rf(ctx, id)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, domain.SupportArchiveID) string); ok {
+		r0 = rf(ctx, id)
+	} else {
+		r0 = ret.Get(0).(string)
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, domain.SupportArchiveID) error); ok {
+		r1 = rf(ctx, id)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// mockInMemoryArchiveRepository_GetSpecHash_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'GetSpecHash'
+type mockInMemoryArchiveRepository_GetSpecHash_Call struct {
+	*mock.Call
+}
+
+// GetSpecHash is a helper method to define mock.On call
+//   - ctx context.Context
+//   - id domain.SupportArchiveID
+func (_e *mockInMemoryArchiveRepository_Expecter) GetSpecHash(ctx interface{}, id interface{}) *mockInMemoryArchiveRepository_GetSpecHash_Call {
+	return &mockInMemoryArchiveRepository_GetSpecHash_Call{Call: _e.mock.On("GetSpecHash", ctx, id)}
+}
+
+func (_c *mockInMemoryArchiveRepository_GetSpecHash_Call) Run(run func(ctx context.Context, id domain.SupportArchiveID)) *mockInMemoryArchiveRepository_GetSpecHash_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(domain.SupportArchiveID))
+	})
+	return _c
+}
+
+func (_c *mockInMemoryArchiveRepository_GetSpecHash_Call) Return(_a0 string, _a1 error) *mockInMemoryArchiveRepository_GetSpecHash_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *mockInMemoryArchiveRepository_GetSpecHash_Call) RunAndReturn(run func(context.Context, domain.SupportArchiveID) (string, error)) *mockInMemoryArchiveRepository_GetSpecHash_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// SetSpecHash provides a mock function with given fields: ctx, id, hash
+func (_m *mockInMemoryArchiveRepository) SetSpecHash(ctx context.Context, id domain.SupportArchiveID, hash string) error {
+	ret := _m.Called(ctx, id, hash)
+
+	if len(ret) == 0 {
+		panic("no return value specified for SetSpecHash")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, domain.SupportArchiveID, string) error); ok {
+		r0 = rf(ctx, id, hash)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// mockInMemoryArchiveRepository_SetSpecHash_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'SetSpecHash'
+type mockInMemoryArchiveRepository_SetSpecHash_Call struct {
+	*mock.Call
+}
+
+// SetSpecHash is a helper method to define mock.On call
+//   - ctx context.Context
+//   - id domain.SupportArchiveID
+//   - hash string
+func (_e *mockInMemoryArchiveRepository_Expecter) SetSpecHash(ctx interface{}, id interface{}, hash interface{}) *mockInMemoryArchiveRepository_SetSpecHash_Call {
+	return &mockInMemoryArchiveRepository_SetSpecHash_Call{Call: _e.mock.On("SetSpecHash", ctx, id, hash)}
+}
+
+func (_c *mockInMemoryArchiveRepository_SetSpecHash_Call) Run(run func(ctx context.Context, id domain.SupportArchiveID, hash string)) *mockInMemoryArchiveRepository_SetSpecHash_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(domain.SupportArchiveID), args[2].(string))
+	})
+	return _c
+}
+
+func (_c *mockInMemoryArchiveRepository_SetSpecHash_Call) Return(_a0 error) *mockInMemoryArchiveRepository_SetSpecHash_Call {
+	_c.Call.Return(_a0)
+	return _c
+}
+
+func (_c *mockInMemoryArchiveRepository_SetSpecHash_Call) RunAndReturn(run func(context.Context, domain.SupportArchiveID, string) error) *mockInMemoryArchiveRepository_SetSpecHash_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// newMockInMemoryArchiveRepository creates a new instance of mockInMemoryArchiveRepository. It also registers a testing interface on the mock and a cleanup function to assert the mocks expectations.
+// The first argument is typically a *testing.T value.
+func newMockInMemoryArchiveRepository(t interface {
+	mock.TestingT
+	Cleanup(func())
+}) *mockInMemoryArchiveRepository {
+	mock := &mockInMemoryArchiveRepository{}
+	mock.Mock.Test(t)
+
+	t.Cleanup(func() { mock.AssertExpectations(t) })
+
+	return mock
+}
@@ -0,0 +1,132 @@
+// Code generated by mockery v2.53.3. DO NOT EDIT.
+
+package usecase
+
+import (
+	context "context"
+
+	domain "github.com/cloudogu/k8s-support-archive-operator/pkg/domain"
+	mock "github.com/stretchr/testify/mock"
+)
+
+// mockPostProcessingRepository is an autogenerated mock type for the postProcessingRepository type
+type mockPostProcessingRepository struct {
+	mock.Mock
+}
+
+type mockPostProcessingRepository_Expecter struct {
+	mock *mock.Mock
+}
+
+func (_m *mockPostProcessingRepository) EXPECT() *mockPostProcessingRepository_Expecter {
+	return &mockPostProcessingRepository_Expecter{mock: &_m.Mock}
+}
+
+// Delete provides a mock function with given fields: ctx, id
+func (_m *mockPostProcessingRepository) Delete(ctx context.Context, id domain.SupportArchiveID) error {
+	ret := _m.Called(ctx, id)
+
+	if len(ret) == 0 {
+		panic("no return value specified for Delete")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, domain.SupportArchiveID) error); ok {
+		r0 = rf(ctx, id)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// mockPostProcessingRepository_Delete_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'Delete'
+type mockPostProcessingRepository_Delete_Call struct {
+	*mock.Call
+}
+
+// Delete is a helper method to define mock.On call
+//   - ctx context.Context
+//   - id domain.SupportArchiveID
+func (_e *mockPostProcessingRepository_Expecter) Delete(ctx interface{}, id interface{}) *mockPostProcessingRepository_Delete_Call {
+	return &mockPostProcessingRepository_Delete_Call{Call: _e.mock.On("Delete", ctx, id)}
+}
+
+func (_c *mockPostProcessingRepository_Delete_Call) Run(run func(ctx context.Context, id domain.SupportArchiveID)) *mockPostProcessingRepository_Delete_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(domain.SupportArchiveID))
+	})
+	return _c
+}
+
+func (_c *mockPostProcessingRepository_Delete_Call) Return(_a0 error) *mockPostProcessingRepository_Delete_Call {
+	_c.Call.Return(_a0)
+	return _c
+}
+
+func (_c *mockPostProcessingRepository_Delete_Call) RunAndReturn(run func(context.Context, domain.SupportArchiveID) error) *mockPostProcessingRepository_Delete_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// Stream provides a mock function with given fields: ctx, id, stream
+func (_m *mockPostProcessingRepository) Stream(ctx context.Context, id domain.SupportArchiveID, stream *domain.Stream) error {
+	ret := _m.Called(ctx, id, stream)
+
+	if len(ret) == 0 {
+		panic("no return value specified for Stream")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, domain.SupportArchiveID, *domain.Stream) error); ok {
+		r0 = rf(ctx, id, stream)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// mockPostProcessingRepository_Stream_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'Stream'
+type mockPostProcessingRepository_Stream_Call struct {
+	*mock.Call
+}
+
+// Stream is a helper method to define mock.On call
+//   - ctx context.Context
+//   - id domain.SupportArchiveID
+//   - stream *domain.Stream
+func (_e *mockPostProcessingRepository_Expecter) Stream(ctx interface{}, id interface{}, stream interface{}) *mockPostProcessingRepository_Stream_Call {
+	return &mockPostProcessingRepository_Stream_Call{Call: _e.mock.On("Stream", ctx, id, stream)}
+}
+
+func (_c *mockPostProcessingRepository_Stream_Call) Run(run func(ctx context.Context, id domain.SupportArchiveID, stream *domain.Stream)) *mockPostProcessingRepository_Stream_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(domain.SupportArchiveID), args[2].(*domain.Stream))
+	})
+	return _c
+}
+
+func (_c *mockPostProcessingRepository_Stream_Call) Return(_a0 error) *mockPostProcessingRepository_Stream_Call {
+	_c.Call.Return(_a0)
+	return _c
+}
+
+func (_c *mockPostProcessingRepository_Stream_Call) RunAndReturn(run func(context.Context, domain.SupportArchiveID, *domain.Stream) error) *mockPostProcessingRepository_Stream_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// newMockPostProcessingRepository creates a new instance of mockPostProcessingRepository. It also registers a testing interface on the mock and a cleanup function to assert the mocks expectations.
+// The first argument is typically a *testing.T value.
+func newMockPostProcessingRepository(t interface {
+	mock.TestingT
+	Cleanup(func())
+}) *mockPostProcessingRepository {
+	mock := &mockPostProcessingRepository{}
+	mock.Mock.Test(t)
+
+	t.Cleanup(func() { mock.AssertExpectations(t) })
+
+	return mock
+}
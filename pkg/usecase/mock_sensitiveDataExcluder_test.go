@@ -0,0 +1,79 @@
+// Code generated by mockery v2.53.3. DO NOT EDIT.
+
+package usecase
+
+import mock "github.com/stretchr/testify/mock"
+
+// mockSensitiveDataExcluder is an autogenerated mock type for the sensitiveDataExcluder type
+type mockSensitiveDataExcluder struct {
+	mock.Mock
+}
+
+type mockSensitiveDataExcluder_Expecter struct {
+	mock *mock.Mock
+}
+
+func (_m *mockSensitiveDataExcluder) EXPECT() *mockSensitiveDataExcluder_Expecter {
+	return &mockSensitiveDataExcluder_Expecter{mock: &_m.Mock}
+}
+
+// WithoutSensitiveData provides a mock function with no fields
+func (_m *mockSensitiveDataExcluder) WithoutSensitiveData() interface{} {
+	ret := _m.Called()
+
+	if len(ret) == 0 {
+		panic("no return value specified for WithoutSensitiveData")
+	}
+
+	var r0 interface{}
+	if rf, ok := ret.Get(0).(func() interface{}); ok {
+		r0 = rf()
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(interface{})
+		}
+	}
+
+	return r0
+}
+
+// mockSensitiveDataExcluder_WithoutSensitiveData_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'WithoutSensitiveData'
+type mockSensitiveDataExcluder_WithoutSensitiveData_Call struct {
+	*mock.Call
+}
+
+// WithoutSensitiveData is a helper method to define mock.On call
+func (_e *mockSensitiveDataExcluder_Expecter) WithoutSensitiveData() *mockSensitiveDataExcluder_WithoutSensitiveData_Call {
+	return &mockSensitiveDataExcluder_WithoutSensitiveData_Call{Call: _e.mock.On("WithoutSensitiveData")}
+}
+
+func (_c *mockSensitiveDataExcluder_WithoutSensitiveData_Call) Run(run func()) *mockSensitiveDataExcluder_WithoutSensitiveData_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run()
+	})
+	return _c
+}
+
+func (_c *mockSensitiveDataExcluder_WithoutSensitiveData_Call) Return(_a0 interface{}) *mockSensitiveDataExcluder_WithoutSensitiveData_Call {
+	_c.Call.Return(_a0)
+	return _c
+}
+
+func (_c *mockSensitiveDataExcluder_WithoutSensitiveData_Call) RunAndReturn(run func() interface{}) *mockSensitiveDataExcluder_WithoutSensitiveData_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// newMockSensitiveDataExcluder creates a new instance of mockSensitiveDataExcluder. It also registers a testing interface on the mock and a cleanup function to assert the mocks expectations.
+// The first argument is typically a *testing.T value.
+func newMockSensitiveDataExcluder(t interface {
+	mock.TestingT
+	Cleanup(func())
+}) *mockSensitiveDataExcluder {
+	mock := &mockSensitiveDataExcluder{}
+	mock.Mock.Test(t)
+
+	t.Cleanup(func() { mock.AssertExpectations(t) })
+
+	return mock
+}
@@ -0,0 +1,180 @@
+// Code generated by mockery v2.53.3. DO NOT EDIT.
+
+package usecase
+
+import (
+	context "context"
+
+	domain "github.com/cloudogu/k8s-support-archive-operator/pkg/domain"
+	mock "github.com/stretchr/testify/mock"
+)
+
+// mockSummaryRepository is an autogenerated mock type for the summaryRepository type
+type mockSummaryRepository struct {
+	mock.Mock
+}
+
+type mockSummaryRepository_Expecter struct {
+	mock *mock.Mock
+}
+
+func (_m *mockSummaryRepository) EXPECT() *mockSummaryRepository_Expecter {
+	return &mockSummaryRepository_Expecter{mock: &_m.Mock}
+}
+
+// Create provides a mock function with given fields: ctx, id, summary
+func (_m *mockSummaryRepository) Create(ctx context.Context, id domain.SupportArchiveID, summary *domain.ArchiveSummary) error {
+	ret := _m.Called(ctx, id, summary)
+
+	if len(ret) == 0 {
+		panic("no return value specified for Create")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, domain.SupportArchiveID, *domain.ArchiveSummary) error); ok {
+		r0 = rf(ctx, id, summary)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// mockSummaryRepository_Create_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'Create'
+type mockSummaryRepository_Create_Call struct {
+	*mock.Call
+}
+
+// Create is a helper method to define mock.On call
+//   - ctx context.Context
+//   - id domain.SupportArchiveID
+//   - summary *domain.ArchiveSummary
+func (_e *mockSummaryRepository_Expecter) Create(ctx interface{}, id interface{}, summary interface{}) *mockSummaryRepository_Create_Call {
+	return &mockSummaryRepository_Create_Call{Call: _e.mock.On("Create", ctx, id, summary)}
+}
+
+func (_c *mockSummaryRepository_Create_Call) Run(run func(ctx context.Context, id domain.SupportArchiveID, summary *domain.ArchiveSummary)) *mockSummaryRepository_Create_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(domain.SupportArchiveID), args[2].(*domain.ArchiveSummary))
+	})
+	return _c
+}
+
+func (_c *mockSummaryRepository_Create_Call) Return(_a0 error) *mockSummaryRepository_Create_Call {
+	_c.Call.Return(_a0)
+	return _c
+}
+
+func (_c *mockSummaryRepository_Create_Call) RunAndReturn(run func(context.Context, domain.SupportArchiveID, *domain.ArchiveSummary) error) *mockSummaryRepository_Create_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// Delete provides a mock function with given fields: ctx, id
+func (_m *mockSummaryRepository) Delete(ctx context.Context, id domain.SupportArchiveID) error {
+	ret := _m.Called(ctx, id)
+
+	if len(ret) == 0 {
+		panic("no return value specified for Delete")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, domain.SupportArchiveID) error); ok {
+		r0 = rf(ctx, id)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// mockSummaryRepository_Delete_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'Delete'
+type mockSummaryRepository_Delete_Call struct {
+	*mock.Call
+}
+
+// Delete is a helper method to define mock.On call
+//   - ctx context.Context
+//   - id domain.SupportArchiveID
+func (_e *mockSummaryRepository_Expecter) Delete(ctx interface{}, id interface{}) *mockSummaryRepository_Delete_Call {
+	return &mockSummaryRepository_Delete_Call{Call: _e.mock.On("Delete", ctx, id)}
+}
+
+func (_c *mockSummaryRepository_Delete_Call) Run(run func(ctx context.Context, id domain.SupportArchiveID)) *mockSummaryRepository_Delete_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(domain.SupportArchiveID))
+	})
+	return _c
+}
+
+func (_c *mockSummaryRepository_Delete_Call) Return(_a0 error) *mockSummaryRepository_Delete_Call {
+	_c.Call.Return(_a0)
+	return _c
+}
+
+func (_c *mockSummaryRepository_Delete_Call) RunAndReturn(run func(context.Context, domain.SupportArchiveID) error) *mockSummaryRepository_Delete_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// Stream provides a mock function with given fields: ctx, id, stream
+func (_m *mockSummaryRepository) Stream(ctx context.Context, id domain.SupportArchiveID, stream *domain.Stream) error {
+	ret := _m.Called(ctx, id, stream)
+
+	if len(ret) == 0 {
+		panic("no return value specified for Stream")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, domain.SupportArchiveID, *domain.Stream) error); ok {
+		r0 = rf(ctx, id, stream)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// mockSummaryRepository_Stream_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'Stream'
+type mockSummaryRepository_Stream_Call struct {
+	*mock.Call
+}
+
+// Stream is a helper method to define mock.On call
+//   - ctx context.Context
+//   - id domain.SupportArchiveID
+//   - stream *domain.Stream
+func (_e *mockSummaryRepository_Expecter) Stream(ctx interface{}, id interface{}, stream interface{}) *mockSummaryRepository_Stream_Call {
+	return &mockSummaryRepository_Stream_Call{Call: _e.mock.On("Stream", ctx, id, stream)}
+}
+
+func (_c *mockSummaryRepository_Stream_Call) Run(run func(ctx context.Context, id domain.SupportArchiveID, stream *domain.Stream)) *mockSummaryRepository_Stream_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(domain.SupportArchiveID), args[2].(*domain.Stream))
+	})
+	return _c
+}
+
+func (_c *mockSummaryRepository_Stream_Call) Return(_a0 error) *mockSummaryRepository_Stream_Call {
+	_c.Call.Return(_a0)
+	return _c
+}
+
+func (_c *mockSummaryRepository_Stream_Call) RunAndReturn(run func(context.Context, domain.SupportArchiveID, *domain.Stream) error) *mockSummaryRepository_Stream_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// newMockSummaryRepository creates a new instance of mockSummaryRepository. It also registers a testing interface on the mock and a cleanup function to assert the mocks expectations.
+// The first argument is typically a *testing.T value.
+func newMockSummaryRepository(t interface {
+	mock.TestingT
+	Cleanup(func())
+}) *mockSummaryRepository {
+	mock := &mockSummaryRepository{}
+	mock.Mock.Test(t)
+
+	t.Cleanup(func() { mock.AssertExpectations(t) })
+
+	return mock
+}
@@ -7,6 +7,8 @@ import (
 
 	domain "github.com/cloudogu/k8s-support-archive-operator/pkg/domain"
 	mock "github.com/stretchr/testify/mock"
+
+	time "time"
 )
 
 // mockSupportArchiveRepository is an autogenerated mock type for the supportArchiveRepository type
@@ -22,9 +24,9 @@ func (_m *mockSupportArchiveRepository) EXPECT() *mockSupportArchiveRepository_E
 	return &mockSupportArchiveRepository_Expecter{mock: &_m.Mock}
 }
 
-// Create provides a mock function with given fields: ctx, id, streams
-func (_m *mockSupportArchiveRepository) Create(ctx context.Context, id domain.SupportArchiveID, streams map[domain.CollectorType]*domain.Stream) (string, error) {
-	ret := _m.Called(ctx, id, streams)
+// Create provides a mock function with given fields: ctx, id, streams, collectionEnd
+func (_m *mockSupportArchiveRepository) Create(ctx context.Context, id domain.SupportArchiveID, streams map[domain.CollectorType]*domain.Stream, collectionEnd time.Time) (string, error) {
+	ret := _m.Called(ctx, id, streams, collectionEnd)
 
 	if len(ret) == 0 {
 		panic("no return value specified for Create")
@@ -32,17 +34,17 @@ func (_m *mockSupportArchiveRepository) Create(ctx context.Context, id domain.Su
 
 	var r0 string
 	var r1 error
-	if rf, ok := ret.Get(0).(func(context.Context, domain.SupportArchiveID, map[domain.CollectorType]*domain.Stream) (string, error)); ok {
-		return rf(ctx, id, streams)
+	if rf, ok := ret.Get(0).(func(context.Context, domain.SupportArchiveID, map[domain.CollectorType]*domain.Stream, time.Time) (string, error)); ok {
+		return rf(ctx, id, streams, collectionEnd)
 	}
-	if rf, ok := ret.Get(0).(func(context.Context, domain.SupportArchiveID, map[domain.CollectorType]*domain.Stream) string); ok {
-		r0 = rf(ctx, id, streams)
+	if rf, ok := ret.Get(0).(func(context.Context, domain.SupportArchiveID, map[domain.CollectorType]*domain.Stream, time.Time) string); ok {
+		r0 = rf(ctx, id, streams, collectionEnd)
 	} else {
 		r0 = ret.Get(0).(string)
 	}
 
-	if rf, ok := ret.Get(1).(func(context.Context, domain.SupportArchiveID, map[domain.CollectorType]*domain.Stream) error); ok {
-		r1 = rf(ctx, id, streams)
+	if rf, ok := ret.Get(1).(func(context.Context, domain.SupportArchiveID, map[domain.CollectorType]*domain.Stream, time.Time) error); ok {
+		r1 = rf(ctx, id, streams, collectionEnd)
 	} else {
 		r1 = ret.Error(1)
 	}
@@ -59,13 +61,14 @@ type mockSupportArchiveRepository_Create_Call struct {
 //   - ctx context.Context
 //   - id domain.SupportArchiveID
 //   - streams map[domain.CollectorType]*domain.Stream
-func (_e *mockSupportArchiveRepository_Expecter) Create(ctx interface{}, id interface{}, streams interface{}) *mockSupportArchiveRepository_Create_Call {
-	return &mockSupportArchiveRepository_Create_Call{Call: _e.mock.On("Create", ctx, id, streams)}
+//   - collectionEnd time.Time
+func (_e *mockSupportArchiveRepository_Expecter) Create(ctx interface{}, id interface{}, streams interface{}, collectionEnd interface{}) *mockSupportArchiveRepository_Create_Call {
+	return &mockSupportArchiveRepository_Create_Call{Call: _e.mock.On("Create", ctx, id, streams, collectionEnd)}
 }
 
-func (_c *mockSupportArchiveRepository_Create_Call) Run(run func(ctx context.Context, id domain.SupportArchiveID, streams map[domain.CollectorType]*domain.Stream)) *mockSupportArchiveRepository_Create_Call {
+func (_c *mockSupportArchiveRepository_Create_Call) Run(run func(ctx context.Context, id domain.SupportArchiveID, streams map[domain.CollectorType]*domain.Stream, collectionEnd time.Time)) *mockSupportArchiveRepository_Create_Call {
 	_c.Call.Run(func(args mock.Arguments) {
-		run(args[0].(context.Context), args[1].(domain.SupportArchiveID), args[2].(map[domain.CollectorType]*domain.Stream))
+		run(args[0].(context.Context), args[1].(domain.SupportArchiveID), args[2].(map[domain.CollectorType]*domain.Stream), args[3].(time.Time))
 	})
 	return _c
 }
@@ -75,7 +78,7 @@ func (_c *mockSupportArchiveRepository_Create_Call) Return(url string, err error
 	return _c
 }
 
-func (_c *mockSupportArchiveRepository_Create_Call) RunAndReturn(run func(context.Context, domain.SupportArchiveID, map[domain.CollectorType]*domain.Stream) (string, error)) *mockSupportArchiveRepository_Create_Call {
+func (_c *mockSupportArchiveRepository_Create_Call) RunAndReturn(run func(context.Context, domain.SupportArchiveID, map[domain.CollectorType]*domain.Stream, time.Time) (string, error)) *mockSupportArchiveRepository_Create_Call {
 	_c.Call.Return(run)
 	return _c
 }
@@ -242,6 +245,65 @@ func (_c *mockSupportArchiveRepository_List_Call) RunAndReturn(run func(context.
 	return _c
 }
 
+// ReadContent provides a mock function with given fields: ctx, id
+func (_m *mockSupportArchiveRepository) ReadContent(ctx context.Context, id domain.SupportArchiveID) (*domain.ArchiveContent, error) {
+	ret := _m.Called(ctx, id)
+
+	if len(ret) == 0 {
+		panic("no return value specified for ReadContent")
+	}
+
+	var r0 *domain.ArchiveContent
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, domain.SupportArchiveID) (*domain.ArchiveContent, error)); ok {
+		return rf(ctx, id)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, domain.SupportArchiveID) *domain.ArchiveContent); ok {
+		r0 = rf(ctx, id)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*domain.ArchiveContent)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, domain.SupportArchiveID) error); ok {
+		r1 = rf(ctx, id)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// mockSupportArchiveRepository_ReadContent_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'ReadContent'
+type mockSupportArchiveRepository_ReadContent_Call struct {
+	*mock.Call
+}
+
+// ReadContent is a helper method to define mock.On call
+//   - ctx context.Context
+//   - id domain.SupportArchiveID
+func (_e *mockSupportArchiveRepository_Expecter) ReadContent(ctx interface{}, id interface{}) *mockSupportArchiveRepository_ReadContent_Call {
+	return &mockSupportArchiveRepository_ReadContent_Call{Call: _e.mock.On("ReadContent", ctx, id)}
+}
+
+func (_c *mockSupportArchiveRepository_ReadContent_Call) Run(run func(ctx context.Context, id domain.SupportArchiveID)) *mockSupportArchiveRepository_ReadContent_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(domain.SupportArchiveID))
+	})
+	return _c
+}
+
+func (_c *mockSupportArchiveRepository_ReadContent_Call) Return(_a0 *domain.ArchiveContent, _a1 error) *mockSupportArchiveRepository_ReadContent_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *mockSupportArchiveRepository_ReadContent_Call) RunAndReturn(run func(context.Context, domain.SupportArchiveID) (*domain.ArchiveContent, error)) *mockSupportArchiveRepository_ReadContent_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
 // newMockSupportArchiveRepository creates a new instance of mockSupportArchiveRepository. It also registers a testing interface on the mock and a cleanup function to assert the mocks expectations.
 // The first argument is typically a *testing.T value.
 func newMockSupportArchiveRepository(t interface {
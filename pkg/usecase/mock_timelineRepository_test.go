@@ -0,0 +1,180 @@
+// Code generated by mockery v2.53.3. DO NOT EDIT.
+
+package usecase
+
+import (
+	context "context"
+
+	domain "github.com/cloudogu/k8s-support-archive-operator/pkg/domain"
+	mock "github.com/stretchr/testify/mock"
+)
+
+// mockTimelineRepository is an autogenerated mock type for the timelineRepository type
+type mockTimelineRepository struct {
+	mock.Mock
+}
+
+type mockTimelineRepository_Expecter struct {
+	mock *mock.Mock
+}
+
+func (_m *mockTimelineRepository) EXPECT() *mockTimelineRepository_Expecter {
+	return &mockTimelineRepository_Expecter{mock: &_m.Mock}
+}
+
+// Create provides a mock function with given fields: ctx, id, collectors
+func (_m *mockTimelineRepository) Create(ctx context.Context, id domain.SupportArchiveID, collectors []domain.CollectorType) error {
+	ret := _m.Called(ctx, id, collectors)
+
+	if len(ret) == 0 {
+		panic("no return value specified for Create")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, domain.SupportArchiveID, []domain.CollectorType) error); ok {
+		r0 = rf(ctx, id, collectors)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// mockTimelineRepository_Create_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'Create'
+type mockTimelineRepository_Create_Call struct {
+	*mock.Call
+}
+
+// Create is a helper method to define mock.On call
+//   - ctx context.Context
+//   - id domain.SupportArchiveID
+//   - collectors []domain.CollectorType
+func (_e *mockTimelineRepository_Expecter) Create(ctx interface{}, id interface{}, collectors interface{}) *mockTimelineRepository_Create_Call {
+	return &mockTimelineRepository_Create_Call{Call: _e.mock.On("Create", ctx, id, collectors)}
+}
+
+func (_c *mockTimelineRepository_Create_Call) Run(run func(ctx context.Context, id domain.SupportArchiveID, collectors []domain.CollectorType)) *mockTimelineRepository_Create_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(domain.SupportArchiveID), args[2].([]domain.CollectorType))
+	})
+	return _c
+}
+
+func (_c *mockTimelineRepository_Create_Call) Return(_a0 error) *mockTimelineRepository_Create_Call {
+	_c.Call.Return(_a0)
+	return _c
+}
+
+func (_c *mockTimelineRepository_Create_Call) RunAndReturn(run func(context.Context, domain.SupportArchiveID, []domain.CollectorType) error) *mockTimelineRepository_Create_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// Delete provides a mock function with given fields: ctx, id
+func (_m *mockTimelineRepository) Delete(ctx context.Context, id domain.SupportArchiveID) error {
+	ret := _m.Called(ctx, id)
+
+	if len(ret) == 0 {
+		panic("no return value specified for Delete")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, domain.SupportArchiveID) error); ok {
+		r0 = rf(ctx, id)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// mockTimelineRepository_Delete_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'Delete'
+type mockTimelineRepository_Delete_Call struct {
+	*mock.Call
+}
+
+// Delete is a helper method to define mock.On call
+//   - ctx context.Context
+//   - id domain.SupportArchiveID
+func (_e *mockTimelineRepository_Expecter) Delete(ctx interface{}, id interface{}) *mockTimelineRepository_Delete_Call {
+	return &mockTimelineRepository_Delete_Call{Call: _e.mock.On("Delete", ctx, id)}
+}
+
+func (_c *mockTimelineRepository_Delete_Call) Run(run func(ctx context.Context, id domain.SupportArchiveID)) *mockTimelineRepository_Delete_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(domain.SupportArchiveID))
+	})
+	return _c
+}
+
+func (_c *mockTimelineRepository_Delete_Call) Return(_a0 error) *mockTimelineRepository_Delete_Call {
+	_c.Call.Return(_a0)
+	return _c
+}
+
+func (_c *mockTimelineRepository_Delete_Call) RunAndReturn(run func(context.Context, domain.SupportArchiveID) error) *mockTimelineRepository_Delete_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// Stream provides a mock function with given fields: ctx, id, stream
+func (_m *mockTimelineRepository) Stream(ctx context.Context, id domain.SupportArchiveID, stream *domain.Stream) error {
+	ret := _m.Called(ctx, id, stream)
+
+	if len(ret) == 0 {
+		panic("no return value specified for Stream")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, domain.SupportArchiveID, *domain.Stream) error); ok {
+		r0 = rf(ctx, id, stream)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// mockTimelineRepository_Stream_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'Stream'
+type mockTimelineRepository_Stream_Call struct {
+	*mock.Call
+}
+
+// Stream is a helper method to define mock.On call
+//   - ctx context.Context
+//   - id domain.SupportArchiveID
+//   - stream *domain.Stream
+func (_e *mockTimelineRepository_Expecter) Stream(ctx interface{}, id interface{}, stream interface{}) *mockTimelineRepository_Stream_Call {
+	return &mockTimelineRepository_Stream_Call{Call: _e.mock.On("Stream", ctx, id, stream)}
+}
+
+func (_c *mockTimelineRepository_Stream_Call) Run(run func(ctx context.Context, id domain.SupportArchiveID, stream *domain.Stream)) *mockTimelineRepository_Stream_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(domain.SupportArchiveID), args[2].(*domain.Stream))
+	})
+	return _c
+}
+
+func (_c *mockTimelineRepository_Stream_Call) Return(_a0 error) *mockTimelineRepository_Stream_Call {
+	_c.Call.Return(_a0)
+	return _c
+}
+
+func (_c *mockTimelineRepository_Stream_Call) RunAndReturn(run func(context.Context, domain.SupportArchiveID, *domain.Stream) error) *mockTimelineRepository_Stream_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// newMockTimelineRepository creates a new instance of mockTimelineRepository. It also registers a testing interface on the mock and a cleanup function to assert the mocks expectations.
+// The first argument is typically a *testing.T value.
+func newMockTimelineRepository(t interface {
+	mock.TestingT
+	Cleanup(func())
+}) *mockTimelineRepository {
+	mock := &mockTimelineRepository{}
+	mock.Mock.Test(t)
+
+	t.Cleanup(func() { mock.AssertExpectations(t) })
+
+	return mock
+}
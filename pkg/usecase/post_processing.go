@@ -0,0 +1,187 @@
+package usecase
+
+import (
+	"context"
+	"fmt"
+	"slices"
+	"strings"
+
+	"golang.org/x/sync/errgroup"
+
+	"github.com/cloudogu/k8s-support-archive-operator/pkg/domain"
+
+	"sigs.k8s.io/controller-runtime/pkg/log"
+)
+
+// PostProcessingRepositories contains the repositories of the steps executed after all collectors and before packaging.
+type PostProcessingRepositories struct {
+	CollectedDataReader collectedDataReader
+	Timeline            timelineRepository
+	Findings            findingsRepository
+	Summary             summaryRepository
+	Diff                diffRepository
+}
+
+// postProcess builds the timeline, if enabled, analyzes the collected data, creates the html summary and compares the
+// collected data with the referenced archive, if any. The results are returned as a single stream for the root of the archive.
+// Post-processing only summarizes the collected data. Thus, failing steps are logged and the archive is created without their results.
+func (c *CreateArchiveUseCase) postProcess(errCtx context.Context, group *errgroup.Group, id domain.SupportArchiveID, collectors []domain.CollectorType, skippedCollectors map[domain.CollectorType]string, timeframes domain.ContentTimeframes, compareTo *domain.SupportArchiveID) *domain.Stream {
+	logger := log.FromContext(errCtx).WithName("CreateArchiveUseCase.postProcess")
+
+	var streams []*domain.Stream
+	if c.timelineEnabled {
+		err := c.postProcessing.Timeline.Create(errCtx, id, collectors)
+		if err != nil {
+			logger.Error(err, "could not create timeline")
+		} else {
+			streams = append(streams, streamPostProcessingResult(errCtx, group, c.postProcessing.Timeline, id, "timeline"))
+		}
+	}
+
+	streams = append(streams, c.createReports(errCtx, group, id, collectors, skippedCollectors, timeframes)...)
+	if compareTo != nil {
+		err := c.createDiff(errCtx, id, *compareTo)
+		if err != nil {
+			logger.Error(err, "could not create diff", "compareTo", compareTo.Name)
+		} else {
+			streams = append(streams, streamPostProcessingResult(errCtx, group, c.postProcessing.Diff, id, "diff"))
+		}
+	}
+	if len(streams) == 0 {
+		return nil
+	}
+
+	return mergeStreams(errCtx, group, streams...)
+}
+
+// createReports evaluates the analysis rules over the collected data and writes the findings and the summary.
+func (c *CreateArchiveUseCase) createReports(errCtx context.Context, group *errgroup.Group, id domain.SupportArchiveID, collectors []domain.CollectorType, skippedCollectors map[domain.CollectorType]string, timeframes domain.ContentTimeframes) []*domain.Stream {
+	logger := log.FromContext(errCtx).WithName("CreateArchiveUseCase.createReports")
+
+	data, err := c.postProcessing.CollectedDataReader.Read(errCtx, id, collectors, timeframes)
+	if err != nil {
+		logger.Error(err, "could not read collected data")
+		return nil
+	}
+
+	var streams []*domain.Stream
+	findings := evaluateAnalysisRules(data, defaultAnalysisRules)
+	err = c.postProcessing.Findings.Create(errCtx, id, findings)
+	if err != nil {
+		logger.Error(err, "could not create findings")
+	} else {
+		streams = append(streams, streamPostProcessingResult(errCtx, group, c.postProcessing.Findings, id, "findings"))
+	}
+
+	err = c.postProcessing.Summary.Create(errCtx, id, newArchiveSummary(id, data, findings, collectors, skippedCollectors))
+	if err != nil {
+		logger.Error(err, "could not create summary")
+	} else {
+		streams = append(streams, streamPostProcessingResult(errCtx, group, c.postProcessing.Summary, id, "summary"))
+	}
+
+	return streams
+}
+
+// createDiff compares the collected data with the referenced archive of the same repository.
+func (c *CreateArchiveUseCase) createDiff(errCtx context.Context, id domain.SupportArchiveID, compareTo domain.SupportArchiveID) error {
+	before, err := c.supportArchiveRepository.ReadContent(errCtx, compareTo)
+	if err != nil {
+		return fmt.Errorf("could not read archive %s: %w", compareTo.Name, err)
+	}
+	after, err := c.postProcessing.CollectedDataReader.ReadContent(errCtx, id)
+	if err != nil {
+		return fmt.Errorf("could not read collected data: %w", err)
+	}
+
+	return c.postProcessing.Diff.Create(errCtx, id, DiffArchives(compareTo.Name, before, after))
+}
+
+func newArchiveSummary(id domain.SupportArchiveID, data *domain.CollectedData, findings []domain.Finding, collectors []domain.CollectorType, skippedCollectors map[domain.CollectorType]string) *domain.ArchiveSummary {
+	summary := &domain.ArchiveSummary{
+		ID:        id,
+		CreatedAt: data.End,
+		Findings:  findings,
+		Data:      data,
+	}
+	for _, col := range collectors {
+		summary.Collectors = append(summary.Collectors, domain.CollectorSummary{Type: col})
+	}
+	for col, reason := range skippedCollectors {
+		summary.Collectors = append(summary.Collectors, domain.CollectorSummary{Type: col, SkippedReason: reason})
+	}
+	slices.SortFunc(summary.Collectors, func(a, b domain.CollectorSummary) int {
+		return strings.Compare(string(a.Type), string(b.Type))
+	})
+
+	return summary
+}
+
+// deletePostProcessingResults removes the results because they are created again for every archive creation.
+func (c *CreateArchiveUseCase) deletePostProcessingResults(ctx context.Context, id domain.SupportArchiveID) {
+	logger := log.FromContext(ctx).WithName("CreateArchiveUseCase.deletePostProcessingResults")
+	repositories := map[string]postProcessingRepository{
+		"timeline": c.postProcessing.Timeline,
+		"findings": c.postProcessing.Findings,
+		"summary":  c.postProcessing.Summary,
+		"diff":     c.postProcessing.Diff,
+	}
+	for name, repo := range repositories {
+		err := repo.Delete(ctx, id)
+		if err != nil {
+			logger.Error(err, fmt.Sprintf("could not delete %s", name))
+		}
+	}
+}
+
+func streamPostProcessingResult(errCtx context.Context, group *errgroup.Group, repo postProcessingRepository, id domain.SupportArchiveID, name string) *domain.Stream {
+	stream := &domain.Stream{
+		Data: make(chan domain.StreamData),
+	}
+	group.Go(func() error {
+		err := repo.Stream(errCtx, id, stream)
+		if err != nil {
+			return fmt.Errorf("could not stream %s: %w", name, err)
+		}
+		return nil
+	})
+
+	return stream
+}
+
+// mergeStreams forwards the data of all streams one after another to a single stream.
+func mergeStreams(errCtx context.Context, group *errgroup.Group, streams ...*domain.Stream) *domain.Stream {
+	merged := &domain.Stream{
+		Data: make(chan domain.StreamData),
+	}
+	group.Go(func() error {
+		defer close(merged.Data)
+		for _, stream := range streams {
+			err := forwardStream(errCtx, stream, merged)
+			if err != nil {
+				return err
+			}
+		}
+		return nil
+	})
+
+	return merged
+}
+
+func forwardStream(errCtx context.Context, source *domain.Stream, destination *domain.Stream) error {
+	for {
+		select {
+		case <-errCtx.Done():
+			return errCtx.Err()
+		case data, ok := <-source.Data:
+			if !ok {
+				return nil
+			}
+			select {
+			case <-errCtx.Done():
+				return errCtx.Err()
+			case destination.Data <- data:
+			}
+		}
+	}
+}
@@ -0,0 +1,70 @@
+package usecase
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/cloudogu/k8s-support-archive-operator/pkg/domain"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"golang.org/x/sync/errgroup"
+)
+
+func Test_mergeStreams(t *testing.T) {
+	newStream := func(ids ...string) *domain.Stream {
+		stream := &domain.Stream{Data: make(chan domain.StreamData, len(ids))}
+		for _, id := range ids {
+			stream.Data <- domain.StreamData{ID: id}
+		}
+		close(stream.Data)
+		return stream
+	}
+
+	t.Run("should forward all streams one after another", func(t *testing.T) {
+		// given
+		group, errCtx := errgroup.WithContext(testCtx)
+
+		// when
+		merged := mergeStreams(errCtx, group, newStream("a", "b"), newStream(), newStream("c"))
+
+		// then
+		assert.Equal(t, []string{"a", "b", "c"}, readStreamIDs(merged))
+		require.NoError(t, group.Wait())
+	})
+	t.Run("should stop and close merged stream if context is cancelled", func(t *testing.T) {
+		// given
+		ctx, cancel := context.WithCancel(testCtx)
+		group, errCtx := errgroup.WithContext(ctx)
+		neverClosed := &domain.Stream{Data: make(chan domain.StreamData)}
+
+		// when
+		merged := mergeStreams(errCtx, group, neverClosed)
+		cancel()
+
+		// then
+		assert.Empty(t, readStreamIDs(merged))
+		assert.ErrorIs(t, group.Wait(), context.Canceled)
+	})
+}
+
+func Test_newArchiveSummary(t *testing.T) {
+	// given
+	data := &domain.CollectedData{End: time.Date(2025, 1, 5, 0, 0, 0, 0, time.UTC)}
+	findings := []domain.Finding{{Rule: ruleNameVolumeUsage}}
+	skipped := map[domain.CollectorType]string{domain.CollectorTypeEvents: "events error"}
+
+	// when
+	summary := newArchiveSummary(testID, data, findings, []domain.CollectorType{domain.CollectorTypeVolumeInfo, domain.CollectorTypeLog}, skipped)
+
+	// then
+	assert.Equal(t, testID, summary.ID)
+	assert.Same(t, data, summary.Data)
+	assert.Equal(t, findings, summary.Findings)
+	assert.Equal(t, data.End, summary.CreatedAt)
+	assert.Equal(t, []domain.CollectorSummary{
+		{Type: domain.CollectorTypeEvents, SkippedReason: "events error"},
+		{Type: domain.CollectorTypeLog},
+		{Type: domain.CollectorTypeVolumeInfo},
+	}, summary.Collectors)
+}